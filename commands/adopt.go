@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// Adopt scaffolds LiveTemplate versions of an existing net/http +
+// html/template app's pages, side by side with the originals.
+func Adopt(args []string) error {
+	if ShowHelpIfRequested(args, printAdoptHelp) {
+		return nil
+	}
+
+	outDir := "live"
+	dryRun := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--dry-run":
+			dryRun = true
+		case args[i] == "--out":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--out requires a directory")
+			}
+			outDir = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--out="):
+			outDir = strings.TrimPrefix(args[i], "--out=")
+		default:
+			return fmt.Errorf("unknown argument: %s\n\nRun 'lvt adopt --help' for usage", args[i])
+		}
+	}
+	outDir = filepath.Clean(outDir)
+	if filepath.IsAbs(outDir) || strings.HasPrefix(outDir, "..") || outDir == "." {
+		return fmt.Errorf("--out must be a directory inside the project, got %q", outDir)
+	}
+
+	basePath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	moduleName, err := getModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w (run lvt adopt from the app's root, next to go.mod)", err)
+	}
+
+	app, err := generator.AnalyzeApp(basePath, moduleName, outDir)
+	if err != nil {
+		return err
+	}
+	if len(app.Pages) == 0 {
+		logging.Println("No pages to adopt: no route handler renders an html/template.")
+		if len(app.Routes) > 0 {
+			logging.Printf("Found %d route(s), none rendering a template parsed with template.ParseFiles, ParseGlob or ParseFS.\n", len(app.Routes))
+		}
+		return nil
+	}
+
+	if dryRun {
+		logging.Printf("Dry run: nothing written. lvt adopt would scaffold these pages and write this checklist to %s:\n\n",
+			filepath.ToSlash(filepath.Join(outDir, generator.AdoptChecklistFile)))
+		logging.Print(app.Checklist())
+		return nil
+	}
+
+	projectConfig, err := config.LoadProjectConfig(basePath)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	if err := generator.Adopt(app, projectConfig.GetKit()); err != nil {
+		return err
+	}
+
+	created := 0
+	for _, page := range app.Pages {
+		if !page.Exists {
+			created++
+		}
+	}
+	if created > 0 {
+		logging.Printf("✅ Scaffolded %d LiveTemplate page(s) in %s/\n", created, filepath.ToSlash(outDir))
+	} else {
+		logging.Printf("✅ All %d page(s) are already scaffolded in %s/\n", len(app.Pages), filepath.ToSlash(outDir))
+	}
+	logging.Println()
+	for _, page := range app.Pages {
+		status := ""
+		if page.Exists {
+			status = "  (exists, left as is)"
+		}
+		logging.Printf("  %-24s → %-24s %s/%s/%s\n", page.Pattern, page.LivePath, filepath.ToSlash(outDir), page.Name, status)
+	}
+	logging.Println()
+	if app.Wired != "" {
+		logging.Printf("Live routes mounted at %s\n", app.Wired)
+	} else {
+		logging.Printf("Mount the live routes by calling %s.Register(mux) where your routes are registered.\n", filepath.Base(outDir))
+	}
+	if app.DepsErr != "" {
+		logging.Println("⚠️  Could not add the livetemplate dependency; run: go get github.com/livetemplate/livetemplate")
+	}
+	logging.Printf("Checklist: %s\n", filepath.ToSlash(filepath.Join(outDir, generator.AdoptChecklistFile)))
+	return nil
+}
+
+func printAdoptHelp() {
+	fmt.Println("Usage: lvt adopt [--out <dir>] [--dry-run]")
+	fmt.Println()
+	fmt.Println("Scaffolds LiveTemplate versions of an existing net/http + html/template app's")
+	fmt.Println("pages, side by side with the originals. Run it from the app's root (next to go.mod).")
+	fmt.Println()
+	fmt.Println("lvt adopt finds route registrations (mux.HandleFunc, http.Handle, ...) whose")
+	fmt.Println("handlers render a template parsed with template.ParseFiles, ParseGlob or ParseFS,")
+	fmt.Println("and for each one writes <dir>/<page>/ with:")
+	fmt.Println("  <page>.tmpl   The original template, plus the templates it calls, loading the")
+	fmt.Println("                LiveTemplate client")
+	fmt.Println("  <page>.go     A controller, a state with the fields the template reads (typed")
+	fmt.Println("                from the handler's data struct when possible) and Handler()")
+	fmt.Println()
+	fmt.Println("It also writes <dir>/routes.go, whose Register(mux) serves every page under /live/,")
+	fmt.Println("adds that call after the app's own route registrations, and writes a checklist of")
+	fmt.Println("what is left to port by hand to <dir>/ADOPTION.md. Original handlers and templates")
+	fmt.Println("are not changed, and pages that already exist are not overwritten.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --out <dir>   Directory for the live pages (default: live)")
+	fmt.Println("  --dry-run     Print the checklist without writing anything")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt adopt --dry-run")
+	fmt.Println("  lvt adopt")
+	fmt.Println("  lvt adopt --out web/live")
+	fmt.Println()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/generator"
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // GenAPI generates a JSON API handler for a resource.
@@ -75,15 +76,15 @@ func GenAPI(args []string) error {
 		return fmt.Errorf("failed to get module name: %w (are you in a Go project?)", err)
 	}
 
-	fmt.Printf("Generating API resource: %s\n", resourceName)
-	fmt.Printf("Fields: ")
+	logging.Printf("Generating API resource: %s\n", resourceName)
+	logging.Printf("Fields: ")
 	for i, f := range fields {
 		if i > 0 {
-			fmt.Printf(", ")
+			logging.Printf(", ")
 		}
-		fmt.Printf("%s:%s", f.Name, f.Type)
+		logging.Printf("%s:%s", f.Name, f.Type)
 	}
-	fmt.Println()
+	logging.Println()
 
 	if err := generator.GenerateAPI(basePath, moduleName, resourceName, fields, kit); err != nil {
 		return err
@@ -91,30 +92,30 @@ func GenAPI(args []string) error {
 
 	resourceNameLower := strings.ToLower(resourceName)
 
-	fmt.Println()
-	fmt.Println("✅ API resource generated successfully!")
-	fmt.Println()
-	fmt.Println("Files created:")
-	fmt.Printf("  app/api/%s.go\n", resourceNameLower)
-	fmt.Printf("  app/api/%s_test.go\n", resourceNameLower)
-	fmt.Println()
-	fmt.Println("Files updated:")
-	fmt.Println("  database/queries.sql (paginated queries added)")
-	fmt.Println()
-	fmt.Println("API endpoints:")
-	fmt.Printf("  GET    /api/v1/%s        List (paginated)\n", resourceNameLower)
-	fmt.Printf("  POST   /api/v1/%s        Create\n", resourceNameLower)
-	fmt.Printf("  GET    /api/v1/%s/{id}   Get by ID\n", resourceNameLower)
-	fmt.Printf("  PUT    /api/v1/%s/{id}   Update\n", resourceNameLower)
-	fmt.Printf("  DELETE /api/v1/%s/{id}   Delete\n", resourceNameLower)
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Run migration:")
-	fmt.Println("     lvt migration up")
-	fmt.Println("  2. Regenerate sqlc code:")
-	fmt.Println("     sqlc generate")
-	fmt.Println("  3. Run your app")
-	fmt.Println()
+	logging.Println()
+	logging.Println("✅ API resource generated successfully!")
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Printf("  app/api/%s.go\n", resourceNameLower)
+	logging.Printf("  app/api/%s_test.go\n", resourceNameLower)
+	logging.Println()
+	logging.Println("Files updated:")
+	logging.Println("  database/queries.sql (paginated queries added)")
+	logging.Println()
+	logging.Println("API endpoints:")
+	logging.Printf("  GET    /api/v1/%s        List (paginated)\n", resourceNameLower)
+	logging.Printf("  POST   /api/v1/%s        Create\n", resourceNameLower)
+	logging.Printf("  GET    /api/v1/%s/{id}   Get by ID\n", resourceNameLower)
+	logging.Printf("  PUT    /api/v1/%s/{id}   Update\n", resourceNameLower)
+	logging.Printf("  DELETE /api/v1/%s/{id}   Delete\n", resourceNameLower)
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run migration:")
+	logging.Println("     lvt migration up")
+	logging.Println("  2. Regenerate sqlc code:")
+	logging.Println("     sqlc generate")
+	logging.Println("  3. Run your app")
+	logging.Println()
 
 	return nil
 }
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// Audit generates the audit trail (audit_logs table, app/audit package and page).
+func Audit(args []string) error {
+	if ShowHelpIfRequested(args, printGenAuditHelp) {
+		return nil
+	}
+
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument: %s", args[0])
+	}
+
+	basePath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	moduleName, err := getModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w (are you in a Go project?)", err)
+	}
+
+	logging.Println("Generating audit trail...")
+
+	cfg := &generator.AuditConfig{
+		ModuleName: moduleName,
+	}
+
+	if err := generator.GenerateAudit(basePath, cfg); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Audit trail generated!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  database/migrations/<timestamp>_create_audit_logs.sql")
+	logging.Println("  database/queries.sql (audit queries appended)")
+	logging.Println("  database/schema.sql (audit_logs table appended)")
+	logging.Println("  app/audit/audit.go")
+	logging.Println("  app/audit/handler.go")
+	logging.Println("  app/audit/audit.tmpl")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run migration:")
+	logging.Println("     lvt migration up")
+	logging.Println("  2. Regenerate sqlc code:")
+	logging.Println("     sqlc generate")
+	logging.Println("  3. Generate resources (they record changes automatically):")
+	logging.Println("     lvt gen resource posts title content:text")
+	logging.Println("  4. Browse the trail at /audit")
+	logging.Println()
+	logging.Println("Note: resources generated before this command do not record changes;")
+	logging.Println("regenerate them or call audit.Record from their handlers.")
+	logging.Println()
+
+	return nil
+}
+
+func printGenAuditHelp() {
+	fmt.Println("Usage: lvt gen audit")
+	fmt.Println()
+	fmt.Println("Generates an audit trail of create/update/delete changes.")
+	fmt.Println("Adds an 'audit_logs' table, an app/audit package with audit.Record,")
+	fmt.Println("and an /audit page filterable by resource and record.")
+	fmt.Println()
+	fmt.Println("Resources generated afterwards record who changed what, with the")
+	fmt.Println("old and new values stored as JSON. When 'lvt gen auth' has been run,")
+	fmt.Println("the signed-in user is recorded.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt gen audit")
+	fmt.Println("  lvt gen resource posts title content:text")
+	fmt.Println()
+}
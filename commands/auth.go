@@ -10,6 +10,8 @@ import (
 
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/inflect"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/telemetry"
 )
 
@@ -64,10 +66,6 @@ func Auth(args []string) error {
 	if structName == "" {
 		structName = "User"
 	}
-	if tableName == "" {
-		tableName = pluralizeNoun(structName)
-	}
-
 	// Validate flags
 	if flags.NoPassword && flags.NoMagicLink {
 		return errors.New("at least one authentication method (password or magic-link) must be enabled")
@@ -84,6 +82,9 @@ func Auth(args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load project config: %w", err)
 	}
+	if tableName == "" {
+		tableName = pluralizeNoun(structName, inflect.New(cfg.Inflections))
+	}
 
 	// Create generator config
 	genConfig := &generator.AuthConfig{
@@ -107,24 +108,24 @@ func Auth(args []string) error {
 	})
 
 	// Generate auth files
-	fmt.Println("Generating authentication system...")
+	logging.Println("Generating authentication system...")
 	if err := generator.GenerateAuth(wd, genConfig); err != nil {
 		capture.RecordError(telemetry.GenerationError{Phase: "generation", Message: err.Error()})
 		capture.Complete(false, "")
 		return fmt.Errorf("failed to generate auth: %w", err)
 	}
 
-	fmt.Println("✅ Authentication system generated successfully!")
-	fmt.Println("\n📁 Generated files:")
-	fmt.Println("  - app/auth/auth.go          (handler with all auth flows)")
-	fmt.Println("  - app/auth/auth.tmpl        (LiveTemplate UI)")
-	fmt.Println("  - app/auth/middleware.go    (route protection middleware)")
-	fmt.Println("  - app/auth/auth_e2e_test.go (E2E tests with chromedp)")
-	fmt.Println("  - database/migrations/      (auth tables migration)")
-	fmt.Println("  - database/queries.sql      (auth SQL queries)")
-	fmt.Println("\n📦 Dependencies added:")
-	fmt.Println("  - github.com/livetemplate/lvt/pkg/password (bcrypt utilities)")
-	fmt.Println("  - github.com/livetemplate/lvt/pkg/email    (email sender interface)")
+	logging.Println("✅ Authentication system generated successfully!")
+	logging.Println("\n📁 Generated files:")
+	logging.Println("  - app/auth/auth.go          (handler with all auth flows)")
+	logging.Println("  - app/auth/auth.tmpl        (LiveTemplate UI)")
+	logging.Println("  - app/auth/middleware.go    (route protection middleware)")
+	logging.Println("  - app/auth/auth_e2e_test.go (E2E tests with chromedp)")
+	logging.Println("  - database/migrations/      (auth tables migration)")
+	logging.Println("  - database/queries.sql      (auth SQL queries)")
+	logging.Println("\n📦 Dependencies added:")
+	logging.Println("  - github.com/livetemplate/lvt/pkg/password (bcrypt utilities)")
+	logging.Println("  - github.com/livetemplate/lvt/pkg/email    (email sender interface)")
 
 	// Post-generation validation (before interactive prompts).
 	// Unlike gen.go which defers the error to show the full file listing,
@@ -147,8 +148,8 @@ func Auth(args []string) error {
 	// Check for existing resources to protect
 	resources, err := generator.ReadResources(wd)
 	if err != nil {
-		fmt.Printf("⚠️  Could not read resources: %v\n", err)
-	} else if len(resources) > 0 {
+		logging.Printf("⚠️  Could not read resources: %v\n", err)
+	} else if len(resources) > 0 && !dryRun {
 		// Filter out auth and home from protectable resources
 		var protectableResources []generator.ResourceEntry
 		for _, r := range resources {
@@ -178,7 +179,7 @@ func Auth(args []string) error {
 			reader := bufio.NewReader(os.Stdin)
 			choice, err := reader.ReadString('\n')
 			if err != nil {
-				fmt.Printf("⚠️  Could not read input: %v\n", err)
+				logging.Printf("⚠️  Could not read input: %v\n", err)
 			} else {
 				choice = strings.TrimSpace(strings.ToLower(choice))
 
@@ -199,23 +200,23 @@ func Auth(args []string) error {
 									selectedResources = append(selectedResources, protectableResources[num-1])
 								}
 							} else {
-								fmt.Printf("⚠️  Invalid selection: %d (out of range)\n", num)
+								logging.Printf("⚠️  Invalid selection: %d (out of range)\n", num)
 							}
 						} else {
-							fmt.Printf("⚠️  Invalid selection: %s (not a number)\n", part)
+							logging.Printf("⚠️  Invalid selection: %s (not a number)\n", part)
 						}
 					}
 				}
 
 				if len(selectedResources) > 0 {
-					fmt.Printf("\nProtecting %d resource(s)...\n", len(selectedResources))
+					logging.Printf("\nProtecting %d resource(s)...\n", len(selectedResources))
 					if err := generator.ProtectResources(wd, genConfig.ModuleName, selectedResources); err != nil {
-						fmt.Printf("⚠️  Could not protect resources: %v\n", err)
-						fmt.Println("   You can manually wrap routes with authController.RequireAuth()")
+						logging.Printf("⚠️  Could not protect resources: %v\n", err)
+						logging.Println("   You can manually wrap routes with authController.RequireAuth()")
 					} else {
-						fmt.Println("✅ Resources protected!")
+						logging.Println("✅ Resources protected!")
 						for _, r := range selectedResources {
-							fmt.Printf("   - %s (%s)\n", r.Name, r.Path)
+							logging.Printf("   - %s (%s)\n", r.Name, r.Path)
 						}
 					}
 				}
@@ -223,15 +224,15 @@ func Auth(args []string) error {
 		}
 	}
 
-	fmt.Println("\n📝 Next steps:")
-	fmt.Println("  1. Run migrations:")
-	fmt.Println("     lvt migration up")
-	fmt.Println("\n  2. Generate sqlc code:")
-	fmt.Println("     sqlc generate")
-	fmt.Println("\n  3. Configure email sender (see github.com/livetemplate/lvt/pkg/email)")
-	fmt.Println("\n  4. Run E2E tests (requires Docker):")
-	fmt.Println("     go test ./app/auth -run TestAuthE2E -v")
-	fmt.Println("\n💡 Tip: Check app/auth/auth.go for complete usage examples!")
+	logging.Println("\n📝 Next steps:")
+	logging.Println("  1. Run migrations:")
+	logging.Println("     lvt migration up")
+	logging.Println("\n  2. Generate sqlc code:")
+	logging.Println("     sqlc generate")
+	logging.Println("\n  3. Configure email sender (see github.com/livetemplate/lvt/pkg/email)")
+	logging.Println("\n  4. Run E2E tests (requires Docker):")
+	logging.Println("     go test ./app/auth -run TestAuthE2E -v")
+	logging.Println("\n💡 Tip: Check app/auth/auth.go for complete usage examples!")
 
 	capture.Complete(true, validationResultJSON)
 	return nil
@@ -242,46 +243,8 @@ func startsWithDash(s string) bool {
 	return strings.HasPrefix(s, "-")
 }
 
-// pluralizeNoun converts a singular noun to plural (simple English rules)
-func pluralizeNoun(word string) string {
-	if word == "" {
-		return ""
-	}
-
-	lower := strings.ToLower(word)
-
-	// Handle special cases
-	if lower == "user" {
-		return "users"
-	}
-	if lower == "account" {
-		return "accounts"
-	}
-	if lower == "admin" {
-		return "admins"
-	}
-	if lower == "member" {
-		return "members"
-	}
-
-	// General rules
-	if strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "z") ||
-		strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh") {
-		return lower + "es"
-	}
-
-	if strings.HasSuffix(lower, "y") && len(lower) > 1 {
-		// Check if preceded by consonant
-		secondLast := lower[len(lower)-2]
-		if !isVowel(secondLast) {
-			return lower[:len(lower)-1] + "ies"
-		}
-	}
-
-	return lower + "s"
-}
-
-// isVowel checks if a character is a vowel
-func isVowel(c byte) bool {
-	return c == 'a' || c == 'e' || c == 'i' || c == 'o' || c == 'u'
+// pluralizeNoun returns the table name for a struct name: its plural, in
+// lower case.
+func pluralizeNoun(word string, in *inflect.Inflector) string {
+	return in.Plural(strings.ToLower(word))
 }
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/livetemplate/lvt/internal/logging"
 	_ "modernc.org/sqlite"
 )
 
@@ -82,7 +83,7 @@ func AuthConfirm(args []string, dbPath string) error {
 
 	// Check if already confirmed
 	if confirmedAt.Valid {
-		fmt.Printf("User %s is already confirmed (confirmed at: %s)\n", email, confirmedAt.Time.Format(time.RFC3339))
+		logging.Printf("User %s is already confirmed (confirmed at: %s)\n", email, confirmedAt.Time.Format(time.RFC3339))
 		return nil
 	}
 
@@ -93,7 +94,7 @@ func AuthConfirm(args []string, dbPath string) error {
 		return fmt.Errorf("failed to confirm user: %w", err)
 	}
 
-	fmt.Printf("✅ User %s confirmed successfully!\n", email)
+	logging.Printf("✅ User %s confirmed successfully!\n", email)
 	return nil
 }
 
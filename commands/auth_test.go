@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/livetemplate/lvt/internal/inflect"
 )
 
 func TestAuth_Flags(t *testing.T) {
@@ -120,12 +122,14 @@ func TestPluralizeNoun(t *testing.T) {
 		{"Box", "boxes"},           // x -> xes
 		{"Buzz", "buzzes"},         // z -> zes
 		{"Class", "classes"},       // ss -> sses
+		{"Person", "people"},
+		{"Status", "statuses"},
 		{"", ""},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got := pluralizeNoun(tt.input)
+			got := pluralizeNoun(tt.input, inflect.Default)
 			if got != tt.want {
 				t.Errorf("pluralizeNoun(%q) = %q, want %q", tt.input, got, tt.want)
 			}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // Authz generates the authorization system (role column + queries).
@@ -29,7 +30,7 @@ func Authz(args []string) error {
 		tableName = args[0]
 	}
 
-	fmt.Println("Generating authorization system...")
+	logging.Println("Generating authorization system...")
 
 	cfg := &generator.AuthzConfig{
 		ModuleName: moduleName,
@@ -40,24 +41,24 @@ func Authz(args []string) error {
 		return err
 	}
 
-	fmt.Println()
-	fmt.Println("✅ Authorization system generated!")
-	fmt.Println()
-	fmt.Println("Files created/updated:")
-	fmt.Println("  database/migrations/<timestamp>_add_user_roles.sql")
-	fmt.Println("  database/queries.sql (role queries appended)")
-	fmt.Println("  database/schema.sql (role column added)")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Run migration:")
-	fmt.Println("     lvt migration up")
-	fmt.Println("  2. Regenerate sqlc code:")
-	fmt.Println("     sqlc generate")
-	fmt.Println("  3. Generate resources with authorization:")
-	fmt.Printf("     lvt gen resource posts title content:text --with-authz\n")
-	fmt.Println("  4. Set admin role:")
-	fmt.Println("     UPDATE users SET role = 'admin' WHERE email = 'admin@example.com';")
-	fmt.Println()
+	logging.Println()
+	logging.Println("✅ Authorization system generated!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  database/migrations/<timestamp>_add_user_roles.sql")
+	logging.Println("  database/queries.sql (role queries appended)")
+	logging.Println("  database/schema.sql (role column added)")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run migration:")
+	logging.Println("     lvt migration up")
+	logging.Println("  2. Regenerate sqlc code:")
+	logging.Println("     sqlc generate")
+	logging.Println("  3. Generate resources with authorization:")
+	logging.Printf("     lvt gen resource posts title content:text --with-authz\n")
+	logging.Println("  4. Set admin role:")
+	logging.Println("     UPDATE users SET role = 'admin' WHERE email = 'admin@example.com';")
+	logging.Println()
 
 	return nil
 }
@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/wire"
+	"gopkg.in/yaml.v3"
+)
+
+// budgetScenario is a scenario file of lvt parse budget: data over the
+// sample data, and the state changes to apply in turn.
+type budgetScenario struct {
+	Data  map[string]any `json:"data" yaml:"data"`
+	Steps []budgetStep   `json:"steps" yaml:"steps"`
+}
+
+type budgetStep struct {
+	Name string         `json:"name" yaml:"name"`
+	Set  map[string]any `json:"set" yaml:"set"`
+}
+
+// payloadSize is the size of an update payload, split into the static
+// markup it carries and the rest, and its size over a WebSocket that
+// deflates updates (lvt gen compression).
+type payloadSize struct {
+	Step     string   `json:"step"`
+	Bytes    int      `json:"bytes"`
+	Deflated int      `json:"deflated"`
+	Statics  int      `json:"statics"`
+	Dynamics int      `json:"dynamics"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// largeUpdateRatio is the share of the initial render above which an
+// update is reported as re-sending most of the page.
+const largeUpdateRatio = 0.5
+
+// ParseBudget renders a template through a scenario of state changes (lvt
+// parse budget) and reports the size of each update payload.
+func ParseBudget(args []string) error {
+	var templatePath, scenarioPath string
+	format := "text"
+	maxBytes := 0
+	compressed := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--compressed":
+			compressed = true
+		case arg == "--scenario" || arg == "--max-bytes" || arg == "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			switch arg {
+			case "--scenario":
+				scenarioPath = args[i]
+			case "--format":
+				format = args[i]
+			default:
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("--max-bytes must be a positive number, got %q", args[i])
+				}
+				maxBytes = n
+			}
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		case templatePath != "":
+			return fmt.Errorf("unexpected argument: %s", arg)
+		default:
+			templatePath = arg
+		}
+	}
+	if templatePath == "" {
+		return fmt.Errorf("template file required\nUsage: lvt parse budget <template-file> [--scenario <file>] [--max-bytes <n>] [--compressed] [--format text|json]")
+	}
+	if compressed && maxBytes == 0 {
+		return fmt.Errorf("--compressed applies the --max-bytes budget to deflated updates; set --max-bytes")
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid --format %q (valid: text, json)", format)
+	}
+	if JSONOutput() {
+		format = "json"
+	}
+	if _, err := os.Stat(templatePath); err != nil {
+		return fmt.Errorf("template file not found: %s", templatePath)
+	}
+
+	data, sampleErr := generator.SampleData(".", templatePath)
+	if sampleErr != nil {
+		data = map[string]any{}
+	}
+	var scenario *budgetScenario
+	if scenarioPath != "" {
+		var err error
+		if scenario, err = readBudgetScenario(scenarioPath); err != nil {
+			return err
+		}
+	} else {
+		if sampleErr != nil {
+			return fmt.Errorf("%w\n(or describe the state changes with --scenario)", sampleErr)
+		}
+		scenario = defaultBudgetScenario(data)
+	}
+	for k, v := range scenario.Data {
+		data[k] = v
+	}
+
+	funcs := generator.TemplateFuncs(".")
+	if err := generator.ValidateTemplateFuncs(templatePath, funcs); err != nil {
+		return err
+	}
+	tmpl, err := newAppTemplate(templatePath, funcs)
+	if err != nil {
+		return fmt.Errorf("LiveTemplate parse error in %s: %w", templatePath, err)
+	}
+
+	var sizes []payloadSize
+	render := func(step string) error {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteUpdates(&buf, data); err != nil {
+			return fmt.Errorf("step %q: %w", step, err)
+		}
+		size, err := measurePayload(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("step %q: %w", step, err)
+		}
+		size.Step = step
+		sizes = append(sizes, size)
+		return nil
+	}
+	if err := render("initial render"); err != nil {
+		return err
+	}
+	for i, step := range scenario.Steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step %d", i+1)
+		}
+		for k, v := range step.Set {
+			data[k] = v
+		}
+		if err := render(name); err != nil {
+			return err
+		}
+	}
+
+	overBudget := 0
+	initial := sizes[0].Bytes
+	// The budget is for updates; the initial render is the page itself
+	for i := 1; i < len(sizes); i++ {
+		s := &sizes[i]
+		if compressed && s.Deflated > maxBytes {
+			overBudget++
+			s.Warnings = append(s.Warnings, fmt.Sprintf("%d deflated bytes is over the %d byte budget", s.Deflated, maxBytes))
+		} else if !compressed && maxBytes > 0 && s.Bytes > maxBytes {
+			overBudget++
+			s.Warnings = append(s.Warnings, fmt.Sprintf("%d bytes is over the %d byte budget", s.Bytes, maxBytes))
+		}
+		if s.Statics > 0 {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("re-sends %d bytes of static markup; an {{if}}/{{else}} switching between different markup, or range items without a key, re-render markup the client already has", s.Statics))
+		}
+		if initial > 0 && float64(s.Bytes) >= largeUpdateRatio*float64(initial) {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("re-sends %d%% of the initial render; split the changing part into smaller dynamic values", s.Bytes*100/initial))
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{
+			"template":   templatePath,
+			"max_bytes":  maxBytes,
+			"compressed": compressed,
+			"payloads":   sizes,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printBudget(templatePath, sizes)
+	}
+
+	if overBudget > 0 {
+		return fmt.Errorf("%d payload(s) over the %d byte budget", overBudget, maxBytes)
+	}
+	return nil
+}
+
+func printBudget(templatePath string, sizes []payloadSize) {
+	fmt.Printf("Update payloads of %s\n\n", templatePath)
+	fmt.Printf("  %-28s %8s %9s %8s %9s %8s\n", "STEP", "BYTES", "DEFLATED", "STATICS", "DYNAMICS", "STATIC%")
+	total, deflated := 0, 0
+	for _, s := range sizes {
+		ratio := 0
+		if s.Bytes > 0 {
+			ratio = s.Statics * 100 / s.Bytes
+		}
+		fmt.Printf("  %-28s %8d %9d %8d %9d %7d%%\n", truncateStep(s.Step), s.Bytes, s.Deflated, s.Statics, s.Dynamics, ratio)
+		total += s.Bytes
+		deflated += s.Deflated
+	}
+	if total > 0 {
+		fmt.Printf("\n  Deflated (lvt gen compression): %d → %d bytes in all, %d%% smaller\n", total, deflated, 100-deflated*100/total)
+	}
+
+	warned := false
+	for _, s := range sizes {
+		for _, w := range s.Warnings {
+			if !warned {
+				fmt.Println()
+				warned = true
+			}
+			fmt.Printf("⚠️  %s: %s\n", s.Step, w)
+		}
+	}
+	if !warned {
+		fmt.Println("\n✅ Updates send only changed values")
+	}
+}
+
+func truncateStep(name string) string {
+	if len(name) <= 28 {
+		return name
+	}
+	return name[:25] + "..."
+}
+
+// measurePayload sizes an update payload and the static markup in it: the
+// "s" arrays of its tree nodes, as sent.
+func measurePayload(payload []byte) (payloadSize, error) {
+	if !json.Valid(payload) {
+		return payloadSize{}, fmt.Errorf("invalid update payload")
+	}
+	size := payloadSize{Bytes: len(payload), Deflated: len(wire.Deflate(payload))}
+
+	var walk func(raw json.RawMessage)
+	walk = func(raw json.RawMessage) {
+		var node map[string]json.RawMessage
+		if json.Unmarshal(raw, &node) == nil {
+			for k, child := range node {
+				if k == "s" {
+					size.Statics += len(child)
+					continue
+				}
+				walk(child)
+			}
+			return
+		}
+		var list []json.RawMessage
+		if json.Unmarshal(raw, &list) == nil {
+			for _, child := range list {
+				walk(child)
+			}
+		}
+	}
+	walk(payload)
+	size.Dynamics = size.Bytes - size.Statics
+	return size, nil
+}
+
+// readBudgetScenario reads a JSON or YAML scenario file.
+func readBudgetScenario(path string) (*budgetScenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	scenario := &budgetScenario{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, scenario)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, scenario)
+	default:
+		return nil, fmt.Errorf("unsupported scenario file %s (expected .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("scenario %s has no steps", path)
+	}
+	return scenario, nil
+}
+
+// defaultBudgetScenario adds, edits and removes a row of every item list
+// of the sample data.
+func defaultBudgetScenario(data map[string]any) *budgetScenario {
+	added, edited, removed := map[string]any{}, map[string]any{}, map[string]any{}
+	for k, v := range data {
+		rows, ok := v.([]any)
+		if !ok || len(rows) == 0 {
+			continue
+		}
+		first, ok := rows[0].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		row := copyRow(first)
+		row["ID"] = fmt.Sprintf("%v-new", first["ID"])
+		withRow := append(append([]any{}, rows...), row)
+		added[k] = withRow
+
+		row = copyRow(first)
+		for field, value := range row {
+			if s, ok := value.(string); ok && field != "ID" {
+				row[field] = s + " (edited)"
+				break
+			}
+		}
+		withEdit := append([]any{row}, withRow[1:]...)
+		edited[k] = withEdit
+
+		removed[k] = append([]any{}, withEdit[1:]...)
+	}
+
+	return &budgetScenario{Steps: []budgetStep{
+		{Name: "no change", Set: map[string]any{}},
+		{Name: "add a row", Set: added},
+		{Name: "edit a row", Set: edited},
+		{Name: "remove a row", Set: removed},
+	}}
+}
+
+func copyRow(row map[string]any) map[string]any {
+	c := make(map[string]any, len(row))
+	for k, v := range row {
+		c[k] = v
+	}
+	return c
+}
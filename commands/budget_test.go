@@ -0,0 +1,50 @@
+package commands
+
+import "testing"
+
+func TestMeasurePayload(t *testing.T) {
+	tests := []struct {
+		payload string
+		statics int
+	}{
+		{`{"0":"b"}`, 0},
+		{`{"1":{"0":"2","s":["<p>on ","</p>"]}}`, len(`["<p>on ","</p>"]`)},
+		{`{"2":[["a",[{"0":"2","s":["<li>"]}]]]}`, len(`["<li>"]`)},
+	}
+	for _, tt := range tests {
+		size, err := measurePayload([]byte(tt.payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size.Bytes != len(tt.payload) || size.Statics != tt.statics || size.Dynamics != size.Bytes-size.Statics || size.Deflated == 0 {
+			t.Errorf("measurePayload(%s) = %+v, want %d statics", tt.payload, size, tt.statics)
+		}
+	}
+
+	if _, err := measurePayload([]byte("not json")); err == nil {
+		t.Error("expected an error for an invalid payload")
+	}
+}
+
+func TestDefaultBudgetScenario(t *testing.T) {
+	rows := []any{
+		map[string]any{"ID": "posts-1", "Title": "One"},
+		map[string]any{"ID": "posts-2", "Title": "Two"},
+	}
+	scenario := defaultBudgetScenario(map[string]any{"Items": rows, "Title": "Posts"})
+
+	var lengths []int
+	for _, step := range scenario.Steps[1:] {
+		lengths = append(lengths, len(step.Set["Items"].([]any)))
+	}
+	if len(lengths) != 3 || lengths[0] != 3 || lengths[1] != 3 || lengths[2] != 2 {
+		t.Errorf("expected add, edit and remove of a row, got lengths %v", lengths)
+	}
+	edited := scenario.Steps[2].Set["Items"].([]any)[0].(map[string]any)
+	if edited["Title"] != "One (edited)" || rows[0].(map[string]any)["Title"] != "One" {
+		t.Errorf("expected the edit to change a copy of the first row, got %v", edited)
+	}
+	if _, ok := scenario.Steps[1].Set["Title"]; ok {
+		t.Error("only item lists should change")
+	}
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenCache sets up the shared/cache package.
+func GenCache(args []string) error {
+	if ShowHelpIfRequested(args, printGenCacheHelp) {
+		return nil
+	}
+
+	withRedis := false
+	for _, arg := range args {
+		switch arg {
+		case "--redis":
+			withRedis = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+			return fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GenerateCache(cwd, moduleName, withRedis); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Cache set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/cache/cache.go       GetOrLoad, Invalidate and the in-memory store")
+	if withRedis {
+		logging.Println("  shared/cache/redis.go       Redis store, used when REDIS_URL is set")
+		logging.Println("  shared/config/config.go     REDIS_URL declaration")
+		logging.Println("  cmd/*/main.go               cache.Setup")
+	}
+	logging.Println()
+	logging.Println("Next steps:")
+	if withRedis {
+		logging.Println("  1. Run 'go mod tidy' to fetch the Redis client")
+		logging.Println("  2. Set REDIS_URL, e.g. redis://localhost:6379")
+	} else {
+		logging.Println("  1. Cache a resource's list: lvt gen resource <name> ... --cache")
+		logging.Println("  2. Or wrap expensive queries in cache.GetOrLoad (see shared/cache)")
+	}
+	logging.Println()
+
+	return nil
+}
+
+func printGenCacheHelp() {
+	fmt.Println("Usage: lvt gen cache [--redis]")
+	fmt.Println()
+	fmt.Println("Creates shared/cache, which caches query results with a TTL:")
+	fmt.Println()
+	fmt.Println("  cache.GetOrLoad(ctx, key, ttl, load)  Return the cached value or load and cache it")
+	fmt.Println("  cache.Key(\"orders\", \"stats\", status)  Build a key from parts")
+	fmt.Println("  cache.Invalidate(ctx, \"orders\")       Drop a key and every key under it")
+	fmt.Println()
+	fmt.Println("Values are stored in memory, per process. Resources generated with")
+	fmt.Println("--cache cache their list per search and sort order and invalidate it")
+	fmt.Println("on create, update and delete.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --redis    Add a Redis store, used when REDIS_URL is set, so instances")
+	fmt.Println("             share cached values and invalidations")
+	fmt.Println()
+}
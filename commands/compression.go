@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenCompression sets up the shared/compression package, which deflates
+// the updates pages get over their WebSocket.
+func GenCompression(args []string) error {
+	if ShowHelpIfRequested(args, printGenCompressionHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		return fmt.Errorf("unexpected argument: %s", arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GenerateCompression(cwd, moduleName); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Compressed updates set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/compression/compression.go   Option, which accepts permessage-deflate")
+	logging.Println("  shared/config/config.go             LIVE_COMPRESSION declaration")
+	logging.Println()
+	logging.Println("Resources, views and wizards generated from now on deflate their updates")
+	logging.Println("for browsers that offer it. Regenerate existing ones to use it.")
+	logging.Println("See the savings per update with: lvt parse budget <template>")
+	logging.Println()
+
+	return nil
+}
+
+func printGenCompressionHelp() {
+	fmt.Println("Usage: lvt gen compression")
+	fmt.Println()
+	fmt.Println("Lets handlers generated afterwards accept the permessage-deflate WebSocket")
+	fmt.Println("extension, which browsers offer when they connect. Where both sides agree,")
+	fmt.Println("each update is sent as compressed JSON and inflated by the browser before")
+	fmt.Println("the page sees it, so the client needs no change. Clients that do not offer")
+	fmt.Println("it, and HTTP responses, stay uncompressed.")
+	fmt.Println()
+	fmt.Println("LIVE_COMPRESSION=false turns it off, e.g. when CPU matters more than")
+	fmt.Println("bandwidth: tiny updates grow by a few bytes when deflated.")
+	fmt.Println()
+	fmt.Println("lvt parse budget reports each update's size before and after compression;")
+	fmt.Println("--compressed applies its --max-bytes budget to the deflated size.")
+	fmt.Println()
+}
@@ -1,10 +1,17 @@
 package commands
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/dbconsole"
+	"github.com/livetemplate/lvt/internal/logging"
+	_ "modernc.org/sqlite"
 )
 
 // Console opens an interactive database shell for the current app.
@@ -13,74 +20,127 @@ func Console(args []string) error {
 		return nil
 	}
 
-	dbPath := findDBPath()
+	var command string
+	hasCommand := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--command" || args[i] == "-c":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a SQL statement or dot command", args[i])
+			}
+			command = args[i+1]
+			hasCommand = true
+			i++
+		case strings.HasPrefix(args[i], "--command="):
+			command = strings.TrimPrefix(args[i], "--command=")
+			hasCommand = true
+		default:
+			return fmt.Errorf("unknown argument: %s\n\nRun 'lvt db console --help' for usage", args[i])
+		}
+	}
+
+	dbPath, err := findDBPath()
+	if err != nil {
+		return err
+	}
 	if dbPath == "" {
-		return fmt.Errorf("no database found. Are you in a LiveTemplate project directory?\nExpected: app.db or DATABASE_PATH environment variable")
+		return fmt.Errorf("no database found. Are you in a LiveTemplate project directory?\nExpected: app.db, database= in .lvtrc, or DATABASE_PATH environment variable")
 	}
 
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
 		return fmt.Errorf("database file not found: %s\nRun 'lvt migration up' to create it", dbPath)
 	}
 
-	sqlite3Path, err := exec.LookPath("sqlite3")
+	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
-		fmt.Printf("Database path: %s\n", dbPath)
-		return fmt.Errorf("sqlite3 not found in PATH. Install it:\n  macOS:  brew install sqlite3\n  Ubuntu: sudo apt install sqlite3\n  Or open manually: sqlite3 %s", dbPath)
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		return fmt.Errorf("failed to open database %s: %w", dbPath, err)
 	}
 
-	fmt.Printf("Opening %s...\n", dbPath)
-	fmt.Println("Type .tables to list tables, .schema to see schema, .quit to exit")
-	fmt.Println()
+	shell := dbconsole.New(db, os.Stdout)
+	if hasCommand {
+		return shell.Exec(command)
+	}
 
-	cmd := exec.Command(sqlite3Path, "-header", "-column", dbPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	if term.IsTerminal(os.Stdin.Fd()) {
+		logging.Printf("Connected to %s\n", dbPath)
+		logging.Println("Type .tables to list tables, .schema to see schema, .help for more, .quit to exit")
+		logging.Println()
+	}
+	return shell.Run(os.Stdin, consoleHistoryPath())
 }
 
-// findDBPath locates the database file for the current project.
-func findDBPath() string {
-	if path := os.Getenv("DATABASE_PATH"); path != "" {
-		return path
+// consoleHistoryPath is where the console keeps its line history, shared by
+// all projects like a shell's. Empty disables saving it.
+func consoleHistoryPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
 	}
+	return filepath.Join(homeDir, config.DefaultConfigDir, "db_history")
+}
 
-	dir, err := os.Getwd()
+// findDBPath locates the database file for the current project and
+// environment (LVT_ENV), or "" when there is none. It fails when the
+// environment's database is in memory, which no command can open.
+func findDBPath() (string, error) {
+	wd, err := os.Getwd()
 	if err != nil {
-		return ""
+		return "", nil
+	}
+	root := config.ProjectRoot(wd)
+	if path := config.DatabasePath(root); path == config.MemoryDatabase {
+		return "", config.MemoryDatabaseError(config.Environment(root))
+	} else if path != "" {
+		return path, nil
 	}
-	for {
+
+	// Default: app.db in the current or a parent directory, up to the
+	// project root
+	for dir := wd; ; {
 		path := filepath.Join(dir, "app.db")
 		if _, err := os.Stat(path); err == nil {
-			return path
-		}
-		// Stop at project boundary (go.mod indicates project root)
-		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
-			break
+			return path, nil
 		}
 		parent := filepath.Dir(dir)
-		if parent == dir {
-			break
+		if dir == root || parent == dir {
+			return "", nil
 		}
 		dir = parent
 	}
-	return ""
 }
 
 func printConsoleHelp() {
-	fmt.Println("Usage: lvt console  (alias: lvt db)")
+	fmt.Println("Usage: lvt db console [--command <sql>]  (alias: lvt console)")
 	fmt.Println()
-	fmt.Println("Opens an interactive SQLite database shell for the current app.")
+	fmt.Println("Opens an interactive SQL shell on the app's SQLite database, with line")
+	fmt.Println("editing and history (Up/Down recall earlier lines, kept in ~/.config/lvt/db_history).")
+	fmt.Println("End statements with ; - they may span several lines.")
 	fmt.Println()
-	fmt.Println("The database is located by:")
+	fmt.Println("Options:")
+	fmt.Println("  -c, --command <sql>  Run one SQL statement (or several, ;-separated) or dot")
+	fmt.Println("                       command, print the result and exit")
+	fmt.Println()
+	fmt.Println("The database is located by, for the environment in LVT_ENV (default: development):")
 	fmt.Println("  1. DATABASE_PATH environment variable")
-	fmt.Println("  2. app.db in the current or parent directories")
+	fmt.Println("  2. DATABASE_PATH_<ENV> environment variable, e.g. DATABASE_PATH_TEST")
+	fmt.Println("  3. database=<path> in the project's .lvtrc (relative to the project root)")
+	fmt.Println("  4. app.db in the current or parent directories")
+	fmt.Println("Variables may also be set in the project's .env file.")
+	fmt.Println()
+	fmt.Println("Console commands:")
+	fmt.Println("  .tables          List tables and views")
+	fmt.Println("  .schema [table]  Show CREATE statements")
+	fmt.Println("  .help            Show console help")
+	fmt.Println("  .quit            Exit the console (also .exit or Ctrl-D)")
 	fmt.Println()
-	fmt.Println("Useful SQLite commands:")
-	fmt.Println("  .tables     List all tables")
-	fmt.Println("  .schema     Show CREATE TABLE statements")
-	fmt.Println("  .headers on Enable column headers")
-	fmt.Println("  .mode csv   Switch to CSV output")
-	fmt.Println("  .quit       Exit the console")
+	fmt.Println("Examples:")
+	fmt.Println("  lvt db console")
+	fmt.Println("  lvt db console --command \"SELECT count(*) FROM posts\"")
+	fmt.Println("  lvt db console -c .tables")
+	fmt.Println("  lvt db console < fixes.sql      Run a script, stopping at the first error")
 	fmt.Println()
 }
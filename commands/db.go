@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/dbbackup"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/migration"
+)
+
+// DB dispatches `lvt db <command>`. Without a known command it opens the
+// console, so `lvt db` and `lvt db -c ...` keep working.
+func DB(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "backup":
+			return DBBackup(args[1:])
+		case "restore":
+			return DBRestore(args[1:])
+		case "console":
+			return Console(args[1:])
+		}
+	}
+	return Console(args)
+}
+
+// DBBackup snapshots the app's database into the backups directory and
+// prunes old backups by the retention flags.
+func DBBackup(args []string) error {
+	if ShowHelpIfRequested(args, printDBBackupHelp) {
+		return nil
+	}
+
+	dir := dbbackup.DefaultDir
+	list := false
+	var retention dbbackup.Retention
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--dir" && i+1 < len(args):
+			dir = args[i+1]
+			i++
+		case args[i] == "--keep" && i+1 < len(args):
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid --keep %q (expected a number of backups, at least 1)", args[i+1])
+			}
+			retention.Keep = n
+			i++
+		case args[i] == "--max-age" && i+1 < len(args):
+			age, err := dbbackup.ParseAge(args[i+1])
+			if err != nil {
+				return fmt.Errorf("invalid --max-age: %w", err)
+			}
+			retention.MaxAge = age
+			i++
+		case args[i] == "--list":
+			list = true
+		default:
+			return fmt.Errorf("unknown argument: %s\n\nRun 'lvt db backup --help' for usage", args[i])
+		}
+	}
+
+	db, err := findBackupDatabase()
+	if err != nil {
+		return err
+	}
+	if list {
+		return listBackups(db, dir)
+	}
+
+	now := time.Now()
+	path, err := dbbackup.Create(db, dir, now)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	logging.Printf("✅ Backed up %s to %s (%s)\n", db.Describe(), path, formatBytes(info.Size()))
+
+	removed, err := dbbackup.Prune(db, dir, retention, now)
+	for _, b := range removed {
+		logging.Printf("   Removed old backup %s\n", b.Path)
+	}
+	return err
+}
+
+// DBRestore replaces the app's database with a backup, then runs the
+// migrations added since the backup was taken.
+func DBRestore(args []string) error {
+	if ShowHelpIfRequested(args, printDBRestoreHelp) {
+		return nil
+	}
+
+	dir := dbbackup.DefaultDir
+	var file string
+	latest := false
+	migrate := true
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--dir" && i+1 < len(args):
+			dir = args[i+1]
+			i++
+		case args[i] == "--latest":
+			latest = true
+		case args[i] == "--no-migrate":
+			migrate = false
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s\n\nRun 'lvt db restore --help' for usage", args[i])
+		case file == "":
+			file = args[i]
+		default:
+			return fmt.Errorf("unexpected argument: %s (restore takes one backup file)", args[i])
+		}
+	}
+	if file != "" && latest {
+		return fmt.Errorf("give either a backup file or --latest, not both")
+	}
+
+	db, err := findBackupDatabase()
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		if !latest {
+			return fmt.Errorf("backup file required: lvt db restore <file>, or --latest for the newest in %s/", dir)
+		}
+		backups, err := dbbackup.List(db, dir)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			return fmt.Errorf("no backups of %s found in %s/", db.Name(), dir)
+		}
+		file = backups[0].Path
+	}
+
+	if db.URL == "" {
+		if err := dbbackup.Check(file); err != nil {
+			return err
+		}
+	} else if !fileExists(file) {
+		return fmt.Errorf("backup not found: %s", file)
+	}
+
+	// Keep what is being replaced, in case the wrong backup was picked
+	if db.URL != "" || fileExists(db.Path) {
+		snapshot, err := dbbackup.CreatePreRestore(db, dir, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to back up the current database before restoring: %w", err)
+		}
+		logging.Printf("Saved the current database to %s\n", snapshot)
+	}
+
+	if err := dbbackup.Restore(db, file); err != nil {
+		return err
+	}
+	logging.Printf("✅ Restored %s from %s\n", db.Describe(), file)
+
+	if !migrate {
+		return nil
+	}
+	if db.URL != "" {
+		logging.Println()
+		logging.Println("lvt migrations run against SQLite only; apply any migrations added since")
+		logging.Println("this backup with your Postgres deployment's migration step.")
+		return nil
+	}
+	runner, err := migration.Open(db.Path)
+	if err != nil {
+		logging.Printf("Skipping migrations: %v\n", err)
+		return nil
+	}
+	defer runner.Close()
+	logging.Println("Running pending migrations...")
+	if err := runner.ApplyPending(); err != nil {
+		return fmt.Errorf("restored, but migrations failed: %w", err)
+	}
+	logging.Println("✅ Migrations complete!")
+	return nil
+}
+
+// findBackupDatabase finds the database to back up or restore: Postgres when
+// DATABASE_URL points at one, otherwise the app's SQLite file.
+func findBackupDatabase() (dbbackup.Database, error) {
+	if url := os.Getenv("DATABASE_URL"); dbbackup.IsPostgresURL(url) {
+		return dbbackup.Database{URL: url}, nil
+	}
+	path, err := findDBPath()
+	if err != nil {
+		return dbbackup.Database{}, err
+	}
+	if path == "" {
+		return dbbackup.Database{}, fmt.Errorf("no database found. Are you in a LiveTemplate project directory?\nExpected: app.db, database= in .lvtrc, DATABASE_PATH, or a postgres:// DATABASE_URL")
+	}
+	return dbbackup.Database{Path: path}, nil
+}
+
+func listBackups(db dbbackup.Database, dir string) error {
+	backups, err := dbbackup.List(db, dir)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Printf("No backups of %s in %s/\n", db.Name(), dir)
+		return nil
+	}
+	for _, b := range backups {
+		fmt.Printf("  %s  %s  %s\n", b.Time.Local().Format("2006-01-02 15:04:05"), formatBytes(b.Size), filepath.Base(b.Path))
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func printDBBackupHelp() {
+	fmt.Println("Usage: lvt db backup [--dir <dir>] [--keep <n>] [--max-age <age>] [--list]")
+	fmt.Println()
+	fmt.Println("Backs up the app's database to a timestamped file, e.g. backups/app-20240101-120000.db.")
+	fmt.Println("SQLite databases are copied with SQLite's online backup API, so it is safe to run")
+	fmt.Println("while the app is serving. When DATABASE_URL is a postgres:// URL, pg_dump writes a")
+	fmt.Println("custom-format archive (.dump) instead.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --dir <dir>      Where backups go (default: backups)")
+	fmt.Println("  --keep <n>       After backing up, keep only the newest n backups")
+	fmt.Println("  --max-age <age>  After backing up, remove backups older than age (e.g. 30d, 72h)")
+	fmt.Println("  --list           List existing backups instead of taking one")
+	fmt.Println()
+	fmt.Println("The newest backup is never pruned.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt db backup")
+	fmt.Println("  lvt db backup --keep 7")
+	fmt.Println("  lvt db backup --dir /var/backups/myapp --max-age 30d")
+	fmt.Println()
+}
+
+func printDBRestoreHelp() {
+	fmt.Println("Usage: lvt db restore <file> | --latest [--dir <dir>] [--no-migrate]")
+	fmt.Println()
+	fmt.Println("Replaces the app's database with a backup taken by lvt db backup, then runs the")
+	fmt.Println("migrations added since. SQLite backups are integrity-checked before anything is")
+	fmt.Println("overwritten, and the current database is first saved to <dir>/<name>-<time>-pre-restore.")
+	fmt.Println("Postgres archives are restored with pg_restore.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --latest      Restore the newest backup in the backups directory")
+	fmt.Println("  --dir <dir>   Backups directory (default: backups)")
+	fmt.Println("  --no-migrate  Do not run pending migrations after restoring")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt db restore --latest")
+	fmt.Println("  lvt db restore backups/app-20240101-120000.db")
+	fmt.Println()
+}
@@ -0,0 +1,309 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/migration"
+	"github.com/livetemplate/lvt/internal/parser"
+	"github.com/livetemplate/lvt/internal/seeder"
+	"github.com/livetemplate/lvt/internal/serve"
+	"github.com/livetemplate/lvt/internal/ui/progress"
+)
+
+// demoApp is an app `lvt demo` can generate: the resources `lvt gen` would
+// create and how many rows `lvt seed` puts in each.
+type demoApp struct {
+	Description string
+	Resources   []demoResource
+}
+
+type demoResource struct {
+	Name       string
+	Fields     []string
+	Searchable bool
+	Count      int
+}
+
+var demoApps = map[string]demoApp{
+	"blog": {
+		Description: "a blog with posts, authors and comments",
+		Resources: []demoResource{
+			{Name: "posts", Fields: []string{"title:string", "content:text", "published:bool"}, Searchable: true, Count: 25},
+			{Name: "authors", Fields: []string{"name:string", "email:email", "bio:text"}, Count: 8},
+			{Name: "comments", Fields: []string{"author:string", "body:text", "approved:bool"}, Count: 40},
+		},
+	},
+	"tasks": {
+		Description: "a task manager with projects and tasks",
+		Resources: []demoResource{
+			{Name: "projects", Fields: []string{"name:string", "description:text", "archived:bool"}, Count: 6},
+			{Name: "tasks", Fields: []string{"title:string", "notes:text", "priority:int", "due:time", "done:bool"}, Searchable: true, Count: 40},
+		},
+	},
+}
+
+// Demo generates a seeded sample app, then serves it and opens the browser.
+func Demo(args []string) error {
+	if ShowHelpIfRequested(args, printDemoHelp) {
+		return nil
+	}
+
+	name := "blog"
+	dir := ""
+	seed := int64(1)
+	run := true
+	config := serve.DefaultConfig()
+	config.Mode = serve.ModeApp
+	config.AutoDetect = false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--dir" && i+1 < len(args):
+			dir = args[i+1]
+			i++
+		case args[i] == "--port" && i+1 < len(args):
+			port, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return fmt.Errorf("invalid port number: %s", args[i+1])
+			}
+			config.Port = port
+			i++
+		case args[i] == "--seed" && i+1 < len(args):
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --seed %q (expected a number)", args[i+1])
+			}
+			seed = n
+			i++
+		case args[i] == "--no-browser":
+			config.OpenBrowser = false
+		case args[i] == "--no-run":
+			run = false
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s\n\nRun 'lvt demo --help' for usage", args[i])
+		default:
+			name = args[i]
+		}
+	}
+	app, ok := demoApps[name]
+	if !ok {
+		return fmt.Errorf("unknown demo %q (available: %s)", name, strings.Join(demoNames(), ", "))
+	}
+
+	// The app goes into <dir>/<name>, so its module and binary are named
+	// after the demo
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "lvt-demo-")
+		if err != nil {
+			return fmt.Errorf("failed to create temp dir: %w", err)
+		}
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	appDir := filepath.Join(dir, name)
+	if _, err := os.Stat(appDir); err == nil {
+		return fmt.Errorf("%s already exists; choose another --dir", appDir)
+	}
+
+	logging.Printf("Generating the %s demo: %s\n", name, app.Description)
+	logging.Printf("Directory: %s\n\n", appDir)
+	if err := generateDemo(name, app, dir, appDir, seed); err != nil {
+		return fmt.Errorf("%w\n\nThe partial app is in %s", err, appDir)
+	}
+
+	logging.Println()
+	logging.Printf("✅ Demo ready in %s\n", appDir)
+	logging.Println()
+	logging.Println("It is the same app as:")
+	logging.Printf("  lvt new %s\n", name)
+	for _, r := range app.Resources {
+		search := ""
+		if r.Searchable {
+			search = " --searchable"
+		}
+		logging.Printf("  lvt gen resource %s %s%s\n", r.Name, strings.Join(r.Fields, " "), search)
+	}
+	logging.Println("  lvt migration up")
+	for _, r := range app.Resources {
+		logging.Printf("  lvt seed %s --count %d\n", r.Name, r.Count)
+	}
+	logging.Printf("Seeded with --seed %d; the same seed gives the same data, for bug reports and benchmarks.\n", seed)
+	logging.Println()
+
+	if !run {
+		logging.Println("Run it with:")
+		logging.Printf("  cd %s && lvt serve\n", appDir)
+		return nil
+	}
+
+	config.Dir = appDir
+	logging.Println("Starting the demo (Ctrl-C to stop)...")
+	server, err := serve.NewServer(config)
+	if err != nil {
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+	if err := server.Start(context.Background()); err != nil {
+		return fmt.Errorf("server error: %w", err)
+	}
+	return nil
+}
+
+// generateDemo runs what `lvt new`, `lvt gen resource`, `lvt migration up`
+// and `lvt seed` would for app, leaving the working directory in appDir.
+func generateDemo(name string, app demoApp, dir, appDir string, seed int64) error {
+	const kit, styles = "multi", "tailwind"
+	kitInfo, err := kits.DefaultLoader().Load(kit)
+	if err != nil {
+		return fmt.Errorf("failed to load kit: %w", err)
+	}
+
+	steps := progress.New(logging.Messages(), 4)
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	if err := steps.Run("Generating app files", func() error {
+		return generator.GenerateApp(name, name, kit, styles, "", false)
+	}); err != nil {
+		return err
+	}
+	if err := os.Chdir(appDir); err != nil {
+		return err
+	}
+
+	if err := steps.Run(fmt.Sprintf("Generating resources (%s)", strings.Join(demoResourceNames(app), ", ")), func() error {
+		for _, r := range app.Resources {
+			fields, err := parser.ParseFields(r.Fields)
+			if err != nil {
+				return fmt.Errorf("%s: %w", r.Name, err)
+			}
+			if err := generator.GenerateResource(appDir, name, r.Name, fields, kit, kitInfo.Manifest.CSSFramework, styles,
+				"infinite", 20, "modal", "", false, r.Searchable); err != nil {
+				return fmt.Errorf("failed to generate %s: %w", r.Name, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var output []byte
+	if err := steps.Run("Installing dependencies (go mod tidy)", func() error {
+		output, err = goCommand(appDir, "mod", "tidy")
+		return err
+	}); err != nil {
+		return fmt.Errorf("go mod tidy failed: %w\n%s", err, output)
+	}
+
+	logging.Println()
+	runner, err := migration.New()
+	if err != nil {
+		return err
+	}
+	err = runner.Up()
+	runner.Close()
+	if err != nil {
+		return err
+	}
+
+	// Fail here rather than in the dev server when sqlc or a template broke
+	if err := steps.Run("Building app", func() error {
+		output, err = goCommand(appDir, "build", "-o", os.DevNull, "./...")
+		return err
+	}); err != nil {
+		return fmt.Errorf("the demo app does not build: %w\n%s", err, output)
+	}
+
+	if err := seeder.SetSeed(seed); err != nil {
+		return err
+	}
+	schemaPath, err := seeder.FindSchemaFile()
+	if err != nil {
+		return err
+	}
+	tables, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if err := seeder.Configure(appDir, tables, ""); err != nil {
+		return err
+	}
+	s, err := seeder.New()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	for _, r := range app.Resources {
+		table := seeder.FindTable(tables, r.Name)
+		if table == nil {
+			return fmt.Errorf("resource '%s' not found in schema", r.Name)
+		}
+		if err := s.Seed(*table, r.Count, seeder.SeedOptions{Tables: tables}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func goCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	return cmd.CombinedOutput()
+}
+
+func demoNames() []string {
+	var names []string
+	for name := range demoApps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func demoResourceNames(app demoApp) []string {
+	var names []string
+	for _, r := range app.Resources {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func printDemoHelp() {
+	fmt.Println("Usage: lvt demo [blog|tasks] [--dir <dir>] [--seed <n>] [--port <n>] [--no-browser] [--no-run]")
+	fmt.Println()
+	fmt.Println("Generates a complete, seeded sample app into a temp directory, runs it with")
+	fmt.Println("lvt serve and opens the browser: a quick way to try lvt, and a reproducible")
+	fmt.Println("starting point for bug reports and benchmarks.")
+	fmt.Println()
+	fmt.Println("Demos:")
+	for _, name := range demoNames() {
+		fmt.Printf("  %-6s %s\n", name, demoApps[name].Description)
+	}
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --dir <dir>   Generate into <dir>/<demo> instead of a new temp directory")
+	fmt.Println("  --seed <n>    Seed for the sample data (default: 1); the same seed gives the same data")
+	fmt.Println("  --port <n>    Port to serve on (default: 3000)")
+	fmt.Println("  --no-browser  Don't open the browser")
+	fmt.Println("  --no-run      Generate the app without starting it")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt demo")
+	fmt.Println("  lvt demo tasks --port 8080")
+	fmt.Println("  lvt demo blog --dir ./repro --no-run")
+	fmt.Println()
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestDemoAppsParse(t *testing.T) {
+	for name, app := range demoApps {
+		if len(app.Resources) == 0 {
+			t.Errorf("demo %s has no resources", name)
+		}
+		for _, r := range app.Resources {
+			if _, err := parser.ParseFields(r.Fields); err != nil {
+				t.Errorf("demo %s, resource %s: %v", name, r.Name, err)
+			}
+			if r.Count < 1 {
+				t.Errorf("demo %s, resource %s seeds no rows", name, r.Name)
+			}
+		}
+	}
+}
+
+func TestDemo_UnknownDemo(t *testing.T) {
+	err := Demo([]string{"shop", "--no-run"})
+	if err == nil || !strings.Contains(err.Error(), "available: blog, tasks") {
+		t.Errorf("Demo(shop) = %v, want an error listing the demos", err)
+	}
+}
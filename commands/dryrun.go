@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/livetemplate/lvt/internal/dryrun"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// dryRun is set while a command generates into a scratch copy for
+// --dry-run. Commands skip what would reach beyond the copy: validation,
+// prompts and plugin hooks.
+var dryRun bool
+
+// dryRunSubcommands are the `lvt gen` subcommands that support --dry-run.
+var dryRunSubcommands = map[string]bool{"resource": true, "view": true, "schema": true, "auth": true}
+
+// extractDryRun removes --dry-run from args and reports whether it was
+// there.
+func extractDryRun(args []string) ([]string, bool) {
+	var rest []string
+	found := false
+	for _, arg := range args {
+		if arg == "--dry-run" {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// previewChanges runs generate against a scratch copy of dir and prints the
+// files it would create, modify or delete with their diffs, writing
+// nothing. The generator's own output is silenced and go get and sqlc
+// generate are skipped. Paths are printed with prefix, the location of dir
+// relative to the working directory (or absolute).
+func previewChanges(command, dir, prefix string, generate func(copy string) error) error {
+	restore := logging.Silence()
+	dryRun, generator.SkipTools = true, true
+	changes, err := dryrun.Preview(dir, generate)
+	dryRun, generator.SkipTools = false, false
+	restore()
+	if err != nil {
+		return err
+	}
+	for i := range changes {
+		changes[i].Path = prefix + changes[i].Path
+	}
+
+	if JSONOutput() {
+		type fileJSON struct {
+			Path    string `json:"path"`
+			Status  string `json:"status"`
+			Binary  bool   `json:"binary"`
+			Added   int    `json:"added"`
+			Removed int    `json:"removed"`
+			Diff    string `json:"diff"`
+		}
+		out := struct {
+			Command string     `json:"command"`
+			Files   []fileJSON `json:"files"`
+		}{Command: command, Files: []fileJSON{}}
+		for _, c := range changes {
+			added, removed := c.Stat()
+			out.Files = append(out.Files, fileJSON{
+				Path:    c.Path,
+				Status:  string(c.Status),
+				Binary:  c.Binary,
+				Added:   added,
+				Removed: removed,
+				Diff:    c.Diff(),
+			})
+		}
+		return printJSON(out)
+	}
+
+	fmt.Printf("Dry run: nothing written. lvt %s would %s", command, dryrun.Summary(changes))
+	if len(changes) == 0 {
+		fmt.Println(".")
+		return nil
+	}
+	fmt.Println(":")
+	fmt.Println()
+	for _, c := range changes {
+		added, removed := c.Stat()
+		fmt.Printf("  %-9s %s (+%d -%d)\n", c.Status, c.Path, added, removed)
+	}
+	for _, c := range changes {
+		fmt.Println()
+		fmt.Print(c.Diff())
+	}
+	return nil
+}
+
+// inDir runs fn with dir as the working directory.
+func inDir(dir string, fn func() error) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+	return fn()
+}
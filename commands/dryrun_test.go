@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/generator"
+)
+
+// snapshot returns the files under dir with their contents.
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		files[path] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// TestDryRunWritesNothing runs the generators that support --dry-run and
+// checks that the project is left as it was.
+func TestDryRunWritesNothing(t *testing.T) {
+	tmpDir, cleanup := setupGenTestDir(t)
+	defer cleanup()
+
+	// The previews print whole files
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	if err := New([]string{"preview", "--dry-run"}); err != nil {
+		t.Fatalf("new --dry-run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "preview")); !os.IsNotExist(err) {
+		t.Fatalf("new --dry-run created the app: %v", err)
+	}
+
+	if err := generator.GenerateApp("testapp", "testapp", "multi", "tailwind", "", false); err != nil {
+		t.Fatalf("Failed to create test app: %v", err)
+	}
+	appDir := filepath.Join(tmpDir, "testapp")
+	if err := os.Chdir(appDir); err != nil {
+		t.Fatalf("Failed to change to app dir: %v", err)
+	}
+	before := snapshot(t, appDir)
+
+	for _, args := range [][]string{
+		{"resource", "tasks", "title:string", "done:bool", "--dry-run"},
+		{"view", "dashboard", "--dry-run"},
+		{"schema", "tags", "name:string", "--dry-run"},
+		{"auth", "--dry-run"},
+	} {
+		if err := Gen(args); err != nil {
+			t.Errorf("gen %s: %v", strings.Join(args, " "), err)
+		}
+	}
+	if err := Kits([]string{"customize", "multi", "--dry-run"}); err != nil {
+		t.Errorf("kits customize --dry-run: %v", err)
+	}
+
+	after := snapshot(t, appDir)
+	for path, content := range after {
+		if old, ok := before[path]; !ok {
+			t.Errorf("--dry-run created %s", path)
+		} else if old != content {
+			t.Errorf("--dry-run modified %s", path)
+		}
+	}
+	if len(after) != len(before) {
+		t.Errorf("--dry-run removed files: %d before, %d after", len(before), len(after))
+	}
+	if dryRun || generator.SkipTools {
+		t.Error("--dry-run state was left set")
+	}
+
+	err = Gen([]string{"stack", "docker", "--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), "--dry-run is supported by") {
+		t.Errorf("gen stack --dry-run: err = %v", err)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/livetemplate/lvt/internal/envschema"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // Env handles environment variable management commands
@@ -18,7 +21,7 @@ func Env(args []string) error {
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("subcommand required\nUsage: lvt env <command> [args]\n\nCommands:\n  generate    Generate .env.example file\n  set         Set environment variable\n  unset       Unset environment variable\n  list        List environment variables\n  validate    Validate environment configuration")
+		return fmt.Errorf("subcommand required\nUsage: lvt env <command> [args]\n\nCommands:\n  generate    Generate .env.example file\n  set         Set environment variable\n  unset       Unset environment variable\n  list        List environment variables\n  validate    Validate environment configuration\n  diff        Compare the environment with the declared variables\n  secrets     Manage encrypted secrets")
 	}
 
 	subcommand := args[0]
@@ -41,6 +44,10 @@ func Env(args []string) error {
 		return EnvList(subArgs)
 	case "validate":
 		return EnvValidate(subArgs)
+	case "diff":
+		return EnvDiff(subArgs)
+	case "secrets":
+		return EnvSecrets(subArgs)
 	default:
 		return fmt.Errorf("unknown env subcommand: %s\n\nAvailable commands:\n  generate    Generate .env.example file\n  set         Set environment variable\n  unset       Unset environment variable\n  list        List environment variables\n  validate    Validate environment configuration", subcommand)
 	}
@@ -65,13 +72,13 @@ func EnvGenerate(args []string) error {
 		return fmt.Errorf("failed to write %s: %w", envFile, err)
 	}
 
-	fmt.Printf("✅ Generated %s\n", envFile)
-	fmt.Println("\nNext steps:")
-	fmt.Println("1. Copy .env.example to .env:")
-	fmt.Println("   cp .env.example .env")
-	fmt.Println("2. Fill in your actual values in .env")
-	fmt.Println("3. Never commit .env to version control")
-	fmt.Println("\nTip: Add '.env' to your .gitignore if not already there")
+	logging.Printf("✅ Generated %s\n", envFile)
+	logging.Println("\nNext steps:")
+	logging.Println("1. Copy .env.example to .env:")
+	logging.Println("   cp .env.example .env")
+	logging.Println("2. Fill in your actual values in .env")
+	logging.Println("3. Never commit .env to version control")
+	logging.Println("\nTip: Add '.env' to your .gitignore if not already there")
 
 	return nil
 }
@@ -95,11 +102,15 @@ func detectFeatures() map[string]bool {
 		features["database"] = true
 	}
 
+	// Check for CSRF protection (generated by lvt new)
+	if _, err := os.Stat("shared/csrf/csrf.go"); err == nil {
+		features["csrf"] = true
+	}
+
 	// Check for auth
 	if _, err := os.Stat("app/auth"); err == nil {
 		features["auth"] = true
 		features["sessions"] = true
-		features["csrf"] = true
 
 		// Check if email features are used
 		authFiles, _ := filepath.Glob("app/auth/*.go")
@@ -144,8 +155,9 @@ func generateEnvContent(features map[string]bool) string {
 		b.WriteString("# Server host (default: localhost, use 0.0.0.0 for all interfaces)\n")
 		b.WriteString("HOST=localhost\n")
 		b.WriteString("\n")
-		b.WriteString("# Application environment (development, staging, production)\n")
-		b.WriteString("APP_ENV=development\n")
+		b.WriteString("# Application environment (development, test, staging, production)\n")
+		b.WriteString("# The app and lvt commands use the database configured for it\n")
+		b.WriteString("LVT_ENV=development\n")
 		b.WriteString("\n")
 		b.WriteString("# Application URL (used for redirects, emails, etc.)\n")
 		b.WriteString("APP_URL=http://localhost:3000\n")
@@ -158,9 +170,12 @@ func generateEnvContent(features map[string]bool) string {
 		b.WriteString("# Database Configuration\n")
 		b.WriteString("# ============================================================================\n")
 		b.WriteString("\n")
-		b.WriteString("# SQLite database file path (relative to app root)\n")
+		b.WriteString("# SQLite database for each environment (relative to app root).\n")
+		b.WriteString("# DATABASE_PATH overrides all of them except test.\n")
+		b.WriteString("DATABASE_PATH_DEVELOPMENT=app.db\n")
+		b.WriteString("DATABASE_PATH_TEST=:memory:\n")
 		b.WriteString("# For production, use an absolute path or persistent volume mount\n")
-		b.WriteString("DATABASE_PATH=./dev.db\n")
+		b.WriteString("# DATABASE_PATH_PRODUCTION=/data/app.db\n")
 		b.WriteString("\n")
 		b.WriteString("# Database connection options\n")
 		b.WriteString("# DB_TIMEOUT=5000\n")
@@ -182,12 +197,22 @@ func generateEnvContent(features map[string]bool) string {
 		b.WriteString("# Session duration in seconds (default: 30 days)\n")
 		b.WriteString("# SESSION_DURATION=2592000\n")
 		b.WriteString("\n")
-		if features["csrf"] {
-			b.WriteString("# CSRF secret key (generate with: openssl rand -hex 32)\n")
-			b.WriteString("# MUST be set in production and kept secret!\n")
-			b.WriteString("CSRF_SECRET=change-me-to-random-32-byte-hex\n")
-			b.WriteString("\n")
-		}
+	}
+
+	// CSRF configuration
+	if features["csrf"] {
+		b.WriteString("# ============================================================================\n")
+		b.WriteString("# CSRF Protection\n")
+		b.WriteString("# ============================================================================\n")
+		b.WriteString("\n")
+		b.WriteString("# CSRF secret key, which signs form tokens (generate with: openssl rand -hex 32)\n")
+		b.WriteString("# MUST be set in production and kept secret!\n")
+		b.WriteString("CSRF_SECRET=change-me-to-random-32-byte-hex\n")
+		b.WriteString("\n")
+		b.WriteString("# Other origins allowed to post to the app, and path prefixes left unchecked\n")
+		b.WriteString("# CSRF_TRUSTED_ORIGINS=https://admin.example.com\n")
+		b.WriteString("# CSRF_EXEMPT=/api/\n")
+		b.WriteString("\n")
 	}
 
 	// Email configuration
@@ -366,9 +391,9 @@ func EnvSet(args []string) error {
 	ensureGitignore(".env")
 
 	if oldValue == "" {
-		fmt.Printf("✅ Set %s=%s\n", key, maskValue(key, value))
+		logging.Printf("✅ Set %s=%s\n", key, maskValue(key, value))
 	} else {
-		fmt.Printf("✅ Updated %s (old: %s, new: %s)\n", key, maskValue(key, oldValue), maskValue(key, value))
+		logging.Printf("✅ Updated %s (old: %s, new: %s)\n", key, maskValue(key, oldValue), maskValue(key, value))
 	}
 
 	return nil
@@ -410,7 +435,7 @@ func EnvUnset(args []string) error {
 		return fmt.Errorf("failed to write %s: %w", envFile, err)
 	}
 
-	fmt.Printf("✅ Unset %s\n", key)
+	logging.Printf("✅ Unset %s\n", key)
 
 	return nil
 }
@@ -513,19 +538,43 @@ func EnvValidate(args []string) error {
 			fmt.Println("Usage: lvt env validate [flags]")
 			fmt.Println("\nFlags:")
 			fmt.Println("  --strict    Also validate values (test connections, etc.)")
+			fmt.Println("\nApps with " + envschema.File + " are checked against the variables")
+			fmt.Println("it declares, as set in the shell environment or .env.")
 			return nil
 		}
 	}
 
+	// Apps that declare their variables are checked against the declarations
+	if _, err := os.Stat(envschema.File); err == nil {
+		entries, err := compareEnvSchema()
+		if err != nil {
+			return err
+		}
+		values := map[string]string{}
+		for _, e := range entries {
+			values[e.Name] = e.Value
+		}
+		if err := validateEnvSchema(entries); err != nil || !strict {
+			return err
+		}
+		if err := validateValues(values, detectFeatures()); err != nil {
+			return fmt.Errorf("strict validation failed: %w", err)
+		}
+		if !JSONOutput() {
+			logging.Println("✅ All values validated successfully")
+		}
+		return nil
+	}
+
 	// Load .env file
 	envFile := ".env"
 	envVars, err := parseEnvFile(envFile)
 	if err != nil {
 		if os.IsNotExist(err) {
-			fmt.Println("❌ No .env file found")
-			fmt.Println("\nCreate one with:")
-			fmt.Println("  lvt env generate")
-			fmt.Println("  cp .env.example .env")
+			logging.Println("❌ No .env file found")
+			logging.Println("\nCreate one with:")
+			logging.Println("  lvt env generate")
+			logging.Println("  cp .env.example .env")
 			return fmt.Errorf("validation failed")
 		}
 		return fmt.Errorf("failed to parse %s: %w", envFile, err)
@@ -541,6 +590,10 @@ func EnvValidate(args []string) error {
 
 	for _, key := range requiredVars {
 		value, exists := envVars[key]
+		if key == "LVT_ENV" && value == "" {
+			// Apps generated before LVT_ENV set APP_ENV
+			value, exists = envVars["APP_ENV"]
+		}
 		if !exists || value == "" {
 			missing = append(missing, key)
 			continue
@@ -554,42 +607,42 @@ func EnvValidate(args []string) error {
 
 	// Report results
 	if len(missing) == 0 && len(invalid) == 0 {
-		fmt.Println("✅ All required environment variables are set")
+		logging.Println("✅ All required environment variables are set")
 
 		if strict {
-			fmt.Println("\n🔍 Running strict validation...")
+			logging.Println("\n🔍 Running strict validation...")
 			if err := validateValues(envVars, features); err != nil {
-				fmt.Printf("❌ Validation failed: %v\n", err)
+				logging.Printf("❌ Validation failed: %v\n", err)
 				return fmt.Errorf("validation failed")
 			}
-			fmt.Println("✅ All values validated successfully")
+			logging.Println("✅ All values validated successfully")
 		}
 
 		return nil
 	}
 
 	// Report errors
-	fmt.Println("❌ Environment validation failed")
+	logging.Println("❌ Environment validation failed")
 
 	if len(missing) > 0 {
-		fmt.Println("Missing required variables:")
+		logging.Println("Missing required variables:")
 		for _, key := range missing {
 			reason := getVarReason(key, features)
-			fmt.Printf("  - %s (%s)\n", key, reason)
+			logging.Printf("  - %s (%s)\n", key, reason)
 		}
-		fmt.Println()
+		logging.Println()
 	}
 
 	if len(invalid) > 0 {
-		fmt.Println("Invalid placeholder values (need to be replaced):")
+		logging.Println("Invalid placeholder values (need to be replaced):")
 		for _, key := range invalid {
 			value := envVars[key]
-			fmt.Printf("  - %s=%s\n", key, value)
+			logging.Printf("  - %s=%s\n", key, value)
 		}
-		fmt.Println()
+		logging.Println()
 	}
 
-	fmt.Println("Fix these issues, then run 'lvt env validate' again")
+	logging.Println("Fix these issues, then run 'lvt env validate' again")
 
 	return fmt.Errorf("validation failed")
 }
@@ -720,13 +773,9 @@ func isPlaceholderValue(value string) bool {
 func getRequiredVars(features map[string]bool) []string {
 	var required []string
 
-	// Server (always)
-	required = append(required, "APP_ENV")
-
-	// Database
-	if features["database"] {
-		required = append(required, "DATABASE_PATH")
-	}
+	// Server (always). The database defaults per environment, so no
+	// database variable is required.
+	required = append(required, "LVT_ENV")
 
 	// Auth
 	if features["auth"] {
@@ -750,10 +799,9 @@ func getRequiredVars(features map[string]bool) []string {
 // getVarReason returns a human-readable reason why a variable is required
 func getVarReason(key string, features map[string]bool) string {
 	reasons := map[string]string{
-		"APP_ENV":         "application environment",
-		"DATABASE_PATH":   "database configuration",
+		"LVT_ENV":         "application environment (APP_ENV also works)",
 		"SESSION_SECRET":  "session security (auth enabled)",
-		"CSRF_SECRET":     "CSRF protection (auth enabled)",
+		"CSRF_SECRET":     "CSRF token signing (shared/csrf)",
 		"EMAIL_PROVIDER":  "email functionality (auth with email features)",
 		"SMTP_HOST":       "SMTP email sending",
 		"SMTP_PORT":       "SMTP email sending",
@@ -771,11 +819,11 @@ func getVarReason(key string, features map[string]bool) string {
 
 // validateValues performs strict validation of environment variable values
 func validateValues(envVars map[string]string, features map[string]bool) error {
-	// Validate APP_ENV
-	if appEnv, ok := envVars["APP_ENV"]; ok {
-		validEnvs := []string{"development", "staging", "production"}
-		if !contains(validEnvs, appEnv) {
-			return fmt.Errorf("APP_ENV must be one of: %s", strings.Join(validEnvs, ", "))
+	// Validate LVT_ENV and APP_ENV
+	validEnvs := []string{"development", "test", "staging", "production"}
+	for _, key := range []string{"LVT_ENV", "APP_ENV"} {
+		if appEnv, ok := envVars[key]; ok && !contains(validEnvs, appEnv) {
+			return fmt.Errorf("%s must be one of: %s", key, strings.Join(validEnvs, ", "))
 		}
 	}
 
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/envschema"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/pkg/secrets"
+)
+
+// EnvDiff compares the environment, the encrypted secrets and .env with
+// the variables the app declares in shared/config.
+func EnvDiff(args []string) error {
+	showValues := false
+	for _, arg := range args {
+		switch arg {
+		case "--show-values":
+			showValues = true
+		case "-h", "--help":
+			fmt.Println("Usage: lvt env diff [flags]")
+			fmt.Println("\nCompares the shell environment and .env with the variables declared")
+			fmt.Println("in " + envschema.File + ".")
+			fmt.Println("\nFlags:")
+			fmt.Println("  --show-values   Show actual values (secrets are masked by default)")
+			return nil
+		default:
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	entries, err := compareEnvSchema()
+	if err != nil {
+		return err
+	}
+	if !showValues {
+		maskEntries(entries)
+	}
+
+	if JSONOutput() {
+		return printJSON(struct {
+			Variables []envschema.Entry `json:"variables"`
+		}{entries})
+	}
+
+	width := len("VARIABLE")
+	for _, e := range entries {
+		width = max(width, len(e.Name))
+	}
+	fmt.Printf("%-*s  %-10s  %-8s  %s\n", width, "VARIABLE", "STATUS", "SOURCE", "VALUE")
+	for _, e := range entries {
+		source := e.Source
+		if e.Overrides {
+			source = "env*"
+		}
+		value := e.Value
+		if e.Problem != "" {
+			value = strings.TrimSpace(value + " (" + e.Problem + ")")
+		}
+		line := fmt.Sprintf("%-*s  %-10s  %-8s  %s", width, e.Name, e.Status, source, value)
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	for _, e := range entries {
+		if e.Overrides {
+			fmt.Println("\n* set in the environment, overriding .env")
+			break
+		}
+	}
+	return nil
+}
+
+// validateEnvSchema is `lvt env validate` for apps that declare their
+// variables in shared/config: every required variable set and every value
+// of its declared type.
+func validateEnvSchema(entries []envschema.Entry) error {
+	maskEntries(entries)
+	var failed []envschema.Entry
+	for _, e := range entries {
+		if e.Failed() {
+			failed = append(failed, e)
+		}
+	}
+
+	if JSONOutput() {
+		if err := printJSON(struct {
+			Valid     bool              `json:"valid"`
+			Variables []envschema.Entry `json:"variables"`
+		}{len(failed) == 0, entries}); err != nil {
+			return err
+		}
+	} else if len(failed) == 0 {
+		logging.Printf("✅ Environment matches %s (%d variables)\n", envschema.File, countDeclared(entries))
+	} else {
+		logging.Printf("❌ Environment does not match %s\n", envschema.File)
+		for _, e := range failed {
+			if e.Status == envschema.StatusMissing {
+				logging.Printf("  - %s: %s (%s)\n", e.Name, e.Problem, e.Var.Description)
+			} else {
+				logging.Printf("  - %s=%s: %s (from %s)\n", e.Name, e.Value, e.Problem, e.Source)
+			}
+		}
+		logging.Println("\nFix these issues, then run 'lvt env validate' again")
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}
+
+// compareEnvSchema compares the app's declared variables with the process
+// environment and .env.
+func compareEnvSchema() ([]envschema.Entry, error) {
+	vars, err := envschema.Load(".")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s not found; apps generated before lvt declared their variables have no schema", envschema.File)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config schema: %w", err)
+	}
+
+	dotenv, err := parseEnvFile(".env")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to parse .env: %w", err)
+	}
+	env := map[string]string{}
+	secretValues := map[string]string{}
+	store, err := secrets.Load(".")
+	if err != nil {
+		logging.Warn("encrypted secrets not checked", "error", err)
+		store = &secrets.Store{}
+	}
+	for _, v := range vars {
+		if value, ok := os.LookupEnv(v.Name); ok {
+			env[v.Name] = value
+		}
+		// The app sets top-level secrets in its environment
+		if value, ok := store.Lookup(v.Name); ok {
+			secretValues[v.Name] = value
+		}
+	}
+	return envschema.Compare(vars, env, secretValues, dotenv), nil
+}
+
+// maskEntries masks the values of secrets and of variables whose names
+// suggest one.
+func maskEntries(entries []envschema.Entry) {
+	for i := range entries {
+		e := &entries[i]
+		mask := func(value string) string {
+			if value == "" {
+				return ""
+			}
+			if e.Source == "secrets" || e.Var != nil && e.Var.Secret {
+				return "****"
+			}
+			return maskValue(e.Name, value)
+		}
+		e.Value, e.EnvValue, e.DotEnv = mask(e.Value), mask(e.EnvValue), mask(e.DotEnv)
+	}
+}
+
+func countDeclared(entries []envschema.Entry) int {
+	n := 0
+	for _, e := range entries {
+		if e.Var != nil {
+			n++
+		}
+	}
+	return n
+}
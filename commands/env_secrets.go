@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/pkg/secrets"
+)
+
+// EnvSecrets manages the app's encrypted secrets.
+func EnvSecrets(args []string) error {
+	if len(args) < 1 || args[0] == "-h" || args[0] == "--help" {
+		printEnvSecretsHelp()
+		if len(args) < 1 {
+			return fmt.Errorf("subcommand required")
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "edit":
+		return EnvSecretsEdit(args[1:])
+	case "show":
+		return EnvSecretsShow(args[1:])
+	default:
+		return fmt.Errorf("unknown env secrets subcommand: %s\n\nAvailable commands:\n  edit    Edit the encrypted secrets in $EDITOR\n  show    Print the decrypted secrets", args[0])
+	}
+}
+
+func printEnvSecretsHelp() {
+	fmt.Println("lvt env secrets - Manage encrypted secrets")
+	fmt.Println()
+	fmt.Println("Usage: lvt env secrets <command>")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  edit          Edit the secrets in $EDITOR and store them encrypted")
+	fmt.Println("  show          Print the decrypted secrets")
+	fmt.Println("  show --keys   Print only the names of the secrets")
+	fmt.Println()
+	fmt.Printf("Secrets are a YAML document stored encrypted in %s, which is\n", secrets.File)
+	fmt.Printf("safe to commit. The master key is read from %s or %s;\n", secrets.KeyEnv, secrets.KeyFile)
+	fmt.Println("the first edit creates the key file and adds it to .gitignore.")
+	fmt.Println()
+	fmt.Println("Apps load the secrets at startup through shared/secrets. Top-level")
+	fmt.Println("secrets named like environment variables (SESSION_SECRET) are set in")
+	fmt.Println("the environment unless it already sets them.")
+}
+
+// secretsTemplate is the document the first edit starts from.
+const secretsTemplate = `# Secrets for this app, stored encrypted in ` + secrets.File + `.
+# Edit with: lvt env secrets edit
+#
+# Top-level keys named like environment variables are set in the app's
+# environment unless it already sets them:
+#
+# SESSION_SECRET: generate with openssl rand -hex 32
+#
+# Other secrets are read with secrets.Get("stripe.api_key"):
+#
+# stripe:
+#   api_key: sk_live_...
+`
+
+// EnvSecretsEdit decrypts the secrets into a temporary file, opens it in
+// $VISUAL or $EDITOR, and stores the result encrypted.
+func EnvSecretsEdit(args []string) error {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			printEnvSecretsHelp()
+			return nil
+		}
+		return fmt.Errorf("unknown flag: %s", arg)
+	}
+	if _, err := os.Stat("go.mod"); os.IsNotExist(err) {
+		return fmt.Errorf("not in a LiveTemplate app directory (go.mod not found)")
+	}
+
+	key, err := secrets.MasterKey(".")
+	if err != nil {
+		return fmt.Errorf("failed to read master key: %w", err)
+	}
+	encrypted, err := os.ReadFile(secrets.File)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", secrets.File, err)
+	}
+
+	plaintext := []byte(secretsTemplate)
+	switch {
+	case exists && key == "":
+		return secrets.ErrNoKey
+	case exists:
+		if plaintext, err = secrets.Decrypt(key, encrypted); err != nil {
+			return fmt.Errorf("%s: %w", secrets.File, err)
+		}
+	case key == "":
+		if key, err = createMasterKey(); err != nil {
+			return err
+		}
+	}
+
+	edited, err := editInEditor(plaintext)
+	if err != nil {
+		return err
+	}
+	if exists && bytes.Equal(edited, plaintext) {
+		logging.Println("No changes")
+		return nil
+	}
+	if _, err := secrets.Parse(edited); err != nil {
+		return fmt.Errorf("secrets not saved: %w", err)
+	}
+
+	data, err := secrets.Encrypt(key, edited)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(secrets.File), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(secrets.File), err)
+	}
+	if err := os.WriteFile(secrets.File, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", secrets.File, err)
+	}
+	logging.Printf("✅ Saved %s\n", secrets.File)
+
+	// Apps load the secrets through shared/secrets
+	if projectConfig, err := config.LoadProjectConfig("."); err == nil && projectConfig.Module != "" {
+		generated, err := generator.GenerateSecrets(".", projectConfig.Module)
+		if err != nil {
+			return err
+		}
+		if generated {
+			logging.Printf("✅ Generated %s, loaded at the start of main\n", generator.SecretsPackage)
+		}
+	}
+	return nil
+}
+
+// createMasterKey writes a new master key to the key file and keeps it
+// out of version control.
+func createMasterKey() (string, error) {
+	key, err := secrets.GenerateKey()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(secrets.KeyFile), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(secrets.KeyFile), err)
+	}
+	if err := os.WriteFile(secrets.KeyFile, []byte(key+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", secrets.KeyFile, err)
+	}
+	if err := ensureGitignore(secrets.KeyFile); err != nil {
+		logging.Warn("could not add "+secrets.KeyFile+" to .gitignore", "error", err)
+	}
+	logging.Printf("🔑 Created %s (git-ignored). Keep it safe: the secrets cannot be\n", secrets.KeyFile)
+	logging.Printf("   decrypted without it. In production, set %s to its contents.\n", secrets.KeyEnv)
+	return key, nil
+}
+
+// editInEditor opens content in the user's editor and returns the edited
+// content. The temporary file is only readable by the user and removed
+// afterwards.
+func editInEditor(content []byte) ([]byte, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := os.CreateTemp("", "lvt-secrets-*.yml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], f.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %q failed, secrets not saved: %w", editor, err)
+	}
+	return os.ReadFile(f.Name())
+}
+
+// EnvSecretsShow prints the decrypted secrets, or with --keys only their
+// names.
+func EnvSecretsShow(args []string) error {
+	keysOnly := false
+	for _, arg := range args {
+		switch arg {
+		case "--keys":
+			keysOnly = true
+		case "-h", "--help":
+			printEnvSecretsHelp()
+			return nil
+		default:
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	encrypted, err := os.ReadFile(secrets.File)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no secrets yet; create them with 'lvt env secrets edit'")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", secrets.File, err)
+	}
+	key, err := secrets.MasterKey(".")
+	if err != nil {
+		return fmt.Errorf("failed to read master key: %w", err)
+	}
+	if key == "" {
+		return secrets.ErrNoKey
+	}
+	plaintext, err := secrets.Decrypt(key, encrypted)
+	if err != nil {
+		return fmt.Errorf("%s: %w", secrets.File, err)
+	}
+	if !keysOnly {
+		fmt.Print(string(plaintext))
+		return nil
+	}
+
+	store, err := secrets.Parse(plaintext)
+	if err != nil {
+		return err
+	}
+	if JSONOutput() {
+		keys := store.Keys()
+		if keys == nil {
+			keys = []string{}
+		}
+		return printJSON(struct {
+			Keys []string `json:"keys"`
+		}{keys})
+	}
+	for _, k := range store.Keys() {
+		fmt.Println(k)
+	}
+	return nil
+}
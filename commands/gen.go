@@ -2,16 +2,20 @@ package commands
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/generator"
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/parser"
+	"github.com/livetemplate/lvt/internal/plugins"
 	"github.com/livetemplate/lvt/internal/telemetry"
 	"github.com/livetemplate/lvt/internal/validation"
 	"github.com/livetemplate/lvt/internal/validator"
@@ -36,30 +40,124 @@ func Gen(args []string) error {
 		return err
 	}
 
-	switch subcommand {
-	case "resource":
-		return GenResource(args[1:])
-	case "view":
-		return GenView(args[1:])
-	case "schema":
-		return GenSchema(args[1:])
-	case "auth":
-		return Auth(args[1:])
-	case "stack":
-		return GenStack(args[1:])
-	case "queue":
-		return GenQueue(args[1:])
-	case "job":
-		return GenJob(args[1:])
-	case "authz":
-		return Authz(args[1:])
-	case "api":
-		return GenAPI(args[1:])
-	case "task":
-		return GenTask(args[1:])
-	default:
-		return fmt.Errorf("unknown subcommand: %s\n\nAvailable subcommands:\n  resource  Generate full CRUD resource with database\n  view      Generate view-only handler (no database)\n  schema    Generate database schema only\n  auth      Generate authentication system\n  authz     Generate role-based authorization\n  api       Generate JSON API endpoints\n  stack     Generate deployment stack configuration\n  queue     Set up background job processing (River)\n  job       Scaffold a new background job handler\n  task      Scaffold a new scheduled task\n\nRun 'lvt gen' for interactive mode", subcommand)
+	run, ok := genSubcommands[subcommand]
+	if !ok {
+		return genPlugin(subcommand, args[1:])
 	}
+
+	if term.IsTerminal(os.Stdin.Fd()) {
+		generator.ConfirmSectionReplace = confirmSectionReplace(os.Stdin, os.Stdout)
+	}
+
+	if rest, preview := extractDryRun(args[1:]); preview {
+		if !dryRunSubcommands[subcommand] {
+			return fmt.Errorf("--dry-run is supported by lvt gen resource, view, schema and auth, not %s", subcommand)
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		return previewChanges("gen "+subcommand, dir, "", func(copy string) error {
+			return inDir(copy, func() error { return run(rest) })
+		})
+	}
+
+	// Plugins' pre-gen and post-gen hooks run around the built-in generators
+	plugs, env := loadPlugins()
+	if _, err := plugins.RunHooks(plugs, env, plugins.HookPreGen, subcommand, args[1:]); err != nil {
+		return err
+	}
+	if err := run(args[1:]); err != nil {
+		return err
+	}
+	written, err := plugins.RunHooks(plugs, env, plugins.HookPostGen, subcommand, args[1:])
+	if len(written) > 0 {
+		logging.Println()
+		logging.Println("Added by plugins:")
+		for _, path := range written {
+			logging.Printf("  %s\n", path)
+		}
+	}
+	return err
+}
+
+// genSubcommands are the built-in `lvt gen` subcommands. Any other name is
+// looked up among the project's plugins.
+var genSubcommands = map[string]func([]string) error{
+	"resource":         GenResource,
+	"view":             GenView,
+	"wizard":           GenWizard,
+	"schema":           GenSchema,
+	"auth":             Auth,
+	"stack":            GenStack,
+	"deploy":           GenDeploy,
+	"queue":            GenQueue,
+	"job":              GenJob,
+	"authz":            Authz,
+	"audit":            Audit,
+	"api":              GenAPI,
+	"i18n":             GenI18n,
+	"task":             GenTask,
+	"metrics":          GenMetrics,
+	"otel":             GenOtel,
+	"cache":            GenCache,
+	"notifications":    GenNotifications,
+	"sessions":         GenSessions,
+	"offline":          GenOffline,
+	"sse":              GenSSE,
+	"pubsub":           GenPubSub,
+	"compression":      GenCompression,
+	"prerender":        GenPrerender,
+	"seo":              GenSEO,
+	"security-headers": GenSecurityHeaders,
+}
+
+// loadPlugins discovers the plugins of the project in the current
+// directory, and the environment they run in.
+func loadPlugins() ([]*plugins.Plugin, plugins.Env) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, plugins.Env{}
+	}
+	env := plugins.Env{ProjectDir: dir}
+	env.Module, _ = getModuleName()
+	if cfg, err := config.LoadProjectConfig(dir); err == nil {
+		env.Kit = cfg.GetKit()
+	}
+	plugs, err := plugins.Discover(dir)
+	if err != nil {
+		logging.Warn("failed to load plugins", "error", err)
+	}
+	return plugs, env
+}
+
+// pluginGenCommands returns the subcommands the plugins add, leaving out
+// those the built-in subcommands of the same name hide.
+func pluginGenCommands(plugs []*plugins.Plugin) []plugins.Command {
+	var cmds []plugins.Command
+	for _, c := range plugins.Commands(plugs) {
+		if _, builtin := genSubcommands[c.Name]; !builtin {
+			cmds = append(cmds, c)
+		}
+	}
+	return cmds
+}
+
+// genPlugin runs a plugin's `lvt gen` subcommand.
+func genPlugin(subcommand string, args []string) error {
+	plugs, env := loadPlugins()
+	if p, ok := plugins.FindCommand(plugs, subcommand); ok {
+		return plugins.RunCommand(p, env, subcommand, args)
+	}
+
+	msg := fmt.Sprintf("unknown subcommand: %s\n\nAvailable subcommands:\n  resource  Generate full CRUD resource with database\n  view      Generate view-only handler (no database)\n  wizard    Generate a multi-step form saved to the database\n  schema    Generate database schema only\n  auth      Generate authentication system\n  authz     Generate role-based authorization\n  audit     Generate audit trail of record changes\n  api       Generate JSON API endpoints\n  i18n      Set up translations (T helper, locale files, middleware)\n  stack     Generate deployment stack configuration\n  deploy    Generate deployment for Fly.io, Railway or Render\n  queue     Set up background job processing (River)\n  job       Scaffold a new background job handler\n  task      Scaffold a new scheduled task\n  metrics   Set up Prometheus metrics\n  otel      Set up OpenTelemetry tracing\n  cache     Set up query result caching\n  notifications  Set up toasts shown with lvt.Notify\n  sessions  Keep live sessions across restarts and reconnects\n  offline   Queue actions while disconnected and replay them\n  sse       Fall back to server-sent events where WebSockets are blocked\n  pubsub    Relay presence and broadcasts between instances\n  compression  Deflate updates sent over the WebSocket\n  prerender  Serve crawlers and ?static=1 static renders\n  seo       Serve sitemap.xml and robots.txt and add meta tags\n  security-headers  Send a nonce-based Content-Security-Policy and security headers", subcommand)
+	if cmds := pluginGenCommands(plugs); len(cmds) > 0 {
+		msg += "\n\nPlugin subcommands:"
+		for _, c := range cmds {
+			msg += fmt.Sprintf("\n  %-9s %s", c.Name, c.Description)
+		}
+	}
+	return fmt.Errorf("%s\n\nRun 'lvt gen' for interactive mode", msg)
 }
 
 func interactiveGen() error {
@@ -68,11 +166,34 @@ func interactiveGen() error {
 	fmt.Println("Subcommands:")
 	fmt.Println("  resource <name> <field:type>...       Generate full CRUD with database")
 	fmt.Println("  view <name>                           Generate view-only handler (no database)")
+	fmt.Println("  wizard <name> <step:field,...>...     Generate a multi-step form")
 	fmt.Println("  schema <table> <field:type>...        Generate database schema only")
 	fmt.Println("  auth [StructName] [table_name]        Generate authentication system")
 	fmt.Println("  stack <target>                        Generate deployment stack configuration")
+	fmt.Println("  deploy --target <fly|railway|render>  Generate a one-command production deployment")
 	fmt.Println("  queue                                 Set up background job processing (River)")
 	fmt.Println("  job <name>                            Scaffold a new background job handler")
+	fmt.Println("  audit                                 Record create/update/delete changes")
+	fmt.Println("  i18n [--locales en,fr]                Set up translations")
+	fmt.Println("  metrics [--path /metrics]             Set up Prometheus metrics")
+	fmt.Println("  otel                                  Set up OpenTelemetry tracing")
+	fmt.Println("  cache [--redis]                       Set up query result caching")
+	fmt.Println("  notifications [--dismiss 5s]          Set up toasts shown with lvt.Notify")
+	fmt.Println("  sessions [--ttl 24h]                  Keep live sessions across restarts and reconnects")
+	fmt.Println("  offline                               Queue actions while disconnected and replay them")
+	fmt.Println("  sse                                   Fall back to server-sent events where WebSockets are blocked")
+	fmt.Println("  compression                           Deflate updates sent over the WebSocket")
+	fmt.Println("  prerender                             Serve crawlers and ?static=1 static renders")
+	fmt.Println("  seo                                   Serve sitemap.xml and robots.txt and add meta tags")
+	fmt.Println("  security-headers                      Send a nonce-based Content-Security-Policy and security headers")
+	plugs, _ := loadPlugins()
+	if cmds := pluginGenCommands(plugs); len(cmds) > 0 {
+		fmt.Println()
+		fmt.Println("Plugin subcommands:")
+		for _, c := range cmds {
+			fmt.Printf("  %-37s %s\n", strings.TrimSpace(c.Name+" "+c.Usage), c.Description)
+		}
+	}
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  lvt gen resource posts title content:text published:bool")
@@ -122,6 +243,22 @@ func GenResource(args []string) error {
 	parentResource := ""
 	withAuthz := false
 	searchable := false
+	emitEvents := false
+	withCache := false
+	withRenderCache := false
+	withFilters := false
+	withSavedViews := false
+	sortable := false
+	withUndo := false
+	withPresence := false
+	withConflicts := false
+	withPolicy := false
+	withHooks := false
+	idType := projectConfig.IDType // default from .lvtrc
+	actionsSpec := ""
+	readOnly := false
+	fromTable := ""
+	var indexes []parser.Index
 	var filteredArgs []string
 	for i := 0; i < len(args); i++ {
 		if args[i] == "--pagination" && i+1 < len(args) {
@@ -144,17 +281,114 @@ func GenResource(args []string) error {
 			withAuthz = true
 		} else if args[i] == "--searchable" {
 			searchable = true
+		} else if args[i] == "--emit-events" {
+			emitEvents = true
+		} else if args[i] == "--cache" {
+			withCache = true
+		} else if args[i] == "--render-cache" {
+			withRenderCache = true
+		} else if args[i] == "--filters" {
+			withFilters = true
+		} else if args[i] == "--saved-views" {
+			withSavedViews = true
+		} else if args[i] == "--sortable" {
+			sortable = true
+		} else if args[i] == "--undo" {
+			withUndo = true
+		} else if args[i] == "--presence" {
+			withPresence = true
+		} else if args[i] == "--conflicts" {
+			withConflicts = true
+		} else if args[i] == "--policy" {
+			withPolicy = true
+		} else if args[i] == "--hooks" {
+			withHooks = true
+		} else if args[i] == "--id" && i+1 < len(args) {
+			idType = strings.ToLower(args[i+1])
+			i++ // skip next arg
+		} else if args[i] == "--actions" && i+1 < len(args) {
+			actionsSpec = args[i+1]
+			i++ // skip next arg
+		} else if args[i] == "--readonly" {
+			readOnly = true
+		} else if args[i] == "--from-table" && i+1 < len(args) {
+			fromTable = args[i+1]
+			i++ // skip next arg
+		} else if args[i] == "--index" && i+1 < len(args) {
+			index, err := parser.ParseIndex(args[i+1])
+			if err != nil {
+				return err
+			}
+			indexes = append(indexes, index)
+			i++ // skip next arg
 		} else {
 			filteredArgs = append(filteredArgs, args[i])
 		}
 	}
 
+	// With --from-table the resource is named after the table unless a name is given
+	if fromTable != "" && len(filteredArgs) == 0 {
+		filteredArgs = []string{fromTable}
+	}
+
 	if len(filteredArgs) < 1 {
 		return fmt.Errorf("resource name required")
 	}
 
 	resourceName := filteredArgs[0]
 
+	// Resolve --actions / --readonly
+	actions := generator.AllActions()
+	if readOnly && actionsSpec != "" {
+		return fmt.Errorf("--readonly and --actions cannot be used together")
+	}
+	if readOnly {
+		actions = generator.ReadOnlyActions()
+	} else if actionsSpec != "" {
+		if actions, err = generator.ParseActions(actionsSpec); err != nil {
+			return err
+		}
+	}
+	if !actions.IsFull() && parentResource != "" {
+		return fmt.Errorf("--actions and --readonly cannot be combined with --parent")
+	}
+	if emitEvents && parentResource != "" {
+		return fmt.Errorf("--emit-events cannot be combined with --parent")
+	}
+	if withCache && parentResource != "" {
+		return fmt.Errorf("--cache cannot be combined with --parent")
+	}
+	if withRenderCache && parentResource != "" {
+		return fmt.Errorf("--render-cache cannot be combined with --parent")
+	}
+	if withFilters && parentResource != "" {
+		return fmt.Errorf("--filters cannot be combined with --parent")
+	}
+	if withSavedViews && parentResource != "" {
+		return fmt.Errorf("--saved-views cannot be combined with --parent")
+	}
+	if sortable && parentResource != "" {
+		return fmt.Errorf("--sortable cannot be combined with --parent")
+	}
+	if withUndo && parentResource != "" {
+		return fmt.Errorf("--undo cannot be combined with --parent")
+	}
+	if withPresence && parentResource != "" {
+		return fmt.Errorf("--presence cannot be combined with --parent")
+	}
+	if withConflicts && parentResource != "" {
+		return fmt.Errorf("--conflicts cannot be combined with --parent")
+	}
+	if withPolicy && parentResource != "" {
+		return fmt.Errorf("--policy cannot be combined with --parent")
+	}
+	if withHooks && parentResource != "" {
+		return fmt.Errorf("--hooks cannot be combined with --parent")
+	}
+	if err := generator.ValidateIDType(idType); err != nil {
+		return err
+	}
+
 	// Validate --with-authz prerequisites
 	if withAuthz {
 		if _, err := os.Stat(filepath.Join(basePath, "app", "auth")); os.IsNotExist(err) {
@@ -169,14 +403,28 @@ func GenResource(args []string) error {
 
 	fieldArgs := filteredArgs[1:]
 
+	// Introspect the existing table; its columns become the field definitions
+	var tableInfo *generator.TableInfo
+	if fromTable != "" {
+		if len(fieldArgs) > 0 {
+			return fmt.Errorf("--from-table reads fields from the table; remove the field arguments")
+		}
+		if tableInfo, err = introspectTable(fromTable); err != nil {
+			return err
+		}
+		if fieldArgs, err = tableInfo.FieldSpecs(); err != nil {
+			return err
+		}
+	}
+
 	if len(fieldArgs) == 0 {
 		return fmt.Errorf("at least one field required (format: name:type)")
 	}
 
 	// Validate pagination mode
-	validPaginationModes := map[string]bool{"infinite": true, "load-more": true, "prev-next": true, "numbers": true}
+	validPaginationModes := map[string]bool{"infinite": true, "load-more": true, "prev-next": true, "numbers": true, "cursor": true}
 	if !validPaginationModes[paginationMode] {
-		return fmt.Errorf("invalid pagination mode: %s (valid: infinite, load-more, prev-next, numbers)", paginationMode)
+		return fmt.Errorf("invalid pagination mode: %s (valid: infinite, load-more, prev-next, numbers, cursor)", paginationMode)
 	}
 
 	// Validate edit mode
@@ -227,6 +475,8 @@ func GenResource(args []string) error {
 		"kit":             kit,
 		"pagination_mode": paginationMode,
 		"edit_mode":       editMode,
+		"actions":         actions.String(),
+		"from_table":      fromTable != "",
 	})
 	capture.SetKit(kit) // also sets the dedicated Kit column for SQL queries; inputs has it for context
 
@@ -235,22 +485,31 @@ func GenResource(args []string) error {
 	compUsage := generator.ComputeComponentUsage(resourceData)
 	capture.RecordComponentsUsed(telemetry.ComponentsFromUsage(compUsage))
 
-	fmt.Printf("Generating CRUD resource: %s\n", resourceName)
-	fmt.Printf("Kit: %s\n", kit)
-	fmt.Printf("CSS Framework: %s\n", cssFramework)
-	fmt.Printf("Pagination: %s (page size: %d)\n", paginationMode, pageSize)
-	fmt.Printf("Edit Mode: %s\n", editMode)
-	fmt.Printf("Fields: ")
+	logging.Printf("Generating CRUD resource: %s\n", resourceName)
+	logging.Printf("Kit: %s\n", kit)
+	logging.Printf("CSS Framework: %s\n", cssFramework)
+	logging.Printf("Pagination: %s (page size: %d)\n", paginationMode, pageSize)
+	logging.Printf("Edit Mode: %s\n", editMode)
+	if !actions.IsFull() {
+		logging.Printf("Actions: %s\n", actions)
+	}
+	if tableInfo != nil {
+		logging.Printf("From table: %s (no migration)\n", tableInfo.Name)
+	}
+	if idType != "" {
+		logging.Printf("IDs: %s\n", idType)
+	}
+	logging.Printf("Fields: ")
 	for i, f := range fields {
 		if i > 0 {
-			fmt.Printf(", ")
+			logging.Printf(", ")
 		}
-		fmt.Printf("%s:%s", f.Name, f.Type)
+		logging.Printf("%s:%s", f.Name, f.Type)
 	}
-	fmt.Println()
+	logging.Println()
 
 	styles := projectConfig.Styles
-	if err := generator.GenerateResource(basePath, moduleName, resourceName, fields, kit, cssFramework, styles, paginationMode, pageSize, editMode, parentResource, withAuthz, searchable); err != nil {
+	if err := generator.GenerateResource(basePath, moduleName, resourceName, fields, kit, cssFramework, styles, paginationMode, pageSize, editMode, parentResource, withAuthz, searchable, generator.ResourceOptions{Actions: &actions, FromTable: tableInfo, EmitEvents: emitEvents, Cache: withCache, RenderCache: withRenderCache, Filters: withFilters, SavedViews: withSavedViews, Sortable: sortable, Undo: withUndo, Presence: withPresence, Conflicts: withConflicts, Policy: withPolicy, Hooks: withHooks, IDType: idType, Indexes: indexes}); err != nil {
 		capture.RecordError(telemetry.GenerationError{Phase: "generation", Message: err.Error()})
 		capture.AttributeComponentErrors() // attribute errors on failure path
 		capture.Complete(false, "")
@@ -275,51 +534,134 @@ func GenResource(args []string) error {
 	resourceNameLower := strings.ToLower(resourceName)
 
 	if validationErr != nil {
-		fmt.Println()
-		fmt.Println("⚠️  Resource generated, but validation found issues.")
+		logging.Println()
+		logging.Println("⚠️  Resource generated, but validation found issues.")
 	} else {
-		fmt.Println()
-		fmt.Println("✅ Resource generated successfully!")
-	}
-	fmt.Println()
-	fmt.Println("Files created:")
-	fmt.Printf("  app/%s/%s.go\n", resourceNameLower, resourceNameLower)
-	fmt.Printf("  app/%s/%s.tmpl\n", resourceNameLower, resourceNameLower)
-	fmt.Println()
-	fmt.Println("Files updated:")
-	fmt.Println("  database/schema.sql")
-	fmt.Println("  database/queries.sql")
-	fmt.Println()
+		logging.Println()
+		logging.Println("✅ Resource generated successfully!")
+	}
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Printf("  app/%s/%s.go\n", resourceNameLower, resourceNameLower)
+	logging.Printf("  app/%s/%s.tmpl\n", resourceNameLower, resourceNameLower)
+	logging.Println()
+	logging.Println("Files updated:")
+	logging.Println("  database/schema.sql")
+	logging.Println("  database/queries.sql")
+	logging.Println()
 	if parentResource != "" {
-		fmt.Printf("Embedded in parent: %s\n", parentResource)
-		fmt.Printf("  app/%s/%s.go (modified)\n", parentResource, parentResource)
-		fmt.Printf("  app/%s/%s.tmpl (modified)\n", parentResource, parentResource)
-		fmt.Println()
-		fmt.Println("No separate route — child is rendered on the parent's detail page.")
+		logging.Printf("Embedded in parent: %s\n", parentResource)
+		logging.Printf("  app/%s/%s.go (modified)\n", parentResource, parentResource)
+		logging.Printf("  app/%s/%s.tmpl (modified)\n", parentResource, parentResource)
+		logging.Println()
+		logging.Println("No separate route — child is rendered on the parent's detail page.")
 	} else if compUsage.UseUpload {
-		fmt.Println("Manual route setup required (file uploads need storage):")
-		fmt.Println("  Add to main.go:")
-		fmt.Printf("    store := storage.NewLocalStore(\"uploads\", \"/uploads\")\n")
-		fmt.Printf("    http.Handle(\"/uploads/\", http.StripPrefix(\"/uploads/\", store.FileServer()))\n")
-		fmt.Printf("    http.Handle(\"/%s\", %s.Handler(queries, store))\n", resourceNameLower, resourceNameLower)
-		fmt.Println()
-		fmt.Println("  Add imports:")
-		fmt.Println("    \"github.com/livetemplate/lvt/pkg/storage\"")
-		fmt.Printf("    \"%s/app/%s\"\n", moduleName, resourceNameLower)
+		logging.Println("Manual route setup required (file uploads need storage):")
+		logging.Println("  Add to main.go:")
+		logging.Printf("    store := storage.NewLocalStore(\"uploads\", \"/uploads\")\n")
+		logging.Printf("    http.Handle(\"/uploads/\", http.StripPrefix(\"/uploads/\", store.FileServer()))\n")
+		logging.Printf("    http.Handle(\"/%s\", %s.Handler(queries, store))\n", resourceNameLower, resourceNameLower)
+		logging.Println()
+		logging.Println("  Add imports:")
+		logging.Println("    \"github.com/livetemplate/lvt/pkg/storage\"")
+		logging.Printf("    \"%s/app/%s\"\n", moduleName, resourceNameLower)
+	} else {
+		logging.Println("Route auto-injected:")
+		logging.Printf("  http.Handle(\"/%s\", %s.Handler(queries))\n", resourceNameLower, resourceNameLower)
+	}
+	if emitEvents {
+		logging.Println()
+		logging.Println("Change events:")
+		logging.Println("  Create/update/delete publish <table>.created/updated/deleted through app/events")
+		logging.Println("  Payload schemas: app/events/schemas/")
+		logging.Println("  Choose the sink with EVENTS_SINK=bus|webhook|queue (default: bus)")
+	}
+	if withCache {
+		logging.Println()
+		logging.Println("List caching:")
+		logging.Println("  The filtered, sorted list is cached per search and sort order through shared/cache")
+		logging.Println("  Create/update/delete invalidate it; it expires after 5 minutes otherwise")
+	}
+	if withRenderCache {
+		logging.Println()
+		logging.Println("Render caching:")
+		logging.Println("  Paging, search and filter actions reuse the state they produced from the same state through shared/rendercache")
+		logging.Println("  Create/update/delete invalidate it; it expires after 30 seconds otherwise")
+		logging.Println("  Hits and misses: /debug/queries (dev mode), under render_cache")
+	}
+	if withFilters {
+		logging.Println()
+		logging.Println("Filters:")
+		logging.Println("  The toolbar filters selects by option, booleans by yes/no and times by date range")
+		logging.Println("  Search, filters and sort run in one query built through app/filter")
+	}
+	if withSavedViews {
+		logging.Println()
+		logging.Println("Saved views:")
+		logging.Println("  The toolbar saves the search, filters, sort and page size as named views")
+		logging.Println("  Views are kept per user in saved_views; without 'lvt gen auth' all visitors share them")
+	}
+	if sortable {
+		logging.Println()
+		logging.Println("Manual order:")
+		logging.Println("  Drag rows by their handle to reorder them; the order is kept in the position column")
+		logging.Println("  New rows go last; the sort select switches between manual order and the other sorts")
+	}
+	if withUndo {
+		logging.Println()
+		logging.Println("Undo:")
+		logging.Println("  Deleting shows a notice with an Undo button for 10 seconds (undoWindow in the handler)")
+		logging.Println("  Undo re-inserts the row with its ID and creation time")
+	}
+	if withPresence {
+		logging.Println()
+		logging.Println("Presence:")
+		logging.Println("  The page shows an avatar chip for everyone else viewing it and marks the rows they edit")
+		logging.Println("  Viewers are tracked in memory by app/presence; with several instances each sees its own")
+	}
+	if withConflicts {
+		logging.Println()
+		logging.Println("Edit Conflicts:")
+		logging.Println("  Saving over a change made since the edit form opened shows a banner instead")
+		logging.Println("  The banner lists the changed fields and offers Reload or Overwrite")
+	}
+	if withPolicy {
+		logging.Println()
+		logging.Println("Policy:")
+		logging.Printf("  app/%s/policy.go decides who may list, create, update and delete; edit its functions\n", resourceNameLower)
+		logging.Println("  The page hides the controls they refuse; regenerating the resource keeps the file")
+	}
+	if withHooks {
+		logging.Println()
+		logging.Println("Hooks:")
+		logging.Printf("  app/%s/%s_hooks.go runs your code before and after each create, update and delete\n", resourceNameLower, resourceNameLower)
+		logging.Println("  A Before hook's error stops the change; regenerating the resource keeps the file")
+	}
+	printIndexes(indexes)
+	logging.Println()
+	logging.Println("Next steps:")
+	if tableInfo != nil {
+		logging.Println("  1. Regenerate models (no migration needed for an existing table):")
+		logging.Println("     cd database && sqlc generate")
 	} else {
-		fmt.Println("Route auto-injected:")
-		fmt.Printf("  http.Handle(\"/%s\", %s.Handler(queries))\n", resourceNameLower, resourceNameLower)
+		logging.Println("  1. Run migration:")
+		logging.Println("     lvt migration up")
 	}
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Run migration:")
-	fmt.Println("     lvt migration up")
-	fmt.Println("  2. Run your app")
-	fmt.Println()
+	logging.Println("  2. Run your app")
+	logging.Println()
 
 	return validationErr
 }
 
+// viewPatternSummaries describe what each `gen view --pattern` scaffolds.
+var viewPatternSummaries = map[string]string{
+	"counter": "Buttons and a form that change per-session state",
+	"form":    "A validated form with errors next to each field",
+	"wizard":  "A multi-step form that validates each step and keeps earlier entries",
+	"chat":    "A shared chat room on the controller, synced to every session",
+	"kanban":  "A board whose cards move between columns by drag and drop",
+}
+
 func GenView(args []string) error {
 	// Handle --help flag
 	if ShowHelpIfRequested(args, printGenViewHelp) {
@@ -329,12 +671,16 @@ func GenView(args []string) error {
 	// Parse --skip-validation flag before checking positional args,
 	// otherwise `lvt gen view --skip-validation` panics on args[0].
 	skipValidation := false
+	pattern := ""
 	var filteredArgs []string
-	for _, arg := range args {
-		if arg == "--skip-validation" {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--skip-validation" {
 			skipValidation = true
+		} else if args[i] == "--pattern" && i+1 < len(args) {
+			pattern = strings.ToLower(args[i+1])
+			i++ // skip next arg
 		} else {
-			filteredArgs = append(filteredArgs, arg)
+			filteredArgs = append(filteredArgs, args[i])
 		}
 	}
 	args = filteredArgs
@@ -342,6 +688,9 @@ func GenView(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("view name required")
 	}
+	if err := generator.ValidateViewPattern(pattern); err != nil {
+		return err
+	}
 
 	// Get current directory for project config
 	basePath, err := os.Getwd()
@@ -384,14 +733,19 @@ func GenView(args []string) error {
 	capture := collector.StartCapture("gen view", map[string]any{
 		"view_name": viewName,
 		"kit":       kit,
+		"pattern":   pattern,
 	})
 	capture.SetKit(kit) // also sets the dedicated Kit column for SQL queries; inputs has it for context
 
-	fmt.Printf("Generating view-only handler: %s\n", viewName)
-	fmt.Printf("Kit: %s\n", kit)
-	fmt.Printf("CSS Framework: %s\n", cssFramework)
+	logging.Printf("Generating view-only handler: %s\n", viewName)
+	logging.Printf("Kit: %s\n", kit)
+	logging.Printf("CSS Framework: %s\n", cssFramework)
 
-	if err := generator.GenerateView(basePath, moduleName, viewName, kit, cssFramework); err != nil {
+	if pattern != "" {
+		logging.Printf("Pattern: %s\n", pattern)
+	}
+
+	if err := generator.GenerateView(basePath, moduleName, viewName, kit, cssFramework, generator.ViewOptions{Pattern: pattern}); err != nil {
 		capture.RecordError(telemetry.GenerationError{Phase: "generation", Message: err.Error()})
 		capture.Complete(false, "")
 		return err
@@ -414,26 +768,32 @@ func GenView(args []string) error {
 	viewNameLower := strings.ToLower(viewName)
 
 	if validationErr != nil {
-		fmt.Println()
-		fmt.Println("⚠️  View generated, but validation found issues.")
+		logging.Println()
+		logging.Println("⚠️  View generated, but validation found issues.")
 	} else {
-		fmt.Println()
-		fmt.Println("✅ View generated successfully!")
-	}
-	fmt.Println()
-	fmt.Println("Files created:")
-	fmt.Printf("  app/%s/%s.go\n", viewNameLower, viewNameLower)
-	fmt.Printf("  app/%s/%s.tmpl\n", viewNameLower, viewNameLower)
-	fmt.Printf("  app/%s/%s_test.go\n", viewNameLower, viewNameLower)
-	fmt.Println()
-	fmt.Println("Route auto-injected:")
-	fmt.Printf("  http.Handle(\"/%s\", %s.Handler())\n", viewNameLower, viewNameLower)
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Customize handler: app/%s/%s.go\n", viewNameLower, viewNameLower)
-	fmt.Printf("  2. Edit template: app/%s/%s.tmpl\n", viewNameLower, viewNameLower)
-	fmt.Println("  3. Run your app")
-	fmt.Println()
+		logging.Println()
+		logging.Println("✅ View generated successfully!")
+	}
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Printf("  app/%s/%s.go\n", viewNameLower, viewNameLower)
+	logging.Printf("  app/%s/%s.tmpl\n", viewNameLower, viewNameLower)
+	logging.Printf("  app/%s/%s_test.go\n", viewNameLower, viewNameLower)
+	logging.Println()
+	logging.Println("Route auto-injected:")
+	logging.Printf("  http.Handle(\"/%s\", %s.Handler())\n", viewNameLower, viewNameLower)
+	if pattern != "" {
+		logging.Println()
+		logging.Printf("Pattern (%s):\n", pattern)
+		logging.Printf("  %s\n", viewPatternSummaries[pattern])
+		logging.Println("  Each action is a controller method; the comments in the handler walk through them")
+	}
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Customize handler: app/%s/%s.go\n", viewNameLower, viewNameLower)
+	logging.Printf("  2. Edit template: app/%s/%s.tmpl\n", viewNameLower, viewNameLower)
+	logging.Println("  3. Run your app")
+	logging.Println()
 
 	return validationErr
 }
@@ -447,16 +807,45 @@ func GenSchema(args []string) error {
 	// Parse --skip-validation flag before checking positional args,
 	// otherwise `lvt gen schema --skip-validation` panics on args[0].
 	skipValidation := false
+	fromSQL := ""
+	var indexes []parser.Index
 	var filteredArgs []string
-	for _, arg := range args {
-		if arg == "--skip-validation" {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--skip-validation":
 			skipValidation = true
-		} else {
+		case arg == "--index":
+			if i+1 >= len(args) {
+				return fmt.Errorf(`--index requires columns, e.g. --index "org_id,created_at"`)
+			}
+			index, err := parser.ParseIndex(args[i+1])
+			if err != nil {
+				return err
+			}
+			indexes = append(indexes, index)
+			i++
+		case arg == "--from-sql":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from-sql requires a .sql file or SQLite database")
+			}
+			fromSQL = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--from-sql="):
+			fromSQL = strings.TrimPrefix(arg, "--from-sql=")
+		default:
 			filteredArgs = append(filteredArgs, arg)
 		}
 	}
 	args = filteredArgs
 
+	if fromSQL != "" {
+		if len(indexes) > 0 {
+			return fmt.Errorf("--index cannot be combined with --from-sql (adopted tables keep their own indexes)")
+		}
+		return genSchemaFromSQL(fromSQL, args, skipValidation)
+	}
+
 	if len(args) < 1 {
 		return fmt.Errorf("table name required")
 	}
@@ -518,18 +907,18 @@ func GenSchema(args []string) error {
 	})
 	capture.SetKit(kit) // also sets the dedicated Kit column for SQL queries; inputs has it for context
 
-	fmt.Printf("Generating database schema: %s\n", tableName)
-	fmt.Printf("Kit: %s\n", kit)
-	fmt.Printf("Fields: ")
+	logging.Printf("Generating database schema: %s\n", tableName)
+	logging.Printf("Kit: %s\n", kit)
+	logging.Printf("Fields: ")
 	for i, f := range fields {
 		if i > 0 {
-			fmt.Printf(", ")
+			logging.Printf(", ")
 		}
-		fmt.Printf("%s:%s", f.Name, f.Type)
+		logging.Printf("%s:%s", f.Name, f.Type)
 	}
-	fmt.Println()
+	logging.Println()
 
-	if err := generator.GenerateSchema(basePath, moduleName, tableName, fields, kit, cssFramework); err != nil {
+	if err := generator.GenerateSchema(basePath, moduleName, tableName, fields, kit, cssFramework, indexes...); err != nil {
 		capture.RecordError(telemetry.GenerationError{Phase: "generation", Message: err.Error()})
 		capture.Complete(false, "")
 		return err
@@ -552,35 +941,234 @@ func GenSchema(args []string) error {
 	tableNameLower := strings.ToLower(tableName)
 
 	if validationErr != nil {
-		fmt.Println()
-		fmt.Println("⚠️  Schema generated, but validation found issues.")
+		logging.Println()
+		logging.Println("⚠️  Schema generated, but validation found issues.")
 	} else {
-		fmt.Println()
-		fmt.Println("✅ Schema generated successfully!")
+		logging.Println()
+		logging.Println("✅ Schema generated successfully!")
+	}
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  database/migrations/<timestamp>_create_" + tableNameLower + ".sql")
+	logging.Println("  database/schema.sql (updated)")
+	logging.Println("  database/queries.sql (updated)")
+	printIndexes(indexes)
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run migration:")
+	logging.Println("     lvt migration up")
+	logging.Println("  2. Use generated types in your handlers")
+	logging.Println()
+
+	return validationErr
+}
+
+// printIndexes lists the indexes declared with --index, as the migration
+// creates them.
+func printIndexes(indexes []parser.Index) {
+	if len(indexes) == 0 {
+		return
+	}
+	logging.Println()
+	logging.Println("Indexes:")
+	for _, ix := range indexes {
+		line := "  (" + strings.Join(ix.Columns, ", ") + ")"
+		if ix.Unique {
+			line = "  UNIQUE " + line[2:]
+		}
+		if ix.Where != "" {
+			line += " WHERE " + ix.Where
+		}
+		logging.Println(line)
+	}
+}
+
+// genSchemaFromSQL adopts the tables of an existing schema: their DDL goes
+// to schema.sql and the standard queries to queries.sql, and the fields read
+// from their columns are printed for generating resources later. With table
+// names, only those tables are adopted.
+func genSchemaFromSQL(source string, tableNames []string, skipValidation bool) error {
+	basePath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	projectConfig, err := config.LoadProjectConfig(basePath)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kit := projectConfig.GetKit()
+	moduleName, err := getModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w (are you in a Go project?)", err)
+	}
+	for _, name := range tableNames {
+		if err := ValidatePositionalArg(name, "table name"); err != nil {
+			return err
+		}
 	}
-	fmt.Println()
-	fmt.Println("Files created/updated:")
-	fmt.Println("  database/migrations/<timestamp>_create_" + tableNameLower + ".sql")
-	fmt.Println("  database/schema.sql (updated)")
-	fmt.Println("  database/queries.sql (updated)")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Run migration:")
-	fmt.Println("     lvt migration up")
-	fmt.Println("  2. Use generated types in your handlers")
-	fmt.Println()
+
+	db, err := openSQLSource(source)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	tables, err := generator.SchemaTables(db)
+	if err != nil {
+		return err
+	}
+	if len(tableNames) > 0 {
+		byName := make(map[string]*generator.TableInfo, len(tables))
+		for _, t := range tables {
+			byName[strings.ToLower(t.Name)] = t
+		}
+		tables = tables[:0:0]
+		for _, name := range tableNames {
+			t, ok := byName[strings.ToLower(name)]
+			if !ok {
+				return fmt.Errorf("table %q not found in %s", name, source)
+			}
+			tables = append(tables, t)
+		}
+	}
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables found in %s", source)
+	}
+
+	collector := telemetry.NewCollector()
+	defer collector.Close()
+	capture := collector.StartCapture("gen schema", map[string]any{
+		"from_sql": true,
+		"tables":   len(tables),
+		"kit":      kit,
+	})
+	capture.SetKit(kit)
+
+	logging.Printf("Adopting database schema from %s\n", source)
+	logging.Printf("Kit: %s\n", kit)
+
+	adopted, skipped, err := generator.GenerateSchemaFromTables(basePath, moduleName, tables, kit)
+	if err != nil {
+		capture.RecordError(telemetry.GenerationError{Phase: "generation", Message: err.Error()})
+		capture.Complete(false, "")
+		return err
+	}
+
+	var validationErr error
+	var validationResult *validator.ValidationResult
+	if len(adopted) > 0 && !skipValidation {
+		validationResult, validationErr = runPostGenValidation(basePath)
+	}
+	if validationErr != nil {
+		capture.RecordError(telemetry.GenerationError{
+			Phase:   "validation",
+			Message: validationErr.Error(),
+		})
+	}
+	capture.Complete(len(adopted) > 0 && validationErr == nil, marshalValidationResult(validationResult))
+
+	logging.Println()
+	if len(adopted) > 0 {
+		logging.Println("Adopted tables:")
+		for _, t := range adopted {
+			logging.Printf("  %s: %s\n", t.Name, strings.Join(t.Fields, " "))
+		}
+	}
+	if len(skipped) > 0 {
+		logging.Println()
+		logging.Println("Skipped tables:")
+		for _, t := range skipped {
+			logging.Printf("  %s: %s\n", t.Name, strings.ReplaceAll(t.Reason, "\n", "\n    "))
+		}
+	}
+	if len(adopted) == 0 {
+		return fmt.Errorf("no tables adopted from %s", source)
+	}
+
+	logging.Println()
+	if validationErr != nil {
+		logging.Println("⚠️  Schema adopted, but validation found issues.")
+	} else {
+		logging.Println("✅ Schema adopted successfully!")
+	}
+	logging.Println()
+	logging.Println("Files updated:")
+	logging.Println("  database/schema.sql")
+	logging.Println("  database/queries.sql")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Make sure the tables exist in the app's database (no migration was written)")
+	logging.Println("  2. Generate handlers for a table when you are ready, e.g.:")
+	logging.Printf("     lvt gen resource %s --from-table %s\n", strings.ToLower(adopted[0].Name), adopted[0].Name)
+	logging.Println()
 
 	return validationErr
 }
 
+// openSQLSource opens the schema to adopt: a .sql file of CREATE TABLE
+// statements, loaded into an in-memory database, or a SQLite database file,
+// opened read-only.
+func openSQLSource(source string) (*sql.DB, error) {
+	if strings.EqualFold(filepath.Ext(source), ".sql") {
+		ddl, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+		db, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		// Every connection to :memory: is a database of its own
+		db.SetMaxOpenConns(1)
+		if _, err := db.Exec(string(ddl)); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to load %s (the schema must be SQLite SQL): %w", source, err)
+		}
+		return db, nil
+	}
+
+	if _, err := os.Stat(source); err != nil {
+		return nil, fmt.Errorf("database file not found: %s", source)
+	}
+	db, err := sql.Open("sqlite", "file:"+source+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// introspectTable reads an existing table from the project's SQLite database.
+func introspectTable(table string) (*generator.TableInfo, error) {
+	dbPath, err := findDBPath()
+	if err != nil {
+		return nil, err
+	}
+	if dbPath == "" {
+		return nil, fmt.Errorf("--from-table requires a database. Expected: app.db or DATABASE_PATH environment variable")
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("database file not found: %s", dbPath)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	return generator.IntrospectTable(db, table)
+}
+
 // runPostGenValidation runs structural validation (go.mod, templates, migrations)
 // after code generation. It skips compilation because the app may not compile until
 // sqlc generate is run. Prints the formatted result and returns both the result
-// (for telemetry) and an error if validation found issues.
+// (for telemetry) and an error if validation found issues. Dry runs skip it.
 func runPostGenValidation(basePath string) (*validator.ValidationResult, error) {
-	fmt.Println("Running validation...")
+	if dryRun {
+		return nil, nil
+	}
+	logging.Println("Running validation...")
 	result := validation.ValidatePostGen(context.Background(), basePath)
-	fmt.Print(result.Format())
+	logging.Print(result.Format())
 	if result.HasErrors() {
 		return result, fmt.Errorf("validation failed with %d error(s)", result.ErrorCount())
 	}
@@ -594,6 +1182,20 @@ func parseFieldsWithInference(fieldArgs []string) ([]parser.Field, error) {
 	for _, arg := range fieldArgs {
 		var name, typ string
 
+		// Delegate defaults and generated fields to ParseFields; a default
+		// without a type (published=false) gets the type inferred from the name
+		if spec, value, hasDefault := strings.Cut(arg, "="); hasDefault || strings.Contains(arg, ":generated(") {
+			if hasDefault && !strings.Contains(spec, ":") {
+				arg = spec + ":" + inferTypeForDirectMode(strings.TrimSpace(spec)) + "=" + value
+			}
+			parsed, err := parser.ParseFields([]string{arg})
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, parsed...)
+			continue
+		}
+
 		// Check if it contains ":"
 		if strings.Contains(arg, ":") {
 			// Explicit type - use normal parser
@@ -609,9 +1211,9 @@ func parseFieldsWithInference(fieldArgs []string) ([]parser.Field, error) {
 			typ = inferTypeForDirectMode(name)
 		}
 
-		// Delegate select and file/image types to ParseFields to avoid duplication
+		// Delegate select, file/image, counter and richtext types to ParseFields to avoid duplication
 		lowerTyp := strings.ToLower(typ)
-		if lowerTyp == "select" || lowerTyp == "file" || lowerTyp == "image" {
+		if lowerTyp == "select" || strings.HasPrefix(lowerTyp, "select:") || lowerTyp == "file" || lowerTyp == "image" || strings.HasPrefix(lowerTyp, "counter") || lowerTyp == "richtext" {
 			parsed, err := parser.ParseFields([]string{arg})
 			if err != nil {
 				return nil, err
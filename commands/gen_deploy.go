@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/livetemplate/lvt/internal/envschema"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/stack"
+	"github.com/livetemplate/lvt/pkg/secrets"
+)
+
+// GenDeploy generates everything a platform needs to run the app: its
+// config file, a Dockerfile, the release step that migrates the SQLite
+// database, and the secrets to set.
+func GenDeploy(args []string) error {
+	if ShowHelpIfRequested(args, printGenDeployHelp) {
+		return nil
+	}
+
+	config := stack.StackConfig{
+		Database: stack.DatabaseSQLite,
+		Backup:   stack.BackupNone,
+		Redis:    stack.RedisNone,
+		Storage:  stack.StorageNone,
+		CI:       stack.CINone,
+	}
+	force := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--target" && i+1 < len(args):
+			config.Provider = stack.Provider(args[i+1])
+			i++
+		case args[i] == "--db" && i+1 < len(args):
+			config.Database = stack.DatabaseType(args[i+1])
+			i++
+		case args[i] == "--force":
+			force = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	switch config.Provider {
+	case stack.ProviderFly, stack.ProviderRailway, stack.ProviderRender:
+	case "":
+		return fmt.Errorf("--target required (fly, railway or render)")
+	default:
+		return fmt.Errorf("invalid --target %q (expected fly, railway or render)", config.Provider)
+	}
+	if config.Database != stack.DatabaseSQLite && config.Database != stack.DatabaseNone {
+		return fmt.Errorf("invalid --db %q (expected sqlite or none)", config.Database)
+	}
+	if _, err := os.Stat("go.mod"); os.IsNotExist(err) {
+		return fmt.Errorf("not in a LiveTemplate app directory (go.mod not found)")
+	}
+
+	// Platforms probe the health endpoints before routing traffic
+	changed, err := generator.EnsureHealthChecks(".")
+	if err != nil {
+		return fmt.Errorf("failed to add health checks: %w", err)
+	}
+	if changed {
+		logging.Println("✅ Registered /healthz and /readyz in main.go")
+	}
+
+	config.Secrets = deploySecrets()
+	tracking, err := generateStack(config, force)
+	if err != nil {
+		return err
+	}
+
+	logging.Println("Deployment generated successfully!")
+	logging.Println()
+	logging.Println("Generated files:")
+	for _, f := range tracking.Files {
+		logging.Printf("  %s\n", f.Path)
+	}
+
+	if len(config.Secrets) > 0 {
+		names := make([]string, 0, len(config.Secrets))
+		for name := range config.Secrets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		logging.Println()
+		logging.Println("Required secrets (see deploy/README.md):")
+		for _, name := range names {
+			logging.Printf("  %-20s %s\n", name, config.Secrets[name])
+		}
+	}
+
+	steps := []string{"Ensure go.sum is committed and up-to-date (run: go mod tidy)"}
+	switch config.Provider {
+	case stack.ProviderFly:
+		steps = append(steps, "Run: fly launch --no-deploy (creates app + volume)")
+		if len(config.Secrets) > 0 {
+			steps = append(steps, "Set the secrets: fly secrets set NAME=VALUE")
+		}
+		steps = append(steps, "Deploy: fly deploy")
+	case stack.ProviderRailway:
+		steps = append(steps, "Run: railway init")
+		if config.Database == stack.DatabaseSQLite {
+			steps = append(steps, "Run: railway volume add --mount-path /data")
+		}
+		if len(config.Secrets) > 0 {
+			steps = append(steps, "Set the secrets: railway variables --set NAME=VALUE")
+		}
+		steps = append(steps, "Deploy: railway up")
+	case stack.ProviderRender:
+		steps = append(steps, "Push the repository to GitHub, GitLab or Bitbucket")
+		if len(config.Secrets) > 0 {
+			steps = append(steps, "Create a Blueprint from render.yaml in the Render dashboard, entering the secrets when asked")
+		} else {
+			steps = append(steps, "Create a Blueprint from render.yaml in the Render dashboard")
+		}
+	}
+	logging.Println()
+	logging.Println("Next steps:")
+	for i, step := range steps {
+		logging.Printf("  %d. %s\n", i+1, step)
+	}
+	logging.Println()
+	return nil
+}
+
+// deploySecrets returns the variables the app needs set as platform
+// secrets, with a description of each: those shared/config declares secret
+// or required without a default, and the master key when the app has
+// encrypted secrets.
+func deploySecrets() map[string]string {
+	required := map[string]string{}
+	vars, err := envschema.Load(".")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logging.Warn("declared variables not read, required secrets may be missing", "file", envschema.File, "error", err)
+	}
+	for _, v := range vars {
+		if v.Secret || v.Required && v.Default == "" {
+			required[v.Name] = v.Description
+		}
+	}
+	if _, err := os.Stat(secrets.File); err == nil {
+		required[secrets.KeyEnv] = "Decrypts " + secrets.File + " (the contents of " + secrets.KeyFile + ")"
+	}
+	return required
+}
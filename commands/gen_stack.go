@@ -9,11 +9,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/stack"
-	"github.com/livetemplate/lvt/internal/stack/digitalocean"
-	"github.com/livetemplate/lvt/internal/stack/docker"
-	"github.com/livetemplate/lvt/internal/stack/fly"
-	"github.com/livetemplate/lvt/internal/stack/k8s"
 )
 
 func GenStack(args []string) error {
@@ -23,7 +21,7 @@ func GenStack(args []string) error {
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("provider required\n\nUsage: lvt gen stack <provider> [flags]\n\nProviders:\n  docker  - Docker Compose deployment\n  fly     - Fly.io deployment\n  do      - DigitalOcean App Platform\n  k8s     - Kubernetes deployment\n\nFlags:\n  --db <sqlite|postgres|none>               Database type (default: sqlite)\n  --backup <litestream|none>                Backup strategy (default: none)\n  --redis <upstash|fly|none>                Redis provider (default: none)\n  --storage <s3|do-spaces|b2|none>          Storage provider (default: none)\n  --ci <github|gitlab|none>                 CI/CD provider (default: none)\n  --multi-region                            Enable multi-region (fly, k8s only)\n  --namespace <name>                        Kubernetes namespace (k8s only)\n  --ingress <nginx|traefik|none>            Ingress controller (k8s only, default: nginx)\n  --registry <ghcr|docker|gcr|ecr>          Container registry (k8s only, default: ghcr)\n  --force                                   Overwrite existing files")
+		return fmt.Errorf("provider required\n\nUsage: lvt gen stack <provider> [flags]\n\nProviders:\n  docker  - Docker Compose deployment\n  fly     - Fly.io deployment\n  do      - DigitalOcean App Platform\n  k8s     - Kubernetes deployment\n  railway - Railway deployment\n  render  - Render deployment\n\nFlags:\n  --db <sqlite|postgres|none>               Database type (default: sqlite)\n  --backup <litestream|none>                Backup strategy (default: none)\n  --redis <upstash|fly|none>                Redis provider (default: none)\n  --storage <s3|do-spaces|b2|none>          Storage provider (default: none)\n  --ci <github|gitlab|none>                 CI/CD provider (default: none)\n  --multi-region                            Enable multi-region (fly, k8s only)\n  --namespace <name>                        Kubernetes namespace (k8s only)\n  --ingress <nginx|traefik|none>            Ingress controller (k8s only, default: nginx)\n  --registry <ghcr|docker|gcr|ecr>          Container registry (k8s only, default: ghcr)\n  --force                                   Overwrite existing files")
 	}
 
 	provider := args[0]
@@ -95,75 +93,115 @@ func GenStack(args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	tracking, err := generateStack(config, force)
+	if err != nil {
+		return err
+	}
+
+	// Print success message
+	logging.Println("Stack generated successfully!")
+	logging.Println()
+	logging.Println("Generated files:")
+	for _, f := range tracking.Files {
+		logging.Printf("  %s\n", f.Path)
+	}
+	logging.Println()
+	logging.Println("Tracking file created: .lvtstack")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Review generated files in deploy/")
+	logging.Println("  2. Configure environment variables")
+
+	switch config.Provider {
+	case stack.ProviderDocker:
+		logging.Println("  3. Run: make build && make run")
+	case stack.ProviderFly:
+		logging.Println("  3. Ensure go.sum is committed and up-to-date (run: go mod tidy)")
+		logging.Println("  4. Run: fly launch --no-deploy (creates app + volume)")
+		logging.Println("  5. Set secrets: fly secrets set BASE_URL=https://<app-name>.fly.dev")
+		logging.Println("  6. Deploy: fly deploy --local-only")
+	case stack.ProviderDigitalOcean:
+		logging.Println("  3. Run: doctl apps create --spec deploy/app.yaml")
+	case stack.ProviderK8s:
+		logging.Println("  3. Run: kubectl apply -f deploy/")
+	case stack.ProviderRailway:
+		logging.Println("  3. Run: railway init && railway volume add --mount-path /data")
+		logging.Println("  4. Deploy: railway up")
+	case stack.ProviderRender:
+		logging.Println("  3. Push the repository and create a Blueprint from render.yaml")
+	}
+
+	logging.Println()
+
+	return nil
+}
+
+// generateStack writes the deploy/ files and root-level platform config
+// for config in the current directory and records them in .lvtstack.
+func generateStack(config stack.StackConfig, force bool) (*stack.TrackingFile, error) {
 	// Get working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
 	}
 
 	// Check for existing stack
 	trackingPath := filepath.Join(wd, ".lvtstack")
 	if _, err := os.Stat(trackingPath); err == nil && !force {
-		return fmt.Errorf("stack already exists (use --force to overwrite)\n\nRun 'lvt stack info' to see current stack configuration")
+		return nil, fmt.Errorf("stack already exists (use --force to overwrite)\n\nRun 'lvt stack info' to see current stack configuration")
 	}
 
 	// Set project root so generators don't need to derive it
 	config.ProjectDir = wd
+	if config.PubSub == "" {
+		config.PubSub = generator.PubSubBroker(wd)
+	}
 
 	// Create output directory
 	outputDir := filepath.Join(wd, "deploy")
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create deploy directory: %w", err)
+		return nil, fmt.Errorf("failed to create deploy directory: %w", err)
 	}
 
 	// Create generator based on provider
-	var generator stack.Generator
-	switch config.Provider {
-	case stack.ProviderDocker:
-		generator = docker.New()
-	case stack.ProviderFly:
-		generator = fly.New()
-	case stack.ProviderDigitalOcean:
-		generator = digitalocean.New()
-	case stack.ProviderK8s:
-		generator = k8s.New()
-	default:
-		return fmt.Errorf("unsupported provider: %s", provider)
+	generator, err := createGenerator(config.Provider)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate stack
 	ctx := context.Background()
-	fmt.Printf("Generating %s deployment stack...\n", provider)
-	fmt.Printf("Configuration:\n")
-	fmt.Printf("  Database: %s\n", config.Database)
+	logging.Printf("Generating %s deployment stack...\n", config.Provider)
+	logging.Printf("Configuration:\n")
+	logging.Printf("  Database: %s\n", config.Database)
 	if config.Backup != stack.BackupNone {
-		fmt.Printf("  Backup: %s\n", config.Backup)
+		logging.Printf("  Backup: %s\n", config.Backup)
 	}
 	if config.Redis != stack.RedisNone {
-		fmt.Printf("  Redis: %s\n", config.Redis)
+		logging.Printf("  Redis: %s\n", config.Redis)
 	}
 	if config.Storage != stack.StorageNone {
-		fmt.Printf("  Storage: %s\n", config.Storage)
+		logging.Printf("  Storage: %s\n", config.Storage)
 	}
 	if config.CI != stack.CINone {
-		fmt.Printf("  CI/CD: %s\n", config.CI)
+		logging.Printf("  CI/CD: %s\n", config.CI)
 	}
 	if config.MultiRegion {
-		fmt.Printf("  Multi-Region: enabled\n")
+		logging.Printf("  Multi-Region: enabled\n")
 	}
 	if config.Provider == stack.ProviderK8s {
 		if config.Namespace != "" {
-			fmt.Printf("  Namespace: %s\n", config.Namespace)
+			logging.Printf("  Namespace: %s\n", config.Namespace)
 		}
 		if config.Ingress != stack.IngressNone {
-			fmt.Printf("  Ingress: %s\n", config.Ingress)
+			logging.Printf("  Ingress: %s\n", config.Ingress)
 		}
-		fmt.Printf("  Registry: %s\n", config.Registry)
+		logging.Printf("  Registry: %s\n", config.Registry)
 	}
-	fmt.Println()
+	logging.Println()
 
 	if err := generator.Generate(ctx, config, outputDir); err != nil {
-		return fmt.Errorf("failed to generate stack: %w", err)
+		return nil, fmt.Errorf("failed to generate stack: %w", err)
 	}
 
 	// Create tracking file
@@ -193,62 +231,33 @@ func GenStack(args []string) error {
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed to track generated files: %w", err)
+		return nil, fmt.Errorf("failed to track generated files: %w", err)
 	}
 
 	// Track root-level generated files (e.g. Makefile, fly.toml).
 	// Not every provider generates every file — Makefile is Docker-only,
-	// fly.toml is Fly-only — so missing files are silently skipped unless
+	// fly.toml is Fly-only, railway.toml and render.yaml belong to their
+	// platforms — so missing files are silently skipped unless
 	// a provider-specific guard below requires the file to exist.
-	for _, rootFile := range []string{"Makefile", "fly.toml"} {
+	for _, rootFile := range []string{"Makefile", "fly.toml", "railway.toml", "render.yaml"} {
 		rootPath := filepath.Join(wd, rootFile)
 		if _, err := os.Stat(rootPath); err == nil {
 			checksum, err := calculateFileChecksum(rootPath)
 			if err != nil {
-				return fmt.Errorf("failed to calculate checksum for %s: %w", rootFile, err)
+				return nil, fmt.Errorf("failed to calculate checksum for %s: %w", rootFile, err)
 			}
 			tracking.AddFile(rootFile, checksum)
 		} else if rootFile == "fly.toml" && config.Provider == stack.ProviderFly {
-			return fmt.Errorf("fly.toml not found at %s after Fly stack generation", rootPath)
+			return nil, fmt.Errorf("fly.toml not found at %s after Fly stack generation", rootPath)
 		}
 	}
 
 	// Write tracking file
 	if err := tracking.Write(trackingPath); err != nil {
-		return fmt.Errorf("failed to write tracking file: %w", err)
-	}
-
-	// Print success message
-	fmt.Println("Stack generated successfully!")
-	fmt.Println()
-	fmt.Println("Generated files:")
-	for _, f := range tracking.Files {
-		fmt.Printf("  %s\n", f.Path)
-	}
-	fmt.Println()
-	fmt.Println("Tracking file created: .lvtstack")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Review generated files in %s/\n", outputDir)
-	fmt.Println("  2. Configure environment variables")
-
-	switch config.Provider {
-	case stack.ProviderDocker:
-		fmt.Println("  3. Run: make build && make run")
-	case stack.ProviderFly:
-		fmt.Println("  3. Ensure go.sum is committed and up-to-date (run: go mod tidy)")
-		fmt.Println("  4. Run: fly launch --no-deploy (creates app + volume)")
-		fmt.Println("  5. Set secrets: fly secrets set BASE_URL=https://<app-name>.fly.dev")
-		fmt.Println("  6. Deploy: fly deploy --local-only")
-	case stack.ProviderDigitalOcean:
-		fmt.Println("  3. Run: doctl apps create --spec deploy/app.yaml")
-	case stack.ProviderK8s:
-		fmt.Println("  3. Run: kubectl apply -f deploy/")
+		return nil, fmt.Errorf("failed to write tracking file: %w", err)
 	}
 
-	fmt.Println()
-
-	return nil
+	return tracking, nil
 }
 
 // calculateFileChecksum calculates SHA256 checksum for tracking
@@ -269,3 +278,10 @@ func calculateFileChecksum(path string) (string, error) {
 
 // version is set by the main package
 var version = "dev"
+
+// SetVersion sets the lvt version recorded in stack tracking files and the
+// generation manifest.
+func SetVersion(v string) {
+	version = v
+	generator.Version = v
+}
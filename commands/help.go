@@ -3,6 +3,8 @@ package commands
 import (
 	"fmt"
 	"strings"
+
+	"github.com/livetemplate/lvt/internal/lint"
 )
 
 // ShowHelpIfRequested checks if args contain --help or -h flags.
@@ -36,9 +38,13 @@ func printNewHelp() {
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --module <name>     Go module name (defaults to app name)")
-	fmt.Println("  --kit <kit>         Template kit: multi, single, simple (default: multi)")
+	fmt.Println("  --kit <kit>         Template kit: multi, single, simple, daisyui (default: multi)")
 	fmt.Println("  --styles <adapter>  Style adapter: tailwind, unstyled (default: tailwind)")
+	fmt.Println("  --theme <name>      Default daisyUI theme, e.g. light, dark, corporate (daisyui kit;")
+	fmt.Println("                      default: follow the browser's light/dark preference)")
 	fmt.Println("  --dev               Use local development mode")
+	fmt.Println("  --otel              Set up OpenTelemetry tracing (see 'lvt gen otel')")
+	fmt.Println("  --dry-run           Print the files that would be created, with their contents")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -51,9 +57,30 @@ func printGenHelp() {
 	fmt.Println("Subcommands:")
 	fmt.Println("  resource <name> <field:type>...   Generate full CRUD with database")
 	fmt.Println("  view <name>                       Generate view-only handler (no database)")
+	fmt.Println("  wizard <name> <step:fields>...    Generate a multi-step form saved to the database")
 	fmt.Println("  schema <table> <field:type>...    Generate database schema only")
 	fmt.Println("  auth [StructName] [table_name]    Generate authentication system")
+	fmt.Println("  authz [table_name]                Generate role-based authorization")
+	fmt.Println("  audit                             Generate audit trail of record changes")
+	fmt.Println("  api <resource> <field:type>...    Generate JSON API endpoints")
+	fmt.Println("  i18n [--locales <list>]           Set up translations (T helper, locale files, middleware)")
 	fmt.Println("  stack <provider>                  Generate deployment stack")
+	fmt.Println("  deploy --target <platform>        Generate deployment for Fly.io, Railway or Render")
+	fmt.Println("  queue                             Set up background job processing (River)")
+	fmt.Println("  job <name>                        Scaffold a new background job handler")
+	fmt.Println("  task <name> --schedule <interval> Scaffold a new scheduled task")
+	fmt.Println("  metrics [--path <path>]           Set up Prometheus metrics")
+	fmt.Println("  otel                              Set up OpenTelemetry tracing")
+	fmt.Println("  cache [--redis]                   Set up query result caching")
+	fmt.Println("  notifications [--dismiss <d>]     Set up toasts shown with lvt.Notify")
+	fmt.Println("  sessions [--ttl <d>]              Keep live sessions across restarts and reconnects")
+	fmt.Println("  offline                           Queue actions while disconnected and replay them")
+	fmt.Println("  sse                               Fall back to server-sent events where WebSockets are blocked")
+	fmt.Println("  pubsub [--nats]                   Relay presence and broadcasts between instances")
+	fmt.Println("  compression                       Deflate updates sent over the WebSocket")
+	fmt.Println("  prerender                         Serve crawlers and ?static=1 static renders")
+	fmt.Println("  seo                               Serve sitemap.xml and robots.txt and add meta tags")
+	fmt.Println("  security-headers                  Send a nonce-based Content-Security-Policy and security headers")
 	fmt.Println()
 	fmt.Println("Run 'lvt gen <subcommand> --help' for subcommand-specific help.")
 	fmt.Println("Run 'lvt --help' for full documentation.")
@@ -68,21 +95,54 @@ func printGenResourceHelp() {
 	fmt.Println("  <name>          Resource name (singular, e.g., 'post', 'user')")
 	fmt.Println("  <field:type>    Field definitions (type optional, defaults to string)")
 	fmt.Println()
-	fmt.Println("Types: string, int, bool, float, time, text, textarea")
+	fmt.Println("Types: string, int, bool, float, time, text, textarea, json")
+	fmt.Println()
+	fmt.Println("Field options:")
+	fmt.Println("  name:type=value                    Default value (SQL DEFAULT, prefilled in the add form)")
+	fmt.Println("  name:string:generated(slugify f)   Unique slug of field f, set on create")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --parent <name>     Embed this resource in the parent's detail page")
-	fmt.Println("  --pagination <mode> Pagination: infinite, load-more, prev-next, numbers")
+	fmt.Println("  --pagination <mode> Pagination: infinite, load-more, prev-next, numbers, cursor")
 	fmt.Println("  --page-size <n>     Items per page (default: 20)")
 	fmt.Println("  --edit-mode <mode>  Edit mode: modal, page")
 	fmt.Println("  --with-authz        Add ownership tracking and permission checks")
 	fmt.Println("  --searchable        Enable FTS5 full-text search on string fields")
+	fmt.Println("  --emit-events       Publish created/updated/deleted events (app/events)")
+	fmt.Println("  --cache             Cache the list per search and sort (requires 'lvt gen cache')")
+	fmt.Println("  --render-cache      Reuse the states of repeated paging, search and filter actions")
+	fmt.Println("  --filters           Filter widgets for selects, booleans and dates; query in the database")
+	fmt.Println("  --saved-views       Let users save the list's search, filters, sort and page size as views")
+	fmt.Println("  --sortable          Add a position column and drag handles to reorder rows")
+	fmt.Println("  --undo              Show an Undo notice after delete that restores the row")
+	fmt.Println("  --presence          Show who else is viewing the list and which rows they are editing")
+	fmt.Println("  --conflicts         Hold back edits that would overwrite someone else's concurrent change")
+	fmt.Println("  --policy            Generate policy.go with CanList/CanCreate/CanUpdate/CanDelete checks")
+	fmt.Println("  --hooks             Generate <resource>_hooks.go with Before/After Create/Update/Delete functions")
+	fmt.Println("  --index <spec>      Add an index: \"a,b\", \"email unique\", \"a where <cond>\" (repeatable)")
+	fmt.Println("  --id <type>         Record ID type: uuid or ulid (default: id= in .lvtrc)")
+	fmt.Println("  --actions <list>    Actions to generate: list, show, create, edit, delete")
+	fmt.Println("  --readonly          Generate list and show only (same as --actions list,show)")
+	fmt.Println("  --from-table <name> Read fields from an existing table (no migration is created)")
 	fmt.Println("  --skip-validation   Skip post-generation validation checks")
+	fmt.Println("  --dry-run           Print the files that would change, with diffs; write nothing")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  lvt gen resource posts title content:text published:bool")
+	fmt.Println("  lvt gen resource countries name code --readonly")
+	fmt.Println("  lvt gen resource --from-table products")
 	fmt.Println("  lvt gen resource users name email age:int")
 	fmt.Println("  lvt gen resource comments post_id:references:posts author text --parent posts")
+	fmt.Println("  lvt gen resource posts title 'comments_count:counter(comments)'")
+	fmt.Println("  lvt gen resource posts title published=false 'slug:string:generated(slugify title)'")
+	fmt.Println("  lvt gen resource orders total:float --emit-events")
+	fmt.Println("  lvt gen resource meetups name starts_at:time --id ulid")
+	fmt.Println("  lvt gen resource users email org_id --index \"email unique\" --index \"org_id,created_at\"")
+	fmt.Println()
+	fmt.Println("Event sinks (--emit-events), chosen at runtime with EVENTS_SINK:")
+	fmt.Println("  bus       In-process subscribers via events.Subscribe (default)")
+	fmt.Println("  webhook   POST to EVENTS_WEBHOOK_URL with retry and optional HMAC signing")
+	fmt.Println("  queue     Deliver to the webhook through the job queue (requires 'lvt gen queue')")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -90,17 +150,26 @@ func printGenResourceHelp() {
 func printGenViewHelp() {
 	fmt.Println("lvt gen view - Generate a view-only handler (no database)")
 	fmt.Println()
-	fmt.Println("Usage: lvt gen view <name>")
+	fmt.Println("Usage: lvt gen view <name> [--pattern <pattern>]")
 	fmt.Println()
 	fmt.Println("Arguments:")
 	fmt.Println("  <name>    View name (e.g., 'dashboard', 'counter')")
 	fmt.Println()
 	fmt.Println("Options:")
+	fmt.Println("  --pattern <pattern> Scaffold an interactive example instead of a bare handler:")
+	fmt.Println("                      counter  buttons and a form changing per-session state")
+	fmt.Println("                      form     a validated form with per-field errors")
+	fmt.Println("                      wizard   a multi-step form validated step by step")
+	fmt.Println("                      chat     a chat room shared by every session")
+	fmt.Println("                      kanban   a board with drag-and-drop cards")
 	fmt.Println("  --skip-validation   Skip post-generation validation checks")
+	fmt.Println("  --dry-run           Print the files that would change, with diffs; write nothing")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  lvt gen view dashboard")
 	fmt.Println("  lvt gen view counter")
+	fmt.Println("  lvt gen view signup --pattern wizard")
+	fmt.Println("  lvt gen view board --pattern kanban")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -109,18 +178,25 @@ func printGenSchemaHelp() {
 	fmt.Println("lvt gen schema - Generate database schema only (no handlers/templates)")
 	fmt.Println()
 	fmt.Println("Usage: lvt gen schema <table> <field:type>...")
+	fmt.Println("       lvt gen schema --from-sql <file.sql|database.db> [table...]")
 	fmt.Println()
 	fmt.Println("Arguments:")
 	fmt.Println("  <table>         Table name")
 	fmt.Println("  <field:type>    Field definitions")
 	fmt.Println()
-	fmt.Println("Types: string, int, bool, float, time, text")
+	fmt.Println("Types: string, int, bool, float, time, text, json")
 	fmt.Println()
 	fmt.Println("Options:")
+	fmt.Println("  --from-sql <path>   Adopt the tables of an existing .sql file or SQLite database:")
+	fmt.Println("                      add their DDL to schema.sql and queries to queries.sql, no migration")
+	fmt.Println("  --index <spec>      Add an index: \"a,b\", \"email unique\", \"a where <cond>\" (repeatable)")
 	fmt.Println("  --skip-validation   Skip post-generation validation checks")
+	fmt.Println("  --dry-run           Print the files that would change, with diffs; write nothing")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  lvt gen schema products name price:float quantity:int")
+	fmt.Println("  lvt gen schema products sku name --index \"sku unique\"")
+	fmt.Println("  lvt gen schema --from-sql legacy/schema.sql products orders")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -133,17 +209,81 @@ func printGenStackHelp() {
 	fmt.Println("Providers:")
 	fmt.Println("  fly        Fly.io deployment configuration")
 	fmt.Println("  docker     Docker/Docker Compose configuration")
+	fmt.Println("  railway    Railway deployment configuration")
+	fmt.Println("  render     Render deployment configuration")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
 
+func printGenDeployHelp() {
+	fmt.Println("lvt gen deploy - Generate everything needed to deploy the app")
+	fmt.Println()
+	fmt.Println("Usage: lvt gen deploy --target <platform> [flags]")
+	fmt.Println()
+	fmt.Println("Platforms:")
+	fmt.Println("  fly        Fly.io (fly.toml)")
+	fmt.Println("  railway    Railway (railway.toml)")
+	fmt.Println("  render     Render (render.yaml Blueprint)")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  --target <platform>   Platform to deploy to (required)")
+	fmt.Println("  --db <sqlite|none>    Database (default: sqlite, on a volume at /data)")
+	fmt.Println("  --force               Overwrite an existing deployment (.lvtstack)")
+	fmt.Println()
+	fmt.Println("Writes the platform config at the project root and deploy/Dockerfile,")
+	fmt.Println("deploy/release.sh, which applies pending migrations before the app starts,")
+	fmt.Println("and deploy/README.md with the steps and the secrets to set. The secrets")
+	fmt.Println("are the variables shared/config declares secret or required, plus")
+	fmt.Println("LVT_MASTER_KEY when the app has encrypted secrets. Registers the")
+	fmt.Println("/healthz and /readyz endpoints in main.go if missing.")
+	fmt.Println()
+	fmt.Println("Run 'lvt stack validate' or 'lvt stack info' afterwards to check the files.")
+}
+
 func printParseHelp() {
 	fmt.Println("lvt parse - Validate and analyze a template file")
 	fmt.Println()
 	fmt.Println("Usage: lvt parse <template-file>")
+	fmt.Println("       lvt parse [./... | <dir>...] [--watch]")
+	fmt.Println("       lvt parse --lint [path...] [--format text|json]")
+	fmt.Println("       lvt parse graph [path...] [--format mermaid|json]")
+	fmt.Println("       lvt parse render <template-file> [--data <file>] [--out <dir>]")
+	fmt.Println("       lvt parse budget <template-file> [--scenario <file>] [--max-bytes <n>] [--compressed] [--format text|json]")
 	fmt.Println()
 	fmt.Println("Arguments:")
 	fmt.Println("  <template-file>    Path to .tmpl file to validate")
+	fmt.Println("  ./... | <dir>      Parse every .tmpl under the directories with the functions")
+	fmt.Println("                     generated apps register (components, T after gen i18n)")
+	fmt.Println("  [path...]          Templates or directories to lint or graph (default: app/)")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --watch            Keep parsing templates as they change (default path: ./...)")
+	fmt.Println("  --data FILE        render: JSON or YAML data; its fields replace the sample data's")
+	fmt.Println("  --out DIR          render: where to write <name>.html and <name>.tree.json")
+	fmt.Println("                     (default: .lvt/render)")
+	fmt.Println("  --scenario FILE    budget: JSON or YAML state changes (default: add, edit and")
+	fmt.Println("                     remove a row of the sample data)")
+	fmt.Println("  --max-bytes N      budget: fail when an update payload is larger")
+	fmt.Println("  --compressed       budget: apply --max-bytes to the deflated size, for apps")
+	fmt.Println("                     set up with lvt gen compression")
+	fmt.Println("  --lint             Check templates with the lint rules; fails on errors")
+	fmt.Println("  --format FORMAT    Lint output: text (file:line:col) or json (default: text);")
+	fmt.Println("                     graph output: mermaid or json (default: mermaid)")
+	fmt.Println()
+	fmt.Println("Render executes a template without the app. Without --data, it uses the")
+	fmt.Println("state struct of the template's handler with its initial values, and sample")
+	fmt.Println("rows for the resource's table in database/schema.sql.")
+	fmt.Println()
+	fmt.Println("Budget renders the template through the scenario's state changes and reports")
+	fmt.Println("the size of each update payload and how much of it is static markup.")
+	fmt.Println()
+	fmt.Println("The graph shows which templates and defines invoke which; defines nothing")
+	fmt.Println("invokes and templates defined nowhere are reported on stderr.")
+	fmt.Println()
+	fmt.Println("Lint rules (severity: off, warning or error; set in .lvtrc as lint.<rule>=<severity>):")
+	for _, r := range lint.Rules {
+		fmt.Printf("  %-20s %s (default: %s)\n", r.Name, r.Description, r.Default)
+	}
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -152,6 +292,8 @@ func printSeedHelp() {
 	fmt.Println("lvt seed - Generate test data for a resource")
 	fmt.Println()
 	fmt.Println("Usage: lvt seed <resource> [options]")
+	fmt.Println("       lvt seed <resource> --from <file> [--map <Header=column,...>] [--batch <n>] [--dry-run]")
+	fmt.Println("       lvt seed --profile <name> [--scenario <name,...>] [--seed <n>]")
 	fmt.Println()
 	fmt.Println("Arguments:")
 	fmt.Println("  <resource>    Resource name to seed")
@@ -159,10 +301,71 @@ func printSeedHelp() {
 	fmt.Println("Options:")
 	fmt.Println("  --count N     Number of records to generate (default: 10)")
 	fmt.Println("  --cleanup     Remove existing test data before seeding")
+	fmt.Println("  --locale L    Locale for names, addresses and phone numbers (e.g. de_DE;")
+	fmt.Println("                default: seed.locale in .lvtrc, else en_US)")
+	fmt.Println("  --seed-value N  Generate the same dataset on every run and machine: values")
+	fmt.Println("                come from seed N and dates are relative to 2025-01-01 instead")
+	fmt.Println("                of today. Combine with --cleanup to reproduce it again")
+	fmt.Println("  --parents S   How foreign keys are filled (default: reuse):")
+	fmt.Println("                  reuse     existing parent rows, creating a few if there are none")
+	fmt.Println("                  create    a new parent row for every row")
+	fmt.Println("                  existing  existing parent rows only; fails if a required")
+	fmt.Println("                            parent table is empty")
+	fmt.Println()
+	fmt.Println("Importing:")
+	fmt.Println("  --from <file>  Import a .csv, .tsv, .json (array of objects) or .jsonl file")
+	fmt.Println("  --map <spec>   Map file columns to fields: 'Post Title=title,Notes=-' (- skips)")
+	fmt.Println("  --batch <n>    Rows per transaction (default: 500)")
+	fmt.Println("  --dry-run      Validate every row without inserting")
+	fmt.Println()
+	fmt.Println("File columns match fields by name, ignoring case, spaces and dashes. Values are")
+	fmt.Println("checked against each field's type; invalid rows are skipped and reported with")
+	fmt.Println("their CSV line or JSON record number.")
+	fmt.Println("Missing ids and timestamps are generated.")
+	fmt.Println()
+	fmt.Println("Values follow each field's name and type: emails, names, addresses, lorem ipsum")
+	fmt.Println("for text fields, one of the options for selects, past dates. Override a column in")
+	fmt.Println(".lvtrc with a gofakeit function or template:")
+	fmt.Println()
+	fmt.Println("  seed.field.sku=\"SKU-####\"")
+	fmt.Println("  seed.field.posts.title=\"{hackerphrase}\"")
+	fmt.Println()
+	fmt.Println("Profiles:")
+	fmt.Println("  --profile <name>       Apply database/seeds/<name>.yaml (or run <name>.go)")
+	fmt.Println("  --scenario <name,...>  Apply only these scenarios of the profile")
+	fmt.Println("  --seed <n>             Seed the generated values, for reproducible data (also")
+	fmt.Println("                         --seed-value)")
+	fmt.Println()
+	fmt.Println("A YAML profile lists scenarios of explicit records, upserted by a natural key so")
+	fmt.Println("re-running it updates rather than duplicates them. A record's _ref names it, and")
+	fmt.Println("\"@name\" (or \"@name.column\") in a later record refers to it. count tops a table")
+	fmt.Println("up to that many rows with generated data:")
+	fmt.Println()
+	fmt.Println("  scenarios:")
+	fmt.Println("    - name: authors-and-posts")
+	fmt.Println("      tables:")
+	fmt.Println("        - table: authors")
+	fmt.Println("          key: email")
+	fmt.Println("          records:")
+	fmt.Println("            - {_ref: alice, name: Alice, email: alice@example.com}")
+	fmt.Println("        - table: posts")
+	fmt.Println("          key: title")
+	fmt.Println("          records:")
+	fmt.Println("            - {title: Hello, author_id: \"@alice\"}")
+	fmt.Println("          count: 20")
+	fmt.Println()
+	fmt.Println("A Go profile is a program (mark it //go:build ignore) run from the project root")
+	fmt.Println("with the database in DATABASE_PATH and the scenarios in LVT_SEED_SCENARIOS.")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  lvt seed posts --count 50")
 	fmt.Println("  lvt seed users --cleanup")
+	fmt.Println("  lvt seed users --count 20 --locale fr_FR")
+	fmt.Println("  lvt seed comments --count 100 --parents create")
+	fmt.Println("  lvt seed posts --cleanup --count 50 --seed-value 42")
+	fmt.Println("  lvt seed posts --from data/posts.csv --map 'Post Title=title'")
+	fmt.Println("  lvt seed --profile demo")
+	fmt.Println("  lvt seed --profile demo --scenario authors-and-posts --seed 1")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -175,6 +378,19 @@ func printResourceHelp() {
 	fmt.Println("Commands:")
 	fmt.Println("  list              List all available resources")
 	fmt.Println("  describe <name>   Show detailed schema for a resource")
+	fmt.Println("  openapi           Print an OpenAPI 3.1 document for all resources")
+	fmt.Println("  erd               Print an entity-relationship diagram of schema.sql")
+	fmt.Println("  diff              Compare schema.sql and migrations with the live database")
+	fmt.Println()
+	fmt.Println("Options for openapi and erd:")
+	fmt.Println("  -o, --output <file>       Write to a file instead of stdout")
+	fmt.Println("  --format json|yaml        openapi format (default: json, or yaml for .yaml/.yml files)")
+	fmt.Println("  --format mermaid|dot      erd format (default: mermaid, or dot for .dot/.gv files)")
+	fmt.Println()
+	fmt.Println("Options for diff:")
+	fmt.Println("  --db <path>               Database to compare (default: app.db or DATABASE_PATH)")
+	fmt.Println("  --format text|json        Output format (default: text); --json is shorthand")
+	fmt.Println("  Exits non-zero when pending migrations or schema drift are found.")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -189,6 +405,21 @@ func printMigrationHelp() {
 	fmt.Println("  down            Rollback last migration")
 	fmt.Println("  status          Show migration status")
 	fmt.Println("  create <name>   Create new migration file")
+	fmt.Println("  unlock          Release a stale migration lock left by a crashed run")
+	fmt.Println()
+	fmt.Println("Options for up and down:")
+	fmt.Println("  --lock-timeout <d>      How long to wait for a concurrent migration to finish (default 1m)")
+	fmt.Println("  --lock-stale-after <d>  How long the lock holder may go without a heartbeat before")
+	fmt.Println("                          its lock is reported as stale (default 10m)")
+	fmt.Println()
+	fmt.Println("Options for down:")
+	fmt.Println("  --to <version>  Roll back every migration newer than <version> (0 for all)")
+	fmt.Println("  --steps <n>     Roll back the last n applied migrations")
+	fmt.Println("  --dry-run       List the migrations and Down SQL that would run, without changes")
+	fmt.Println()
+	fmt.Println("Options for create:")
+	fmt.Println("  --type sql|go   sql (default) or a Go migration whose Up/Down funcs receive a *sql.Tx,")
+	fmt.Println("                  for backfills and transformations SQL cannot express")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -200,13 +431,92 @@ func printKitsHelp() {
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  list              List all available kits")
-	fmt.Println("  info <kit>        Show detailed information about a kit")
+	fmt.Println("  info <kit>        Show a kit's details and the kits it extends")
 	fmt.Println("  create <name>     Create a new custom kit")
 	fmt.Println("  validate <path>   Validate a kit implementation")
+	fmt.Println("  customize <kit>   Copy a kit into the project to edit it")
+	fmt.Println("  diff <kit>        Compare a customized kit with the embedded version")
+	fmt.Println("  test [path]       Check a kit against the contract generated apps rely on")
+	fmt.Println("  install <name|git-url>  Install a community kit into ~/.config/lvt/kits")
+	fmt.Println("  update [name...]  Fetch installed kits again (default: all)")
+	fmt.Println("  remove <name>     Remove an installed kit")
+	fmt.Println("  publish [path]    Validate, test and package a kit for distribution")
+	fmt.Println()
+	fmt.Println("Install options:")
+	fmt.Println("  --version <ref>   Pin a tag or commit (also for update)")
+	fmt.Println("  --checksum <sum>  Expected sha256:<hex> of the kit's files")
+	fmt.Println("  --force           Replace a kit of the same name")
+	fmt.Println()
+	fmt.Println("Publish options:")
+	fmt.Println("  --out <dir>       Where to write <name>-<version>.tar.gz (default: dist)")
+	fmt.Println("  --skip-test       Skip generating and vetting a sample app with the kit")
+	fmt.Println("  --release         Create a GitHub release with the package (needs gh)")
+	fmt.Println("  --registry <url>  PUT the package to <url>/<file>; LVT_REGISTRY_TOKEN is")
+	fmt.Println("                    sent as a bearer token")
+	fmt.Println()
+	fmt.Println("Test options:")
+	fmt.Println("  --no-browser      Skip the browser checks (modal, form, pagination)")
+	fmt.Println("  --keep            Keep the generated app to inspect failures")
+	fmt.Println()
+	fmt.Println("Customize options:")
+	fmt.Println("  --scope <scope>   project (.lvt/kits) or global (~/.config/lvt/kits)")
+	fmt.Println("  --components-only Copy only the components")
+	fmt.Println("  --dry-run         Print the files that would change, with diffs")
+	fmt.Println()
+	fmt.Println("Diff options:")
+	fmt.Println("  --scope <scope>   project or global copy (default: the one in use)")
+	fmt.Println("  --stat            List changed files without the diffs")
+	fmt.Println("  --merge           Go through the changes, taking the upstream version of")
+	fmt.Println("                    the ones you pick")
+	fmt.Println()
+	fmt.Println("Names are looked up in the kit index (LVT_KIT_INDEX, or kit_index in")
+	fmt.Println("~/.config/lvt/config.yaml), which pins each version's checksum.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt kits install bulma")
+	fmt.Println("  lvt kits install https://github.com/acme/lvt-kit-acme --version v1.2.0")
+	fmt.Println("  lvt kits update")
+	fmt.Println("  lvt kits remove bulma")
+	fmt.Println("  lvt kits diff multi --merge")
+	fmt.Println("  lvt kits test ./lvt-kit-acme")
+	fmt.Println("  lvt kits publish ./lvt-kit-acme --release")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
 
+func printPluginsHelp() {
+	fmt.Println("lvt plugins - Generator plugins")
+	fmt.Println()
+	fmt.Println("Usage: lvt plugins list")
+	fmt.Println()
+	fmt.Println("Plugins are executables in .lvt/plugins/ (in the project or a parent")
+	fmt.Println("directory) or ~/.config/lvt/plugins/. They add 'lvt gen <name>'")
+	fmt.Println("subcommands and hooks that run before and after the built-in generators.")
+	fmt.Println("A plugin describes itself when run as '<plugin> describe'; see the CLI")
+	fmt.Println("guide for the protocol.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  list              List the plugins found, their subcommands and hooks")
+	fmt.Println()
+}
+
+func printStatusHelp() {
+	fmt.Println("lvt status - Compare generated files with the generation manifest")
+	fmt.Println()
+	fmt.Println("Usage: lvt status")
+	fmt.Println()
+	fmt.Println("lvt new and lvt gen record every file they write in .lvt/manifest.json,")
+	fmt.Println("with its generator, the lvt version and a checksum. lvt status lists:")
+	fmt.Println("  - files modified since lvt last wrote them")
+	fmt.Println("  - generated files that were deleted")
+	fmt.Println("  - files left behind by resources no longer in .lvtresources")
+	fmt.Println("  - generators whose kit templates changed since they ran, e.g. after")
+	fmt.Println("    upgrading lvt or customizing a kit")
+	fmt.Println()
+	fmt.Println("With --json the same lists are printed as a JSON object.")
+	fmt.Println()
+}
+
 func printEnvHelp() {
 	fmt.Println("lvt env - Manage environment variables")
 	fmt.Println()
@@ -217,6 +527,10 @@ func printEnvHelp() {
 	fmt.Println("  list              List configured environment variables")
 	fmt.Println("  set <key> <val>   Set an environment variable")
 	fmt.Println("  unset <key>       Unset an environment variable")
+	fmt.Println("  validate          Check the environment against the declared variables")
+	fmt.Println("  diff              Compare the environment with the declared variables")
+	fmt.Println("  secrets edit      Edit the encrypted secrets in $EDITOR")
+	fmt.Println("  secrets show      Print the decrypted secrets")
 	fmt.Println()
 	fmt.Println("Run 'lvt --help' for full documentation.")
 }
@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -120,3 +123,34 @@ func TestNewRejectsFlags(t *testing.T) {
 		})
 	}
 }
+
+// Every built-in subcommand is listed by `lvt gen --help` and by the error
+// for an unknown one.
+func TestGenHelpListsSubcommands(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	printGenHelp()
+	os.Stdout = stdout
+	w.Close()
+	help, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unknown := genPlugin("nope", nil)
+	if unknown == nil {
+		t.Fatal("an unknown subcommand was accepted")
+	}
+	for name := range genSubcommands {
+		if !strings.Contains(string(help), "\n  "+name+" ") {
+			t.Errorf("lvt gen --help does not list %s", name)
+		}
+		if !strings.Contains(unknown.Error(), "\n  "+name+" ") {
+			t.Errorf("the unknown subcommand error does not list %s", name)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenI18n sets up translations (app/i18n package, locale files, middleware).
+func GenI18n(args []string) error {
+	if ShowHelpIfRequested(args, printGenI18nHelp) {
+		return nil
+	}
+
+	cfg := &generator.I18nConfig{DefaultLocale: "en"}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--locales":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--locales requires a comma-separated list, e.g. en,fr")
+			}
+			i++
+			for _, locale := range strings.Split(args[i], ",") {
+				if locale = strings.TrimSpace(locale); locale != "" {
+					cfg.Locales = append(cfg.Locales, locale)
+				}
+			}
+		case "--default":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--default requires a locale, e.g. en")
+			}
+			i++
+			cfg.DefaultLocale = args[i]
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+	if !generator.ValidLocale(cfg.DefaultLocale) {
+		return fmt.Errorf("invalid default locale %q (expected a tag like en or pt-BR)", cfg.DefaultLocale)
+	}
+
+	basePath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	moduleName, err := getModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w (are you in a Go project?)", err)
+	}
+	cfg.ModuleName = moduleName
+
+	logging.Println("Generating i18n scaffolding...")
+
+	if err := generator.GenerateI18n(basePath, cfg); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ i18n scaffolding generated!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  app/i18n/i18n.go (T, locale loading and negotiation)")
+	logging.Println("  app/i18n/templates/switcher.tmpl (language switcher)")
+	files, _ := filepath.Glob(filepath.Join(basePath, generator.LocalesDir, "*.json"))
+	for _, file := range files {
+		logging.Printf("  %s/%s\n", generator.LocalesDir, filepath.Base(file))
+	}
+	logging.Println("  cmd/*/main.go (i18n.Load and i18n.Middleware)")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Generate resources (their templates translate with T):")
+	logging.Println("     lvt gen resource posts title content:text")
+	logging.Println("  2. Add the new strings to every locale file:")
+	logging.Println("     lvt i18n extract")
+	logging.Println("  3. Translate the empty entries in locales/*.json")
+	logging.Println("  4. Switch languages with ?lang=<locale> (remembered in a cookie), or add a")
+	logging.Println("     switcher to a template: {{template \"lvt:i18n:switcher:v1\" .Locale}}")
+	logging.Println()
+	logging.Println("Note: resources generated before this command keep their English text;")
+	logging.Println("regenerate them to translate it.")
+	logging.Println()
+
+	return nil
+}
+
+// I18n handles translation subcommands (extract).
+func I18n(args []string) error {
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" || args[0] == "help" {
+		printI18nHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "extract":
+		return I18nExtract(args[1:])
+	default:
+		return fmt.Errorf("unknown i18n subcommand: %s\n\nAvailable subcommands:\n  extract    Add the strings templates translate to every locale file", args[0])
+	}
+}
+
+// I18nExtract adds the strings templates pass to T to the locale files and
+// reports what is left to translate.
+func I18nExtract(args []string) error {
+	if ShowHelpIfRequested(args, printI18nHelp) {
+		return nil
+	}
+
+	check := false
+	for _, arg := range args {
+		switch arg {
+		case "--check":
+			check = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+			return fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+
+	basePath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	report, err := generator.ExtractTranslations(basePath, check)
+	if err != nil {
+		return err
+	}
+
+	logging.Printf("Found %d translatable strings in app/ templates\n\n", len(report.Strings))
+	for _, l := range report.Locales {
+		name := l.Locale
+		if l.Locale == report.DefaultLocale {
+			name += " (default)"
+		}
+		verb := "added"
+		if check {
+			verb = "missing"
+		}
+		logging.Printf("  %-16s %3d %s, %3d untranslated, %3d unused\n", name, len(l.Added), verb, len(l.Untranslated), len(l.Unused))
+		for _, s := range l.Untranslated {
+			logging.Printf("      - %q\n", s)
+		}
+	}
+	logging.Println()
+
+	if check {
+		if report.Pending() {
+			return fmt.Errorf("locale files are missing strings or translations (run 'lvt i18n extract' and translate the empty entries)")
+		}
+		logging.Println("✅ Every locale is complete")
+		return nil
+	}
+	if report.Pending() {
+		logging.Println("Translate the empty entries in " + generator.LocalesDir + "/*.json; unused entries can be removed.")
+	} else {
+		logging.Println("✅ Every locale is complete")
+	}
+	return nil
+}
+
+func printGenI18nHelp() {
+	fmt.Println("Usage: lvt gen i18n [--locales <list>] [--default <locale>]")
+	fmt.Println()
+	fmt.Println("Sets up translations: an app/i18n package with the T template function")
+	fmt.Println("and locale negotiation middleware, and a locales/<locale>.json file per")
+	fmt.Println("locale. Resources generated afterwards translate their text with T.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --locales <list>    Locale files to create, comma-separated (default: the default locale)")
+	fmt.Println("  --default <locale>  Locale of the source text and the fallback (default: en)")
+	fmt.Println()
+	fmt.Println("The locale of each request comes from ?lang=, then the lang cookie,")
+	fmt.Println("then the Accept-Language header.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt gen i18n --locales en,fr,de")
+	fmt.Println("  lvt gen resource posts title content:text")
+	fmt.Println("  lvt i18n extract")
+	fmt.Println()
+}
+
+func printI18nHelp() {
+	fmt.Println("Usage: lvt i18n extract [--check]")
+	fmt.Println()
+	fmt.Println("Collects the strings app/ templates pass to T and adds the missing ones")
+	fmt.Println("to every locale file: with the source text in the default locale, empty")
+	fmt.Println("in the others. Prints the untranslated strings of each locale.")
+	fmt.Println()
+	fmt.Println("To add a locale, create locales/<locale>.json containing {} and run extract.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --check    Change nothing; fail when a locale is missing strings or")
+	fmt.Println("             translations (for CI)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt i18n extract")
+	fmt.Println("  lvt i18n extract --check")
+	fmt.Println()
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 //go:embed claude_resources
@@ -143,13 +145,13 @@ func InstallAgent(args []string) error {
 	// Validate LLM type
 	config, ok := agentConfigs[llm]
 	if !ok {
-		fmt.Printf("❌ Unknown LLM type: %s\n\n", llm)
-		fmt.Println("Available LLM types:")
+		logging.Printf("❌ Unknown LLM type: %s\n\n", llm)
+		logging.Println("Available LLM types:")
 		for name, cfg := range agentConfigs {
-			fmt.Printf("  • %s - %s\n", name, cfg.Description)
+			logging.Printf("  • %s - %s\n", name, cfg.Description)
 		}
-		fmt.Println()
-		fmt.Println("Usage: lvt install-agent --llm <type>")
+		logging.Println()
+		logging.Println("Usage: lvt install-agent --llm <type>")
 		return fmt.Errorf("invalid LLM type")
 	}
 
@@ -177,8 +179,8 @@ func InstallAgent(args []string) error {
 		return upgradeAgent(targetDir, config)
 	}
 
-	fmt.Printf("Installing %s agent...\n", config.Name)
-	fmt.Println()
+	logging.Printf("Installing %s agent...\n", config.Name)
+	logging.Println()
 
 	// Create target directory if it doesn't exist
 	if err := os.MkdirAll(targetDir, 0755); err != nil {
@@ -234,34 +236,34 @@ func InstallAgent(args []string) error {
 	currentVersion := getCurrentVersion()
 	if err := os.WriteFile(versionFile, []byte(currentVersion+"\n"), 0644); err != nil {
 		// Non-fatal error, just log it
-		fmt.Printf("⚠️  Warning: Could not write version file: %v\n", err)
+		logging.Printf("⚠️  Warning: Could not write version file: %v\n", err)
 	}
 
-	fmt.Printf("✅ Successfully installed %d files!\n", installed)
-	fmt.Println()
-	fmt.Printf("📁 Installed to: %s/\n", targetDir)
-	fmt.Printf("📦 Version: %s\n", currentVersion)
-	fmt.Println()
-	fmt.Println("🚀 Next steps:")
+	logging.Printf("✅ Successfully installed %d files!\n", installed)
+	logging.Println()
+	logging.Printf("📁 Installed to: %s/\n", targetDir)
+	logging.Printf("📦 Version: %s\n", currentVersion)
+	logging.Println()
+	logging.Println("🚀 Next steps:")
 	for i, step := range config.NextSteps {
-		fmt.Printf("  %d. %s\n", i+1, step)
+		logging.Printf("  %d. %s\n", i+1, step)
 	}
-	fmt.Println()
-	fmt.Println("💡 The agent will guide you through LiveTemplate workflows!")
-	fmt.Println()
+	logging.Println()
+	logging.Println("💡 The agent will guide you through LiveTemplate workflows!")
+	logging.Println()
 
 	return nil
 }
 
 // upgradeAgent upgrades an existing agent installation while preserving user settings
 func upgradeAgent(targetDir string, config AgentConfig) error {
-	fmt.Printf("🔄 Upgrading %s agent...\n", config.Name)
-	fmt.Println()
+	logging.Printf("🔄 Upgrading %s agent...\n", config.Name)
+	logging.Println()
 
 	// Check if directory exists
 	if _, err := os.Stat(targetDir); os.IsNotExist(err) {
-		fmt.Println("❌ No existing installation found")
-		fmt.Println("   Run 'lvt install-agent' instead")
+		logging.Println("❌ No existing installation found")
+		logging.Println("   Run 'lvt install-agent' instead")
 		return fmt.Errorf("no existing installation to upgrade")
 	}
 
@@ -269,10 +271,10 @@ func upgradeAgent(targetDir string, config AgentConfig) error {
 	oldVersion := getInstalledVersion(targetDir)
 	newVersion := getCurrentVersion()
 
-	fmt.Println("📊 Version Information:")
-	fmt.Printf("   Current: %s\n", oldVersion)
-	fmt.Printf("   New:     %s\n", newVersion)
-	fmt.Println()
+	logging.Println("📊 Version Information:")
+	logging.Printf("   Current: %s\n", oldVersion)
+	logging.Printf("   New:     %s\n", newVersion)
+	logging.Println()
 
 	// Backup user settings for Claude (settings.local.json) or other config files
 	var settingsFiles = []string{"settings.local.json", ".aider.local.yml", "local.md"}
@@ -282,7 +284,7 @@ func upgradeAgent(targetDir string, config AgentConfig) error {
 		settingsPath := filepath.Join(targetDir, filename)
 		if data, err := os.ReadFile(settingsPath); err == nil {
 			backups[filename] = data
-			fmt.Printf("📦 Backing up %s...\n", filename)
+			logging.Printf("📦 Backing up %s...\n", filename)
 		}
 	}
 
@@ -300,7 +302,7 @@ func upgradeAgent(targetDir string, config AgentConfig) error {
 	})
 
 	// Remove old installation
-	fmt.Println("🗑️  Removing old installation...")
+	logging.Println("🗑️  Removing old installation...")
 	if err := os.RemoveAll(targetDir); err != nil {
 		return fmt.Errorf("failed to remove old installation: %w", err)
 	}
@@ -311,7 +313,7 @@ func upgradeAgent(targetDir string, config AgentConfig) error {
 	}
 
 	// Install new agent files
-	fmt.Println("📥 Installing updated agent...")
+	logging.Println("📥 Installing updated agent...")
 	installed := 0
 	err := fs.WalkDir(config.ResourceFS, config.ResourceDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -354,33 +356,33 @@ func upgradeAgent(targetDir string, config AgentConfig) error {
 	// Write version marker file
 	versionFile := filepath.Join(targetDir, ".version")
 	if err := os.WriteFile(versionFile, []byte(newVersion+"\n"), 0644); err != nil {
-		fmt.Printf("⚠️  Warning: Could not write version file: %v\n", err)
+		logging.Printf("⚠️  Warning: Could not write version file: %v\n", err)
 	}
 
 	// Restore user settings if they existed
 	if len(backups) > 0 {
-		fmt.Println("♻️  Restoring your custom settings...")
+		logging.Println("♻️  Restoring your custom settings...")
 		for filename, data := range backups {
 			settingsPath := filepath.Join(targetDir, filename)
 			if err := os.WriteFile(settingsPath, data, 0644); err != nil {
-				fmt.Printf("⚠️  Warning: failed to restore %s: %v\n", filename, err)
+				logging.Printf("⚠️  Warning: failed to restore %s: %v\n", filename, err)
 			}
 		}
 	}
 
 	// Show upgrade summary
-	fmt.Println()
-	fmt.Printf("✅ Successfully upgraded %s! (%s → %s)\n", config.Name, oldVersion, newVersion)
-	fmt.Printf("   Files: %d → %d\n", oldFileCount, installed)
-	fmt.Println()
-	fmt.Println("📚 What's new:")
-	fmt.Println("  • Latest documentation and improvements")
-	fmt.Println("  • Updated workflows and best practices")
-	fmt.Println("  • Bug fixes and enhancements")
+	logging.Println()
+	logging.Printf("✅ Successfully upgraded %s! (%s → %s)\n", config.Name, oldVersion, newVersion)
+	logging.Printf("   Files: %d → %d\n", oldFileCount, installed)
+	logging.Println()
+	logging.Println("📚 What's new:")
+	logging.Println("  • Latest documentation and improvements")
+	logging.Println("  • Updated workflows and best practices")
+	logging.Println("  • Bug fixes and enhancements")
 	if len(backups) > 0 {
-		fmt.Println("  • Your custom settings preserved")
+		logging.Println("  • Your custom settings preserved")
 	}
-	fmt.Println()
+	logging.Println()
 
 	return nil
 }
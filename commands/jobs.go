@@ -7,6 +7,7 @@ import (
 
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // GenQueue sets up background job infrastructure using River.
@@ -34,21 +35,21 @@ func GenQueue(args []string) error {
 		return err
 	}
 
-	fmt.Println()
-	fmt.Println("✅ Background job queue set up successfully!")
-	fmt.Println()
-	fmt.Println("Generated files:")
-	fmt.Println("  app/jobs/worker.go                         Job worker registration")
-	fmt.Println("  database/migrations/..._setup_river_queue.sql  River queue tables")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Run 'lvt gen job <name>' to create your first job handler")
-	fmt.Println("  2. Run 'go mod tidy' to fetch River dependencies")
-	fmt.Println("  3. Run 'lvt migration up' to create River tables")
-	fmt.Println("  4. Start your app — the job worker will start automatically")
-	fmt.Println()
-	fmt.Println("Example:")
-	fmt.Println("  lvt gen job send_email")
+	logging.Println()
+	logging.Println("✅ Background job queue set up successfully!")
+	logging.Println()
+	logging.Println("Generated files:")
+	logging.Println("  app/jobs/worker.go                         Job worker registration")
+	logging.Println("  database/migrations/..._setup_river_queue.sql  River queue tables")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run 'lvt gen job <name>' to create your first job handler")
+	logging.Println("  2. Run 'go mod tidy' to fetch River dependencies")
+	logging.Println("  3. Run 'lvt migration up' to create River tables")
+	logging.Println("  4. Start your app — the job worker will start automatically")
+	logging.Println()
+	logging.Println("Example:")
+	logging.Println("  lvt gen job send_email")
 
 	return nil
 }
@@ -94,18 +95,18 @@ func GenJob(args []string) error {
 		return err
 	}
 
-	fmt.Println()
-	fmt.Printf("✅ Job '%s' created successfully!\n", jobName)
-	fmt.Println()
-	fmt.Println("Generated files:")
-	fmt.Printf("  app/jobs/%s.go    Job handler\n", jobName)
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Edit app/jobs/%s.go to define your payload and logic\n", jobName)
-	fmt.Println("  2. Enqueue jobs from your handlers:")
-	fmt.Println()
-	fmt.Printf("     // In any handler with access to riverClient:\n")
-	fmt.Printf("     riverClient.Insert(ctx, jobs.%sArgs{...}, nil)\n", generator.ToCamelCase(jobName))
+	logging.Println()
+	logging.Printf("✅ Job '%s' created successfully!\n", jobName)
+	logging.Println()
+	logging.Println("Generated files:")
+	logging.Printf("  app/jobs/%s.go    Job handler\n", jobName)
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Edit app/jobs/%s.go to define your payload and logic\n", jobName)
+	logging.Println("  2. Enqueue jobs from your handlers:")
+	logging.Println()
+	logging.Printf("     // In any handler with access to riverClient:\n")
+	logging.Printf("     riverClient.Insert(ctx, jobs.%sArgs{...}, nil)\n", generator.ToCamelCase(jobName))
 
 	return nil
 }
@@ -1,13 +1,14 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/validator"
 )
 
@@ -18,7 +19,7 @@ func Kits(args []string) error {
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("command required: list, create, info, validate, customize")
+		return fmt.Errorf("command required: list, create, info, validate, customize, diff, test, install, update, remove, publish")
 	}
 
 	command := args[0]
@@ -39,8 +40,20 @@ func Kits(args []string) error {
 		return validateKit(args[1:])
 	case "customize":
 		return customizeKit(args[1:])
+	case "install":
+		return installKit(args[1:])
+	case "update":
+		return updateKits(args[1:])
+	case "remove":
+		return removeKit(args[1:])
+	case "publish":
+		return publishKit(args[1:])
+	case "diff":
+		return diffKit(args[1:])
+	case "test":
+		return testKitContract(args[1:])
 	default:
-		return fmt.Errorf("unknown command: %s (expected: list, create, info, validate, customize)", command)
+		return fmt.Errorf("unknown command: %s (expected: list, create, info, validate, customize, diff, test, install, update, remove, publish)", command)
 	}
 }
 
@@ -74,6 +87,9 @@ func listKits(args []string) error {
 	if !validFormats[format] {
 		return fmt.Errorf("invalid format: %s (valid: table, json, simple)", format)
 	}
+	if JSONOutput() {
+		format = "json"
+	}
 
 	// Build search options
 	opts := &kits.KitSearchOptions{
@@ -190,13 +206,110 @@ func outputKitsSimple(kitList []*kits.KitInfo) error {
 	return nil
 }
 
+// kitSummary is a kit of lvt kits list --json.
+type kitSummary struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Description  string   `json:"description"`
+	CSSFramework string   `json:"css_framework"`
+	Source       string   `json:"source"`
+	Path         string   `json:"path"`
+	Author       string   `json:"author,omitempty"`
+	CDN          string   `json:"cdn,omitempty"`
+	Tags         []string `json:"tags"`
+	Extends      string   `json:"extends,omitempty"`
+}
+
+func newKitSummary(kit *kits.KitInfo) kitSummary {
+	tags := kit.Manifest.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return kitSummary{
+		Name:         kit.Manifest.Name,
+		Version:      kit.Manifest.Version,
+		Description:  kit.Manifest.Description,
+		CSSFramework: kit.Manifest.CSSFramework,
+		Source:       string(kit.Source),
+		Path:         kit.Path,
+		Author:       kit.Manifest.Author,
+		CDN:          kit.Manifest.CDN,
+		Tags:         tags,
+		Extends:      kit.Manifest.Extends,
+	}
+}
+
+// kitDetail is the output of lvt kits info --json.
+type kitDetail struct {
+	kitSummary
+	Chain    []kitSummary      `json:"chain"`
+	Helpers  map[string]string `json:"helpers"`
+	Assets   []kitAsset        `json:"assets"`
+	IconSet  string            `json:"icon_set,omitempty"`
+	Icons    []string          `json:"icons"`
+	Warnings []string          `json:"warnings"`
+}
+
+type kitAsset struct {
+	Path   string `json:"path"`
+	Bytes  int    `json:"bytes"`
+	Loaded bool   `json:"loaded"`
+}
+
 func outputKitsJSON(kitList []*kits.KitInfo) error {
-	data, err := json.MarshalIndent(kitList, "", "  ")
+	summaries := make([]kitSummary, 0, len(kitList))
+	for _, kit := range kitList {
+		summaries = append(summaries, newKitSummary(kit))
+	}
+	return printJSON(map[string]any{"kits": summaries})
+}
+
+// outputKitJSON prints the details of a kit for lvt kits info --json.
+func outputKitJSON(kit *kits.KitInfo) error {
+	detail := kitDetail{
+		kitSummary: newKitSummary(kit),
+		Chain:      []kitSummary{},
+		Helpers:    kit.Manifest.Helpers,
+		Assets:     []kitAsset{},
+		IconSet:    kit.IconSet(),
+		Icons:      []string{},
+		Warnings:   []string{},
+	}
+	if detail.Helpers == nil {
+		detail.Helpers = map[string]string{}
+	}
+	if kit.Parent != nil {
+		for _, k := range kit.Chain() {
+			detail.Chain = append(detail.Chain, newKitSummary(k))
+		}
+	}
+
+	assets, err := kit.Assets()
+	if err != nil {
+		return err
+	}
+	// Entries are still returned with the assets the manifest lists but
+	// the kit lacks
+	entries, err := kit.AssetEntries()
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+		detail.Warnings = append(detail.Warnings, err.Error())
 	}
-	fmt.Println(string(data))
-	return nil
+	loaded := make(map[string]bool, len(entries))
+	for _, a := range entries {
+		loaded[a.Path] = true
+	}
+	for _, a := range assets {
+		detail.Assets = append(detail.Assets, kitAsset{Path: a.Path, Bytes: len(a.Data), Loaded: loaded[a.Path]})
+	}
+
+	icons, err := kit.Icons()
+	if err != nil {
+		return err
+	}
+	if icons != nil {
+		detail.Icons = icons
+	}
+	return printJSON(detail)
 }
 
 func createKit(args []string) error {
@@ -389,18 +502,18 @@ Add usage examples and documentation for your kit here.
 		return fmt.Errorf("failed to create README.md: %w", err)
 	}
 
-	fmt.Println("✅ Kit created successfully!")
-	fmt.Println()
-	fmt.Println("Files created:")
-	fmt.Printf("  %s/kit.yaml\n", kitDir)
-	fmt.Printf("  %s/helpers.go\n", kitDir)
-	fmt.Printf("  %s/README.md\n", kitDir)
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Edit helper methods: %s/helpers.go\n", kitDir)
-	fmt.Printf("  2. Update metadata: %s/kit.yaml\n", kitDir)
-	fmt.Printf("  3. Use the kit: lvt gen resource name --css %s\n", kitName)
-	fmt.Println()
+	logging.Println("✅ Kit created successfully!")
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Printf("  %s/kit.yaml\n", kitDir)
+	logging.Printf("  %s/helpers.go\n", kitDir)
+	logging.Printf("  %s/README.md\n", kitDir)
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Edit helper methods: %s/helpers.go\n", kitDir)
+	logging.Printf("  2. Update metadata: %s/kit.yaml\n", kitDir)
+	logging.Printf("  3. Use the kit: lvt gen resource name --css %s\n", kitName)
+	logging.Println()
 
 	return nil
 }
@@ -424,6 +537,10 @@ func infoKit(args []string) error {
 		return fmt.Errorf("failed to load kit %q: %w", kitName, err)
 	}
 
+	if JSONOutput() {
+		return outputKitJSON(kit)
+	}
+
 	// Display kit info
 	fmt.Printf("Kit: %s\n", kit.Manifest.Name)
 	fmt.Printf("Description: %s\n", kit.Manifest.Description)
@@ -445,6 +562,61 @@ func infoKit(args []string) error {
 
 	fmt.Printf("Path: %s\n", kit.Path)
 
+	if kit.Parent != nil {
+		fmt.Printf("Extends: %s\n", kit.Manifest.Extends)
+		fmt.Println()
+		fmt.Println("Resolution chain (templates and components come from the first kit that has them):")
+		for i, k := range kit.Chain() {
+			fmt.Printf("  %d. %s (%s) %s\n", i+1, k.Manifest.Name, k.Source, k.Path)
+		}
+	}
+
+	if len(kit.Manifest.Helpers) > 0 {
+		keys := make([]string, 0, len(kit.Manifest.Helpers))
+		for key := range kit.Manifest.Helpers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Println()
+		fmt.Println("Helper overrides:")
+		for _, key := range keys {
+			fmt.Printf("  %s: %q\n", key, kit.Manifest.Helpers[key])
+		}
+	}
+
+	if assets, err := kit.Assets(); err != nil {
+		fmt.Printf("\nAssets: %v\n", err)
+	} else if len(assets) > 0 {
+		entries, err := kit.AssetEntries()
+		loaded := make(map[string]bool, len(entries))
+		for _, a := range entries {
+			loaded[a.Path] = true
+		}
+		fmt.Println()
+		fmt.Println("Assets (copied to web/assets/" + kit.Manifest.Name + ", * = loaded by the layout instead of the CDN):")
+		for _, a := range assets {
+			mark := " "
+			if loaded[a.Path] {
+				mark = "*"
+			}
+			fmt.Printf("  %s %s (%d bytes)\n", mark, a.Path, len(a.Data))
+		}
+		if err != nil {
+			fmt.Printf("  ⚠️  %v\n", err)
+		}
+	}
+
+	if icons, err := kit.Icons(); err != nil {
+		fmt.Printf("\nIcons: %v\n", err)
+	} else if len(icons) > 0 {
+		set := kit.IconSet()
+		if set == "" {
+			set = "none"
+		}
+		fmt.Println()
+		fmt.Printf("Icons (set: %s): %s\n", set, strings.Join(icons, ", "))
+	}
+
 	// Show README if available
 	readmePath := filepath.Join(kit.Path, "README.md")
 	if content, err := os.ReadFile(readmePath); err == nil {
@@ -473,7 +645,7 @@ func validateKit(args []string) error {
 	result := validator.ValidateKit(kitPath)
 
 	// Print results
-	fmt.Println(result.Format())
+	logging.Println(result.Format())
 
 	// Return error if validation failed
 	if !result.Valid {
@@ -483,6 +655,156 @@ func validateKit(args []string) error {
 	return nil
 }
 
+// newKitInstaller returns an installer that also runs the checks of
+// `lvt kits validate` on fetched kits.
+func newKitInstaller() (*kits.Installer, error) {
+	installer, err := kits.NewInstaller()
+	if err != nil {
+		return nil, err
+	}
+	installer.Check = func(dir string) error {
+		if result := validator.ValidateKit(dir); !result.Valid {
+			return fmt.Errorf("the kit is invalid:\n%s", result.Format())
+		}
+		return nil
+	}
+	return installer, nil
+}
+
+func installKit(args []string) error {
+	var source string
+	var opts kits.InstallOptions
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--version" && i+1 < len(args):
+			opts.Ref = args[i+1]
+			i++
+		case args[i] == "--checksum" && i+1 < len(args):
+			opts.Checksum = args[i+1]
+			i++
+		case args[i] == "--force":
+			opts.Force = true
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		case source == "":
+			source = args[i]
+		default:
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+	if source == "" {
+		return fmt.Errorf("kit name or git URL required: lvt kits install <name|git-url> [--version <tag|commit>]")
+	}
+	if opts.Checksum != "" && !strings.HasPrefix(opts.Checksum, "sha256:") {
+		return fmt.Errorf("invalid --checksum %q (expected sha256:<hex>)", opts.Checksum)
+	}
+
+	installer, err := newKitInstaller()
+	if err != nil {
+		return err
+	}
+	logging.Printf("Installing %s...\n", source)
+	result, err := installer.Install(source, opts)
+	if err != nil {
+		return err
+	}
+	logging.Printf("✅ Installed kit %s (%s) into %s\n", result.Name, describeKitRecord(result.Record), filepath.Join(installer.Dir, result.Name))
+	logging.Printf("   Checksum: %s\n", result.Record.Checksum)
+	if result.Record.Ref != "" {
+		logging.Printf("   Pinned to %s; 'lvt kits update %s --version <ref>' moves it\n", result.Record.Ref, result.Name)
+	}
+	logging.Printf("\nUse it with: lvt new myapp --kit %s\n", result.Name)
+	return nil
+}
+
+func updateKits(args []string) error {
+	var names []string
+	var ref string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--version" && i+1 < len(args):
+			ref = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			names = append(names, args[i])
+		}
+	}
+	if ref != "" && len(names) != 1 {
+		return fmt.Errorf("--version needs exactly one kit name")
+	}
+
+	installer, err := newKitInstaller()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		if names, err = installer.Installed(); err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			logging.Println("No kits installed with 'lvt kits install'")
+			return nil
+		}
+	}
+
+	var failed int
+	for _, name := range names {
+		result, err := installer.Update(name, ref)
+		switch {
+		case err != nil:
+			logging.Printf("❌ %s: %v\n", name, err)
+			failed++
+		case !result.Updated && result.Record.Ref != "":
+			logging.Printf("   %s is pinned to %s (pass --version to move it)\n", name, result.Record.Ref)
+		case !result.Updated:
+			logging.Printf("   %s is up to date (%s)\n", name, describeKitRecord(result.Record))
+		default:
+			logging.Printf("✅ Updated %s: %s → %s\n", name, describeKitRecord(result.Previous), describeKitRecord(result.Record))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d kit(s) failed to update", failed)
+	}
+	return nil
+}
+
+func removeKit(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("kit name required: lvt kits remove <name>")
+	}
+	if err := ValidatePositionalArg(args[0], "kit name"); err != nil {
+		return err
+	}
+	installer, err := newKitInstaller()
+	if err != nil {
+		return err
+	}
+	if err := installer.Remove(args[0]); err != nil {
+		return err
+	}
+	logging.Printf("✅ Removed kit %s\n", args[0])
+	return nil
+}
+
+// describeKitRecord names what an install record points at: the version
+// or pinned ref, and the commit.
+func describeKitRecord(rec *kits.InstallRecord) string {
+	commit := rec.Commit
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	switch {
+	case rec.Version != "":
+		return rec.Version + " @ " + commit
+	case rec.Ref != "" && !strings.HasPrefix(rec.Commit, rec.Ref):
+		return rec.Ref + " @ " + commit
+	default:
+		return commit
+	}
+}
+
 func customizeKit(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("kit name required")
@@ -498,6 +820,7 @@ func customizeKit(args []string) error {
 	// Parse flags
 	scope := "project"      // default to project-level
 	componentsOnly := false // for backward compatibility with --components-only
+	preview := false        // --dry-run: print the files instead of writing them
 
 	for i := 1; i < len(args); i++ {
 		switch args[i] {
@@ -511,6 +834,8 @@ func customizeKit(args []string) error {
 			scope = "global" // backward compatibility
 		case "--components-only":
 			componentsOnly = true
+		case "--dry-run":
+			preview = true
 		}
 	}
 
@@ -527,29 +852,59 @@ func customizeKit(args []string) error {
 	}
 
 	// Determine destination directory
-	var destDir string
-	if scope == "global" {
-		// Respect XDG_CONFIG_HOME if set, otherwise use ~/.config
-		configHome := os.Getenv("XDG_CONFIG_HOME")
-		if configHome == "" {
-			homeDir, err := os.UserHomeDir()
-			if err != nil {
-				return fmt.Errorf("failed to get home directory: %w", err)
-			}
-			configHome = filepath.Join(homeDir, ".config")
+	destDir, err := customizedKitDir(scope, kitName)
+	if err != nil {
+		return err
+	}
+
+	if preview {
+		prefix := filepath.ToSlash(destDir) + "/"
+		if scope == "project" {
+			prefix = ".lvt/kits/" + kitName + "/"
 		}
-		destDir = filepath.Join(configHome, "lvt", "kits", kitName)
+		return previewChanges("kits customize "+kitName, destDir, prefix, func(copy string) error {
+			_, err := writeCustomizedKit(loader, kit, copy, componentsOnly)
+			return err
+		})
+	}
+
+	copiedItems, err := writeCustomizedKit(loader, kit, destDir, componentsOnly)
+	if err != nil {
+		return err
+	}
+
+	// Success message
+	logging.Println("✅ Kit customized successfully!")
+	logging.Println()
+	if scope == "global" {
+		logging.Printf("Location: %s\n", destDir)
 	} else {
-		currentDir, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-		destDir = filepath.Join(currentDir, ".lvt", "kits", kitName)
+		logging.Printf("Location: .lvt/kits/%s/\n", kitName)
+	}
+	logging.Println()
+	logging.Println("Copied items:")
+	for _, item := range copiedItems {
+		logging.Printf("  - %s\n", item)
 	}
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Customize the copied files to match your needs")
+	if scope == "global" {
+		logging.Println("  2. The customized kit will be used for all projects")
+	} else {
+		logging.Println("  2. The customized kit will override system/user kits for this project")
+	}
+	logging.Println()
 
+	return nil
+}
+
+// writeCustomizedKit copies kit's files into destDir, all of them or only
+// its components, and returns the items copied.
+func writeCustomizedKit(loader *kits.KitLoader, kit *kits.KitInfo, destDir string, componentsOnly bool) ([]string, error) {
 	// Create destination directory
 	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	copiedItems := []string{}
@@ -559,23 +914,24 @@ func customizeKit(args []string) error {
 	if !componentsOnly {
 		manifestDest := filepath.Join(destDir, "kit.yaml")
 		var data []byte
+		var err error
 		if isSystemKit {
 			// Read from embedded FS via loader
 			manifestPath := filepath.Join(kit.Path, "kit.yaml")
 			data, err = loader.ReadEmbeddedFile(manifestPath)
 			if err != nil {
-				return fmt.Errorf("failed to read embedded kit.yaml: %w", err)
+				return nil, fmt.Errorf("failed to read embedded kit.yaml: %w", err)
 			}
 		} else {
 			// Read from regular filesystem
 			manifestSrc := filepath.Join(kit.Path, "kit.yaml")
 			data, err = os.ReadFile(manifestSrc)
 			if err != nil {
-				return fmt.Errorf("failed to read kit.yaml: %w", err)
+				return nil, fmt.Errorf("failed to read kit.yaml: %w", err)
 			}
 		}
 		if err := os.WriteFile(manifestDest, data, 0644); err != nil {
-			return fmt.Errorf("failed to write kit.yaml: %w", err)
+			return nil, fmt.Errorf("failed to write kit.yaml: %w", err)
 		}
 		copiedItems = append(copiedItems, "kit.yaml")
 	}
@@ -592,7 +948,7 @@ func customizeKit(args []string) error {
 		componentsSrc := filepath.Join(kit.Path, "components")
 		if info, err := os.Stat(componentsSrc); err == nil && info.IsDir() {
 			if err := copyDir(componentsSrc, componentsDest); err != nil {
-				return fmt.Errorf("failed to copy components: %w", err)
+				return nil, fmt.Errorf("failed to copy components: %w", err)
 			}
 			copiedItems = append(copiedItems, "components/")
 		}
@@ -611,7 +967,7 @@ func customizeKit(args []string) error {
 			templatesSrc := filepath.Join(kit.Path, "templates")
 			if info, err := os.Stat(templatesSrc); err == nil && info.IsDir() {
 				if err := copyDir(templatesSrc, templatesDest); err != nil {
-					return fmt.Errorf("failed to copy templates: %w", err)
+					return nil, fmt.Errorf("failed to copy templates: %w", err)
 				}
 				copiedItems = append(copiedItems, "templates/")
 			}
@@ -626,7 +982,7 @@ func customizeKit(args []string) error {
 			helpersPath := filepath.Join(kit.Path, "helpers.go")
 			if data, err := loader.ReadEmbeddedFile(helpersPath); err == nil {
 				if err := os.WriteFile(helpersDest, data, 0644); err != nil {
-					return fmt.Errorf("failed to write helpers.go: %w", err)
+					return nil, fmt.Errorf("failed to write helpers.go: %w", err)
 				}
 				copiedItems = append(copiedItems, "helpers.go")
 			}
@@ -634,7 +990,7 @@ func customizeKit(args []string) error {
 			helpersSrc := filepath.Join(kit.Path, "helpers.go")
 			if _, err := os.Stat(helpersSrc); err == nil {
 				if err := copyFile(helpersSrc, helpersDest); err != nil {
-					return fmt.Errorf("failed to copy helpers.go: %w", err)
+					return nil, fmt.Errorf("failed to copy helpers.go: %w", err)
 				}
 				copiedItems = append(copiedItems, "helpers.go")
 			}
@@ -646,7 +1002,7 @@ func customizeKit(args []string) error {
 			readmePath := filepath.Join(kit.Path, "README.md")
 			if data, err := loader.ReadEmbeddedFile(readmePath); err == nil {
 				if err := os.WriteFile(readmeDest, data, 0644); err != nil {
-					return fmt.Errorf("failed to write README.md: %w", err)
+					return nil, fmt.Errorf("failed to write README.md: %w", err)
 				}
 				copiedItems = append(copiedItems, "README.md")
 			}
@@ -654,37 +1010,14 @@ func customizeKit(args []string) error {
 			readmeSrc := filepath.Join(kit.Path, "README.md")
 			if _, err := os.Stat(readmeSrc); err == nil {
 				if err := copyFile(readmeSrc, readmeDest); err != nil {
-					return fmt.Errorf("failed to copy README.md: %w", err)
+					return nil, fmt.Errorf("failed to copy README.md: %w", err)
 				}
 				copiedItems = append(copiedItems, "README.md")
 			}
 		}
 	}
 
-	// Success message
-	fmt.Println("✅ Kit customized successfully!")
-	fmt.Println()
-	if scope == "global" {
-		fmt.Printf("Location: %s\n", destDir)
-	} else {
-		fmt.Printf("Location: .lvt/kits/%s/\n", kitName)
-	}
-	fmt.Println()
-	fmt.Println("Copied items:")
-	for _, item := range copiedItems {
-		fmt.Printf("  - %s\n", item)
-	}
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Customize the copied files to match your needs")
-	if scope == "global" {
-		fmt.Println("  2. The customized kit will be used for all projects")
-	} else {
-		fmt.Println("  2. The customized kit will override system/user kits for this project")
-	}
-	fmt.Println()
-
-	return nil
+	return copiedItems, nil
 }
 
 // copyFile copies a single file from src to dst
@@ -731,6 +1064,28 @@ func copyDir(src, dst string) error {
 }
 
 // copyEmbeddedDir copies a directory from embedded FS to regular filesystem
+// customizedKitDir returns where `lvt kits customize` puts a kit's copy
+// for the given scope (project or global).
+func customizedKitDir(scope, kitName string) (string, error) {
+	if scope == "global" {
+		// Respect XDG_CONFIG_HOME if set, otherwise use ~/.config
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to get home directory: %w", err)
+			}
+			configHome = filepath.Join(homeDir, ".config")
+		}
+		return filepath.Join(configHome, "lvt", "kits", kitName), nil
+	}
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return filepath.Join(currentDir, ".lvt", "kits", kitName), nil
+}
+
 func copyEmbeddedDir(loader *kits.KitLoader, embeddedPath, dst string) error {
 	// Create destination directory
 	if err := os.MkdirAll(dst, 0755); err != nil {
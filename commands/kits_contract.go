@@ -0,0 +1,577 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/parser"
+	"github.com/livetemplate/lvt/internal/seeder"
+	"github.com/livetemplate/lvt/internal/ui/progress"
+	"github.com/livetemplate/lvt/internal/validator"
+	e2etest "github.com/livetemplate/lvt/testing"
+)
+
+// kitContractFields are the fields of the resource a kit is tested with:
+// one of every field type the generator knows.
+var kitContractFields = []string{
+	"name:string", "notes:text", "quantity:int", "price:float", "active:bool", "due:time",
+	"contact:email", "website:url", "phone:phone", "secret:password", "settings:json",
+	"status:select:draft,published", "attachment:file", "photo:image",
+}
+
+const (
+	// contractPageSize and contractSeedRows give the resource three pages
+	contractPageSize = 5
+	contractSeedRows = 12
+	// contractItemName is the name of the item the form test adds
+	contractItemName = "Kit contract item"
+)
+
+// contractStatus is the outcome of one contract check.
+type contractStatus string
+
+const (
+	contractPass contractStatus = "pass"
+	contractFail contractStatus = "fail"
+	contractSkip contractStatus = "skip"
+)
+
+// contractCheck is one line of the compatibility report.
+type contractCheck struct {
+	Name     string
+	Status   contractStatus
+	Err      error
+	Reason   string // why the check was skipped
+	Duration time.Duration
+}
+
+// kitContract runs the checks in order; once a check fails, the checks
+// that depend on it are skipped.
+type kitContract struct {
+	steps   *progress.Progress
+	checks  []contractCheck
+	blocked string // reason the remaining checks are skipped
+}
+
+// run runs a check unless an earlier failure blocks it. A failing check
+// blocks every check after it.
+func (c *kitContract) run(name string, fn func() error) bool {
+	if !c.try(name, fn) {
+		if c.blocked == "" {
+			c.blocked = fmt.Sprintf("%q failed", name)
+		}
+		return false
+	}
+	return true
+}
+
+// try runs a check like run, without blocking the checks after it.
+func (c *kitContract) try(name string, fn func() error) bool {
+	if c.blocked != "" {
+		c.skip(name, c.blocked)
+		return false
+	}
+	start := time.Now()
+	err := c.steps.Run(name, fn)
+	check := contractCheck{Name: name, Status: contractPass, Duration: time.Since(start)}
+	if err != nil {
+		check.Status, check.Err = contractFail, err
+		logging.Printf("   %s\n", strings.ReplaceAll(strings.TrimSpace(err.Error()), "\n", "\n   "))
+	}
+	c.checks = append(c.checks, check)
+	return err == nil
+}
+
+// skip records a check that did not run.
+func (c *kitContract) skip(name, reason string) {
+	logging.Printf("⏭️  %s (skipped: %s)\n", name, reason)
+	c.checks = append(c.checks, contractCheck{Name: name, Status: contractSkip, Reason: reason})
+}
+
+// count returns the number of checks with the given status.
+func (c *kitContract) count(status contractStatus) int {
+	n := 0
+	for _, check := range c.checks {
+		if check.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// printReport prints the compatibility report.
+func (c *kitContract) printReport(kitName string) {
+	logging.Printf("\n=== Kit Compatibility Report: %s ===\n\n", kitName)
+	for _, check := range c.checks {
+		switch check.Status {
+		case contractPass:
+			logging.Printf("✅ PASS %s (%v)\n", check.Name, check.Duration.Round(time.Millisecond))
+		case contractFail:
+			logging.Printf("❌ FAIL %s (%v)\n", check.Name, check.Duration.Round(time.Millisecond))
+			logging.Printf("   Error: %s\n", firstLine(check.Err.Error()))
+		case contractSkip:
+			logging.Printf("⏭️  SKIP %s (%s)\n", check.Name, check.Reason)
+		}
+	}
+	logging.Printf("\nResults: %d passed, %d failed, %d skipped\n", c.count(contractPass), c.count(contractFail), c.count(contractSkip))
+}
+
+// testKitContract generates a throwaway app with the kit, builds and boots
+// it, and drives it in a headless browser, reporting which parts of the
+// contract the generated code relies on the kit keeps.
+func testKitContract(args []string) error {
+	path := "."
+	browser, keep := true, false
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--no-browser":
+			browser = false
+		case args[i] == "--keep":
+			keep = true
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("expected one kit path, got %s", strings.Join(positional, " "))
+	}
+	if len(positional) == 1 {
+		path = positional[0]
+	}
+	kitDir, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	c := &kitContract{steps: progress.New(logging.Messages(), 0)}
+	var manifest *kits.KitManifest
+	logging.Printf("Testing %s against the kit contract\n\n", kitDir)
+	c.run("Kit validates", func() error {
+		result := validator.ValidateKit(kitDir)
+		if !result.Valid {
+			return fmt.Errorf("%d validation error(s):\n%s", result.ErrorCount(), result.Format())
+		}
+		manifest, err = kits.LoadManifest(kitDir)
+		return err
+	})
+	kitName := filepath.Base(kitDir)
+	if manifest != nil {
+		kitName = manifest.Name
+		if !kitHasTemplates(manifest) {
+			c.blocked = "the kit declares no app or resource templates"
+		}
+	}
+
+	var app *sampleApp
+	if c.blocked == "" {
+		if app, err = newSampleApp(kitDir, manifest); err != nil {
+			return err
+		}
+		app.keep = keep
+		defer app.close()
+	}
+
+	c.run("Generates an app and a resource with every field type", func() error {
+		if err := app.generateApp(); err != nil {
+			return err
+		}
+		return app.generateResource(kitContractFields, "prev-next", contractPageSize)
+	})
+	c.run("All generated templates parse", func() error {
+		return parseAppTemplates(app.dir)
+	})
+	var output []byte
+	c.run("Dependencies install (go mod tidy)", func() error {
+		if output, err = goCommand(app.dir, "mod", "tidy"); err != nil {
+			return fmt.Errorf("%w\n%s", err, output)
+		}
+		return nil
+	})
+	binary := ""
+	c.run("App compiles (go build)", func() error {
+		binary = filepath.Join(app.work, "sample-app")
+		if runtime.GOOS == "windows" {
+			binary += ".exe"
+		}
+		if output, err = goCommand(app.dir, "build", "-o", binary, "./cmd/sample"); err != nil {
+			return fmt.Errorf("%w\n%s", err, output)
+		}
+		return nil
+	})
+	c.run("Migrations run and sample data seeds", func() error {
+		if err := app.migrate(); err != nil {
+			return err
+		}
+		return seedContractItems()
+	})
+
+	var baseURL string
+	stopApp := func() {}
+	defer func() { stopApp() }()
+	c.run("App boots and serves /items", func() error {
+		baseURL, stopApp, err = bootSampleApp(app.dir, binary)
+		if err != nil {
+			stopApp = func() {}
+			return err
+		}
+		return checkItemsPage(baseURL)
+	})
+
+	browserChecks := []string{"Page renders in the browser", "Add modal opens", "Add form submits", "Pagination moves to the next page"}
+	chrome := findChrome()
+	if c.blocked == "" && !browser {
+		c.blocked = "--no-browser"
+	} else if c.blocked == "" && chrome == "" {
+		c.blocked = "Chrome or Chromium not found; set LVT_CHROME to its path"
+	}
+	if c.blocked != "" {
+		for _, name := range browserChecks {
+			c.skip(name, c.blocked)
+		}
+	} else {
+		runBrowserChecks(c, chrome, baseURL, app.dir, browserChecks)
+	}
+
+	c.printReport(kitName)
+	if keep && app != nil {
+		logging.Printf("\nThe generated app is kept in %s\n", app.dir)
+	}
+	if failed := c.count(contractFail); failed > 0 {
+		return fmt.Errorf("kit %s failed %d contract check(s)", kitName, failed)
+	}
+	logging.Printf("\n✅ Kit %s passes the kit contract\n", kitName)
+	return nil
+}
+
+// parseAppTemplates parses every .tmpl file of the generated app.
+func parseAppTemplates(dir string) error {
+	var errs []string
+	parsed := 0
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".tmpl" {
+			return nil
+		}
+		parsed++
+		if err := generator.ValidateTemplate(path); err != nil {
+			errs = append(errs, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if parsed == 0 {
+		return fmt.Errorf("no templates were generated")
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// seedContractItems fills the items table with enough rows for three
+// pages.
+func seedContractItems() error {
+	schemaPath, err := seeder.FindSchemaFile()
+	if err != nil {
+		return err
+	}
+	tables, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	table := seeder.FindTable(tables, "items")
+	if table == nil {
+		return fmt.Errorf("no items table in %s", schemaPath)
+	}
+	s, err := seeder.New()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return s.Seed(*table, contractSeedRows, seeder.SeedOptions{Tables: tables})
+}
+
+// bootSampleApp starts the compiled app on a free port and waits for its
+// health check. stop kills it.
+func bootSampleApp(dir, binary string) (string, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	var logs strings.Builder
+	cmd := exec.Command(binary)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), fmt.Sprintf("PORT=%d", port), "DATABASE_PATH="+filepath.Join(dir, "app.db"), "LVT_ENV=development")
+	cmd.Stdout = &logs
+	cmd.Stderr = &logs
+	if err := cmd.Start(); err != nil {
+		return "", nil, err
+	}
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	stop := func() {
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+
+	baseURL := fmt.Sprintf("http://localhost:%d", port)
+	healthURL := baseURL + e2etest.HealthPath("healthz")
+	client := &http.Client{Timeout: 2 * time.Second}
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		select {
+		case <-exited:
+			return "", nil, fmt.Errorf("the app exited during startup:\n%s", strings.TrimSpace(logs.String()))
+		default:
+		}
+		if resp, err := client.Get(healthURL); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return baseURL, stop, nil
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	stop()
+	return "", nil, fmt.Errorf("the app did not answer on %s within 30s:\n%s", healthURL, strings.TrimSpace(logs.String()))
+}
+
+// checkItemsPage fetches /items and checks it rendered completely.
+func checkItemsPage(baseURL string) error {
+	resp, err := http.Get(baseURL + "/items")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET /items returned %s:\n%s", resp.Status, firstLine(string(body)))
+	}
+	page := string(body)
+	for _, leak := range []string{"{{", "[[", "template: ", "can't evaluate field", "<no value>"} {
+		if strings.Contains(page, leak) {
+			return fmt.Errorf("GET /items contains %q; a template did not render", leak)
+		}
+	}
+	if !strings.Contains(page, "data-lvt-id") {
+		return fmt.Errorf("GET /items has no LiveTemplate wrapper (data-lvt-id)")
+	}
+	return nil
+}
+
+// runBrowserChecks drives /items in headless Chrome: the page renders, the
+// add modal opens, the add form saves an item and pagination moves on.
+func runBrowserChecks(c *kitContract, chrome, baseURL, appDir string, names []string) {
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(),
+		append(chromedp.DefaultExecAllocatorOptions[:],
+			chromedp.ExecPath(chrome),
+			chromedp.Flag("headless", true),
+			chromedp.Flag("disable-gpu", true),
+			chromedp.Flag("no-sandbox", true),
+		)...)
+	defer allocCancel()
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+	ctx, timeoutCancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer timeoutCancel()
+
+	const addButton = `[command="show-modal"][commandfor="add-modal"]`
+	c.run(names[0], func() error {
+		return chromedp.Run(ctx,
+			chromedp.Navigate(baseURL+"/items"),
+			e2etest.WaitForWebSocketReady(20*time.Second),
+			e2etest.ValidateNoTemplateExpressions("[data-lvt-id]"),
+		)
+	})
+
+	opened := c.try(names[1], func() error {
+		return chromedp.Run(ctx,
+			chromedp.WaitVisible(addButton, chromedp.ByQuery),
+			chromedp.Click(addButton, chromedp.ByQuery),
+			e2etest.WaitFor(`document.querySelector('dialog#add-modal')?.open === true`, 5*time.Second),
+		)
+	})
+
+	if opened {
+		c.try(names[2], func() error {
+			if err := chromedp.Run(ctx,
+				chromedp.Evaluate(fillAddFormScript(), nil),
+				chromedp.Click(`form[name="add"] button[type="submit"]`, chromedp.ByQuery),
+			); err != nil {
+				return err
+			}
+			return waitForContractItem(ctx, filepath.Join(appDir, "app.db"))
+		})
+	} else {
+		reason := c.blocked
+		if reason == "" {
+			reason = fmt.Sprintf("%q failed", names[1])
+		}
+		c.skip(names[2], reason)
+	}
+
+	c.try(names[3], func() error {
+		var before string
+		return chromedp.Run(ctx,
+			chromedp.Navigate(baseURL+"/items"),
+			e2etest.WaitForWebSocketReady(20*time.Second),
+			chromedp.Text(`table tbody`, &before, chromedp.ByQuery),
+			chromedp.Click(`button[name="next_page"]`, chromedp.ByQuery),
+			e2etest.WaitFor(fmt.Sprintf(`(() => {
+				const body = document.querySelector('table tbody');
+				return body !== null && body.innerText !== %q;
+			})()`, before), 10*time.Second),
+		)
+	})
+}
+
+// fillAddFormScript returns JavaScript that fills every field of the add
+// form with a valid value for its type.
+func fillAddFormScript() string {
+	fields, _ := parser.ParseFields(kitContractFields)
+	var values strings.Builder
+	for _, f := range fields {
+		value := ""
+		switch {
+		case f.Name == "name":
+			value = contractItemName
+		case f.IsFile:
+			continue
+		case f.IsSelect:
+			value = f.SelectOptions[0]
+		case f.IsJSON:
+			value = `{"contract": true}`
+		case f.Metadata.IsPassword:
+			value = "contract-pass-123"
+		default:
+			switch f.Metadata.HTMLInputType {
+			case "email":
+				value = "kit@example.com"
+			case "url":
+				value = "https://example.com"
+			case "tel":
+				value = "+1 555 0100"
+			case "datetime-local":
+				value = "2030-01-02T15:04"
+			case "number":
+				value = "42"
+			case "checkbox":
+				value = "true"
+			default:
+				value = "Kit contract " + f.Name
+			}
+		}
+		fmt.Fprintf(&values, "%q: %q, ", f.Name, value)
+	}
+	return fmt.Sprintf(`(() => {
+		const values = {%s};
+		const form = document.querySelector('form[name="add"]');
+		for (const [name, value] of Object.entries(values)) {
+			for (const el of form.querySelectorAll('[name="' + name + '"]')) {
+				if (el.type === 'checkbox') {
+					el.checked = value === 'true';
+				} else {
+					el.value = value;
+				}
+				el.dispatchEvent(new Event('input', {bubbles: true}));
+				el.dispatchEvent(new Event('change', {bubbles: true}));
+			}
+		}
+		return true;
+	})()`, values.String())
+}
+
+// waitForContractItem polls the database until the item the add form
+// submitted is saved.
+func waitForContractItem(ctx context.Context, dbPath string) error {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM items WHERE name = ?", contractItemName).Scan(&n); err == nil && n > 0 {
+			return nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	var errorText string
+	_ = chromedp.Run(ctx, chromedp.Evaluate(`(() => {
+		const dialog = document.querySelector('dialog#add-modal');
+		return dialog ? dialog.innerText : '';
+	})()`, &errorText))
+	return fmt.Errorf("no item was saved within 15s; the add form shows:\n%s", strings.TrimSpace(errorText))
+}
+
+// findChrome returns the path of a Chrome or Chromium executable, or ""
+// when none is installed. LVT_CHROME overrides the search.
+func findChrome() string {
+	if path := os.Getenv("LVT_CHROME"); path != "" {
+		return path
+	}
+	candidates := []string{"headless-shell", "headless_shell", "chromium", "chromium-browser", "google-chrome", "google-chrome-stable", "chrome"}
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = append(candidates,
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium")
+	case "windows":
+		candidates = append(candidates, "chrome.exe",
+			`C:\Program Files\Google\Chrome\Application\chrome.exe`,
+			`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`)
+	}
+	for _, candidate := range candidates {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestKitContractFieldsCoverEveryType(t *testing.T) {
+	fields, err := parser.ParseFields(kitContractFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := make(map[string]bool)
+	for _, f := range fields {
+		types[f.Type] = true
+	}
+	for _, want := range []string{"string", "text", "int", "float", "bool", "time", "email", "url", "phone", "password", "json", "select", "file", "image"} {
+		if !types[want] {
+			t.Errorf("kitContractFields has no %s field", want)
+		}
+	}
+}
+
+func TestParseAppTemplates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := parseAppTemplates(dir); err == nil {
+		t.Error("parseAppTemplates() on an empty app = nil, want an error")
+	}
+
+	write("app/items/items.tmpl", `{{define "content"}}{{range .Items}}{{.Name}}{{end}}{{end}}`)
+	if err := parseAppTemplates(dir); err != nil {
+		t.Errorf("parseAppTemplates() = %v, want nil", err)
+	}
+
+	write("app/home/home.tmpl", `{{if .Title}}{{.Title}}`)
+	err := parseAppTemplates(dir)
+	if err == nil || !strings.Contains(err.Error(), "home.tmpl") {
+		t.Errorf("parseAppTemplates() = %v, want an error naming home.tmpl", err)
+	}
+}
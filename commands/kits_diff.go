@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffKit compares a customized kit with the kit embedded in this lvt and
+// optionally merges upstream changes into it, one change at a time.
+func diffKit(args []string) error {
+	scope := ""
+	stat, merge := false, false
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--scope" && i+1 < len(args):
+			scope = args[i+1]
+			i++
+		case args[i] == "--stat":
+			stat = true
+		case args[i] == "--merge":
+			merge = true
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("kit name required: lvt kits diff <name> [--scope project|global] [--stat] [--merge]")
+	}
+	kitName := positional[0]
+	if scope != "" && scope != "project" && scope != "global" {
+		return fmt.Errorf("invalid scope: %s (expected: project or global)", scope)
+	}
+
+	// Without --scope, the copy the loader would pick: project, then global
+	var kitDir string
+	scopes := []string{"project", "global"}
+	if scope != "" {
+		scopes = []string{scope}
+	}
+	for _, s := range scopes {
+		dir, err := customizedKitDir(s, kitName)
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			kitDir = dir
+			break
+		}
+	}
+	if kitDir == "" {
+		return fmt.Errorf("no customized copy of kit %q found (run 'lvt kits customize %s' first)", kitName, kitName)
+	}
+
+	diff, err := kits.DiffWithEmbedded(kitDir, kitName)
+	if err != nil {
+		return err
+	}
+	modified := diff.Modified()
+
+	fmt.Printf("Comparing %s with the %s kit embedded in this lvt\n\n", displayPath(kitDir), kitName)
+	for _, f := range diff.Files {
+		switch f.Status {
+		case kits.StatusModified:
+			added, removed := f.Stat()
+			fmt.Printf("  M %s (+%d -%d)\n", f.Path, added, removed)
+		case kits.StatusLocalOnly:
+			fmt.Printf("  A %s (only in your copy)\n", f.Path)
+		}
+	}
+	fmt.Printf("\n%d modified, %d only in your copy, %d unchanged, %d not customized (used from the embedded kit)\n",
+		len(modified), diff.Count(kits.StatusLocalOnly), diff.Count(kits.StatusUnchanged), len(diff.Inherited))
+
+	if len(modified) == 0 {
+		fmt.Println("\n✅ Your copy matches the embedded kit")
+		return nil
+	}
+	if merge {
+		return mergeKitChanges(diff, os.Stdin, os.Stdout)
+	}
+	if !stat {
+		for _, f := range modified {
+			fmt.Println()
+			fmt.Print(f.Unified(filepath.ToSlash(filepath.Join("embedded", kitName, f.Path)), filepath.ToSlash(filepath.Join(displayPath(kitDir), f.Path)), diffContext))
+		}
+		fmt.Printf("\nLines with - are in the embedded kit, lines with + in your copy.\n")
+	}
+	fmt.Printf("Run 'lvt kits diff %s --merge' to take upstream changes one by one.\n", kitName)
+	return nil
+}
+
+// mergeKitChanges walks every change of the modified files and asks
+// whether to take the upstream version, writing each file once its
+// changes are decided.
+func mergeKitChanges(diff *kits.KitDiff, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	taken, files := 0, 0
+
+	for _, f := range diff.Modified() {
+		accept := make([]bool, f.Changes())
+		decided := false
+		quit := false
+		for i := 0; i < f.Changes() && !decided && !quit; i++ {
+			fmt.Fprintf(out, "\n%s (change %d of %d)\n%s", f.Path, i+1, f.Changes(), f.FormatChange(i, diffContext))
+			for {
+				fmt.Fprint(out, "Take the upstream version? [y]es, [n]o, [a]ll in file, [d]one with file, [q]uit: ")
+				answer, err := reader.ReadString('\n')
+				if err != nil && answer == "" {
+					// No more input: stop, keeping what was decided
+					quit = true
+					break
+				}
+				switch strings.TrimSpace(strings.ToLower(answer)) {
+				case "y", "yes":
+					accept[i] = true
+				case "n", "no":
+				case "a", "all":
+					for j := i; j < len(accept); j++ {
+						accept[j] = true
+					}
+					decided = true
+				case "d", "done":
+					decided = true
+				case "q", "quit":
+					quit = true
+				default:
+					continue
+				}
+				break
+			}
+		}
+
+		n := 0
+		for _, ok := range accept {
+			if ok {
+				n++
+			}
+		}
+		if n > 0 {
+			merged := f.Merge(func(change int) bool { return accept[change] })
+			if err := os.WriteFile(filepath.Join(diff.Dir, filepath.FromSlash(f.Path)), []byte(merged), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", f.Path, err)
+			}
+			fmt.Fprintf(out, "✅ Updated %s (%d of %d changes from upstream)\n", f.Path, n, f.Changes())
+			taken += n
+			files++
+		}
+		if quit {
+			break
+		}
+	}
+
+	fmt.Fprintf(out, "\nTook %d upstream change(s) into %d file(s)\n", taken, files)
+	return nil
+}
+
+// displayPath shortens path to be relative to the working directory when
+// it is inside it.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
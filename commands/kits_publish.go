@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/migration"
+	"github.com/livetemplate/lvt/internal/parser"
+	"github.com/livetemplate/lvt/internal/ui/progress"
+	"github.com/livetemplate/lvt/internal/validator"
+)
+
+// kitTestFields are the fields of the sample resource a kit's templates
+// are tested against: one of each type the generator handles.
+var kitTestFields = []string{"name:string", "notes:text", "price:float", "quantity:int", "active:bool", "due:time"}
+
+// publishKit validates a kit, tests its templates, packages it and
+// optionally uploads the package.
+func publishKit(args []string) error {
+	path := "."
+	out := "dist"
+	registry := ""
+	release, skipTest := false, false
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--out" && i+1 < len(args):
+			out = args[i+1]
+			i++
+		case args[i] == "--registry" && i+1 < len(args):
+			registry = args[i+1]
+			i++
+		case args[i] == "--release":
+			release = true
+		case args[i] == "--skip-test":
+			skipTest = true
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("expected one kit path, got %s", strings.Join(positional, " "))
+	}
+	if len(positional) == 1 {
+		path = positional[0]
+	}
+	kitDir, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	logging.Printf("Validating %s...\n", kitDir)
+	result := validator.ValidateKit(kitDir)
+	logging.Println(result.Format())
+	if !result.Valid {
+		return fmt.Errorf("validation failed with %d error(s)", result.ErrorCount())
+	}
+	manifest, err := kits.LoadManifest(kitDir)
+	if err != nil {
+		return err
+	}
+
+	if skipTest {
+		logging.Println("Skipping the template test (--skip-test)")
+	} else if err := testKitTemplates(kitDir, manifest); err != nil {
+		return fmt.Errorf("template test failed: %w", err)
+	}
+
+	pkgPath, pkg, err := kits.Package(kitDir, out)
+	if err != nil {
+		return fmt.Errorf("failed to package the kit: %w", err)
+	}
+	data, err := os.ReadFile(pkgPath)
+	if err != nil {
+		return err
+	}
+	logging.Printf("\n✅ Packaged %s %s: %s (%d files)\n", pkg.Name, pkg.Version, pkgPath, len(pkg.Files))
+	logging.Printf("   Kit checksum: %s\n", pkg.Checksum)
+	if dirty := gitOutput(kitDir, "status", "--porcelain"); dirty != "" {
+		logging.Println("   ⚠️  The kit has uncommitted changes: the checksum covers them, a git tag would not")
+	}
+
+	tag := "v" + strings.TrimPrefix(pkg.Version, "v")
+	if release {
+		if err := createKitRelease(kitDir, tag, pkgPath, pkg); err != nil {
+			return err
+		}
+	}
+	if registry != "" {
+		if err := uploadKitPackage(registry, pkgPath, data, pkg); err != nil {
+			return err
+		}
+	}
+
+	repository := gitOutput(kitDir, "remote", "get-url", "origin")
+	if repository == "" {
+		repository = "<git URL>"
+	}
+	logging.Println()
+	logging.Println("To list this version in a kit index (see 'lvt kits install'), add:")
+	logging.Printf("  - name: %s\n", pkg.Name)
+	logging.Printf("    repository: %s\n", repository)
+	logging.Println("    versions:")
+	logging.Printf("      - version: %s\n", tag)
+	if commit := gitOutput(kitDir, "rev-parse", "HEAD"); commit != "" {
+		logging.Printf("        commit: %s\n", commit)
+	}
+	logging.Printf("        checksum: %s\n", pkg.Checksum)
+	return nil
+}
+
+// testKitTemplates generates an app and a sample resource with the kit in
+// a temp dir, the way `lvt new` and `lvt gen resource` would, and vets the
+// result.
+func testKitTemplates(kitDir string, manifest *kits.KitManifest) error {
+	if !kitHasTemplates(manifest) {
+		logging.Println("The kit declares no app or resource templates; skipping the template test")
+		return nil
+	}
+	app, err := newSampleApp(kitDir, manifest)
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	logging.Printf("\nTesting the templates with a sample app (lvt new sample --kit %s, lvt gen resource items %s)\n", app.appKit, strings.Join(kitTestFields, " "))
+	steps := progress.New(logging.Messages(), 4)
+	if err := steps.Run("Generating the app", app.generateApp); err != nil {
+		return err
+	}
+	if err := steps.Run("Generating the items resource", func() error {
+		return app.generateResource(kitTestFields, "infinite", 20)
+	}); err != nil {
+		return err
+	}
+
+	var output []byte
+	if err := steps.Run("Installing dependencies (go mod tidy)", func() error {
+		output, err = goCommand(app.dir, "mod", "tidy")
+		return err
+	}); err != nil {
+		return fmt.Errorf("go mod tidy failed: %w\n%s", err, output)
+	}
+	if err := app.migrate(); err != nil {
+		return err
+	}
+	if err := steps.Run("Vetting the generated code (go vet ./...)", func() error {
+		output, err = goCommand(app.dir, "vet", "./...")
+		return err
+	}); err != nil {
+		return fmt.Errorf("the generated app does not vet: %w\n%s", err, output)
+	}
+	return nil
+}
+
+// kitHasTemplates reports whether the kit can generate an app or a
+// resource, through its own templates or the kit it extends.
+func kitHasTemplates(manifest *kits.KitManifest) bool {
+	return manifest.Templates.App || manifest.Templates.Resource || manifest.Extends != ""
+}
+
+// sampleApp is a throwaway app generated with a kit under test. Templates
+// the kit does not declare come from the multi kit, or from the kit it
+// extends.
+type sampleApp struct {
+	work string // temp dir holding the kit and the app
+	dir  string // the app, work/sample
+	orig string // working directory to restore
+
+	appKit      string
+	resourceKit string
+	styles      string
+	keep        bool // leave the temp dir in place on close
+}
+
+// newSampleApp copies the kit into a temp dir as a project kit, where it
+// shadows any installed copy, and changes into that dir. Call close to
+// restore the working directory and remove the temp dir.
+func newSampleApp(kitDir string, manifest *kits.KitManifest) (*sampleApp, error) {
+	app := &sampleApp{appKit: "multi", resourceKit: "multi"}
+	if manifest.Templates.App || manifest.Extends != "" {
+		app.appKit = manifest.Name
+	}
+	if manifest.Templates.Resource || manifest.Extends != "" {
+		app.resourceKit = manifest.Name
+	}
+
+	var err error
+	if app.orig, err = os.Getwd(); err != nil {
+		return nil, err
+	}
+	if app.work, err = os.MkdirTemp("", "lvt-kit-test-"); err != nil {
+		return nil, err
+	}
+	app.dir = filepath.Join(app.work, "sample")
+	if err := copyDir(kitDir, filepath.Join(app.work, ".lvt", "kits", manifest.Name)); err != nil {
+		app.close()
+		return nil, err
+	}
+	if err := os.Chdir(app.work); err != nil {
+		app.close()
+		return nil, err
+	}
+
+	// The CSS framework may be inherited
+	kitInfo, err := kits.DefaultLoader().Load(manifest.Name)
+	if err != nil {
+		app.close()
+		return nil, err
+	}
+	app.styles = "unstyled"
+	if framework := kitInfo.Manifest.CSSFramework; framework == "tailwind" || framework == "daisyui" {
+		app.styles = "tailwind"
+	}
+	return app, nil
+}
+
+// generateApp runs the equivalent of `lvt new sample` and changes into the
+// app.
+func (a *sampleApp) generateApp() error {
+	if err := generator.GenerateApp("sample", "sample", a.appKit, a.styles, "", false); err != nil {
+		return err
+	}
+	return os.Chdir(a.dir)
+}
+
+// generateResource runs the equivalent of `lvt gen resource items <fields>`
+// with modal editing.
+func (a *sampleApp) generateResource(fieldSpecs []string, pagination string, pageSize int) error {
+	kitInfo, err := kits.DefaultLoader().Load(a.resourceKit)
+	if err != nil {
+		return err
+	}
+	fields, err := parser.ParseFields(fieldSpecs)
+	if err != nil {
+		return err
+	}
+	return generator.GenerateResource(a.dir, "sample", "items", fields, a.resourceKit, kitInfo.Manifest.CSSFramework, a.styles,
+		pagination, pageSize, "modal", "", false, true)
+}
+
+// migrate applies the app's migrations to its development database.
+func (a *sampleApp) migrate() error {
+	runner, err := migration.New()
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+	return runner.Up()
+}
+
+// close restores the working directory and removes the app unless keep
+// is set.
+func (a *sampleApp) close() {
+	_ = os.Chdir(a.orig)
+	if !a.keep {
+		_ = os.RemoveAll(a.work)
+	}
+}
+
+// createKitRelease creates a GitHub release of the kit's repository for
+// tag, with the package attached.
+func createKitRelease(kitDir, tag, pkgPath string, pkg *kits.PackageManifest) error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return fmt.Errorf("--release needs the GitHub CLI (gh): https://cli.github.com")
+	}
+	notes := fmt.Sprintf("%s %s\n\nKit checksum: `%s`\n\nInstall with:\n\n    lvt kits install <repository URL> --version %s --checksum %s\n",
+		pkg.Name, tag, pkg.Checksum, tag, pkg.Checksum)
+	cmd := exec.Command("gh", "release", "create", tag, pkgPath, "--title", pkg.Name+" "+tag, "--notes", notes)
+	cmd.Dir = kitDir
+	cmd.Stdout = logging.Messages()
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("gh release create failed: %w", err)
+	}
+	logging.Printf("✅ Created GitHub release %s\n", tag)
+	return nil
+}
+
+// uploadKitPackage PUTs the package to <registry>/<package file name>,
+// with LVT_REGISTRY_TOKEN as a bearer token when set.
+func uploadKitPackage(registry, pkgPath string, data []byte, pkg *kits.PackageManifest) error {
+	url := strings.TrimSuffix(registry, "/") + "/" + filepath.Base(pkgPath)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid --registry: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("X-Kit-Checksum", pkg.Checksum)
+	if token := os.Getenv("LVT_REGISTRY_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("upload to %s failed: %s %s", url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	logging.Printf("✅ Uploaded to %s\n", url)
+	return nil
+}
+
+// gitOutput runs git in dir and returns its trimmed output, or "" when dir
+// is not a repository or git fails.
+func gitOutput(dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
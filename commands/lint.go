@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/lint"
+)
+
+// Lint checks templates with the lint rules (lvt parse --lint).
+func Lint(args []string) error {
+	format := "text"
+	var paths []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--lint":
+		case arg == "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires a value (text or json)")
+			}
+			i++
+			format = args[i]
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		default:
+			paths = append(paths, arg)
+		}
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid --format %q (valid: text, json)", format)
+	}
+	if JSONOutput() {
+		format = "json"
+	}
+
+	projectConfig, err := config.LoadProjectConfig(".")
+	if err != nil {
+		return err
+	}
+	cfg, err := lint.ConfigFrom(projectConfig.LintRules)
+	if err != nil {
+		return fmt.Errorf("invalid lint settings in %s: %w", config.ProjectConfigFileName, err)
+	}
+
+	files, err := templateFiles(defaultTemplatePaths(paths))
+	if err != nil {
+		return err
+	}
+
+	issues := []lint.Issue{}
+	for _, file := range files {
+		fileIssues, err := lint.File(file, cfg)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, fileIssues...)
+	}
+	errors, warnings := 0, 0
+	for _, issue := range issues {
+		if issue.Severity == lint.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{
+			"files":    len(files),
+			"errors":   errors,
+			"warnings": warnings,
+			"issues":   issues,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		if len(issues) == 0 {
+			fmt.Printf("✅ No issues in %d template(s)\n", len(files))
+		} else {
+			fmt.Printf("\n%d error(s), %d warning(s) in %d template(s)\n", errors, warnings, len(files))
+		}
+	}
+
+	if errors > 0 {
+		return fmt.Errorf("lint found %d error(s)", errors)
+	}
+	return nil
+}
+
+// ParseGraph prints which templates and defines invoke which (lvt parse
+// graph), and reports dead defines and missing templates.
+func ParseGraph(args []string) error {
+	format := "mermaid"
+	var paths []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires a value (mermaid or json)")
+			}
+			i++
+			format = args[i]
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		default:
+			paths = append(paths, arg)
+		}
+	}
+	if format != "mermaid" && format != "json" {
+		return fmt.Errorf("invalid --format %q (valid: mermaid, json)", format)
+	}
+	if JSONOutput() {
+		format = "json"
+	}
+
+	files, err := templateFiles(defaultTemplatePaths(paths))
+	if err != nil {
+		return err
+	}
+	graph, err := lint.BuildGraph(files)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(graph)
+	}
+
+	// The report goes to stderr, so the diagram can be redirected to a file
+	fmt.Print(graph.Mermaid())
+	for _, issue := range graph.Errors {
+		fmt.Fprintln(os.Stderr, issue)
+	}
+	for _, n := range graph.Unused {
+		fmt.Fprintf(os.Stderr, "%s:%d: define %q is never invoked\n", n.File, n.Line, n.Name)
+	}
+	for _, ref := range graph.Missing {
+		msg := fmt.Sprintf("%s:%d: template %q is not defined", ref.File, ref.Line, ref.Name)
+		if ref.Suggestion != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", ref.Suggestion)
+		}
+		fmt.Fprintln(os.Stderr, msg)
+	}
+	fmt.Fprintf(os.Stderr, "%d template(s), %d unused define(s), %d missing template(s)\n", len(files), len(graph.Unused), len(graph.Missing))
+	return nil
+}
+
+// defaultTemplatePaths returns paths, or app/ (else the current directory)
+// when none are given.
+func defaultTemplatePaths(paths []string) []string {
+	if len(paths) > 0 {
+		return paths
+	}
+	if info, err := os.Stat("app"); err == nil && info.IsDir() {
+		return []string{"app"}
+	}
+	return []string{"."}
+}
+
+// templateFiles expands paths (files, directories or ./... patterns) to the
+// .tmpl files they name or contain.
+func templateFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		// Go-style ./... patterns name the directory and everything in it
+		if trimmed := strings.TrimSuffix(path, "..."); trimmed != path {
+			path = filepath.Clean(trimmed)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("template path not found: %s", path)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if name := d.Name(); p != path && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(p, ".tmpl") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/devlog"
+)
+
+// Logs prints the development log lvt serve keeps of the app in the working
+// directory: its output, its pages' browser console and their WebSocket
+// traffic, keyed by session. With --docker it prints the output of the
+// app's container instead.
+func Logs(args []string) error {
+	if ShowHelpIfRequested(args, printLogsHelp) {
+		return nil
+	}
+
+	var (
+		follow, docker bool
+		since          string
+		filter         devlog.Filter
+	)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-f" || args[i] == "--follow":
+			follow = true
+		case args[i] == "--json":
+			EnableJSON()
+		case args[i] == "--docker":
+			docker = true
+		case args[i] == "--since" && i+1 < len(args):
+			since = args[i+1]
+			t, err := parseSince(since, time.Now())
+			if err != nil {
+				return err
+			}
+			filter.Since = t
+			i++
+		case args[i] == "--session" && i+1 < len(args):
+			filter.Session = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if docker {
+		return dockerLogs(ctx, dir, follow, since, filter.Session)
+	}
+	if _, err := os.Stat(filepath.Join(dir, devlog.Path)); os.IsNotExist(err) && !follow {
+		return fmt.Errorf("no %s here: it is written by lvt serve, so start the app with it first (or use --docker for its container)", devlog.Path)
+	}
+	if follow {
+		return devlog.Follow(ctx, dir, filter, printLogEntry)
+	}
+	return devlog.Read(dir, filter, printLogEntry)
+}
+
+// printLogEntry prints e as a line of text, or of JSON with --json.
+func printLogEntry(e devlog.Entry) {
+	if JSONOutput() {
+		if data, err := json.Marshal(e); err == nil {
+			fmt.Println(string(data))
+		}
+		return
+	}
+	fmt.Println(devlog.Format(e))
+}
+
+// parseSince reads --since: a duration before now, such as 5m, or an
+// RFC 3339 time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (expected a duration such as 5m or an RFC 3339 time)", s)
+}
+
+// dockerLogs prints the output of the app's container: the one `make run`
+// starts, named after the project, or else the app service of
+// deploy/docker-compose.yml. Docker filters by --since itself.
+func dockerLogs(ctx context.Context, dir string, follow bool, since, session string) error {
+	name := filepath.Base(dir)
+	args := []string{"logs", "--timestamps"}
+	target := name
+	if exec.Command("docker", "inspect", "--format", "{{.Id}}", name).Run() != nil {
+		compose := filepath.Join("deploy", "docker-compose.yml")
+		if _, err := os.Stat(filepath.Join(dir, compose)); err != nil {
+			return fmt.Errorf("no container named %s: start it with 'make run' (see lvt gen stack docker)", name)
+		}
+		args = []string{"compose", "-f", compose, "logs", "--timestamps", "--no-log-prefix"}
+		target = "app"
+	}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if since != "" {
+		args = append(args, "--since", since)
+	}
+	args = append(args, target)
+
+	// The container's stdout and stderr are the app's
+	r, w := io.Pipe()
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = w, w
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run docker: %w", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		w.Close()
+		done <- err
+	}()
+
+	filter := devlog.Filter{Session: session}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		if e := parseDockerLine(scanner.Text()); filter.Match(e) {
+			printLogEntry(e)
+		}
+	}
+	if err := <-done; err != nil && ctx.Err() == nil {
+		return fmt.Errorf("docker %s: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// parseDockerLine reads a line of `docker logs --timestamps`: the time
+// Docker received the line, then the line the app wrote.
+func parseDockerLine(line string) devlog.Entry {
+	stamp, rest, _ := strings.Cut(line, " ")
+	t, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return devlog.ParseServerLine(line)
+	}
+	e := devlog.ParseServerLine(rest)
+	if e.Time.IsZero() {
+		e.Time = t
+	}
+	return e
+}
+
+func printLogsHelp() {
+	fmt.Println("Usage: lvt logs [-f] [--json] [--since <d>] [--session <id>] [--docker]")
+	fmt.Println()
+	fmt.Println("Prints the development log lvt serve keeps in " + devlog.Path + ", one stream")
+	fmt.Println("correlated by LiveTemplate session ID:")
+	fmt.Println()
+	fmt.Println("  server   The app's stdout and stderr; request lines get the session of")
+	fmt.Println("           the request through its X-Request-ID")
+	fmt.Println("  browser  console.log, warnings, errors and uncaught exceptions of the")
+	fmt.Println("           app's pages")
+	fmt.Println("  ws       WebSocket connections, the actions pages send (field names,")
+	fmt.Println("           not values) and the updates the app sends back")
+	fmt.Println()
+	fmt.Println("Each line shows the time, the first 8 characters of the session (- for")
+	fmt.Println("none), the source, the level and the message.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -f, --follow       Keep printing entries as they are written")
+	fmt.Println("  --json             Print each entry as a line of JSON")
+	fmt.Println("  --since <d>        Only entries from the last <d> (e.g. 5m, 2h) or since")
+	fmt.Println("                     an RFC 3339 time")
+	fmt.Println("  --session <id>     Only entries of the session starting with <id>")
+	fmt.Println("  --docker           Print the output of the app's Docker container (make run,")
+	fmt.Println("                     or the app service of deploy/docker-compose.yml)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt logs -f")
+	fmt.Println("  lvt logs --since 5m --session 3f9a1c2e")
+	fmt.Println("  lvt logs --docker -f --json")
+	fmt.Println()
+}
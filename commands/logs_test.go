@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"5m", now.Add(-5 * time.Minute)},
+		{"1h30m", now.Add(-90 * time.Minute)},
+		{"2026-03-01T11:00:00Z", time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "-5m", "yesterday"} {
+		if _, err := parseSince(in, now); err == nil {
+			t.Errorf("parseSince(%q) should fail", in)
+		}
+	}
+}
+
+func TestParseDockerLine(t *testing.T) {
+	e := parseDockerLine(`2026-03-01T10:00:00.123456789Z {"time":"2026-03-01T10:00:00.1Z","level":"WARN","msg":"slow"}`)
+	if e.Level != "WARN" || e.Message != "slow" || !e.Time.Equal(time.Date(2026, 3, 1, 10, 0, 0, 1e8, time.UTC)) {
+		t.Errorf("slog line = %+v", e)
+	}
+	e = parseDockerLine("2026-03-01T10:00:00.5Z Listening on :8080")
+	if e.Message != "Listening on :8080" || !e.Time.Equal(time.Date(2026, 3, 1, 10, 0, 0, 5e8, time.UTC)) {
+		t.Errorf("plain line = %+v", e)
+	}
+	if e = parseDockerLine("no timestamp"); e.Message != "no timestamp" {
+		t.Errorf("line without a timestamp = %+v", e)
+	}
+}
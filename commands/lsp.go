@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/livetemplate/lvt/internal/lsp"
+)
+
+// Lsp runs the template language server on stdin and stdout (lvt lsp).
+func Lsp(args []string) error {
+	if ShowHelpIfRequested(args, printLspHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		// Editors pass --stdio to servers by convention
+		if arg != "--stdio" {
+			return fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return lsp.NewServer(root, os.Stdin, os.Stdout).Run()
+}
+
+func printLspHelp() {
+	fmt.Println("Usage: lvt lsp")
+	fmt.Println()
+	fmt.Println("Runs a language server for templates over stdio, for editors:")
+	fmt.Println("  - diagnostics: parse errors, functions the app does not register,")
+	fmt.Println("    and the lint rules of lvt parse --lint (settings from .lvtrc)")
+	fmt.Println("  - completion: lvt-* attributes in tags, template functions and")
+	fmt.Println("    keywords in {{ }}, template names after {{template \", and kit")
+	fmt.Println("    helpers in the [[ ]] actions of kit templates (*.tmpl.tmpl)")
+	fmt.Println("  - go to definition: from {{template \"name\"}} to its {{define}}")
+	fmt.Println()
+	fmt.Println("Start it from the project root, or let the editor send the workspace root.")
+	fmt.Println()
+	fmt.Println("Neovim:")
+	fmt.Println("  vim.api.nvim_create_autocmd('FileType', { pattern = 'gotmpl', callback = function()")
+	fmt.Println("    vim.lsp.start({ name = 'lvt', cmd = { 'lvt', 'lsp' },")
+	fmt.Println("      root_dir = vim.fs.root(0, { 'go.mod', '.lvtrc' }) })")
+	fmt.Println("  end })")
+	fmt.Println()
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenMetrics instruments the app with Prometheus metrics.
+func GenMetrics(args []string) error {
+	if ShowHelpIfRequested(args, printGenMetricsHelp) {
+		return nil
+	}
+
+	path := generator.DefaultMetricsPath
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--path":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--path requires a URL path, e.g. /metrics")
+			}
+			i++
+			path = args[i]
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GenerateMetrics(cwd, moduleName, path); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Prometheus metrics set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/metrics/metrics.go   Metrics, middleware and the /metrics handler")
+	logging.Println("  shared/config/config.go     METRICS_TOKEN declaration")
+	logging.Println("  cmd/*/main.go               " + path + " route and metrics.Middleware")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run 'go mod tidy' to fetch the Prometheus client")
+	logging.Println("  2. Start your app and open " + path)
+	logging.Println("  3. In production, set METRICS_TOKEN and configure Prometheus to send it:")
+	logging.Println("       authorization: { credentials: <token> }")
+	logging.Println()
+
+	return nil
+}
+
+func printGenMetricsHelp() {
+	fmt.Println("Usage: lvt gen metrics [--path <path>]")
+	fmt.Println()
+	fmt.Println("Instruments the app with Prometheus metrics, served in the Prometheus")
+	fmt.Println("text format. Creates shared/metrics and wires it into main.go:")
+	fmt.Println()
+	fmt.Println("  http_requests_total                   Requests by method, route and status")
+	fmt.Println("  http_request_duration_seconds         Request latency by method and route")
+	fmt.Println("  livetemplate_websocket_sessions       Open WebSocket sessions by route")
+	fmt.Println("  livetemplate_action_duration_seconds  Action handling time by route and transport")
+	fmt.Println("  livetemplate_update_bytes_total       Update payload bytes by route and transport")
+	fmt.Println("  db_query_*                            Query counts, errors and timings by query")
+	fmt.Println()
+	fmt.Println("Go runtime and process metrics are included. Routes are labelled with")
+	fmt.Println("their mux pattern, e.g. \"GET /posts/{id}\", not the request path.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --path <path>    Path of the metrics endpoint (default: /metrics)")
+	fmt.Println()
+	fmt.Println("Set METRICS_TOKEN to require \"Authorization: Bearer <token>\" to read them.")
+	fmt.Println()
+}
@@ -2,7 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/migration"
 )
 
@@ -13,7 +16,7 @@ func Migration(args []string) error {
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("command required: up, down, status, or create <name>")
+		return fmt.Errorf("command required: up, down, status, unlock, or create <name>")
 	}
 
 	command := args[0]
@@ -32,24 +35,60 @@ func Migration(args []string) error {
 
 	switch command {
 	case "up":
-		fmt.Println("Running pending migrations...")
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--lock-timeout" && i+1 < len(args):
+				if err := setLockTimeout(runner, args[i+1]); err != nil {
+					return err
+				}
+				i++
+			case args[i] == "--lock-stale-after" && i+1 < len(args):
+				if err := setLockStaleAfter(runner, args[i+1]); err != nil {
+					return err
+				}
+				i++
+			default:
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+		}
+		logging.Println("Running pending migrations...")
 		if err := runner.Up(); err != nil {
 			return err
 		}
-		fmt.Println("✅ Migrations complete!")
-		fmt.Println()
-		fmt.Println("Next steps:")
-		fmt.Println("  go mod tidy              # Resolve dependencies")
-		fmt.Println("  go run cmd/*/main.go     # Run your app")
+		logging.Println("✅ Migrations complete!")
+		logging.Println()
+		logging.Println("Next steps:")
+		logging.Println("  go mod tidy              # Resolve dependencies")
+		logging.Println("  go run cmd/*/main.go     # Run your app")
 
 	case "down":
-		fmt.Println("Rolling back last migration...")
-		if err := runner.Down(); err != nil {
+		return migrationDown(runner, args[1:])
+
+	case "unlock":
+		released, err := runner.Unlock()
+		if err != nil {
 			return err
 		}
-		fmt.Println("✅ Rollback complete!")
+		if released == nil {
+			logging.Println("Migration lock is not held.")
+			return nil
+		}
+		logging.Printf("✅ Released migration lock held by %s since %s\n", released.Holder, released.AcquiredAt.Format(time.RFC3339))
 
 	case "status":
+		if JSONOutput() {
+			entries, err := runner.StatusEntries()
+			if err != nil {
+				return err
+			}
+			pending := 0
+			for _, e := range entries {
+				if !e.Applied {
+					pending++
+				}
+			}
+			return printJSON(map[string]any{"migrations": entries, "pending": pending})
+		}
 		fmt.Println("Migration status:")
 		if err := runner.Status(); err != nil {
 			return err
@@ -64,13 +103,143 @@ func Migration(args []string) error {
 		if err := ValidatePositionalArg(name, "migration name"); err != nil {
 			return err
 		}
-		if err := runner.Create(name); err != nil {
+		migrationType := "sql"
+		for i := 2; i < len(args); i++ {
+			switch {
+			case args[i] == "--type" && i+1 < len(args):
+				migrationType = args[i+1]
+				i++
+			default:
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+		}
+		switch migrationType {
+		case "sql":
+			err = runner.Create(name)
+		case "go":
+			err = runner.CreateGo(name)
+		default:
+			return fmt.Errorf("invalid --type %q (expected sql or go)", migrationType)
+		}
+		if err != nil {
 			return err
 		}
 
 	default:
-		return fmt.Errorf("unknown command: %s (expected: up, down, status, create)", command)
+		return fmt.Errorf("unknown command: %s (expected: up, down, status, unlock, create)", command)
+	}
+
+	return nil
+}
+
+// setLockTimeout applies a --lock-timeout value such as "30s" or "2m".
+func setLockTimeout(runner *migration.Runner, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fmt.Errorf("invalid --lock-timeout %q (expected a duration such as 30s or 2m)", value)
+	}
+	runner.SetLockTimeout(d)
+	return nil
+}
+
+// setLockStaleAfter applies a --lock-stale-after value such as "30m".
+func setLockStaleAfter(runner *migration.Runner, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid --lock-stale-after %q (expected a duration such as 10m or 1h)", value)
+	}
+	runner.SetLockStaleAfter(d)
+	return nil
+}
+
+// migrationDown handles `lvt migration down [--to <version> | --steps N] [--dry-run] [--lock-timeout d] [--lock-stale-after d]`.
+func migrationDown(runner *migration.Runner, args []string) error {
+	var (
+		toVersion int64
+		steps     int
+		hasTo     bool
+		dryRun    bool
+	)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--to" && i+1 < len(args):
+			v, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || v < 0 {
+				return fmt.Errorf("invalid --to version %q (expected a migration version such as 20240101120000, or 0)", args[i+1])
+			}
+			toVersion, hasTo = v, true
+			i++
+		case args[i] == "--steps" && i+1 < len(args):
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid --steps %q (expected a positive number)", args[i+1])
+			}
+			steps = n
+			i++
+		case args[i] == "--dry-run":
+			dryRun = true
+		case args[i] == "--lock-timeout" && i+1 < len(args):
+			if err := setLockTimeout(runner, args[i+1]); err != nil {
+				return err
+			}
+			i++
+		case args[i] == "--lock-stale-after" && i+1 < len(args):
+			if err := setLockStaleAfter(runner, args[i+1]); err != nil {
+				return err
+			}
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	if hasTo && steps > 0 {
+		return fmt.Errorf("--to and --steps cannot be combined")
+	}
+	if !hasTo && steps == 0 {
+		if !dryRun {
+			logging.Println("Rolling back last migration...")
+			if err := runner.Down(); err != nil {
+				return err
+			}
+			logging.Println("✅ Rollback complete!")
+			return nil
+		}
+		steps = 1
+	}
+
+	plan, target, err := runner.PlanDown(toVersion, steps)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		logging.Println("Nothing to roll back.")
+		return nil
 	}
 
+	if dryRun {
+		logging.Printf("Dry run: would roll back %d migration%s to version %d:\n", len(plan), pluralize(len(plan)), target)
+		for _, step := range plan {
+			logging.Printf("\n-- %s\n", step.Source)
+			if step.Go {
+				logging.Println("-- (Go migration: runs its down function)")
+			} else if step.DownSQL == "" {
+				logging.Println("-- (no Down section)")
+			} else {
+				logging.Println(step.DownSQL)
+			}
+		}
+		logging.Println()
+		logging.Println("No changes made.")
+		return nil
+	}
+
+	logging.Printf("Rolling back %d migration%s to version %d...\n", len(plan), pluralize(len(plan)), target)
+	for _, step := range plan {
+		logging.Printf("  %s\n", step.Source)
+	}
+	if err := runner.DownTo(target); err != nil {
+		return err
+	}
+	logging.Println("✅ Rollback complete!")
 	return nil
 }
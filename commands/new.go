@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/ui/progress"
 )
 
 func New(args []string) error {
@@ -33,6 +38,9 @@ func New(args []string) error {
 	devMode := false            // Default to production (use CDN)
 	kit := "multi"              // Default kit
 	stylesAdapter := "tailwind" // Default style adapter
+	theme := ""                 // Default daisyUI theme (daisyui kit only)
+	otel := false               // Set up OpenTelemetry tracing
+	preview := false            // --dry-run: print the files instead of writing them
 
 	// Check for flags
 	for i := 1; i < len(args); i++ {
@@ -41,12 +49,19 @@ func New(args []string) error {
 			i++ // Skip next arg
 		} else if args[i] == "--dev" {
 			devMode = true
+		} else if args[i] == "--otel" {
+			otel = true
+		} else if args[i] == "--dry-run" {
+			preview = true
 		} else if args[i] == "--kit" && i+1 < len(args) {
 			kit = args[i+1]
 			i++ // Skip next arg
 		} else if args[i] == "--styles" && i+1 < len(args) {
 			stylesAdapter = args[i+1]
 			i++ // Skip next arg
+		} else if args[i] == "--theme" && i+1 < len(args) {
+			theme = args[i+1]
+			i++ // Skip next arg
 		}
 	}
 
@@ -56,17 +71,58 @@ func New(args []string) error {
 		return fmt.Errorf("invalid styles adapter: %s (valid: tailwind, unstyled)", stylesAdapter)
 	}
 
-	// Validate kit
-	validKits := map[string]bool{"multi": true, "single": true, "simple": true}
+	// Validate kit: a system kit, or a project or installed one
+	validKits := map[string]bool{"multi": true, "single": true, "simple": true, "daisyui": true}
 	if !validKits[kit] {
-		return fmt.Errorf("invalid kit: %s (valid: multi, single, simple)", kit)
+		if _, err := kits.DefaultLoader().Load(kit); err != nil {
+			return fmt.Errorf("invalid kit: %s (valid: multi, single, simple, daisyui, or an installed kit)", kit)
+		}
+	}
+
+	// Validate theme
+	if theme != "" {
+		if kit != "daisyui" {
+			return fmt.Errorf("--theme requires --kit daisyui")
+		}
+		if !kits.IsDaisyUITheme(theme) {
+			return fmt.Errorf("invalid theme: %s (valid: %s)", theme, strings.Join(kits.DaisyUIThemes, ", "))
+		}
+	}
+
+	if otel && kit == "simple" {
+		return fmt.Errorf("--otel requires the multi, single or daisyui kit")
 	}
 
-	fmt.Printf("Creating new LiveTemplate app: %s\n", appName)
-	fmt.Printf("Kit: %s\n", kit)
-	fmt.Printf("Styles: %s\n", stylesAdapter)
+	if preview {
+		name := strings.ToLower(strings.TrimSpace(appName))
+		dir, err := filepath.Abs(name)
+		if err != nil {
+			return err
+		}
+		return previewChanges("new "+name, dir, name+"/", func(copy string) error {
+			return inDir(filepath.Dir(copy), func() error {
+				if err := generator.GenerateApp(appName, moduleName, kit, stylesAdapter, theme, devMode); err != nil {
+					return err
+				}
+				if otel {
+					return generator.GenerateTracing(appName, moduleName)
+				}
+				return nil
+			})
+		})
+	}
+
+	logging.Printf("Creating new LiveTemplate app: %s\n", appName)
+	logging.Printf("Kit: %s\n", kit)
+	logging.Printf("Styles: %s\n", stylesAdapter)
+	if theme != "" {
+		logging.Printf("Theme: %s\n", theme)
+	}
 	if devMode {
-		fmt.Println("Mode: Development (using local client library)")
+		logging.Println("Mode: Development (using local client library)")
+	}
+	if otel {
+		logging.Println("Tracing: OpenTelemetry")
 	}
 
 	// Check if we're inside another Go module
@@ -75,67 +131,83 @@ func New(args []string) error {
 		isNested = true
 	}
 
-	if err := generator.GenerateApp(appName, moduleName, kit, stylesAdapter, devMode); err != nil {
+	logging.Println()
+	stepCount := 2
+	if otel {
+		stepCount++
+	}
+	steps := progress.New(logging.Messages(), stepCount)
+	if err := steps.Run("Generating app files", func() error {
+		return generator.GenerateApp(appName, moduleName, kit, stylesAdapter, theme, devMode)
+	}); err != nil {
 		return err
 	}
-
-	fmt.Println()
-	fmt.Println("✅ App created successfully!")
-
-	if isNested {
-		fmt.Println()
-		fmt.Println("⚠️  Warning: Creating app inside another Go module")
-		if kit == "simple" {
-			fmt.Printf("   You'll need to use: GOWORK=off go run main.go\n")
-		} else {
-			fmt.Printf("   You'll need to use: GOWORK=off go run cmd/%s/main.go\n", appName)
+	if otel {
+		if err := steps.Run("Setting up OpenTelemetry tracing", func() error {
+			return generator.GenerateTracing(appName, moduleName)
+		}); err != nil {
+			return err
 		}
-		fmt.Println("   For production, create apps outside Go module directories")
 	}
 
-	fmt.Println()
-
 	// Run go mod tidy to resolve and download dependencies
-	fmt.Println("Installing dependencies...")
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir = appName
-	// Disable workspace mode to prevent background processes
-	cmd.Env = append(os.Environ(), "GOWORK=off")
-
-	// Use CombinedOutput() to capture stdout/stderr and properly close pipes
-	// This prevents "Test I/O incomplete" errors when running under test frameworks
-	output, err := cmd.CombinedOutput()
+	var output []byte
+	err := steps.Run("Installing dependencies (go mod tidy)", func() error {
+		cmd := exec.Command("go", "mod", "tidy")
+		cmd.Dir = appName
+		// Disable workspace mode to prevent background processes
+		cmd.Env = append(os.Environ(), "GOWORK=off")
+
+		// Use CombinedOutput() to capture stdout/stderr and properly close pipes
+		// This prevents "Test I/O incomplete" errors when running under test frameworks
+		var err error
+		output, err = cmd.CombinedOutput()
+		return err
+	})
 	if err != nil {
-		fmt.Printf("⚠️  Warning: failed to install dependencies: %v\n", err)
+		logging.Printf("⚠️  Warning: failed to install dependencies: %v\n", err)
 		if len(output) > 0 {
-			fmt.Printf("   Output: %s\n", string(output))
+			logging.Printf("   Output: %s\n", string(output))
 		}
-		fmt.Printf("   You can run it manually: cd %s && go mod tidy\n", appName)
-	} else {
-		if len(output) > 0 {
-			fmt.Print(string(output))
+		logging.Printf("   You can run it manually: cd %s && go mod tidy\n", appName)
+	}
+
+	logging.Println()
+	logging.Println("✅ App created successfully!")
+
+	if isNested {
+		logging.Println()
+		logging.Println("⚠️  Warning: Creating app inside another Go module")
+		if kit == "simple" {
+			logging.Printf("   You'll need to use: GOWORK=off go run main.go\n")
+		} else {
+			logging.Printf("   You'll need to use: GOWORK=off go run cmd/%s/main.go\n", appName)
 		}
-		fmt.Println("✅ Dependencies installed!")
+		logging.Println("   For production, create apps outside Go module directories")
 	}
 
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  cd %s\n", appName)
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  cd %s\n", appName)
 
 	// Different instructions based on kit type
 	if kit == "simple" {
-		fmt.Println("  go run main.go")
-		fmt.Println()
-		fmt.Println("Then open http://localhost:8080 in your browser")
-		fmt.Println()
-		fmt.Println("Edit main.go to customize your app logic")
-		fmt.Printf("Edit %s.tmpl to modify the UI\n", appName)
+		logging.Println("  go run main.go")
+		logging.Println()
+		logging.Println("Then open http://localhost:8080 in your browser")
+		logging.Println()
+		logging.Println("Edit main.go to customize your app logic")
+		logging.Printf("Edit %s.tmpl to modify the UI\n", appName)
 	} else {
-		fmt.Println("  lvt gen users name:string email:string")
-		fmt.Println("  lvt migration up")
-		fmt.Printf("  go run cmd/%s/main.go\n", appName)
+		logging.Println("  lvt gen users name:string email:string")
+		logging.Println("  lvt migration up")
+		logging.Printf("  go run cmd/%s/main.go\n", appName)
+	}
+	logging.Println()
+	if otel {
+		logging.Println("Export traces by setting OTEL_EXPORTER_OTLP_ENDPOINT, e.g. http://localhost:4318")
+		logging.Println()
 	}
-	fmt.Println()
 
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"text/template"
 	"unicode"
+
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // NewComponent handles the `lvt new component` command.
@@ -83,23 +85,23 @@ func NewComponent(args []string) error {
 		}
 	}
 
-	fmt.Printf("✅ Created component scaffold: %s\n", componentName)
-	fmt.Println()
-	fmt.Println("Created files:")
+	logging.Printf("✅ Created component scaffold: %s\n", componentName)
+	logging.Println()
+	logging.Println("Created files:")
 	for _, f := range files {
 		rel, _ := filepath.Rel(".", f.path)
-		fmt.Printf("  - %s\n", rel)
+		logging.Printf("  - %s\n", rel)
 	}
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Edit %s/%s.go to implement your component\n", destDir, componentName)
-	fmt.Printf("  2. Edit %s/templates/default.tmpl for the HTML\n", destDir)
-	fmt.Printf("  3. Run tests: go test ./%s/...\n", destDir)
-	fmt.Println()
-	fmt.Println("To contribute to the official library:")
-	fmt.Println("  - Fork github.com/livetemplate/lvt/components")
-	fmt.Println("  - Move your component to the fork")
-	fmt.Println("  - Submit a pull request")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Edit %s/%s.go to implement your component\n", destDir, componentName)
+	logging.Printf("  2. Edit %s/templates/default.tmpl for the HTML\n", destDir)
+	logging.Printf("  3. Run tests: go test ./%s/...\n", destDir)
+	logging.Println()
+	logging.Println("To contribute to the official library:")
+	logging.Println("  - Fork github.com/livetemplate/lvt/components")
+	logging.Println("  - Move your component to the fork")
+	logging.Println("  - Submit a pull request")
 
 	return nil
 }
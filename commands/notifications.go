@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenNotifications sets up the shared/lvt package and its toasts.
+func GenNotifications(args []string) error {
+	if ShowHelpIfRequested(args, printGenNotificationsHelp) {
+		return nil
+	}
+
+	dismiss := generator.DefaultNotifyDismiss
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dismiss":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--dismiss requires a duration, e.g. 5s (0 keeps toasts until closed)")
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d < 0 {
+				return fmt.Errorf("invalid --dismiss duration %q (expected e.g. 5s, 2500ms or 0)", args[i])
+			}
+			dismiss = d
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	if err := generator.GenerateNotifications(cwd, dismiss); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Notifications set up successfully!")
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Println("  shared/lvt/notify.go   lvt.Notify and how long toasts stay (DismissAfter)")
+	logging.Println()
+	logging.Println("Show a toast from any controller action:")
+	logging.Println("  lvt.Notify(ctx, lvt.Success, \"Saved\")")
+	logging.Println()
+	logging.Println("Resources, views and wizards generated from now on render the toasts;")
+	logging.Println("resources report creates, updates, deletes and their failures with them.")
+	logging.Println("Regenerate existing ones, or add to their templates:")
+	logging.Println("  {{template \"notifications\" .}}")
+	logging.Println()
+
+	return nil
+}
+
+func printGenNotificationsHelp() {
+	fmt.Println("Usage: lvt gen notifications [--dismiss <duration>]")
+	fmt.Println()
+	fmt.Println("Creates shared/lvt, whose Notify shows a toast after the current action:")
+	fmt.Println()
+	fmt.Println("  lvt.Notify(ctx, lvt.Success, \"Saved\")")
+	fmt.Println("  lvt.Notify(ctx, lvt.Error, \"Could not reach the payment provider\")")
+	fmt.Println()
+	fmt.Println("Kinds are success, info, warning and error, styled by the kit's")
+	fmt.Println("notifications component. Success and info toasts close themselves;")
+	fmt.Println("warnings and errors stay until closed. Notifications are flash messages,")
+	fmt.Println("so they also show after a redirect or a form post without JavaScript.")
+	fmt.Println()
+	fmt.Println("Resources, views and wizards generated afterwards render the toasts, and")
+	fmt.Println("resource handlers report creates, updates, deletes and their failures")
+	fmt.Println("with them instead of their own toast container.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --dismiss <duration>   How long success and info toasts stay (default: 5s;")
+	fmt.Println("                         0 keeps them until closed)")
+	fmt.Println()
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenOffline sets up the shared/offline package and the offline action
+// queue.
+func GenOffline(args []string) error {
+	if ShowHelpIfRequested(args, printGenOfflineHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		return fmt.Errorf("unexpected argument: %s", arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	if err := generator.GenerateOffline(cwd); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Offline actions set up successfully!")
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Println("  shared/offline/offline.go   Seen, which skips actions that were applied already")
+	logging.Println()
+	logging.Println("Resources, views and wizards generated from now on keep working while their")
+	logging.Println("connection is down: clicks and form submissions are queued under an")
+	logging.Println("\"Offline\" indicator and sent in order once the page has reconnected.")
+	logging.Println("Regenerate existing ones to use it.")
+	logging.Println()
+
+	return nil
+}
+
+func printGenOfflineHelp() {
+	fmt.Println("Usage: lvt gen offline")
+	fmt.Println()
+	fmt.Println("Makes pages generated afterwards queue the actions fired while their")
+	fmt.Println("WebSocket is down, lvt-click and button clicks as well as form")
+	fmt.Println("submissions, instead of losing them. An indicator shows the page is")
+	fmt.Println("offline and how many actions wait; once the page has reconnected they")
+	fmt.Println("are sent one at a time, in the order they were fired.")
+	fmt.Println()
+	fmt.Println("Each replayed action carries an idempotency key. Generated actions that")
+	fmt.Println("change data check it first, so an action resent because the connection")
+	fmt.Println("dropped again before its answer is not applied twice:")
+	fmt.Println()
+	fmt.Println("  if offline.Seen(ctx) {")
+	fmt.Println("      return state, nil")
+	fmt.Println("  }")
+	fmt.Println()
+	fmt.Println("Add the same check to hand-written actions that must not run twice.")
+	fmt.Println("Keys are remembered in memory for an hour (offline.KeepKeys).")
+	fmt.Println()
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// jsonOutput is set by the global --json flag.
+var jsonOutput bool
+
+// EnableJSON makes commands print their results as JSON on stdout instead
+// of text, as the global --json flag does. Errors still go to stderr with a
+// non-zero exit status.
+func EnableJSON() {
+	jsonOutput = true
+}
+
+// JSONOutput reports whether commands print JSON.
+func JSONOutput() bool {
+	return jsonOutput
+}
+
+// EnableQuiet silences the messages commands print and the output of the
+// tools they run (logging.Messages), and warnings, as the global --quiet
+// flag does. Errors, prompts, help and the results commands exist to print
+// (listings, reports, diffs and --json output) still go to stdout. Debug
+// records asked for with --verbose or LVT_LOG=debug still go to stderr.
+func EnableQuiet() {
+	verbose := logging.Enabled(slog.LevelDebug)
+	logging.SetQuiet()
+	if verbose {
+		logging.SetVerbose()
+	}
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/devlog"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/seeder"
+)
+
+// The --json schemas are consumed by scripts; their keys must not change.
+func TestJSONSchemas(t *testing.T) {
+	kit, err := kits.DefaultLoader().Load("multi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := &seeder.TableSchema{
+		Name:        "comments",
+		Columns:     []seeder.Column{{Name: "id", Type: "TEXT", IsPrimary: true}, {Name: "post_id", Type: "TEXT"}},
+		Indexes:     []seeder.Index{{Name: "idx_comments_post_id", Columns: []string{"post_id"}}, {Name: "idx_comments_post_id_unique", Columns: []string{"post_id"}, Unique: true, Where: "post_id != ''"}},
+		ForeignKeys: []seeder.ForeignKey{{Column: "post_id", RefTable: "posts", RefColumn: "id", OnDelete: "CASCADE"}},
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		keys []string
+	}{
+		{"kit", newKitSummary(kit), []string{"name", "version", "description", "css_framework", "source", "path", "tags"}},
+		{"resource", newResourceDetail(table, map[string]bool{"idx_comments_post_id_unique": true}), []string{"name", "fields", "indexes", "foreign_keys", "primary_key", "nullable", "example", "ref_table", "on_delete", "unique", "where", "declared"}},
+		{"seed", seedResult{Resource: "posts", Seeded: 3}, []string{"resource", "seeded", "removed"}},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range tt.keys {
+			if !strings.Contains(string(data), `"`+key+`":`) {
+				t.Errorf("%s JSON has no %q key: %s", tt.name, key, data)
+			}
+		}
+	}
+}
+
+// --quiet drops messages, not stdout: prompts, help and results still print.
+func TestQuietKeepsPromptsAndResults(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		logging.Reset()
+	})
+
+	EnableQuiet()
+	if os.Stdout != w {
+		t.Fatal("--quiet replaced stdout")
+	}
+	logging.Println("✅ Resource generated successfully!")
+	confirm := confirmSectionReplace(strings.NewReader("y\n"), os.Stdout)
+	if !confirm("app/tasks/tasks.tmpl", "list", "old\n", "new\n") {
+		t.Error("the answer to the prompt was lost")
+	}
+	if err := printJSON(map[string]int{"seeded": 3}); err != nil {
+		t.Fatal(err)
+	}
+	printLogEntry(devlog.Entry{Source: "server", Level: "INFO", Message: "listening on :8080"})
+	printLogsHelp()
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "generated successfully") {
+		t.Error("a message was printed under --quiet")
+	}
+	for _, want := range []string{"Replace it with the regenerated section? [y/N]", `"seeded": 3`, "listening on :8080", "Usage: lvt logs"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output under --quiet is missing %q:\n%s", want, out)
+		}
+	}
+}
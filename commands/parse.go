@@ -1,13 +1,25 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/livetemplate/livetemplate"
+	"github.com/livetemplate/lvt/components"
+	"github.com/livetemplate/lvt/components/base"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/serve"
+	"gopkg.in/yaml.v3"
 )
 
 // Parse validates a template file and shows detailed information
@@ -17,6 +29,32 @@ func Parse(args []string) error {
 		return nil
 	}
 
+	if len(args) > 0 {
+		switch args[0] {
+		case "graph":
+			return ParseGraph(args[1:])
+		case "render":
+			return ParseRender(args[1:])
+		case "budget":
+			return ParseBudget(args[1:])
+		}
+	}
+
+	project := false
+	for _, arg := range args {
+		if arg == "--lint" || arg == "--format" || strings.HasPrefix(arg, "--format=") {
+			return Lint(args)
+		}
+		if arg == "--watch" || strings.HasSuffix(arg, "...") {
+			project = true
+		} else if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			project = true
+		}
+	}
+	if project {
+		return ParseProject(args)
+	}
+
 	if len(args) < 1 {
 		return fmt.Errorf("template file required\nUsage: lvt parse <template-file>")
 	}
@@ -43,42 +81,42 @@ func Parse(args []string) error {
 	baseName := filepath.Base(templateFile)
 	name := strings.TrimSuffix(baseName, filepath.Ext(baseName))
 
-	fmt.Printf("Parsing template: %s\n", templateFile)
-	fmt.Printf("Template name: %s\n", name)
-	fmt.Println(strings.Repeat("=", 60))
+	logging.Printf("Parsing template: %s\n", templateFile)
+	logging.Printf("Template name: %s\n", name)
+	logging.Println(strings.Repeat("=", 60))
 
 	// Test 1: Standard html/template parsing
-	fmt.Println("\n1. Testing html/template parsing...")
+	logging.Println("\n1. Testing html/template parsing...")
 	tmpl, err := template.New(name).Parse(templateStr)
 	if err != nil {
-		fmt.Printf("   ❌ Parse error: %v\n", err)
+		logging.Printf("   ❌ Parse error: %v\n", err)
 		return fmt.Errorf("template parse failed")
 	}
-	fmt.Println("   ✅ Successfully parsed with html/template")
+	logging.Println("   ✅ Successfully parsed with html/template")
 
 	// List defined templates
-	fmt.Println("\n2. Defined templates:")
+	logging.Println("\n2. Defined templates:")
 	templates := tmpl.Templates()
 	if len(templates) > 0 {
 		for _, t := range templates {
-			fmt.Printf("   - %s\n", t.Name())
+			logging.Printf("   - %s\n", t.Name())
 		}
 	} else {
-		fmt.Println("   (no nested templates)")
+		logging.Println("   (no nested templates)")
 	}
 
 	// Test 2: LiveTemplate parsing
-	fmt.Println("\n3. Testing LiveTemplate parsing...")
+	logging.Println("\n3. Testing LiveTemplate parsing...")
 	lvtTmpl := livetemplate.Must(livetemplate.New(name))
 	_, err = lvtTmpl.Parse(templateStr)
 	if err != nil {
-		fmt.Printf("   ❌ LiveTemplate parse error: %v\n", err)
+		logging.Printf("   ❌ LiveTemplate parse error: %v\n", err)
 		return fmt.Errorf("LiveTemplate parse failed")
 	}
-	fmt.Println("   ✅ Successfully parsed with LiveTemplate")
+	logging.Println("   ✅ Successfully parsed with LiveTemplate")
 
 	// Test 3: Try to execute with sample data
-	fmt.Println("\n4. Testing template execution...")
+	logging.Println("\n4. Testing template execution...")
 	testData := map[string]interface{}{
 		"Title":          "Test Title",
 		"CSSFramework":   "tailwind",
@@ -98,15 +136,15 @@ func Parse(args []string) error {
 	var testBuf strings.Builder
 	err = lvtTmpl.Execute(&testBuf, testData)
 	if err != nil {
-		fmt.Printf("   ⚠️  Execution error: %v\n", err)
-		fmt.Println("   (This might be OK if template expects specific data structure)")
+		logging.Printf("   ⚠️  Execution error: %v\n", err)
+		logging.Println("   (This might be OK if template expects specific data structure)")
 	} else {
 		htmlLen := len(testBuf.String())
-		fmt.Printf("   ✅ Successfully executed (generated %d bytes of HTML)\n", htmlLen)
+		logging.Printf("   ✅ Successfully executed (generated %d bytes of HTML)\n", htmlLen)
 	}
 
 	// Test 4: Check for common issues
-	fmt.Println("\n5. Checking for common issues...")
+	logging.Println("\n5. Checking for common issues...")
 	issues := []string{}
 
 	// Check for mismatched blocks
@@ -135,23 +173,308 @@ func Parse(args []string) error {
 	}
 
 	if len(issues) > 0 {
-		fmt.Println("   ⚠️  Potential issues found:")
+		logging.Println("   ⚠️  Potential issues found:")
 		for _, issue := range issues {
-			fmt.Printf("   - %s\n", issue)
+			logging.Printf("   - %s\n", issue)
 		}
 	} else {
-		fmt.Println("   ✅ No common issues detected")
+		logging.Println("   ✅ No common issues detected")
 	}
 
 	// Summary
-	fmt.Println("\n" + strings.Repeat("=", 60))
-	fmt.Println("✅ Template validation complete")
-	fmt.Println("\nTemplate structure:")
-	fmt.Printf("  - Size: %d bytes\n", len(templateStr))
-	fmt.Printf("  - Lines: %d\n", strings.Count(templateStr, "\n")+1)
-	fmt.Printf("  - Defines: %d\n", defineCount)
-	fmt.Printf("  - Blocks: %d\n", blockCount)
-	fmt.Printf("  - Template invocations: %d\n", strings.Count(templateStr, "{{template"))
+	logging.Println("\n" + strings.Repeat("=", 60))
+	logging.Println("✅ Template validation complete")
+	logging.Println("\nTemplate structure:")
+	logging.Printf("  - Size: %d bytes\n", len(templateStr))
+	logging.Printf("  - Lines: %d\n", strings.Count(templateStr, "\n")+1)
+	logging.Printf("  - Defines: %d\n", defineCount)
+	logging.Printf("  - Blocks: %d\n", blockCount)
+	logging.Printf("  - Template invocations: %d\n", strings.Count(templateStr, "{{template"))
+
+	return nil
+}
+
+// ParseProject parses every template under the given paths (default ./...)
+// with the functions generated code registers, and with --watch parses
+// templates again whenever they change.
+func ParseProject(args []string) error {
+	watch := false
+	var paths []string
+	for _, arg := range args {
+		switch {
+		case arg == "--watch":
+			watch = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		default:
+			paths = append(paths, arg)
+		}
+	}
+	if len(paths) == 0 {
+		paths = []string{"./..."}
+	}
+
+	files, err := templateFiles(paths)
+	if err != nil {
+		return err
+	}
+	funcs := generator.TemplateFuncs(".")
+
+	failed := 0
+	for _, file := range files {
+		if err := checkTemplate(file, funcs); err != nil {
+			failed++
+			logging.Printf("❌ %v\n", err)
+		}
+	}
+	if failed == 0 {
+		logging.Printf("✅ All %d template(s) parse\n", len(files))
+	} else {
+		logging.Printf("\n%d of %d template(s) failed to parse\n", failed, len(files))
+	}
+
+	if !watch {
+		if failed > 0 {
+			return fmt.Errorf("%d template(s) failed to parse", failed)
+		}
+		return nil
+	}
+
+	return watchTemplates(paths, func(path string) {
+		stamp := time.Now().Format("15:04:05")
+		if err := checkTemplate(path, funcs); err != nil {
+			logging.Printf("[%s] ❌ %v\n", stamp, err)
+		} else {
+			logging.Printf("[%s] ✅ %s\n", stamp, path)
+		}
+	})
+}
+
+// checkTemplate parses a template with html/template and LiveTemplate,
+// allowing only the builtins and funcs.
+func checkTemplate(path string, funcs template.FuncMap) error {
+	if err := generator.ValidateTemplateFuncs(path, funcs); err != nil {
+		return err
+	}
+	if _, err := newAppTemplate(path, funcs); err != nil {
+		return fmt.Errorf("LiveTemplate parse error in %s: %w", path, err)
+	}
+	return nil
+}
+
+// newAppTemplate parses the template at path like generated handlers do:
+// with the component templates and funcs, and without discovering other
+// templates.
+func newAppTemplate(path string, funcs template.FuncMap) (*livetemplate.Template, error) {
+	// Component sets are parsed first, so carrying funcs on one of them
+	// makes the funcs available while the file is parsed
+	sets := components.All()
+	sets[0] = base.WithFuncs(sets[0], funcs)
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	tmpl, err := livetemplate.New(name,
+		livetemplate.WithComponentTemplates(sets...),
+		livetemplate.WithParseFiles(path),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Update trees are built from a fresh parse, with these funcs
+	tmpl.Funcs(funcs)
+	return tmpl, nil
+}
+
+// ParseRender renders a template offline (lvt parse render) and writes the
+// HTML and the update tree the client receives on the first render.
+func ParseRender(args []string) error {
+	var templatePath, dataPath string
+	outDir := filepath.Join(".lvt", "render")
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--data" || arg == "--out":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			if arg == "--data" {
+				dataPath = args[i]
+			} else {
+				outDir = args[i]
+			}
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		case templatePath != "":
+			return fmt.Errorf("unexpected argument: %s", arg)
+		default:
+			templatePath = arg
+		}
+	}
+	if templatePath == "" {
+		return fmt.Errorf("template file required\nUsage: lvt parse render <template-file> [--data <file>] [--out <dir>]")
+	}
+	if _, err := os.Stat(templatePath); err != nil {
+		return fmt.Errorf("template file not found: %s", templatePath)
+	}
+
+	// A data file only needs the fields it changes: they replace those of
+	// the sample data, when the template has any
+	data, err := generator.SampleData(".", templatePath)
+	if dataPath != "" {
+		fileData, fileErr := readRenderData(dataPath)
+		if fileErr != nil {
+			return fileErr
+		}
+		if err != nil {
+			data, err = map[string]any{}, nil
+		}
+		for k, v := range fileData {
+			data[k] = v
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	funcs := generator.TemplateFuncs(".")
+	if err := generator.ValidateTemplateFuncs(templatePath, funcs); err != nil {
+		return err
+	}
+	tmpl, err := newAppTemplate(templatePath, funcs)
+	if err != nil {
+		return fmt.Errorf("LiveTemplate parse error in %s: %w", templatePath, err)
+	}
+
+	// The first update is the full tree; render it before the HTML, which
+	// would otherwise be cached as the previous state
+	var tree bytes.Buffer
+	if err := tmpl.ExecuteUpdates(&tree, data); err != nil {
+		return fmt.Errorf("failed to build the update tree: %w", err)
+	}
+	var html bytes.Buffer
+	if err := tmpl.Execute(&html, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", templatePath, err)
+	}
+	var treeJSON bytes.Buffer
+	if err := json.Indent(&treeJSON, tree.Bytes(), "", "  "); err != nil {
+		return fmt.Errorf("invalid update tree: %w", err)
+	}
+	treeJSON.WriteByte('\n')
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", outDir, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(templatePath), filepath.Ext(templatePath))
+	htmlPath := filepath.Join(outDir, name+".html")
+	treePath := filepath.Join(outDir, name+".tree.json")
+	// The wrapper ID is random; a fixed one keeps previews diffable
+	page := wrapperIDPattern.ReplaceAll(html.Bytes(), []byte(`data-lvt-id="lvt-preview"`))
+	if err := os.WriteFile(htmlPath, page, 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(treePath, treeJSON.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	source := "sample data from the handler state and database/schema.sql"
+	if dataPath != "" {
+		source = dataPath
+	}
+	logging.Printf("✅ Rendered %s with %s\n", templatePath, source)
+	logging.Printf("  %s (%d bytes)\n", htmlPath, len(page))
+	logging.Printf("  %s (%d bytes)\n", treePath, treeJSON.Len())
+	return nil
+}
+
+var wrapperIDPattern = regexp.MustCompile(`data-lvt-id="lvt-[0-9a-f]+"`)
 
+// readRenderData reads template data from a JSON or YAML file.
+func readRenderData(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+	data := map[string]any{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(content, &data)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &data)
+	default:
+		return nil, fmt.Errorf("unsupported data file %s (expected .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid data file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// watchTemplates calls check with each template under paths that changes,
+// until interrupted.
+func watchTemplates(paths []string, check func(path string)) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	var dirs []string
+	files := map[string]bool{}
+	for _, path := range paths {
+		abs, err := filepath.Abs(strings.TrimSuffix(path, "..."))
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+			files[abs] = true
+		} else {
+			dirs = append(dirs, abs)
+		}
+	}
+	watched := func(path string) bool {
+		if files[path] {
+			return true
+		}
+		for _, dir := range dirs {
+			if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	roots := append([]string{}, dirs...)
+	for file := range files {
+		roots = append(roots, filepath.Dir(file))
+	}
+	var watchers []*serve.Watcher
+	for _, root := range roots {
+		watcher, err := serve.NewWatcher(root, func(path string) {
+			if filepath.Ext(path) != ".tmpl" || !watched(path) {
+				return
+			}
+			if _, err := os.Stat(path); err != nil {
+				return // Removed
+			}
+			if rel, err := filepath.Rel(wd, path); err == nil {
+				path = rel
+			}
+			check(path)
+		})
+		if err != nil {
+			return err
+		}
+		if err := watcher.Start(); err != nil {
+			return err
+		}
+		watchers = append(watchers, watcher)
+	}
+	logging.Println("\nWatching for template changes (Ctrl+C to stop)...")
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	<-sigChan
+
+	for _, watcher := range watchers {
+		watcher.Stop()
+	}
 	return nil
 }
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/plugins"
+)
+
+func Plugins(args []string) error {
+	// Handle --help flag
+	if ShowHelpIfRequested(args, printPluginsHelp) {
+		return nil
+	}
+
+	if len(args) < 1 {
+		return fmt.Errorf("command required: list")
+	}
+
+	command := args[0]
+	if err := ValidatePositionalArg(command, "command"); err != nil {
+		return err
+	}
+
+	switch command {
+	case "list":
+		return listPlugins()
+	default:
+		return fmt.Errorf("unknown command: %s (expected: list)", command)
+	}
+}
+
+type pluginJSON struct {
+	Name        string            `json:"name"`
+	Source      string            `json:"source"`
+	Path        string            `json:"path"`
+	Description string            `json:"description"`
+	Commands    []plugins.Command `json:"commands"`
+	Hooks       []string          `json:"hooks"`
+}
+
+func listPlugins() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	plugs, err := plugins.Discover(dir)
+	if err != nil {
+		return err
+	}
+
+	if JSONOutput() {
+		out := struct {
+			Plugins []pluginJSON `json:"plugins"`
+		}{Plugins: []pluginJSON{}}
+		for _, p := range plugs {
+			entry := pluginJSON{
+				Name:        p.Name,
+				Source:      string(p.Source),
+				Path:        p.Path,
+				Description: p.Description,
+				Commands:    p.Commands,
+				Hooks:       p.Hooks,
+			}
+			if entry.Commands == nil {
+				entry.Commands = []plugins.Command{}
+			}
+			if entry.Hooks == nil {
+				entry.Hooks = []string{}
+			}
+			out.Plugins = append(out.Plugins, entry)
+		}
+		return printJSON(out)
+	}
+
+	if len(plugs) == 0 {
+		fmt.Println("No plugins found in .lvt/plugins or ~/.config/lvt/plugins")
+		return nil
+	}
+	for i, p := range plugs {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s (%s) %s\n", p.Name, p.Source, p.Path)
+		if p.Description != "" {
+			fmt.Printf("  %s\n", p.Description)
+		}
+		for _, c := range p.Commands {
+			desc := c.Description
+			if _, builtin := genSubcommands[c.Name]; builtin {
+				desc += " (hidden by the built-in subcommand)"
+			}
+			fmt.Printf("  lvt gen %-20s %s\n", strings.TrimSpace(c.Name+" "+c.Usage), strings.TrimSpace(desc))
+		}
+		if len(p.Hooks) > 0 {
+			fmt.Printf("  hooks: %s\n", strings.Join(p.Hooks, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenPrerender sets up the shared/prerender package, which serves search
+// engine crawlers and ?static=1 a static render of each page.
+func GenPrerender(args []string) error {
+	if ShowHelpIfRequested(args, printGenPrerenderHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		return fmt.Errorf("unexpected argument: %s", arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GeneratePrerender(cwd, moduleName); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Static renders set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/prerender/prerender.go   Mount, which serves crawlers and ?static=1 static renders")
+	logging.Println("  shared/config/config.go         LIVE_PRERENDER declaration")
+	logging.Println()
+	logging.Println("Resources, views and wizards generated from now on are mounted with it.")
+	logging.Println("Regenerate existing ones to use it.")
+	logging.Println("Try it with: curl 'http://localhost:8080/<resource>?static=1'")
+	logging.Println()
+
+	return nil
+}
+
+func printGenPrerenderHelp() {
+	fmt.Println("Usage: lvt gen prerender")
+	fmt.Println()
+	fmt.Println("Makes pages generated afterwards readable without JavaScript, so search")
+	fmt.Println("engines index them and no-JS clients can use them. Search engine crawlers,")
+	fmt.Println("recognised by their User-Agent, and any GET with ?static=1 get a static")
+	fmt.Println("render: the page's HTML for an anonymous visitor, without scripts, from a")
+	fmt.Println("session of its own.")
+	fmt.Println()
+	fmt.Println("Resource pages rendered statically page with links (?static=1&page=2, or")
+	fmt.Println("?static=1&cursor=... for cursor pagination) instead of the buttons, scroll")
+	fmt.Println("sentinel and load-more button that need the WebSocket.")
+	fmt.Println()
+	fmt.Println("LIVE_PRERENDER=false turns crawler detection off; ?static=1 works either way.")
+	fmt.Println()
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenPubSub sets up the shared/pubsub package, which relays presence and
+// broadcasts between the app's instances.
+func GenPubSub(args []string) error {
+	if ShowHelpIfRequested(args, printGenPubSubHelp) {
+		return nil
+	}
+
+	broker := generator.PubSubRedis
+	for _, arg := range args {
+		switch arg {
+		case "--nats":
+			broker = generator.PubSubNATS
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+			return fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GeneratePubSub(cwd, moduleName, broker); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Pub/sub bridge set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/pubsub/pubsub.go     Publish, Subscribe and the bridge setup")
+	logging.Printf("  shared/pubsub/%-13s The %s bridge, used when PUBSUB_URL is set\n", broker+".go", broker)
+	if _, err := os.Stat("app/presence/bridge.go"); err == nil {
+		logging.Println("  app/presence/bridge.go      Relays who has resource pages open")
+	}
+	if _, err := os.Stat("shared/sse/bridge.go"); err == nil {
+		logging.Println("  shared/sse/bridge.go        Relays the events of SSE streams")
+	}
+	logging.Println("  shared/config/config.go     PUBSUB_URL declaration")
+	logging.Println("  cmd/*/main.go               pubsub.Setup")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Run 'go mod tidy' to fetch the %s client\n", broker)
+	if broker == generator.PubSubNATS {
+		logging.Println("  2. Set PUBSUB_URL on every instance, e.g. nats://localhost:4222")
+	} else {
+		logging.Println("  2. Set PUBSUB_URL on every instance, e.g. redis://localhost:6379")
+	}
+	logging.Println("  3. Route each browser back to its instance with cookie affinity at the")
+	logging.Println("     load balancer; 'lvt gen stack' describes it for your platform")
+	logging.Println()
+
+	return nil
+}
+
+func printGenPubSubHelp() {
+	fmt.Println("Usage: lvt gen pubsub [--nats]")
+	fmt.Println()
+	fmt.Println("Creates shared/pubsub, which carries messages between the instances of")
+	fmt.Println("the app over Redis or NATS, so presence and broadcasts reach sessions")
+	fmt.Println("connected to other instances:")
+	fmt.Println()
+	fmt.Println("  app/presence   Viewers of --presence resources, on every instance")
+	fmt.Println("  shared/sse     sse.Publish and the refresh of a session's other tabs")
+	fmt.Println()
+	fmt.Println("Both get a bridge.go, now or when they are generated. App code can use")
+	fmt.Println("the bridge too:")
+	fmt.Println()
+	fmt.Println("  pubsub.Publish(\"orders\", order)                 Send to the other instances")
+	fmt.Println("  pubsub.Subscribe(\"orders\", func(data []byte))   Receive what they send")
+	fmt.Println()
+	fmt.Println("PUBSUB_URL names the broker; without it the app runs as one instance.")
+	fmt.Println("Live sessions are held by the instance serving them, so the load")
+	fmt.Println("balancer should send each browser back there (sticky sessions). With")
+	fmt.Println("SESSION_STORE=redis (lvt gen sessions --redis) a session moved to")
+	fmt.Println("another instance resumes from its snapshot instead of starting over.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --nats     Use NATS instead of Redis")
+	fmt.Println()
+}
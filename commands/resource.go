@@ -1,10 +1,18 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/seeder"
+	"gopkg.in/yaml.v3"
 )
 
 func Resource(args []string) error {
@@ -14,7 +22,7 @@ func Resource(args []string) error {
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("command required: list or describe <resource-name>")
+		return fmt.Errorf("command required: list, describe <resource-name>, openapi, erd or diff")
 	}
 
 	command := args[0]
@@ -39,8 +47,17 @@ func Resource(args []string) error {
 		}
 		return describeResource(resourceName)
 
+	case "openapi":
+		return resourceOpenAPI(args[1:])
+
+	case "erd":
+		return resourceERD(args[1:])
+
+	case "diff":
+		return resourceDiff(args[1:])
+
 	default:
-		return fmt.Errorf("unknown command: %s (expected: list, describe)", command)
+		return fmt.Errorf("unknown command: %s (expected: list, describe, openapi, erd, diff)", command)
 	}
 }
 
@@ -57,6 +74,14 @@ func listResources() error {
 		return fmt.Errorf("failed to parse schema: %w", err)
 	}
 
+	if JSONOutput() {
+		resources := make([]resourceSummary, 0, len(tables))
+		for _, table := range tables {
+			resources = append(resources, resourceSummary{Name: table.Name, Fields: len(table.Columns)})
+		}
+		return printJSON(map[string]any{"resources": resources})
+	}
+
 	if len(tables) == 0 {
 		fmt.Println("No resources found in schema.")
 		return nil
@@ -74,6 +99,84 @@ func listResources() error {
 	return nil
 }
 
+// resourceSummary is a resource of lvt resource list --json.
+type resourceSummary struct {
+	Name   string `json:"name"`
+	Fields int    `json:"fields"`
+}
+
+// resourceDetail is the output of lvt resource describe --json.
+type resourceDetail struct {
+	Name        string          `json:"name"`
+	Fields      []resourceField `json:"fields"`
+	Indexes     []resourceIndex `json:"indexes"`
+	ForeignKeys []resourceFK    `json:"foreign_keys"`
+}
+
+type resourceField struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+	Nullable   bool   `json:"nullable"`
+	Example    string `json:"example"`
+}
+
+type resourceIndex struct {
+	Name     string   `json:"name"`
+	Columns  []string `json:"columns"`
+	Unique   bool     `json:"unique,omitempty"`
+	Where    string   `json:"where,omitempty"`
+	Declared bool     `json:"declared,omitempty"` // declared with --index rather than generated
+}
+
+type resourceFK struct {
+	Column    string `json:"column"`
+	RefTable  string `json:"ref_table"`
+	RefColumn string `json:"ref_column"`
+	OnDelete  string `json:"on_delete,omitempty"`
+}
+
+// newResourceDetail describes table; declared are the names of its indexes
+// the resource manifest records as declared with --index.
+func newResourceDetail(table *seeder.TableSchema, declared map[string]bool) resourceDetail {
+	d := resourceDetail{Name: table.Name, Fields: []resourceField{}, Indexes: []resourceIndex{}, ForeignKeys: []resourceFK{}}
+	for _, col := range table.Columns {
+		d.Fields = append(d.Fields, resourceField{
+			Name:       col.Name,
+			Type:       col.Type,
+			PrimaryKey: col.IsPrimary,
+			Nullable:   col.Nullable,
+			Example:    seeder.GenerateExampleValue(col),
+		})
+	}
+	for _, idx := range table.Indexes {
+		d.Indexes = append(d.Indexes, resourceIndex{Name: idx.Name, Columns: idx.Columns, Unique: idx.Unique, Where: idx.Where, Declared: declared[idx.Name]})
+	}
+	for _, fk := range table.ForeignKeys {
+		d.ForeignKeys = append(d.ForeignKeys, resourceFK{Column: fk.Column, RefTable: fk.RefTable, RefColumn: fk.RefColumn, OnDelete: fk.OnDelete})
+	}
+	return d
+}
+
+// declaredIndexes returns the names of the indexes on table that the
+// resource manifest (.lvtresources) records as declared with --index.
+func declaredIndexes(basePath, table string) map[string]bool {
+	declared := make(map[string]bool)
+	resources, err := generator.ReadResources(basePath)
+	if err != nil {
+		return declared
+	}
+	for _, r := range resources {
+		if r.Table != table {
+			continue
+		}
+		for _, idx := range r.Indexes {
+			declared[idx.Name] = true
+		}
+	}
+	return declared
+}
+
 func describeResource(resourceName string) error {
 	// Find schema file
 	schemaPath, err := seeder.FindSchemaFile()
@@ -93,6 +196,11 @@ func describeResource(resourceName string) error {
 		return fmt.Errorf("resource '%s' not found in schema", resourceName)
 	}
 
+	declared := declaredIndexes(filepath.Dir(filepath.Dir(schemaPath)), table.Name)
+	if JSONOutput() {
+		return printJSON(newResourceDetail(table, declared))
+	}
+
 	// Display resource details
 	fmt.Printf("Resource: %s\n", table.Name)
 	fmt.Printf("Table: %s\n", table.Name)
@@ -141,7 +249,17 @@ func describeResource(resourceName string) error {
 		fmt.Println()
 		fmt.Println("Indexes:")
 		for _, idx := range table.Indexes {
-			fmt.Printf("  %s (%s)\n", idx.Name, strings.Join(idx.Columns, ", "))
+			line := fmt.Sprintf("  %s (%s)", idx.Name, strings.Join(idx.Columns, ", "))
+			if idx.Unique {
+				line += " UNIQUE"
+			}
+			if idx.Where != "" {
+				line += " WHERE " + idx.Where
+			}
+			if declared[idx.Name] {
+				line += "  [--index]"
+			}
+			fmt.Println(line)
 		}
 	}
 
@@ -153,6 +271,168 @@ func describeResource(resourceName string) error {
 	return nil
 }
 
+func resourceOpenAPI(args []string) error {
+	output := ""
+	format := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case (args[i] == "--output" || args[i] == "-o") && i+1 < len(args):
+			output = args[i+1]
+			i++
+		case args[i] == "--format" && i+1 < len(args):
+			format = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	if format == "" {
+		format = "json"
+		if ext := filepath.Ext(output); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	doc, err := generator.GenerateOpenAPI(wd)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(doc, "", "  ")
+		data = append(data, '\n')
+	case "yaml":
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err = enc.Encode(doc)
+		data = buf.Bytes()
+	default:
+		return fmt.Errorf("invalid --format %q (expected json or yaml)", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	logging.Printf("✅ Wrote OpenAPI %s document to %s\n", generator.OpenAPIVersion, output)
+	return nil
+}
+
+func resourceERD(args []string) error {
+	output := ""
+	format := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case (args[i] == "--output" || args[i] == "-o") && i+1 < len(args):
+			output = args[i+1]
+			i++
+		case args[i] == "--format" && i+1 < len(args):
+			format = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	if format == "" {
+		format = generator.ERDFormatMermaid
+		if ext := filepath.Ext(output); ext == ".dot" || ext == ".gv" {
+			format = generator.ERDFormatDOT
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	diagram, err := generator.GenerateERD(wd, format)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		fmt.Print(diagram)
+		return nil
+	}
+	if err := os.WriteFile(output, []byte(diagram), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	logging.Printf("✅ Wrote %s ER diagram to %s\n", format, output)
+	return nil
+}
+
+// errSchemaDrift is returned by resource diff so the process exits non-zero
+// after the report has been printed.
+var errSchemaDrift = errors.New("schema drift detected")
+
+func resourceDiff(args []string) error {
+	dbPath := ""
+	format := "text"
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--db" && i+1 < len(args):
+			dbPath = args[i+1]
+			i++
+		case args[i] == "--format" && i+1 < len(args):
+			format = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q (expected text or json)", format)
+	}
+	if JSONOutput() {
+		format = "json"
+	}
+	if dbPath == "" {
+		found, err := findDBPath()
+		if err != nil {
+			return err
+		}
+		if dbPath = found; dbPath == "" {
+			return fmt.Errorf("no database found. Expected: app.db, DATABASE_PATH environment variable, or --db <path>")
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	diff, err := generator.DiffSchema(wd, dbPath)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(generator.FormatSchemaDiff(diff))
+	}
+
+	if diff.HasDrift() {
+		return errSchemaDrift
+	}
+	return nil
+}
+
 func pluralize(count int) string {
 	if count == 1 {
 		return ""
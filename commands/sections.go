@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// confirmSectionReplace returns a generator.ConfirmSectionReplace that
+// shows how regenerating would change a hand-edited section of a shared
+// file and asks whether to replace it. Dry runs keep the section.
+func confirmSectionReplace(in io.Reader, out io.Writer) func(path, section, current, regenerated string) bool {
+	reader := bufio.NewReader(in)
+	return func(path, section, current, regenerated string) bool {
+		if dryRun {
+			return false
+		}
+		fmt.Fprintf(out, "\nThe %q section of %s was edited since lvt wrote it:\n", section, path)
+		fmt.Fprint(out, kits.CompareText(path, current, regenerated).Unified("edited", "regenerated", diffContext))
+		for {
+			fmt.Fprint(out, "Replace it with the regenerated section? [y/N]: ")
+			answer, err := reader.ReadString('\n')
+			switch strings.TrimSpace(strings.ToLower(answer)) {
+			case "y", "yes":
+				return true
+			case "", "n", "no":
+				return false
+			}
+			if err != nil {
+				return false
+			}
+		}
+	}
+}
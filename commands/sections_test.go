@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirmSectionReplace(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		dry   bool
+		want  bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"\n", false, false},
+		{"maybe\nn\n", false, false},
+		{"", false, false},
+		{"y\n", true, false},
+	} {
+		dryRun = tc.dry
+		var out bytes.Buffer
+		confirm := confirmSectionReplace(strings.NewReader(tc.input), &out)
+		got := confirm("database/queries.sql", "resource posts", "-- edited\n", "-- regenerated\n")
+		dryRun = false
+		if got != tc.want {
+			t.Errorf("input %q (dry run %v) = %v, want %v", tc.input, tc.dry, got, tc.want)
+		}
+		if !tc.dry && (!strings.Contains(out.String(), "-- edited") || !strings.Contains(out.String(), "+-- regenerated")) {
+			t.Errorf("prompt does not show the diff:\n%s", out.String())
+		}
+	}
+}
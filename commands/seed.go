@@ -2,7 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/seeder"
 )
 
@@ -12,8 +19,14 @@ func Seed(args []string) error {
 		return nil
 	}
 
+	for _, arg := range args {
+		if arg == "--profile" {
+			return seedProfile(args)
+		}
+	}
+
 	if len(args) < 1 {
-		return fmt.Errorf("resource name required: lvt seed <resource-name> --count N [--cleanup]")
+		return fmt.Errorf("resource name required: lvt seed <resource-name> --count N [--cleanup], or lvt seed --profile <name>")
 	}
 
 	resourceName := args[0]
@@ -27,6 +40,14 @@ func Seed(args []string) error {
 	var count int
 	var cleanup bool
 	var hasCount bool
+	var locale string
+	var from string
+	var dryRun bool
+	var parents seeder.ParentStrategy
+	var seedValue int64
+	var hasSeedValue bool
+	batchSize := seeder.DefaultBatchSize
+	mapping := map[string]string{}
 
 	for i := 1; i < len(args); i++ {
 		switch args[i] {
@@ -43,13 +64,91 @@ func Seed(args []string) error {
 		case "--cleanup":
 			cleanup = true
 
+		case "--locale":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--locale requires a value")
+			}
+			i++
+			locale = args[i]
+
+		case "--from":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from requires a file")
+			}
+			i++
+			from = args[i]
+
+		case "--map":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--map requires a value, e.g. --map 'Post Title=title'")
+			}
+			i++
+			for _, pair := range strings.Split(args[i], ",") {
+				header, column, ok := strings.Cut(pair, "=")
+				if !ok || strings.TrimSpace(header) == "" || strings.TrimSpace(column) == "" {
+					return fmt.Errorf("invalid --map %q (expected Header=column, or Header=- to skip)", pair)
+				}
+				mapping[strings.TrimSpace(header)] = strings.TrimSpace(column)
+			}
+
+		case "--batch":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--batch requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid --batch %q (expected a number of rows, at least 1)", args[i])
+			}
+			batchSize = n
+
+		case "--dry-run":
+			dryRun = true
+
+		case "--seed-value":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--seed-value requires a number")
+			}
+			i++
+			n, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --seed-value %q (expected a number)", args[i])
+			}
+			seedValue = n
+			hasSeedValue = true
+
+		case "--parents":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--parents requires a value (reuse, create or existing)")
+			}
+			i++
+			strategy, err := seeder.ParseParentStrategy(args[i])
+			if err != nil {
+				return err
+			}
+			parents = strategy
+
 		default:
 			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
 	// Validate flags
-	if !cleanup && !hasCount {
+	if from != "" {
+		if hasCount || cleanup {
+			return fmt.Errorf("--from cannot be combined with --count or --cleanup")
+		}
+	} else if len(mapping) > 0 || dryRun {
+		return fmt.Errorf("--map and --dry-run only apply to --from")
+	}
+	if parents != "" && !hasCount {
+		return fmt.Errorf("--parents only applies to --count")
+	}
+	if hasSeedValue && !hasCount {
+		return fmt.Errorf("--seed-value only applies to --count")
+	}
+
+	if !cleanup && !hasCount && from == "" {
 		return fmt.Errorf("either --count or --cleanup must be specified")
 	}
 
@@ -69,6 +168,15 @@ func Seed(args []string) error {
 		return fmt.Errorf("failed to parse schema: %w", err)
 	}
 
+	if err := seeder.Configure(seeder.ProjectRoot(schemaPath), tables, locale); err != nil {
+		return err
+	}
+	if hasSeedValue {
+		if err := seeder.Reproducible(seedValue); err != nil {
+			return err
+		}
+	}
+
 	// Find the table
 	table := seeder.FindTable(tables, resourceName)
 	if table == nil {
@@ -81,33 +189,295 @@ func Seed(args []string) error {
 		return err
 	}
 	defer s.Close()
+	if JSONOutput() {
+		s.SetOutput(io.Discard)
+	}
+
+	if from != "" {
+		opts := seeder.ImportOptions{
+			Mapping:   mapping,
+			BatchSize: batchSize,
+			DryRun:    dryRun,
+		}
+		if !JSONOutput() {
+			opts.Progress = logging.Messages()
+		}
+		return seedFromFile(s, *table, from, opts)
+	}
+
+	result := seedResult{Resource: table.Name}
 
 	// Perform cleanup if requested
 	if cleanup {
-		if err := s.Cleanup(table.Name); err != nil {
+		if result.Removed, err = s.Cleanup(table.Name); err != nil {
 			return err
 		}
 
 		// If only cleanup was requested, we're done
 		if !hasCount {
-			return nil
+			return printSeedResult(result)
 		}
 
-		fmt.Println()
+		if !JSONOutput() {
+			logging.Println()
+		}
+	}
+
+	// A seeded run generates the same ids every time, so it can only add
+	// to a table without seeded rows
+	if hasSeedValue && !cleanup {
+		if n, err := s.CountTestRecords(table.Name); err == nil && n > 0 {
+			return fmt.Errorf("%s already has %d seeded row(s); add --cleanup to reproduce the dataset with --seed-value", table.Name, n)
+		}
 	}
 
 	// Perform seeding if count was specified
 	if hasCount {
-		if err := s.Seed(*table, count); err != nil {
+		if err := s.Seed(*table, count, seeder.SeedOptions{Tables: tables, Parents: parents}); err != nil {
 			return err
 		}
 
+		result.Seeded = count
+
 		// Show total test records
 		totalTest, err := s.CountTestRecords(table.Name)
-		if err == nil && totalTest > 0 {
-			fmt.Printf("\nTotal test records in %s: %d\n", table.Name, totalTest)
+		if err == nil && totalTest > 0 && !JSONOutput() {
+			logging.Printf("\nTotal test records in %s: %d\n", table.Name, totalTest)
+		}
+	}
+
+	return printSeedResult(result)
+}
+
+// seedResult is the output of lvt seed --json.
+type seedResult struct {
+	Resource string `json:"resource"`
+	Seeded   int    `json:"seeded"`
+	Removed  int64  `json:"removed"`
+}
+
+func printSeedResult(result seedResult) error {
+	if !JSONOutput() {
+		return nil
+	}
+	return printJSON(result)
+}
+
+// importResult is the output of lvt seed --from --json.
+type importResult struct {
+	Resource string   `json:"resource"`
+	File     string   `json:"file"`
+	DryRun   bool     `json:"dry_run"`
+	Imported int      `json:"imported"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors"`
+}
+
+// seedFromFile imports a CSV or JSON file into table and reports the rows
+// it skipped.
+func seedFromFile(s *seeder.Seeder, table seeder.TableSchema, path string, opts seeder.ImportOptions) error {
+	if JSONOutput() {
+		result, err := s.Import(table, path, opts)
+		if err != nil {
+			return err
+		}
+		out := importResult{Resource: table.Name, File: path, DryRun: opts.DryRun, Imported: result.Imported, Failed: result.Failed, Errors: []string{}}
+		for _, rowErr := range result.Errors {
+			out.Errors = append(out.Errors, rowErr.Error())
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+		if result.Failed > 0 {
+			return fmt.Errorf("%d row(s) failed", result.Failed)
+		}
+		return nil
+	}
+
+	if opts.DryRun {
+		logging.Printf("Validating %s against %s (dry run)...\n", path, table.Name)
+	} else {
+		logging.Printf("Importing %s into %s...\n", path, table.Name)
+	}
+	result, err := s.Import(table, path, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range result.Errors {
+		logging.Printf("  ❌ %v\n", rowErr)
+	}
+	if hidden := result.Failed - len(result.Errors); hidden > 0 {
+		logging.Printf("  ... and %d more invalid row(s)\n", hidden)
+	}
+	verb := "Imported"
+	if opts.DryRun {
+		verb = "Validated"
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%s %d row(s) into %s; %d row(s) failed", strings.ToLower(verb), result.Imported, table.Name, result.Failed)
+	}
+	logging.Printf("✅ %s %d row(s) into %s\n", verb, result.Imported, table.Name)
+	return nil
+}
+
+// seedProfile applies a seed profile from database/seeds: the scenarios of
+// a YAML file, or a Go program run against the database.
+func seedProfile(args []string) error {
+	var name, locale string
+	var scenarios []string
+	seed := int64(-1)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--profile" && i+1 < len(args):
+			name = args[i+1]
+			i++
+		case args[i] == "--scenario" && i+1 < len(args):
+			for _, sc := range strings.Split(args[i+1], ",") {
+				if sc = strings.TrimSpace(sc); sc != "" {
+					scenarios = append(scenarios, sc)
+				}
+			}
+			i++
+		case (args[i] == "--seed" || args[i] == "--seed-value") && i+1 < len(args):
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid %s %q (expected a non-negative number)", args[i], args[i+1])
+			}
+			seed = n
+			i++
+		case args[i] == "--locale" && i+1 < len(args):
+			locale = args[i+1]
+			i++
+		case args[i] == "--profile":
+			return fmt.Errorf("--profile requires a value")
+		default:
+			return fmt.Errorf("unknown argument with --profile: %s", args[i])
+		}
+	}
+	if err := ValidatePositionalArg(name, "profile name"); err != nil {
+		return err
+	}
+
+	path, err := seeder.FindProfile(name)
+	if err != nil {
+		return err
+	}
+	dbPath, err := seeder.FindDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	if filepath.Ext(path) == ".go" {
+		return runGoProfile(path, dbPath, scenarios, seed, locale)
+	}
+
+	profile, err := seeder.LoadProfile(path)
+	if err != nil {
+		return err
+	}
+	selected, err := profile.Select(scenarios)
+	if err != nil {
+		return err
+	}
+	schemaPath, err := seeder.FindSchemaFile()
+	if err != nil {
+		return err
+	}
+	tables, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if err := seeder.Configure(seeder.ProjectRoot(schemaPath), tables, locale); err != nil {
+		return err
+	}
+	if seed >= 0 {
+		if err := seeder.Reproducible(seed); err != nil {
+			return err
 		}
 	}
 
+	s, err := seeder.New()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if JSONOutput() {
+		s.SetOutput(io.Discard)
+	} else {
+		logging.Printf("Seeding profile %s into %s...\n", name, dbPath)
+	}
+	results, err := s.ApplyProfile(selected, tables)
+	if err != nil {
+		return err
+	}
+	if JSONOutput() {
+		out := profileOutput{Profile: name, Source: path, Tables: []profileTable{}}
+		for _, r := range results {
+			out.Tables = append(out.Tables, profileTable{Scenario: r.Scenario, Table: r.Table, Inserted: r.Inserted, Updated: r.Updated, Generated: r.Generated})
+		}
+		return printJSON(out)
+	}
+	scenario := ""
+	for _, r := range results {
+		if r.Scenario != scenario {
+			scenario = r.Scenario
+			logging.Printf("  %s\n", scenario)
+		}
+		logging.Printf("    %-20s %d inserted, %d updated, %d generated\n", r.Table, r.Inserted, r.Updated, r.Generated)
+	}
+	logging.Printf("✅ Applied %d scenario(s) from %s\n", len(selected), path)
+	return nil
+}
+
+// profileOutput is the output of lvt seed --profile --json.
+type profileOutput struct {
+	Profile string         `json:"profile"`
+	Source  string         `json:"source"`
+	Tables  []profileTable `json:"tables"`
+}
+
+type profileTable struct {
+	Scenario  string `json:"scenario"`
+	Table     string `json:"table"`
+	Inserted  int    `json:"inserted"`
+	Updated   int    `json:"updated"`
+	Generated int    `json:"generated"`
+}
+
+// runGoProfile runs a Go seed program from the project root. It gets the
+// database in DATABASE_PATH, and the flags in LVT_SEED_SCENARIOS, LVT_SEED
+// and LVT_SEED_LOCALE.
+func runGoProfile(path, dbPath string, scenarios []string, seed int64, locale string) error {
+	root := filepath.Dir(filepath.Dir(filepath.Dir(path)))
+	abs, err := filepath.Abs(dbPath)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("go", "run", path)
+	cmd.Dir = root
+	cmd.Stdout = logging.Messages()
+	if JSONOutput() {
+		// Keep stdout for the result
+		cmd.Stdout = os.Stderr
+	}
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "DATABASE_PATH="+abs, "LVT_SEED_SCENARIOS="+strings.Join(scenarios, ","))
+	if seed >= 0 {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("LVT_SEED=%d", seed))
+	}
+	if locale != "" {
+		cmd.Env = append(cmd.Env, "LVT_SEED_LOCALE="+locale)
+	}
+	if !JSONOutput() {
+		logging.Printf("Running seed program %s against %s...\n", path, abs)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("seed program %s failed: %w", path, err)
+	}
+	if JSONOutput() {
+		return printJSON(profileOutput{Profile: strings.TrimSuffix(filepath.Base(path), ".go"), Source: path, Tables: []profileTable{}})
+	}
 	return nil
 }
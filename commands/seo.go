@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenSEO sets up the shared/seo package, which serves sitemap.xml and
+// robots.txt and gives resource pages their meta tags.
+func GenSEO(args []string) error {
+	if ShowHelpIfRequested(args, printGenSEOHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		return fmt.Errorf("unexpected argument: %s", arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GenerateSEO(cwd, moduleName); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ SEO set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/seo/seo.go         Sitemap, Robots, Register and Meta")
+	logging.Println("  shared/config/config.go   BASE_URL and ROBOTS_DISALLOW declarations")
+	logging.Println("  main.go                   /sitemap.xml and /robots.txt routes")
+	logging.Println()
+	logging.Println("Resources generated from now on are listed in the sitemap and render")
+	logging.Println("title, description and Open Graph tags. Regenerate existing ones to use it.")
+	logging.Println("Set BASE_URL to the app's public URL for absolute sitemap entries and og:url.")
+	logging.Println()
+
+	return nil
+}
+
+func printGenSEOHelp() {
+	fmt.Println("Usage: lvt gen seo")
+	fmt.Println()
+	fmt.Println("Serves /sitemap.xml and /robots.txt, and gives resource pages generated")
+	fmt.Println("afterwards a title, description and Open Graph tags.")
+	fmt.Println()
+	fmt.Println("The sitemap lists the home page and each resource page. Resources with")
+	fmt.Println("--edit-mode page list the page of each record too.")
+	fmt.Println()
+	fmt.Println("robots.txt is built from seo.Rules, which you can edit, and ROBOTS_DISALLOW,")
+	fmt.Println("comma-separated paths kept from every crawler (e.g. ROBOTS_DISALLOW=/ on")
+	fmt.Println("staging). It points crawlers at the sitemap.")
+	fmt.Println()
+	fmt.Println("BASE_URL is the app's public URL, e.g. https://example.com. Without it the")
+	fmt.Println("sitemap uses the host of its request and pages leave og:url out.")
+	fmt.Println()
+}
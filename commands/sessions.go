@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenSessions sets up the shared/sessions package and its table.
+func GenSessions(args []string) error {
+	if ShowHelpIfRequested(args, printGenSessionsHelp) {
+		return nil
+	}
+
+	ttl := generator.DefaultSessionTTL
+	withRedis := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--ttl":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--ttl requires a duration, e.g. 24h")
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --ttl duration %q (expected e.g. 24h or 30m)", args[i])
+			}
+			ttl = d
+		case "--redis":
+			withRedis = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	moduleName, err := getModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w (are you in a Go project?)", err)
+	}
+
+	if err := generator.GenerateSessions(cwd, moduleName, ttl, withRedis); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ Sessions set up successfully!")
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Println("  database/migrations/*_create_live_sessions.sql")
+	logging.Println("  database/queries.sql (live session queries appended)")
+	logging.Println("  database/schema.sql (live_sessions table appended)")
+	logging.Println("  shared/sessions/sessions.go   The session store and how long snapshots are kept (TTL)")
+	if withRedis {
+		logging.Println("  shared/sessions/redis.go      Redis backend, used when SESSION_STORE=redis")
+	}
+	logging.Println("  shared/config/config.go       SESSION_STORE declaration")
+	logging.Println("  cmd/*/main.go                 sessions.Setup")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run migration:")
+	logging.Println("     lvt migration up")
+	logging.Println("  2. Regenerate sqlc code:")
+	logging.Println("     sqlc generate")
+	if withRedis {
+		logging.Println("  3. Run 'go mod tidy' to fetch the Redis client")
+		logging.Println("  4. Set SESSION_STORE=redis and REDIS_URL, e.g. redis://localhost:6379")
+	}
+	logging.Println()
+	logging.Println("Resources, views, wizards and the auth page generated from now on keep")
+	logging.Println("their sessions in the store: an open edit form, a search or a wizard's")
+	logging.Println("step survive a reload or a server restart, and typed but unsent fields")
+	logging.Println("come back when the page reconnects. Regenerate existing ones to use it.")
+	logging.Println()
+
+	return nil
+}
+
+func printGenSessionsHelp() {
+	fmt.Println("Usage: lvt gen sessions [--ttl <duration>] [--redis]")
+	fmt.Println()
+	fmt.Println("Creates the live_sessions table and shared/sessions, a session store that")
+	fmt.Println("snapshots each page's state after every action. The browser's session")
+	fmt.Println("cookie is the token the session resumes with: after a reload, a reconnect")
+	fmt.Println("or a server restart the page continues from its snapshot instead of")
+	fmt.Println("starting over.")
+	fmt.Println()
+	fmt.Println("Snapshots are kept where SESSION_STORE says:")
+	fmt.Println()
+	fmt.Println("  sqlite   The live_sessions table of the app's database (default)")
+	fmt.Println("  memory   Nowhere; sessions end with the process")
+	fmt.Println("  redis    The REDIS_URL server, shared by every instance (--redis)")
+	fmt.Println()
+	fmt.Println("Resources, views, wizards and the auth page generated afterwards use the")
+	fmt.Println("store. A controller with a Restore method refreshes resumed sessions:")
+	fmt.Println()
+	fmt.Println("  func (c *NotesController) Restore(state NotesState, ctx *livetemplate.Context) (NotesState, error)")
+	fmt.Println()
+	fmt.Println("Resources get one that reloads the list and reopens the edit form. Their")
+	fmt.Println("pages also put back what the server never saw: typed but unsent form")
+	fmt.Println("fields, open dialogs and the scroll position.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --ttl <duration>   How long a snapshot is kept after its session's last")
+	fmt.Println("                     action (default: 24h)")
+	fmt.Println("  --redis            Add the Redis backend, so instances behind a load")
+	fmt.Println("                     balancer share sessions")
+	fmt.Println()
+}
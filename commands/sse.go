@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenSSE sets up the shared/sse package, the fallback transport for pages
+// whose WebSocket is blocked.
+func GenSSE(args []string) error {
+	if ShowHelpIfRequested(args, printGenSSEHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		return fmt.Errorf("unexpected argument: %s", arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GenerateSSE(cwd, moduleName); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ SSE fallback set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/sse/sse.go           Mount, which serves each page's event stream")
+	logging.Println("  shared/config/config.go     LIVE_TRANSPORT declaration")
+	logging.Println()
+	logging.Println("Resources, views and wizards generated from now on stay live where")
+	logging.Println("WebSockets are blocked: their actions are sent over HTTP and updates")
+	logging.Println("arrive over server-sent events. Regenerate existing ones to use it.")
+	logging.Println()
+
+	return nil
+}
+
+func printGenSSEHelp() {
+	fmt.Println("Usage: lvt gen sse")
+	fmt.Println()
+	fmt.Println("Adds a Server-Sent Events transport for networks whose proxies block")
+	fmt.Println("WebSockets. Handlers generated afterwards are mounted with sse.Mount,")
+	fmt.Println("which serves an event stream next to the page. A page whose WebSocket")
+	fmt.Println("does not connect within a few seconds sends its actions as HTTP POSTs")
+	fmt.Println("and opens the stream, so what another tab of the same session does, or")
+	fmt.Println("what sse.Publish sends, still reaches it.")
+	fmt.Println()
+	fmt.Println("LIVE_TRANSPORT picks the transport:")
+	fmt.Println("  auto        WebSocket, falling back to the event stream (default)")
+	fmt.Println("  websocket   WebSocket only")
+	fmt.Println("  sse         HTTP actions and the event stream only")
+	fmt.Println()
+	fmt.Println("Streams are kept in memory: with several instances, publish on each.")
+	fmt.Println()
+}
@@ -8,11 +8,14 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/stack"
 	"github.com/livetemplate/lvt/internal/stack/digitalocean"
 	"github.com/livetemplate/lvt/internal/stack/docker"
 	"github.com/livetemplate/lvt/internal/stack/fly"
 	"github.com/livetemplate/lvt/internal/stack/k8s"
+	"github.com/livetemplate/lvt/internal/stack/railway"
+	"github.com/livetemplate/lvt/internal/stack/render"
 )
 
 func Stack(args []string) error {
@@ -49,23 +52,23 @@ func StackValidate(args []string) error {
 	}
 
 	// Check for modifications
-	fmt.Println("Checking for modified files...")
+	logging.Println("Checking for modified files...")
 	modified, err := tracking.CheckModifications(wd)
 	if err != nil {
 		return fmt.Errorf("failed to check modifications: %w", err)
 	}
 
 	if len(modified) > 0 {
-		fmt.Println()
-		fmt.Println("Warning: The following files have been modified:")
+		logging.Println()
+		logging.Println("Warning: The following files have been modified:")
 		for _, f := range modified {
-			fmt.Printf("  %s\n", f)
+			logging.Printf("  %s\n", f)
 		}
-		fmt.Println()
-		fmt.Println("Manual changes may be overwritten if you regenerate the stack.")
+		logging.Println()
+		logging.Println("Manual changes may be overwritten if you regenerate the stack.")
 	} else {
-		fmt.Println("All tracked files are unchanged.")
-		fmt.Println()
+		logging.Println("All tracked files are unchanged.")
+		logging.Println()
 	}
 
 	// Create generator
@@ -78,13 +81,13 @@ func StackValidate(args []string) error {
 	stackDir := filepath.Join(wd, "deploy")
 	ctx := context.Background()
 
-	fmt.Println("Running provider-specific validation...")
+	logging.Println("Running provider-specific validation...")
 	if err := generator.Validate(ctx, stackDir); err != nil {
-		fmt.Printf("Validation failed: %v\n", err)
+		logging.Printf("Validation failed: %v\n", err)
 		return err
 	}
 
-	fmt.Println("Stack configuration is valid!")
+	logging.Println("Stack configuration is valid!")
 	return nil
 }
 
@@ -208,6 +211,10 @@ func createGenerator(provider stack.Provider) (stack.Generator, error) {
 		return digitalocean.New(), nil
 	case stack.ProviderK8s:
 		return k8s.New(), nil
+	case stack.ProviderRailway:
+		return railway.New(), nil
+	case stack.ProviderRender:
+		return render.New(), nil
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", provider)
 	}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/manifest"
+)
+
+// Status compares the generated files of the project in the working
+// directory with .lvt/manifest.json.
+func Status(args []string) error {
+	if ShowHelpIfRequested(args, printStatusHelp) {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument: %s", args[0])
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if _, err := os.Stat(manifest.Path); os.IsNotExist(err) {
+		return fmt.Errorf("no %s here: it is written by lvt new and lvt gen, so generate something first", manifest.Path)
+	}
+	m, err := manifest.Load(dir)
+	if err != nil {
+		return err
+	}
+	removed, err := generator.RemovedResources(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read .lvtresources: %w", err)
+	}
+	loader := kits.DefaultLoader()
+	report, err := manifest.Check(dir, m, removed, loader.LoadKitFile)
+	if err != nil {
+		return err
+	}
+
+	if JSONOutput() {
+		out := struct {
+			Files    int                   `json:"files"`
+			Modified []manifest.FileStatus `json:"modified"`
+			Missing  []manifest.FileStatus `json:"missing"`
+			Orphaned []manifest.FileStatus `json:"orphaned"`
+			Updates  []manifest.Update     `json:"updates"`
+		}{
+			Files:    len(m.Files),
+			Modified: append([]manifest.FileStatus{}, report.Modified...),
+			Missing:  append([]manifest.FileStatus{}, report.Missing...),
+			Orphaned: append([]manifest.FileStatus{}, report.Orphaned...),
+			Updates:  append([]manifest.Update{}, report.Updates...),
+		}
+		return printJSON(out)
+	}
+
+	if report.Clean() {
+		fmt.Printf("All %d generated files are as lvt wrote them.\n", len(m.Files))
+		return nil
+	}
+	printFileStatuses("Modified since generation", report.Modified)
+	printFileStatuses("Missing", report.Missing)
+	printFileStatuses("Orphaned (their resource was removed)", report.Orphaned)
+	if len(report.Updates) > 0 {
+		fmt.Printf("Pending template updates (%d):\n", len(report.Updates))
+		for _, u := range report.Updates {
+			fmt.Printf("  %s (generated by lvt %s, now %s, %d files)\n", u.Generator, u.Version, version, len(u.Files))
+			fmt.Printf("    changed: %s\n", strings.Join(u.Templates, ", "))
+		}
+		fmt.Println()
+		fmt.Println("Regenerate to pick up template updates; lvt gen --dry-run shows what would change.")
+	}
+	return nil
+}
+
+func printFileStatuses(title string, files []manifest.FileStatus) {
+	if len(files) == 0 {
+		return
+	}
+	width := 0
+	for _, f := range files {
+		width = max(width, len(f.Path))
+	}
+	fmt.Printf("%s (%d):\n", title, len(files))
+	for _, f := range files {
+		fmt.Printf("  %-*s  %s\n", width, f.Path, f.Generator)
+	}
+	fmt.Println()
+}
@@ -9,6 +9,7 @@ import (
 	// Register both adapters so they're available for listing.
 	_ "github.com/livetemplate/lvt/components/styles/tailwind"
 	_ "github.com/livetemplate/lvt/components/styles/unstyled"
+	"github.com/livetemplate/lvt/internal/logging"
 
 	"github.com/livetemplate/lvt/components/styles"
 	unstyledpkg "github.com/livetemplate/lvt/components/styles/unstyled"
@@ -122,7 +123,7 @@ func stylesScaffold(name, output string) error {
 		return err
 	}
 
-	fmt.Printf("CSS scaffold written to %s (%d BEM classes)\n", output, unstyledpkg.ClassCount())
+	logging.Printf("CSS scaffold written to %s (%d BEM classes)\n", output, unstyledpkg.ClassCount())
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // GenTask scaffolds a new scheduled task.
@@ -69,18 +70,18 @@ func GenTask(args []string) error {
 		return err
 	}
 
-	fmt.Println()
-	fmt.Printf("✅ Scheduled task '%s' created!\n", taskName)
-	fmt.Println()
-	fmt.Printf("Schedule: %s\n", schedule)
-	fmt.Println()
-	fmt.Println("Generated files:")
-	fmt.Printf("  app/jobs/%s.go    Task handler\n", taskName)
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Edit app/jobs/%s.go to implement your task logic\n", taskName)
-	fmt.Println("  2. The task will run automatically when the app starts")
-	fmt.Println()
+	logging.Println()
+	logging.Printf("✅ Scheduled task '%s' created!\n", taskName)
+	logging.Println()
+	logging.Printf("Schedule: %s\n", schedule)
+	logging.Println()
+	logging.Println("Generated files:")
+	logging.Printf("  app/jobs/%s.go    Task handler\n", taskName)
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Edit app/jobs/%s.go to implement your task logic\n", taskName)
+	logging.Println("  2. The task will run automatically when the app starts")
+	logging.Println()
 
 	return nil
 }
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenOtel sets up OpenTelemetry tracing.
+func GenOtel(args []string) error {
+	if ShowHelpIfRequested(args, printGenOtelHelp) {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		return fmt.Errorf("unexpected argument: %s", arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	moduleName := projectConfig.Module
+	if moduleName == "" {
+		return fmt.Errorf("could not determine module name from project config")
+	}
+
+	if err := generator.GenerateTracing(cwd, moduleName); err != nil {
+		return err
+	}
+
+	logging.Println()
+	logging.Println("✅ OpenTelemetry tracing set up successfully!")
+	logging.Println()
+	logging.Println("Files created/updated:")
+	logging.Println("  shared/tracing/tracing.go   Tracer setup, middleware, query and log hooks")
+	logging.Println("  shared/config/config.go     OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_SERVICE_NAME")
+	logging.Println("  cmd/*/main.go               tracing.Setup and tracing.Middleware")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run 'go mod tidy' to fetch the OpenTelemetry SDK")
+	logging.Println("  2. Point the app at a collector, e.g. Jaeger:")
+	logging.Println("       docker run -p 16686:16686 -p 4318:4318 jaegertracing/all-in-one")
+	logging.Println("       OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318")
+	logging.Println("  3. Start your app; request logs now carry trace_id and span_id")
+	logging.Println()
+
+	return nil
+}
+
+func printGenOtelHelp() {
+	fmt.Println("Usage: lvt gen otel")
+	fmt.Println()
+	fmt.Println("Sets up OpenTelemetry tracing. Creates shared/tracing and wires it into")
+	fmt.Println("main.go, giving spans for:")
+	fmt.Println()
+	fmt.Println("  - each HTTP request, named after its route, e.g. \"GET /posts/{id}\"")
+	fmt.Println("  - each LiveTemplate action (\"livetemplate.action\"), over WebSocket or HTTP")
+	fmt.Println("  - each database query, named after the sqlc query, e.g. \"GetPost\"")
+	fmt.Println()
+	fmt.Println("Logs written with a context (slog.InfoContext) get trace_id and span_id.")
+	fmt.Println("Incoming traceparent headers continue the caller's trace.")
+	fmt.Println()
+	fmt.Println("Spans are exported over OTLP/HTTP to OTEL_EXPORTER_OTLP_ENDPOINT when it is")
+	fmt.Println("set. The standard OTEL_* variables configure the exporter and sampling.")
+	fmt.Println()
+	fmt.Println("New apps can start with tracing: lvt new myapp --otel")
+	fmt.Println()
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// GenWizard generates a multi-step form whose final submit saves the
+// entries of every step.
+func GenWizard(args []string) error {
+	if ShowHelpIfRequested(args, printGenWizardHelp) {
+		return nil
+	}
+
+	skipValidation := false
+	var filteredArgs []string
+	for _, arg := range args {
+		if arg == "--skip-validation" {
+			skipValidation = true
+		} else {
+			filteredArgs = append(filteredArgs, arg)
+		}
+	}
+	if len(filteredArgs) < 1 {
+		return fmt.Errorf("wizard name required")
+	}
+	wizardName := filteredArgs[0]
+	if err := ValidatePositionalArg(wizardName, "wizard name"); err != nil {
+		return err
+	}
+	steps, err := parseWizardSteps(filteredArgs[1:])
+	if err != nil {
+		return err
+	}
+
+	basePath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	projectConfig, err := config.LoadProjectConfig(basePath)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kit := projectConfig.GetKit()
+	kitInfo, err := kits.DefaultLoader().Load(kit)
+	if err != nil {
+		return fmt.Errorf("failed to load kit: %w", err)
+	}
+	moduleName, err := getModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w (are you in a Go project?)", err)
+	}
+
+	logging.Printf("Generating wizard: %s\n", wizardName)
+	logging.Printf("Kit: %s\n", kit)
+	for i, step := range steps {
+		if step.Confirm {
+			logging.Printf("Step %d: %s (review)\n", i+1, step.Name)
+			continue
+		}
+		names := make([]string, len(step.Fields))
+		for j, f := range step.Fields {
+			names[j] = f.Name + ":" + f.Type
+		}
+		logging.Printf("Step %d: %s (%s)\n", i+1, step.Name, strings.Join(names, ", "))
+	}
+
+	if err := generator.GenerateWizard(basePath, moduleName, wizardName, steps, kit, kitInfo.Manifest.CSSFramework, generator.WizardOptions{IDType: projectConfig.IDType}); err != nil {
+		return err
+	}
+
+	var validationErr error
+	if !skipValidation {
+		_, validationErr = runPostGenValidation(basePath)
+	}
+
+	wizardNameLower := strings.ToLower(wizardName)
+	logging.Println()
+	if validationErr != nil {
+		logging.Println("⚠️  Wizard generated, but validation found issues.")
+	} else {
+		logging.Println("✅ Wizard generated successfully!")
+	}
+	logging.Println()
+	logging.Println("Files created:")
+	logging.Printf("  app/%s/%s.go\n", wizardNameLower, wizardNameLower)
+	logging.Printf("  app/%s/%s.tmpl\n", wizardNameLower, wizardNameLower)
+	logging.Println()
+	logging.Println("Files updated:")
+	logging.Println("  database/schema.sql")
+	logging.Println("  database/queries.sql")
+	logging.Println()
+	logging.Println("Route auto-injected:")
+	logging.Printf("  http.Handle(\"/%s\", %s.Handler(queries))\n", wizardNameLower, wizardNameLower)
+	logging.Println()
+	logging.Println("Each step's fields are validated before the next one shows; Back keeps the entries.")
+	logging.Println("The last step saves every entry in one transaction.")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Println("  1. Run migration:")
+	logging.Println("     lvt migration up")
+	logging.Println("  2. Run your app")
+	logging.Println()
+
+	return validationErr
+}
+
+// parseWizardSteps parses step arguments such as "account:name,email" and
+// "review:confirm". Fields take the resource field syntax, with the type
+// inferred from the name when it is left out.
+func parseWizardSteps(args []string) ([]generator.WizardStep, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("at least two steps required (format: step:field,field)")
+	}
+	steps := make([]generator.WizardStep, 0, len(args))
+	for _, arg := range args {
+		name, spec, ok := strings.Cut(arg, ":")
+		if !ok || strings.TrimSpace(spec) == "" {
+			return nil, fmt.Errorf("invalid step %q (format: step:field,field)", arg)
+		}
+		step := generator.WizardStep{Name: strings.ToLower(strings.TrimSpace(name))}
+		if strings.TrimSpace(spec) == generator.WizardConfirm {
+			step.Confirm = true
+			steps = append(steps, step)
+			continue
+		}
+		fields, err := parseFieldsWithInference(strings.Split(spec, ","))
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		step.Fields = fields
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func printGenWizardHelp() {
+	fmt.Println("Usage: lvt gen wizard <name> <step>:<field>[,<field>...]... [options]")
+	fmt.Println()
+	fmt.Println("Generates a multi-step form. Each step validates its fields before the")
+	fmt.Println("next one shows, Back returns with the entries kept, and the kit's")
+	fmt.Println("progress indicator marks the current step. The last step saves every")
+	fmt.Println("entry to the <name>s table in one transaction.")
+	fmt.Println()
+	fmt.Println("Fields take the resource syntax (name:type); the type is inferred from")
+	fmt.Println("the name when left out. A step written <step>:confirm reviews the other")
+	fmt.Println("steps' entries before saving; it must be the last step.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --skip-validation   Skip post-generation validation")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  lvt gen wizard onboarding step1:name,email step2:company step3:confirm")
+	fmt.Println("  lvt gen wizard survey about:age:int,country feedback:comments:text,recommend:bool")
+	fmt.Println()
+}
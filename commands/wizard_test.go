@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWizardSteps(t *testing.T) {
+	steps, err := parseWizardSteps([]string{"Step1:name,email", "step2:company,size:int", "step3:confirm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 3 {
+		t.Fatalf("got %d steps, want 3", len(steps))
+	}
+	if steps[0].Name != "step1" || len(steps[0].Fields) != 2 || steps[0].Fields[1].Name != "email" {
+		t.Errorf("step1 = %+v", steps[0])
+	}
+	if f := steps[1].Fields[1]; f.Name != "size" || f.GoType != "int64" {
+		t.Errorf("size field = %+v, want an int", f)
+	}
+	if !steps[2].Confirm || len(steps[2].Fields) != 0 {
+		t.Errorf("step3 = %+v, want a review step", steps[2])
+	}
+
+	for _, args := range [][]string{nil, {"step1"}, {"step1:"}, {"step1:age:nope"}} {
+		if _, err := parseWizardSteps(args); err == nil {
+			t.Errorf("parseWizardSteps(%q) succeeded", args)
+		} else if len(args) == 1 && args[0] == "step1" && !strings.Contains(err.Error(), "format: step:field,field") {
+			t.Errorf("parseWizardSteps(%q) = %v, want the format", args, err)
+		}
+	}
+}
@@ -93,11 +93,21 @@ func (h *DockerContainerHandle) Stop(t *testing.T) {
 }
 
 // enableDevMode enables development mode for the test app by writing .lvtrc config
-// In DevMode, the app serves the local client library instead of using CDN
+// In DevMode, the app serves the local client library instead of using CDN.
+// Other settings (kit, styles) are kept so later `lvt gen` runs use the app's kit.
 func enableDevMode(t *testing.T, appDir string) {
 	t.Helper()
 	lvtrcPath := filepath.Join(appDir, ".lvtrc")
-	lvtrcContent := "dev_mode=true\n"
+	var lvtrcContent string
+	if existing, err := os.ReadFile(lvtrcPath); err == nil {
+		for _, line := range strings.Split(string(existing), "\n") {
+			if line == "" || strings.HasPrefix(strings.TrimSpace(line), "dev_mode=") {
+				continue
+			}
+			lvtrcContent += line + "\n"
+		}
+	}
+	lvtrcContent += "dev_mode=true\n"
 	if err := os.WriteFile(lvtrcPath, []byte(lvtrcContent), 0644); err != nil {
 		t.Fatalf("Failed to write .lvtrc: %v", err)
 	}
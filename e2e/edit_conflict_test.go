@@ -0,0 +1,199 @@
+//go:build browser
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// TestEditConflict tests a resource generated with --conflicts from two
+// browser tabs, each with its own connection: saving over a change made in
+// the other tab shows the conflict banner instead, whose Reload and
+// Overwrite buttons resolve it
+func TestEditConflict(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping test in short mode")
+	}
+
+	tmpDir := t.TempDir()
+
+	t.Log("Creating blog app...")
+	appDir := createTestApp(t, tmpDir, "blog", &AppOptions{
+		Kit: "multi",
+	})
+	setupLocalClientLibrary(t, appDir)
+
+	t.Log("Generating posts resource with --conflicts...")
+	runLvtCommand(t, appDir, "gen", "resource", "posts", "title", "--conflicts")
+
+	t.Log("Running migrations...")
+	runLvtCommand(t, appDir, "migration", "up")
+
+	t.Log("Building Docker image...")
+	serverPort := allocateTestPort()
+	imageName := "lvt-test-edit-conflict:latest"
+	buildDockerImage(t, appDir, imageName)
+	_ = runDockerContainer(t, imageName, serverPort)
+
+	serverURL := fmt.Sprintf("http://localhost:%d", serverPort)
+	waitForServer(t, serverURL+"/posts", 10*time.Second)
+	t.Log("✅ Server running")
+
+	browserCtx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+
+	testURL := getTestURL(serverPort)
+
+	row := func(title string) string {
+		return fmt.Sprintf(`Array.from(document.querySelectorAll('table tbody tr')).find(row => row.textContent.includes(%q))`, title)
+	}
+
+	// openTab opens the list in a tab of its own
+	openTab := func(t *testing.T) context.Context {
+		ctx, cancel := chromedp.NewContext(browserCtx, chromedp.WithLogf(t.Logf))
+		t.Cleanup(cancel)
+		ctx, timeoutCancel := context.WithTimeout(ctx, 90*time.Second)
+		t.Cleanup(timeoutCancel)
+		if err := chromedp.Run(ctx,
+			chromedp.Navigate(testURL+"/posts"),
+			waitForWebSocketReady(5*time.Second),
+			chromedp.WaitVisible(`[data-lvt-id]`, chromedp.ByQuery),
+		); err != nil {
+			t.Fatalf("Failed to open the list: %v", err)
+		}
+		return ctx
+	}
+
+	// openEdit opens the edit form of the post titled title
+	openEdit := func(title string) chromedp.Tasks {
+		return chromedp.Tasks{
+			waitFor(row(title)+` !== undefined`, 10*time.Second),
+			chromedp.Evaluate(row(title)+`.querySelector('button[name="edit"]').click()`, nil),
+			waitFor(`document.querySelector('form[name="update"] input[name="title"]') !== null`, 10*time.Second),
+		}
+	}
+
+	// saveTitle changes the title in the open edit form and saves it
+	saveTitle := func(title string) chromedp.Action {
+		return chromedp.Evaluate(fmt.Sprintf(`(() => {
+			const input = document.querySelector('form[name="update"] input[name="title"]');
+			input.value = %q;
+			input.dispatchEvent(new Event('input', { bubbles: true }));
+			document.querySelector('form[name="update"] button[type="submit"]').click();
+		})()`, title), nil)
+	}
+
+	mine, theirs := openTab(t), openTab(t)
+
+	if err := chromedp.Run(mine,
+		clickUntilModalOpens(`[command="show-modal"][commandfor="add-modal"]`, `input[name="title"]`, 15*time.Second),
+		chromedp.SendKeys(`form[name="add"] input[name="title"]`, "Original", chromedp.ByQuery),
+		chromedp.Click(`form[name="add"] button[type="submit"]`, chromedp.ByQuery),
+		waitFor(row("Original")+` !== undefined`, 10*time.Second),
+	); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := chromedp.Run(theirs,
+		chromedp.Navigate(testURL+"/posts"),
+		waitForWebSocketReady(5*time.Second),
+	); err != nil {
+		t.Fatalf("Failed to reload the other tab: %v", err)
+	}
+
+	// conflict opens the post in both tabs, saves it as theirTitle in the
+	// other tab, then as myTitle in this one
+	conflict := func(t *testing.T, title, theirTitle, myTitle string) {
+		t.Helper()
+		if err := chromedp.Run(mine, openEdit(title)); err != nil {
+			t.Fatalf("Failed to open the edit form: %v", err)
+		}
+		if err := chromedp.Run(theirs,
+			openEdit(title),
+			saveTitle(theirTitle),
+			waitFor(row(theirTitle)+` !== undefined`, 10*time.Second),
+		); err != nil {
+			t.Fatalf("Failed to save in the other tab: %v", err)
+		}
+		if err := chromedp.Run(mine,
+			saveTitle(myTitle),
+			waitFor(fmt.Sprintf(`(() => {
+				const banner = document.querySelector('[data-conflict="changed"]');
+				return banner !== null && banner.textContent.includes(%q) && banner.textContent.includes(%q);
+			})()`, theirTitle, myTitle), 10*time.Second),
+		); err != nil {
+			t.Fatalf("Conflict banner not shown: %v", err)
+		}
+	}
+
+	t.Run("Reload_Shows_Their_Change", func(t *testing.T) {
+		conflict(t, "Original", "Their Title", "My Title")
+		t.Log("✅ Saving over the other tab's change showed the banner")
+
+		var value string
+		err := chromedp.Run(mine,
+			chromedp.Click(`button[name="reload_edit"]`, chromedp.ByQuery),
+			waitFor(`document.querySelector('[data-conflict]') === null && document.querySelector('form[name="update"] input[name="title"]') !== null`, 10*time.Second),
+			chromedp.Evaluate(`document.querySelector('form[name="update"] input[name="title"]').value`, &value),
+			chromedp.Click(`button[name="cancel_edit"]`, chromedp.ByQuery),
+		)
+		if err != nil {
+			t.Fatalf("Reload failed: %v", err)
+		}
+		if value != "Their Title" {
+			t.Fatalf("❌ Reloaded form has title %q, want %q", value, "Their Title")
+		}
+		t.Log("✅ Reload showed the other tab's change")
+	})
+
+	t.Run("Overwrite_Saves_Mine", func(t *testing.T) {
+		conflict(t, "Their Title", "Their Second Title", "My Second Title")
+
+		err := chromedp.Run(mine,
+			chromedp.Click(`button[name="overwrite_edit"]`, chromedp.ByQuery),
+			waitFor(`document.querySelector('[data-conflict]') === null`, 10*time.Second),
+			waitFor(row("My Second Title")+` !== undefined`, 10*time.Second),
+		)
+		if err != nil {
+			t.Fatalf("Overwrite failed: %v", err)
+		}
+		if err := chromedp.Run(theirs,
+			chromedp.Navigate(testURL+"/posts"),
+			waitForWebSocketReady(5*time.Second),
+			waitFor(row("My Second Title")+` !== undefined`, 10*time.Second),
+		); err != nil {
+			t.Fatalf("Overwritten title not saved: %v", err)
+		}
+		t.Log("✅ Overwrite saved this tab's change")
+	})
+
+	t.Run("Deleted_Record", func(t *testing.T) {
+		if err := chromedp.Run(mine,
+			chromedp.Navigate(testURL+"/posts"),
+			waitForWebSocketReady(5*time.Second),
+			openEdit("My Second Title"),
+		); err != nil {
+			t.Fatalf("Failed to open the edit form: %v", err)
+		}
+		if err := chromedp.Run(theirs,
+			chromedp.Evaluate(`window.confirm = () => true;`, nil),
+			chromedp.Evaluate(row("My Second Title")+`.querySelector('button[name="delete"]').click()`, nil),
+			waitFor(row("My Second Title")+` === undefined`, 10*time.Second),
+		); err != nil {
+			t.Fatalf("Failed to delete in the other tab: %v", err)
+		}
+		if err := chromedp.Run(mine,
+			saveTitle("Too Late"),
+			waitFor(`document.querySelector('[data-conflict="deleted"]') !== null`, 10*time.Second),
+			chromedp.Click(`[data-conflict] button[name="cancel_edit"]`, chromedp.ByQuery),
+			waitFor(`document.querySelector('[data-conflict]') === null`, 10*time.Second),
+		); err != nil {
+			t.Fatalf("Deleted banner not shown: %v", err)
+		}
+		t.Log("✅ Saving a deleted post showed the banner")
+	})
+}
@@ -36,7 +36,7 @@ func TestEditModalReopenFix(t *testing.T) {
 	baseURL := getTestURL(8083)
 
 	// Check if the testfix server is running, skip if not
-	resp, err := http.Get(baseURL + "/health")
+	resp, err := http.Get(baseURL + "/healthz")
 	if err != nil {
 		t.Skipf("Skipping: testfix server not running on port 8083 (run 'PORT=8083 go run cmd/testfix/main.go' in testfix directory)")
 	}
@@ -56,7 +56,7 @@ func TestHTTP_AppHealthCheck(t *testing.T) {
 	}
 
 	// Test health endpoint
-	resp := test.Get("/health")
+	resp := test.Get("/healthz")
 	assert := lvttest.NewHTTPAssert(resp)
 	assert.StatusOK(t)
 	assert.Contains(t, `"status":"ok"`)
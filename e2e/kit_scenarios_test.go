@@ -0,0 +1,271 @@
+//go:build browser
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// kitScenario is one user-facing flow that every built-in kit must support.
+// Scenarios run in order against the same app, so later ones may rely on the
+// data earlier ones created.
+type kitScenario struct {
+	name string
+	// applies reports whether the kit generates the UI this scenario drives.
+	applies func(kit *kits.KitInfo) bool
+	run     func(t *testing.T, ctx context.Context, baseURL string)
+}
+
+const (
+	scenarioResource  = "items"
+	scenarioPageSize  = 3
+	scenarioSeedCount = 5
+	scenarioItemName  = "Kit Scenario Item"
+	scenarioItemEdit  = "Kit Scenario Item Edited"
+)
+
+func hasResourceTemplates(kit *kits.KitInfo) bool { return kit.Manifest.Templates.Resource }
+
+// hasCounterApp matches kits whose app template is the counter example.
+func hasCounterApp(kit *kits.KitInfo) bool {
+	return kit.Manifest.Templates.App && !kit.Manifest.Templates.Resource
+}
+
+var kitScenarios = []kitScenario{
+	{name: "Counter", applies: hasCounterApp, run: runCounterScenario},
+	{name: "Resource_Page", applies: hasResourceTemplates, run: runResourcePageScenario},
+	{name: "Add_Modal", applies: hasResourceTemplates, run: runAddModalScenario},
+	{name: "Pagination", applies: hasResourceTemplates, run: runPaginationScenario},
+	{name: "Create_And_Edit", applies: hasResourceTemplates, run: runCreateAndEditScenario},
+	{name: "Search", applies: hasResourceTemplates, run: runSearchScenario},
+	{name: "Delete", applies: hasResourceTemplates, run: runDeleteScenario},
+}
+
+// builtInKits returns the kits embedded in lvt, so a newly added kit is
+// covered without touching this file.
+func builtInKits(t *testing.T) []*kits.KitInfo {
+	t.Helper()
+	list, err := kits.DefaultLoader().List(&kits.KitSearchOptions{Source: kits.SourceSystem})
+	if err != nil {
+		t.Fatalf("Failed to list kits: %v", err)
+	}
+	if len(list) == 0 {
+		t.Fatal("No built-in kits found")
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Manifest.Name < list[j].Manifest.Name })
+	return list
+}
+
+// TestKitScenarios runs the shared scenario suite against an app generated
+// with each built-in kit, so kit-specific template regressions surface here
+// instead of only in the multi kit tests.
+func TestKitScenarios(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping kit scenario suite in short mode")
+	}
+
+	for _, kit := range builtInKits(t) {
+		t.Run(kit.Manifest.Name, func(t *testing.T) {
+			t.Parallel()
+
+			baseURL := startScenarioApp(t, kit)
+
+			ctx, _, cleanup := GetPooledChrome(t)
+			defer cleanup()
+
+			for _, scenario := range kitScenarios {
+				t.Run(scenario.name, func(t *testing.T) {
+					if !scenario.applies(kit) {
+						t.Skipf("kit %s does not generate the UI for this scenario", kit.Manifest.Name)
+					}
+					// A fresh tab per scenario gives each its own LiveTemplate session
+					tabCtx, cancel := chromedp.NewContext(ctx)
+					defer cancel()
+					tabCtx, timeoutCancel := context.WithTimeout(tabCtx, getBrowserTimeout())
+					defer timeoutCancel()
+					scenario.run(t, tabCtx, baseURL)
+				})
+			}
+		})
+	}
+}
+
+// startScenarioApp generates an app with the kit, adds a seeded resource when
+// the kit supports resources, and serves it natively. It returns the URL
+// Chrome should use to reach the app.
+func startScenarioApp(t *testing.T, kit *kits.KitInfo) string {
+	t.Helper()
+
+	appDir := createTestApp(t, t.TempDir(), "kitapp", &AppOptions{
+		Kit:           kit.Manifest.Name,
+		SkipGoModTidy: true,
+	})
+	// Before generating resources so DevMode=true is baked into handlers
+	enableDevMode(t, appDir)
+
+	if hasResourceTemplates(kit) {
+		if err := runLvtCommand(t, appDir, "gen", "resource", scenarioResource, "name",
+			"--pagination", "prev-next", "--page-size", fmt.Sprint(scenarioPageSize)); err != nil {
+			t.Fatalf("Failed to generate resource: %v", err)
+		}
+		if err := runLvtCommand(t, appDir, "migration", "up"); err != nil {
+			t.Fatalf("Failed to run migrations: %v", err)
+		}
+		if err := runLvtCommand(t, appDir, "seed", scenarioResource, "--count", fmt.Sprint(scenarioSeedCount)); err != nil {
+			t.Fatalf("Failed to seed data: %v", err)
+		}
+	}
+
+	port := allocateTestPort()
+	_ = buildAndRunNative(t, appDir, port)
+	return getTestURL(port)
+}
+
+// rowWithName returns a JS expression finding the table row whose first
+// cell is name.
+func rowWithName(name string) string {
+	return fmt.Sprintf(`Array.from(document.querySelectorAll('table tbody tr')).find(row => {
+		const cell = row.querySelector('td');
+		return cell && cell.textContent.trim() === %q;
+	})`, name)
+}
+
+func openResourcePage(baseURL string) chromedp.Tasks {
+	return chromedp.Tasks{
+		chromedp.Navigate(baseURL + "/" + scenarioResource),
+		waitForWebSocketReady(0),
+		chromedp.WaitVisible(`[data-lvt-id]`, chromedp.ByQuery),
+	}
+}
+
+func runCounterScenario(t *testing.T, ctx context.Context, baseURL string) {
+	verifyNoTemplateErrors(t, ctx, baseURL+"/")
+
+	err := chromedp.Run(ctx,
+		waitForWebSocketReady(0),
+		validateNoTemplateExpressions("[data-lvt-id]"),
+		chromedp.Click(`button[name="increment"]`, chromedp.ByQuery),
+		chromedp.Click(`button[name="increment"]`, chromedp.ByQuery),
+		waitFor(`document.body.innerText.includes('Counter: 2')`, 5*time.Second),
+		chromedp.Click(`button[name="reset"]`, chromedp.ByQuery),
+		waitFor(`document.body.innerText.includes('Counter: 0')`, 5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Counter actions did not update the page: %v", err)
+	}
+}
+
+func runResourcePageScenario(t *testing.T, ctx context.Context, baseURL string) {
+	verifyNoTemplateErrors(t, ctx, baseURL+"/"+scenarioResource)
+
+	var rows int
+	err := chromedp.Run(ctx,
+		waitForWebSocketReady(0),
+		validateNoTemplateExpressions("[data-lvt-id]"),
+		chromedp.Evaluate(`document.querySelectorAll('table tbody tr').length`, &rows),
+	)
+	if err != nil {
+		t.Fatalf("Resource page did not load: %v", err)
+	}
+	if rows != scenarioPageSize {
+		t.Errorf("First page shows %d rows, want %d", rows, scenarioPageSize)
+	}
+}
+
+func runAddModalScenario(t *testing.T, ctx context.Context, baseURL string) {
+	err := chromedp.Run(ctx,
+		openResourcePage(baseURL),
+		clickUntilModalOpens(`[command="show-modal"][commandfor="add-modal"]`, `dialog#add-modal input[name="name"]`, 15*time.Second),
+		chromedp.Click(`dialog#add-modal button[type="button"][command="close"]`, chromedp.ByQuery),
+		waitFor(`!document.querySelector('dialog#add-modal')?.open`, 5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Add modal did not open and close: %v", err)
+	}
+}
+
+func runPaginationScenario(t *testing.T, ctx context.Context, baseURL string) {
+	totalPages := (scenarioSeedCount + scenarioPageSize - 1) / scenarioPageSize
+	err := chromedp.Run(ctx,
+		openResourcePage(baseURL),
+		waitFor(fmt.Sprintf(`document.body.innerText.includes('Page 1 of %d')`, totalPages), 5*time.Second),
+		chromedp.Click(`button[name="next_page"]`, chromedp.ByQuery),
+		waitFor(fmt.Sprintf(`document.body.innerText.includes('Page 2 of %d')`, totalPages), 5*time.Second),
+		waitFor(fmt.Sprintf(`document.querySelectorAll('table tbody tr').length === %d`, scenarioSeedCount-scenarioPageSize), 5*time.Second),
+		chromedp.Click(`button[name="prev_page"]`, chromedp.ByQuery),
+		waitFor(fmt.Sprintf(`document.body.innerText.includes('Page 1 of %d')`, totalPages), 5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Pagination did not move between pages: %v", err)
+	}
+}
+
+func runCreateAndEditScenario(t *testing.T, ctx context.Context, baseURL string) {
+	err := chromedp.Run(ctx,
+		openResourcePage(baseURL),
+		clickUntilModalOpens(`[command="show-modal"][commandfor="add-modal"]`, `dialog#add-modal input[name="name"]`, 15*time.Second),
+		chromedp.SendKeys(`form[name="add"] input[name="name"]`, scenarioItemName, chromedp.ByQuery),
+		chromedp.Click(`form[name="add"] button[type="submit"]`, chromedp.ByQuery),
+		// New items are listed first
+		waitFor(rowWithName(scenarioItemName)+` !== undefined`, 10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Created item did not appear: %v", err)
+	}
+
+	err = chromedp.Run(ctx,
+		chromedp.Evaluate(rowWithName(scenarioItemName)+`.querySelector('button[name="edit"]').click()`, nil),
+		waitFor(`document.querySelector('form[name="update"] input[name="name"]') !== null`, 10*time.Second),
+		chromedp.Evaluate(fmt.Sprintf(`(() => {
+			const input = document.querySelector('form[name="update"] input[name="name"]');
+			input.value = %q;
+			input.dispatchEvent(new Event('input', { bubbles: true }));
+			document.querySelector('form[name="update"] button[type="submit"]').click();
+		})()`, scenarioItemEdit), nil),
+		waitFor(rowWithName(scenarioItemEdit)+` !== undefined`, 10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Edited item did not update: %v", err)
+	}
+}
+
+func runSearchScenario(t *testing.T, ctx context.Context, baseURL string) {
+	err := chromedp.Run(ctx,
+		openResourcePage(baseURL),
+		chromedp.Evaluate(`(() => {
+			const input = document.querySelector('input[name="query"]');
+			input.value = 'Scenario Item Edited';
+			input.dispatchEvent(new Event('change', { bubbles: true }));
+		})()`, nil),
+		waitFor(fmt.Sprintf(`document.querySelectorAll('table tbody tr').length === 1 && %s !== undefined`, rowWithName(scenarioItemEdit)), 10*time.Second),
+		chromedp.Evaluate(`(() => {
+			const input = document.querySelector('input[name="query"]');
+			input.value = 'no such item';
+			input.dispatchEvent(new Event('change', { bubbles: true }));
+		})()`, nil),
+		waitFor(`document.querySelectorAll('table tbody tr').length === 0`, 10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Search did not filter the list: %v", err)
+	}
+}
+
+func runDeleteScenario(t *testing.T, ctx context.Context, baseURL string) {
+	err := chromedp.Run(ctx,
+		openResourcePage(baseURL),
+		waitFor(rowWithName(scenarioItemEdit)+` !== undefined`, 5*time.Second),
+		chromedp.Evaluate(`window.confirm = () => true;`, nil),
+		chromedp.Evaluate(rowWithName(scenarioItemEdit)+`.querySelector('button[name="delete"]').click()`, nil),
+		waitFor(rowWithName(scenarioItemEdit)+` === undefined`, 10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Deleted item is still listed: %v", err)
+	}
+}
@@ -20,105 +20,28 @@ import (
 	"github.com/chromedp/chromedp"
 	"github.com/livetemplate/livetemplate"
 	e2etest "github.com/livetemplate/lvt/testing"
+	"github.com/livetemplate/lvt/testing/fixtures"
 )
 
 var updateGolden = flag.Bool("update-golden", false, "update golden files")
 
-// E2E test data structures
-type TodoItem struct {
-	ID        string `json:"id"`
-	Text      string `json:"text"`
-	Completed bool   `json:"completed"`
-	Priority  string `json:"priority,omitempty"`
-}
-
-type E2EAppState struct {
-	Title          string     `json:"title"`
-	Counter        int        `json:"counter"`
-	Todos          []TodoItem `json:"todos"`
-	TodoCount      int        `json:"todo_count"`
-	CompletedCount int        `json:"completed_count"`
-	RemainingCount int        `json:"remaining_count"`
-	CompletionRate int        `json:"completion_rate"`
-	LastUpdated    string     `json:"last_updated"`
-	SessionID      string     `json:"session_id"`
-}
-
-type CounterAppState struct {
-	Title       string `json:"title"`
-	Counter     int    `json:"counter"`
-	Status      string `json:"status"`
-	LastUpdated string `json:"last_updated"`
-	SessionID   string `json:"session_id"`
-}
-
 func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	// Initial state
-	initialState := E2EAppState{
-		Title:          "Task Manager",
-		Counter:        1,
-		Todos:          []TodoItem{},
-		TodoCount:      0,
-		CompletedCount: 0,
-		RemainingCount: 0,
-		CompletionRate: 0,
-		LastUpdated:    "2023-01-01 10:00:00",
-		SessionID:      "session-12345",
-	}
+	initialState := fixtures.TodosInitial()
 
 	// Update 1: Add some todos and increase counter
-	update1State := E2EAppState{
-		Title:   "Task Manager",
-		Counter: 3,
-		Todos: []TodoItem{
-			{ID: "todo-1", Text: "Learn Go templates", Completed: false, Priority: "High"},
-			{ID: "todo-2", Text: "Build live updates", Completed: true, Priority: "Medium"},
-			{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-		},
-		TodoCount:      3,
-		CompletedCount: 1,
-		RemainingCount: 2,
-		CompletionRate: 33,
-		LastUpdated:    "2023-01-01 10:15:00",
-		SessionID:      "session-12345",
-	}
+	update1State := fixtures.TodosAdded()
 
 	// Update 2: Remove a todo and increase counter significantly
-	update2State := E2EAppState{
-		Title:   "Task Manager",
-		Counter: 8, // Triggers "High Activity" status
-		Todos: []TodoItem{
-			{ID: "todo-1", Text: "Learn Go templates", Completed: false, Priority: "High"}, // Keep same completion status
-			{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-		},
-		TodoCount:      2,
-		CompletedCount: 0, // Adjusted since no todos are completed now
-		RemainingCount: 2, // Both todos are remaining
-		CompletionRate: 0, // 0% completion
-		LastUpdated:    "2023-01-01 10:30:00",
-		SessionID:      "session-12345",
-	}
+	update2State := fixtures.TodosRemoved()
 
 	// Update 3: Complete ONE todo (tests single update operation)
 	// Note: Items are in reverse alphabetical order for sorting test
-	update3State := E2EAppState{
-		Title:   "Task Manager",
-		Counter: 8, // Same counter value
-		Todos: []TodoItem{
-			{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"}, // Keep uncompleted
-			{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},  // Complete this one
-		},
-		TodoCount:      2,
-		CompletedCount: 1,  // Only 1 completed
-		RemainingCount: 1,  // 1 remaining
-		CompletionRate: 50, // 50% completion
-		LastUpdated:    "2023-01-01 10:45:00",
-		SessionID:      "session-12345",
-	}
+	update3State := fixtures.TodosCompleted()
 
 	// Create template
 	tmpl := livetemplate.Must(livetemplate.New("e2e-test"))
-	_, err := tmpl.ParseFiles("testdata/e2e/todos/input.tmpl")
+	_, err := fixtures.Parse(tmpl, fixtures.TodosTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse template: %v", err)
 	}
@@ -154,7 +77,7 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 
 		// Generate the initial tree structure for TypeScript client (force first render)
 		tmplForTree := livetemplate.Must(livetemplate.New("e2e-tree-test"))
-		_, err = tmplForTree.ParseFiles("testdata/e2e/todos/input.tmpl")
+		_, err = fixtures.Parse(tmplForTree, fixtures.TodosTemplate)
 		if err == nil {
 			var treeBuf bytes.Buffer
 			err = tmplForTree.ExecuteUpdates(&treeBuf, initialState)
@@ -186,7 +109,7 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	t.Run("2_Add_Todos_Update", func(t *testing.T) {
 		// Create a fresh template instance for the first update to include statics
 		tmplFirstUpdate := livetemplate.Must(livetemplate.New("e2e-first-update"))
-		_, err := tmplFirstUpdate.ParseFiles("testdata/e2e/todos/input.tmpl")
+		_, err := fixtures.Parse(tmplFirstUpdate, fixtures.TodosTemplate)
 		if err != nil {
 			t.Fatalf("Failed to parse template: %v", err)
 		}
@@ -483,21 +406,7 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	// Step 5: Sort todos alphabetically
 	t.Run("5_Sort_Todos_Alphabetically", func(t *testing.T) {
 		// Create sorted state (same content, just reordered)
-		sortedState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 8,
-			Todos: []TodoItem{
-				// Sorted alphabetically by Text field
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      2,
-			CompletedCount: 1,
-			RemainingCount: 1,
-			CompletionRate: 50,
-			LastUpdated:    "2023-01-01 10:50:00",
-			SessionID:      "session-12345",
-		}
+		sortedState := fixtures.TodosSorted()
 
 		// Continue with the same template to maintain state
 		var prevBuf1, prevBuf2, prevBuf3 bytes.Buffer
@@ -614,39 +523,10 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	t.Run("5a_Insert_Single_Start", func(t *testing.T) {
 		// t.Skip("Skipping - Phase 3 golden file needs whitespace adjustment for prepend statics")
 		// Create state with one new todo inserted at the beginning
-		insertStartState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 9, // Increment counter
-			Todos: []TodoItem{
-				// NEW todo inserted at start
-				{ID: "todo-4", Text: "Setup development environment", Completed: false, Priority: "High"},
-				// Existing todos (alphabetically sorted from previous step)
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      3,
-			CompletedCount: 1,
-			RemainingCount: 2,
-			CompletionRate: 33,
-			LastUpdated:    "2023-01-01 11:00:00",
-			SessionID:      "session-12345",
-		}
+		insertStartState := fixtures.TodosInsertedStart()
 
 		// Define the sorted state from previous step
-		sortedState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 8,
-			Todos: []TodoItem{
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      2,
-			CompletedCount: 1,
-			RemainingCount: 1,
-			CompletionRate: 50,
-			LastUpdated:    "2023-01-01 10:50:00",
-			SessionID:      "session-12345",
-		}
+		sortedState := fixtures.TodosSorted()
 
 		// Continue with the same template to maintain state from sorting
 		var prevBuf1, prevBuf2, prevBuf3, prevBuf4 bytes.Buffer
@@ -739,57 +619,12 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	// Step 5b: Single item insertion in middle
 	t.Run("5b_Insert_Single_Middle", func(t *testing.T) {
 		// Create state with one new todo inserted between existing todos
-		insertMiddleState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 10, // Increment counter
-			Todos: []TodoItem{
-				// Existing todo from previous step
-				{ID: "todo-4", Text: "Setup development environment", Completed: false, Priority: "High"},
-				// NEW todo inserted in middle (after todo-4, before todo-1)
-				{ID: "todo-5", Text: "Configure CI/CD pipeline", Completed: false, Priority: "Medium"},
-				// Existing todos
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      4,
-			CompletedCount: 1,
-			RemainingCount: 3,
-			CompletionRate: 25,
-			LastUpdated:    "2023-01-01 11:15:00",
-			SessionID:      "session-12345",
-		}
+		insertMiddleState := fixtures.TodosInsertedMiddle()
 
 		// Define the previous state (after insert at start)
-		insertStartState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 9,
-			Todos: []TodoItem{
-				{ID: "todo-4", Text: "Setup development environment", Completed: false, Priority: "High"},
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      3,
-			CompletedCount: 1,
-			RemainingCount: 2,
-			CompletionRate: 33,
-			LastUpdated:    "2023-01-01 11:00:00",
-			SessionID:      "session-12345",
-		}
-
-		sortedState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 8,
-			Todos: []TodoItem{
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      2,
-			CompletedCount: 1,
-			RemainingCount: 1,
-			CompletionRate: 50,
-			LastUpdated:    "2023-01-01 10:50:00",
-			SessionID:      "session-12345",
-		}
+		insertStartState := fixtures.TodosInsertedStart()
+
+		sortedState := fixtures.TodosSorted()
 
 		// Continue with the same template to maintain state from previous insertions
 		var prevBuf1, prevBuf2, prevBuf3, prevBuf4, prevBuf5 bytes.Buffer
@@ -886,76 +721,14 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	t.Run("6_Multiple_Range_Operations", func(t *testing.T) {
 		// t.Skip("Skipping - Phase 3 golden file needs whitespace adjustment for append statics")
 		// Create state with multiple simultaneous changes: removes, updates, and adds
-		multipleOpsState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 11, // Increment counter
-			Todos: []TodoItem{
-				// todo-5 removed (was "Configure CI/CD pipeline")
-				// todo-4 remains but marked completed
-				{ID: "todo-4", Text: "Setup development environment", Completed: true, Priority: "High"},
-				// todo-1 remains unchanged
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				// todo-3 removed (was "Write documentation")
-				// NEW todos added
-				{ID: "todo-6", Text: "Deploy to production", Completed: false, Priority: "Critical"},
-				{ID: "todo-7", Text: "Monitor performance", Completed: false, Priority: "Medium"},
-			},
-			TodoCount:      4,
-			CompletedCount: 2, // todo-4 and todo-1 are completed
-			RemainingCount: 2, // todo-6 and todo-7 are not
-			CompletionRate: 50,
-			LastUpdated:    "2023-01-01 11:30:00",
-			SessionID:      "session-12345",
-		}
+		multipleOpsState := fixtures.TodosMultipleOps()
 
 		// Define the previous state (after insert in middle)
-		insertMiddleState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 10,
-			Todos: []TodoItem{
-				{ID: "todo-4", Text: "Setup development environment", Completed: false, Priority: "High"},
-				{ID: "todo-5", Text: "Configure CI/CD pipeline", Completed: false, Priority: "Medium"},
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      4,
-			CompletedCount: 1,
-			RemainingCount: 3,
-			CompletionRate: 25,
-			LastUpdated:    "2023-01-01 11:15:00",
-			SessionID:      "session-12345",
-		}
-
-		insertStartState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 9,
-			Todos: []TodoItem{
-				{ID: "todo-4", Text: "Setup development environment", Completed: false, Priority: "High"},
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      3,
-			CompletedCount: 1,
-			RemainingCount: 2,
-			CompletionRate: 33,
-			LastUpdated:    "2023-01-01 11:00:00",
-			SessionID:      "session-12345",
-		}
-
-		sortedState := E2EAppState{
-			Title:   "Task Manager",
-			Counter: 8,
-			Todos: []TodoItem{
-				{ID: "todo-1", Text: "Learn Go templates", Completed: true, Priority: "High"},
-				{ID: "todo-3", Text: "Write documentation", Completed: false, Priority: "Low"},
-			},
-			TodoCount:      2,
-			CompletedCount: 1,
-			RemainingCount: 1,
-			CompletionRate: 50,
-			LastUpdated:    "2023-01-01 10:50:00",
-			SessionID:      "session-12345",
-		}
+		insertMiddleState := fixtures.TodosInsertedMiddle()
+
+		insertStartState := fixtures.TodosInsertedStart()
+
+		sortedState := fixtures.TodosSorted()
 
 		// Continue with the same template to maintain state from all previous tests
 		var prevBuf1, prevBuf2, prevBuf3, prevBuf4, prevBuf5, prevBuf6 bytes.Buffer
@@ -1072,7 +845,7 @@ func TestTemplate_E2E_CompleteRenderingSequence(t *testing.T) {
 	t.Run("7_No_Change_Update", func(t *testing.T) {
 		// Use the same sequence as step 4 to ensure proper fingerprint comparison
 		tmplSequence3 := livetemplate.Must(livetemplate.New("e2e-sequence-3"))
-		_, err := tmplSequence3.ParseFiles("testdata/e2e/todos/input.tmpl")
+		_, err := fixtures.Parse(tmplSequence3, fixtures.TodosTemplate)
 		if err != nil {
 			t.Fatalf("Failed to parse template: %v", err)
 		}
@@ -1642,17 +1415,20 @@ func getMapKeys(m map[string]interface{}) []string {
 func compareWithGoldenFile(t *testing.T, appType, updateName string, generatedUpdate map[string]interface{}) {
 	goldenFile := "testdata/e2e/" + appType + "/" + updateName + ".golden.json"
 
-	// Convert generated update to map for comparison
-	generated := map[string]interface{}(generatedUpdate)
+	// Goldens are stored and compared in canonical form, so map iteration
+	// order inside livetemplate cannot make them flaky
+	generatedJSON, err := json.Marshal(generatedUpdate)
+	if err != nil {
+		t.Fatalf("Failed to marshal generated update: %v", err)
+	}
+	generatedJSON, err = e2etest.CanonicalUpdate(generatedJSON)
+	if err != nil {
+		t.Fatalf("Failed to canonicalize generated update: %v", err)
+	}
 
 	if *updateGolden {
 		// Update mode: write the generated data to golden file
-		generatedJSON, err := json.MarshalIndent(generated, "", "  ")
-		if err != nil {
-			t.Fatalf("Failed to marshal generated update: %v", err)
-		}
-
-		err = os.WriteFile(goldenFile, generatedJSON, 0644)
+		err = os.WriteFile(goldenFile, append(generatedJSON, '\n'), 0644)
 		if err != nil {
 			t.Fatalf("Failed to write golden file %s: %v", goldenFile, err)
 		}
@@ -1668,25 +1444,22 @@ func compareWithGoldenFile(t *testing.T, appType, updateName string, generatedUp
 		return
 	}
 
-	// Parse golden file
-	var expected map[string]interface{}
-	err = json.Unmarshal(goldenData, &expected)
+	expectedJSON, err := e2etest.CanonicalUpdate(goldenData)
 	if err != nil {
 		t.Fatalf("Failed to parse golden file %s: %v", goldenFile, err)
 	}
 
 	// Compare structures
-	if !reflect.DeepEqual(expected, generated) {
+	if !bytes.Equal(expectedJSON, generatedJSON) {
 		t.Errorf("Generated update for %s does not match golden file", updateName)
 
-		// Show detailed differences
-		expectedJSON, _ := json.MarshalIndent(expected, "", "  ")
-		generatedJSON, _ := json.MarshalIndent(generated, "", "  ")
-
 		t.Logf("Expected (golden):\n%s", string(expectedJSON))
 		t.Logf("Generated (actual):\n%s", string(generatedJSON))
 
 		// Show specific differences
+		var expected, generated map[string]interface{}
+		_ = json.Unmarshal(expectedJSON, &expected)
+		_ = json.Unmarshal(generatedJSON, &generated)
 		showDifferences(t, expected, generated, "")
 	} else {
 		t.Logf("✅ %s matches golden file perfectly", updateName)
@@ -1722,62 +1495,26 @@ func showDifferences(t *testing.T, expected, actual map[string]interface{}, pref
 
 func TestTemplate_E2E_SimpleCounter(t *testing.T) {
 	// Initial state
-	initialState := CounterAppState{
-		Title:       "Simple Counter",
-		Counter:     0,
-		Status:      "zero",
-		LastUpdated: "2023-01-01 10:00:00",
-		SessionID:   "counter-12345",
-	}
+	initialState := fixtures.CounterInitial()
 
 	// Update 1: Increment counter
-	update1State := CounterAppState{
-		Title:       "Simple Counter",
-		Counter:     5,
-		Status:      "positive",
-		LastUpdated: "2023-01-01 10:05:00",
-		SessionID:   "counter-12345",
-	}
+	update1State := fixtures.CounterIncremented()
 
 	// Update 2: Large increment
-	update2State := CounterAppState{
-		Title:       "Simple Counter",
-		Counter:     25,
-		Status:      "positive",
-		LastUpdated: "2023-01-01 10:10:00",
-		SessionID:   "counter-12345",
-	}
+	update2State := fixtures.CounterLargeIncrement()
 
 	// Update 3: Decrement
-	update3State := CounterAppState{
-		Title:       "Simple Counter",
-		Counter:     10,
-		Status:      "positive",
-		LastUpdated: "2023-01-01 10:15:00",
-		SessionID:   "counter-12345",
-	}
+	update3State := fixtures.CounterDecremented()
 
 	// Update 4: Go negative
-	update4State := CounterAppState{
-		Title:       "Simple Counter",
-		Counter:     -3,
-		Status:      "negative",
-		LastUpdated: "2023-01-01 10:20:00",
-		SessionID:   "counter-12345",
-	}
+	update4State := fixtures.CounterNegative()
 
 	// Update 5: Reset to zero
-	update5State := CounterAppState{
-		Title:       "Simple Counter",
-		Counter:     0,
-		Status:      "zero",
-		LastUpdated: "2023-01-01 10:25:00",
-		SessionID:   "counter-12345",
-	}
+	update5State := fixtures.CounterReset()
 
 	// Create template
 	tmpl := livetemplate.Must(livetemplate.New("counter-e2e-test"))
-	_, err := tmpl.ParseFiles("testdata/e2e/counter/input.tmpl")
+	_, err := fixtures.Parse(tmpl, fixtures.CounterTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse template: %v", err)
 	}
@@ -1814,7 +1551,7 @@ func TestTemplate_E2E_SimpleCounter(t *testing.T) {
 
 		// Generate the initial tree structure for TypeScript client (force first render)
 		tmplForTree := livetemplate.Must(livetemplate.New("counter-tree-test"))
-		_, err = tmplForTree.ParseFiles("testdata/e2e/counter/input.tmpl")
+		_, err = fixtures.Parse(tmplForTree, fixtures.CounterTemplate)
 		if err == nil {
 			var treeBuf bytes.Buffer
 			err = tmplForTree.ExecuteUpdates(&treeBuf, initialState)
@@ -1845,7 +1582,7 @@ func TestTemplate_E2E_SimpleCounter(t *testing.T) {
 	// Step 2: Increment counter
 	t.Run("2_Increment_Update", func(t *testing.T) {
 		tmplFirstUpdate := livetemplate.Must(livetemplate.New("counter-first-update"))
-		_, err := tmplFirstUpdate.ParseFiles("testdata/e2e/counter/input.tmpl")
+		_, err := fixtures.Parse(tmplFirstUpdate, fixtures.CounterTemplate)
 		if err != nil {
 			t.Fatalf("Failed to parse template: %v", err)
 		}
@@ -2192,7 +1929,7 @@ func TestTemplate_E2E_SimpleCounter(t *testing.T) {
 	// Step 7: No-change test (verify caching)
 	t.Run("7_No_Change_Update", func(t *testing.T) {
 		tmplSequence := livetemplate.Must(livetemplate.New("counter-sequence"))
-		_, err := tmplSequence.ParseFiles("testdata/e2e/counter/input.tmpl")
+		_, err := fixtures.Parse(tmplSequence, fixtures.CounterTemplate)
 		if err != nil {
 			t.Fatalf("Failed to parse template: %v", err)
 		}
@@ -2256,36 +1993,13 @@ func TestTemplate_E2E_SimpleCounter(t *testing.T) {
 
 func TestTemplate_E2E_ComponentBased(t *testing.T) {
 	// Test with component-based template (like generated myblog resources)
-	initialState := E2EAppState{
-		Title:          "Component Test",
-		Counter:        1,
-		Todos:          []TodoItem{},
-		TodoCount:      0,
-		CompletedCount: 0,
-		RemainingCount: 0,
-		CompletionRate: 0,
-		LastUpdated:    "2023-01-01 10:00:00",
-		SessionID:      "comp-12345",
-	}
+	initialState := fixtures.ComponentsInitial()
 
-	updateState := E2EAppState{
-		Title:   "Component Test",
-		Counter: 5,
-		Todos: []TodoItem{
-			{ID: "todo-1", Text: "Test component templates", Completed: false},
-			{ID: "todo-2", Text: "Verify flattening works", Completed: true},
-		},
-		TodoCount:      2,
-		CompletedCount: 1,
-		RemainingCount: 1,
-		CompletionRate: 50,
-		LastUpdated:    "2023-01-01 10:15:00",
-		SessionID:      "comp-12345",
-	}
+	updateState := fixtures.ComponentsUpdated()
 
 	// Create template using component-based template file
 	tmpl := livetemplate.Must(livetemplate.New("component-test"))
-	_, err := tmpl.ParseFiles("testdata/e2e/components/input.tmpl")
+	_, err := fixtures.Parse(tmpl, fixtures.ComponentsTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse component-based template: %v", err)
 	}
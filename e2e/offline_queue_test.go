@@ -0,0 +1,96 @@
+//go:build browser
+
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	e2etest "github.com/livetemplate/lvt/testing"
+)
+
+// TestOfflineQueue stops the server, sends a chat message while the page is
+// offline and checks that, once the server is back, the queued message is
+// sent and shows up exactly once.
+func TestOfflineQueue(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	appDir := createTestApp(t, tmpDir, "offlineapp", nil)
+
+	// Enable DevMode BEFORE generating views so DevMode=true gets baked into handler code
+	enableDevMode(t, appDir)
+
+	if err := runLvtCommand(t, appDir, "gen", "offline"); err != nil {
+		t.Fatalf("Failed to set up offline actions: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "gen", "view", "lobby", "--pattern", "chat"); err != nil {
+		t.Fatalf("Failed to generate view: %v", err)
+	}
+
+	port := allocateTestPort()
+	server := buildAndRunNative(t, appDir, port)
+
+	ctx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(fmt.Sprintf("%s/lobby", e2etest.GetChromeTestURL(port))),
+		e2etest.WaitForWebSocketReady(30*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load the lobby: %v", err)
+	}
+
+	if err := server.Process.Kill(); err != nil {
+		t.Fatalf("Failed to kill server: %v", err)
+	}
+	_ = server.Wait()
+
+	// The page notices the dropped connection and queues the message
+	err = chromedp.Run(ctx,
+		waitFor(`!!document.querySelector('[data-lvt-offline]')`, 10*time.Second),
+		chromedp.SendKeys(`form[name="send"] input[name="author"]`, "Ada", chromedp.ByQuery),
+		chromedp.SendKeys(`form[name="send"] input[name="text"]`, "sent while offline", chromedp.ByQuery),
+		chromedp.Click(`form[name="send"] button[type="submit"]`, chromedp.ByQuery),
+		waitFor(`document.querySelector('[data-lvt-offline]').textContent.indexOf('1 action') >= 0`, 5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Message was not queued while offline: %v", err)
+	}
+
+	restarted := exec.Command(filepath.Join(appDir, "server"))
+	restarted.Dir = appDir
+	restarted.Env = append(os.Environ(), "PORT="+strconv.Itoa(port), "LVT_DEV_MODE=true")
+	if err := restarted.Start(); err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = restarted.Process.Kill()
+		_ = restarted.Wait()
+	})
+	waitForServer(t, fmt.Sprintf("http://localhost:%d/lobby", port), 10*time.Second)
+
+	// The client reconnects by itself and the queue is sent
+	var count int
+	err = chromedp.Run(ctx,
+		waitFor(`!document.querySelector('[data-lvt-offline]')`, 30*time.Second),
+		waitFor(`document.querySelector('[data-chat-log]').textContent.indexOf('sent while offline') >= 0`, 10*time.Second),
+		chromedp.Evaluate(`document.querySelector('[data-chat-log]').textContent.split('sent while offline').length - 1`, &count),
+	)
+	if err != nil {
+		var html string
+		_ = chromedp.Run(ctx, chromedp.OuterHTML("body", &html))
+		t.Fatalf("Queued message was not sent after the restart: %v\nBody: %s", err, truncateString(html, 2000))
+	}
+	if count != 1 {
+		t.Errorf("Queued message shows up %d times, want 1", count)
+	}
+	t.Log("✅ Action queued while offline was sent once after reconnecting")
+}
@@ -38,7 +38,7 @@ func TestProductionTemplate(t *testing.T) {
 		}
 		envStr := string(content)
 
-		for _, expected := range []string{"PORT=", "APP_ENV=", "LOG_LEVEL=", "DATABASE_PATH=", "CLIENT_LIB_PATH"} {
+		for _, expected := range []string{"PORT=", "LVT_ENV=", "LOG_LEVEL=", "DATABASE_PATH_DEVELOPMENT=", "CLIENT_LIB_PATH"} {
 			if !strings.Contains(envStr, expected) {
 				t.Errorf(".env.example missing %q", expected)
 			}
@@ -62,9 +62,9 @@ func TestProductionTemplate(t *testing.T) {
 
 	// Step 4: Test health endpoints
 	t.Run("HealthLive", func(t *testing.T) {
-		resp, err := http.Get(serverURL + "/health/live")
+		resp, err := http.Get(serverURL + "/healthz")
 		if err != nil {
-			t.Fatalf("GET /health/live failed: %v", err)
+			t.Fatalf("GET /healthz failed: %v", err)
 		}
 		defer resp.Body.Close()
 
@@ -82,15 +82,15 @@ func TestProductionTemplate(t *testing.T) {
 		if err := json.Unmarshal(body, &result); err != nil {
 			t.Fatalf("invalid JSON response: %v", err)
 		}
-		if result["status"] != "healthy" {
-			t.Errorf("expected status=healthy, got %q", result["status"])
+		if result["status"] != "ok" {
+			t.Errorf("expected status=ok, got %q", result["status"])
 		}
 	})
 
 	t.Run("HealthReady", func(t *testing.T) {
-		resp, err := http.Get(serverURL + "/health/ready")
+		resp, err := http.Get(serverURL + "/readyz")
 		if err != nil {
-			t.Fatalf("GET /health/ready failed: %v", err)
+			t.Fatalf("GET /readyz failed: %v", err)
 		}
 		defer resp.Body.Close()
 
@@ -103,8 +103,8 @@ func TestProductionTemplate(t *testing.T) {
 		if err := json.Unmarshal(body, &result); err != nil {
 			t.Fatalf("invalid JSON response: %v", err)
 		}
-		if result["status"] != "healthy" {
-			t.Errorf("expected status=healthy, got %q", result["status"])
+		if result["status"] != "ok" {
+			t.Errorf("expected status=ok, got %q", result["status"])
 		}
 	})
 
@@ -257,7 +257,7 @@ func buildAndRunProdApp(t *testing.T, appDir string, port int) (*exec.Cmd, strin
 
 	// Wait for server to be ready
 	serverURL := fmt.Sprintf("http://localhost:%d", port)
-	waitForServer(t, serverURL+"/health/live", 15*time.Second)
+	waitForServer(t, serverURL+"/healthz", 15*time.Second)
 
 	t.Cleanup(func() {
 		if serverCmd.Process != nil {
@@ -415,6 +415,28 @@ func TestResourceGen_TextareaFields(t *testing.T) {
 	t.Log("✅ Textarea fields test passed")
 }
 
+// TestResourceGen_UndoWithHooks tests that --undo with --hooks compiles
+// without audit or events, with and without the create action
+func TestResourceGen_UndoWithHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	appDir := createTestApp(t, tmpDir, "testapp", nil)
+
+	t.Log("Generating posts and notes resources with --undo --hooks...")
+	if err := runLvtCommand(t, appDir, "gen", "resource", "posts", "title:string", "--undo", "--hooks"); err != nil {
+		t.Fatalf("Failed to generate posts: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "gen", "resource", "notes", "body:string",
+		"--actions", "list,delete", "--undo", "--hooks"); err != nil {
+		t.Fatalf("Failed to generate notes: %v", err)
+	}
+
+	// Validate generated code compiles
+	helpers.ValidateCompilation(t, appDir)
+
+	t.Log("✅ Undo with hooks test passed")
+}
+
 // TestResourceGen_AllFieldTypes tests all supported field types in one resource
 func TestResourceGen_AllFieldTypes(t *testing.T) {
 	tmpDir := t.TempDir()
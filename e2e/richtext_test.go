@@ -0,0 +1,88 @@
+//go:build browser
+
+package e2e
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	e2etest "github.com/livetemplate/lvt/testing"
+)
+
+// TestRichText adds posts with a richtext body: one written in the editor,
+// and one whose submitted HTML carries an event handler, which must be
+// sanitized away before the list renders it.
+func TestRichText(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	appDir := createTestApp(t, tmpDir, "rtapp", nil)
+
+	// Enable DevMode BEFORE generating resources so DevMode=true gets baked into handler code
+	enableDevMode(t, appDir)
+
+	if err := runLvtCommand(t, appDir, "gen", "resource", "posts", "title", "body:richtext"); err != nil {
+		t.Fatalf("Failed to generate resource: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "migration", "up"); err != nil {
+		t.Fatalf("Failed to run migration: %v", err)
+	}
+
+	port := allocateTestPort()
+	_ = buildAndRunNative(t, appDir, port)
+
+	ctx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+	ctx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	addButton := `[command="show-modal"][commandfor="add-modal"]`
+	postsURL := fmt.Sprintf("%s/posts", e2etest.GetChromeTestURL(port))
+	var body string
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(postsURL),
+		waitForWebSocketReady(10*time.Second),
+		chromedp.WaitVisible(addButton, chromedp.ByQuery),
+		clickUntilModalOpens(addButton, `[data-richtext-editor]`, 15*time.Second),
+		chromedp.SendKeys(`input[name="title"]`, "Edited", chromedp.ByQuery),
+		chromedp.Click(`[data-richtext-editor]`, chromedp.ByQuery),
+		chromedp.Click(`[data-command="bold"]`, chromedp.ByQuery),
+		chromedp.SendKeys(`[data-richtext-editor]`, "Bold words", chromedp.ByQuery),
+		chromedp.Value(`textarea[name="body"]`, &body, chromedp.ByQuery),
+	)
+	if err != nil {
+		t.Fatalf("Failed to write in the editor: %v", err)
+	}
+	if body != "<b>Bold words</b>" {
+		t.Errorf("Editor submits %q, want the bold text", body)
+	}
+
+	var pwned bool
+	var imgs int
+	err = chromedp.Run(ctx,
+		chromedp.Click(`button[type="submit"]`, chromedp.ByQuery),
+		waitFor(`document.body.textContent.indexOf('Bold words') >= 0`, 10*time.Second),
+		clickUntilModalOpens(addButton, `[data-richtext-editor]`, 15*time.Second),
+		chromedp.SendKeys(`input[name="title"]`, "Injected", chromedp.ByQuery),
+		chromedp.SendKeys(`[data-richtext-editor]`, "x", chromedp.ByQuery),
+		// Submit HTML the editor would not produce, as a hand-made request could
+		chromedp.Evaluate(`document.querySelector('textarea[name="body"]').value =
+			'<p>Injected body<img src=x onerror="window.pwned = true"></p>'`, nil),
+		chromedp.Click(`button[type="submit"]`, chromedp.ByQuery),
+		waitFor(`document.body.textContent.indexOf('Injected body') >= 0`, 10*time.Second),
+		chromedp.Sleep(500*time.Millisecond),
+		chromedp.Evaluate(`!!window.pwned`, &pwned),
+		chromedp.Evaluate(`document.querySelectorAll('img[src="x"]').length`, &imgs),
+	)
+	if err != nil {
+		var html string
+		_ = chromedp.Run(ctx, chromedp.OuterHTML("body", &html))
+		t.Fatalf("Failed to add the injected post: %v\nBody: %s", err, truncateString(html, 2000))
+	}
+	if pwned || imgs > 0 {
+		t.Errorf("Injected handler survived: ran=%v, images=%d", pwned, imgs)
+	}
+	t.Log("✅ Rich text is edited with formatting and rendered sanitized")
+}
@@ -0,0 +1,143 @@
+//go:build browser
+
+package e2e
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cdplog "github.com/chromedp/cdproto/log"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+	e2etest "github.com/livetemplate/lvt/testing"
+)
+
+// TestSecurityHeaders generates a resource after `lvt gen security-headers`
+// and checks that the page works under the enforced Content-Security-Policy:
+// its inline scripts carry the response's nonce, the browser reports no
+// violation while an item is added, and delete confirmations still ask.
+func TestSecurityHeaders(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	appDir := createTestApp(t, tmpDir, "secapp", nil)
+
+	// Enable DevMode BEFORE generating resources so DevMode=true gets baked into handler code
+	enableDevMode(t, appDir)
+
+	if err := runLvtCommand(t, appDir, "gen", "security-headers"); err != nil {
+		t.Fatalf("Failed to set up security headers: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "gen", "resource", "posts", "title"); err != nil {
+		t.Fatalf("Failed to generate resource: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "migration", "up"); err != nil {
+		t.Fatalf("Failed to run migration: %v", err)
+	}
+
+	port := allocateTestPort()
+	_ = buildAndRunNative(t, appDir, port)
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/posts", port))
+	if err != nil {
+		t.Fatalf("Failed to fetch page: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	policy := resp.Header.Get("Content-Security-Policy")
+	m := regexp.MustCompile(`script-src [^;]*'nonce-([^']+)'`).FindStringSubmatch(policy)
+	if m == nil {
+		t.Fatalf("Content-Security-Policy %q has no script nonce", policy)
+	}
+	for _, tag := range regexp.MustCompile(`<script\b[^>]*>`).FindAllString(string(body), -1) {
+		if !strings.Contains(tag, " src=") && !strings.Contains(tag, `nonce="`+m[1]+`"`) {
+			t.Errorf("Inline script without the response's nonce: %s", tag)
+		}
+	}
+	if got := resp.Header.Get("X-Frame-Options"); got != "DENY" {
+		t.Errorf("X-Frame-Options = %q, want DENY", got)
+	}
+
+	ctx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+	ctx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	// Blocked scripts, handlers and connections are logged as security entries
+	var (
+		mu         sync.Mutex
+		violations []string
+		dialogs    []string
+	)
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		switch ev := ev.(type) {
+		case *cdplog.EventEntryAdded:
+			if ev.Entry.Source == cdplog.SourceSecurity {
+				mu.Lock()
+				violations = append(violations, ev.Entry.Text)
+				mu.Unlock()
+			}
+		case *page.EventJavascriptDialogOpening:
+			mu.Lock()
+			dialogs = append(dialogs, ev.Message)
+			mu.Unlock()
+			go func() {
+				_ = chromedp.Run(ctx, page.HandleJavaScriptDialog(false))
+			}()
+		}
+	})
+
+	postsURL := fmt.Sprintf("%s/posts", e2etest.GetChromeTestURL(port))
+	err = chromedp.Run(ctx,
+		chromedp.Navigate(postsURL),
+		waitForWebSocketReady(10*time.Second),
+		chromedp.WaitVisible(`[command="show-modal"][commandfor="add-modal"]`, chromedp.ByQuery),
+		clickUntilModalOpens(`[command="show-modal"][commandfor="add-modal"]`, `input[name="title"]`, 15*time.Second),
+		chromedp.SendKeys(`input[name="title"]`, "Under the policy", chromedp.ByQuery),
+		chromedp.Click(`button[type="submit"]`, chromedp.ByQuery),
+		waitFor(`document.body.textContent.indexOf('Under the policy') >= 0`, 10*time.Second),
+	)
+	if err != nil {
+		var html string
+		_ = chromedp.Run(ctx, chromedp.OuterHTML("body", &html))
+		t.Fatalf("Failed to add a post under the policy: %v\nBody: %s", err, truncateString(html, 2000))
+	}
+
+	// The page's nonce'd script turns data-confirm into a confirmation;
+	// dismissing it must keep the click from reaching the page
+	var clicked bool
+	err = chromedp.Run(ctx,
+		chromedp.Evaluate(`(() => {
+			const b = document.createElement('button');
+			b.id = 'confirm-probe';
+			b.setAttribute('data-confirm', 'Delete the probe?');
+			b.addEventListener('click', () => { window.probeClicked = true; });
+			document.body.appendChild(b);
+		})()`, nil),
+		chromedp.Click(`#confirm-probe`, chromedp.ByQuery),
+		chromedp.Sleep(500*time.Millisecond),
+		chromedp.Evaluate(`!!window.probeClicked`, &clicked),
+	)
+	if err != nil {
+		t.Fatalf("Failed to click the confirmation probe: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(dialogs) != 1 || dialogs[0] != "Delete the probe?" {
+		t.Errorf("Confirmation dialogs = %q, want the probe's; the page's inline script did not run", dialogs)
+	}
+	if clicked {
+		t.Error("Dismissed confirmation still let the click through")
+	}
+	for _, v := range violations {
+		t.Errorf("Content-Security-Policy violation: %s", v)
+	}
+	t.Log("✅ Page works under the enforced Content-Security-Policy")
+}
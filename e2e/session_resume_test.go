@@ -0,0 +1,96 @@
+//go:build browser
+
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	e2etest "github.com/livetemplate/lvt/testing"
+)
+
+// TestSessionResume kills the server while a note is being edited and
+// checks that, once it is back, the page reconnects to its session: the edit
+// modal is open again and still holds the unsent changes.
+func TestSessionResume(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	appDir := createTestApp(t, tmpDir, "sessionapp", nil)
+
+	// Enable DevMode BEFORE generating resources so DevMode=true gets baked into handler code
+	enableDevMode(t, appDir)
+
+	if err := runLvtCommand(t, appDir, "gen", "sessions"); err != nil {
+		t.Fatalf("Failed to set up sessions: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "gen", "resource", "notes", "title"); err != nil {
+		t.Fatalf("Failed to generate resource: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "migration", "up"); err != nil {
+		t.Fatalf("Failed to run migration: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "seed", "notes", "--count", "3"); err != nil {
+		t.Fatalf("Failed to seed notes: %v", err)
+	}
+
+	port := allocateTestPort()
+	server := buildAndRunNative(t, appDir, port)
+
+	ctx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+
+	const titleInput = `form[name="update"] input[name="title"]`
+	var draft string
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(fmt.Sprintf("%s/notes", e2etest.GetChromeTestURL(port))),
+		e2etest.WaitForWebSocketReady(30*time.Second),
+		chromedp.Click(`button[name="edit"]`, chromedp.ByQuery),
+		waitFor(`!!document.querySelector('`+titleInput+`')`, 10*time.Second),
+		chromedp.SendKeys(titleInput, " (draft)", chromedp.ByQuery),
+		chromedp.Value(titleInput, &draft, chromedp.ByQuery),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start editing a note: %v", err)
+	}
+	t.Logf("Unsent title: %q", draft)
+
+	// Kill the server mid-edit, without a graceful shutdown
+	if err := server.Process.Kill(); err != nil {
+		t.Fatalf("Failed to kill server: %v", err)
+	}
+	_ = server.Wait()
+	if err := chromedp.Run(ctx, chromedp.Sleep(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := exec.Command(filepath.Join(appDir, "server"))
+	restarted.Dir = appDir
+	restarted.Env = append(os.Environ(), "PORT="+strconv.Itoa(port), "LVT_DEV_MODE=true")
+	if err := restarted.Start(); err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = restarted.Process.Kill()
+		_ = restarted.Wait()
+	})
+	waitForServer(t, fmt.Sprintf("http://localhost:%d/notes", port), 10*time.Second)
+
+	// The client reconnects by itself; the session's snapshot reopens the
+	// modal and the page puts the draft back
+	err = chromedp.Run(ctx,
+		waitFor(fmt.Sprintf(`(document.querySelector('%s') || {}).value === %q`, titleInput, draft), 30*time.Second),
+	)
+	if err != nil {
+		var html string
+		_ = chromedp.Run(ctx, chromedp.OuterHTML("body", &html))
+		t.Fatalf("Edit form did not come back with the draft after the restart: %v\nBody: %s", err, truncateString(html, 2000))
+	}
+	t.Log("✅ Session resumed with the edit form and its unsent changes")
+}
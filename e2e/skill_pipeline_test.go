@@ -115,11 +115,11 @@ func TestSkillPipeline(t *testing.T) {
 	})
 
 	serverURL := fmt.Sprintf("http://localhost:%d", port)
-	waitForServer(t, serverURL+"/health/live", 15*time.Second)
+	waitForServer(t, serverURL+"/healthz", 15*time.Second)
 
 	// Step 10: Verify via HTTP
 	t.Run("HealthEndpoints", func(t *testing.T) {
-		for _, path := range []string{"/health/live", "/health/ready"} {
+		for _, path := range []string{"/healthz", "/readyz"} {
 			resp, err := http.Get(serverURL + path)
 			if err != nil {
 				t.Fatalf("GET %s failed: %v", path, err)
@@ -0,0 +1,98 @@
+//go:build browser
+
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	e2etest "github.com/livetemplate/lvt/testing"
+)
+
+// TestSSEFallback runs the app with LIVE_TRANSPORT=sse, so no WebSocket is
+// accepted, sends a chat message from one tab and checks that it shows up
+// in a second tab of the same session through the event stream.
+func TestSSEFallback(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	appDir := createTestApp(t, tmpDir, "sseapp", nil)
+
+	// Enable DevMode BEFORE generating views so DevMode=true gets baked into handler code
+	enableDevMode(t, appDir)
+
+	if err := runLvtCommand(t, appDir, "gen", "sse"); err != nil {
+		t.Fatalf("Failed to set up the SSE fallback: %v", err)
+	}
+	if err := runLvtCommand(t, appDir, "gen", "view", "lobby", "--pattern", "chat"); err != nil {
+		t.Fatalf("Failed to generate view: %v", err)
+	}
+
+	// Build with the default transport, then restart with WebSockets refused
+	port := allocateTestPort()
+	server := buildAndRunNative(t, appDir, port)
+	if err := server.Process.Kill(); err != nil {
+		t.Fatalf("Failed to kill server: %v", err)
+	}
+	_ = server.Wait()
+
+	restarted := exec.Command(filepath.Join(appDir, "server"))
+	restarted.Dir = appDir
+	restarted.Env = append(os.Environ(), "PORT="+strconv.Itoa(port), "LVT_DEV_MODE=true", "LIVE_TRANSPORT=sse")
+	if err := restarted.Start(); err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = restarted.Process.Kill()
+		_ = restarted.Wait()
+	})
+	lobbyURL := fmt.Sprintf("%s/lobby", e2etest.GetChromeTestURL(port))
+	waitForServer(t, fmt.Sprintf("http://localhost:%d/lobby", port), 10*time.Second)
+
+	ctx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+
+	// Tabs of one browser share the session cookie
+	watcher, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+	err := chromedp.Run(watcher,
+		chromedp.Navigate(lobbyURL),
+		waitFor(`!!document.querySelector('[data-chat-log]')`, 10*time.Second),
+		// Give the page's script time to open the stream
+		chromedp.Sleep(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load the watching tab: %v", err)
+	}
+
+	sender, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+	err = chromedp.Run(sender,
+		chromedp.Navigate(lobbyURL),
+		waitFor(`!!document.querySelector('form[name="send"]')`, 10*time.Second),
+		chromedp.Sleep(time.Second),
+		chromedp.SendKeys(`form[name="send"] input[name="author"]`, "Ada", chromedp.ByQuery),
+		chromedp.SendKeys(`form[name="send"] input[name="text"]`, "sent over HTTP", chromedp.ByQuery),
+		chromedp.Click(`form[name="send"] button[type="submit"]`, chromedp.ByQuery),
+		waitFor(`document.querySelector('[data-chat-log]').textContent.indexOf('sent over HTTP') >= 0`, 10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Message was not sent without a WebSocket: %v", err)
+	}
+
+	err = chromedp.Run(watcher,
+		waitFor(`document.querySelector('[data-chat-log]').textContent.indexOf('sent over HTTP') >= 0`, 15*time.Second),
+	)
+	if err != nil {
+		var html string
+		_ = chromedp.Run(watcher, chromedp.OuterHTML("body", &html))
+		t.Fatalf("Message did not reach the other tab over the event stream: %v\nBody: %s", err, truncateString(html, 2000))
+	}
+	t.Log("✅ Update reached the other tab over server-sent events")
+}
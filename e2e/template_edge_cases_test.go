@@ -137,6 +137,6 @@ func verifyAppBuildsAndRuns(t *testing.T, appDir string, hasQueries bool) {
 	})
 
 	serverURL := fmt.Sprintf("http://localhost:%d", port)
-	waitForServer(t, serverURL+"/health/live", 15*time.Second)
+	waitForServer(t, serverURL+"/healthz", 15*time.Second)
 	t.Logf("Server running at %s", serverURL)
 }
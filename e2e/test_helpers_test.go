@@ -16,17 +16,12 @@ import (
 
 	"github.com/livetemplate/lvt/commands"
 	"github.com/livetemplate/lvt/internal/serve"
+	e2etest "github.com/livetemplate/lvt/testing"
 )
 
 // sqlcPackage is the pinned sqlc version used by all e2e tests for reproducible CI builds.
 const sqlcPackage = "github.com/sqlc-dev/sqlc/cmd/sqlc@v1.30.0"
 
-// Port allocation for parallel tests
-var (
-	portMutex    sync.Mutex
-	nextTestPort int = 8800
-)
-
 // getTimeout returns local or CI timeout based on environment
 func getTimeout(envVar string, localDefault, ciDefault time.Duration) time.Duration {
 	if os.Getenv("CI") == "true" {
@@ -40,13 +35,14 @@ func getTimeout(envVar string, localDefault, ciDefault time.Duration) time.Durat
 	return localDefault
 }
 
-// allocateTestPort returns a unique port for a test
+// allocateTestPort returns a port that no other test in this process holds.
+// Ports are reserved for the lifetime of the test binary because several
+// tests hand them to servers that outlive the allocating helper.
 func allocateTestPort() int {
-	portMutex.Lock()
-	defer portMutex.Unlock()
-
-	port := nextTestPort
-	nextTestPort++
+	port, err := e2etest.DefaultAllocator().ReservePort()
+	if err != nil {
+		panic(fmt.Sprintf("failed to allocate test port: %v", err))
+	}
 	return port
 }
 
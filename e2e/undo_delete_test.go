@@ -0,0 +1,133 @@
+//go:build browser
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// TestUndoDelete tests the undo notice of a resource generated with --undo:
+// a deleted row comes back with Undo, and stays deleted once the notice is dismissed
+func TestUndoDelete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping test in short mode")
+	}
+
+	tmpDir := t.TempDir()
+
+	t.Log("Creating blog app...")
+	appDir := createTestApp(t, tmpDir, "blog", &AppOptions{
+		Kit: "multi",
+	})
+	setupLocalClientLibrary(t, appDir)
+
+	// Without edit and show, the delete button sits in the table row
+	t.Log("Generating posts resource with --undo...")
+	runLvtCommand(t, appDir, "gen", "resource", "posts", "title", "--actions", "list,create,delete", "--undo")
+
+	t.Log("Running migrations...")
+	runLvtCommand(t, appDir, "migration", "up")
+
+	t.Log("Building Docker image...")
+	serverPort := allocateTestPort()
+	imageName := "lvt-test-undo-delete:latest"
+	buildDockerImage(t, appDir, imageName)
+	_ = runDockerContainer(t, imageName, serverPort)
+
+	serverURL := fmt.Sprintf("http://localhost:%d", serverPort)
+	waitForServer(t, serverURL+"/posts", 10*time.Second)
+	t.Log("✅ Server running")
+
+	ctx, _, cleanup := GetPooledChrome(t)
+	defer cleanup()
+
+	testURL := getTestURL(serverPort)
+
+	rowExists := func(title string) string {
+		return fmt.Sprintf(`Array.from(document.querySelectorAll('table tbody tr')).some(row => row.textContent.includes(%q))`, title)
+	}
+
+	// deletePost opens the list, creates a post and deletes it again
+	deletePost := func(ctx context.Context, title string) error {
+		return chromedp.Run(ctx,
+			chromedp.Navigate(testURL+"/posts"),
+			waitForWebSocketReady(5*time.Second),
+			chromedp.WaitVisible(`[data-lvt-id]`, chromedp.ByQuery),
+
+			clickUntilModalOpens(`[command="show-modal"][commandfor="add-modal"]`, `input[name="title"]`, 15*time.Second),
+			chromedp.SendKeys(`input[name="title"]`, title, chromedp.ByQuery),
+			chromedp.Click(`button[type="submit"]`, chromedp.ByQuery),
+			waitFor(rowExists(title), 10*time.Second),
+
+			// Override confirm() for headless Chrome
+			chromedp.Evaluate(fmt.Sprintf(`
+				(() => {
+					window.confirm = () => true;
+					const row = Array.from(document.querySelectorAll('table tbody tr')).find(row => row.textContent.includes(%q));
+					row.querySelector('button[name="delete"]').click();
+				})()
+			`, title), nil),
+			waitFor(`!`+rowExists(title)+` && document.querySelector('button[name="undo_delete"]') !== null`, 10*time.Second),
+		)
+	}
+
+	t.Run("Undo_Restores_Row", func(t *testing.T) {
+		ctx, cancel := chromedp.NewContext(ctx, chromedp.WithLogf(t.Logf))
+		defer cancel()
+		ctx, timeoutCancel := context.WithTimeout(ctx, 60*time.Second)
+		defer timeoutCancel()
+
+		if err := deletePost(ctx, "Restore Me"); err != nil {
+			t.Fatalf("Failed to delete post: %v", err)
+		}
+		t.Log("✅ Post deleted, undo notice shown")
+
+		err := chromedp.Run(ctx,
+			chromedp.Click(`button[name="undo_delete"]`, chromedp.ByQuery),
+			waitFor(rowExists("Restore Me")+` && document.querySelector('button[name="undo_delete"]') === null`, 10*time.Second),
+
+			// The restored row is in the database, not just the session
+			chromedp.Navigate(testURL+"/posts"),
+			waitForWebSocketReady(5*time.Second),
+			waitFor(rowExists("Restore Me"), 10*time.Second),
+		)
+		if err != nil {
+			t.Fatalf("Undo did not restore the post: %v", err)
+		}
+		t.Log("✅ Undo restored the post")
+	})
+
+	t.Run("Dismiss_Keeps_Row_Deleted", func(t *testing.T) {
+		ctx, cancel := chromedp.NewContext(ctx, chromedp.WithLogf(t.Logf))
+		defer cancel()
+		ctx, timeoutCancel := context.WithTimeout(ctx, 60*time.Second)
+		defer timeoutCancel()
+
+		if err := deletePost(ctx, "Delete Me"); err != nil {
+			t.Fatalf("Failed to delete post: %v", err)
+		}
+
+		var restored bool
+		err := chromedp.Run(ctx,
+			chromedp.Click(`button[name="dismiss_toast_undo"]`, chromedp.ByQuery),
+			waitFor(`document.querySelector('button[name="undo_delete"]') === null`, 10*time.Second),
+
+			chromedp.Navigate(testURL+"/posts"),
+			waitForWebSocketReady(5*time.Second),
+			chromedp.WaitVisible(`[data-lvt-id]`, chromedp.ByQuery),
+			chromedp.Evaluate(rowExists("Delete Me"), &restored),
+		)
+		if err != nil {
+			t.Fatalf("Failed to dismiss the undo notice: %v", err)
+		}
+		if restored {
+			t.Fatal("❌ Dismissed post is back in the list")
+		}
+		t.Log("✅ Dismissing the notice kept the post deleted")
+	})
+}
@@ -80,7 +80,7 @@ func TestGeneratedFilesExist(t *testing.T) {
 		t.Fatalf("Failed to change directory: %v", err)
 	}
 
-	if err := generator.GenerateApp("testapp", "testapp", "multi", "tailwind", false); err != nil { // false = production mode
+	if err := generator.GenerateApp("testapp", "testapp", "multi", "tailwind", "", false); err != nil { // false = production mode
 		t.Fatalf("Failed to generate app: %v", err)
 	}
 
@@ -92,6 +92,10 @@ func TestGeneratedFilesExist(t *testing.T) {
 		"README.md",
 		"cmd/testapp/main.go",
 		"database/db.go",
+		"database/stmtcache.go",
+		"shared/actionqueue/actionqueue.go",
+		"shared/csrf/csrf.go",
+		"shared/lifecycle/lifecycle.go",
 		"database/schema.sql",
 		"database/queries.sql",
 		"database/sqlc.yaml",
@@ -288,7 +292,7 @@ func TestGeneratedAppFullFlow(t *testing.T) {
 
 	// Step 1: Generate app
 	t.Log("Step 1: Generating app...")
-	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", false); err != nil {
+	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", "", false); err != nil {
 		t.Fatalf("Failed to generate app: %v", err)
 	}
 	t.Log("✅ App generated")
@@ -378,6 +382,61 @@ func TestGeneratedAppFullFlow(t *testing.T) {
 	t.Log("✅ Full flow test passed - generated app works in a single shot!")
 }
 
+// TestReadOnlyResourceGeneration validates that restricting a resource's
+// actions drops the corresponding handlers, inputs, and UI controls.
+func TestReadOnlyResourceGeneration(t *testing.T) {
+	fields := []parser.Field{
+		{Name: "name", Type: "string", GoType: "string", SQLType: "TEXT", Metadata: parser.GetFieldMetadata("string")},
+		{Name: "population", Type: "int", GoType: "int64", SQLType: "INTEGER", Metadata: parser.GetFieldMetadata("int")},
+	}
+
+	for _, editMode := range []string{"modal", "page"} {
+		t.Run(editMode, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(tmpDir, "database"), 0755); err != nil {
+				t.Fatalf("Failed to create database directory: %v", err)
+			}
+
+			actions := generator.ReadOnlyActions()
+			if err := generator.GenerateResource(tmpDir, "testmodule", "City", fields, "multi", "tailwind", "tailwind", "infinite", 20, editMode, "", false, false, generator.ResourceOptions{Actions: &actions}); err != nil {
+				t.Fatalf("Failed to generate resource: %v", err)
+			}
+
+			handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "city", "city.go"))
+			if err != nil {
+				t.Fatalf("Failed to read handler: %v", err)
+			}
+			tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "city", "city.tmpl"))
+			if err != nil {
+				t.Fatalf("Failed to read template: %v", err)
+			}
+			testFile, err := os.ReadFile(filepath.Join(tmpDir, "app", "city", "city_test.go"))
+			if err != nil {
+				t.Fatalf("Failed to read generated test: %v", err)
+			}
+
+			for _, substr := range []string{"func (c *CityController) View(", "func (c *CityController) Back(", "type IDInput struct"} {
+				if !strings.Contains(string(handler), substr) {
+					t.Errorf("Handler missing %q", substr)
+				}
+			}
+			for _, substr := range []string{"type AddInput", "type UpdateInput", ") Add(", ") Edit(", ") Update(", ") Delete(", ") CancelEdit("} {
+				if strings.Contains(string(handler), substr) {
+					t.Errorf("Read-only handler should not contain %q", substr)
+				}
+			}
+			for _, substr := range []string{`name="add"`, `name="update"`, `name="delete"`, `lvt-on:click="delete"`, "Add one above!"} {
+				if strings.Contains(string(tmpl), substr) {
+					t.Errorf("Read-only template should not contain %q", substr)
+				}
+			}
+			if strings.Contains(string(testFile), `"action": "add"`) {
+				t.Error("Read-only generated test should not send an add action")
+			}
+		})
+	}
+}
+
 // TestFileUploadResourceGeneration validates that generating a resource with
 // file/image fields produces correct handler, SQL, and template output.
 func TestFileUploadResourceGeneration(t *testing.T) {
@@ -610,7 +669,7 @@ func TestFileUploadFullFlow(t *testing.T) {
 
 	// Step 1: Generate app
 	t.Log("Step 1: Generating app...")
-	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", false); err != nil {
+	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", "", false); err != nil {
 		t.Fatalf("Failed to generate app: %v", err)
 	}
 
@@ -868,7 +927,7 @@ func TestAuthzFullFlow(t *testing.T) {
 
 	// Step 1: Generate app
 	t.Log("Step 1: Generating app...")
-	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", false); err != nil {
+	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", "", false); err != nil {
 		t.Fatalf("Failed to generate app: %v", err)
 	}
 
@@ -1139,7 +1198,7 @@ func TestAPIFullFlow(t *testing.T) {
 
 	// Step 1: Generate app
 	t.Log("Step 1: Generating app...")
-	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", false); err != nil {
+	if err := generator.GenerateApp(appName, appName, "multi", "tailwind", "", false); err != nil {
 		t.Fatalf("Failed to generate app: %v", err)
 	}
 
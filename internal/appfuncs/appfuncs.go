@@ -0,0 +1,93 @@
+// Package appfuncs reads the names of the template functions a generated
+// app registers in its shared/funcs package, so that lvt's own template
+// checks and previews accept calls to them.
+package appfuncs
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Dir is the package apps register their template functions in, relative
+// to the app root.
+const Dir = "shared/funcs"
+
+// Names returns the functions registered in the app in dir: the keys of the
+// template.FuncMap literals of its shared/funcs package, sorted. Apps
+// generated before shared/funcs register none.
+func Names(dir string) []string {
+	paths, _ := filepath.Glob(filepath.Join(dir, Dir, "*.go"))
+	var names []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, name := range Parse(path, src) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Parse returns the string keys of the template.FuncMap literals in src. A
+// file that does not parse has none.
+func Parse(filename string, src []byte) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, 0)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || !isFuncMap(lit.Type) {
+			return true
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.BasicLit)
+			if !ok || key.Kind != token.STRING {
+				continue
+			}
+			if name, err := strconv.Unquote(key.Value); err == nil {
+				names = append(names, name)
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// isFuncMap reports whether expr is the type template.FuncMap.
+func isFuncMap(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "FuncMap"
+}
+
+// Stubs returns a stand-in for each function registered in the app in dir,
+// for parsing its templates: they accept any arguments and return nothing.
+func Stubs(dir string) template.FuncMap {
+	funcs := template.FuncMap{}
+	for _, name := range Names(dir) {
+		funcs[name] = stub
+	}
+	return funcs
+}
+
+func stub(args ...any) any { return nil }
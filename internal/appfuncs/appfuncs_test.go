@@ -0,0 +1,71 @@
+package appfuncs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	dir := t.TempDir()
+	pkg := filepath.Join(dir, Dir)
+	if err := os.MkdirAll(pkg, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"funcs.go": `package funcs
+
+import "html/template"
+
+var registry = template.FuncMap{
+	"upper": strings.ToUpper,
+	"money": formatMoney,
+}
+`,
+		"dates.go": `package funcs
+
+import "html/template"
+
+func init() {
+	Register(template.FuncMap{"ago": ago, "upper": strings.ToUpper})
+}
+`,
+		"funcs_test.go": `package funcs
+
+var _ = template.FuncMap{"testonly": nil}
+`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(pkg, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"ago", "money", "upper"}
+	if got := Names(dir); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names = %v, want %v", got, want)
+	}
+	if got := Names(t.TempDir()); len(got) != 0 {
+		t.Errorf("Names without shared/funcs = %v", got)
+	}
+}
+
+// A function added where the generated package says to is found.
+func TestParseKitTemplates(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		path := filepath.Join("..", "kits", "system", kit, "templates", "funcs", "funcs.go.tmpl")
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names := Parse(path, src); len(names) != 0 {
+			t.Errorf("%s: generated names = %v", kit, names)
+		}
+		src = bytes.Replace(src, []byte("// Add your template functions here."), []byte(`"upper": strings.ToUpper,`), 1)
+		if names := Parse(path, src); !reflect.DeepEqual(names, []string{"upper"}) {
+			t.Errorf("%s: names = %v, want [upper]", kit, names)
+		}
+	}
+}
@@ -47,6 +47,10 @@ type Config struct {
 	// Standard paths (~/.config/lvt/kits/ and .lvt/kits/) are searched automatically
 	KitPaths []string `yaml:"kit_paths,omitempty"`
 
+	// KitIndex is the URL or file of the index `lvt kits install <name>`
+	// looks kits up in; empty uses the central index
+	KitIndex string `yaml:"kit_index,omitempty"`
+
 	// Version tracks the config file version for future migrations
 	Version string `yaml:"version,omitempty"`
 }
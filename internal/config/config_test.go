@@ -616,6 +616,7 @@ func TestLoadProjectConfig_WithFile(t *testing.T) {
 kit=simple
 styles=unstyled
 dev_mode=true
+id=ulid
 `
 	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
 		t.Fatal(err)
@@ -638,6 +639,10 @@ dev_mode=true
 	if !cfg.DevMode {
 		t.Error("Expected DevMode true")
 	}
+
+	if cfg.IDType != "ulid" {
+		t.Errorf("Expected IDType 'ulid', got '%s'", cfg.IDType)
+	}
 }
 
 func TestSaveProjectConfig(t *testing.T) {
@@ -647,6 +652,7 @@ func TestSaveProjectConfig(t *testing.T) {
 		Kit:     "multi",
 		Styles:  "tailwind",
 		DevMode: true,
+		IDType:  "uuid",
 	}
 
 	// Save config
@@ -677,6 +683,10 @@ func TestSaveProjectConfig(t *testing.T) {
 	if loadedCfg.DevMode != cfg.DevMode {
 		t.Errorf("DevMode mismatch: expected %v, got %v", cfg.DevMode, loadedCfg.DevMode)
 	}
+
+	if loadedCfg.IDType != cfg.IDType {
+		t.Errorf("IDType mismatch: expected %s, got %s", cfg.IDType, loadedCfg.IDType)
+	}
 }
 
 func TestSaveProjectConfig_QuotedValuesRoundTrip(t *testing.T) {
@@ -720,6 +730,136 @@ func TestSaveProjectConfig_QuotedValuesRoundTrip(t *testing.T) {
 	}
 }
 
+func TestSaveProjectConfig_ResourcesRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := DefaultProjectConfig()
+	cfg.SetResource("Posts", &ResourceConfig{Pagination: "prev-next", PageSize: 3, EditMode: "page", Searchable: true, Actions: "list,show"})
+	cfg.SetResource("comments", &ResourceConfig{Pagination: "infinite", PageSize: 20, EditMode: "modal", Parent: "posts"})
+
+	if err := SaveProjectConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, ProjectConfigFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`resource.posts.pagination="prev-next"`, "resource.posts.page_size=3", "resource.posts.searchable=true", `resource.comments.parent="posts"`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected .lvtrc to contain %s, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), "resource.comments.searchable") {
+		t.Errorf("unset options should not be written:\n%s", content)
+	}
+
+	loaded, err := LoadProjectConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	for _, name := range []string{"posts", "comments"} {
+		if got, want := loaded.Resource(name), cfg.Resource(name); got == nil || *got != *want {
+			t.Errorf("resource %s = %+v, want %+v", name, got, want)
+		}
+	}
+	if loaded.Resource("tags") != nil {
+		t.Error("Resource returned options for a resource that was never recorded")
+	}
+}
+
+func TestSaveProjectConfig_Database(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := DefaultProjectConfig()
+	cfg.Database = "data/dev.db"
+	if err := SaveProjectConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	loaded, err := LoadProjectConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if loaded.Database != "data/dev.db" {
+		t.Errorf("database: expected %q, got %q", "data/dev.db", loaded.Database)
+	}
+}
+
+func TestSaveProjectConfig_Theme(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := DefaultProjectConfig()
+	cfg.Kit = "daisyui"
+	cfg.Theme = "corporate"
+	if err := SaveProjectConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	loaded, err := LoadProjectConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if loaded.Theme != "corporate" {
+		t.Errorf("theme: expected %q, got %q", "corporate", loaded.Theme)
+	}
+	if err := loaded.Validate(); err != nil {
+		t.Errorf("daisyui config should be valid, got: %v", err)
+	}
+}
+
+func TestSaveProjectConfig_SeedSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := DefaultProjectConfig()
+	cfg.SeedLocale = "de_DE"
+	cfg.SeedFields = map[string]string{"sku": "{letter}{letter}-{number:100,999}", "posts.title": "sentence"}
+	if err := SaveProjectConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	loaded, err := LoadProjectConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if loaded.SeedLocale != "de_DE" {
+		t.Errorf("seed.locale: expected %q, got %q", "de_DE", loaded.SeedLocale)
+	}
+	if len(loaded.SeedFields) != 2 || loaded.SeedFields["sku"] != cfg.SeedFields["sku"] || loaded.SeedFields["posts.title"] != "sentence" {
+		t.Errorf("seed.field: expected %v, got %v", cfg.SeedFields, loaded.SeedFields)
+	}
+}
+
+func TestSaveProjectConfig_LintRules(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := DefaultProjectConfig()
+	cfg.LintRules = map[string]string{"range-key": "error", "unused-define": "off"}
+	if err := SaveProjectConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	loaded, err := LoadProjectConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if len(loaded.LintRules) != 2 || loaded.LintRules["range-key"] != "error" || loaded.LintRules["unused-define"] != "off" {
+		t.Errorf("lint: expected %v, got %v", cfg.LintRules, loaded.LintRules)
+	}
+}
+
+func TestSaveProjectConfig_Inflections(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := DefaultProjectConfig()
+	cfg.Inflections = map[string]string{"cactus": "cacti", "equipment": "equipment"}
+	if err := SaveProjectConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	loaded, err := LoadProjectConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if len(loaded.Inflections) != 2 || loaded.Inflections["cactus"] != "cacti" || loaded.Inflections["equipment"] != "equipment" {
+		t.Errorf("inflection: expected %v, got %v", cfg.Inflections, loaded.Inflections)
+	}
+}
+
 func TestLoadProjectConfig_UnquotedAndSingleQuoted(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, ProjectConfigFileName)
@@ -814,4 +954,13 @@ func TestProjectConfig_Validate(t *testing.T) {
 	if err := cfg3.Validate(); err == nil {
 		t.Error("Expected error for invalid kit")
 	}
+	// Invalid: unknown id type
+	cfg4 := &ProjectConfig{
+		Kit:    "multi",
+		IDType: "serial",
+	}
+
+	if err := cfg4.Validate(); err == nil {
+		t.Error("Expected error for invalid id type")
+	}
 }
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environments an app runs in. LVT_ENV may name others (e.g. staging); these
+// are the ones with defaults.
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+// MemoryDatabase is the test environment's database unless one is
+// configured: every test server starts from an empty schema.
+const MemoryDatabase = ":memory:"
+
+// Environment returns the environment lvt commands act on: LVT_ENV, else
+// APP_ENV, else development. TEST_MODE=1, which tests generated before
+// LVT_ENV set, means test. Values come from the process environment, then
+// from the .env file in dir.
+func Environment(dir string) string {
+	lookup := envLookup(dir)
+	for _, key := range []string{"LVT_ENV", "APP_ENV"} {
+		if env := strings.ToLower(strings.TrimSpace(lookup(key))); env != "" {
+			return env
+		}
+	}
+	if lookup("TEST_MODE") == "1" {
+		return EnvTest
+	}
+	return EnvDevelopment
+}
+
+// DatabaseEnvVar is the variable holding env's database path, e.g.
+// DATABASE_PATH_TEST.
+func DatabaseEnvVar(env string) string {
+	return "DATABASE_PATH_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(env))
+}
+
+// DatabasePath returns the SQLite database configured for the current
+// environment of the project in dir, or "" when nothing is configured and
+// the app.db default applies. In order:
+//
+//  1. DATABASE_PATH_<ENV>, e.g. DATABASE_PATH_TEST
+//  2. DATABASE_PATH, except in the test environment, so tests never run
+//     against a database exported for development
+//  3. MemoryDatabase in the test environment
+//  4. database= in .lvtrc
+//
+// Variables are read from the process environment, then from dir/.env.
+// Relative paths from .env and .lvtrc are joined to dir.
+func DatabasePath(dir string) string {
+	env := Environment(dir)
+	keys := []string{DatabaseEnvVar(env)}
+	if env != EnvTest {
+		keys = append(keys, "DATABASE_PATH")
+	}
+	for _, key := range keys {
+		if path := os.Getenv(key); path != "" {
+			return path
+		}
+		if path := readEnvFile(dir)[key]; path != "" {
+			return projectPath(dir, path)
+		}
+	}
+	if env == EnvTest {
+		return MemoryDatabase
+	}
+	if _, err := os.Stat(filepath.Join(dir, ProjectConfigFileName)); err == nil {
+		if cfg, err := LoadProjectConfig(dir); err == nil && cfg.Database != "" {
+			return projectPath(dir, cfg.Database)
+		}
+	}
+	return ""
+}
+
+// HealthPathPrefix returns HEALTH_PATH_PREFIX, under which generated apps
+// serve /healthz and /readyz, without a trailing slash. It is read from the
+// process environment, then from dir/.env.
+func HealthPathPrefix(dir string) string {
+	return strings.TrimRight(strings.TrimSpace(envLookup(dir)("HEALTH_PATH_PREFIX")), "/")
+}
+
+// ProjectRoot returns the nearest directory at or above dir holding a .lvtrc
+// or go.mod, or dir itself when there is none.
+func ProjectRoot(dir string) string {
+	for d := dir; ; {
+		for _, name := range []string{ProjectConfigFileName, "go.mod"} {
+			if _, err := os.Stat(filepath.Join(d, name)); err == nil {
+				return d
+			}
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// MemoryDatabaseError explains that the environment's database is in memory,
+// so there is no file for a command to open.
+func MemoryDatabaseError(env string) error {
+	return fmt.Errorf("the %s environment uses an in-memory database; set %s to use a database file", env, DatabaseEnvVar(env))
+}
+
+func projectPath(dir, path string) string {
+	if path == MemoryDatabase || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// envLookup reads a variable from the process environment, then dir/.env.
+func envLookup(dir string) func(string) string {
+	var file map[string]string
+	return func(key string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		if file == nil {
+			file = readEnvFile(dir)
+		}
+		return file[key]
+	}
+}
+
+// readEnvFile parses the KEY=value lines of dir/.env, as `lvt env set`
+// writes them. A missing file has no variables.
+func readEnvFile(dir string) map[string]string {
+	vars := map[string]string{}
+	f, err := os.Open(filepath.Join(dir, ".env"))
+	if err != nil {
+		return vars
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars
+}
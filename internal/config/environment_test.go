@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func clearEnvironment(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"LVT_ENV", "APP_ENV", "TEST_MODE", "DATABASE_PATH",
+		"DATABASE_PATH_DEVELOPMENT", "DATABASE_PATH_TEST", "DATABASE_PATH_PRODUCTION"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	clearEnvironment(t)
+	dir := t.TempDir()
+	if env := Environment(dir); env != EnvDevelopment {
+		t.Errorf("default environment = %q, want development", env)
+	}
+
+	t.Setenv("TEST_MODE", "1")
+	if env := Environment(dir); env != EnvTest {
+		t.Errorf("TEST_MODE=1 environment = %q, want test", env)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("APP_ENV=staging\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if env := Environment(dir); env != "staging" {
+		t.Errorf("environment with APP_ENV in .env = %q, want staging", env)
+	}
+
+	t.Setenv("LVT_ENV", "Production")
+	if env := Environment(dir); env != EnvProduction {
+		t.Errorf("environment with LVT_ENV = %q, want production", env)
+	}
+}
+
+func TestDatabasePath(t *testing.T) {
+	clearEnvironment(t)
+	dir := t.TempDir()
+	if path := DatabasePath(dir); path != "" {
+		t.Errorf("unconfigured DatabasePath = %q, want \"\"", path)
+	}
+
+	cfg := DefaultProjectConfig()
+	cfg.Database = "data/app.db"
+	if err := SaveProjectConfig(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if path := DatabasePath(dir); path != filepath.Join(dir, "data/app.db") {
+		t.Errorf("DatabasePath from .lvtrc = %q", path)
+	}
+
+	env := "LVT_ENV=test\nDATABASE_PATH_DEVELOPMENT=dev.db\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := DatabasePath(dir); path != MemoryDatabase {
+		t.Errorf("test DatabasePath = %q, want %s", path, MemoryDatabase)
+	}
+
+	// DATABASE_PATH never points tests at another environment's database
+	t.Setenv("DATABASE_PATH", "/srv/app.db")
+	if path := DatabasePath(dir); path != MemoryDatabase {
+		t.Errorf("test DatabasePath with DATABASE_PATH = %q, want %s", path, MemoryDatabase)
+	}
+	t.Setenv("DATABASE_PATH_TEST", "/tmp/test.db")
+	if path := DatabasePath(dir); path != "/tmp/test.db" {
+		t.Errorf("DatabasePath with DATABASE_PATH_TEST = %q", path)
+	}
+
+	t.Setenv("LVT_ENV", "development")
+	if path := DatabasePath(dir); path != filepath.Join(dir, "dev.db") {
+		t.Errorf("development DatabasePath = %q, want dev.db from .env", path)
+	}
+	t.Setenv("LVT_ENV", "production")
+	if path := DatabasePath(dir); path != "/srv/app.db" {
+		t.Errorf("production DatabasePath = %q, want DATABASE_PATH", path)
+	}
+}
+
+func TestProjectRoot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(dir, "app", "posts")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if root := ProjectRoot(sub); root != dir {
+		t.Errorf("ProjectRoot(%s) = %s, want %s", sub, root, dir)
+	}
+}
+
+func TestHealthPathPrefix(t *testing.T) {
+	t.Setenv("HEALTH_PATH_PREFIX", "")
+	dir := t.TempDir()
+	if prefix := HealthPathPrefix(dir); prefix != "" {
+		t.Errorf("unconfigured HealthPathPrefix = %q, want \"\"", prefix)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("HEALTH_PATH_PREFIX=/_/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if prefix := HealthPathPrefix(dir); prefix != "/_" {
+		t.Errorf("HealthPathPrefix from .env = %q, want /_", prefix)
+	}
+	t.Setenv("HEALTH_PATH_PREFIX", "/internal")
+	if prefix := HealthPathPrefix(dir); prefix != "/internal" {
+		t.Errorf("HealthPathPrefix from environment = %q, want /internal", prefix)
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 const (
@@ -27,6 +30,82 @@ type ProjectConfig struct {
 
 	// DevMode indicates whether to use local client library
 	DevMode bool
+
+	// Theme is the default daisyUI theme of a daisyui kit project (e.g.
+	// "corporate"); empty follows the browser's light/dark preference
+	Theme string
+
+	// IDType is the default record ID type for generated resources
+	// ("uuid" or "ulid"); empty keeps "<resource>-<unix nanos>" IDs
+	IDType string
+
+	// Database is the SQLite database `lvt db console` opens, relative to
+	// the project root; empty means app.db
+	Database string
+
+	// SeedLocale is the locale `lvt seed` generates names and addresses
+	// for, e.g. de_DE; empty means en_US
+	SeedLocale string
+
+	// SeedFields overrides the value `lvt seed` generates for a column,
+	// keyed by column or table.column. Values are gofakeit function names
+	// (e.g. "company") or templates (e.g. "{firstname}-{number:1,99}").
+	// Stored as seed.field.<key>.
+	SeedFields map[string]string
+
+	// Inflections are the project's singular -> plural pairs for words the
+	// built-in rules get wrong, used when deriving table, struct and route
+	// names; a word mapped to itself is uncountable. Stored as
+	// inflection.<singular>.
+	Inflections map[string]string
+
+	// LintRules sets the severity of `lvt parse --lint` rules (off,
+	// warning or error), keyed by rule name. Stored as lint.<rule>.
+	LintRules map[string]string
+
+	// Resources records the options each resource was generated with,
+	// keyed by resource name, so later commands can read them instead of
+	// guessing from the generated code. Stored as resource.<name>.<option>.
+	Resources map[string]*ResourceConfig
+}
+
+// ResourceConfig is the set of `lvt gen resource` options a resource was
+// generated with.
+type ResourceConfig struct {
+	Pagination  string // infinite, load-more, prev-next, numbers, cursor
+	PageSize    int
+	EditMode    string // modal, page
+	Parent      string // parent resource it is embedded in (--parent)
+	WithAuthz   bool
+	Searchable  bool
+	EmitEvents  bool
+	Cache       bool
+	RenderCache bool
+	Filters     bool
+	SavedViews  bool
+	Sortable    bool
+	Undo        bool
+	Presence    bool
+	Conflicts   bool
+	Policy      bool
+	Hooks       bool
+	IDType      string // uuid, ulid, or "" for the default
+	Actions     string // comma-separated actions (--actions); "" for all
+	FromTable   string // existing table it was generated from (--from-table)
+}
+
+// Resource returns the recorded options for a resource, or nil if it was
+// generated before options were recorded (or not by lvt).
+func (c *ProjectConfig) Resource(name string) *ResourceConfig {
+	return c.Resources[strings.ToLower(name)]
+}
+
+// SetResource records the options a resource was generated with.
+func (c *ProjectConfig) SetResource(name string, rc *ResourceConfig) {
+	if c.Resources == nil {
+		c.Resources = make(map[string]*ResourceConfig)
+	}
+	c.Resources[strings.ToLower(name)] = rc
 }
 
 // DefaultProjectConfig returns a new ProjectConfig with default values
@@ -45,6 +124,7 @@ func LoadProjectConfig(basePath string) (*ProjectConfig, error) {
 
 	// If config file doesn't exist, return default config
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		logging.Debug("no project config, using defaults", "path", configPath)
 		return DefaultProjectConfig(), nil
 	}
 
@@ -90,6 +170,40 @@ func LoadProjectConfig(basePath string) (*ProjectConfig, error) {
 			config.Styles = value
 		case "dev_mode":
 			config.DevMode = value == "true"
+		case "theme":
+			config.Theme = value
+		case "id":
+			config.IDType = value
+		case "database":
+			config.Database = value
+		case "seed.locale":
+			config.SeedLocale = value
+		default:
+			if field, ok := strings.CutPrefix(key, "seed.field."); ok && field != "" {
+				if config.SeedFields == nil {
+					config.SeedFields = make(map[string]string)
+				}
+				config.SeedFields[field] = value
+			} else if singular, ok := strings.CutPrefix(key, "inflection."); ok && singular != "" {
+				if config.Inflections == nil {
+					config.Inflections = make(map[string]string)
+				}
+				config.Inflections[singular] = value
+			} else if rule, ok := strings.CutPrefix(key, "lint."); ok && rule != "" {
+				if config.LintRules == nil {
+					config.LintRules = make(map[string]string)
+				}
+				config.LintRules[rule] = value
+			} else if rest, ok := strings.CutPrefix(key, "resource."); ok {
+				if name, option, ok := strings.Cut(rest, "."); ok && name != "" {
+					rc := config.Resource(name)
+					if rc == nil {
+						rc = &ResourceConfig{}
+						config.SetResource(name, rc)
+					}
+					rc.set(option, value)
+				}
+			}
 		}
 	}
 
@@ -97,6 +211,7 @@ func LoadProjectConfig(basePath string) (*ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to read project config: %w", err)
 	}
 
+	logging.Debug("loaded project config", "path", configPath, "kit", config.Kit, "styles", config.Styles, "module", config.Module)
 	return config, nil
 }
 
@@ -115,6 +230,50 @@ func SaveProjectConfig(basePath string, config *ProjectConfig) error {
 		lines = append(lines, fmt.Sprintf("styles=%q", config.Styles))
 	}
 	lines = append(lines, fmt.Sprintf("dev_mode=%v", config.DevMode))
+	if config.Theme != "" {
+		lines = append(lines, fmt.Sprintf("theme=%q", config.Theme))
+	}
+	if config.IDType != "" {
+		lines = append(lines, fmt.Sprintf("id=%q", config.IDType))
+	}
+	if config.Database != "" {
+		lines = append(lines, fmt.Sprintf("database=%q", config.Database))
+	}
+	if config.SeedLocale != "" {
+		lines = append(lines, fmt.Sprintf("seed.locale=%q", config.SeedLocale))
+	}
+	fields := make([]string, 0, len(config.SeedFields))
+	for field := range config.SeedFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		lines = append(lines, fmt.Sprintf("seed.field.%s=%q", field, config.SeedFields[field]))
+	}
+	singulars := make([]string, 0, len(config.Inflections))
+	for singular := range config.Inflections {
+		singulars = append(singulars, singular)
+	}
+	sort.Strings(singulars)
+	for _, singular := range singulars {
+		lines = append(lines, fmt.Sprintf("inflection.%s=%q", singular, config.Inflections[singular]))
+	}
+	rules := make([]string, 0, len(config.LintRules))
+	for rule := range config.LintRules {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		lines = append(lines, fmt.Sprintf("lint.%s=%q", rule, config.LintRules[rule]))
+	}
+	names := make([]string, 0, len(config.Resources))
+	for name := range config.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lines = append(lines, config.Resources[name].lines("resource."+name+".")...)
+	}
 
 	content := strings.Join(lines, "\n") + "\n"
 
@@ -125,6 +284,91 @@ func SaveProjectConfig(basePath string, config *ProjectConfig) error {
 	return nil
 }
 
+// set applies one resource.<name>.<option> value. Unknown options are
+// ignored so older lvt versions can read newer files.
+func (rc *ResourceConfig) set(option, value string) {
+	switch option {
+	case "pagination":
+		rc.Pagination = value
+	case "page_size":
+		rc.PageSize, _ = strconv.Atoi(value)
+	case "edit_mode":
+		rc.EditMode = value
+	case "parent":
+		rc.Parent = value
+	case "with_authz":
+		rc.WithAuthz = value == "true"
+	case "searchable":
+		rc.Searchable = value == "true"
+	case "emit_events":
+		rc.EmitEvents = value == "true"
+	case "cache":
+		rc.Cache = value == "true"
+	case "render_cache":
+		rc.RenderCache = value == "true"
+	case "filters":
+		rc.Filters = value == "true"
+	case "saved_views":
+		rc.SavedViews = value == "true"
+	case "sortable":
+		rc.Sortable = value == "true"
+	case "undo":
+		rc.Undo = value == "true"
+	case "presence":
+		rc.Presence = value == "true"
+	case "conflicts":
+		rc.Conflicts = value == "true"
+	case "policy":
+		rc.Policy = value == "true"
+	case "hooks":
+		rc.Hooks = value == "true"
+	case "id":
+		rc.IDType = value
+	case "actions":
+		rc.Actions = value
+	case "from_table":
+		rc.FromTable = value
+	}
+}
+
+// lines renders the options that are set, each key prefixed with prefix.
+func (rc *ResourceConfig) lines(prefix string) []string {
+	var lines []string
+	str := func(option, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%s%s=%q", prefix, option, value))
+		}
+	}
+	flag := func(option string, value bool) {
+		if value {
+			lines = append(lines, prefix+option+"=true")
+		}
+	}
+	str("pagination", rc.Pagination)
+	if rc.PageSize > 0 {
+		lines = append(lines, fmt.Sprintf("%spage_size=%d", prefix, rc.PageSize))
+	}
+	str("edit_mode", rc.EditMode)
+	str("parent", rc.Parent)
+	flag("with_authz", rc.WithAuthz)
+	flag("searchable", rc.Searchable)
+	flag("emit_events", rc.EmitEvents)
+	flag("cache", rc.Cache)
+	flag("render_cache", rc.RenderCache)
+	flag("filters", rc.Filters)
+	flag("saved_views", rc.SavedViews)
+	flag("sortable", rc.Sortable)
+	flag("undo", rc.Undo)
+	flag("presence", rc.Presence)
+	flag("conflicts", rc.Conflicts)
+	flag("policy", rc.Policy)
+	flag("hooks", rc.Hooks)
+	str("id", rc.IDType)
+	str("actions", rc.Actions)
+	str("from_table", rc.FromTable)
+	return lines
+}
+
 // GetKit returns the kit for the project
 func (c *ProjectConfig) GetKit() string {
 	if c.Kit == "" {
@@ -135,9 +379,12 @@ func (c *ProjectConfig) GetKit() string {
 
 // Validate validates the project configuration
 func (c *ProjectConfig) Validate() error {
-	validKits := map[string]bool{"multi": true, "single": true, "simple": true}
+	validKits := map[string]bool{"multi": true, "single": true, "simple": true, "daisyui": true}
 	if !validKits[c.Kit] {
-		return fmt.Errorf("invalid kit: %s (valid: multi, single, simple)", c.Kit)
+		return fmt.Errorf("invalid kit: %s (valid: multi, single, simple, daisyui)", c.Kit)
+	}
+	if c.IDType != "" && c.IDType != "uuid" && c.IDType != "ulid" {
+		return fmt.Errorf("invalid id: %s (valid: uuid, ulid)", c.IDType)
 	}
 
 	return nil
@@ -0,0 +1,320 @@
+// Package dbbackup implements `lvt db backup` and `lvt db restore`: online
+// snapshots of the app's SQLite database through SQLite's backup API (safe
+// while the app is writing, unlike copying the file), pg_dump archives for
+// Postgres, and the retention policy that prunes old snapshots.
+package dbbackup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// DefaultDir is where backups are written when no --dir is given, relative
+// to the project root.
+const DefaultDir = "backups"
+
+// timestampFormat is the part of a backup's name that orders it.
+const timestampFormat = "20060102-150405"
+
+// Database is the database being backed up: a SQLite file, or a Postgres
+// server when URL is set.
+type Database struct {
+	Path string // SQLite database file
+	URL  string // postgres:// connection URL
+}
+
+// IsPostgresURL reports whether s is a Postgres connection URL.
+func IsPostgresURL(s string) bool {
+	return strings.HasPrefix(s, "postgres://") || strings.HasPrefix(s, "postgresql://")
+}
+
+// Name is the prefix of the database's backup files: the SQLite file's base
+// name, or the Postgres database name.
+func (d Database) Name() string {
+	if d.URL != "" {
+		name := d.URL
+		if i := strings.IndexAny(name, "?#"); i >= 0 {
+			name = name[:i]
+		}
+		if i := strings.LastIndex(name, "/"); i >= 0 && i+1 < len(name) && !strings.HasSuffix(name[:i], "/") {
+			return name[i+1:]
+		}
+		return "postgres"
+	}
+	return strings.TrimSuffix(filepath.Base(d.Path), filepath.Ext(d.Path))
+}
+
+// Describe names the database for messages, without any password in the URL.
+func (d Database) Describe() string {
+	if d.URL == "" {
+		return d.Path
+	}
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return "postgres database " + d.Name()
+	}
+	return u.Redacted()
+}
+
+// Ext is the extension of the database's backup files.
+func (d Database) Ext() string {
+	if d.URL != "" {
+		return ".dump"
+	}
+	return ".db"
+}
+
+// Backup is one backup file.
+type Backup struct {
+	Path string
+	Time time.Time // when it was taken, from its name
+	Size int64
+}
+
+// FileName is the name of a backup taken at t: app-20240101-120000.db.
+func (d Database) FileName(t time.Time) string {
+	return d.Name() + "-" + t.UTC().Format(timestampFormat) + d.Ext()
+}
+
+// Create writes a backup of d into dir and returns its path.
+func Create(d Database, dir string, now time.Time) (string, error) {
+	return create(d, filepath.Join(dir, d.FileName(now)))
+}
+
+// CreatePreRestore backs up d into dir before a restore overwrites it. Its
+// name keeps it out of List, so it is neither restored by --latest nor
+// pruned.
+func CreatePreRestore(d Database, dir string, now time.Time) (string, error) {
+	name := strings.TrimSuffix(d.FileName(now), d.Ext()) + "-pre-restore" + d.Ext()
+	return create(d, filepath.Join(dir, name))
+}
+
+func create(d Database, path string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("backup %s already exists", path)
+	}
+
+	var err error
+	if d.URL != "" {
+		err = pgDump(d.URL, path)
+	} else {
+		err = snapshotSQLite(d.Path, path)
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// snapshotSQLite copies the database at src to dst with the backup API,
+// which sees a consistent snapshot even while other connections write.
+func snapshotSQLite(src, dst string) error {
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("database file not found: %s", src)
+	}
+	db, err := sql.Open("sqlite", src+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	return withBackup(db, func(c backuper) (*sqlite.Backup, error) { return c.NewBackup(dst) })
+}
+
+// restoreSQLite overwrites the database at dst with the backup at src, page
+// by page, under the database's own locks.
+func restoreSQLite(src, dst string) error {
+	db, err := sql.Open("sqlite", dst+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	return withBackup(db, func(c backuper) (*sqlite.Backup, error) { return c.NewRestore(src) })
+}
+
+// backuper is the backup API of the modernc driver's connections.
+type backuper interface {
+	NewBackup(dstURI string) (*sqlite.Backup, error)
+	NewRestore(srcURI string) (*sqlite.Backup, error)
+}
+
+func withBackup(db *sql.DB, start func(backuper) (*sqlite.Backup, error)) error {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(backuper)
+		if !ok {
+			return fmt.Errorf("sqlite driver does not support the backup API")
+		}
+		b, err := start(c)
+		if err != nil {
+			return fmt.Errorf("failed to start backup: %w", err)
+		}
+		for {
+			more, err := b.Step(-1)
+			if err != nil {
+				_ = b.Finish()
+				return fmt.Errorf("backup failed: %w", err)
+			}
+			if !more {
+				break
+			}
+		}
+		return b.Finish()
+	})
+}
+
+// Check verifies that path is an intact SQLite database.
+func Check(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("backup not found: %s", path)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("%s is not a SQLite database: %w", path, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("%s is damaged: %s", path, result)
+	}
+	return nil
+}
+
+// Restore replaces the contents of d with the backup at path. SQLite backups
+// are checked first, so a damaged file never overwrites a good database.
+func Restore(d Database, path string) error {
+	if d.URL != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("backup not found: %s", path)
+		}
+		return pgRestore(d.URL, path)
+	}
+	if err := Check(path); err != nil {
+		return err
+	}
+	return restoreSQLite(path, d.Path)
+}
+
+func pgDump(dsn, path string) error {
+	if _, err := exec.LookPath("pg_dump"); err != nil {
+		return fmt.Errorf("pg_dump not found in PATH; install the PostgreSQL client tools to back up Postgres")
+	}
+	return runTool("pg_dump", "--format=custom", "--no-owner", "--file", path, "--dbname", dsn)
+}
+
+func pgRestore(dsn, path string) error {
+	if _, err := exec.LookPath("pg_restore"); err != nil {
+		return fmt.Errorf("pg_restore not found in PATH; install the PostgreSQL client tools to restore Postgres")
+	}
+	return runTool("pg_restore", "--clean", "--if-exists", "--no-owner", "--single-transaction", "--dbname", dsn, path)
+}
+
+func runTool(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s failed: %s", name, msg)
+		}
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+// List returns d's backups in dir, newest first. Files that do not follow
+// the backup naming, such as pre-restore snapshots, are not included.
+func List(d Database, dir string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(d.Name()) + `-(\d{8}-\d{6})` + regexp.QuoteMeta(d.Ext()) + `$`)
+	var backups []Backup
+	for _, entry := range entries {
+		m := pattern.FindStringSubmatch(entry.Name())
+		if m == nil || entry.IsDir() {
+			continue
+		}
+		t, err := time.Parse(timestampFormat, m[1])
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, entry.Name()), Time: t, Size: info.Size()})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Time.After(backups[j].Time) })
+	return backups, nil
+}
+
+// Retention says which backups Prune keeps. Zero values keep everything.
+type Retention struct {
+	Keep   int           // keep at most this many of the newest backups
+	MaxAge time.Duration // remove backups older than this
+}
+
+// Prune removes d's backups in dir that the retention policy does not keep,
+// and returns them. The newest backup is always kept.
+func Prune(d Database, dir string, r Retention, now time.Time) ([]Backup, error) {
+	backups, err := List(d, dir)
+	if err != nil {
+		return nil, err
+	}
+	var removed []Backup
+	for i, b := range backups {
+		if i == 0 {
+			continue
+		}
+		tooMany := r.Keep > 0 && i >= r.Keep
+		tooOld := r.MaxAge > 0 && now.Sub(b.Time) > r.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(b.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove old backup: %w", err)
+		}
+		removed = append(removed, b)
+	}
+	return removed, nil
+}
+
+// ParseAge parses a --max-age value: a Go duration such as 72h, or a
+// number of days such as 30d.
+func ParseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		if _, err := fmt.Sscanf(days, "%d", &n); err == nil && n > 0 && fmt.Sprint(n) == days {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid age %q (expected e.g. 30d or 72h)", value)
+}
@@ -0,0 +1,166 @@
+package dbbackup
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func countPosts(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT count(*) FROM posts").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	d := Database{Path: filepath.Join(dir, "app.db")}
+	db := openTestDB(t, d.Path)
+	if _, err := db.Exec("CREATE TABLE posts (title TEXT); INSERT INTO posts VALUES ('a'), ('b')"); err != nil {
+		t.Fatal(err)
+	}
+
+	backupDir := filepath.Join(dir, "backups")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	path, err := Create(d, backupDir, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "app-20240102-030405.db" {
+		t.Errorf("backup name = %s", filepath.Base(path))
+	}
+	if err := Check(path); err != nil {
+		t.Errorf("backup fails its check: %v", err)
+	}
+	if _, err := Create(d, backupDir, now); err == nil {
+		t.Error("a second backup in the same second overwrote the first")
+	}
+
+	// Changes after the backup are undone by restoring it, through the
+	// connection the app still holds open
+	if _, err := db.Exec("DELETE FROM posts"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(d, path); err != nil {
+		t.Fatal(err)
+	}
+	if n := countPosts(t, db); n != 2 {
+		t.Errorf("restored database has %d posts, want 2", n)
+	}
+
+	// A file that is not a database is refused before anything is touched
+	bad := filepath.Join(dir, "bad.db")
+	if err := os.WriteFile(bad, []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(d, bad); err == nil {
+		t.Error("restored from a file that is not a database")
+	}
+	if n := countPosts(t, db); n != 2 {
+		t.Errorf("failed restore changed the database: %d posts", n)
+	}
+}
+
+func TestListAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	d := Database{Path: filepath.Join(dir, "app.db")}
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	for _, days := range []int{0, 1, 2, 10, 40} {
+		name := d.FileName(now.AddDate(0, 0, -days))
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Not backups of app.db: left out of List and never pruned
+	for _, name := range []string{"app-20240101-000000-pre-restore.db", "other-20240101-000000.db", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := List(d, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 5 || !backups[0].Time.Equal(now) {
+		t.Fatalf("List = %d backups, newest %v; want 5, newest first", len(backups), backups)
+	}
+
+	removed, err := Prune(d, dir, Retention{MaxAge: 30 * 24 * time.Hour}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0].Path != backups[4].Path {
+		t.Errorf("pruning by age removed %v, want the 40-day-old backup", removed)
+	}
+
+	removed, err = Prune(d, dir, Retention{Keep: 2}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("keeping 2 of 4 removed %d", len(removed))
+	}
+
+	// The newest backup survives even a policy that rejects everything
+	if _, err := Prune(d, dir, Retention{MaxAge: time.Nanosecond}, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	left, _ := os.ReadDir(dir)
+	if len(left) != 4 {
+		t.Errorf("%d files left, want the newest backup and the 3 other files", len(left))
+	}
+}
+
+func TestDatabaseName(t *testing.T) {
+	tests := []struct {
+		db   Database
+		name string
+		ext  string
+	}{
+		{Database{Path: "/srv/app/app.db"}, "app", ".db"},
+		{Database{Path: "data/blog.sqlite3"}, "blog", ".db"},
+		{Database{URL: "postgres://u:secret@db:5432/blog?sslmode=disable"}, "blog", ".dump"},
+		{Database{URL: "postgresql://localhost"}, "postgres", ".dump"},
+	}
+	for _, tt := range tests {
+		if got := tt.db.Name(); got != tt.name {
+			t.Errorf("%+v Name() = %q, want %q", tt.db, got, tt.name)
+		}
+		if got := tt.db.Ext(); got != tt.ext {
+			t.Errorf("%+v Ext() = %q, want %q", tt.db, got, tt.ext)
+		}
+	}
+	if got := (Database{URL: "postgres://u:secret@db/blog"}).Describe(); got != "postgres://u:xxxxx@db/blog" {
+		t.Errorf("Describe() = %q, want the password redacted", got)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	valid := map[string]time.Duration{"30d": 30 * 24 * time.Hour, "72h": 72 * time.Hour, "90m": 90 * time.Minute}
+	for value, want := range valid {
+		if got, err := ParseAge(value); err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "d", "0d", "-1d", "1.5d", "week", "-2h"} {
+		if _, err := ParseAge(value); err == nil {
+			t.Errorf("ParseAge(%q) succeeded", value)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package dbconsole
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxHistory is how many lines the history file keeps.
+const maxHistory = 1000
+
+// errInterrupt is returned by readLine when the user presses Ctrl-C.
+var errInterrupt = errors.New("interrupt")
+
+// lineEditor reads lines from a terminal in raw mode, with cursor movement
+// and Up/Down recall of earlier lines. Keys it does not know are ignored.
+type lineEditor struct {
+	in      *bufio.Reader
+	out     io.Writer
+	history []string
+}
+
+// readLine shows prompt and returns the line typed, without the newline.
+// It returns io.EOF for Ctrl-D on an empty line and errInterrupt for Ctrl-C.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	var line []rune
+	pos := 0
+	histIdx := len(e.history)
+	draft := ""
+
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, string(line))
+		if back := len(line) - pos; back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+	recall := func(idx int) {
+		if histIdx == len(e.history) {
+			draft = string(line)
+		}
+		histIdx = idx
+		if idx == len(e.history) {
+			line = []rune(draft)
+		} else {
+			line = []rune(e.history[idx])
+		}
+		pos = len(line)
+		redraw()
+	}
+
+	fmt.Fprint(e.out, prompt)
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupt
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+		case 127, 8: // Backspace
+			if pos > 0 {
+				line = append(line[:pos-1], line[pos:]...)
+				pos--
+				redraw()
+			}
+		case 1: // Ctrl-A
+			pos = 0
+			redraw()
+		case 5: // Ctrl-E
+			pos = len(line)
+			redraw()
+		case 21: // Ctrl-U
+			line = line[pos:]
+			pos = 0
+			redraw()
+		case 27: // Escape sequence: arrows, Home/End, Delete
+			if b, _ := e.in.ReadByte(); b != '[' && b != 'O' {
+				continue
+			}
+			key, _ := e.in.ReadByte()
+			switch key {
+			case 'A':
+				if histIdx > 0 {
+					recall(histIdx - 1)
+				}
+			case 'B':
+				if histIdx < len(e.history) {
+					recall(histIdx + 1)
+				}
+			case 'C':
+				if pos < len(line) {
+					pos++
+					redraw()
+				}
+			case 'D':
+				if pos > 0 {
+					pos--
+					redraw()
+				}
+			case 'H':
+				pos = 0
+				redraw()
+			case 'F':
+				pos = len(line)
+				redraw()
+			case '3':
+				if b, _ := e.in.ReadByte(); b == '~' && pos < len(line) {
+					line = append(line[:pos], line[pos+1:]...)
+					redraw()
+				}
+			}
+		default:
+			if r < ' ' {
+				continue
+			}
+			line = append(line[:pos], append([]rune{r}, line[pos:]...)...)
+			pos++
+			redraw()
+		}
+	}
+}
+
+// add records a line in the in-memory history, skipping blanks and
+// immediate repeats. It reports whether the line was added.
+func (e *lineEditor) add(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	if n := len(e.history); n > 0 && e.history[n-1] == line {
+		return false
+	}
+	e.history = append(e.history, line)
+	if len(e.history) > maxHistory {
+		e.history = e.history[len(e.history)-maxHistory:]
+	}
+	return true
+}
+
+// loadHistory reads the last maxHistory lines of the history file. A
+// missing file is an empty history.
+func loadHistory(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	if len(lines) > maxHistory {
+		lines = lines[len(lines)-maxHistory:]
+	}
+	return lines
+}
+
+// saveHistory writes history to path, creating its directory.
+func saveHistory(path string, history []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(history, "\n")+"\n"), 0600)
+}
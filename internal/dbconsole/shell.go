@@ -0,0 +1,383 @@
+// Package dbconsole implements the interactive SQL shell behind
+// `lvt db console`: SQL statements against the app's SQLite database, plus
+// the sqlite3-style dot commands people reach for first (.tables, .schema).
+package dbconsole
+
+import (
+	"bufio"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Shell executes console input against a database and writes results to out.
+type Shell struct {
+	db  *sql.DB
+	out io.Writer
+}
+
+// errQuit is returned by Exec for .quit and .exit.
+var errQuit = errors.New("quit")
+
+// New returns a shell over db that prints to out.
+func New(db *sql.DB, out io.Writer) *Shell {
+	return &Shell{db: db, out: out}
+}
+
+// Exec runs one dot command or one or more semicolon-separated SQL
+// statements, printing any rows they return as aligned columns.
+func (s *Shell) Exec(input string) error {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, ".") {
+		return s.dotCommand(input)
+	}
+	for _, stmt := range splitStatements(input) {
+		if err := s.execStatement(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run reads statements from in until EOF or .quit. On a terminal it prompts,
+// edits lines with history (kept in historyPath when set) and reports errors
+// without stopping; otherwise in is a script, run until its first error.
+func (s *Shell) Run(in *os.File, historyPath string) error {
+	if !term.IsTerminal(in.Fd()) {
+		scanner := bufio.NewScanner(in)
+		return s.loop(func(string) (string, error) {
+			if scanner.Scan() {
+				return scanner.Text(), nil
+			}
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}, false)
+	}
+
+	editor := &lineEditor{in: bufio.NewReader(in), out: s.out, history: loadHistory(historyPath)}
+	err := s.loop(func(prompt string) (string, error) {
+		state, err := term.MakeRaw(in.Fd())
+		if err != nil {
+			return "", fmt.Errorf("failed to set up terminal: %w", err)
+		}
+		defer func() { _ = term.Restore(in.Fd(), state) }()
+		line, err := editor.readLine(prompt)
+		if err == nil {
+			editor.add(line)
+		}
+		return line, err
+	}, true)
+	if historyPath != "" {
+		if saveErr := saveHistory(historyPath, editor.history); saveErr != nil {
+			fmt.Fprintf(s.out, "Warning: failed to save history: %v\n", saveErr)
+		}
+	}
+	return err
+}
+
+// loop collects lines from next into statements and runs each one once it
+// is complete. Dot commands run as soon as they are entered.
+func (s *Shell) loop(next func(prompt string) (string, error), interactive bool) error {
+	var pending strings.Builder
+	for {
+		prompt := "lvt> "
+		if pending.Len() > 0 {
+			prompt = " ...> "
+		}
+		line, err := next(prompt)
+		if errors.Is(err, errInterrupt) {
+			pending.Reset()
+			continue
+		}
+		if err == io.EOF {
+			if pending.Len() == 0 || interactive {
+				return nil
+			}
+			// A script may leave off the final semicolon
+			line, err = ";", nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if pending.Len() == 0 {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if !strings.HasPrefix(strings.TrimSpace(line), ".") {
+				pending.WriteString(line)
+			}
+		} else {
+			pending.WriteString("\n" + line)
+		}
+		var input string
+		switch {
+		case pending.Len() == 0:
+			input = line
+		case complete(pending.String()):
+			input = pending.String()
+			pending.Reset()
+		default:
+			continue
+		}
+
+		if err := s.Exec(input); err != nil {
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+			if !interactive {
+				return err
+			}
+			fmt.Fprintf(s.out, "Error: %v\n", err)
+		}
+	}
+}
+
+func (s *Shell) dotCommand(input string) error {
+	fields := strings.Fields(input)
+	switch fields[0] {
+	case ".tables":
+		tables, err := s.tables()
+		if err != nil {
+			return err
+		}
+		for _, name := range tables {
+			fmt.Fprintln(s.out, name)
+		}
+		return nil
+	case ".schema":
+		table := ""
+		if len(fields) > 1 {
+			table = fields[1]
+		}
+		return s.schema(table)
+	case ".help":
+		printDotHelp(s.out)
+		return nil
+	case ".quit", ".exit":
+		return errQuit
+	default:
+		return fmt.Errorf("unknown command %s (try .help)", fields[0])
+	}
+}
+
+// tables lists the user tables and views, without SQLite's internal ones.
+func (s *Shell) tables() ([]string, error) {
+	rows, err := s.db.Query(`SELECT name FROM sqlite_master
+WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%'
+ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// schema prints the CREATE statements for table and its indexes and
+// triggers, or for everything when table is empty.
+func (s *Shell) schema(table string) error {
+	query := `SELECT sql FROM sqlite_master
+WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%'`
+	var args []any
+	if table != "" {
+		query += ` AND tbl_name = ?`
+		args = append(args, table)
+	}
+	query += ` ORDER BY tbl_name, type = 'table' DESC, name`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	found := false
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "%s;\n", stmt)
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found && table != "" {
+		return fmt.Errorf("no such table: %s", table)
+	}
+	return nil
+}
+
+func (s *Shell) execStatement(stmt string) error {
+	if !returnsRows(stmt) {
+		res, err := s.db.Exec(stmt)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err == nil && n > 0 {
+			fmt.Fprintf(s.out, "%d row(s) affected\n", n)
+		}
+		return nil
+	}
+
+	rows, err := s.db.Query(stmt)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(cols) == 0 {
+		return rows.Err()
+	}
+
+	// Buffer the rows so each column is as wide as its widest value
+	table := [][]string{cols, nil}
+	values := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		cells := make([]string, len(cols))
+		for i, v := range values {
+			cells[i] = formatValue(v)
+		}
+		table = append(table, cells)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	widths := make([]int, len(cols))
+	for _, row := range table {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	dashes := make([]string, len(cols))
+	for i, w := range widths {
+		dashes[i] = strings.Repeat("-", w)
+	}
+	table[1] = dashes
+
+	for _, row := range table {
+		var line strings.Builder
+		for i, cell := range row {
+			line.WriteString(cell)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+			}
+		}
+		fmt.Fprintln(s.out, line.String())
+	}
+	return nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// returnsRows reports whether stmt produces a result set, so it is run with
+// Query rather than Exec.
+func returnsRows(stmt string) bool {
+	fields := strings.Fields(strings.ToUpper(stmt))
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "SELECT", "WITH", "PRAGMA", "EXPLAIN", "VALUES":
+		return true
+	}
+	for _, f := range fields {
+		if f == "RETURNING" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitStatements splits input on the semicolons that end statements,
+// ignoring those inside quoted strings and identifiers.
+func splitStatements(input string) []string {
+	var stmts []string
+	start := 0
+	var quote rune
+	for i, c := range input {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == ';':
+			if stmt := strings.TrimSpace(input[start:i]); stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+			start = i + 1
+		}
+	}
+	if stmt := strings.TrimSpace(input[start:]); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+// complete reports whether input ends a statement: its last character
+// outside quotes is a semicolon.
+func complete(input string) bool {
+	var quote rune
+	var last rune
+	for _, c := range input {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			last = c
+		}
+	}
+	return quote == 0 && last == ';'
+}
+
+func printDotHelp(out io.Writer) {
+	fmt.Fprintln(out, ".tables          List tables and views")
+	fmt.Fprintln(out, ".schema [TABLE]  Show CREATE statements (for TABLE, or all)")
+	fmt.Fprintln(out, ".help            Show this help")
+	fmt.Fprintln(out, ".quit            Exit the console (also .exit or Ctrl-D)")
+	fmt.Fprintln(out, "End SQL statements with ; (they may span several lines).")
+}
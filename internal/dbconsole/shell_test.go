@@ -0,0 +1,192 @@
+package dbconsole
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE posts (id TEXT PRIMARY KEY, title TEXT, body TEXT);
+CREATE INDEX idx_posts_title ON posts(title);
+CREATE TABLE tags (id TEXT PRIMARY KEY, name TEXT);`); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestExec(t *testing.T) {
+	db := openTestDB(t)
+	var out bytes.Buffer
+	shell := New(db, &out)
+
+	if err := shell.Exec("INSERT INTO posts VALUES ('p1', 'Hello', NULL); INSERT INTO posts VALUES ('p2', 'A much longer title', 'x')"); err != nil {
+		t.Fatalf("Exec insert: %v", err)
+	}
+	out.Reset()
+	if err := shell.Exec("SELECT id, title, body FROM posts ORDER BY id;"); err != nil {
+		t.Fatalf("Exec select: %v", err)
+	}
+	want := "id  title                body\n" +
+		"--  -------------------  ----\n" +
+		"p1  Hello                NULL\n" +
+		"p2  A much longer title  x\n"
+	if out.String() != want {
+		t.Errorf("select output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := shell.Exec(".tables"); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "posts\ntags\n" {
+		t.Errorf(".tables = %q", out.String())
+	}
+
+	out.Reset()
+	if err := shell.Exec(".schema posts"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "CREATE TABLE posts") || !strings.Contains(out.String(), "CREATE INDEX idx_posts_title") || strings.Contains(out.String(), "tags") {
+		t.Errorf(".schema posts = %q", out.String())
+	}
+	if err := shell.Exec(".schema nope"); err == nil {
+		t.Error(".schema of a missing table should fail")
+	}
+
+	if err := shell.Exec("SELECT * FROM missing"); err == nil {
+		t.Error("expected an error for a missing table")
+	}
+	if err := shell.Exec(".quit"); !errors.Is(err, errQuit) {
+		t.Errorf(".quit = %v, want errQuit", err)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	got := splitStatements("SELECT ';' AS a; INSERT INTO t VALUES (\"x;y\");;  SELECT 1")
+	want := []string{"SELECT ';' AS a", `INSERT INTO t VALUES ("x;y")`, "SELECT 1"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("splitStatements = %q, want %q", got, want)
+	}
+
+	for input, want := range map[string]bool{
+		"SELECT 1;":        true,
+		"SELECT 1;  \n":    true,
+		"SELECT 1":         false,
+		"SELECT ';":        false,
+		"SELECT ';';":      true,
+		"SELECT 1;\nSELEC": false,
+	} {
+		if got := complete(input); got != want {
+			t.Errorf("complete(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestLoop(t *testing.T) {
+	db := openTestDB(t)
+	var out bytes.Buffer
+	shell := New(db, &out)
+
+	lines := []string{"SELECT", "  'a;b' AS x", ";", "", ".tables", "SELECT nope;", "SELECT 2 AS y;", ".quit", "SELECT 3;"}
+	next := func(string) (string, error) {
+		if len(lines) == 0 {
+			return "", io.EOF
+		}
+		line := lines[0]
+		lines = lines[1:]
+		return line, nil
+	}
+	if err := shell.loop(next, true); err != nil {
+		t.Fatalf("loop: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"x\n", "a;b\n", "posts\ntags\n", "Error: ", "y\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "3") {
+		t.Errorf("statements after .quit ran:\n%s", got)
+	}
+
+	// A script stops at its first error
+	lines = []string{"SELECT nope;", "SELECT 4 AS z;"}
+	out.Reset()
+	if err := shell.loop(next, false); err == nil {
+		t.Error("expected the script's error")
+	}
+	if strings.Contains(out.String(), "z") {
+		t.Errorf("script kept running after an error:\n%s", out.String())
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	editor := &lineEditor{out: io.Discard, history: []string{"SELECT 1;", "SELECT 2;"}}
+	read := func(keys string) (string, error) {
+		editor.in = bufio.NewReader(strings.NewReader(keys))
+		return editor.readLine("lvt> ")
+	}
+
+	tests := []struct {
+		name, keys, want string
+	}{
+		{"typing", "abc\r", "abc"},
+		{"backspace", "abx\x7fc\r", "abc"},
+		{"insert after left arrow", "ac\x1b[Db\r", "abc"},
+		{"home and end", "bc\x01a\x05d\r", "abcd"},
+		{"delete key", "abxc\x1b[D\x1b[D\x1b[3~\r", "abc"},
+		{"kill to start", "junk\x15abc\r", "abc"},
+		{"up recalls the last line", "\x1b[A\r", "SELECT 2;"},
+		{"up twice", "\x1b[A\x1b[A\r", "SELECT 1;"},
+		{"down returns to the draft", "dr\x1b[A\x1b[Baft\r", "draft"},
+	}
+	for _, tt := range tests {
+		got, err := read(tt.keys)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: readLine = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := read("\x04"); err != io.EOF {
+		t.Errorf("Ctrl-D on an empty line = %v, want io.EOF", err)
+	}
+	if _, err := read("abc\x03"); !errors.Is(err, errInterrupt) {
+		t.Errorf("Ctrl-C = %v, want errInterrupt", err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lvt", "db_history")
+	if h := loadHistory(path); h != nil {
+		t.Errorf("missing history file = %q, want none", h)
+	}
+
+	editor := &lineEditor{}
+	for _, line := range []string{"SELECT 1;", "", "SELECT 1;", ".tables"} {
+		editor.add(line)
+	}
+	if strings.Join(editor.history, "|") != "SELECT 1;|.tables" {
+		t.Errorf("history = %q; blanks and repeats should be skipped", editor.history)
+	}
+
+	if err := saveHistory(path, editor.history); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadHistory(path); strings.Join(got, "|") != "SELECT 1;|.tables" {
+		t.Errorf("loadHistory = %q", got)
+	}
+}
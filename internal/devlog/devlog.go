@@ -0,0 +1,308 @@
+// Package devlog is the log lvt serve keeps of an app in development: the
+// app's output, its pages' browser console and their WebSocket traffic, as
+// one stream of entries keyed by the LiveTemplate session they belong to.
+// It lives in .lvt/logs/dev.log, one JSON entry per line, and lvt logs
+// reads it.
+package devlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Path is the log's location relative to the project root. When it grows
+// past maxSize it is moved to Path+".1", replacing the previous one.
+const Path = ".lvt/logs/dev.log"
+
+// maxSize is the size at which the log is rotated.
+const maxSize = 10 << 20
+
+// SessionCookie is the cookie LiveTemplate keeps a browser's session ID in.
+const SessionCookie = "livetemplate-id"
+
+// The sources of entries.
+const (
+	SourceServer    = "server"  // a line the app wrote to stdout or stderr
+	SourceBrowser   = "browser" // a console message or error of a page
+	SourceWebSocket = "ws"      // a WebSocket connection or message
+)
+
+// Entry is a line of the log.
+type Entry struct {
+	Time    time.Time      `json:"time"`
+	Source  string         `json:"source"`
+	Session string         `json:"session,omitempty"`
+	Level   string         `json:"level"` // DEBUG, INFO, WARN or ERROR
+	Message string         `json:"msg"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// Writer appends entries to the log of a project. Its methods are safe for
+// concurrent use, and do nothing on a nil Writer.
+type Writer struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	size int64
+}
+
+// Open opens the log of the project at root for appending, creating it.
+func Open(root string) (*Writer, error) {
+	path := filepath.Join(root, Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	w := &Writer{path: path}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.f, w.size = f, info.Size()
+	return nil
+}
+
+// Write appends e, stamping it with the current time if it has none.
+func (w *Writer) Write(e Entry) {
+	if w == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return
+	}
+	if w.size+int64(len(line)) > maxSize {
+		w.f.Close()
+		_ = os.Rename(w.path, w.path+".1")
+		if err := w.open(); err != nil {
+			w.f = nil
+			return
+		}
+	}
+	n, _ := w.f.Write(line)
+	w.size += int64(n)
+}
+
+// Close closes the log.
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
+
+// ParseServerLine turns a line of the app's output into an entry. The
+// JSON records of log/slog keep their time, level, message and attributes,
+// and a "session" attribute names the entry's session; other lines are
+// messages of their own.
+func ParseServerLine(line string) Entry {
+	e := Entry{Source: SourceServer, Level: "INFO", Message: line}
+	var record map[string]any
+	if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &record) != nil {
+		return e
+	}
+	if s, ok := record["time"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			e.Time = t
+		}
+	}
+	if s, ok := record["level"].(string); ok {
+		e.Level = s
+	}
+	if s, ok := record["msg"].(string); ok {
+		e.Message = s
+	}
+	if s, ok := record["session"].(string); ok {
+		e.Session = s
+	}
+	for _, key := range []string{"time", "level", "msg", "session"} {
+		delete(record, key)
+	}
+	if len(record) > 0 {
+		e.Attrs = record
+	}
+	return e
+}
+
+// Filter selects entries.
+type Filter struct {
+	Since   time.Time // entries from then on, all when zero
+	Session string    // entries of the sessions starting with it, all when empty
+}
+
+// Match reports whether f selects e.
+func (f Filter) Match(e Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	return f.Session == "" || strings.HasPrefix(e.Session, f.Session)
+}
+
+// Read calls fn with the entries of the project at root that f selects,
+// oldest first, including those rotated out of the current log. Lines that
+// are not entries are skipped.
+func Read(root string, f Filter, fn func(Entry)) error {
+	path := filepath.Join(root, Path)
+	for _, p := range []string{path + ".1", path} {
+		file, err := os.Open(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		scan(file, f, fn)
+		file.Close()
+	}
+	return nil
+}
+
+// Follow calls fn with the entries f selects as they are appended to the
+// log of the project at root, after those already in it, until ctx is
+// done. It waits for a log that does not exist yet and reopens one that is
+// rotated.
+func Follow(ctx context.Context, root string, f Filter, fn func(Entry)) error {
+	path := filepath.Join(root, Path)
+	if rotated, err := os.Open(path + ".1"); err == nil {
+		scan(rotated, f, fn)
+		rotated.Close()
+	}
+	var (
+		file   *os.File
+		offset int64
+	)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	var pending []byte // a line not yet complete
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if info, err := os.Stat(path); err == nil {
+			if file != nil {
+				if current, err := file.Stat(); err != nil || !os.SameFile(info, current) || info.Size() < offset {
+					file.Close()
+					file, offset, pending = nil, 0, nil // rotated
+				}
+			}
+			if file == nil {
+				if file, err = os.Open(path); err != nil {
+					return err
+				}
+			}
+			if info.Size() > offset {
+				data := make([]byte, info.Size()-offset)
+				n, err := file.ReadAt(data, offset)
+				if err != nil && err != io.EOF {
+					return err
+				}
+				offset += int64(n)
+				pending = append(pending, data[:n]...)
+				if i := bytes.LastIndexByte(pending, '\n'); i >= 0 {
+					scan(bytes.NewReader(pending[:i+1]), f, fn)
+					pending = append([]byte(nil), pending[i+1:]...)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func scan(r io.Reader, f Filter, fn func(Entry)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxSize)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		if f.Match(e) {
+			fn(e)
+		}
+	}
+}
+
+// Format renders e as a line for the terminal: its time, the first eight
+// characters of its session, its source and level, its message and its
+// attributes sorted by name.
+func Format(e Entry) string {
+	session := e.Session
+	if len(session) > 8 {
+		session = session[:8]
+	}
+	if session == "" {
+		session = "-"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %-8s %-7s %-5s %s", e.Time.Local().Format("15:04:05.000"), session, e.Source, e.Level, e.Message)
+	keys := make([]string, 0, len(e.Attrs))
+	for k := range e.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var s string
+		switch v := e.Attrs[k].(type) {
+		case string:
+			s = v
+			if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+				s = fmt.Sprintf("%q", s)
+			}
+		default:
+			// Numbers, booleans and the objects of slog groups as JSON
+			data, err := json.Marshal(v)
+			if err != nil {
+				data = []byte(fmt.Sprint(v))
+			}
+			s = string(data)
+		}
+		fmt.Fprintf(&b, " %s=%s", k, s)
+	}
+	return b.String()
+}
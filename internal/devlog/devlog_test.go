@@ -0,0 +1,157 @@
+package devlog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteRead(t *testing.T) {
+	root := t.TempDir()
+	w, err := Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	w.Write(Entry{Time: start.Add(-time.Hour), Source: SourceServer, Level: "INFO", Message: "old"})
+	w.Write(Entry{Source: SourceBrowser, Session: "abc123", Level: "ERROR", Message: "boom"})
+	w.Write(Entry{Source: SourceWebSocket, Session: "def456", Level: "INFO", Message: "connected"})
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	w.Write(Entry{Message: "after close"}) // dropped
+
+	var got []string
+	collect := func(e Entry) { got = append(got, e.Message) }
+	if err := Read(root, Filter{}, collect); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "old,boom,connected" {
+		t.Errorf("Read() = %v", got)
+	}
+
+	got = nil
+	if err := Read(root, Filter{Since: start.Add(-time.Minute)}, collect); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "boom,connected" {
+		t.Errorf("Read() since a minute ago = %v", got)
+	}
+
+	got = nil
+	if err := Read(root, Filter{Session: "def"}, collect); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "connected" {
+		t.Errorf("Read() of session def = %v", got)
+	}
+
+	var nilWriter *Writer
+	nilWriter.Write(Entry{Message: "ignored"})
+	if err := nilWriter.Close(); err != nil {
+		t.Errorf("Close() on a nil Writer = %v", err)
+	}
+}
+
+func TestParseServerLine(t *testing.T) {
+	e := ParseServerLine(`{"time":"2026-03-01T10:00:00.5Z","level":"WARN","msg":"Slow HTTP request","path":"/tasks","status":200,"session":"s1"}`)
+	if e.Source != SourceServer || e.Level != "WARN" || e.Message != "Slow HTTP request" || e.Session != "s1" {
+		t.Errorf("slog record parsed as %+v", e)
+	}
+	if !e.Time.Equal(time.Date(2026, 3, 1, 10, 0, 0, 5e8, time.UTC)) {
+		t.Errorf("Time = %v", e.Time)
+	}
+	if len(e.Attrs) != 2 || e.Attrs["path"] != "/tasks" || e.Attrs["status"] != float64(200) {
+		t.Errorf("Attrs = %v", e.Attrs)
+	}
+
+	e = ParseServerLine("panic: runtime error")
+	if e.Message != "panic: runtime error" || e.Level != "INFO" || !e.Time.IsZero() || e.Attrs != nil {
+		t.Errorf("plain line parsed as %+v", e)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	e := Entry{
+		Time:    time.Date(2026, 3, 1, 10, 4, 5, 123e6, time.Local),
+		Source:  SourceWebSocket,
+		Session: "0123456789abcdef",
+		Level:   "INFO",
+		Message: "update",
+		Attrs:   map[string]any{"bytes": float64(312), "action": "save", "errors": map[string]any{"title": "required"}, "path": "/a b"},
+	}
+	want := `10:04:05.123 01234567 ws      INFO  update action=save bytes=312 errors={"title":"required"} path="/a b"`
+	if got := Format(e); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+	if got := Format(Entry{Time: e.Time, Source: SourceServer, Level: "INFO", Message: "Starting"}); !strings.Contains(got, " -        server ") {
+		t.Errorf("Format() without a session = %q", got)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	root := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	done := make(chan error, 1)
+	go func() {
+		done <- Follow(ctx, root, Filter{}, func(e Entry) {
+			mu.Lock()
+			got = append(got, e.Message)
+			mu.Unlock()
+		})
+	}()
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			mu.Lock()
+			s := strings.Join(got, ",")
+			mu.Unlock()
+			if s == want {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		t.Fatalf("followed %v, want %s", got, want)
+	}
+
+	// The log does not exist yet
+	w, err := Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(Entry{Message: "first"})
+	waitFor("first")
+	w.Write(Entry{Message: "second"})
+	waitFor("first,second")
+
+	// Rotated by hand, as Write does when it grows too large
+	w.Close()
+	path := filepath.Join(root, Path)
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if w, err = Open(root); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write(Entry{Message: "third"})
+	waitFor("first,second,third")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Follow() = %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+// Package dryrun previews generators: it runs one against a scratch copy of
+// a directory and reports how the copy changed, leaving the directory
+// itself alone.
+package dryrun
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// Status tells how a generator changed a file.
+type Status string
+
+const (
+	Created  Status = "created"
+	Modified Status = "modified"
+	Appended Status = "appended" // modified only by adding to the end
+	Deleted  Status = "deleted"
+)
+
+// diffContext is the number of unchanged lines around each change in Diff.
+const diffContext = 3
+
+// skipDirs are neither copied nor compared: version control and installed
+// dependencies, which generators don't write.
+var skipDirs = map[string]bool{".git": true, "node_modules": true}
+
+// Change is a file the generator created, modified or deleted.
+type Change struct {
+	Path   string // slash-separated, relative to the directory
+	Status Status
+	Binary bool
+	Before string // "" for a created file
+	After  string // "" for a deleted file
+}
+
+// Diff returns the change as a unified diff, or a one-line note for a
+// binary file.
+func (c Change) Diff() string {
+	if c.Binary {
+		return fmt.Sprintf("Binary file %s %s\n", c.Path, c.Status)
+	}
+	from, to := "a/"+c.Path, "b/"+c.Path
+	if path.IsAbs(c.Path) {
+		from, to = c.Path, c.Path
+	}
+	switch c.Status {
+	case Created:
+		from = "/dev/null"
+	case Deleted:
+		to = "/dev/null"
+	}
+	return kits.CompareText(c.Path, c.Before, c.After).Unified(from, to, diffContext)
+}
+
+// Stat returns the number of lines the change adds and removes.
+func (c Change) Stat() (added, removed int) {
+	if c.Binary {
+		return 0, 0
+	}
+	return kits.CompareText(c.Path, c.Before, c.After).Stat()
+}
+
+// Preview copies dir into a temporary directory, runs generate with the
+// copy's path, and returns how the copy differs from dir afterwards, sorted
+// by path. The copy has dir's base name; if dir does not exist, neither does
+// the copy, so generate can create it. dir is never written.
+func Preview(dir string, generate func(copy string) error) ([]Change, error) {
+	tmp, err := os.MkdirTemp("", "lvt-dry-run-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	scratch := filepath.Join(tmp, filepath.Base(dir))
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		if err := copyTree(dir, scratch); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", dir, err)
+		}
+	}
+	if err := generate(scratch); err != nil {
+		return nil, err
+	}
+
+	before, err := readTree(dir)
+	if err != nil {
+		return nil, err
+	}
+	after, err := readTree(scratch)
+	if err != nil {
+		return nil, err
+	}
+	return compare(before, after), nil
+}
+
+func compare(before, after map[string][]byte) []Change {
+	var changes []Change
+	for path, data := range after {
+		old, existed := before[path]
+		switch {
+		case !existed:
+			changes = append(changes, newChange(path, Created, nil, data))
+		case !bytes.Equal(old, data):
+			status := Modified
+			if bytes.HasPrefix(data, old) {
+				status = Appended
+			}
+			changes = append(changes, newChange(path, status, old, data))
+		}
+	}
+	for path, old := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, newChange(path, Deleted, old, nil))
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func newChange(path string, status Status, before, after []byte) Change {
+	return Change{
+		Path:   path,
+		Status: status,
+		Binary: bytes.IndexByte(before, 0) >= 0 || bytes.IndexByte(after, 0) >= 0,
+		Before: string(before),
+		After:  string(after),
+	}
+}
+
+// readTree returns the regular files under dir by slash-separated relative
+// path. A missing dir has no files.
+func readTree(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files, nil
+	}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && skipDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// copyTree copies src to dst, keeping file modes and symlinks.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case entry.IsDir():
+			if path != src && skipDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case entry.Type().IsRegular():
+			return copyFile(path, target)
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Summary describes changes in a phrase, e.g. "create 3 files and modify 2".
+func Summary(changes []Change) string {
+	var created, modified, deleted int
+	for _, c := range changes {
+		switch c.Status {
+		case Created:
+			created++
+		case Modified, Appended:
+			modified++
+		case Deleted:
+			deleted++
+		}
+	}
+	var parts []string
+	for _, p := range []struct {
+		verb string
+		n    int
+	}{{"create", created}, {"modify", modified}, {"delete", deleted}} {
+		if p.n == 0 {
+			continue
+		}
+		part := fmt.Sprintf("%s %d", p.verb, p.n)
+		if len(parts) == 0 {
+			part += " file"
+			if p.n != 1 {
+				part += "s"
+			}
+		}
+		parts = append(parts, part)
+	}
+	switch len(parts) {
+	case 0:
+		return "change nothing"
+	case 1:
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
@@ -0,0 +1,123 @@
+package dryrun
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "app")
+	writeFiles(t, dir, map[string]string{
+		"main.go":         "package main\n\nfunc main() {\n}\n",
+		"schema.sql":      "CREATE TABLE a (id TEXT);\n",
+		"old.txt":         "gone\n",
+		"same.txt":        "same\n",
+		".git/HEAD":       "ref: refs/heads/main\n",
+		"logo.png":        "\x89PNG\x00\x01",
+		"app/home/a.tmpl": "home\n",
+	})
+
+	changes, err := Preview(dir, func(copy string) error {
+		if filepath.Base(copy) != "app" {
+			t.Errorf("copy %s does not keep the directory's name", copy)
+		}
+		if _, err := os.Stat(filepath.Join(copy, ".git")); err == nil {
+			t.Error(".git was copied")
+		}
+		writeFiles(t, copy, map[string]string{
+			"main.go":          "package main\n\nfunc main() {\n\trun()\n}\n",
+			"schema.sql":       "CREATE TABLE a (id TEXT);\nCREATE TABLE b (id TEXT);\n",
+			"app/posts/p.go":   "package posts\n",
+			"logo.png":         "\x89PNG\x00\x02",
+			"app/home/a.tmpl":  "home\n",
+			".git/index":       "scratch",
+			"node_modules/x.j": "x",
+		})
+		return os.Remove(filepath.Join(copy, "old.txt"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Status)+" "+c.Path)
+	}
+	want := []string{
+		"created app/posts/p.go",
+		"modified logo.png",
+		"modified main.go",
+		"deleted old.txt",
+		"appended schema.sql",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "main.go")); !strings.Contains(string(data), "func main() {\n}") {
+		t.Errorf("dir was written: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app", "posts")); err == nil {
+		t.Error("dir was written: app/posts exists")
+	}
+
+	if diff := changes[0].Diff(); !strings.HasPrefix(diff, "--- /dev/null\n+++ b/app/posts/p.go\n@@ -0,0 +1 @@\n+package posts\n") {
+		t.Errorf("created diff:\n%s", diff)
+	}
+	if !changes[1].Binary || changes[1].Diff() != "Binary file logo.png modified\n" {
+		t.Errorf("binary change = %+v", changes[1])
+	}
+	if diff := changes[2].Diff(); !strings.Contains(diff, "--- a/main.go\n+++ b/main.go\n") || !strings.Contains(diff, "+\trun()\n") {
+		t.Errorf("modified diff:\n%s", diff)
+	}
+	if diff := changes[3].Diff(); !strings.Contains(diff, "+++ /dev/null\n") || !strings.Contains(diff, "-gone\n") {
+		t.Errorf("deleted diff:\n%s", diff)
+	}
+	if added, removed := changes[4].Stat(); added != 1 || removed != 0 {
+		t.Errorf("schema.sql stat = +%d -%d", added, removed)
+	}
+	if got := Summary(changes); got != "create 1 file, modify 3 and delete 1" {
+		t.Errorf("Summary = %q", got)
+	}
+}
+
+func TestPreviewNewDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	changes, err := Preview(dir, func(copy string) error {
+		if _, err := os.Stat(copy); !os.IsNotExist(err) {
+			t.Errorf("copy of a missing directory exists: %v", err)
+		}
+		writeFiles(t, copy, map[string]string{"go.mod": "module demo\n", "main.go": "package main\n"})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Status != Created || changes[1].Status != Created {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("directory was created: %v", err)
+	}
+	if got := Summary(changes); got != "create 2 files" {
+		t.Errorf("Summary = %q", got)
+	}
+	if got := Summary(nil); got != "change nothing" {
+		t.Errorf("Summary(nil) = %q", got)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // ComponentInfo holds information about an ejectable component.
@@ -113,30 +115,30 @@ func EjectComponent(opts EjectOptions) error {
 		for _, file := range files {
 			if strings.HasSuffix(file, ".go") {
 				if err := updateImports(file, comp.Package, newPkg); err != nil {
-					fmt.Printf("Warning: failed to update imports in %s: %v\n", file, err)
+					logging.Warn("could not update imports", "file", file, "error", err)
 				}
 			}
 		}
 	}
 
-	fmt.Printf("✅ Ejected %s to %s\n", opts.ComponentName, destDir)
-	fmt.Println()
-	fmt.Println("Ejected files:")
+	logging.Printf("✅ Ejected %s to %s\n", opts.ComponentName, destDir)
+	logging.Println()
+	logging.Println("Ejected files:")
 	for _, f := range files {
 		rel, _ := filepath.Rel(destDir, f)
-		fmt.Printf("  - %s\n", rel)
+		logging.Printf("  - %s\n", rel)
 	}
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Update your imports from:\n")
-	fmt.Printf("       %s\n", comp.Package)
-	fmt.Printf("     to:\n")
+	logging.Println()
+	logging.Println("Next steps:")
+	logging.Printf("  1. Update your imports from:\n")
+	logging.Printf("       %s\n", comp.Package)
+	logging.Printf("     to:\n")
 	if opts.ModuleName != "" {
-		fmt.Printf("       %s/internal/components/%s\n", opts.ModuleName, opts.ComponentName)
+		logging.Printf("       %s/internal/components/%s\n", opts.ModuleName, opts.ComponentName)
 	} else {
-		fmt.Printf("       yourmodule/internal/components/%s\n", opts.ComponentName)
+		logging.Printf("       yourmodule/internal/components/%s\n", opts.ComponentName)
 	}
-	fmt.Printf("  2. Customize the component as needed\n")
+	logging.Printf("  2. Customize the component as needed\n")
 
 	return nil
 }
@@ -212,10 +214,10 @@ func EjectTemplate(opts EjectTemplateOptions) error {
 		return fmt.Errorf("failed to copy template: %v", err)
 	}
 
-	fmt.Printf("✅ Ejected template to %s\n", destFile)
-	fmt.Println()
-	fmt.Println("The template will automatically override the library version.")
-	fmt.Println("The Go logic remains in the library and updates with 'go get -u'.")
+	logging.Printf("✅ Ejected template to %s\n", destFile)
+	logging.Println()
+	logging.Println("The template will automatically override the library version.")
+	logging.Println("The Go logic remains in the library and updates with 'go get -u'.")
 
 	return nil
 }
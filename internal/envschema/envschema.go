@@ -0,0 +1,257 @@
+// Package envschema reads the environment variables a generated app
+// declares in shared/config/config.go and checks values against them, for
+// `lvt env validate` and `lvt env diff`. The app's config package applies
+// the same rules when it starts.
+package envschema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// File is where generated apps declare their variables, relative to the
+// app root.
+const File = "shared/config/config.go"
+
+// Var is a declared environment variable.
+type Var struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Secret      bool     `json:"secret,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Load reads the schema of the app in dir. The error wraps os.ErrNotExist
+// when the app declares none, as apps generated before shared/config.
+func Load(dir string) ([]Var, error) {
+	path := filepath.Join(dir, File)
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, src)
+}
+
+// Parse reads the Schema literal of a config package source.
+func Parse(filename string, src []byte) ([]Var, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	var list *ast.CompositeLit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name == "Schema" && i < len(vs.Values) {
+					list, _ = vs.Values[i].(*ast.CompositeLit)
+				}
+			}
+		}
+	}
+	if list == nil {
+		return nil, fmt.Errorf("%s: no Schema list", filename)
+	}
+
+	var vars []Var
+	for _, elt := range list.Elts {
+		lit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			return nil, fmt.Errorf("%s: Schema entries must be Var literals", filename)
+		}
+		v := Var{Type: "string"}
+		for _, field := range lit.Elts {
+			kv, ok := field.(*ast.KeyValueExpr)
+			if !ok {
+				return nil, fmt.Errorf("%s: Schema entries must name their fields", filename)
+			}
+			key, _ := kv.Key.(*ast.Ident)
+			if key == nil {
+				continue
+			}
+			if err := setField(&v, key.Name, kv.Value); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", filename, key.Name, err)
+			}
+		}
+		if v.Name == "" {
+			return nil, fmt.Errorf("%s: Schema entry without a Name", filename)
+		}
+		vars = append(vars, v)
+	}
+	return vars, nil
+}
+
+func setField(v *Var, field string, expr ast.Expr) error {
+	switch field {
+	case "Name", "Default", "Description":
+		s, err := stringValue(expr)
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "Name":
+			v.Name = s
+		case "Default":
+			v.Default = s
+		default:
+			v.Description = s
+		}
+	case "Type":
+		ident, ok := expr.(*ast.Ident)
+		if !ok || !strings.HasPrefix(ident.Name, "Type") {
+			return fmt.Errorf("must be one of the Type constants")
+		}
+		v.Type = strings.ToLower(strings.TrimPrefix(ident.Name, "Type"))
+	case "Required", "Secret":
+		ident, ok := expr.(*ast.Ident)
+		if !ok || (ident.Name != "true" && ident.Name != "false") {
+			return fmt.Errorf("must be true or false")
+		}
+		if field == "Required" {
+			v.Required = ident.Name == "true"
+		} else {
+			v.Secret = ident.Name == "true"
+		}
+	case "Values":
+		lit, ok := expr.(*ast.CompositeLit)
+		if !ok {
+			return fmt.Errorf("must be a []string literal")
+		}
+		for _, elt := range lit.Elts {
+			s, err := stringValue(elt)
+			if err != nil {
+				return err
+			}
+			v.Values = append(v.Values, s)
+		}
+	}
+	return nil
+}
+
+func stringValue(expr ast.Expr) (string, error) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", fmt.Errorf("must be a string literal")
+	}
+	return strconv.Unquote(lit.Value)
+}
+
+// Check reports whether value is a valid value of v.
+func (v Var) Check(value string) error {
+	var err error
+	switch v.Type {
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "float":
+		_, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("not a valid %s", v.Type)
+	}
+	if len(v.Values) > 0 && !slices.Contains(v.Values, value) {
+		return fmt.Errorf("must be one of %s", strings.Join(v.Values, ", "))
+	}
+	return nil
+}
+
+// Statuses of an Entry.
+const (
+	StatusSet        = "set"        // set to a valid value
+	StatusDefault    = "default"    // unset; the default applies
+	StatusUnset      = "unset"      // unset and optional, without a default
+	StatusMissing    = "missing"    // required but unset
+	StatusInvalid    = "invalid"    // set to a value of the wrong type
+	StatusUndeclared = "undeclared" // set in .env but not declared
+)
+
+// Entry compares a variable's declaration with its values.
+type Entry struct {
+	Name     string `json:"name"`
+	Var      *Var   `json:"declared,omitempty"`
+	Status   string `json:"status"`
+	Source   string `json:"source,omitempty"` // env, secrets, .env or default
+	Value    string `json:"value,omitempty"`  // the value the app gets
+	EnvValue string `json:"env_value,omitempty"`
+	DotEnv   string `json:"dotenv_value,omitempty"`
+	// Overrides is set when the environment overrides a different .env value
+	Overrides bool   `json:"overrides_dotenv,omitempty"`
+	Problem   string `json:"problem,omitempty"`
+}
+
+// Failed reports whether the entry stops the app from starting.
+func (e Entry) Failed() bool {
+	return e.Status == StatusMissing || e.Status == StatusInvalid
+}
+
+// Compare checks the declared variables against the process environment
+// (env), the top-level encrypted secrets the app exports (secrets) and the
+// .env file (dotenv), in that order of precedence. Variables set in .env
+// without a declaration are listed after the declared ones.
+func Compare(vars []Var, env, secrets, dotenv map[string]string) []Entry {
+	var entries []Entry
+	declared := map[string]bool{}
+	for i := range vars {
+		v := &vars[i]
+		declared[v.Name] = true
+		e := Entry{Name: v.Name, Var: v, EnvValue: env[v.Name], DotEnv: dotenv[v.Name]}
+		switch {
+		case e.EnvValue != "":
+			e.Source, e.Value = "env", e.EnvValue
+			e.Overrides = e.DotEnv != "" && e.DotEnv != e.EnvValue
+		case secrets[v.Name] != "":
+			e.Source, e.Value = "secrets", secrets[v.Name]
+		case e.DotEnv != "":
+			e.Source, e.Value = ".env", e.DotEnv
+		}
+		switch {
+		case e.Value == "" && v.Required:
+			e.Status = StatusMissing
+			e.Problem = "required but not set"
+		case e.Value == "" && v.Default != "":
+			e.Status, e.Source, e.Value = StatusDefault, "default", v.Default
+		case e.Value == "":
+			e.Status = StatusUnset
+		default:
+			e.Status = StatusSet
+		}
+		if e.Value != "" {
+			if err := v.Check(e.Value); err != nil {
+				e.Status = StatusInvalid
+				e.Problem = err.Error()
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	var undeclared []string
+	for name := range dotenv {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		entries = append(entries, Entry{Name: name, Status: StatusUndeclared, Source: ".env", Value: dotenv[name], DotEnv: dotenv[name]})
+	}
+	return entries
+}
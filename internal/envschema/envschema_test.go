@@ -0,0 +1,98 @@
+package envschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The config packages apps are generated with must stay readable.
+func TestParseKitTemplates(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		path := filepath.Join("..", "kits", "system", kit, "templates", "app", "config.go.tmpl")
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vars, err := Parse(path, src)
+		if err != nil {
+			t.Fatalf("%s: %v", kit, err)
+		}
+		byName := map[string]Var{}
+		for _, v := range vars {
+			byName[v.Name] = v
+		}
+		port := byName["PORT"]
+		if port.Type != "int" || port.Default != "8080" {
+			t.Errorf("%s: PORT = %+v", kit, port)
+		}
+		if level := byName["LOG_LEVEL"]; len(level.Values) != 4 {
+			t.Errorf("%s: LOG_LEVEL values = %v", kit, level.Values)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	src := `package config
+
+var Schema = []Var{
+	{Name: "API_KEY", Required: true, Secret: true},
+	{Name: "TIMEOUT", Type: TypeDuration, Default: "5s"},
+}
+`
+	vars, err := Parse("config.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vars) != 2 || vars[0].Type != "string" || !vars[0].Required || !vars[0].Secret || vars[1].Type != "duration" {
+		t.Errorf("vars = %+v", vars)
+	}
+
+	if _, err := Parse("config.go", []byte("package config\n\nvar Schema = []Var{{Name: name}}\n")); err == nil {
+		t.Error("non-literal Name: want error")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	vars := []Var{
+		{Name: "API_KEY", Type: "string", Required: true},
+		{Name: "PORT", Type: "int", Default: "8080"},
+		{Name: "WORKERS", Type: "int"},
+		{Name: "DEBUG", Type: "bool"},
+		{Name: "MODE", Type: "string", Values: []string{"a", "b"}},
+		{Name: "TOKEN", Type: "string", Required: true},
+	}
+	env := map[string]string{"PORT": "9000", "MODE": "c"}
+	dotenv := map[string]string{"PORT": "3000", "DEBUG": "yes", "EXTRA": "1"}
+
+	want := []struct{ name, status, source, value string }{
+		{"API_KEY", StatusMissing, "", ""},
+		{"PORT", StatusSet, "env", "9000"},
+		{"WORKERS", StatusUnset, "", ""},
+		{"DEBUG", StatusInvalid, ".env", "yes"},
+		{"MODE", StatusInvalid, "env", "c"},
+		{"TOKEN", StatusSet, "secrets", "t0k"},
+		{"EXTRA", StatusUndeclared, ".env", "1"},
+	}
+	secrets := map[string]string{"TOKEN": "t0k", "PORT": "7000"}
+	entries := Compare(vars, env, secrets, dotenv)
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %+v", entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Name != w.name || e.Status != w.status || e.Source != w.source || e.Value != w.value {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+	if !entries[1].Overrides {
+		t.Error("PORT from env should override .env")
+	}
+	if !entries[0].Failed() || entries[1].Failed() {
+		t.Error("Failed: want only missing and invalid entries")
+	}
+
+	if e := Compare([]Var{{Name: "PORT", Type: "int", Default: "8080"}}, nil, nil, nil)[0]; e.Status != StatusDefault || e.Value != "8080" {
+		t.Errorf("default entry = %+v", e)
+	}
+}
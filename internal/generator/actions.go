@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceActions selects which CRUD actions a generated resource exposes.
+// Listing (with search, sort, and pagination) is always generated.
+type ResourceActions struct {
+	Show   bool // detail view ("view"/"back" actions, page-mode detail page)
+	Create bool // "add" action and add form
+	Edit   bool // "edit"/"update"/"cancel_edit" actions and edit form
+	Delete bool // "delete" action and delete buttons
+}
+
+// validActions lists the action names accepted by ParseActions, in display order.
+var validActions = []string{"list", "show", "create", "edit", "delete"}
+
+// AllActions returns the full CRUD action set (the default).
+func AllActions() ResourceActions {
+	return ResourceActions{Show: true, Create: true, Edit: true, Delete: true}
+}
+
+// ReadOnlyActions returns the action set used by --readonly: list and show.
+func ReadOnlyActions() ResourceActions {
+	return ResourceActions{Show: true}
+}
+
+// ParseActions parses a comma-separated action list such as "list,show".
+// "list" is implied and may be omitted.
+func ParseActions(spec string) (ResourceActions, error) {
+	var actions ResourceActions
+	seen := false
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		seen = true
+		switch name {
+		case "list":
+		case "show":
+			actions.Show = true
+		case "create":
+			actions.Create = true
+		case "edit":
+			actions.Edit = true
+		case "delete":
+			actions.Delete = true
+		default:
+			return ResourceActions{}, fmt.Errorf("invalid action: %s (valid: %s)", name, strings.Join(validActions, ", "))
+		}
+	}
+	if !seen {
+		return ResourceActions{}, fmt.Errorf("no actions specified (valid: %s)", strings.Join(validActions, ", "))
+	}
+	return actions, nil
+}
+
+// IsFull reports whether every CRUD action is enabled.
+func (a ResourceActions) IsFull() bool {
+	return a == AllActions()
+}
+
+// ReadOnly reports whether the resource has no mutating actions.
+func (a ResourceActions) ReadOnly() bool {
+	return !a.Create && !a.Edit && !a.Delete
+}
+
+// HasForm reports whether the resource renders an add or edit form.
+func (a ResourceActions) HasForm() bool {
+	return a.Create || a.Edit
+}
+
+// HasItemActions reports whether any action operates on a single item by ID.
+func (a ResourceActions) HasItemActions() bool {
+	return a.Show || a.Edit || a.Delete
+}
+
+// String returns the comma-separated action list, e.g. "list,show".
+func (a ResourceActions) String() string {
+	names := []string{"list"}
+	if a.Show {
+		names = append(names, "show")
+	}
+	if a.Create {
+		names = append(names, "create")
+	}
+	if a.Edit {
+		names = append(names, "edit")
+	}
+	if a.Delete {
+		names = append(names, "delete")
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,54 @@
+package generator
+
+import "testing"
+
+func TestParseActions(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    ResourceActions
+		wantErr bool
+	}{
+		{spec: "list,show", want: ReadOnlyActions()},
+		{spec: "show", want: ReadOnlyActions()},
+		{spec: "list,show,create,edit,delete", want: AllActions()},
+		{spec: " List , Create ", want: ResourceActions{Create: true}},
+		{spec: "list", want: ResourceActions{}},
+		{spec: "list,update", wantErr: true},
+		{spec: "", wantErr: true},
+		{spec: ",", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseActions(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseActions(%q) expected error, got %+v", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseActions(%q) unexpected error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseActions(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceActionsString(t *testing.T) {
+	if got := AllActions().String(); got != "list,show,create,edit,delete" {
+		t.Errorf("AllActions().String() = %q", got)
+	}
+	if got := ReadOnlyActions().String(); got != "list,show" {
+		t.Errorf("ReadOnlyActions().String() = %q", got)
+	}
+
+	// String output must round-trip through ParseActions.
+	a := ResourceActions{Show: true, Delete: true}
+	parsed, err := ParseActions(a.String())
+	if err != nil || parsed != a {
+		t.Errorf("round trip of %q = %+v, %v", a.String(), parsed, err)
+	}
+}
@@ -0,0 +1,975 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+	"unicode"
+)
+
+// AdoptApp is what `lvt adopt` found in an existing net/http + html/template
+// app: the pages it can scaffold LiveTemplate versions of, and everything it
+// leaves alone.
+type AdoptApp struct {
+	Root       string
+	ModulePath string
+	OutDir     string // where the live pages go, relative to Root
+
+	Pages           []*AdoptPage
+	Routes          []AdoptRoute // routes that render no template
+	UnusedTemplates []string     // parsed templates no route renders
+
+	// Mux is where most pages are registered; live.Register is wired in
+	// after the last of those registrations. Nil when registrations are
+	// not plain statements lvt can add to.
+	Mux *AdoptMux
+
+	// Filled in by Adopt
+	Wired   string // file:line live.Register was added at
+	DepsErr string // why `go get` of livetemplate failed, if it did
+}
+
+// AdoptRoute is a route registration: mux.HandleFunc(pattern, handler).
+type AdoptRoute struct {
+	Pattern string // as registered, e.g. "/todos" or "GET /todos/{id}"
+	Handler string // handler func, method or "func literal"
+	Pos     string // file:line of the registration
+}
+
+// AdoptPage is a route whose handler renders an html/template.
+type AdoptPage struct {
+	AdoptRoute
+
+	Name     string // package name of the live page
+	LivePath string // route of the live page, under /live
+
+	Template     string   // template file the handler renders, relative to Root
+	TemplateName string   // {{define}} name executed, when not the file itself
+	Includes     []string // other files defining templates it calls
+	UsesFuncs    bool     // the template set registers a FuncMap
+
+	DataType string // type of the data the handler passes, when known
+	Fields   []AdoptField
+	Types    []string // local types the fields use, copied into the page
+	Methods  []string // copied types that have methods, which were not copied
+	Forms    []AdoptForm
+
+	Exists bool // the live page was already there and was left untouched
+}
+
+// AdoptField is a field the template reads from its data.
+type AdoptField struct {
+	Name     string
+	Type     string
+	JSONName string
+	Untyped  bool // the data type is unknown, so the field is `any`
+}
+
+// AdoptForm is a <form> in a page's template.
+type AdoptForm struct {
+	Method string
+	Action string
+}
+
+// AdoptMux is the mux expression pages are registered on and where to add
+// the live routes.
+type AdoptMux struct {
+	Expr   string // e.g. "mux", or "http.DefaultServeMux" for http.HandleFunc
+	File   string
+	Offset int // byte offset just past the last registration statement
+	Indent string
+}
+
+// adoptTemplate is one parsed template file.
+type adoptTemplate struct {
+	path  string // relative to Root
+	trees map[string]*parse.Tree
+}
+
+// adoptPkg is one directory of Go files.
+type adoptPkg struct {
+	dir       string
+	files     []*ast.File
+	funcs     map[string]*ast.FuncDecl
+	types     map[string]*ast.TypeSpec
+	tmplVars  map[string][]string // variable or field name -> template files
+	funcsUsed map[string]bool     // template variables whose set has a FuncMap
+}
+
+// AnalyzeApp inspects the Go app at root for html/template pages and the
+// routes that serve them. outDir is skipped, so re-running adopt does not
+// find the pages it scaffolded.
+func AnalyzeApp(root, modulePath, outDir string) (*AdoptApp, error) {
+	app := &AdoptApp{Root: root, ModulePath: modulePath, OutDir: outDir}
+	fset := token.NewFileSet()
+	pkgs := map[string]*adoptPkg{}
+	var dirs []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				name == "vendor" || name == "testdata" || name == "node_modules" ||
+				path == filepath.Join(root, outDir)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", relPath(root, path), err)
+		}
+		dir := filepath.Dir(path)
+		pkg := pkgs[dir]
+		if pkg == nil {
+			pkg = &adoptPkg{dir: dir, funcs: map[string]*ast.FuncDecl{}, types: map[string]*ast.TypeSpec{},
+				tmplVars: map[string][]string{}, funcsUsed: map[string]bool{}}
+			pkgs[dir] = pkg
+			dirs = append(dirs, dir)
+		}
+		pkg.files = append(pkg.files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no Go files found in %s", root)
+	}
+	sort.Strings(dirs)
+
+	// Templates parsed anywhere, by file
+	templates := map[string]*adoptTemplate{}
+	for _, dir := range dirs {
+		pkg := pkgs[dir]
+		pkg.index()
+		for _, f := range pkg.files {
+			pkg.findTemplateVars(root, f)
+		}
+		for _, files := range pkg.tmplVars {
+			for _, file := range files {
+				if templates[file] == nil {
+					if t, err := parseTemplateFile(root, file); err == nil {
+						templates[file] = t
+					}
+				}
+			}
+		}
+	}
+
+	rendered := map[string]bool{}
+	names := map[string]bool{}
+	muxCount := map[string]int{}
+	for _, dir := range dirs {
+		pkg := pkgs[dir]
+		for _, f := range pkg.files {
+			httpName := importName(f, "net/http", "http")
+			ast.Inspect(f, func(n ast.Node) bool {
+				block, ok := n.(*ast.BlockStmt)
+				if !ok {
+					return true
+				}
+				for _, stmt := range block.List {
+					expr, ok := stmt.(*ast.ExprStmt)
+					if !ok {
+						continue
+					}
+					call, pattern, ok := routeRegistration(expr.X)
+					if !ok {
+						continue
+					}
+					pos := fset.Position(call.Pos())
+					route := AdoptRoute{
+						Pattern: pattern,
+						Pos:     fmt.Sprintf("%s:%d", relPath(root, pos.Filename), pos.Line),
+					}
+					name, body := pkg.resolveHandler(call.Args[1], pkgs)
+					route.Handler = name
+
+					muxExpr := types.ExprString(call.Fun.(*ast.SelectorExpr).X)
+					if muxExpr == httpName {
+						muxExpr = httpName + ".DefaultServeMux"
+					}
+
+					page := pkg.findRender(body, templates)
+					if page == nil {
+						app.Routes = append(app.Routes, route)
+						continue
+					}
+					page.AdoptRoute = route
+					page.Name = uniqueName(pageName(pattern), names)
+					page.LivePath = livePath(pattern)
+					page.finish(root, pkg, templates, fset)
+					rendered[page.Template] = true
+					for _, inc := range page.Includes {
+						rendered[inc] = true
+					}
+					app.Pages = append(app.Pages, page)
+
+					// Wire live.Register after the last registration on the
+					// mux most pages use
+					muxCount[muxExpr]++
+					if app.Mux == nil || muxCount[muxExpr] > muxCount[app.Mux.Expr] || app.Mux.Expr == muxExpr {
+						start := fset.Position(stmt.Pos())
+						app.Mux = &AdoptMux{
+							Expr:   muxExpr,
+							File:   pos.Filename,
+							Offset: fset.Position(stmt.End()).Offset,
+							Indent: lineIndent(pos.Filename, start.Offset),
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	for file := range templates {
+		if !rendered[file] {
+			app.UnusedTemplates = append(app.UnusedTemplates, file)
+		}
+	}
+	sort.Strings(app.UnusedTemplates)
+	return app, nil
+}
+
+// index records the package's functions, methods and types by name.
+func (p *adoptPkg) index() {
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if _, ok := p.funcs[d.Name.Name]; !ok && d.Body != nil {
+					p.funcs[d.Name.Name] = d
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						p.types[ts.Name.Name] = ts
+					}
+				}
+			}
+		}
+	}
+}
+
+// findTemplateVars records the variables (or struct fields) html/template
+// sets are assigned to, with the files they parse.
+func (p *adoptPkg) findTemplateVars(root string, f *ast.File) {
+	tmplName := importName(f, "html/template", "")
+	if tmplName == "" {
+		return
+	}
+	record := func(lhs ast.Expr, rhs ast.Expr) {
+		key := exprKey(lhs)
+		if key == "" {
+			return
+		}
+		files, funcs, ok := p.parsedFiles(root, tmplName, rhs)
+		if !ok {
+			return
+		}
+		p.tmplVars[key] = append(p.tmplVars[key], files...)
+		if funcs {
+			p.funcsUsed[key] = true
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if i < len(n.Values) {
+					record(name, n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if i < len(n.Rhs) {
+					record(lhs, n.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+}
+
+// parsedFiles returns the template files an html/template expression parses
+// and whether it registers a FuncMap.
+func (p *adoptPkg) parsedFiles(root, tmplName string, expr ast.Expr) ([]string, bool, bool) {
+	var files []string
+	funcs, found := false, false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !rootedAt(sel.X, tmplName) {
+			return true
+		}
+		args := call.Args
+		switch sel.Sel.Name {
+		case "Funcs":
+			funcs = true
+			return true
+		case "ParseFiles", "ParseGlob":
+		case "ParseFS":
+			if len(args) == 0 {
+				return true
+			}
+			args = args[1:]
+		default:
+			return true
+		}
+		found = true
+		for _, arg := range args {
+			lit, ok := arg.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			pattern, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			// ParseFS patterns are relative to the embedding package;
+			// ParseFiles and ParseGlob to the working directory, which is
+			// the project root for `go run .`
+			bases := []string{root, p.dir}
+			if sel.Sel.Name == "ParseFS" {
+				bases = []string{p.dir}
+			}
+			for _, base := range bases {
+				matches, _ := filepath.Glob(filepath.Join(base, pattern))
+				if len(matches) > 0 {
+					for _, m := range matches {
+						files = append(files, relPath(root, m))
+					}
+					break
+				}
+			}
+		}
+		return true
+	})
+	return files, funcs, found
+}
+
+// resolveHandler returns a name for a handler expression and the function
+// body that serves it, following http.HandlerFunc conversions and handler
+// constructors.
+func (p *adoptPkg) resolveHandler(expr ast.Expr, pkgs map[string]*adoptPkg) (string, ast.Node) {
+	lookup := func(name string) *ast.FuncDecl {
+		if fn := p.funcs[name]; fn != nil {
+			return fn
+		}
+		for _, other := range pkgs {
+			if fn := other.funcs[name]; fn != nil {
+				return fn
+			}
+		}
+		return nil
+	}
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return "func literal", e.Body
+	case *ast.Ident:
+		if fn := lookup(e.Name); fn != nil {
+			return e.Name, fn.Body
+		}
+		return e.Name, nil
+	case *ast.SelectorExpr:
+		name := types.ExprString(e)
+		if fn := lookup(e.Sel.Name); fn != nil {
+			return name, fn.Body
+		}
+		return name, nil
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "HandlerFunc" && len(e.Args) == 1 {
+			return p.resolveHandler(e.Args[0], pkgs)
+		}
+		// A constructor such as todosHandler(db): the handler is what it returns
+		name, body := p.resolveHandler(e.Fun, pkgs)
+		return name + "(...)", body
+	}
+	return types.ExprString(expr), nil
+}
+
+// findRender looks in a handler body for the template it renders: a known
+// set's Execute, ExecuteTemplate with a literal name, or any call (such as a
+// render helper) passing a known template's name.
+func (p *adoptPkg) findRender(body ast.Node, templates map[string]*adoptTemplate) *AdoptPage {
+	if body == nil {
+		return nil
+	}
+	var page *AdoptPage
+	ast.Inspect(body, func(n ast.Node) bool {
+		if page != nil {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Execute" && len(call.Args) == 2 {
+			key := exprKey(sel.X)
+			if files := p.tmplVars[key]; len(files) > 0 {
+				page = &AdoptPage{Template: files[0], UsesFuncs: p.funcsUsed[key]}
+				page.DataType = dataType(call.Args[1], body)
+				return false
+			}
+		}
+		for i, arg := range call.Args {
+			lit, ok := arg.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			name, _ := strconv.Unquote(lit.Value)
+			file, define := findTemplate(name, templates)
+			if file == "" {
+				continue
+			}
+			page = &AdoptPage{Template: file, TemplateName: define}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+				page.UsesFuncs = p.funcsUsed[exprKey(sel.X)]
+			}
+			if i < len(call.Args)-1 {
+				page.DataType = dataType(call.Args[len(call.Args)-1], body)
+			}
+			return false
+		}
+		return true
+	})
+	return page
+}
+
+// finish fills in what the page's template needs: the files it includes, the
+// fields it reads with their types, and its forms.
+func (page *AdoptPage) finish(root string, pkg *adoptPkg, templates map[string]*adoptTemplate, fset *token.FileSet) {
+	entry := templates[page.Template]
+	if entry == nil {
+		return
+	}
+	if !page.UsesFuncs {
+		for _, funcs := range pkg.funcsUsed {
+			page.UsesFuncs = page.UsesFuncs || funcs
+		}
+	}
+
+	// Follow {{template}} calls to the files that define them
+	start := entry.trees[filepath.Base(page.Template)]
+	if page.TemplateName != "" {
+		start = entry.trees[page.TemplateName]
+	}
+	seen := map[string]bool{page.Template: true}
+	used := map[string]bool{}
+	nested := map[string]bool{}
+	var fieldOrder []string
+	var walk func(tree *parse.Tree)
+	visited := map[*parse.Tree]bool{}
+	walk = func(tree *parse.Tree) {
+		if tree == nil || visited[tree] {
+			return
+		}
+		visited[tree] = true
+		collectTemplateFields(tree.Root, true, func(field string, sub bool) {
+			if !used[field] {
+				used[field] = true
+				fieldOrder = append(fieldOrder, field)
+			}
+			nested[field] = nested[field] || sub
+		}, func(name string) {
+			for _, file := range sortedTemplateFiles(templates) {
+				if t := templates[file].trees[name]; t != nil {
+					if !seen[file] {
+						seen[file] = true
+						page.Includes = append(page.Includes, file)
+					}
+					walk(t)
+					return
+				}
+			}
+		})
+	}
+	walk(start)
+
+	// Type the fields from the data's struct where lvt can copy the types
+	var structFields map[string]ast.Expr
+	if ts := pkg.types[strings.TrimPrefix(page.DataType, "*")]; ts != nil {
+		if st, ok := ts.Type.(*ast.StructType); ok {
+			structFields = map[string]ast.Expr{}
+			for _, field := range st.Fields.List {
+				for _, name := range field.Names {
+					structFields[name.Name] = field.Type
+				}
+			}
+		}
+	}
+	copied := map[string]bool{}
+	for _, name := range fieldOrder {
+		if !token.IsExported(name) {
+			continue
+		}
+		// A nil map renders .Field.Sub as empty where a nil any fails
+		field := AdoptField{Name: name, Type: "any", JSONName: jsonName(name), Untyped: true}
+		if nested[name] {
+			field.Type = "map[string]any"
+		}
+		if expr, ok := structFields[name]; ok {
+			need := map[string]bool{}
+			if pkg.portable(expr, need) {
+				field.Type, field.Untyped = types.ExprString(expr), false
+				for typeName := range need {
+					copied[typeName] = true
+				}
+			}
+		}
+		page.Fields = append(page.Fields, field)
+	}
+	typeNames := make([]string, 0, len(copied))
+	for name := range copied {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		var buf bytes.Buffer
+		decl := &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{pkg.types[name]}}
+		if err := printer.Fprint(&buf, fset, decl); err == nil {
+			page.Types = append(page.Types, buf.String())
+		}
+		if pkg.hasMethods(name) {
+			page.Methods = append(page.Methods, name)
+		}
+	}
+
+	for _, file := range append([]string{page.Template}, page.Includes...) {
+		content, err := os.ReadFile(filepath.Join(root, file))
+		if err != nil {
+			continue
+		}
+		page.Forms = append(page.Forms, findForms(string(content))...)
+	}
+}
+
+// hasMethods reports whether the named type has methods in the package.
+func (p *adoptPkg) hasMethods(typeName string) bool {
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if id, ok := recv.(*ast.Ident); ok && id.Name == typeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// portable reports whether a field type can be copied into the live page:
+// built-in types, time.Time and time.Duration, and local types built from
+// them, which it adds to need.
+func (p *adoptPkg) portable(expr ast.Expr, need map[string]bool) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(e.Name) != nil {
+			return e.Name != "error"
+		}
+		ts := p.types[e.Name]
+		if ts == nil {
+			return false
+		}
+		if need[e.Name] {
+			return true
+		}
+		need[e.Name] = true
+		if !p.portable(ts.Type, need) {
+			delete(need, e.Name)
+			return false
+		}
+		return true
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		return ok && x.Name == "time" && (e.Sel.Name == "Time" || e.Sel.Name == "Duration")
+	case *ast.StarExpr:
+		return p.portable(e.X, need)
+	case *ast.ArrayType:
+		return p.portable(e.Elt, need)
+	case *ast.MapType:
+		return p.portable(e.Key, need) && p.portable(e.Value, need)
+	case *ast.InterfaceType:
+		return len(e.Methods.List) == 0
+	case *ast.StructType:
+		for _, field := range e.Fields.List {
+			if !p.portable(field.Type, need) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// collectTemplateFields walks a template, reporting the top-level data
+// fields it reads (and whether it reads into them, as in .Field.Sub) and the
+// templates it calls with the same data. Inside range and with, dot is
+// something else, so only $.Field counts there.
+func collectTemplateFields(node parse.Node, dotIsData bool, field func(name string, sub bool), call func(string)) {
+	if node == nil {
+		return
+	}
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateFields(child, dotIsData, field, call)
+		}
+	case *parse.ActionNode:
+		collectTemplateFields(n.Pipe, dotIsData, field, call)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateFields(cmd, dotIsData, field, call)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateFields(arg, dotIsData, field, call)
+		}
+	case *parse.FieldNode:
+		if dotIsData && len(n.Ident) > 0 {
+			field(n.Ident[0], len(n.Ident) > 1)
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			field(n.Ident[1], len(n.Ident) > 2)
+		}
+	case *parse.ChainNode:
+		collectTemplateFields(n.Node, dotIsData, field, call)
+	case *parse.IfNode:
+		collectTemplateFields(n.Pipe, dotIsData, field, call)
+		collectTemplateFields(n.List, dotIsData, field, call)
+		collectTemplateFields(n.ElseList, dotIsData, field, call)
+	case *parse.RangeNode:
+		collectTemplateFields(n.Pipe, dotIsData, field, call)
+		collectTemplateFields(n.List, false, field, call)
+		collectTemplateFields(n.ElseList, dotIsData, field, call)
+	case *parse.WithNode:
+		collectTemplateFields(n.Pipe, dotIsData, field, call)
+		collectTemplateFields(n.List, false, field, call)
+		collectTemplateFields(n.ElseList, dotIsData, field, call)
+	case *parse.TemplateNode:
+		collectTemplateFields(n.Pipe, dotIsData, field, call)
+		// Only a call passing the page's data reads its fields
+		if dotIsData && n.Pipe != nil && len(n.Pipe.Cmds) == 1 && len(n.Pipe.Cmds[0].Args) == 1 {
+			if _, ok := n.Pipe.Cmds[0].Args[0].(*parse.DotNode); ok {
+				call(n.Name)
+			}
+		}
+	}
+}
+
+// parseTemplateFile parses a template file without checking function names,
+// since the app's FuncMap is not available.
+func parseTemplateFile(root, file string) (*adoptTemplate, error) {
+	content, err := os.ReadFile(filepath.Join(root, file))
+	if err != nil {
+		return nil, err
+	}
+	trees := map[string]*parse.Tree{}
+	t := parse.New(filepath.Base(file))
+	t.Mode = parse.SkipFuncCheck
+	if _, err := t.Parse(string(content), "", "", trees); err != nil {
+		return nil, err
+	}
+	return &adoptTemplate{path: file, trees: trees}, nil
+}
+
+// findTemplate finds a template by the name a handler executes: a file's
+// base name, or a {{define}} in one of the files. define is set in the
+// second case.
+func findTemplate(name string, templates map[string]*adoptTemplate) (file, define string) {
+	if name == "" {
+		return "", ""
+	}
+	files := sortedTemplateFiles(templates)
+	for _, f := range files {
+		if filepath.Base(f) == name {
+			return f, ""
+		}
+	}
+	for _, f := range files {
+		if templates[f].trees[name] != nil {
+			return f, name
+		}
+	}
+	return "", ""
+}
+
+func sortedTemplateFiles(templates map[string]*adoptTemplate) []string {
+	files := make([]string, 0, len(templates))
+	for f := range templates {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+var (
+	formTagPattern    = regexp.MustCompile(`(?is)<form\b([^>]*)>`)
+	formActionPattern = regexp.MustCompile(`(?is)\baction\s*=\s*["']([^"']*)["']`)
+	formMethodPattern = regexp.MustCompile(`(?is)\bmethod\s*=\s*["']?([a-z]+)`)
+)
+
+func findForms(content string) []AdoptForm {
+	var forms []AdoptForm
+	for _, m := range formTagPattern.FindAllStringSubmatch(content, -1) {
+		form := AdoptForm{Method: "GET"}
+		if a := formActionPattern.FindStringSubmatch(m[1]); a != nil {
+			form.Action = a[1]
+		}
+		if meth := formMethodPattern.FindStringSubmatch(m[1]); meth != nil {
+			form.Method = strings.ToUpper(meth[1])
+		}
+		forms = append(forms, form)
+	}
+	return forms
+}
+
+// routeRegistration matches x.HandleFunc("pattern", h) and
+// x.Handle("pattern", h).
+func routeRegistration(expr ast.Expr) (*ast.CallExpr, string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return nil, "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle") {
+		return nil, "", false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, "", false
+	}
+	pattern, err := strconv.Unquote(lit.Value)
+	if err != nil || pattern == "" {
+		return nil, "", false
+	}
+	return call, pattern, true
+}
+
+// dataType names the type of a template's data argument: a composite
+// literal's type, or the type of a local variable initialized with one.
+func dataType(expr ast.Expr, body ast.Node) string {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return types.ExprString(e.Type)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return dataType(e.X, body)
+		}
+	case *ast.Ident:
+		var found string
+		ast.Inspect(body, func(n ast.Node) bool {
+			if found != "" {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && id.Name == e.Name && i < len(n.Rhs) {
+						found = dataType(n.Rhs[i], nil)
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if name.Name != e.Name {
+						continue
+					}
+					if n.Type != nil {
+						found = types.ExprString(n.Type)
+					} else if i < len(n.Values) {
+						found = dataType(n.Values[i], nil)
+					}
+				}
+			}
+			return true
+		})
+		return found
+	}
+	return ""
+}
+
+// importName returns the name a file refers to an import by, or def when
+// it is imported under its default name; "" if it is not imported.
+func importName(f *ast.File, path, def string) string {
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p == path {
+			if imp.Name != nil {
+				return imp.Name.Name
+			}
+			if def == "" {
+				return filepath.Base(path)
+			}
+			return def
+		}
+	}
+	return ""
+}
+
+// rootedAt reports whether a selector chain such as template.New("").Funcs(m)
+// starts at the identifier name.
+func rootedAt(expr ast.Expr, name string) bool {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e.Name == name
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.CallExpr:
+			if sel, ok := e.Fun.(*ast.SelectorExpr); ok {
+				expr = sel.X
+			} else {
+				expr = e.Fun
+			}
+		default:
+			return false
+		}
+	}
+}
+
+// exprKey names a template variable the same way whether it is assigned or
+// used: tmpl and s.tmpl are both "tmpl".
+func exprKey(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.Name == "_" {
+			return ""
+		}
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return exprKey(e.X)
+	}
+	return ""
+}
+
+// pageName derives a Go package name from a route pattern:
+// "GET /admin/users/{id}" is "adminusers", "/" is "home".
+func pageName(pattern string) string {
+	path := routePath(pattern)
+	var b strings.Builder
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, "{") {
+			continue
+		}
+		for _, r := range strings.ToLower(seg) {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				b.WriteRune(r)
+			}
+		}
+	}
+	name := b.String()
+	switch {
+	case name == "":
+		return "home"
+	case unicode.IsDigit(rune(name[0])):
+		return "page" + name
+	case token.Lookup(name).IsKeyword():
+		return name + "page"
+	}
+	return name
+}
+
+// jsonName converts a Go field name to the snake_case JSON names generated
+// state uses: LastUpdated is "last_updated", UserID "user_id".
+func jsonName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// routePath strips the method and host from a Go 1.22 route pattern.
+func routePath(pattern string) string {
+	if _, rest, ok := strings.Cut(pattern, " "); ok {
+		pattern = strings.TrimSpace(rest)
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
+
+// livePath is where the live version of a route is served: under /live,
+// for every method, since LiveTemplate pages also take POSTs and
+// WebSocket upgrades.
+func livePath(pattern string) string {
+	path := routePath(pattern)
+	if path == "/" {
+		return "/live/"
+	}
+	return "/live" + path
+}
+
+func lineIndent(file string, offset int) string {
+	content, err := os.ReadFile(file)
+	if err != nil || offset > len(content) {
+		return "\t"
+	}
+	start := bytes.LastIndexByte(content[:offset], '\n') + 1
+	return string(content[start:offset])
+}
+
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,400 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// liveClientScript loads the LiveTemplate client; adopted templates get it
+// before </body>.
+const liveClientScript = `<script src="https://unpkg.com/@livetemplate/client@latest/dist/livetemplate-client.browser.js"></script>`
+
+// AdoptChecklistFile is the report Adopt writes into the live directory.
+const AdoptChecklistFile = "ADOPTION.md"
+
+type adoptPageData struct {
+	PackageName  string
+	TemplateName string // livetemplate name, distinct from the {{define}}s
+	StateName    string
+	TemplatePath string // relative to the project root, which the app runs from
+	DevMode      bool
+	Imports      []string
+	Page         *AdoptPage
+}
+
+type adoptRoutesData struct {
+	PackageName string
+	ImportPath  string
+	Pages       []*AdoptPage
+}
+
+// Adopt scaffolds a LiveTemplate page for each page AnalyzeApp found, plus
+// a Register func mounting them under /live, wires Register into the app
+// after its own routes, and writes the checklist report. Pages that already
+// exist are left as they are.
+func Adopt(app *AdoptApp, kitName string) error {
+	if len(app.Pages) == 0 {
+		return fmt.Errorf("no pages to adopt: found no routes whose handlers render an html/template")
+	}
+	kitLoader := kits.DefaultLoader()
+	pageTmpl, err := kitLoader.LoadKitTemplate(kitName, "adopt/page.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read page template: %w", err)
+	}
+	routesTmpl, err := kitLoader.LoadKitTemplate(kitName, "adopt/routes.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read routes template: %w", err)
+	}
+
+	outDir := filepath.Join(app.Root, app.OutDir)
+	devMode := ReadDevMode(app.Root)
+	for _, page := range app.Pages {
+		pageDir := filepath.Join(outDir, page.Name)
+		if _, err := os.Stat(pageDir); err == nil {
+			page.Exists = true
+			continue
+		}
+		if err := os.MkdirAll(pageDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", pageDir, err)
+		}
+
+		tmplPath := filepath.Join(pageDir, page.Name+".tmpl")
+		content, err := liveTemplateSource(app.Root, page)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(tmplPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", tmplPath, err)
+		}
+
+		data := adoptPageData{
+			PackageName:  page.Name,
+			TemplateName: page.Name,
+			StateName:    toCamelCase(page.Name),
+			TemplatePath: path.Join(filepath.ToSlash(app.OutDir), page.Name, page.Name+".tmpl"),
+			DevMode:      devMode,
+			Page:         page,
+		}
+		// The top-level template takes the livetemplate name, so it must
+		// not redefine one of the page's templates
+		if regexp.MustCompile(`\{\{-?\s*(define|block)\s+"` + regexp.QuoteMeta(page.Name) + `"`).MatchString(content) {
+			data.TemplateName = page.Name + "_page"
+		}
+		if usesTime(page) {
+			data.Imports = append(data.Imports, "time")
+		}
+		goPath := filepath.Join(pageDir, page.Name+".go")
+		if err := generateFile(string(pageTmpl), data, goPath, nil); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", goPath, err)
+		}
+		if err := formatGoFile(goPath); err != nil {
+			return err
+		}
+	}
+
+	routesPath := filepath.Join(outDir, "routes.go")
+	routesData := adoptRoutesData{
+		PackageName: livePackageName(app.OutDir),
+		ImportPath:  app.ModulePath + "/" + filepath.ToSlash(app.OutDir),
+		Pages:       app.Pages,
+	}
+	if err := generateFile(string(routesTmpl), routesData, routesPath, nil); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", routesPath, err)
+	}
+	if err := formatGoFile(routesPath); err != nil {
+		return err
+	}
+
+	if !hasRequire(app.Root, "github.com/livetemplate/livetemplate") {
+		if output, err := runTool(app.Root, "go", "get", "github.com/livetemplate/livetemplate@latest"); err != nil {
+			app.DepsErr = strings.TrimSpace(string(output))
+		}
+	}
+
+	if app.Mux != nil {
+		wired, err := wireLiveRoutes(app)
+		if err != nil {
+			logging.Warn("could not add live.Register", "file", relPath(app.Root, app.Mux.File), "error", err)
+		}
+		app.Wired = wired
+	}
+
+	checklist := filepath.Join(outDir, AdoptChecklistFile)
+	if err := os.WriteFile(checklist, []byte(app.Checklist()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", checklist, err)
+	}
+	return nil
+}
+
+// liveTemplateSource is the page's template with the files its
+// {{template}} calls need appended, so it parses on its own, and the
+// LiveTemplate client loaded before the page's </body>.
+func liveTemplateSource(root string, page *AdoptPage) (string, error) {
+	files := append([]string{page.Template}, page.Includes...)
+	parts := make([]string, len(files))
+	hasClient := false
+	for i, file := range files {
+		content, err := os.ReadFile(filepath.Join(root, file))
+		if err != nil {
+			return "", fmt.Errorf("failed to read template %s: %w", file, err)
+		}
+		parts[i] = string(content)
+		hasClient = hasClient || strings.Contains(parts[i], "livetemplate-client")
+	}
+
+	// The page's own </body> first, then a layout's
+	added := hasClient
+	for i, part := range parts {
+		if added {
+			break
+		}
+		if at := strings.LastIndex(strings.ToLower(part), "</body>"); at >= 0 {
+			parts[i] = part[:at] + "  " + liveClientScript + "\n" + part[at:]
+			added = true
+		}
+	}
+
+	var b strings.Builder
+	if page.TemplateName != "" {
+		fmt.Fprintf(&b, "{{template %q .}}\n", page.TemplateName)
+	}
+	for i, part := range parts {
+		if i > 0 {
+			fmt.Fprintf(&b, "\n{{/* from %s */}}\n", files[i])
+		}
+		b.WriteString(part)
+	}
+	if !added {
+		b.WriteString("\n" + liveClientScript + "\n")
+	}
+	return b.String(), nil
+}
+
+// wireLiveRoutes adds live.Register(mux) after the app's last page
+// registration and imports the live package. It returns where the call is,
+// leaving the file alone when it already makes it.
+func wireLiveRoutes(app *AdoptApp) (string, error) {
+	mux := app.Mux
+	content, err := os.ReadFile(mux.File)
+	if err != nil {
+		return "", err
+	}
+	pkgName := livePackageName(app.OutDir)
+	call := fmt.Sprintf("%s.Register(%s)", pkgName, mux.Expr)
+	if i := strings.Index(string(content), call); i >= 0 {
+		return fmt.Sprintf("%s:%d", relPath(app.Root, mux.File), strings.Count(string(content[:i]), "\n")+1), nil
+	}
+	if mux.Offset > len(content) {
+		return "", fmt.Errorf("file changed since it was analyzed")
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, mux.File, content, parser.ImportsOnly)
+	if err != nil {
+		return "", err
+	}
+	var decl *ast.GenDecl
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			decl = gd
+		}
+	}
+	if decl == nil {
+		return "", fmt.Errorf("no imports to add %s to", pkgName)
+	}
+	importPath := strconv.Quote(app.ModulePath + "/" + filepath.ToSlash(app.OutDir))
+
+	// Edit back to front so the import offsets stay valid
+	src := string(content[:mux.Offset]) + "\n" + mux.Indent + call + string(content[mux.Offset:])
+	if decl.Lparen.IsValid() {
+		at := fset.Position(decl.Rparen).Offset
+		src = src[:at] + "\t" + importPath + "\n" + src[at:]
+	} else {
+		// A lone `import "net/http"` needs parentheses for a second path
+		start, end := fset.Position(decl.Pos()).Offset, fset.Position(decl.End()).Offset
+		spec := strings.TrimSpace(strings.TrimPrefix(src[start:end], "import"))
+		src = src[:start] + "import (\n\t" + spec + "\n\t" + importPath + "\n)" + src[end:]
+	}
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return "", fmt.Errorf("failed to format %s: %w", mux.File, err)
+	}
+	if err := os.WriteFile(mux.File, formatted, 0644); err != nil {
+		return "", err
+	}
+	line := strings.Count(string(formatted[:strings.Index(string(formatted), call)]), "\n") + 1
+	return fmt.Sprintf("%s:%d", relPath(app.Root, mux.File), line), nil
+}
+
+// Checklist is the adoption report: what was scaffolded and what is left
+// to port by hand, page by page.
+func (app *AdoptApp) Checklist() string {
+	var b strings.Builder
+	pkgName := livePackageName(app.OutDir)
+	b.WriteString("# Adopting LiveTemplate\n\n")
+	fmt.Fprintf(&b, "`lvt adopt` found %d page(s) rendered with html/template and scaffolded a LiveTemplate version of each in `%s/`, served under `/live/`. ", len(app.Pages), filepath.ToSlash(app.OutDir))
+	b.WriteString("The original handlers and templates are unchanged: compare each live page with the original, then switch the route over.\n\n")
+
+	b.WriteString("## Setup\n\n")
+	switch {
+	case app.Wired != "":
+		fmt.Fprintf(&b, "- [x] `%s.Register(%s)` mounts the live pages (%s)\n", pkgName, app.Mux.Expr, app.Wired)
+	default:
+		fmt.Fprintf(&b, "- [ ] Mount the live pages: call `%s.Register(mux)` where the app registers its routes\n", pkgName)
+	}
+	if app.DepsErr != "" {
+		b.WriteString("- [ ] Add the dependency: `go get github.com/livetemplate/livetemplate` (it failed during adopt)\n")
+	}
+	fmt.Fprintf(&b, "- [ ] Run the app from the project root, where `%s/*/*.tmpl` resolve\n", filepath.ToSlash(app.OutDir))
+	b.WriteString("- [ ] `go build ./...` passes\n\n")
+
+	b.WriteString("## Pages\n")
+	for _, page := range app.Pages {
+		fmt.Fprintf(&b, "\n### `%s` → `%s`\n\n", page.Pattern, page.LivePath)
+		if page.Exists {
+			fmt.Fprintf(&b, "`%s/%s` already existed and was left as it is.\n\n", filepath.ToSlash(app.OutDir), page.Name)
+		}
+		fmt.Fprintf(&b, "`%s` (%s) renders `%s`", page.Handler, page.Pos, page.Template)
+		if page.TemplateName != "" {
+			fmt.Fprintf(&b, " (template %q)", page.TemplateName)
+		}
+		if page.DataType != "" {
+			fmt.Fprintf(&b, " with a `%s`", page.DataType)
+		}
+		b.WriteString(".\n\n")
+
+		state := toCamelCase(page.Name) + "State"
+		fmt.Fprintf(&b, "- [ ] Load the page's data into the initial `%s` in `%s/%s/%s.go`; it starts empty\n", state, filepath.ToSlash(app.OutDir), page.Name, page.Name)
+		var untyped []string
+		for _, f := range page.Fields {
+			if f.Untyped {
+				untyped = append(untyped, "`"+f.Name+"`")
+			}
+		}
+		switch len(untyped) {
+		case 0:
+		case 1:
+			fmt.Fprintf(&b, "- [ ] Give %s its real type; the handler's data type is unknown\n", untyped[0])
+		default:
+			fmt.Fprintf(&b, "- [ ] Give %s their real types; the handler's data type is unknown\n", strings.Join(untyped, ", "))
+		}
+		if len(page.Methods) > 0 {
+			fmt.Fprintf(&b, "- [ ] %s was copied into the page without its methods: add the ones the template calls, or share the type instead\n", quoteList(page.Methods))
+		}
+		if page.UsesFuncs {
+			b.WriteString("- [ ] The original template set registers a FuncMap: compute those values into the state instead\n")
+		}
+		if len(page.Includes) > 0 {
+			fmt.Fprintf(&b, "- [ ] Check the templates appended from %s\n", quoteList(page.Includes))
+		}
+		for _, form := range page.Forms {
+			action := form.Action
+			if action == "" {
+				action = page.Pattern
+			}
+			handledBy := ""
+			if handler := app.routeHandler(form.Method, action); handler != "" {
+				handledBy = ", handled by `" + handler + "`"
+			}
+			fmt.Fprintf(&b, "- [ ] Turn the form (%s %s%s) into an action: name it, `<form name=\"save\">`, and move the handling into a `Save` method on `%sController`\n", form.Method, action, handledBy, toCamelCase(page.Name))
+		}
+		fmt.Fprintf(&b, "- [ ] Once `%s` matches `%s`, serve `%s.Handler()` at `%s` and remove `%s`\n", page.LivePath, page.Pattern, page.Name, routePath(page.Pattern), page.Handler)
+	}
+
+	if len(app.Routes) > 0 || len(app.UnusedTemplates) > 0 {
+		b.WriteString("\n## Not adopted\n\n")
+	}
+	if len(app.Routes) > 0 {
+		b.WriteString("These routes render no template (APIs, redirects, static files) and stay as they are:\n\n")
+		for _, r := range app.Routes {
+			fmt.Fprintf(&b, "- `%s` → `%s` (%s)\n", r.Pattern, r.Handler, r.Pos)
+		}
+		b.WriteString("\n")
+	}
+	if len(app.UnusedTemplates) > 0 {
+		b.WriteString("No route was found rendering these templates:\n\n")
+		for _, t := range app.UnusedTemplates {
+			fmt.Fprintf(&b, "- `%s`\n", t)
+		}
+	}
+	return b.String()
+}
+
+// routeHandler returns the handler of the route a form submits to.
+func (app *AdoptApp) routeHandler(method, path string) string {
+	routes := append([]AdoptRoute{}, app.Routes...)
+	for _, page := range app.Pages {
+		routes = append(routes, page.AdoptRoute)
+	}
+	for _, r := range routes {
+		if routePath(r.Pattern) != path {
+			continue
+		}
+		if m, _, ok := strings.Cut(r.Pattern, " "); ok && !strings.EqualFold(m, method) {
+			continue
+		}
+		return r.Handler
+	}
+	return ""
+}
+
+func quoteList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "`" + item + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// livePackageName is the package name of the live directory.
+func livePackageName(outDir string) string {
+	name := pageName("/" + filepath.Base(outDir))
+	if name == "home" {
+		return "live"
+	}
+	return name
+}
+
+func usesTime(page *AdoptPage) bool {
+	for _, f := range page.Fields {
+		if strings.Contains(f.Type, "time.") {
+			return true
+		}
+	}
+	for _, t := range page.Types {
+		if strings.Contains(t, "time.") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRequire reports whether the project's go.mod requires module.
+func hasRequire(root, module string) bool {
+	content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	return err == nil && strings.Contains(string(content), module+" ")
+}
+
+func formatGoFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Source(content)
+	if err != nil {
+		return fmt.Errorf("generated %s does not parse: %w", path, err)
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
@@ -0,0 +1,288 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const adoptMainGo = `package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+)
+
+type Todo struct {
+	ID    int
+	Title string
+	Done  bool
+	Due   time.Time
+}
+
+type PageData struct {
+	Title string
+	Todos []Todo
+	Count int
+}
+
+var tmpl = template.Must(template.ParseGlob("templates/*.html"))
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", indexHandler)
+	mux.HandleFunc("GET /todos/{id}", todoHandler)
+	mux.HandleFunc("POST /todos", createTodo)
+	mux.Handle("/about", http.HandlerFunc(aboutHandler))
+	mux.HandleFunc("/api/todos", apiTodos)
+
+	log.Fatal(http.ListenAndServe(":8080", mux))
+}
+
+func indexHandler(w http.ResponseWriter, r *http.Request) {
+	data := PageData{Title: "Todos", Todos: []Todo{{ID: 1, Title: "Write docs"}}, Count: 1}
+	if err := tmpl.ExecuteTemplate(w, "index.html", data); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+func todoHandler(w http.ResponseWriter, r *http.Request) {
+	render(w, "todo.html", map[string]any{"Todo": Todo{Title: "x"}})
+}
+
+func render(w http.ResponseWriter, name string, data any) {
+	_ = tmpl.ExecuteTemplate(w, name, data)
+}
+
+func aboutHandler(w http.ResponseWriter, r *http.Request) {
+	_ = tmpl.ExecuteTemplate(w, "about", PageData{Title: "About"})
+}
+
+func createTodo(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func apiTodos(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]Todo{})
+}
+`
+
+// writeAdoptApp writes a small net/http + html/template app to a temp dir.
+// Its go.mod already requires livetemplate, so Adopt does not run go get.
+func writeAdoptApp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/todoapp\n\ngo 1.22\n\nrequire github.com/livetemplate/livetemplate v0.8.4\n",
+		"main.go": adoptMainGo,
+		"templates/index.html": `<!DOCTYPE html>
+<html>
+<body>
+  {{template "header" .}}
+  <ul>
+  {{range .Todos}}<li>{{.Title}}{{if .Done}} ✓{{end}}</li>{{end}}
+  </ul>
+  <p>{{.Count}} todos</p>
+  <form method="post" action="/todos">
+    <input name="title">
+    <button>Add</button>
+  </form>
+</body>
+</html>
+`,
+		"templates/layout.html": `{{define "header"}}<h1>{{.Title}}</h1>{{end}}
+{{define "about"}}<!DOCTYPE html>
+<html><body>{{template "header" .}}<p>About this app</p></body></html>{{end}}
+`,
+		"templates/todo.html":   "<html><body><h1>{{.Todo.Title}}</h1></body></html>\n",
+		"templates/unused.html": "<p>{{.Nothing}}</p>\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func adoptPage(app *AdoptApp, name string) *AdoptPage {
+	for _, page := range app.Pages {
+		if page.Name == name {
+			return page
+		}
+	}
+	return nil
+}
+
+func TestAnalyzeApp(t *testing.T) {
+	dir := writeAdoptApp(t)
+	app, err := AnalyzeApp(dir, "example.com/todoapp", "live")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(app.Pages) != 3 {
+		t.Fatalf("found %d pages, want 3 (home, todos, about)", len(app.Pages))
+	}
+
+	home := adoptPage(app, "home")
+	if home == nil {
+		t.Fatal("no page for /")
+	}
+	if home.Template != "templates/index.html" || home.DataType != "PageData" || home.LivePath != "/live/" {
+		t.Errorf("home = template %q, data %q, live path %q", home.Template, home.DataType, home.LivePath)
+	}
+	if strings.Join(home.Includes, ",") != "templates/layout.html" {
+		t.Errorf("home includes = %v, want the file defining header", home.Includes)
+	}
+	fields := map[string]AdoptField{}
+	for _, f := range home.Fields {
+		fields[f.Name] = f
+	}
+	if fields["Todos"].Type != "[]Todo" || fields["Count"].Type != "int" || fields["Title"].Type != "string" {
+		t.Errorf("home fields = %+v, want them typed from PageData", home.Fields)
+	}
+	if len(home.Types) != 1 || !strings.HasPrefix(home.Types[0], "type Todo struct") {
+		t.Errorf("home copies types %v, want Todo", home.Types)
+	}
+	if len(home.Forms) != 1 || home.Forms[0].Action != "/todos" || !strings.EqualFold(home.Forms[0].Method, "post") {
+		t.Errorf("home forms = %+v", home.Forms)
+	}
+
+	// A map passed through a helper has no type: fields are any, and those
+	// the template reaches into are maps
+	todo := adoptPage(app, "todos")
+	if todo == nil {
+		t.Fatal("no page for GET /todos/{id}")
+	}
+	if todo.LivePath != "/live/todos/{id}" || len(todo.Fields) != 1 || todo.Fields[0].Type != "map[string]any" || !todo.Fields[0].Untyped {
+		t.Errorf("todos = live path %q, fields %+v", todo.LivePath, todo.Fields)
+	}
+
+	about := adoptPage(app, "about")
+	if about == nil || about.Template != "templates/layout.html" || about.TemplateName != "about" {
+		t.Errorf("about = %+v, want the about define in layout.html", about)
+	}
+
+	var routes []string
+	for _, r := range app.Routes {
+		routes = append(routes, r.Pattern)
+	}
+	if strings.Join(routes, ",") != "POST /todos,/api/todos" {
+		t.Errorf("routes without templates = %v", routes)
+	}
+	if strings.Join(app.UnusedTemplates, ",") != "templates/unused.html" {
+		t.Errorf("unused templates = %v", app.UnusedTemplates)
+	}
+	if app.Mux == nil || app.Mux.Expr != "mux" || filepath.Base(app.Mux.File) != "main.go" {
+		t.Errorf("mux = %+v, want mux in main.go", app.Mux)
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	dir := writeAdoptApp(t)
+	app, err := AnalyzeApp(dir, "example.com/todoapp", "live")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Adopt(app, "multi"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"home/home.go", "home/home.tmpl", "todos/todos.go", "about/about.go", "routes.go", AdoptChecklistFile} {
+		if _, err := os.Stat(filepath.Join(dir, "live", name)); err != nil {
+			t.Errorf("live/%s not written: %v", name, err)
+		}
+	}
+	for _, name := range []string{"home/home.go", "routes.go"} {
+		if _, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, "live", name), nil, 0); err != nil {
+			t.Errorf("live/%s does not parse: %v", name, err)
+		}
+	}
+
+	tmpl, _ := os.ReadFile(filepath.Join(dir, "live", "home", "home.tmpl"))
+	if !strings.Contains(string(tmpl), `{{define "header"}}`) || !strings.Contains(string(tmpl), "<script") {
+		t.Errorf("home.tmpl lacks the included header or the client script:\n%s", tmpl)
+	}
+	about, _ := os.ReadFile(filepath.Join(dir, "live", "about", "about.go"))
+	if !strings.Contains(string(about), `livetemplate.New("about_page"`) {
+		t.Errorf("about.go does not rename its template away from the about define:\n%s", about)
+	}
+
+	mainGo, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+	if !strings.Contains(string(mainGo), `"example.com/todoapp/live"`) || strings.Count(string(mainGo), "live.Register(mux)") != 1 {
+		t.Errorf("main.go not wired:\n%s", mainGo)
+	}
+	if app.Wired == "" {
+		t.Error("Wired not set")
+	}
+	checklist, _ := os.ReadFile(filepath.Join(dir, "live", AdoptChecklistFile))
+	for _, want := range []string{"/api/todos", "templates/unused.html", "createTodo"} {
+		if !strings.Contains(string(checklist), want) {
+			t.Errorf("checklist does not mention %s:\n%s", want, checklist)
+		}
+	}
+
+	// Running again keeps edited pages and does not wire twice
+	edited := filepath.Join(dir, "live", "home", "home.go")
+	if err := os.WriteFile(edited, []byte("package home\n\n// edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app, err = AnalyzeApp(dir, "example.com/todoapp", "live")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Adopt(app, "multi"); err != nil {
+		t.Fatal(err)
+	}
+	if home := adoptPage(app, "home"); home == nil || !home.Exists {
+		t.Error("second run did not mark home as existing")
+	}
+	if content, _ := os.ReadFile(edited); !strings.Contains(string(content), "// edited") {
+		t.Error("second run overwrote an edited page")
+	}
+	mainGo, _ = os.ReadFile(filepath.Join(dir, "main.go"))
+	if n := strings.Count(string(mainGo), "live.Register(mux)"); n != 1 {
+		t.Errorf("live.Register appears %d times after a second run", n)
+	}
+}
+
+func TestPageName(t *testing.T) {
+	tests := map[string]string{
+		"/":                       "home",
+		"/about":                  "about",
+		"GET /admin/users/{id}":   "adminusers",
+		"example.com/blog/posts/": "blogposts",
+		"/2024":                   "page2024",
+		"/func":                   "funcpage",
+	}
+	for pattern, want := range tests {
+		if got := pageName(pattern); got != want {
+			t.Errorf("pageName(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestJSONName(t *testing.T) {
+	tests := map[string]string{
+		"Title":       "title",
+		"LastUpdated": "last_updated",
+		"UserID":      "user_id",
+		"HTMLBody":    "html_body",
+	}
+	for name, want := range tests {
+		if got := jsonName(name); got != want {
+			t.Errorf("jsonName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
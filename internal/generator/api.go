@@ -11,6 +11,7 @@ import (
 
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/parser"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -26,10 +27,24 @@ type APIData struct {
 	ResourceNamePlural   string
 	TableName            string
 	Fields               []FieldData
+	IDType               string // "uuid", "ulid", or "" (from id= in .lvtrc)
+}
+
+// NewIDExpr returns the Go expression generating a record ID from app/ids,
+// or "" for the default "<resource>-<unix nanos>" ID.
+func (d APIData) NewIDExpr() string {
+	return newIDExpr(d.IDType)
+}
+
+// RichTextFields returns the fields whose HTML the handler sanitizes.
+func (d APIData) RichTextFields() []FieldData {
+	return richTextFields(d.Fields)
 }
 
 // GenerateAPI generates a JSON API handler for a resource.
 func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Field, kitName string) error {
+	useInflections(basePath)
+	defer track(basePath, "api "+strings.ToLower(resourceName))()
 	if kitName == "" {
 		kitName = "multi"
 	}
@@ -53,6 +68,17 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 	resourceNamePluralCap := titleCaser.String(pluralize(resourceNameSingular))
 	tableName := pluralize(resourceNameSingular)
 
+	for _, f := range fields {
+		if f.IsCounter {
+			return fmt.Errorf("field '%s': counter fields are not supported by gen api; add them with gen resource or gen schema", f.Name)
+		}
+		if f.IsGenerated {
+			return fmt.Errorf("field '%s': generated fields are not supported by gen api; add them with gen resource", f.Name)
+		}
+		if f.HasDefault {
+			return fmt.Errorf("field '%s': defaults are not supported by gen api; add them with gen resource or gen schema", f.Name)
+		}
+	}
 	fieldData := FieldDataFromFields(fields)
 
 	data := APIData{
@@ -64,6 +90,20 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 		ResourceNamePlural:   resourceNamePluralCap,
 		TableName:            tableName,
 		Fields:               fieldData,
+		IDType:               projectConfig.IDType,
+	}
+	if err := ValidateIDType(data.IDType); err != nil {
+		return err
+	}
+	if len(data.RichTextFields()) > 0 {
+		if err := generateRichText(basePath, moduleName, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+	if data.IDType != "" {
+		if err := generateIDs(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
 	}
 
 	// Create api directory
@@ -110,6 +150,7 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 			ResourceNamePlural:   resourceNamePluralCap,
 			TableName:            tableName,
 			Fields:               fieldData,
+			IDType:               data.IDType,
 		}
 
 		kit, err := kitLoader.Load(kitName)
@@ -148,12 +189,15 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 		if err := generateFile(string(migrationTmpl), resourceData, migrationPath, kit); err != nil {
 			return fmt.Errorf("failed to generate migration: %w", err)
 		}
+		if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+			return err
+		}
 
 		schemaTmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/schema.sql.tmpl")
 		if err != nil {
 			return fmt.Errorf("failed to load schema template: %w", err)
 		}
-		if err := appendToFile(string(schemaTmpl), resourceData, filepath.Join(dbDir, "schema.sql"), "\n", kit); err != nil {
+		if err := appendToFile(string(schemaTmpl), resourceData, filepath.Join(dbDir, "schema.sql"), "resource "+tableName, kit); err != nil {
 			return fmt.Errorf("failed to append to schema: %w", err)
 		}
 
@@ -162,7 +206,7 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 		if err != nil {
 			return fmt.Errorf("failed to load queries template: %w", err)
 		}
-		if err := appendToFile(string(queriesTmpl), resourceData, filepath.Join(dbDir, "queries.sql"), "\n", kit); err != nil {
+		if err := appendToFile(string(queriesTmpl), resourceData, filepath.Join(dbDir, "queries.sql"), "resource "+tableName, kit); err != nil {
 			return fmt.Errorf("failed to append base queries: %w", err)
 		}
 	}
@@ -173,11 +217,12 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 		return fmt.Errorf("failed to load API queries template: %w", err)
 	}
 
-	// Check if paginated queries already exist
 	queriesPath := filepath.Join(basePath, "database", "queries.sql")
 	queriesData, _ := os.ReadFile(queriesPath)
 	paginatedName := fmt.Sprintf("List%sPaginated", resourceNamePluralCap)
-	if !strings.Contains(string(queriesData), paginatedName) {
+	// Queries of that name written elsewhere would clash; an earlier run's
+	// section is replaced
+	if _, _, ok := findSection(string(queriesData), "api "+tableName); ok || !strings.Contains(string(queriesData), paginatedName) {
 		kit, err := kitLoader.Load(kitName)
 		if err != nil {
 			return fmt.Errorf("failed to load kit: %w", err)
@@ -185,7 +230,7 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 		if kit.Helpers == nil {
 			kit.SetHelpersForFramework("tailwind")
 		}
-		if err := appendToFile(string(apiQueriesTmpl), data, queriesPath, "\n", kit); err != nil {
+		if err := appendToFile(string(apiQueriesTmpl), data, queriesPath, "api "+tableName, kit); err != nil {
 			return fmt.Errorf("failed to append API queries: %w", err)
 		}
 	}
@@ -194,14 +239,13 @@ func GenerateAPI(basePath, moduleName, resourceName string, fields []parser.Fiel
 	mainGoPath := findMainGo(basePath)
 	if mainGoPath != "" {
 		if err := InjectAPIRegistration(mainGoPath, moduleName+"/app/api"); err != nil {
-			fmt.Printf("⚠️  Could not auto-inject API routes: %v\n", err)
-			fmt.Printf("   Add manually: api.RegisterRoutes(http.DefaultServeMux, queries)\n")
+			logging.Warn("could not add the API routes to main.go; add them manually", "route", "api.RegisterRoutes(http.DefaultServeMux, queries)", "error", err)
 		}
 	}
 
 	// Register resource for home page
-	if err := RegisterResource(basePath, data.ResourceName, "/api/v1/"+resourceNameLower, "api"); err != nil {
-		fmt.Printf("⚠️  Could not register API resource: %v\n", err)
+	if err := RegisterResourceFields(basePath, data.ResourceName, "/api/v1/"+resourceNameLower, "api", tableName, data.Fields); err != nil {
+		logging.Warn("could not register the API resource", "error", err)
 	}
 
 	return nil
@@ -226,5 +270,9 @@ func generateAPIFile(tmplStr string, data APIData, outPath string) error {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return os.WriteFile(outPath, buf.Bytes(), 0644)
+	if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	logging.Debug("wrote file", "path", outPath, "bytes", buf.Len())
+	return nil
 }
@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// copyKitAssets copies the kit's assets into web/assets/<kit> of the app
+// at basePath, where the app serves them. Files already up to date are
+// left alone.
+func copyKitAssets(basePath string, kit *kits.KitInfo) error {
+	assets, err := kit.Assets()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(basePath, "web", "assets", kit.Manifest.Name)
+	for _, a := range assets {
+		target := filepath.Join(dir, filepath.FromSlash(a.Path))
+		if existing, err := os.ReadFile(target); err == nil && bytes.Equal(existing, a.Data) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, a.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write kit asset %s: %w", a.Path, err)
+		}
+		logging.Debug("wrote kit asset", "path", target, "bytes", len(a.Data))
+	}
+	return nil
+}
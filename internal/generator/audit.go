@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// AuditConfig holds configuration for audit trail generation.
+type AuditConfig struct {
+	ModuleName string // Module path from go.mod
+}
+
+// AuditData is the template data for the audit package and page.
+type AuditData struct {
+	ModuleName   string
+	CSSFramework string
+	DevMode      bool
+	Theme        string
+}
+
+// auditPackagePath is the file whose presence marks the audit trail as set
+// up; resources generated afterwards record their changes.
+const auditPackagePath = "app/audit/audit.go"
+
+// AuditEnabled reports whether `lvt gen audit` has been run in projectRoot.
+func AuditEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, auditPackagePath))
+	return err == nil
+}
+
+// GenerateAudit generates the audit trail: the audit_logs table and queries,
+// the app/audit package that generated handlers call to record changes, and
+// a browsable /audit page.
+func GenerateAudit(projectRoot string, cfg *AuditConfig) error {
+	defer track(projectRoot, "audit")()
+	if AuditEnabled(projectRoot) {
+		return fmt.Errorf("audit trail already set up (%s exists)", auditPackagePath)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	kitLoader := kits.DefaultLoader()
+	kit, err := kitLoader.Load(kitName)
+	if err != nil {
+		return fmt.Errorf("failed to load kit %q: %w", kitName, err)
+	}
+	cssFramework := kit.Manifest.CSSFramework
+	if kit.Helpers == nil {
+		if err := kit.SetHelpersForFramework(cssFramework); err != nil {
+			return fmt.Errorf("failed to load CSS helpers for framework %q: %w", cssFramework, err)
+		}
+	}
+
+	data := AuditData{
+		ModuleName:   cfg.ModuleName,
+		CSSFramework: cssFramework,
+		DevMode:      ReadDevMode(projectRoot),
+		Theme:        projectConfig.Theme,
+	}
+
+	// 1. Create migration
+	migrationsDir := filepath.Join(projectRoot, "database", "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	timestamp := time.Now()
+	var migrationPath string
+	for i := 0; i < 3600; i++ {
+		timestampStr := timestamp.Format("20060102150405")
+		migrationPath = filepath.Join(migrationsDir, fmt.Sprintf("%s_create_audit_logs.sql", timestampStr))
+
+		matches, err := filepath.Glob(filepath.Join(migrationsDir, timestampStr+"_*"))
+		if err != nil {
+			return fmt.Errorf("failed to check for existing migrations: %w", err)
+		}
+		if len(matches) == 0 {
+			break
+		}
+		timestamp = timestamp.Add(1 * time.Second)
+		if i == 3599 {
+			return fmt.Errorf("failed to generate unique migration timestamp")
+		}
+	}
+
+	if err := writeTemplateFile(kitLoader, kitName, "audit/migration.sql.tmpl", migrationPath, nil); err != nil {
+		return fmt.Errorf("failed to generate migration: %w", err)
+	}
+	if err := addDownSection(migrationPath, filepath.Join(projectRoot, "database", "schema.sql")); err != nil {
+		return err
+	}
+
+	// 2. Append to schema.sql and queries.sql
+	dbDir := filepath.Join(projectRoot, "database")
+	if err := appendTemplateFile(kitLoader, kitName, "audit/schema.sql.tmpl", filepath.Join(dbDir, "schema.sql"), "audit", nil); err != nil {
+		return fmt.Errorf("failed to append to schema.sql: %w", err)
+	}
+	if err := appendTemplateFile(kitLoader, kitName, "audit/queries.sql.tmpl", filepath.Join(dbDir, "queries.sql"), "audit", nil); err != nil {
+		return fmt.Errorf("failed to append to queries.sql: %w", err)
+	}
+
+	// 3. Create app/audit package and page
+	auditDir := filepath.Join(projectRoot, "app", "audit")
+	if err := os.MkdirAll(auditDir, 0755); err != nil {
+		return fmt.Errorf("failed to create app/audit directory: %w", err)
+	}
+
+	files := []struct {
+		template string
+		output   string
+	}{
+		{"audit/audit.go.tmpl", "audit.go"},
+		{"audit/handler.go.tmpl", "handler.go"},
+		{"audit/template.tmpl.tmpl", "audit.tmpl"},
+	}
+	for _, f := range files {
+		content, err := kitLoader.LoadKitTemplate(kitName, f.template)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.template, err)
+		}
+		if err := generateFile(string(content), data, filepath.Join(auditDir, f.output), kit); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", f.output, err)
+		}
+	}
+	if err := ValidateTemplate(filepath.Join(auditDir, "audit.tmpl")); err != nil {
+		return err
+	}
+	if err := generateFuncs(projectRoot, kitLoader, kitName); err != nil {
+		return err
+	}
+
+	// 4. Inject route into main.go
+	mainGoPath := findMainGo(projectRoot)
+	if mainGoPath != "" {
+		route := RouteInfo{
+			Path:        "/audit",
+			PackageName: "audit",
+			HandlerCall: "audit.Handler(queries)",
+			ImportPath:  cfg.ModuleName + "/app/audit",
+		}
+		if err := InjectRoute(mainGoPath, route); err != nil {
+			logging.Warn("could not add the route to main.go; add it manually", "route", `http.Handle("/audit", audit.Handler(queries))`, "error", err)
+		}
+	}
+
+	// Register page for home page
+	if err := RegisterResource(projectRoot, "Audit", "/audit", "view"); err != nil {
+		logging.Warn("could not register the audit page in the home page", "error", err)
+	}
+
+	return nil
+}
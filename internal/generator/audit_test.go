@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := GenerateAudit(tmpDir, &AuditConfig{ModuleName: "testmodule"}); err != nil {
+		t.Fatalf("GenerateAudit failed: %v", err)
+	}
+	if !AuditEnabled(tmpDir) {
+		t.Error("AuditEnabled should report true after generation")
+	}
+
+	for _, name := range []string{"audit.go", "handler.go"} {
+		path := filepath.Join(tmpDir, "app", "audit", name)
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+		}
+	}
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "audit", "audit.tmpl"))
+	if err != nil {
+		t.Fatalf("audit.tmpl was not created: %v", err)
+	}
+	if !strings.Contains(string(tmpl), "/audit?resource=") {
+		t.Error("audit.tmpl missing resource filter links")
+	}
+
+	// The migration's Up section and the queries must run against SQLite
+	migration := readMigration(t, tmpDir, "audit_logs")
+	up, _, _ := strings.Cut(migration, "-- +goose Down")
+	db := openTestDB(t, up)
+	if _, err := db.Exec(`INSERT INTO audit_logs (id, resource, record_id, action, user_id, old_values, new_values, created_at)
+		VALUES ('audit-1', 'posts', 'post-1', 'update', 'user-1', '{"title":"a"}', '{"title":"b"}', CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatalf("insert audit log: %v", err)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM audit_logs WHERE resource = ? AND record_id = ? ORDER BY created_at DESC LIMIT ?`, "posts", "post-1", 100).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 audit log for posts/post-1, got %d", count)
+	}
+
+	schema, err := os.ReadFile(filepath.Join(tmpDir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(schema), "CREATE TABLE IF NOT EXISTS audit_logs") {
+		t.Error("schema.sql missing audit_logs table")
+	}
+	queries, err := os.ReadFile(filepath.Join(tmpDir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"CreateAuditLog", "ListAuditLogsByResource", "ListAuditLogsByRecord", "ListAuditResources"} {
+		if !strings.Contains(string(queries), "-- name: "+name+" ") {
+			t.Errorf("queries.sql missing %s", name)
+		}
+	}
+
+	if err := GenerateAudit(tmpDir, &AuditConfig{ModuleName: "testmodule"}); err == nil {
+		t.Error("expected error when audit trail already exists")
+	}
+}
+
+func TestResourceRecordsAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	// Resources generated before the audit trail don't record changes
+	if err := generateCounterTestResource(t, tmpDir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(tmpDir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "recordAudit") {
+		t.Error("resource generated before gen audit should not record changes")
+	}
+
+	if err := GenerateAudit(tmpDir, &AuditConfig{ModuleName: "testmodule"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, tmpDir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := string(content)
+	for _, want := range []string{
+		`"testmodule/app/audit"`,
+		"audit.ActionCreate",
+		"audit.ActionUpdate",
+		"audit.ActionDelete",
+		"audit.Record(",
+	} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("posts handler missing %q", want)
+		}
+	}
+	if strings.Contains(handler, "WithAuthenticator") {
+		t.Error("authenticator should only be wired when the auth system exists")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+		t.Errorf("posts handler does not parse: %v", err)
+	}
+}
+
+func TestResourceDataNeedsAuthenticator(t *testing.T) {
+	tests := []struct {
+		name string
+		data ResourceData
+		want bool
+	}{
+		{"plain", ResourceData{}, false},
+		{"authz", ResourceData{WithAuthz: true}, true},
+		{"audit without auth", ResourceData{WithAudit: true}, false},
+		{"audit with auth", ResourceData{WithAudit: true, HasAuth: true}, true},
+		{"presence without auth", ResourceData{WithPresence: true}, false},
+		{"presence with auth", ResourceData{WithPresence: true, HasAuth: true}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.data.NeedsAuthenticator(); got != tt.want {
+			t.Errorf("%s: NeedsAuthenticator() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -12,6 +12,7 @@ import (
 
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 type AuthConfig struct {
@@ -24,9 +25,14 @@ type AuthConfig struct {
 	EnablePasswordReset bool
 	EnableSessionsUI    bool
 	EnableCSRF          bool
+	WithSecurityHeaders bool   // inline scripts carry the cspNonce of shared/security, set when it exists
+	WithSessions        bool   // the auth page keeps its sessions in shared/sessions, set when it exists
+	Theme               string // default daisyUI theme, set from .lvtrc
 }
 
 func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
+	useInflections(projectRoot)
+	defer track(projectRoot, "auth")()
 	// Apply defaults if not set
 	if authConfig.TableName == "" {
 		authConfig.TableName = "users"
@@ -41,6 +47,11 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 		return fmt.Errorf("failed to load project config: %w", err)
 	}
 	kitName := projectConfig.GetKit()
+	authConfig.Theme = projectConfig.Theme
+	// The auth forms carry tokens from shared/csrf, which older apps lack
+	authConfig.EnableCSRF = authConfig.EnableCSRF && CSRFEnabled(projectRoot)
+	authConfig.WithSecurityHeaders = SecurityHeadersEnabled(projectRoot)
+	authConfig.WithSessions = SessionsEnabled(projectRoot)
 
 	// Load kit loader
 	kitLoader := kits.DefaultLoader()
@@ -105,8 +116,11 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 	if err := tmpl.Execute(file, authConfig); err != nil {
 		return fmt.Errorf("failed to execute migration template: %w", err)
 	}
+	if err := addDownSection(migrationPath, filepath.Join(projectRoot, "database", "schema.sql")); err != nil {
+		return err
+	}
 
-	// Append to queries.sql (or create if doesn't exist)
+	// Write the auth section of queries.sql (or create it)
 	queriesPath := filepath.Join(projectRoot, "database", "queries.sql")
 
 	templateContent, err = kitLoader.LoadKitTemplate(kitName, "auth/queries.sql.tmpl")
@@ -119,35 +133,15 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 		return fmt.Errorf("failed to parse queries template: %w", err)
 	}
 
-	// Open in append mode (create if doesn't exist)
-	file, err = os.OpenFile(queriesPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open queries.sql: %w", err)
-	}
-
-	// Add separator if file already has content
-	stat, err := file.Stat()
-	if err != nil {
-		file.Close()
-		return fmt.Errorf("failed to stat queries.sql: %w", err)
-	}
-	if stat.Size() > 0 {
-		if _, err := file.WriteString("\n\n"); err != nil {
-			file.Close()
-			return fmt.Errorf("failed to write separator: %w", err)
-		}
-	}
-
-	if err := tmpl.Execute(file, authConfig); err != nil {
-		file.Close()
+	var queries bytes.Buffer
+	if err := tmpl.Execute(&queries, authConfig); err != nil {
 		return fmt.Errorf("failed to execute queries template: %w", err)
 	}
-
-	if err := file.Close(); err != nil {
-		return fmt.Errorf("failed to close queries.sql: %w", err)
+	if err := writeSection(queriesPath, "auth", queries.Bytes()); err != nil {
+		return fmt.Errorf("failed to write queries.sql: %w", err)
 	}
 
-	// Append to schema.sql for sqlc (separate from migration)
+	// Write the auth section of schema.sql for sqlc (separate from migration)
 	schemaPath := filepath.Join(projectRoot, "database", "schema.sql")
 	schemaTemplateContent, err := kitLoader.LoadKitTemplate(kitName, "auth/schema.sql.tmpl")
 	if err != nil {
@@ -159,32 +153,12 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 		return fmt.Errorf("failed to parse schema template: %w", err)
 	}
 
-	// Open in append mode (create if doesn't exist)
-	schemaFile, err := os.OpenFile(schemaPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open schema.sql: %w", err)
-	}
-
-	// Add separator if file already has content
-	schemaStat, err := schemaFile.Stat()
-	if err != nil {
-		schemaFile.Close()
-		return fmt.Errorf("failed to stat schema.sql: %w", err)
-	}
-	if schemaStat.Size() > 0 {
-		if _, err := schemaFile.WriteString("\n"); err != nil {
-			schemaFile.Close()
-			return fmt.Errorf("failed to write separator: %w", err)
-		}
-	}
-
-	if err := schemaTmpl.Execute(schemaFile, authConfig); err != nil {
-		schemaFile.Close()
+	var schema bytes.Buffer
+	if err := schemaTmpl.Execute(&schema, authConfig); err != nil {
 		return fmt.Errorf("failed to execute schema template: %w", err)
 	}
-
-	if err := schemaFile.Close(); err != nil {
-		return fmt.Errorf("failed to close schema.sql: %w", err)
+	if err := writeSection(schemaPath, "auth", schema.Bytes()); err != nil {
+		return fmt.Errorf("failed to write schema.sql: %w", err)
 	}
 
 	// Generate auth handler
@@ -218,6 +192,9 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 	if err := file.Close(); err != nil {
 		return fmt.Errorf("failed to close auth.go: %w", err)
 	}
+	if err := generateFuncs(projectRoot, kitLoader, kitName); err != nil {
+		return err
+	}
 
 	// Generate template file
 	templateContent, err = kitLoader.LoadKitTemplate(kitName, "auth/template.tmpl.tmpl")
@@ -309,16 +286,11 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 		if authConfig.EnablePassword {
 			dependencies = append(dependencies, "golang.org/x/crypto@latest")
 		}
-		if authConfig.EnableCSRF {
-			dependencies = append(dependencies, "github.com/gorilla/csrf@latest")
-		}
 
 		if len(dependencies) > 0 {
 			args := append([]string{"get"}, dependencies...)
-			cmd := exec.Command("go", args...)
-			cmd.Dir = projectRoot
-			if output, err := cmd.CombinedOutput(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: could not fetch some dependencies (run 'go mod tidy' in %s to resolve):\n%s\n", projectRoot, output)
+			if output, err := runTool(projectRoot, "go", args...); err != nil {
+				logging.Warn("could not fetch some dependencies; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
 			}
 		}
 	}
@@ -402,8 +374,7 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 		for _, route := range routes {
 			if err := InjectRoute(mainGoPath, route); err != nil {
 				// Log warning but don't fail - user can add route manually
-				fmt.Printf("⚠️  Could not auto-inject route %s: %v\n", route.Path, err)
-				fmt.Printf("   Please add manually: %s\n", route.HandlerCall)
+				logging.Warn("could not add the route to main.go; add it manually", "path", route.Path, "handler", route.HandlerCall, "error", err)
 			} else {
 				routesInjected++
 			}
@@ -419,25 +390,22 @@ func GenerateAuth(projectRoot string, authConfig *AuthConfig) error {
 
 		// Wrap existing resource routes with RequireAuth middleware
 		if err := WrapExistingRoutesWithAuth(mainGoPath, authConfig.StructName); err != nil {
-			fmt.Printf("⚠️  Could not wrap existing routes with auth: %v\n", err)
-			fmt.Println("   You may need to manually wrap protected routes with authController.RequireAuth()")
+			logging.Warn("could not wrap existing routes with auth; wrap protected routes with authController.RequireAuth() manually", "error", err)
 		}
 
 		// Update existing resource tests to include authentication
 		if err := UpdateResourceTestsForAuth(projectRoot); err != nil {
-			fmt.Printf("⚠️  Could not update resource tests for auth: %v\n", err)
-			fmt.Println("   Resource tests may need to be updated manually to authenticate")
+			logging.Warn("could not update resource tests for auth; they may need to authenticate", "error", err)
 		}
 
 		// Register auth in home page
 		if err := RegisterResource(projectRoot, "Auth", "/auth", "auth"); err != nil {
-			fmt.Printf("⚠️  Could not register auth in home page: %v\n", err)
+			logging.Warn("could not register auth in the home page", "error", err)
 		}
 
 		// Update home page to show login/logout buttons
 		if err := updateHomeForAuth(projectRoot, authConfig); err != nil {
-			fmt.Printf("⚠️  Could not update home page for auth: %v\n", err)
-			fmt.Println("   You may need to manually add login/logout buttons to your home page")
+			logging.Warn("could not update the home page for auth; add login/logout buttons manually", "error", err)
 		}
 	}
 
@@ -54,7 +54,7 @@ func TestGenerateAuth_Handler(t *testing.T) {
 }
 
 func TestGenerateAuth_NoSharedDirectory(t *testing.T) {
-	// Verify that shared/ directory is no longer generated
+	// Verify that the shared/ utilities are no longer generated
 	tmpDir := t.TempDir()
 
 	err := GenerateAuth(tmpDir, &AuthConfig{
@@ -66,10 +66,12 @@ func TestGenerateAuth_NoSharedDirectory(t *testing.T) {
 		t.Fatalf("GenerateAuth failed: %v", err)
 	}
 
-	// Verify shared/ directory does NOT exist
-	sharedPath := filepath.Join(tmpDir, "shared")
-	if _, err := os.Stat(sharedPath); err == nil {
-		t.Error("shared/ directory should NOT be generated (utilities are now in lvt/pkg/)")
+	// Verify the utilities are not generated into shared/; shared/funcs,
+	// which the handler parses its templates with, is the app's own
+	for _, dir := range []string{"password", "email"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "shared", dir)); err == nil {
+			t.Errorf("shared/%s should NOT be generated (utilities are now in lvt/pkg/)", dir)
+		}
 	}
 }
 
@@ -225,9 +227,9 @@ func TestGenerateAuth_Queries_Append(t *testing.T) {
 		t.Error("queries.sql missing new auth queries")
 	}
 
-	// Verify separator was added
-	if !strings.Contains(contentStr, "\n\n-- Auth Queries") {
-		t.Error("queries.sql missing separator between existing and new content")
+	// Verify the queries were appended in their own section
+	if !strings.Contains(contentStr, "\n\n-- lvt:auth begin\n") || !strings.HasSuffix(contentStr, "-- lvt:auth end\n") {
+		t.Error("queries.sql missing the auth section after the existing content")
 	}
 }
 
@@ -276,11 +278,6 @@ replace github.com/livetemplate/lvt/components => %s/components
 	if !strings.Contains(contentStr, "golang.org/x/crypto") {
 		t.Error("go.mod missing golang.org/x/crypto dependency")
 	}
-
-	// Check for gorilla/csrf
-	if !strings.Contains(contentStr, "github.com/gorilla/csrf") {
-		t.Error("go.mod missing github.com/gorilla/csrf dependency")
-	}
 }
 
 func TestGenerateAuth_HomePageButtons(t *testing.T) {
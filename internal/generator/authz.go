@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,6 +22,8 @@ type AuthzConfig struct {
 // GenerateAuthz generates the authorization system: role migration,
 // role queries, and patches the schema for sqlc.
 func GenerateAuthz(projectRoot string, cfg *AuthzConfig) error {
+	useInflections(projectRoot)
+	defer track(projectRoot, "authz")()
 	if cfg.TableName == "" {
 		cfg.TableName = "users"
 	}
@@ -82,8 +85,12 @@ func GenerateAuthz(projectRoot string, cfg *AuthzConfig) error {
 	if err := tmpl.Execute(file, cfg); err != nil {
 		return fmt.Errorf("failed to execute migration template: %w", err)
 	}
+	// Derived before patchSchemaWithRole so a rebuild uses the table without role
+	if err := addDownSection(migrationPath, filepath.Join(projectRoot, "database", "schema.sql")); err != nil {
+		return err
+	}
 
-	// Append role queries to queries.sql
+	// Write the role queries to the authz section of queries.sql
 	queriesPath := filepath.Join(projectRoot, "database", "queries.sql")
 	templateContent, err = kitLoader.LoadKitTemplate(kitName, "authz/queries.sql.tmpl")
 	if err != nil {
@@ -95,21 +102,13 @@ func GenerateAuthz(projectRoot string, cfg *AuthzConfig) error {
 		return fmt.Errorf("failed to parse authz queries template: %w", err)
 	}
 
-	qFile, err := os.OpenFile(queriesPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open queries.sql: %w", err)
-	}
-
-	stat, _ := qFile.Stat()
-	if stat != nil && stat.Size() > 0 {
-		qFile.WriteString("\n\n")
-	}
-
-	if err := tmpl.Execute(qFile, cfg); err != nil {
-		qFile.Close()
+	var queries bytes.Buffer
+	if err := tmpl.Execute(&queries, cfg); err != nil {
 		return fmt.Errorf("failed to execute authz queries template: %w", err)
 	}
-	qFile.Close()
+	if err := writeSection(queriesPath, "authz", queries.Bytes()); err != nil {
+		return fmt.Errorf("failed to write queries.sql: %w", err)
+	}
 
 	// Patch schema.sql to add role column to the users CREATE TABLE
 	if err := patchSchemaWithRole(projectRoot, cfg.TableName); err != nil {
@@ -153,5 +152,9 @@ func patchSchemaWithRole(projectRoot, tableName string) error {
 
 	content = strings.Replace(content, target, replacement, 1)
 
-	return os.WriteFile(schemaPath, []byte(content), 0644)
+	if err := os.WriteFile(schemaPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	// The users table is in the auth section
+	return refreshSection(schemaPath, "auth")
 }
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// CacheData is the template data for the shared/cache package.
+type CacheData struct {
+	ModuleName string
+	Namespace  string // prefix of the app's Redis keys
+}
+
+// CachePackage is the app package caching query results.
+const CachePackage = "shared/cache/cache.go"
+
+// redisURLVar declares the Redis server the cache uses.
+const redisURLVar = `{Name: "REDIS_URL", Type: TypeString, Secret: true, Description: "Redis server for the cache, e.g. redis://localhost:6379; in memory when unset"}`
+
+// CacheEnabled reports whether the shared/cache package exists in projectRoot.
+func CacheEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, CachePackage))
+	return err == nil
+}
+
+// GenerateCache creates the shared/cache package in the app at projectRoot.
+// With withRedis it also adds the Redis store, declares REDIS_URL and sets
+// the store up in main.go.
+func GenerateCache(projectRoot, moduleName string, withRedis bool) error {
+	defer track(projectRoot, "cache")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no database to cache; use the multi, single or daisyui kit")
+	}
+	if CacheEnabled(projectRoot) {
+		return fmt.Errorf("cache already set up (%s exists)", CachePackage)
+	}
+
+	// 1. Create shared/cache
+	kitLoader := kits.DefaultLoader()
+	cacheDir := filepath.Join(projectRoot, filepath.Dir(CachePackage))
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/cache directory: %w", err)
+	}
+	files := []string{"cache.go", "cache_test.go"}
+	if withRedis {
+		files = append(files, "redis.go")
+	}
+	data := CacheData{ModuleName: moduleName, Namespace: path.Base(moduleName)}
+	for _, f := range files {
+		if err := writeTemplateFile(kitLoader, kitName, "cache/"+f+".tmpl", filepath.Join(cacheDir, f), data); err != nil {
+			return fmt.Errorf("failed to generate shared/cache/%s: %w", f, err)
+		}
+	}
+	if !withRedis {
+		return nil
+	}
+
+	// 2. Declare REDIS_URL
+	if err := declareConfigVar(projectRoot, "REDIS_URL", redisURLVar); err != nil {
+		return fmt.Errorf("failed to declare REDIS_URL: %w", err)
+	}
+
+	// 3. Add the Redis client
+	goModPath := filepath.Join(projectRoot, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		if output, err := runTool(projectRoot, "go", "get", "github.com/redis/go-redis/v9@latest"); err != nil {
+			logging.Warn("could not fetch the Redis client; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+		}
+	}
+
+	// 4. Set up the Redis store in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectCacheSetup(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to inject the cache setup into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// injectCacheSetup sets the cache up once the database is, so its shutdown
+// hook closes the Redis connection before the database closes.
+func injectCacheSetup(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "cache.Setup(") {
+		return nil // Already injected
+	}
+
+	anchor := "\t\tdatabase.CloseDB()\n\t\treturn nil\n\t})\n"
+	idx := strings.Index(mainStr, anchor)
+	if idx < 0 {
+		return fmt.Errorf("could not find the database shutdown hook (expected %q)", "database.CloseDB()")
+	}
+	idx += len(anchor)
+	setup := "\n\t// Cache store: Redis when REDIS_URL is set (see shared/cache)\n" +
+		"\tif err := cache.Setup(context.Background()); err != nil {\n" +
+		"\t\tslog.Error(\"Failed to set up the cache\", \"error\", err)\n" +
+		"\t\tos.Exit(1)\n" +
+		"\t}\n"
+	mainStr = mainStr[:idx] + setup + mainStr[idx:]
+
+	for _, imp := range []string{"\t\"context\"", fmt.Sprintf("\t\"%s/shared/cache\"", moduleName)} {
+		if mainStr, err = injectImport(mainStr, imp); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
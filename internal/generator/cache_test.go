@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := GenerateCache(tmpDir, "example.com/shop", false); err != nil {
+		t.Fatalf("GenerateCache failed: %v", err)
+	}
+	for _, f := range []string{"cache.go", "cache_test.go"} {
+		path := filepath.Join(tmpDir, "shared", "cache", f)
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+			t.Errorf("%s does not parse: %v", f, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "shared", "cache", "redis.go")); err == nil {
+		t.Error("redis.go should only be generated with --redis")
+	}
+
+	if err := GenerateCache(tmpDir, "example.com/shop", false); err == nil {
+		t.Error("GenerateCache should refuse to run twice")
+	}
+}
+
+func TestGenerateCacheRedis(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	mainGoPath := filepath.Join(tmpDir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+func main() {
+	lifecycle.OnShutdown("database", func(ctx context.Context) error {
+		database.CloseDB()
+		return nil
+	})
+
+	slog.Info("Starting")
+	os.Exit(0)
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(tmpDir, "shared", "config", "config.go")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configGo := "package config\n\nvar Schema = []Var{\n\t{Name: \"PORT\", Type: TypeInt, Default: \"8080\"},\n}\n"
+	if err := os.WriteFile(configPath, []byte(configGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GenerateCache(tmpDir, "example.com/shop", true); err != nil {
+		t.Fatalf("GenerateCache failed: %v", err)
+	}
+
+	redisPath := filepath.Join(tmpDir, "shared", "cache", "redis.go")
+	redis, err := os.ReadFile(redisPath)
+	if err != nil {
+		t.Fatalf("redis.go was not generated: %v", err)
+	}
+	if !strings.Contains(string(redis), `NewRedis(client, "shop")`) {
+		t.Error("Redis keys should be namespaced with the module's base name")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), redisPath, redis, parser.AllErrors); err != nil {
+		t.Errorf("redis.go does not parse: %v", err)
+	}
+
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainStr := string(content)
+	for _, want := range []string{`"context"`, `"example.com/shop/shared/cache"`, "cache.Setup(context.Background())"} {
+		if !strings.Contains(mainStr, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+	if strings.Index(mainStr, "cache.Setup(") < strings.Index(mainStr, "database.CloseDB()") {
+		t.Error("the cache should be set up after the database shutdown hook is registered")
+	}
+
+	content, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "\t"+redisURLVar+",\n}") {
+		t.Errorf("config.go does not declare REDIS_URL:\n%s", content)
+	}
+}
+
+func TestResourceCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Cache: true}, "title:string"); err == nil {
+		t.Fatal("--cache should require the cache package")
+	}
+
+	if err := GenerateCache(tmpDir, "testmodule", false); err != nil {
+		t.Fatalf("GenerateCache failed: %v", err)
+	}
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Cache: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"testmodule/shared/cache"`,
+		`cache.Key("posts", "list", state.SearchQuery, state.SortBy)`,
+		`cache.Invalidate(ctx, "posts")`,
+		"func (c *PostsController) queryPostss(",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+	if n := strings.Count(string(handler), "c.invalidateCache(dbCtx)"); n != 3 {
+		t.Errorf("handler invalidates the cache in %d actions, want 3 (add, update, delete)", n)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), handlerPath, handler, parser.AllErrors); err != nil {
+		t.Errorf("handler does not parse: %v", err)
+	}
+}
@@ -3,7 +3,7 @@ package generator
 // ComponentUsage tracks which UI components are needed by a generated resource.
 type ComponentUsage struct {
 	UseModal    bool // delete confirmation modal
-	UseToast    bool // CRUD feedback notifications
+	UseToast    bool // CRUD feedback notifications, unless lvt.Notify reports them
 	UseDropdown bool // select field dropdowns
 	UseUpload   bool // file/image upload support
 }
@@ -13,7 +13,9 @@ type ComponentUsage struct {
 func ComputeComponentUsage(data ResourceData) ComponentUsage {
 	usage := ComponentUsage{
 		UseModal: true, // always: delete confirmation
-		UseToast: true, // always: CRUD feedback
+		// CRUD feedback: a toast container of its own, unless the app's
+		// notifications (lvt gen notifications) show it
+		UseToast: !data.WithNotifications,
 	}
 
 	for _, f := range data.Fields {
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// CompressionData is the template data for the shared/compression package.
+type CompressionData struct {
+	ModuleName string
+}
+
+// CompressionPackage is the app package that lets handlers deflate their
+// WebSocket updates.
+const CompressionPackage = "shared/compression/compression.go"
+
+// liveCompressionVar declares the switch for compressed updates.
+const liveCompressionVar = `{Name: "LIVE_COMPRESSION", Type: TypeBool, Default: "true", Description: "Deflate WebSocket updates for browsers that offer permessage-deflate"}`
+
+// CompressionEnabled reports whether `lvt gen compression` has been run in
+// projectRoot.
+func CompressionEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, CompressionPackage))
+	return err == nil
+}
+
+// GenerateCompression creates the shared/compression package and declares
+// LIVE_COMPRESSION. Handlers generated afterwards pass compression.Option
+// to LiveTemplate, so connections whose browser offers permessage-deflate
+// get their updates compressed.
+func GenerateCompression(projectRoot, moduleName string) error {
+	defer track(projectRoot, "compression")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no shared/config to switch compression with; use the multi, single or daisyui kit")
+	}
+	if CompressionEnabled(projectRoot) {
+		return fmt.Errorf("compression already set up (%s exists)", CompressionPackage)
+	}
+
+	// 1. Create shared/compression
+	dir := filepath.Join(projectRoot, filepath.Dir(CompressionPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/compression directory: %w", err)
+	}
+	data := CompressionData{ModuleName: moduleName}
+	if err := writeTemplateFile(kits.DefaultLoader(), kitName, "compression/compression.go.tmpl", filepath.Join(projectRoot, CompressionPackage), data); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", CompressionPackage, err)
+	}
+
+	// 2. Declare LIVE_COMPRESSION
+	if err := declareConfigVar(projectRoot, "LIVE_COMPRESSION", liveCompressionVar); err != nil {
+		return fmt.Errorf("failed to declare LIVE_COMPRESSION: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// WebSocket compression can be turned off with LIVE_COMPRESSION.
+func TestGenerateCompression(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	configPath := writeTestConfig(t, dir)
+	if err := GenerateCompression(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(config), `{Name: "LIVE_COMPRESSION", Type: TypeBool, Default: "true"`) {
+		t.Errorf("config.go does not declare LIVE_COMPRESSION:\n%s", config)
+	}
+}
+
+func TestPagesCompressUpdates(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "compression.Option") {
+		t.Error("resource generated before gen compression should not compress updates")
+	}
+
+	if err := GenerateCompression(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	generateTestPages(t, dir, "multi")
+
+	for _, page := range []string{"posts", "dashboard", "lobby", "board", "signup"} {
+		path := filepath.Join(dir, "app", page, page+".go")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"testmodule/shared/compression"`, "compression.Option(),"} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s handler is missing %q", page, want)
+			}
+		}
+		typeCheckPackage(t, filepath.Dir(path))
+	}
+}
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Conflicts: true}, "title:string", "due:time", "secret:password"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"database/sql"`,
+		`"errors"`,
+		"state.EditBase = &itemCopy",
+		"if state, held = c.checkConflict(state, dbCtx, input); held {",
+		"errors.Is(err, sql.ErrNoRows)",
+		"if base.Title != current.Title {",
+		"if !base.Due.Equal(current.Due) {",
+		`Theirs: "••••••", Yours: "••••••"`,
+		"func (c *TasksController) ReloadEdit(",
+		"func (c *TasksController) OverwriteEdit(",
+		"return c.Update(state, ctx.WithData(data))",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`{{template "editConflict" .}}`,
+		`data-conflict="{{.Conflict}}"`,
+		`{{range .ConflictChanges}}`,
+		`name="reload_edit"`,
+		`name="overwrite_edit"`,
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+}
+
+func TestResourceConflictsKits(t *testing.T) {
+	fields, err := parser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ kit, css string }{
+		{"single", "tailwind"},
+		{"daisyui", "daisyui"},
+	} {
+		t.Run(tt.kit, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			if err := GenerateResource(tmpDir, "testmodule", "tasks", fields, tt.kit, tt.css, "tailwind", "infinite", 20, "modal", "", false, false, ResourceOptions{Conflicts: true}); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(data)
+			for _, want := range []string{`data-conflict="{{.Conflict}}"`, `name="reload_edit"`, `name="overwrite_edit"`} {
+				if !strings.Contains(content, want) {
+					t.Errorf("template missing %s", want)
+				}
+			}
+			if _, err := template.New("page").Parse(content); err != nil {
+				t.Errorf("template does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestResourceConflictsUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		actions *ResourceActions
+		want    string
+	}{
+		{"file field", []string{"title:string", "cover:image"}, nil, "file fields"},
+		{"no edit", []string{"title:string"}, &ResourceActions{Create: true, Delete: true}, "edit action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			fields, err := parser.ParseFields(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = GenerateResource(tmpDir, "testmodule", "posts", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false, ResourceOptions{Conflicts: true, Actions: tt.actions})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("--conflicts with %s: err = %v, want it rejected", tt.name, err)
+			}
+		})
+	}
+}
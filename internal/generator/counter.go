@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+// CounterData describes a counter cache column: Name on Table holds the
+// number of ChildTable rows whose ForeignKey references the Table row.
+// SQLite triggers on ChildTable keep it in sync, so list pages can show the
+// count without a COUNT(*) query per row.
+type CounterData struct {
+	Table      string // table holding the counter column (e.g., "posts")
+	Name       string // counter column (e.g., "comments_count")
+	ChildTable string // counted table (e.g., "comments")
+	ForeignKey string // column in ChildTable referencing Table (e.g., "post_id")
+}
+
+// Label is the human-readable name of the counted rows (e.g., "comments").
+func (c CounterData) Label() string {
+	return strings.ReplaceAll(c.ChildTable, "_", " ")
+}
+
+// counterMarkerRe matches the comment lvt writes after a counter column in
+// schema.sql, capturing the column, child table, and foreign key.
+var counterMarkerRe = regexp.MustCompile(`(?m)^\s*(\w+) INTEGER NOT NULL DEFAULT 0,? -- counter cache: (\w+)\.(\w+)\s*$`)
+
+var createTableRe = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?["` + "`" + `]?(\w+)`)
+
+// splitCounterFields separates counter fields from regular fields. The
+// foreign key defaults to the singular table name plus "_id".
+func splitCounterFields(fields []parser.Field, tableName string) ([]parser.Field, []CounterData) {
+	var rest []parser.Field
+	var counters []CounterData
+	for _, f := range fields {
+		if !f.IsCounter {
+			rest = append(rest, f)
+			continue
+		}
+		key := f.CounterKey
+		if key == "" {
+			key = singularize(tableName) + "_id"
+		}
+		counters = append(counters, CounterData{
+			Table:      tableName,
+			Name:       f.Name,
+			ChildTable: f.CounterTable,
+			ForeignKey: key,
+		})
+	}
+	return rest, counters
+}
+
+// counterTriggers returns the counters whose sync triggers belong in the
+// migration of tableName. Triggers can only be created once both tables
+// exist, so they go with whichever table is generated second: the table's
+// own counters whose child table is already in schema.sql, plus counters on
+// other tables that count tableName's rows.
+func counterTriggers(schemaPath, tableName string, counters []CounterData, fields []FieldData) ([]CounterData, error) {
+	content, err := os.ReadFile(schemaPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	schema := string(content)
+
+	var triggers []CounterData
+	for _, c := range counters {
+		if c.ChildTable == tableName {
+			if !hasField(fields, c.ForeignKey) {
+				return nil, fmt.Errorf("counter %s counts %s by %s, but %s has no %s field", c.Name, c.ChildTable, c.ForeignKey, c.ChildTable, c.ForeignKey)
+			}
+			triggers = append(triggers, c)
+			continue
+		}
+		defined, err := schemaDefinesTable(schemaPath, c.ChildTable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema: %w", err)
+		}
+		if defined {
+			if !tableHasColumn(schema, c.ChildTable, c.ForeignKey) {
+				return nil, fmt.Errorf("counter %s counts %s by %s, but table %s has no %s column", c.Name, c.ChildTable, c.ForeignKey, c.ChildTable, c.ForeignKey)
+			}
+			triggers = append(triggers, c)
+		}
+	}
+
+	for _, c := range schemaCounters(schema) {
+		if c.ChildTable != tableName || c.Table == tableName {
+			continue
+		}
+		if !hasField(fields, c.ForeignKey) {
+			return nil, fmt.Errorf("%s.%s counts %s by %s; add a %s:references:%s field", c.Table, c.Name, tableName, c.ForeignKey, c.ForeignKey, c.Table)
+		}
+		triggers = append(triggers, c)
+	}
+	return triggers, nil
+}
+
+// schemaCounters finds the counter columns recorded in schema.sql.
+func schemaCounters(schema string) []CounterData {
+	var counters []CounterData
+	for _, m := range counterMarkerRe.FindAllStringSubmatchIndex(schema, -1) {
+		tables := createTableRe.FindAllStringSubmatch(schema[:m[0]], -1)
+		if len(tables) == 0 {
+			continue
+		}
+		counters = append(counters, CounterData{
+			Table:      tables[len(tables)-1][1],
+			Name:       schema[m[2]:m[3]],
+			ChildTable: schema[m[4]:m[5]],
+			ForeignKey: schema[m[6]:m[7]],
+		})
+	}
+	return counters
+}
+
+// tableHasColumn reports whether the CREATE TABLE statement for table in
+// schema declares column.
+func tableHasColumn(schema, table, column string) bool {
+	start := regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?["` + "`" + `]?` + regexp.QuoteMeta(table) + `["` + "`" + `]?\s*\(`).FindStringIndex(schema)
+	if start == nil {
+		return false
+	}
+	body := schema[start[1]:]
+	if end := strings.Index(body, ");"); end >= 0 {
+		body = body[:end]
+	}
+	return regexp.MustCompile(`(?im)^\s*["` + "`" + `]?` + regexp.QuoteMeta(column) + `["` + "`" + `]?\s`).MatchString(body)
+}
+
+func hasField(fields []FieldData, name string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return true
+		}
+	}
+	return false
+}
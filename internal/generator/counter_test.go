@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func generateCounterTestResource(t *testing.T, dir, name string, specs ...string) error {
+	t.Helper()
+	fields, err := parser.ParseFields(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return GenerateResource(dir, "testmodule", name, fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false)
+}
+
+func readMigration(t *testing.T, dir, table string) string {
+	t.Helper()
+	matches, _ := filepath.Glob(filepath.Join(dir, "database", "migrations", "*_create_"+table+".sql"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one migration for %s, found %v", table, matches)
+	}
+	content, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestCounterCache(t *testing.T) {
+	orders := map[string][]string{
+		"parent first": {"posts", "comments"},
+		"child first":  {"comments", "posts"},
+	}
+	for name, order := range orders {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, table := range order {
+				var err error
+				if table == "posts" {
+					err = generateCounterTestResource(t, dir, "posts", "title:string", "comments_count:counter(comments)")
+				} else {
+					err = generateCounterTestResource(t, dir, "comments", "post_id:references:posts", "body:text")
+				}
+				if err != nil {
+					t.Fatalf("generate %s: %v", table, err)
+				}
+			}
+
+			// Triggers belong to whichever migration runs second
+			second := readMigration(t, dir, order[1])
+			if !strings.Contains(second, "CREATE TRIGGER IF NOT EXISTS posts_comments_count_ai AFTER INSERT ON comments") {
+				t.Errorf("%s migration should create the counter triggers:\n%s", order[1], second)
+			}
+			if !strings.Contains(second, "DROP TRIGGER IF EXISTS posts_comments_count_ai") {
+				t.Errorf("%s migration should drop the counter triggers on down", order[1])
+			}
+			if first := readMigration(t, dir, order[0]); strings.Contains(first, "CREATE TRIGGER") {
+				t.Errorf("%s migration should not create triggers:\n%s", order[0], first)
+			}
+
+			// The generated schema keeps the counter in sync
+			schema, err := os.ReadFile(filepath.Join(dir, "database", "schema.sql"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			db := openTestDB(t, string(schema))
+			for _, stmt := range []string{
+				`INSERT INTO posts (id, title, created_at) VALUES ('p1', 'One', CURRENT_TIMESTAMP), ('p2', 'Two', CURRENT_TIMESTAMP)`,
+				`INSERT INTO comments (id, post_id, body, created_at) VALUES ('c1', 'p1', 'a', CURRENT_TIMESTAMP), ('c2', 'p1', 'b', CURRENT_TIMESTAMP), ('c3', 'p2', 'c', CURRENT_TIMESTAMP)`,
+				`DELETE FROM comments WHERE id = 'c3'`,
+				`UPDATE comments SET post_id = 'p2' WHERE id = 'c2'`,
+				`UPDATE comments SET body = 'edited' WHERE id = 'c1'`,
+			} {
+				if _, err := db.Exec(stmt); err != nil {
+					t.Fatalf("exec %q: %v", stmt, err)
+				}
+			}
+			for post, want := range map[string]int{"p1": 1, "p2": 1} {
+				var got int
+				if err := db.QueryRow(`SELECT comments_count FROM posts WHERE id = ?`, post).Scan(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("%s comments_count = %d, want %d", post, got, want)
+				}
+			}
+
+			// The list template shows the cached count
+			tmpl, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(tmpl), "{{.CommentsCount}} comments") {
+				t.Error("posts list should display the comments count")
+			}
+		})
+	}
+}
+
+func TestCounterCache_ChildWithoutForeignKey(t *testing.T) {
+	dir := t.TempDir()
+	if err := generateCounterTestResource(t, dir, "posts", "title:string", "comments_count:counter(comments)"); err != nil {
+		t.Fatal(err)
+	}
+	err := generateCounterTestResource(t, dir, "comments", "body:text")
+	if err == nil || !strings.Contains(err.Error(), "post_id") {
+		t.Fatalf("expected error about missing post_id, got %v", err)
+	}
+}
+
+func TestCounterCache_CustomForeignKey(t *testing.T) {
+	dir := t.TempDir()
+	if err := generateCounterTestResource(t, dir, "books", "title:string", "writer_id:references:authors"); err != nil {
+		t.Fatal(err)
+	}
+	// books already exists, so the authors migration creates the triggers
+	if err := GenerateSchema(dir, "testmodule", "authors", mustParseFields(t, "name:string", "book_total:counter(books.writer_id)"), "multi", "tailwind"); err != nil {
+		t.Fatal(err)
+	}
+	if m := readMigration(t, dir, "authors"); !strings.Contains(m, "UPDATE authors SET book_total = book_total + 1 WHERE id = new.writer_id") {
+		t.Errorf("triggers should use the custom foreign key:\n%s", m)
+	}
+}
+
+func mustParseFields(t *testing.T, specs ...string) []parser.Field {
+	t.Helper()
+	fields, err := parser.ParseFields(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
@@ -0,0 +1,17 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// CSRFPackage is the app package checking requests for cross-site request
+// forgery. `lvt new` generates it; apps created before it have none.
+const CSRFPackage = "shared/csrf/csrf.go"
+
+// CSRFEnabled reports whether the app in projectRoot has shared/csrf, so
+// generated forms carry its token.
+func CSRFEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, CSRFPackage))
+	return err == nil
+}
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestCSRF(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := GenerateApp("shop", "shop", kit, "tailwind", "", false); err != nil {
+				t.Fatalf("GenerateApp failed: %v", err)
+			}
+			if !CSRFEnabled("shop") {
+				t.Fatal("new apps should have shared/csrf")
+			}
+			for _, f := range []string{"csrf.go", "csrf_test.go"} {
+				path := filepath.Join("shop", "shared", "csrf", f)
+				if _, err := goparser.ParseFile(token.NewFileSet(), path, nil, goparser.AllErrors); err != nil {
+					t.Errorf("%s does not parse: %v", f, err)
+				}
+			}
+
+			main, err := os.ReadFile(filepath.Join("shop", "cmd", "shop", "main.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`"shop/shared/csrf"`, "\t\tcsrf.Middleware,\n"} {
+				if !strings.Contains(string(main), want) {
+					t.Errorf("main.go is missing %q", want)
+				}
+			}
+			config, err := os.ReadFile(filepath.Join("shop", "shared", "config", "config.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range []string{"CSRF_ENABLED", "CSRF_SECRET", "CSRF_TRUSTED_ORIGINS", "CSRF_EXEMPT"} {
+				if !strings.Contains(string(config), `{Name: "`+name+`"`) {
+					t.Errorf("config.go does not declare %s", name)
+				}
+			}
+
+			fields, err := fieldparser.ParseFields([]string{"name:string"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := GenerateResource("shop", "shop", "items", fields, kit, "tailwind", "tailwind", "infinite", 20, "modal", "", false, false); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			handlerPath := filepath.Join("shop", "app", "items", "items.go")
+			handler, err := os.ReadFile(handlerPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`"shop/shared/csrf"`, "state.CSRFToken = csrf.Token(ctx)"} {
+				if !strings.Contains(string(handler), want) {
+					t.Errorf("handler is missing %q", want)
+				}
+			}
+			if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+				t.Errorf("handler does not parse: %v", err)
+			}
+
+			tmpl, err := os.ReadFile(filepath.Join("shop", "app", "items", "items.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(tmpl), `{{define "csrfField"}}`) {
+				t.Error("template is missing the csrfField component")
+			}
+			// The add and edit forms
+			if n := strings.Count(string(tmpl), `{{template "csrfField" $}}`); n < 2 {
+				t.Errorf("template renders csrfField in %d forms, want at least 2", n)
+			}
+			if _, err := template.New("page").Parse(string(tmpl)); err != nil {
+				t.Errorf("template does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestCSRF_OlderApps(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	handler, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(handler), "csrf") {
+		t.Error("apps without shared/csrf should not import it")
+	}
+	tmpl, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(tmpl), "csrfField") {
+		t.Error("apps without shared/csrf should not render a token")
+	}
+}
+
+func TestCSRF_Auth(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		sharedCSRF bool
+		enable     bool
+		want       bool
+	}{
+		{"app with shared/csrf", true, true, true},
+		{"--no-csrf", true, false, false},
+		{"older app", false, true, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.sharedCSRF {
+				path := filepath.Join(dir, CSRFPackage)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("package csrf\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := GenerateAuth(dir, &AuthConfig{
+				ModuleName:          "shop",
+				EnablePassword:      true,
+				EnableMagicLink:     true,
+				EnablePasswordReset: true,
+				EnableCSRF:          tt.enable,
+			}); err != nil {
+				t.Fatalf("GenerateAuth failed: %v", err)
+			}
+
+			handlerPath := filepath.Join(dir, "app", "auth", "auth.go")
+			handler, err := os.ReadFile(handlerPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+				t.Errorf("auth.go does not parse: %v", err)
+			}
+			for _, s := range []string{`"shop/shared/csrf"`, "state.CSRFToken = csrf.Token(ctx)", "csrf.Token(r.Context())"} {
+				if got := strings.Contains(string(handler), s); got != tt.want {
+					t.Errorf("auth.go contains %q = %v, want %v", s, got, tt.want)
+				}
+			}
+
+			tmpl, err := os.ReadFile(filepath.Join(dir, "app", "auth", "auth.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Login, magic link, register and forgot password
+			want := 0
+			if tt.want {
+				want = 4
+			}
+			if n := strings.Count(string(tmpl), `name="_csrf" value="{{ .CSRFToken }}"`); n != want {
+				t.Errorf("auth.tmpl has %d token fields, want %d", n, want)
+			}
+			if _, err := template.New("auth").Parse(string(tmpl)); err != nil {
+				t.Errorf("auth.tmpl does not parse: %v", err)
+			}
+		})
+	}
+}
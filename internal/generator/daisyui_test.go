@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/config"
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestDaisyUIKitTheme(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := GenerateApp("shop", "shop", "daisyui", "tailwind", "dark", false); err != nil {
+		t.Fatalf("GenerateApp failed: %v", err)
+	}
+	cfg, err := config.LoadProjectConfig("shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Kit != "daisyui" || cfg.Theme != "dark" {
+		t.Errorf(".lvtrc kit=%q theme=%q, want daisyui and dark", cfg.Kit, cfg.Theme)
+	}
+
+	fields, err := fieldparser.ParseFields([]string{"name:string", "notes:text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource("shop", "shop", "items", fields, "daisyui", "daisyui", "tailwind", "infinite", 20, "modal", "", false, false); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	for _, path := range []string{
+		filepath.Join("shop", "app", "home", "home.tmpl"),
+		filepath.Join("shop", "app", "items", "items.tmpl"),
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := string(data)
+		for _, want := range []string{
+			`<html lang="en" data-theme="dark">`,
+			`daisyui@5/themes.css`,
+			`<select class="select select-sm w-auto" data-theme-switcher`,
+			`<option value="corporate">Corporate</option>`,
+			`localStorage.getItem(key)`,
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s: missing %s", path, want)
+			}
+		}
+		if strings.Contains(content, "#c00") || strings.Contains(content, "background: white") {
+			t.Errorf("%s: has colors that ignore the theme", path)
+		}
+		if _, err := template.New("page").Parse(content); err != nil {
+			t.Errorf("%s does not parse: %v", path, err)
+		}
+	}
+}
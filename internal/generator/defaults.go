@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// SQLDefault returns the field's default as an SQL literal for the DEFAULT
+// clause of its column.
+func (f FieldData) SQLDefault() string {
+	switch f.GoType {
+	case "bool":
+		if f.Default == "true" {
+			return "1"
+		}
+		return "0"
+	case "int64", "float64":
+		return f.Default
+	case "time.Time":
+		return "CURRENT_TIMESTAMP" // the only time default is "now"
+	}
+	return "'" + strings.ReplaceAll(f.Default, "'", "''") + "'"
+}
+
+// GoDefault returns the field's default as a Go expression of its type.
+func (f FieldData) GoDefault() string {
+	switch f.GoType {
+	case "bool", "int64", "float64":
+		return f.Default
+	case "time.Time":
+		return "time.Now()"
+	}
+	return strconv.Quote(f.Default)
+}
+
+// DefaultFields returns the form fields the handler gives their default
+// when they are submitted empty. Bools are left out: an unchecked box is a
+// choice, so their default only checks the box in the add form.
+func (d ResourceData) DefaultFields() []FieldData {
+	var result []FieldData
+	for _, f := range d.NonFileFields() {
+		if f.HasDefault && f.GoType != "bool" {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// FormDefault returns the value the add form starts with: the default,
+// HTML-escaped, or "" for times, whose "now" is only known on submit.
+func (f FieldData) FormDefault() string {
+	if f.GoType == "time.Time" {
+		return ""
+	}
+	return html.EscapeString(f.Default)
+}
@@ -0,0 +1,348 @@
+package generator
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/seeder"
+)
+
+// SchemaDiff is the drift between database/schema.sql (which sqlc generates
+// the models from) and a live SQLite database.
+type SchemaDiff struct {
+	Database          string      `json:"database"`
+	PendingMigrations []string    `json:"pending_migrations,omitempty"`
+	MissingTables     []string    `json:"missing_tables,omitempty"`
+	ExtraTables       []string    `json:"extra_tables,omitempty"`
+	Tables            []TableDiff `json:"tables,omitempty"`
+	MissingIndexes    []string    `json:"missing_indexes,omitempty"`
+	ExtraIndexes      []string    `json:"extra_indexes,omitempty"`
+	MissingTriggers   []string    `json:"missing_triggers,omitempty"`
+}
+
+// TableDiff lists the column drift of a table present on both sides.
+type TableDiff struct {
+	Table          string        `json:"table"`
+	MissingColumns []string      `json:"missing_columns,omitempty"`
+	ExtraColumns   []string      `json:"extra_columns,omitempty"`
+	ChangedColumns []ColumnDrift `json:"changed_columns,omitempty"`
+}
+
+// ColumnDrift is a column whose type or nullability differs.
+type ColumnDrift struct {
+	Column   string `json:"column"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// HasDrift reports whether the database differs from schema.sql in any way.
+func (d *SchemaDiff) HasDrift() bool {
+	return len(d.PendingMigrations) > 0 || len(d.MissingTables) > 0 || len(d.ExtraTables) > 0 ||
+		len(d.Tables) > 0 || len(d.MissingIndexes) > 0 || len(d.ExtraIndexes) > 0 || len(d.MissingTriggers) > 0
+}
+
+var (
+	virtualTableRegex = regexp.MustCompile(`(?i)CREATE\s+VIRTUAL\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+	triggerRegex      = regexp.MustCompile(`(?i)CREATE\s+TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+)
+
+// DiffSchema compares basePath/database/schema.sql and the migrations in
+// basePath/database/migrations against the SQLite database at dbPath.
+// Tables, columns (type and NOT NULL), indexes, FTS tables and triggers
+// declared in schema.sql are checked; goose and SQLite internal tables and
+// FTS shadow tables are ignored.
+func DiffSchema(basePath, dbPath string) (*SchemaDiff, error) {
+	schemaPath := filepath.Join(basePath, "database", "schema.sql")
+	content, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("schema.sql not found at %s", schemaPath)
+	}
+	tables, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("database not found at %s", dbPath)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	live, err := readSQLiteObjects(db)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SchemaDiff{Database: dbPath}
+	if diff.PendingMigrations, err = pendingMigrations(db, filepath.Join(basePath, "database", "migrations")); err != nil {
+		return nil, err
+	}
+
+	expectedTables := map[string]bool{}
+	expectedIndexes := map[string]bool{}
+	for _, t := range tables {
+		expectedTables[t.Name] = true
+		for _, idx := range t.Indexes {
+			expectedIndexes[idx.Name] = true
+		}
+		if !live.tables[t.Name] {
+			diff.MissingTables = append(diff.MissingTables, t.Name)
+			continue
+		}
+		info, err := IntrospectTable(db, t.Name)
+		if err != nil {
+			return nil, err
+		}
+		if td := diffColumns(t, info); td != nil {
+			diff.Tables = append(diff.Tables, *td)
+		}
+	}
+
+	ddl := string(content)
+	for _, m := range virtualTableRegex.FindAllStringSubmatch(ddl, -1) {
+		expectedTables[m[1]] = true
+		if !live.virtual[m[1]] {
+			diff.MissingTables = append(diff.MissingTables, m[1])
+		}
+	}
+	for _, m := range triggerRegex.FindAllStringSubmatch(ddl, -1) {
+		if !live.triggers[m[1]] {
+			diff.MissingTriggers = append(diff.MissingTriggers, m[1])
+		}
+	}
+
+	for name := range live.tables {
+		if !expectedTables[name] && !live.isShadowTable(name) {
+			diff.ExtraTables = append(diff.ExtraTables, name)
+		}
+	}
+	for name := range expectedIndexes {
+		if !live.indexes[name] {
+			diff.MissingIndexes = append(diff.MissingIndexes, name)
+		}
+	}
+	for name, table := range live.indexOwners {
+		if !expectedIndexes[name] && expectedTables[table] {
+			diff.ExtraIndexes = append(diff.ExtraIndexes, name)
+		}
+	}
+
+	for _, list := range [][]string{diff.MissingTables, diff.ExtraTables, diff.MissingIndexes, diff.ExtraIndexes, diff.MissingTriggers} {
+		sort.Strings(list)
+	}
+	return diff, nil
+}
+
+// sqliteObjects is what sqlite_master holds for the diff.
+type sqliteObjects struct {
+	tables      map[string]bool
+	virtual     map[string]bool
+	indexes     map[string]bool
+	indexOwners map[string]string // explicit index name -> table
+	triggers    map[string]bool
+}
+
+func readSQLiteObjects(db *sql.DB) (*sqliteObjects, error) {
+	objs := &sqliteObjects{
+		tables:      map[string]bool{},
+		virtual:     map[string]bool{},
+		indexes:     map[string]bool{},
+		indexOwners: map[string]string{},
+		triggers:    map[string]bool{},
+	}
+	rows, err := db.Query("SELECT type, name, tbl_name, sql FROM sqlite_master")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database schema: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var typ, name, table string
+		var ddl sql.NullString
+		if err := rows.Scan(&typ, &name, &table, &ddl); err != nil {
+			return nil, fmt.Errorf("failed to read database schema: %w", err)
+		}
+		if strings.HasPrefix(name, "sqlite_") || table == migrationsTable || table == migrationLockTable {
+			continue
+		}
+		switch typ {
+		case "table":
+			if virtualTableRegex.MatchString(ddl.String) {
+				objs.virtual[name] = true
+			} else {
+				objs.tables[name] = true
+			}
+		case "index":
+			objs.indexes[name] = true
+			// Automatic indexes (UNIQUE, PRIMARY KEY) have no SQL
+			if ddl.Valid {
+				objs.indexOwners[name] = table
+			}
+		case "trigger":
+			objs.triggers[name] = true
+		}
+	}
+	return objs, rows.Err()
+}
+
+// isShadowTable reports whether name is one of the tables SQLite keeps for a
+// virtual table (posts_fts_data, posts_fts_idx, ...).
+func (o *sqliteObjects) isShadowTable(name string) bool {
+	for v := range o.virtual {
+		if strings.HasPrefix(name, v+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	migrationsTable    = "goose_db_version"
+	migrationLockTable = "lvt_migration_lock" // see internal/migration/lock.go
+)
+
+// pendingMigrations returns the migration files in dir whose version is not
+// recorded as applied in goose_db_version.
+func pendingMigrations(db *sql.DB, dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+
+	applied := map[int64]bool{}
+	var exists int
+	if err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", migrationsTable).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	if exists > 0 {
+		rows, err := db.Query("SELECT version_id FROM " + migrationsTable + " WHERE is_applied")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration status: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var version int64
+			if err := rows.Scan(&version); err != nil {
+				return nil, fmt.Errorf("failed to read migration status: %w", err)
+			}
+			applied[version] = true
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read migration status: %w", err)
+		}
+	}
+
+	var pending []string
+	for _, file := range files {
+		name := filepath.Base(file)
+		if ext := filepath.Ext(name); ext != ".sql" && (ext != ".go" || strings.HasSuffix(name, "_test.go")) {
+			continue
+		}
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if !applied[version] {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+// diffColumns compares the columns schema.sql declares for a table with the
+// live ones. It returns nil when they match.
+func diffColumns(expected seeder.TableSchema, actual *TableInfo) *TableDiff {
+	td := &TableDiff{Table: expected.Name}
+	liveCols := map[string]TableColumn{}
+	for _, c := range actual.Columns {
+		liveCols[c.Name] = c
+	}
+
+	for _, want := range expected.Columns {
+		got, ok := liveCols[want.Name]
+		if !ok {
+			td.MissingColumns = append(td.MissingColumns, want.Name)
+			continue
+		}
+		delete(liveCols, want.Name)
+
+		wantDesc := columnDescription(want.Type, !want.Nullable || want.IsPrimary)
+		gotDesc := columnDescription(got.Type, got.NotNull || got.PrimaryKey)
+		if wantDesc != gotDesc {
+			td.ChangedColumns = append(td.ChangedColumns, ColumnDrift{Column: want.Name, Expected: wantDesc, Actual: gotDesc})
+		}
+	}
+	for _, c := range actual.Columns {
+		if _, extra := liveCols[c.Name]; extra {
+			td.ExtraColumns = append(td.ExtraColumns, c.Name)
+		}
+	}
+
+	if len(td.MissingColumns) == 0 && len(td.ExtraColumns) == 0 && len(td.ChangedColumns) == 0 {
+		return nil
+	}
+	return td
+}
+
+// columnDescription renders a column's declared type and nullability the
+// same way for both sides ("TEXT NOT NULL"). Only the first word of the type
+// is compared, since schema.sql parsing keeps no more than that.
+func columnDescription(sqlType string, notNull bool) string {
+	desc := "?"
+	if fields := strings.Fields(strings.ToUpper(sqlType)); len(fields) > 0 {
+		desc = fields[0]
+	}
+	if notNull {
+		desc += " NOT NULL"
+	}
+	return desc
+}
+
+// FormatSchemaDiff renders d for the terminal.
+func FormatSchemaDiff(d *SchemaDiff) string {
+	var b strings.Builder
+	if !d.HasDrift() {
+		fmt.Fprintf(&b, "✅ %s matches schema.sql\n", d.Database)
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Schema drift between schema.sql and %s:\n", d.Database)
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&b, "  %s\n", item)
+		}
+	}
+	section("Pending migrations (run: lvt migration up)", d.PendingMigrations)
+	section("Tables missing from the database", d.MissingTables)
+	section("Tables not in schema.sql", d.ExtraTables)
+	for _, t := range d.Tables {
+		var items []string
+		for _, c := range t.MissingColumns {
+			items = append(items, "- "+c+" (missing from the database)")
+		}
+		for _, c := range t.ExtraColumns {
+			items = append(items, "+ "+c+" (not in schema.sql)")
+		}
+		for _, c := range t.ChangedColumns {
+			items = append(items, fmt.Sprintf("~ %s: schema.sql has %s, database has %s", c.Column, c.Expected, c.Actual))
+		}
+		section("Table "+t.Table, items)
+	}
+	section("Indexes missing from the database", d.MissingIndexes)
+	section("Indexes not in schema.sql", d.ExtraIndexes)
+	section("Triggers missing from the database", d.MissingTriggers)
+	return b.String()
+}
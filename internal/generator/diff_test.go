@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const diffTestSchema = `-- posts
+CREATE TABLE IF NOT EXISTS posts (
+  id TEXT PRIMARY KEY NOT NULL,
+  title TEXT NOT NULL,
+  views INTEGER NOT NULL,
+  created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at);
+CREATE VIRTUAL TABLE IF NOT EXISTS posts_fts USING fts5(title, content=posts, content_rowid=rowid);
+CREATE TRIGGER IF NOT EXISTS posts_ai AFTER INSERT ON posts BEGIN
+  INSERT INTO posts_fts(rowid, title) VALUES (new.rowid, new.title);
+END;
+`
+
+// setupDiffTestProject writes diffTestSchema as database/schema.sql and
+// applies it, plus the extra statements, to a fresh app.db.
+func setupDiffTestProject(t *testing.T, extra ...string) (dir, dbPath string) {
+	t.Helper()
+	dir = t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "database", "migrations"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "database", "schema.sql"), []byte(diffTestSchema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dbPath = filepath.Join(dir, "app.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range append([]string{diffTestSchema}, extra...) {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("exec %q: %v", stmt, err)
+		}
+	}
+	return dir, dbPath
+}
+
+func TestDiffSchema_NoDrift(t *testing.T) {
+	dir, dbPath := setupDiffTestProject(t)
+
+	diff, err := DiffSchema(dir, dbPath)
+	if err != nil {
+		t.Fatalf("DiffSchema: %v", err)
+	}
+	if diff.HasDrift() {
+		t.Errorf("expected no drift (FTS shadow tables must be ignored), got %+v", diff)
+	}
+	if out := FormatSchemaDiff(diff); !strings.Contains(out, "matches schema.sql") {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+}
+
+func TestDiffSchema_Drift(t *testing.T) {
+	dir, dbPath := setupDiffTestProject(t,
+		`ALTER TABLE posts ADD COLUMN legacy TEXT`,
+		`DROP INDEX idx_posts_created_at`,
+		`CREATE INDEX idx_posts_title ON posts(title)`,
+		`DROP TRIGGER posts_ai`,
+		`CREATE TABLE scratch (id INTEGER)`,
+	)
+	migration := filepath.Join(dir, "database", "migrations", "20240101000000_add_views.sql")
+	if err := os.WriteFile(migration, []byte("-- +goose Up\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// schema.sql now expects a column the database never got
+	schema := strings.Replace(diffTestSchema, "  views INTEGER NOT NULL,\n", "  views TEXT,\n  slug TEXT NOT NULL,\n", 1)
+	if err := os.WriteFile(filepath.Join(dir, "database", "schema.sql"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffSchema(dir, dbPath)
+	if err != nil {
+		t.Fatalf("DiffSchema: %v", err)
+	}
+	if !diff.HasDrift() {
+		t.Fatal("expected drift")
+	}
+
+	want := &SchemaDiff{
+		Database:          dbPath,
+		PendingMigrations: []string{"20240101000000_add_views.sql"},
+		ExtraTables:       []string{"scratch"},
+		Tables: []TableDiff{{
+			Table:          "posts",
+			MissingColumns: []string{"slug"},
+			ExtraColumns:   []string{"legacy"},
+			ChangedColumns: []ColumnDrift{{Column: "views", Expected: "TEXT", Actual: "INTEGER NOT NULL"}},
+		}},
+		MissingIndexes:  []string{"idx_posts_created_at"},
+		ExtraIndexes:    []string{"idx_posts_title"},
+		MissingTriggers: []string{"posts_ai"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("diff mismatch\n got: %+v\nwant: %+v", diff, want)
+	}
+
+	out := FormatSchemaDiff(diff)
+	for _, line := range []string{
+		"20240101000000_add_views.sql",
+		"- slug (missing from the database)",
+		"+ legacy (not in schema.sql)",
+		"~ views: schema.sql has TEXT, database has INTEGER NOT NULL",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("report missing %q:\n%s", line, out)
+		}
+	}
+}
+
+func TestDiffSchema_AppliedMigrationsAreNotPending(t *testing.T) {
+	dir, dbPath := setupDiffTestProject(t,
+		`CREATE TABLE goose_db_version (id INTEGER PRIMARY KEY AUTOINCREMENT, version_id INTEGER NOT NULL, is_applied INTEGER NOT NULL, tstamp TIMESTAMP DEFAULT (datetime('now')))`,
+		`INSERT INTO goose_db_version (version_id, is_applied) VALUES (20240101000000, 1)`,
+	)
+	migration := filepath.Join(dir, "database", "migrations", "20240101000000_create_posts.sql")
+	if err := os.WriteFile(migration, []byte("-- +goose Up\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffSchema(dir, dbPath)
+	if err != nil {
+		t.Fatalf("DiffSchema: %v", err)
+	}
+	if diff.HasDrift() {
+		t.Errorf("expected no drift, got %+v", diff)
+	}
+}
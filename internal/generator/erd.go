@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/seeder"
+)
+
+// ERD output formats.
+const (
+	ERDFormatMermaid = "mermaid"
+	ERDFormatDOT     = "dot"
+)
+
+// erdTable is a table in the diagram, annotated from the resource registry.
+type erdTable struct {
+	schema seeder.TableSchema
+	kind   string // registry type ("resource", "api", "schema"), "" if unregistered
+	path   string // route from the registry, if any
+}
+
+// erdManyToMany is a join table collapsed into a many-to-many edge.
+type erdManyToMany struct {
+	join        string
+	left, right seeder.ForeignKey
+}
+
+// GenerateERD renders an entity-relationship diagram of database/schema.sql
+// in the given format. Foreign keys become one-to-many edges. Join tables
+// (nothing but two references besides id and timestamps) are drawn as a
+// single many-to-many edge between the tables they link. Tables registered
+// in .lvtresources are labelled with their kind and route.
+func GenerateERD(basePath, format string) (string, error) {
+	useInflections(basePath)
+	if format != ERDFormatMermaid && format != ERDFormatDOT {
+		return "", fmt.Errorf("invalid format %q (expected %s or %s)", format, ERDFormatMermaid, ERDFormatDOT)
+	}
+
+	schemaPath := filepath.Join(basePath, "database", "schema.sql")
+	if _, err := os.Stat(schemaPath); err != nil {
+		return "", fmt.Errorf("schema.sql not found at %s", schemaPath)
+	}
+	schemas, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse schema: %w", err)
+	}
+	entries, err := ReadResources(basePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read .lvtresources: %w", err)
+	}
+
+	registry := map[string]ResourceEntry{}
+	for _, e := range entries {
+		table := e.Table
+		if table == "" {
+			table = pluralize(singularize(strings.ToLower(e.Name)))
+		}
+		// Prefer the page route over the API route for the label
+		if prev, ok := registry[table]; !ok || prev.Type != "resource" {
+			registry[table] = e
+		}
+	}
+
+	var tables []erdTable
+	var joins []erdManyToMany
+	for _, s := range schemas {
+		if left, right, ok := joinTableRefs(s); ok {
+			joins = append(joins, erdManyToMany{join: s.Name, left: left, right: right})
+			continue
+		}
+		t := erdTable{schema: s}
+		if e, ok := registry[s.Name]; ok {
+			t.kind, t.path = e.Type, e.Path
+		}
+		tables = append(tables, t)
+	}
+
+	if format == ERDFormatDOT {
+		return renderERDDot(tables, joins), nil
+	}
+	return renderERDMermaid(tables, joins), nil
+}
+
+// joinTableRefs reports whether t is a pure join table: exactly two foreign
+// keys and no columns besides them, id, and created_at/updated_at.
+func joinTableRefs(t seeder.TableSchema) (seeder.ForeignKey, seeder.ForeignKey, bool) {
+	if len(t.ForeignKeys) != 2 {
+		return seeder.ForeignKey{}, seeder.ForeignKey{}, false
+	}
+	for _, c := range t.Columns {
+		switch c.Name {
+		case "id", "created_at", "updated_at", t.ForeignKeys[0].Column, t.ForeignKeys[1].Column:
+		default:
+			return seeder.ForeignKey{}, seeder.ForeignKey{}, false
+		}
+	}
+	return t.ForeignKeys[0], t.ForeignKeys[1], true
+}
+
+var nonWord = regexp.MustCompile(`\W+`)
+
+func renderERDMermaid(tables []erdTable, joins []erdManyToMany) string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+
+	for _, t := range tables {
+		if t.kind != "" {
+			fmt.Fprintf(&b, "    %%%% %s: %s %s\n", t.schema.Name, t.kind, t.path)
+		}
+		fmt.Fprintf(&b, "    %s {\n", t.schema.Name)
+		fks := foreignKeyColumns(t.schema)
+		for _, c := range t.schema.Columns {
+			var keys []string
+			if c.IsPrimary {
+				keys = append(keys, "PK")
+			}
+			if fks[c.Name] {
+				keys = append(keys, "FK")
+			}
+			line := fmt.Sprintf("        %s %s", mermaidType(c.Type), c.Name)
+			if len(keys) > 0 {
+				line += " " + strings.Join(keys, ",")
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, t := range tables {
+		for _, fk := range t.schema.ForeignKeys {
+			parent := "||"
+			if c := t.schema.Column(fk.Column); c != nil && c.Nullable {
+				parent = "|o"
+			}
+			fmt.Fprintf(&b, "    %s %s--o{ %s : %q\n", fk.RefTable, parent, t.schema.Name, fk.Column)
+		}
+	}
+	for _, j := range joins {
+		fmt.Fprintf(&b, "    %s }o--o{ %s : %q\n", j.left.RefTable, j.right.RefTable, j.join)
+	}
+	return b.String()
+}
+
+// mermaidType turns a SQL type into a Mermaid attribute type token
+// (VARCHAR(255) -> VARCHAR).
+func mermaidType(sqlType string) string {
+	t := nonWord.ReplaceAllString(strings.SplitN(sqlType, "(", 2)[0], "_")
+	if t == "" {
+		return "TEXT"
+	}
+	return t
+}
+
+func renderERDDot(tables []erdTable, joins []erdManyToMany) string {
+	var b strings.Builder
+	b.WriteString("digraph erd {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=record, fontname=\"Helvetica\", fontsize=10];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=9, dir=both, arrowtail=tee, arrowhead=crow];\n")
+
+	for _, t := range tables {
+		header := t.schema.Name
+		if t.kind != "" {
+			header += `\n` + t.kind + " " + t.path
+		}
+		fks := foreignKeyColumns(t.schema)
+		var rows []string
+		for _, c := range t.schema.Columns {
+			row := c.Name + " : " + c.Type
+			if c.IsPrimary {
+				row += " PK"
+			}
+			if fks[c.Name] {
+				row += " FK"
+			}
+			rows = append(rows, dotEscape(row)+`\l`)
+		}
+		fmt.Fprintf(&b, "  %q [label=\"{%s|%s}\"];\n", t.schema.Name, dotEscape(header), strings.Join(rows, ""))
+	}
+
+	// Edges point from the parent to the rows that reference it
+	for _, t := range tables {
+		for _, fk := range t.schema.ForeignKeys {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", fk.RefTable, t.schema.Name, fk.Column)
+		}
+	}
+	for _, j := range joins {
+		fmt.Fprintf(&b, "  %q -> %q [label=%q, arrowtail=crow, style=dashed];\n", j.left.RefTable, j.right.RefTable, j.join)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotEscape escapes the characters that are special in record labels.
+func dotEscape(s string) string {
+	r := strings.NewReplacer(`{`, `\{`, `}`, `\}`, `|`, `\|`, `<`, `\<`, `>`, `\>`, `"`, `\"`)
+	return r.Replace(s)
+}
+
+func foreignKeyColumns(t seeder.TableSchema) map[string]bool {
+	cols := map[string]bool{}
+	for _, fk := range t.ForeignKeys {
+		cols[fk.Column] = true
+	}
+	return cols
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func generateERDTestProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	resources := [][]string{
+		{"posts", "title:string"},
+		{"tags", "name:string"},
+		{"post_tags", "post_id:references:posts", "tag_id:references:tags"},
+		{"comments", "post_id:references:posts:set_null", "body:text"},
+	}
+	for _, r := range resources {
+		if err := generateCounterTestResource(t, dir, r[0], r[1:]...); err != nil {
+			t.Fatalf("generate %s: %v", r[0], err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateERDMermaid(t *testing.T) {
+	dir := generateERDTestProject(t)
+
+	out, err := GenerateERD(dir, ERDFormatMermaid)
+	if err != nil {
+		t.Fatalf("GenerateERD: %v", err)
+	}
+	for _, want := range []string{
+		"erDiagram\n",
+		"    %% posts: resource /posts\n    posts {\n        TEXT id PK\n",
+		"        TEXT post_id FK\n",
+		`    posts ||--o{ comments : "post_id"`,
+		`    posts }o--o{ tags : "post_tags"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diagram missing %q\n%s", want, out)
+		}
+	}
+	// The join table is drawn as an edge, not an entity
+	if strings.Contains(out, "post_tags {") {
+		t.Errorf("join table should be collapsed into a many-to-many edge\n%s", out)
+	}
+}
+
+func TestGenerateERDDot(t *testing.T) {
+	dir := generateERDTestProject(t)
+
+	out, err := GenerateERD(dir, ERDFormatDOT)
+	if err != nil {
+		t.Fatalf("GenerateERD: %v", err)
+	}
+	for _, want := range []string{
+		"digraph erd {",
+		`"posts" [label="{posts\nresource /posts|id : TEXT PK\ltitle : TEXT\l`,
+		`"posts" -> "comments" [label="post_id"];`,
+		`"posts" -> "tags" [label="post_tags", arrowtail=crow, style=dashed];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diagram missing %q\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Error("dot output should end with a closing brace")
+	}
+}
+
+func TestGenerateERDErrors(t *testing.T) {
+	if _, err := GenerateERD(t.TempDir(), ERDFormatMermaid); err == nil || !strings.Contains(err.Error(), "schema.sql not found") {
+		t.Errorf("missing schema: got %v", err)
+	}
+	dir := generateERDTestProject(t)
+	if _, err := GenerateERD(dir, "svg"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
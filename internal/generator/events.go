@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// EventsData is the template data for the app/events package.
+type EventsData struct {
+	ModuleName string
+}
+
+// eventsPackagePath is the file whose presence marks the events package as
+// generated by an earlier --emit-events resource.
+const eventsPackagePath = "app/events/events.go"
+
+// deliverEventJobPath is the queue job delivering events to the webhook.
+const deliverEventJobPath = "app/jobs/deliver_event.go"
+
+// EventsEnabled reports whether the app/events package exists in projectRoot.
+func EventsEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, eventsPackagePath))
+	return err == nil
+}
+
+// generateEvents writes the app/events package on first use, the resource's
+// payload schema, and the queue delivery job when the job queue is set up.
+func generateEvents(projectRoot string, data ResourceData, kitLoader *kits.KitLoader, kitName string) error {
+	eventsDir := filepath.Join(projectRoot, "app", "events")
+	if err := os.MkdirAll(filepath.Join(eventsDir, "schemas"), 0755); err != nil {
+		return fmt.Errorf("failed to create app/events directory: %w", err)
+	}
+
+	if !EventsEnabled(projectRoot) {
+		eventsData := EventsData{ModuleName: data.ModuleName}
+		for _, f := range []string{"events.go", "webhook.go"} {
+			if err := writeTemplateFile(kitLoader, kitName, "events/"+f+".tmpl", filepath.Join(eventsDir, f), eventsData); err != nil {
+				return fmt.Errorf("failed to generate app/events/%s: %w", f, err)
+			}
+		}
+	}
+
+	schema, err := eventSchema(data)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(eventsDir, "schemas", data.TableName+".json"), schema, 0644); err != nil {
+		return fmt.Errorf("failed to write event schema: %w", err)
+	}
+
+	return generateEventDeliveryJob(projectRoot, data.ModuleName, kitLoader, kitName)
+}
+
+// generateEventDeliveryJob adds the job that delivers queued events
+// (EVENTS_SINK=queue) once both the events package and the job queue exist.
+func generateEventDeliveryJob(projectRoot, moduleName string, kitLoader *kits.KitLoader, kitName string) error {
+	workerPath := filepath.Join(projectRoot, "app", "jobs", "worker.go")
+	jobPath := filepath.Join(projectRoot, deliverEventJobPath)
+	if !EventsEnabled(projectRoot) {
+		return nil
+	}
+	if _, err := os.Stat(workerPath); err != nil {
+		return nil // no job queue; EVENTS_SINK=queue becomes available after `lvt gen queue`
+	}
+	if _, err := os.Stat(jobPath); err == nil {
+		return nil
+	}
+
+	if err := writeTemplateFile(kitLoader, kitName, "events/deliver_job.go.tmpl", jobPath, EventsData{ModuleName: moduleName}); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", deliverEventJobPath, err)
+	}
+	if err := injectWorkerRegistration(workerPath, "DeliverEvent"); err != nil {
+		return fmt.Errorf("failed to register event delivery worker: %w", err)
+	}
+	return nil
+}
+
+// jsonSchema is the subset of JSON Schema used for event payloads.
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	ID          string                 `json:"$id,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Const       string                 `json:"const,omitempty"`
+	Enum        []string               `json:"enum,omitempty"`
+	Ref         string                 `json:"$ref,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Defs        map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// eventSchema returns the JSON Schema of the events published for a
+// resource. Record properties use the JSON names sqlc emits for the model.
+func eventSchema(data ResourceData) ([]byte, error) {
+	resource := data.TableName
+	actions := []string{"created", "updated", "deleted"}
+	types := make([]string, len(actions))
+	for i, a := range actions {
+		types[i] = resource + "." + a
+	}
+
+	record := &jsonSchema{
+		Type:       "object",
+		Properties: map[string]*jsonSchema{"id": {Type: "string"}},
+		Required:   []string{"id"},
+	}
+	for _, f := range data.Fields {
+		record.Properties[f.Name] = goTypeSchema(f.GoType)
+		record.Required = append(record.Required, f.Name)
+		if f.IsFile {
+			for _, suffix := range []string{"_filename", "_content_type"} {
+				record.Properties[f.Name+suffix] = &jsonSchema{Type: "string"}
+			}
+			record.Properties[f.Name+"_size"] = &jsonSchema{Type: "integer"}
+		}
+	}
+	for _, g := range data.Generated {
+		record.Properties[g.Name] = &jsonSchema{Type: "string"}
+	}
+	for _, c := range data.Counters {
+		record.Properties[c.Name] = &jsonSchema{Type: "integer"}
+	}
+	if data.WithAuthz {
+		record.Properties["created_by"] = &jsonSchema{Type: "string"}
+	}
+	record.Properties["created_at"] = &jsonSchema{Type: "string", Format: "date-time"}
+	record.Required = append(record.Required, "created_at")
+
+	schema := &jsonSchema{
+		Schema:      "https://json-schema.org/draft/2020-12/schema",
+		ID:          resource + ".json",
+		Title:       data.ResourceNameSingular + " event",
+		Description: fmt.Sprintf("Payload of %s events. data is the record after the change (before it, for deletes); previous is the record before an update.", resource),
+		Type:        "object",
+		Required:    []string{"id", "type", "resource", "action", "record_id", "occurred_at"},
+		Properties: map[string]*jsonSchema{
+			"id":          {Type: "string"},
+			"type":        {Enum: types},
+			"resource":    {Const: resource},
+			"action":      {Enum: actions},
+			"record_id":   {Type: "string"},
+			"data":        {Ref: "#/$defs/record"},
+			"previous":    {Ref: "#/$defs/record"},
+			"occurred_at": {Type: "string", Format: "date-time"},
+		},
+		Defs: map[string]*jsonSchema{"record": record},
+	}
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event schema: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+func goTypeSchema(goType string) *jsonSchema {
+	switch goType {
+	case "int", "int64":
+		return &jsonSchema{Type: "integer"}
+	case "float64":
+		return &jsonSchema{Type: "number"}
+	case "bool":
+		return &jsonSchema{Type: "boolean"}
+	case "time.Time":
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
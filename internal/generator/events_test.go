@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+func generateEventsTestResource(t *testing.T, dir, name string, opts ResourceOptions, specs ...string) error {
+	t.Helper()
+	fields, err := fieldparser.ParseFields(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return GenerateResource(dir, "testmodule", name, fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false, opts)
+}
+
+func TestResourceEmitEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "notes", ResourceOptions{}, "body:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	if EventsEnabled(tmpDir) {
+		t.Error("events package should not be generated without EmitEvents")
+	}
+	notes, err := os.ReadFile(filepath.Join(tmpDir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(notes), "emitEvent") {
+		t.Error("resource without EmitEvents should not publish events")
+	}
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{EmitEvents: true}, "title:string", "views:int", "published_at:time"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	for _, name := range []string{"events.go", "webhook.go"} {
+		path := filepath.Join(tmpDir, "app", "events", name)
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"testmodule/app/events"`, "events.Created", "events.Updated", "events.Deleted", "func (c *PostsController) emitEvent("} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), handlerPath, nil, parser.AllErrors); err != nil {
+		t.Errorf("handler does not parse: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tmpDir, "app", "events", "schemas", "posts.json"))
+	if err != nil {
+		t.Fatalf("event schema was not written: %v", err)
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]struct {
+				Type   string `json:"type"`
+				Format string `json:"format"`
+			} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatalf("event schema is not valid JSON: %v", err)
+	}
+	if !strings.Contains(string(schema.Properties["type"]), `"posts.updated"`) {
+		t.Errorf("schema type enum missing posts.updated: %s", schema.Properties["type"])
+	}
+	record := schema.Defs["record"].Properties
+	if record["views"].Type != "integer" {
+		t.Errorf("views should be an integer, got %q", record["views"].Type)
+	}
+	if record["published_at"].Format != "date-time" {
+		t.Errorf("published_at should be a date-time, got %q", record["published_at"].Format)
+	}
+	if _, ok := record["title"]; !ok {
+		t.Error("schema record missing title")
+	}
+
+	// No job queue yet, so the queue delivery job isn't generated
+	if _, err := os.Stat(filepath.Join(tmpDir, deliverEventJobPath)); err == nil {
+		t.Error("deliver_event.go should not be generated without a job queue")
+	}
+}
+
+func TestEventDeliveryJob(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{EmitEvents: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	if err := GenerateQueue(tmpDir, "testmodule"); err != nil {
+		t.Fatalf("GenerateQueue failed: %v", err)
+	}
+
+	jobPath := filepath.Join(tmpDir, deliverEventJobPath)
+	job, err := os.ReadFile(jobPath)
+	if err != nil {
+		t.Fatalf("deliver_event.go was not created: %v", err)
+	}
+	if !strings.Contains(string(job), `Kind() string { return "deliver_event" }`) {
+		t.Error("deliver_event.go missing job kind")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), jobPath, nil, parser.AllErrors); err != nil {
+		t.Errorf("deliver_event.go does not parse: %v", err)
+	}
+	worker, err := os.ReadFile(filepath.Join(tmpDir, "app", "jobs", "worker.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(worker), "river.AddWorker(workers, &DeliverEventWorker{})") {
+		t.Error("worker.go missing DeliverEventWorker registration")
+	}
+
+	// A second resource leaves the existing job and registration alone
+	if err := generateEventsTestResource(t, tmpDir, "comments", ResourceOptions{EmitEvents: true}, "body:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	worker, _ = os.ReadFile(filepath.Join(tmpDir, "app", "jobs", "worker.go"))
+	if n := strings.Count(string(worker), "&DeliverEventWorker{}"); n != 1 {
+		t.Errorf("expected one DeliverEventWorker registration, got %d", n)
+	}
+}
+
+func TestResourceEmitEventsRejectsParent(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	fields, err := fieldparser.ParseFields([]string{"body:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "comments", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "posts", false, false, ResourceOptions{EmitEvents: true})
+	if err == nil {
+		t.Error("expected error combining EmitEvents with a parent resource")
+	}
+}
+
+// A resource named events would be generated into the event bus package.
+func TestResourceNameReserved(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "Events", ResourceOptions{}, "name:string"); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("resource named Events: err = %v, want it rejected", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "app", "events")); !os.IsNotExist(err) {
+		t.Error("rejected resource wrote app/events")
+	}
+	if err := GenerateView(tmpDir, "testmodule", "presence", "multi", "tailwind"); err == nil {
+		t.Error("view named presence: want it rejected")
+	}
+
+	// The bus is still generated for resources emitting events
+	if err := generateEventsTestResource(t, tmpDir, "orders", ResourceOptions{EmitEvents: true}, "total:float"); err != nil {
+		t.Fatal(err)
+	}
+	if !EventsEnabled(tmpDir) {
+		t.Error("app/events not generated")
+	}
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// appFeatures are the generators that add a shared package for the pages
+// generated after them to use.
+var appFeatures = []struct {
+	name     string
+	pkg      string
+	enabled  func(dir string) bool
+	generate func(dir string) error
+}{
+	{"notifications", NotificationsPackage, NotificationsEnabled, func(dir string) error { return GenerateNotifications(dir, DefaultNotifyDismiss) }},
+	{"offline", OfflinePackage, OfflineEnabled, GenerateOffline},
+	{"sessions", SessionsPackage, SessionsEnabled, func(dir string) error { return GenerateSessions(dir, "testmodule", 30*time.Minute, false) }},
+	{"sse", SSEPackage, SSEEnabled, func(dir string) error { return GenerateSSE(dir, "testmodule") }},
+	{"compression", CompressionPackage, CompressionEnabled, func(dir string) error { return GenerateCompression(dir, "testmodule") }},
+	{"prerender", PrerenderPackage, PrerenderEnabled, func(dir string) error { return GeneratePrerender(dir, "testmodule") }},
+	{"seo", SEOPackage, SEOEnabled, func(dir string) error { return GenerateSEO(dir, "testmodule") }},
+	{"security", SecurityPackage, SecurityHeadersEnabled, func(dir string) error { return GenerateSecurityHeaders(dir, "testmodule", false) }},
+	{"pubsub", PubSubPackage, PubSubEnabled, func(dir string) error { return GeneratePubSub(dir, "testmodule", PubSubRedis) }},
+}
+
+// Each feature is generated once per project, from the kit's own template,
+// and not for the simple kit.
+func TestGenerateAppFeatures(t *testing.T) {
+	for _, f := range appFeatures {
+		t.Run(f.name, func(t *testing.T) {
+			for _, kit := range []string{"multi", "single", "daisyui"} {
+				t.Run(kit, func(t *testing.T) {
+					dir := t.TempDir()
+					setupTestProject(t, dir)
+					setProjectKit(t, dir, kit)
+					writeTestConfig(t, dir)
+					if f.enabled(dir) {
+						t.Fatal("enabled before generation")
+					}
+					if err := f.generate(dir); err != nil {
+						t.Fatal(err)
+					}
+					if !f.enabled(dir) {
+						t.Error("not enabled after generation")
+					}
+					typeCheckPackage(t, filepath.Join(dir, filepath.Dir(f.pkg)))
+
+					if err := f.generate(dir); err == nil || !strings.Contains(err.Error(), "already set up") {
+						t.Errorf("second generation = %v, want already set up", err)
+					}
+				})
+			}
+
+			dir := t.TempDir()
+			setupTestProject(t, dir)
+			setProjectKit(t, dir, "simple")
+			if err := f.generate(dir); err == nil || !strings.Contains(err.Error(), "simple kit") {
+				t.Errorf("generation on the simple kit = %v, want it rejected", err)
+			}
+			if f.enabled(dir) {
+				t.Error("rejected generation still created the package")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// filterPackagePath is the generated package building the list queries of
+// resources generated with --filters.
+const filterPackagePath = "app/filter/filter.go"
+
+// generateFilter writes the app/filter package unless it already exists.
+func generateFilter(projectRoot string, kitLoader *kits.KitLoader, kitName string) error {
+	path := filepath.Join(projectRoot, filterPackagePath)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create app/filter directory: %w", err)
+	}
+	for _, f := range []string{"filter.go", "filter_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "filter/"+f+".tmpl", filepath.Join(dir, f), nil); err != nil {
+			return fmt.Errorf("failed to generate app/filter/%s: %w", f, err)
+		}
+	}
+	return nil
+}
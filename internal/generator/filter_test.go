@@ -0,0 +1,117 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Filters: true},
+		"title:string", "status:select:open,done", "urgent:bool", "due:time"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	for _, f := range []string{"filter.go", "filter_test.go"} {
+		path := filepath.Join(tmpDir, "app", "filter", f)
+		if _, err := goparser.ParseFile(token.NewFileSet(), path, nil, goparser.AllErrors); err != nil {
+			t.Errorf("app/filter/%s does not parse: %v", f, err)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"testmodule/app/filter"`,
+		"Filters      TasksFilters",
+		`q.Contains(state.SearchQuery, "title", "status")`,
+		`q.Equal("status", f.Status)`,
+		`q.Bool("urgent", f.Urgent)`,
+		`q.DateRange("due", f.DueFrom, f.DueTo)`,
+		`q.DateRange("created_at", f.CreatedAtFrom, f.CreatedAtTo)`,
+		`var tasksColumns = []string{"id", "title", "status", "urgent", "due", "created_at"}`,
+		"rows.Scan(&item.ID, &item.Title, &item.Status, &item.Urgent, &item.Due, &item.CreatedAt)",
+		"func (c *TasksController) ClearFilters(",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+	if strings.Contains(string(handler), "applySorting") {
+		t.Error("the database sorts filtered lists; applySorting should not be generated")
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`name="status" lvt-on:change="filter"`,
+		`<option value="no" {{if eq .Filters.Urgent "no"}}selected{{end}}>No</option>`,
+		`type="date" name="due_from" value="{{.Filters.DueFrom}}"`,
+		`name="clear_filters"`,
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+}
+
+func TestResourceFiltersSearchableAndCached(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	if err := GenerateCache(tmpDir, "testmodule", false); err != nil {
+		t.Fatalf("GenerateCache failed: %v", err)
+	}
+	fields, err := parser.ParseFields([]string{"title:string", "published:bool"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource(tmpDir, "testmodule", "posts", fields, "multi", "tailwind", "tailwind", "numbers", 10, "modal", "", false, true,
+		ResourceOptions{Filters: true, Cache: true}); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"q.Where(\"rowid IN (SELECT rowid FROM posts_fts WHERE posts_fts MATCH ?)\", state.SearchQuery)",
+		"state.Filters.Published, state.Filters.CreatedAtFrom, state.Filters.CreatedAtTo)",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+}
+
+func TestResourceFiltersUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	fields, err := parser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "posts", fields, "multi", "tailwind", "tailwind", "cursor", 10, "modal", "", false, false, ResourceOptions{Filters: true})
+	if err == nil || !strings.Contains(err.Error(), "cursor") {
+		t.Errorf("--filters with cursor pagination: err = %v, want it rejected", err)
+	}
+}
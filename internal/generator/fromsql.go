@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/parser"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// AdoptedTable is a table GenerateSchemaFromTables added to the project.
+type AdoptedTable struct {
+	Name   string
+	Fields []string // field definitions in the "name:type" format of `lvt gen resource`
+}
+
+// SkippedTable is a table GenerateSchemaFromTables left out, and why.
+type SkippedTable struct {
+	Name   string
+	Reason string
+}
+
+// virtualTableRe matches the DDL of a virtual table, e.g. an FTS5 index.
+var virtualTableRe = regexp.MustCompile(`(?i)^\s*CREATE\s+VIRTUAL\s+TABLE`)
+
+// SchemaTables introspects the tables of db that could be adopted: all but
+// SQLite's own tables and full-text search tables with their shadow tables.
+func SchemaTables(db *sql.DB) ([]*TableInfo, error) {
+	rows, err := db.Query("SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	var names, virtual []string
+	for rows.Next() {
+		var name string
+		var ddl sql.NullString
+		if err := rows.Scan(&name, &ddl); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list tables: %w", err)
+		}
+		if virtualTableRe.MatchString(ddl.String) {
+			virtual = append(virtual, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	var tables []*TableInfo
+	for _, name := range names {
+		if isShadowTable(name, virtual) {
+			continue
+		}
+		table, err := IntrospectTable(db, name)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// isShadowTable reports whether name is one of the tables SQLite keeps the
+// data of a virtual table in, e.g. posts_fts_data for posts_fts.
+func isShadowTable(name string, virtual []string) bool {
+	for _, v := range virtual {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(v)+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateSchemaFromTables adopts existing tables into the project: each
+// table's DDL is added to schema.sql and the standard queries to
+// queries.sql, so sqlc generates the same models and queries as for tables
+// created by lvt. No migration is written; the tables are expected to exist.
+//
+// Tables that don't follow the conventions of generated resources, or whose
+// queries are already in queries.sql, are skipped with the reason.
+func GenerateSchemaFromTables(basePath, moduleName string, tables []*TableInfo, kitName string) ([]AdoptedTable, []SkippedTable, error) {
+	useInflections(basePath)
+	defer track(basePath, "adopt")()
+	if kitName == "" {
+		kitName = "multi"
+	}
+	kitLoader := kits.DefaultLoader()
+	kit, err := kitLoader.Load(kitName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kit %q: %w", kitName, err)
+	}
+	queriesTmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/queries.sql.tmpl")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read queries template: %w", err)
+	}
+
+	dbDir := filepath.Join(basePath, "database")
+	if err := os.MkdirAll(dbDir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+	queriesPath := filepath.Join(dbDir, "queries.sql")
+	titleCaser := cases.Title(language.English)
+
+	var adopted []AdoptedTable
+	var skipped []SkippedTable
+	for _, table := range tables {
+		tableName := strings.ToLower(table.Name)
+		singular := singularize(tableName)
+		if plural := pluralize(singular); plural != tableName {
+			// sqlc names the models after the table, and resources after their plural
+			skipped = append(skipped, SkippedTable{table.Name, fmt.Sprintf("table names must be plural, like %q", plural)})
+			continue
+		}
+
+		data := ResourceData{
+			PackageName:          tableName,
+			ModuleName:           moduleName,
+			ResourceName:         titleCaser.String(tableName),
+			ResourceNameLower:    tableName,
+			ResourceNameSingular: titleCaser.String(singular),
+			ResourceNamePlural:   titleCaser.String(tableName),
+			TableName:            tableName,
+			Kit:                  kit,
+		}
+		defined, err := queriesDefine(queriesPath, "GetAll"+data.ResourceNamePlural)
+		if err != nil {
+			return adopted, skipped, err
+		}
+		if defined {
+			skipped = append(skipped, SkippedTable{table.Name, "queries.sql already has its queries"})
+			continue
+		}
+
+		specs, err := table.FieldSpecs()
+		if err != nil {
+			skipped = append(skipped, SkippedTable{table.Name, err.Error()})
+			continue
+		}
+		fields, err := parser.ParseFields(specs)
+		if err != nil {
+			skipped = append(skipped, SkippedTable{table.Name, err.Error()})
+			continue
+		}
+		data.Fields = FieldDataFromFields(fields)
+
+		if err := appendExistingTableSchema(filepath.Join(dbDir, "schema.sql"), table); err != nil {
+			return adopted, skipped, err
+		}
+		if err := appendToFile(string(queriesTmpl), data, queriesPath, "schema "+tableName, kit); err != nil {
+			return adopted, skipped, fmt.Errorf("failed to append to queries: %w", err)
+		}
+		if err := RegisterResourceFields(basePath, data.ResourceName, "", "schema", tableName, data.Fields); err != nil {
+			logging.Warn("could not register the schema in .lvtresources", "error", err)
+		}
+		adopted = append(adopted, AdoptedTable{Name: table.Name, Fields: specs})
+	}
+
+	if len(adopted) > 0 {
+		runSqlcGenerate(basePath)
+	}
+	return adopted, skipped, nil
+}
+
+// queriesDefine reports whether a queries file already has the named query.
+func queriesDefine(queriesPath, name string) (bool, error) {
+	content, err := os.ReadFile(queriesPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read queries: %w", err)
+	}
+	re := regexp.MustCompile(`(?m)^--\s*name:\s*` + regexp.QuoteMeta(name) + `\s`)
+	return re.Match(content), nil
+}
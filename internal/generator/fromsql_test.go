@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaTables(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE posts (id TEXT PRIMARY KEY, title TEXT NOT NULL, created_at DATETIME NOT NULL)`,
+		`CREATE VIRTUAL TABLE posts_fts USING fts5(title)`,
+		`CREATE TABLE authors (id TEXT PRIMARY KEY, name TEXT NOT NULL, created_at DATETIME NOT NULL)`,
+	)
+
+	tables, err := SchemaTables(db)
+	if err != nil {
+		t.Fatalf("SchemaTables: %v", err)
+	}
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	if want := []string{"authors", "posts"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("tables = %v, want %v (no FTS tables)", names, want)
+	}
+}
+
+func TestGenerateSchemaFromTables(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	db := openTestDB(t,
+		`CREATE TABLE users (id TEXT PRIMARY KEY, email TEXT NOT NULL, created_at DATETIME NOT NULL)`,
+		`CREATE TABLE products (
+			id TEXT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			price DECIMAL(10,2) NOT NULL,
+			owner_id TEXT NOT NULL REFERENCES users(id),
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE events (id INTEGER PRIMARY KEY, payload TEXT)`,
+		`CREATE TABLE person (id TEXT PRIMARY KEY, name TEXT NOT NULL, created_at DATETIME NOT NULL)`,
+	)
+	tables, err := SchemaTables(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adopted, skipped, err := GenerateSchemaFromTables(tmpDir, "testmodule", tables, "multi")
+	if err != nil {
+		t.Fatalf("GenerateSchemaFromTables: %v", err)
+	}
+	wantAdopted := []AdoptedTable{
+		{Name: "products", Fields: []string{"name:string", "price:float", "owner_id:references:users"}},
+		{Name: "users", Fields: []string{"email:string"}},
+	}
+	if !reflect.DeepEqual(adopted, wantAdopted) {
+		t.Errorf("adopted = %+v, want %+v", adopted, wantAdopted)
+	}
+	reasons := make(map[string]string)
+	for _, s := range skipped {
+		reasons[s.Name] = s.Reason
+	}
+	if !strings.Contains(reasons["events"], "TEXT primary key") {
+		t.Errorf("events skipped for %q, want the primary key problem", reasons["events"])
+	}
+	if !strings.Contains(reasons["person"], `"people"`) {
+		t.Errorf("person skipped for %q, want the plural name", reasons["person"])
+	}
+
+	schema, err := os.ReadFile(filepath.Join(tmpDir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries, err := os.ReadFile(filepath.Join(tmpDir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"CREATE TABLE products", "CREATE TABLE users"} {
+		if !strings.Contains(string(schema), want) {
+			t.Errorf("schema.sql missing %s", want)
+		}
+	}
+	for _, want := range []string{"-- name: GetAllProducts :many", "-- name: CreateProduct :one", "-- name: GetUserByID :one"} {
+		if !strings.Contains(string(queries), want) {
+			t.Errorf("queries.sql missing %s", want)
+		}
+	}
+	if strings.Contains(string(schema), "CREATE TABLE events") || strings.Contains(string(queries), "Events") {
+		t.Error("skipped table was added")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(tmpDir, "database", "migrations", "*.sql")); len(matches) > 0 {
+		t.Errorf("adopting wrote migrations: %v", matches)
+	}
+
+	// Adopting again leaves the tables that are already there alone
+	adopted, skipped, err = GenerateSchemaFromTables(tmpDir, "testmodule", tables[2:3], "multi") // products
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adopted) != 0 || len(skipped) != 1 || !strings.Contains(skipped[0].Reason, "already") {
+		t.Errorf("second adoption: adopted %+v, skipped %+v", adopted, skipped)
+	}
+	again, err := os.ReadFile(filepath.Join(tmpDir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(queries) {
+		t.Error("queries.sql changed on the second adoption")
+	}
+}
@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// funcsPackagePath is the generated registry of the app's own template
+// functions, which every generated page parses its templates with.
+const funcsPackagePath = "shared/funcs/funcs.go"
+
+// generateFuncs writes the shared/funcs package unless it already exists, so
+// apps created before it get it along with their next generated page.
+func generateFuncs(projectRoot string, kitLoader *kits.KitLoader, kitName string) error {
+	path := filepath.Join(projectRoot, funcsPackagePath)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/funcs directory: %w", err)
+	}
+	for _, f := range []string{"funcs.go", "funcs_test.go", "funcs.tmpl"} {
+		content, err := kitLoader.LoadKitTemplate(kitName, "funcs/"+f+".tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to read funcs/%s.tmpl: %w", f, err)
+		}
+		// [[ ]] delimiters leave funcs.tmpl's {{ }} comment as it is
+		if err := generateFile(string(content), nil, filepath.Join(dir, f), nil); err != nil {
+			return fmt.Errorf("failed to generate shared/funcs/%s: %w", f, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResourceGeneratesFuncs(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	for _, f := range []string{"funcs.go", "funcs_test.go", "funcs.tmpl"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "shared", "funcs", f)); err != nil {
+			t.Errorf("shared/funcs/%s not generated: %v", f, err)
+		}
+	}
+
+	handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"testmodule/shared/funcs"`, "funcs.Templates(),", "baseTmpl.Funcs(funcs.Map())"} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %q", want)
+		}
+	}
+
+	// The user's functions survive later generators
+	funcsPath := filepath.Join(tmpDir, funcsPackagePath)
+	custom := []byte("package funcs\n\n// custom\n")
+	if err := os.WriteFile(funcsPath, custom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateEventsTestResource(t, tmpDir, "tags", ResourceOptions{}, "name:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	got, err := os.ReadFile(funcsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(custom) {
+		t.Errorf("shared/funcs/funcs.go was overwritten:\n%s", got)
+	}
+}
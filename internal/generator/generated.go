@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+// GeneratedData describes a generated column: the handler sets Name when a
+// record is created, by applying Func to the Source field, e.g. a slug of
+// the title. A unique index keeps the values apart; the generation hook
+// appends a number when the value is taken. Updates leave the value alone,
+// so links using it keep working.
+type GeneratedData struct {
+	Name   string // generated column (e.g., "slug")
+	Func   string // generating function (e.g., "slugify")
+	Source string // field the value is generated from (e.g., "title")
+}
+
+// slugsPackagePath is the generated package that turns text into unique
+// slugs for generated(slugify ...) fields.
+const slugsPackagePath = "app/slugs/slugs.go"
+
+// splitGeneratedFields separates generated fields from regular fields and
+// checks that each is generated from a text field of the resource.
+func splitGeneratedFields(fields []parser.Field) ([]parser.Field, []GeneratedData, error) {
+	var rest []parser.Field
+	var generated []GeneratedData
+	for _, f := range fields {
+		if !f.IsGenerated {
+			rest = append(rest, f)
+			continue
+		}
+		generated = append(generated, GeneratedData{Name: f.Name, Func: f.GeneratedFunc, Source: f.GeneratedFrom})
+	}
+	for _, g := range generated {
+		var source *parser.Field
+		for i := range rest {
+			if rest[i].Name == g.Source {
+				source = &rest[i]
+			}
+		}
+		if source == nil {
+			return nil, nil, fmt.Errorf("field '%s': %s is generated from %s, but there is no %s field", g.Name, g.Name, g.Source, g.Source)
+		}
+		if source.GoType != "string" || source.IsFile || source.IsReference || source.Metadata.IsPassword {
+			return nil, nil, fmt.Errorf("field '%s': %s must be generated from a text field, not %s (%s)", g.Name, g.Name, g.Source, source.Type)
+		}
+	}
+	return rest, generated, nil
+}
+
+// generateSlugs writes the app/slugs package unless it already exists.
+func generateSlugs(projectRoot string, kitLoader *kits.KitLoader, kitName string) error {
+	path := filepath.Join(projectRoot, slugsPackagePath)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create app/slugs directory: %w", err)
+	}
+	for _, f := range []string{"slugs.go", "slugs_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "slugs/"+f+".tmpl", filepath.Join(dir, f), nil); err != nil {
+			return fmt.Errorf("failed to generate app/slugs/%s: %w", f, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResourceDefaultsAndGenerated(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Undo: true},
+		"title:string", "published:bool=true", "rating:int=3", "status:select:draft,live=draft", "slug:string:generated(slugify title)")
+	if err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	migration := readMigration(t, tmpDir, "posts")
+	for _, want := range []string{
+		"published BOOLEAN NOT NULL DEFAULT 1",
+		"rating INTEGER NOT NULL DEFAULT 3",
+		"status TEXT NOT NULL DEFAULT 'draft'",
+		"slug TEXT NOT NULL DEFAULT '', -- generated: slugify(title)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_slug_unique ON posts(slug);",
+	} {
+		if !strings.Contains(migration, want) {
+			t.Errorf("migration missing %q:\n%s", want, migration)
+		}
+	}
+
+	// The columns take their defaults and the slugs stay unique
+	schema, err := os.ReadFile(filepath.Join(tmpDir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := openTestDB(t, string(schema))
+	if _, err := db.Exec(`INSERT INTO posts (id, title, slug, created_at) VALUES ('1', 'Hello', 'hello', CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatal(err)
+	}
+	var published bool
+	var rating int
+	var status string
+	if err := db.QueryRow(`SELECT published, rating, status FROM posts WHERE id = '1'`).Scan(&published, &rating, &status); err != nil {
+		t.Fatal(err)
+	}
+	if !published || rating != 3 || status != "draft" {
+		t.Errorf("defaults = %v, %d, %q", published, rating, status)
+	}
+	if _, err := db.Exec(`INSERT INTO posts (id, title, slug, created_at) VALUES ('2', 'Hello', 'hello', CURRENT_TIMESTAMP)`); err == nil {
+		t.Error("unique index accepted a duplicate slug")
+	}
+
+	queries, err := os.ReadFile(filepath.Join(tmpDir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(queries), "-- name: GetPostBySlug :one") {
+		t.Error("queries.sql missing GetPostBySlug")
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), handlerPath, handler, 0); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"input.applyDefaults()",
+		`input.Status = "draft"`,
+		"c.generateSlug(dbCtx, input)",
+		"slugs.Slugify(input.Title, \"post\")",
+		"Slug: deleted.Slug,",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %q", want)
+		}
+	}
+	if strings.Contains(string(handler), "input.Published =") {
+		t.Error("bool defaults should not be applied by the handler")
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`value="true" checked`, `value="3"`, `<option value="draft" selected>`} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("add form missing %q", want)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "app", "slugs", "slugs.go")); err != nil {
+		t.Errorf("app/slugs not generated: %v", err)
+	}
+}
+
+func TestResourceGeneratedInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs []string
+		opts  ResourceOptions
+		want  string
+	}{
+		{"missing source", []string{"name:string", "slug:string:generated(slugify title)"}, ResourceOptions{}, "no title field"},
+		{"number source", []string{"rank:int", "slug:string:generated(slugify rank)"}, ResourceOptions{}, "text field"},
+		{"existing table", []string{"title:string", "slug:string:generated(slugify title)"}, ResourceOptions{FromTable: &TableInfo{Name: "posts"}}, "existing table"},
+		{"no create", []string{"title:string", "slug:string:generated(slugify title)"}, ResourceOptions{Actions: &ResourceActions{Show: true}}, "create action"},
+		{"index", []string{"title:string", "slug:string:generated(slugify title)"}, ResourceOptions{Indexes: mustParseIndexes(t, "slug")}, "already has a unique index"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			err := generateEventsTestResource(t, tmpDir, "posts", tt.opts, tt.specs...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	err := GenerateSchema(tmpDir, "testmodule", "posts", mustParseFields(t, "title:string", "slug:string:generated(slugify title)"), "multi", "tailwind")
+	if err == nil || !strings.Contains(err.Error(), "gen schema") {
+		t.Errorf("gen schema err = %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// healthHandlers are the health endpoints of main.go.tmpl, for apps
+// generated before they existed. The database package of those apps has no
+// Ready, so readiness only means the process serves requests.
+const healthHandlers = `
+// healthzHandler returns 200 while the process serves requests (liveness probe).
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(` + "`" + `{"status":"ok"}` + "`" + `))
+}
+
+// readyzHandler returns 200 when the app can serve traffic (readiness probe).
+func readyzHandler(w http.ResponseWriter, r *http.Request) {
+	healthzHandler(w, r)
+}
+`
+
+// EnsureHealthChecks registers /healthz and /readyz in the main.go
+// of the app at projectRoot, which deployment platforms probe. It reports
+// whether main.go changed; apps generated with the endpoints are left alone.
+func EnsureHealthChecks(projectRoot string) (bool, error) {
+	mainGoPath := findMainGo(projectRoot)
+	if mainGoPath == "" {
+		return false, fmt.Errorf("main.go not found in %s/cmd", projectRoot)
+	}
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, `"/readyz"`) {
+		return false, nil
+	}
+
+	const marker = "// TODO: Add routes here"
+	i := strings.Index(mainStr, marker)
+	if i < 0 {
+		return false, fmt.Errorf("could not find injection point in main.go (expected %q)", marker)
+	}
+	i = strings.LastIndex(mainStr[:i], "\n") + 1
+	routes := "\t// Health endpoints for load balancers and orchestrators\n" +
+		"\thttp.HandleFunc(\"/healthz\", healthzHandler)\n" +
+		"\thttp.HandleFunc(\"/readyz\", readyzHandler)\n\n"
+	mainStr = mainStr[:i] + routes + mainStr[i:]
+	mainStr = strings.TrimRight(mainStr, "\n") + "\n" + healthHandlers
+
+	lines := strings.Split(mainStr, "\n")
+	for _, route := range []string{"/healthz", "/readyz"} {
+		lines = addStartupRoute(lines, route)
+	}
+	if err := os.WriteFile(mainGoPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return false, fmt.Errorf("failed to write main.go: %w", err)
+	}
+	return true, nil
+}
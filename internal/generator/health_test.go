@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnsureHealthChecks(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainGoPath := filepath.Join(tmpDir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport (\n\t\"net/http\"\n)\n\nvar startupRoutes = []string{\n\t\"/\",\n}\n\nfunc main() {\n\t// TODO: Add routes here\n\thttp.ListenAndServe(\":8080\", nil)\n}\n"
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := EnsureHealthChecks(tmpDir)
+	if err != nil || !changed {
+		t.Fatalf("EnsureHealthChecks() = %v, %v", changed, err)
+	}
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainStr := string(content)
+	for _, want := range []string{
+		"http.HandleFunc(\"/healthz\", healthzHandler)",
+		"http.HandleFunc(\"/readyz\", readyzHandler)",
+		"func readyzHandler(",
+		"\t\"/readyz\",\n}",
+	} {
+		if !strings.Contains(mainStr, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), mainGoPath, content, 0); err != nil {
+		t.Errorf("main.go does not parse: %v", err)
+	}
+
+	// Registered already: nothing to do
+	if changed, err := EnsureHealthChecks(tmpDir); err != nil || changed {
+		t.Errorf("second EnsureHealthChecks() = %v, %v", changed, err)
+	}
+}
@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/config"
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+// setProjectKit points the test project in dir at kit.
+func setProjectKit(t *testing.T, dir, kit string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ".lvtrc"), []byte("kit="+kit+"\nmodule=testmodule\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestConfig writes a shared/config/config.go declaring only PORT, for
+// the generators that add their settings to it, and returns its path.
+func writeTestConfig(t *testing.T, dir string) string {
+	t.Helper()
+	path := filepath.Join(dir, "shared", "config", "config.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configGo := "package config\n\nvar Schema = []Var{\n\t{Name: \"PORT\", Type: TypeInt, Default: \"8080\"},\n}\n"
+	if err := os.WriteFile(path, []byte(configGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// generateTestPages generates one page of each kind other than a resource:
+// a view (dashboard), a chat view (lobby), a kanban view (board) and a
+// wizard (signup).
+func generateTestPages(t *testing.T, dir, kit string) {
+	t.Helper()
+	if err := GenerateView(dir, "testmodule", "dashboard", kit, "tailwind", ViewOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateView(dir, "testmodule", "lobby", kit, "tailwind", ViewOptions{Pattern: "chat"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateView(dir, "testmodule", "board", kit, "tailwind", ViewOptions{Pattern: "kanban"}); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := fieldparser.ParseFields([]string{"name:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := []WizardStep{{Name: "account", Fields: fields}, {Name: "review", Confirm: true}}
+	if err := GenerateWizard(dir, "testmodule", "signup", steps, kit, "tailwind"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// typeCheckPackage type-checks the generated Go package in dir, skipping its
+// tests, along with the app packages it imports. Other packages (sqlc
+// models, livetemplate) aren't available in tests, so they are stubbed out
+// and only lookups in them go unchecked.
+func typeCheckPackage(t *testing.T, dir string) {
+	t.Helper()
+
+	root := config.ProjectRoot(dir)
+	imp := &appImporter{
+		fset:  token.NewFileSet(),
+		root:  root,
+		std:   importer.Default(),
+		pkgs:  map[string]*types.Package{},
+		stubs: map[string]bool{},
+	}
+	if cfg, err := config.LoadProjectConfig(root); err == nil {
+		imp.module = cfg.Module
+	}
+	files, err := imp.parseDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			if !imp.inStub(err) {
+				t.Error(err)
+			}
+		},
+	}
+	_, _ = conf.Check(files[0].Name.Name, imp.fset, files, nil)
+}
+
+// appImporter imports the standard library, type-checks the app's own
+// packages from source and returns empty packages for everything else,
+// recording their names in stubs.
+type appImporter struct {
+	fset   *token.FileSet
+	root   string
+	module string
+	std    types.Importer
+	pkgs   map[string]*types.Package
+	stubs  map[string]bool
+}
+
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+func (i *appImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := i.pkgs[importPath]; ok {
+		return pkg, nil
+	}
+	if _, err := os.Stat(filepath.Join(build.Default.GOROOT, "src", importPath)); err == nil {
+		return i.std.Import(importPath)
+	}
+	// sqlc writes most of database/models, and doesn't run in tests
+	if rel, ok := strings.CutPrefix(importPath, i.module+"/"); ok && i.module != "" && rel != "database/models" {
+		if files, err := i.parseDir(filepath.Join(i.root, filepath.FromSlash(rel))); err == nil && len(files) > 0 {
+			// Its own errors are for its own tests to report. A package
+			// that has some, like a test's partial shared/config, is
+			// treated as a stub.
+			var broken bool
+			conf := types.Config{Importer: i, Error: func(err error) { broken = broken || !i.inStub(err) }}
+			pkg, _ := conf.Check(importPath, i.fset, files, nil)
+			if broken {
+				i.stubs[pkg.Name()] = true
+			}
+			i.pkgs[importPath] = pkg
+			return pkg, nil
+		}
+	}
+
+	name := path.Base(importPath)
+	if majorVersion.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, "go-"), ".go"), "-go")
+	name = strings.ReplaceAll(name, "-", "")
+	i.stubs[name] = true
+	pkg := types.NewPackage(importPath, name)
+	pkg.MarkComplete()
+	i.pkgs[importPath] = pkg
+	return pkg, nil
+}
+
+// inStub reports whether err is a lookup in a stubbed package.
+func (i *appImporter) inStub(err error) bool {
+	rest, ok := strings.CutPrefix(err.(types.Error).Msg, "undefined: ")
+	if !ok {
+		return false
+	}
+	name, _, ok := strings.Cut(rest, ".")
+	return ok && i.stubs[name]
+}
+
+// parseDir parses the Go files of dir other than tests.
+func (i *appImporter) parseDir(dir string) ([]*ast.File, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, m := range matches {
+		if strings.HasSuffix(m, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(i.fset, m, nil, parser.AllErrors)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Hooks: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	hooksPath := filepath.Join(tmpDir, "app", "posts", "posts_hooks.go")
+	hooks, err := os.ReadFile(hooksPath)
+	if err != nil {
+		t.Fatalf("posts_hooks.go not generated: %v", err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), hooksPath, hooks, goparser.AllErrors); err != nil {
+		t.Fatalf("posts_hooks.go does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"func BeforeCreate(ctx context.Context, q *models.Queries, input *AddInput) error",
+		"func AfterCreate(ctx context.Context, q *models.Queries, item PostsItem)",
+		"func BeforeUpdate(ctx context.Context, q *models.Queries, item PostsItem, input *UpdateInput) error",
+		"func AfterUpdate(ctx context.Context, q *models.Queries, before, after PostsItem)",
+		"func BeforeDelete(ctx context.Context, q *models.Queries, item PostsItem) error",
+		"func AfterDelete(ctx context.Context, q *models.Queries, item PostsItem)",
+	} {
+		if !strings.Contains(string(hooks), want) {
+			t.Errorf("posts_hooks.go missing %s", want)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"if err := BeforeCreate(dbCtx, c.Queries, &input); err != nil {",
+		"AfterCreate(dbCtx, c.Queries, created)",
+		"before, err := c.Queries.GetPostByID(dbCtx, input.ID)",
+		"if err := BeforeUpdate(dbCtx, c.Queries, before, &input); err != nil {",
+		"AfterUpdate(dbCtx, c.Queries, before, after)",
+		"if err := BeforeDelete(dbCtx, c.Queries, before); err != nil {",
+		"AfterDelete(dbCtx, c.Queries, before)",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	// The user's code survives regenerating the resource
+	custom := []byte("package posts\n\n// custom\n")
+	if err := os.WriteFile(hooksPath, custom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Hooks: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	got, err := os.ReadFile(hooksPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(custom) {
+		t.Errorf("posts_hooks.go was overwritten:\n%s", got)
+	}
+}
+
+func TestResourceHooksActions(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	actions, err := ParseActions("list,create")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Hooks: true, Actions: &actions}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	hooks, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts_hooks.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(hooks), "func BeforeCreate(") {
+		t.Error("posts_hooks.go missing BeforeCreate")
+	}
+	for _, unwanted := range []string{"func BeforeUpdate(", "func BeforeDelete("} {
+		if strings.Contains(string(hooks), unwanted) {
+			t.Errorf("posts_hooks.go has %s without the action", unwanted)
+		}
+	}
+}
+
+// Undoing a delete saves the record again, so it runs AfterCreate; the
+// restored record must be used even without audit or events.
+func TestResourceHooksUndo(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions string
+		want    string
+	}{
+		{"all actions", "list,show,create,edit,delete", "AfterCreate(dbCtx, c.Queries, restored)"},
+		{"no create", "list,delete", "_, err := c.Queries.CreatePost(dbCtx, models.CreatePostParams{"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			actions, err := ParseActions(tt.actions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Hooks: true, Undo: true, Actions: &actions}, "title:string"); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(handler), tt.want) {
+				t.Errorf("UndoDelete missing %s", tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceHooksUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	fields, err := parser.ParseFields([]string{"body:string", "post_id:references:posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "comments", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "posts", false, false, ResourceOptions{Hooks: true})
+	if err == nil || !strings.Contains(err.Error(), "--parent") {
+		t.Errorf("--hooks with --parent: err = %v, want it rejected", err)
+	}
+}
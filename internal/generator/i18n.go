@@ -0,0 +1,408 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// Kit components write user-facing text with [[T "Add %s" .ResourceName]].
+// Apps without translations get the text itself; after `lvt gen i18n` they
+// get a runtime {{T $.Locale "Add %s" "Post"}} call. Text involving runtime
+// values passes them with runtime: [[T "Page %d of %d" (runtime ".CurrentPage")
+// (runtime ".TotalPages")]]. [[lang]] is the page's language, for <html lang>.
+
+// runtimeArg is a T argument evaluated by the generated template.
+type runtimeArg string
+
+// Format writes the argument as a template action, whatever the verb, so
+// untranslated text renders the value in place.
+func (a runtimeArg) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "{{%s}}", string(a))
+}
+
+// plainText is T for apps without translations.
+func plainText(text string, args ...interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// translatedText is T for apps with translations: a call of the app's T.
+func translatedText(text string, args ...interface{}) string {
+	var b strings.Builder
+	b.WriteString("{{T $.Locale ")
+	b.WriteString(strconv.Quote(text))
+	for _, arg := range args {
+		b.WriteByte(' ')
+		if expr, ok := arg.(runtimeArg); ok {
+			if strings.ContainsAny(string(expr), " \t") {
+				expr = "(" + expr + ")"
+			}
+			b.WriteString(string(expr))
+		} else {
+			b.WriteString(strconv.Quote(fmt.Sprint(arg)))
+		}
+	}
+	b.WriteString("}}")
+	return b.String()
+}
+
+// translatable is template data whose generated templates may translate
+// their text.
+type translatable interface {
+	Translated() bool
+}
+
+// useI18n makes T emit runtime translation calls, and lang the page's
+// locale, when data is translated.
+func useI18n(funcs template.FuncMap, data interface{}) {
+	if t, ok := data.(translatable); ok && t.Translated() {
+		funcs["T"] = translatedText
+		funcs["lang"] = func() string { return "{{$.Locale}}" }
+	}
+}
+
+// I18nConfig holds configuration for i18n generation.
+type I18nConfig struct {
+	ModuleName    string   // Module path from go.mod
+	Locales       []string // Locale files to create, e.g. en, fr
+	DefaultLocale string   // Locale of the source text
+}
+
+// I18nData is the template data for the i18n package.
+type I18nData struct {
+	ModuleName    string
+	DefaultLocale string
+}
+
+const (
+	// i18nPackagePath is the file whose presence marks translations as set
+	// up; resources generated afterwards translate their text.
+	i18nPackagePath = "app/i18n/i18n.go"
+
+	// LocalesDir holds the locale files of an app.
+	LocalesDir = "locales"
+)
+
+var (
+	localePattern        = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+	defaultLocalePattern = regexp.MustCompile(`const DefaultLocale = "([^"]+)"`)
+	// tCallPattern matches the text of a T call in a template
+	tCallPattern = regexp.MustCompile(`\{\{-?\s*T\s+[^\s"` + "`" + `]+\s+("(?:[^"\\]|\\.)*"|` + "`[^`]*`" + `)`)
+)
+
+// I18nEnabled reports whether `lvt gen i18n` has been run in projectRoot.
+func I18nEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, i18nPackagePath))
+	return err == nil
+}
+
+// ValidLocale reports whether locale looks like a locale tag (en, pt-BR).
+func ValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// GenerateI18n sets up translations: the app/i18n package with T and the
+// locale negotiation middleware, a locale file per locale filled with the
+// strings templates already translate, and the wiring in main.go.
+func GenerateI18n(projectRoot string, cfg *I18nConfig) error {
+	defer track(projectRoot, "i18n")()
+	if I18nEnabled(projectRoot) {
+		return fmt.Errorf("i18n already set up (%s exists)", i18nPackagePath)
+	}
+	locales := cfg.Locales
+	if len(locales) == 0 {
+		locales = []string{cfg.DefaultLocale}
+	}
+	hasDefault := false
+	for _, locale := range locales {
+		if !ValidLocale(locale) {
+			return fmt.Errorf("invalid locale %q (expected a tag like en or pt-BR)", locale)
+		}
+		hasDefault = hasDefault || locale == cfg.DefaultLocale
+	}
+	if !hasDefault {
+		locales = append([]string{cfg.DefaultLocale}, locales...)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	kitLoader := kits.DefaultLoader()
+	kit, err := kitLoader.Load(kitName)
+	if err != nil {
+		return fmt.Errorf("failed to load kit %q: %w", kitName, err)
+	}
+
+	// 1. Create app/i18n package
+	i18nDir := filepath.Join(projectRoot, "app", "i18n")
+	if err := os.MkdirAll(i18nDir, 0755); err != nil {
+		return fmt.Errorf("failed to create app/i18n directory: %w", err)
+	}
+	content, err := kitLoader.LoadKitTemplate(kitName, "i18n/i18n.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read i18n/i18n.go.tmpl: %w", err)
+	}
+	data := I18nData{ModuleName: cfg.ModuleName, DefaultLocale: cfg.DefaultLocale}
+	if err := generateFile(string(content), data, filepath.Join(i18nDir, "i18n.go"), kit); err != nil {
+		return fmt.Errorf("failed to generate i18n.go: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(i18nDir, "templates"), 0755); err != nil {
+		return fmt.Errorf("failed to create app/i18n/templates directory: %w", err)
+	}
+	content, err = kitLoader.LoadKitTemplate(kitName, "i18n/templates/switcher.tmpl.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read i18n/templates/switcher.tmpl.tmpl: %w", err)
+	}
+	if err := generateFile(string(content), data, filepath.Join(i18nDir, "templates", "switcher.tmpl"), kit); err != nil {
+		return fmt.Errorf("failed to generate switcher.tmpl: %w", err)
+	}
+
+	// 2. Create locale files with the strings templates already translate
+	localesDir := filepath.Join(projectRoot, LocalesDir)
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create locales directory: %w", err)
+	}
+	for _, locale := range locales {
+		path := filepath.Join(localesDir, locale+".json")
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+	}
+	if _, err := ExtractTranslations(projectRoot, false); err != nil {
+		return err
+	}
+
+	// 3. Load translations and negotiate locales in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectI18n(mainGoPath, cfg.ModuleName); err != nil {
+			logging.Warn("could not wire i18n into main.go; call i18n.Load(\"locales\") at startup and add i18n.Middleware to the middleware chain", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// injectI18n loads the locale files at startup and adds i18n.Middleware to
+// the middleware chain of main.go.
+func injectI18n(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "i18n.Middleware") {
+		return nil // Already injected
+	}
+
+	chain := "\thandler := chainMiddleware(http.DefaultServeMux,"
+	idx := strings.Index(mainStr, chain)
+	if idx < 0 {
+		return fmt.Errorf("could not find the middleware chain (expected %q)", strings.TrimSpace(chain))
+	}
+	// Negotiate the locale before handlers, after logging and recovery
+	end := strings.Index(mainStr[idx:], "\n\t)")
+	if end < 0 {
+		return fmt.Errorf("could not find the end of the middleware chain")
+	}
+	end += idx
+	middleware := "\n\t\ti18n.Middleware,"
+	if queue := strings.Index(mainStr[idx:end], "\n\t\tactionQueue.Middleware,"); queue >= 0 {
+		// The action queue runs handlers, so the locale must be set before it
+		pos := idx + queue
+		mainStr = mainStr[:pos] + middleware + mainStr[pos:]
+	} else {
+		mainStr = mainStr[:end] + middleware + mainStr[end:]
+	}
+
+	load := "\t// Translations (locales/*.json, see app/i18n)\n" +
+		"\tif err := i18n.Load(\"" + LocalesDir + "\"); err != nil {\n" +
+		"\t\tslog.Error(\"Failed to load translations\", \"error\", err)\n" +
+		"\t\tos.Exit(1)\n" +
+		"\t}\n\n"
+	anchor := "\t// Compose middleware pipeline."
+	if i := strings.Index(mainStr, anchor); i >= 0 {
+		mainStr = mainStr[:i] + load + mainStr[i:]
+	} else {
+		idx = strings.Index(mainStr, chain)
+		mainStr = mainStr[:idx] + load + mainStr[idx:]
+	}
+
+	mainStr, err = injectImport(mainStr, fmt.Sprintf("\t\"%s/app/i18n\"", moduleName))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
+
+// LocaleReport describes one locale file after extraction.
+type LocaleReport struct {
+	Locale       string
+	Path         string
+	Added        []string // Strings added to the file
+	Untranslated []string // Strings without a translation
+	Unused       []string // Translations no template uses any more
+}
+
+// ExtractReport is the result of ExtractTranslations.
+type ExtractReport struct {
+	DefaultLocale string
+	Strings       []string // Every string the templates translate, sorted
+	Locales       []LocaleReport
+}
+
+// Pending reports whether a locale file lacks strings or translations.
+func (r *ExtractReport) Pending() bool {
+	for _, l := range r.Locales {
+		if len(l.Added) > 0 || len(l.Untranslated) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractTranslations collects the strings the app's templates translate
+// with T and adds the missing ones to every locale file: with the source
+// text in the default locale, empty (untranslated) in the others. With
+// dryRun the files are left unchanged.
+func ExtractTranslations(projectRoot string, dryRun bool) (*ExtractReport, error) {
+	defaultLocale, err := readDefaultLocale(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	strs, err := templateStrings(filepath.Join(projectRoot, "app"))
+	if err != nil {
+		return nil, err
+	}
+	report := &ExtractReport{DefaultLocale: defaultLocale, Strings: strs}
+
+	files, err := filepath.Glob(filepath.Join(projectRoot, LocalesDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no locale files in %s/", LocalesDir)
+	}
+	for _, file := range files {
+		locale := strings.TrimSuffix(filepath.Base(file), ".json")
+		catalog := map[string]string{}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("invalid locale file %s: %w", file, err)
+		}
+
+		l := LocaleReport{Locale: locale, Path: file}
+		used := make(map[string]bool, len(strs))
+		for _, s := range strs {
+			used[s] = true
+			translation, ok := catalog[s]
+			if !ok {
+				l.Added = append(l.Added, s)
+				if locale == defaultLocale {
+					translation = s
+				}
+				catalog[s] = translation
+			}
+			if translation == "" {
+				l.Untranslated = append(l.Untranslated, s)
+			}
+		}
+		for s := range catalog {
+			if !used[s] {
+				l.Unused = append(l.Unused, s)
+			}
+		}
+		sort.Strings(l.Unused)
+		report.Locales = append(report.Locales, l)
+
+		if !dryRun && len(l.Added) > 0 {
+			if err := writeCatalog(file, catalog); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// readDefaultLocale reads DefaultLocale from the generated i18n package.
+func readDefaultLocale(projectRoot string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, i18nPackagePath))
+	if err != nil {
+		return "", fmt.Errorf("i18n is not set up (run 'lvt gen i18n' first)")
+	}
+	m := defaultLocalePattern.FindSubmatch(data)
+	if m == nil {
+		return "", fmt.Errorf("could not find DefaultLocale in %s", i18nPackagePath)
+	}
+	return string(m[1]), nil
+}
+
+// templateStrings returns the strings the .tmpl files under dir pass to T,
+// sorted.
+func templateStrings(dir string) ([]string, error) {
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".tmpl" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range tCallPattern.FindAllSubmatch(data, -1) {
+			s, err := strconv.Unquote(string(m[1]))
+			if err != nil {
+				return fmt.Errorf("%s: invalid T string %s: %w", path, m[1], err)
+			}
+			seen[s] = true
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	strs := make([]string, 0, len(seen))
+	for s := range seen {
+		strs = append(strs, s)
+	}
+	sort.Strings(strs)
+	return strs, nil
+}
+
+// writeCatalog writes a locale file with sorted keys, leaving HTML as is.
+func writeCatalog(path string, catalog map[string]string) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(catalog); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
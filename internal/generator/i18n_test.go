@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTranslationHelpers(t *testing.T) {
+	tests := []struct {
+		text  string
+		args  []interface{}
+		plain string
+		call  string
+	}{
+		{"Cancel", nil, "Cancel", `{{T $.Locale "Cancel"}}`},
+		{"Add %s", []interface{}{"Post"}, "Add Post", `{{T $.Locale "Add %s" "Post"}}`},
+		{"Page %d of %d", []interface{}{runtimeArg(".CurrentPage"), runtimeArg("len .Items")},
+			"Page {{.CurrentPage}} of {{len .Items}}", `{{T $.Locale "Page %d of %d" .CurrentPage (len .Items)}}`},
+	}
+	for _, tt := range tests {
+		if got := plainText(tt.text, tt.args...); got != tt.plain {
+			t.Errorf("plainText(%q) = %q, want %q", tt.text, got, tt.plain)
+		}
+		if got := translatedText(tt.text, tt.args...); got != tt.call {
+			t.Errorf("translatedText(%q) = %q, want %q", tt.text, got, tt.call)
+		}
+	}
+}
+
+func TestGenerateI18n(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	// Resources generated before translations keep their English text
+	if err := generateCounterTestResource(t, tmpDir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(tmpDir, "app", "notes", "notes.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "{{T ") || !strings.Contains(string(before), "Cancel") {
+		t.Error("resource generated before gen i18n should not translate its text")
+	}
+
+	if err := GenerateI18n(tmpDir, &I18nConfig{ModuleName: "testmodule", Locales: []string{"fr"}, DefaultLocale: "en"}); err != nil {
+		t.Fatalf("GenerateI18n failed: %v", err)
+	}
+	if !I18nEnabled(tmpDir) {
+		t.Error("I18nEnabled should report true after generation")
+	}
+	pkg := filepath.Join(tmpDir, "app", "i18n", "i18n.go")
+	if _, err := parser.ParseFile(token.NewFileSet(), pkg, nil, parser.AllErrors); err != nil {
+		t.Errorf("i18n.go does not parse: %v", err)
+	}
+	if err := ValidateTemplate(filepath.Join(tmpDir, "app", "i18n", "templates", "switcher.tmpl")); err != nil {
+		t.Errorf("switcher template: %v", err)
+	}
+	for _, locale := range []string{"en", "fr"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, LocalesDir, locale+".json")); err != nil {
+			t.Errorf("the default and requested locales should get a file: %v", err)
+		}
+	}
+
+	if err := generateCounterTestResource(t, tmpDir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<html lang="{{$.Locale}}"`, `{{T $.Locale "Cancel"}}`, `{{T $.Locale "Add %s" "Posts"}}`} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("posts.tmpl missing %s", want)
+		}
+	}
+	handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"testmodule/app/i18n"`, "i18n.LocaleFromContext(ctx)", "i18n.Templates()", "Funcs(i18n.Funcs())"} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("posts handler missing %q", want)
+		}
+	}
+
+	report, err := ExtractTranslations(tmpDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Pending() {
+		t.Error("fr has no translations yet; the report should be pending")
+	}
+	catalogs := map[string]map[string]string{}
+	for _, locale := range []string{"en", "fr"} {
+		data, err := os.ReadFile(filepath.Join(tmpDir, LocalesDir, locale+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			t.Fatal(err)
+		}
+		catalogs[locale] = catalog
+	}
+	if got := catalogs["en"]["Add %s"]; got != "Add %s" {
+		t.Errorf("default locale should hold the source text, got %q", got)
+	}
+	if got, ok := catalogs["fr"]["Add %s"]; !ok || got != "" {
+		t.Errorf("other locales should get empty entries, got %q (present: %v)", got, ok)
+	}
+
+	// Once translated and unchanged, the check passes
+	for s := range catalogs["fr"] {
+		catalogs["fr"][s] = "fr: " + s
+	}
+	if err := writeCatalog(filepath.Join(tmpDir, LocalesDir, "fr.json"), catalogs["fr"]); err != nil {
+		t.Fatal(err)
+	}
+	report, err = ExtractTranslations(tmpDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Pending() {
+		t.Errorf("expected complete locales, got %+v", report.Locales)
+	}
+
+	if err := GenerateI18n(tmpDir, &I18nConfig{ModuleName: "testmodule", DefaultLocale: "en"}); err == nil {
+		t.Error("expected error when i18n is already set up")
+	}
+}
+
+func TestInjectI18n(t *testing.T) {
+	mainGo := `package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+)
+
+func main() {
+	// Compose middleware pipeline.
+	handler := chainMiddleware(http.DefaultServeMux,
+		recoveryMiddleware,
+		actionQueue.Middleware,
+	)
+	_ = handler
+}
+`
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := injectI18n(path, "testmodule"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := string(content)
+	if !strings.Contains(result, "recoveryMiddleware,\n\t\ti18n.Middleware,\n\t\tactionQueue.Middleware,") {
+		t.Errorf("i18n.Middleware should run before the action queue:\n%s", result)
+	}
+	if strings.Count(result, "i18n.Load(") != 1 || strings.Count(result, `"testmodule/app/i18n"`) != 1 {
+		t.Errorf("expected a single load and import:\n%s", result)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+		t.Errorf("main.go does not parse: %v", err)
+	}
+}
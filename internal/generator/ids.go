@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// Record ID types selectable with --id (or id= in .lvtrc). The default, "",
+// keeps IDs of the form "<resource>-<unix nanos>".
+const (
+	IDTypeUUID = "uuid"
+	IDTypeULID = "ulid"
+)
+
+// idsPackagePath is the generated package that creates UUIDs and ULIDs.
+const idsPackagePath = "app/ids/ids.go"
+
+// ValidateIDType returns an error unless idType is a supported ID type.
+func ValidateIDType(idType string) error {
+	switch idType {
+	case "", IDTypeUUID, IDTypeULID:
+		return nil
+	}
+	return fmt.Errorf("invalid id type: %q (valid: uuid, ulid)", idType)
+}
+
+// generateIDs writes the app/ids package unless it already exists.
+func generateIDs(projectRoot string, kitLoader *kits.KitLoader, kitName string) error {
+	path := filepath.Join(projectRoot, idsPackagePath)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create app/ids directory: %w", err)
+	}
+	if err := writeTemplateFile(kitLoader, kitName, "ids/ids.go.tmpl", path, nil); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", idsPackagePath, err)
+	}
+	return nil
+}
+
+func newIDExpr(idType string) string {
+	switch idType {
+	case IDTypeUUID:
+		return "ids.NewUUID()"
+	case IDTypeULID:
+		return "ids.NewULID()"
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceIDType(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "notes", ResourceOptions{}, "body:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, idsPackagePath)); err == nil {
+		t.Error("app/ids should not be generated for default IDs")
+	}
+
+	tests := []struct {
+		resource string
+		idType   string
+		newID    string
+	}{
+		{"posts", IDTypeUUID, "id := ids.NewUUID()"},
+		{"orders", IDTypeULID, "id := ids.NewULID()"},
+	}
+	for _, tt := range tests {
+		if err := generateEventsTestResource(t, tmpDir, tt.resource, ResourceOptions{IDType: tt.idType}, "title:string"); err != nil {
+			t.Fatalf("GenerateResource(%s) failed: %v", tt.resource, err)
+		}
+		handlerPath := filepath.Join(tmpDir, "app", tt.resource, tt.resource+".go")
+		handler, err := os.ReadFile(handlerPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"testmodule/app/ids"`, tt.newID, `validate:"required,` + tt.idType + `"`} {
+			if !strings.Contains(string(handler), want) {
+				t.Errorf("%s handler missing %s", tt.resource, want)
+			}
+		}
+		if strings.Contains(string(handler), "now.UnixNano())") {
+			t.Errorf("%s handler still builds nanosecond IDs", tt.resource)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), handlerPath, nil, parser.AllErrors); err != nil {
+			t.Errorf("%s handler does not parse: %v", tt.resource, err)
+		}
+	}
+
+	idsPath := filepath.Join(tmpDir, idsPackagePath)
+	if _, err := parser.ParseFile(token.NewFileSet(), idsPath, nil, parser.AllErrors); err != nil {
+		t.Errorf("app/ids/ids.go does not parse: %v", err)
+	}
+
+	if err := generateEventsTestResource(t, tmpDir, "tags", ResourceOptions{IDType: "serial"}, "name:string"); err == nil {
+		t.Error("expected error for unknown id type")
+	}
+}
+
+func TestEmbeddedResourceIDType(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource(posts) failed: %v", err)
+	}
+
+	fields, err := fieldparser.ParseFields([]string{"post_id:references:posts", "body:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource(tmpDir, "testmodule", "comments", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "posts", false, false, ResourceOptions{IDType: IDTypeULID}); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(tmpDir, "app", "comments", "*.go"))
+	var handler string
+	for _, m := range matches {
+		content, err := os.ReadFile(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(content), "EmbeddedController) Add(") {
+			handler = string(content)
+			if _, err := parser.ParseFile(token.NewFileSet(), m, nil, parser.AllErrors); err != nil {
+				t.Errorf("embedded handler does not parse: %v", err)
+			}
+		}
+	}
+	if !strings.Contains(handler, "id := ids.NewULID()") || !strings.Contains(handler, `"testmodule/app/ids"`) {
+		t.Error("embedded handler does not generate ULIDs")
+	}
+}
+
+func TestAPIIDTypeFromProjectConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	if err := os.WriteFile(filepath.Join(tmpDir, ".lvtrc"), []byte("kit=multi\nmodule=testmodule\nid=uuid\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := fieldparser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateAPI(tmpDir, "testmodule", "posts", fields, "multi"); err != nil {
+		t.Fatalf("GenerateAPI failed: %v", err)
+	}
+	handlerPath := filepath.Join(tmpDir, "app", "api", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(handler), "id := ids.NewUUID()") {
+		t.Error("API handler does not generate UUIDs")
+	}
+	if strings.Contains(string(handler), `"fmt"`) {
+		t.Error("API handler imports fmt without using it")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), handlerPath, nil, parser.AllErrors); err != nil {
+		t.Errorf("API handler does not parse: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, idsPackagePath)); err != nil {
+		t.Errorf("app/ids was not generated: %v", err)
+	}
+}
+
+// Only Create makes IDs, so resources without it must not import app/ids.
+func TestResourceIDTypeReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	for _, spec := range []string{"list", "list,show"} {
+		actions, err := ParseActions(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := generateEventsTestResource(t, dir, "notes", ResourceOptions{IDType: IDTypeULID, Actions: &actions}, "body:string"); err != nil {
+			t.Fatal(err)
+		}
+		typeCheckPackage(t, filepath.Join(dir, "app", "notes"))
+	}
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+// IndexData is an index declared with --index, as written to the migration
+// and schema.sql.
+type IndexData struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Where   string // condition of a partial index; "" for every row
+}
+
+// ColumnList is the index's columns as they appear in CREATE INDEX.
+func (ix IndexData) ColumnList() string {
+	return strings.Join(ix.Columns, ", ")
+}
+
+// indexData checks the declared indexes against the table's columns and
+// names them. indexed are the table's columns besides id, created_at, the
+// fields, counters and generated columns, e.g. created_by; like references
+// and created_at, they have an index of their own, which a plain index on
+// just that column would duplicate. Generated columns have a unique index.
+func indexData(table string, indexes []parser.Index, fields []FieldData, counters []CounterData, generated []GeneratedData, indexed []string) ([]IndexData, error) {
+	known := map[string]bool{"id": true, "created_at": true}
+	for _, f := range fields {
+		known[f.Name] = true
+		if f.IsFile {
+			for _, suffix := range fileColumnSuffixes {
+				known[f.Name+suffix] = true
+			}
+		}
+		if f.IsReference {
+			indexed = append(indexed, f.Name)
+		}
+	}
+	for _, c := range counters {
+		known[c.Name] = true
+	}
+	for _, c := range indexed {
+		known[c] = true
+	}
+	for _, g := range generated {
+		known[g.Name] = true
+	}
+	indexed = append(indexed, "created_at")
+
+	var data []IndexData
+	names := make(map[string]bool)
+	for _, ix := range indexes {
+		for _, col := range ix.Columns {
+			if !known[col] {
+				return nil, fmt.Errorf("--index %s: %s has no column %q", strings.Join(ix.Columns, ","), table, col)
+			}
+		}
+		if len(ix.Columns) == 1 && ix.Where == "" {
+			for _, g := range generated {
+				if ix.Columns[0] == g.Name {
+					return nil, fmt.Errorf("--index %s: the generated column already has a unique index", g.Name)
+				}
+			}
+			for _, col := range indexed {
+				if ix.Columns[0] == col && !ix.Unique {
+					return nil, fmt.Errorf("--index %s: the column is already indexed", col)
+				}
+			}
+		}
+		name := ix.Name(table)
+		if names[name] {
+			return nil, fmt.Errorf("--index %s: declared twice (as %s)", strings.Join(ix.Columns, ","), name)
+		}
+		names[name] = true
+		data = append(data, IndexData{Name: name, Columns: ix.Columns, Unique: ix.Unique, Where: ix.Where})
+	}
+	return data, nil
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func mustParseIndexes(t *testing.T, specs ...string) []parser.Index {
+	t.Helper()
+	var indexes []parser.Index
+	for _, spec := range specs {
+		ix, err := parser.ParseIndex(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexes = append(indexes, ix)
+	}
+	return indexes
+}
+
+func TestResourceIndexes(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	indexes := mustParseIndexes(t, "email unique", "org_id,created_at", "email where archived = 0")
+	if err := generateEventsTestResource(t, tmpDir, "users", ResourceOptions{Indexes: indexes}, "email:string", "org_id:string", "archived:bool"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	want := []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_unique ON users(email);",
+		"CREATE INDEX IF NOT EXISTS idx_users_org_id_created_at ON users(org_id, created_at);",
+		"CREATE INDEX IF NOT EXISTS idx_users_email ON users(email) WHERE archived = 0;",
+	}
+	migrations, _ := filepath.Glob(filepath.Join(tmpDir, "database", "migrations", "*_create_users.sql"))
+	if len(migrations) != 1 {
+		t.Fatalf("migrations = %v", migrations)
+	}
+	migration, err := os.ReadFile(migrations[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := os.ReadFile(filepath.Join(tmpDir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range want {
+		if !strings.Contains(string(migration), stmt) {
+			t.Errorf("migration missing %s", stmt)
+		}
+		if !strings.Contains(string(schema), stmt) {
+			t.Errorf("schema.sql missing %s", stmt)
+		}
+	}
+	if !strings.Contains(string(migration), "DROP INDEX IF EXISTS idx_users_email_unique;") {
+		t.Error("down migration does not drop the unique index")
+	}
+
+	// The statements must be valid SQLite
+	db := openTestDB(t)
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatalf("schema.sql does not apply: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO users (id, email, org_id, archived, created_at) VALUES ('1', 'a@example.com', 'o', 0, CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO users (id, email, org_id, archived, created_at) VALUES ('2', 'a@example.com', 'o', 1, CURRENT_TIMESTAMP)`); err == nil {
+		t.Error("unique index accepted a duplicate email")
+	}
+
+	resources, err := ReadResources(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("resources = %+v", resources)
+	}
+	wantIndexes := []ResourceIndex{
+		{Name: "idx_users_email_unique", Columns: []string{"email"}, Unique: true},
+		{Name: "idx_users_org_id_created_at", Columns: []string{"org_id", "created_at"}},
+		{Name: "idx_users_email", Columns: []string{"email"}, Where: "archived = 0"},
+	}
+	if !reflect.DeepEqual(resources[0].Indexes, wantIndexes) {
+		t.Errorf("manifest indexes = %+v, want %+v", resources[0].Indexes, wantIndexes)
+	}
+}
+
+func TestSchemaIndexes(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := GenerateSchema(tmpDir, "testmodule", "products", mustParseFields(t, "sku:string", "name:string"), "multi", "tailwind", mustParseIndexes(t, "sku unique")...); err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	schema, err := os.ReadFile(filepath.Join(tmpDir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(schema), "CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku_unique ON products(sku);") {
+		t.Errorf("schema.sql missing the unique index:\n%s", schema)
+	}
+	resources, err := ReadResources(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 || len(resources[0].Indexes) != 1 || resources[0].Indexes[0].Name != "idx_products_sku_unique" {
+		t.Errorf("manifest = %+v", resources)
+	}
+}
+
+func TestResourceIndexesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexes []string
+		opts    ResourceOptions
+		want    string
+	}{
+		{"unknown column", []string{"title,slug"}, ResourceOptions{}, `no column "slug"`},
+		{"already indexed", []string{"created_at"}, ResourceOptions{}, "already indexed"},
+		{"position", []string{"position"}, ResourceOptions{Sortable: true}, "already indexed"},
+		{"twice", []string{"title unique", "title  unique"}, ResourceOptions{}, "declared twice"},
+		{"existing table", []string{"title"}, ResourceOptions{FromTable: &TableInfo{Name: "posts"}}, "existing table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			tt.opts.Indexes = mustParseIndexes(t, tt.indexes...)
+			err := generateEventsTestResource(t, tmpDir, "posts", tt.opts, "title:string")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
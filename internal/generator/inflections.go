@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/inflect"
+)
+
+// inflector turns names singular and plural. Generators that derive table,
+// struct or route names call useInflections first, so the pairs in the
+// project's .lvtrc apply.
+var inflector = inflect.Default
+
+// useInflections switches to the built-in rules plus the inflections of
+// the project at basePath.
+func useInflections(basePath string) {
+	inflector = inflect.Default
+	if cfg, err := config.LoadProjectConfig(basePath); err == nil && len(cfg.Inflections) > 0 {
+		inflector = inflect.New(cfg.Inflections)
+	}
+}
+
+// Singularize is the exported version of singularize for use by other packages.
+func Singularize(word string) string {
+	return singularize(word)
+}
+
+func singularize(word string) string {
+	return inflector.Singular(word)
+}
+
+func pluralize(word string) string {
+	return inflector.Plural(word)
+}
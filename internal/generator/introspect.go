@@ -0,0 +1,262 @@
+package generator
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// TableColumn describes a column of an existing database table.
+type TableColumn struct {
+	Name       string
+	Type       string // declared type, e.g. "TEXT", "VARCHAR(255)"
+	NotNull    bool
+	PrimaryKey bool
+}
+
+// ForeignKey describes a single-column foreign key of an existing table.
+type ForeignKey struct {
+	Column string
+	Table  string
+}
+
+// TableInfo is the result of introspecting an existing SQLite table.
+type TableInfo struct {
+	Name        string
+	Columns     []TableColumn
+	ForeignKeys []ForeignKey
+	DDL         string // CREATE TABLE statement as stored in sqlite_master
+}
+
+// IntrospectTable reads the columns, foreign keys, and DDL of an existing
+// SQLite table.
+func IntrospectTable(db *sql.DB, table string) (*TableInfo, error) {
+	info := &TableInfo{Name: table}
+
+	err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&info.DDL)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("table %q not found in database", table)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table definition: %w", err)
+	}
+
+	// PRAGMA arguments cannot be bound; the name was verified against sqlite_master above.
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			cid        int
+			col        TableColumn
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &col.Name, &col.Type, &notNull, &defaultVal, &pk); err != nil {
+			return nil, fmt.Errorf("failed to read columns: %w", err)
+		}
+		col.NotNull = notNull != 0
+		col.PrimaryKey = pk != 0
+		info.Columns = append(info.Columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	fkRows, err := db.Query(fmt.Sprintf("PRAGMA foreign_key_list(%q)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+	defer fkRows.Close()
+	for fkRows.Next() {
+		var (
+			id, seq                   int
+			fk                        ForeignKey
+			to                        sql.NullString
+			onUpdate, onDelete, match string
+		)
+		if err := fkRows.Scan(&id, &seq, &fk.Table, &fk.Column, &to, &onUpdate, &onDelete, &match); err != nil {
+			return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+		}
+		info.ForeignKeys = append(info.ForeignKeys, fk)
+	}
+	if err := fkRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+
+	return info, nil
+}
+
+// Column returns the named column, or nil if the table has no such column.
+func (t *TableInfo) Column(name string) *TableColumn {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// fileColumnSuffixes are the companion columns lvt generates for file/image fields.
+var fileColumnSuffixes = []string{"_filename", "_content_type", "_size"}
+
+// imageNameHints mark file columns that are rendered as images.
+var imageNameHints = []string{"image", "photo", "avatar", "picture", "thumbnail", "logo", "cover"}
+
+// FieldSpecs converts the table's columns into field definitions in the
+// "name:type" format accepted by `lvt gen resource`. The id, created_at, and
+// created_by columns are managed by the generator and are skipped.
+//
+// Generated handlers assume the conventions of tables created by lvt: a TEXT
+// primary key named id, a NOT NULL created_at timestamp, and NOT NULL data
+// columns. Tables that don't follow them are reported as errors rather than
+// producing code that fails to compile.
+func (t *TableInfo) FieldSpecs() ([]string, error) {
+	var problems []string
+
+	if id := t.Column("id"); id == nil || !id.PrimaryKey || sqliteAffinity(id.Type) != "string" {
+		problems = append(problems, "table must have a TEXT primary key named id")
+	}
+	if c := t.Column("created_at"); c == nil || !c.NotNull || sqliteAffinity(c.Type) != "time" {
+		problems = append(problems, "table must have a NOT NULL DATETIME column named created_at")
+	}
+
+	fks := make(map[string]ForeignKey, len(t.ForeignKeys))
+	for _, fk := range t.ForeignKeys {
+		fks[strings.ToLower(fk.Column)] = fk
+	}
+
+	// Detect file fields by their companion columns (photo, photo_filename, ...).
+	fileCompanions := make(map[string]bool)
+	fileFields := make(map[string]bool)
+	for _, col := range t.Columns {
+		name := strings.ToLower(col.Name)
+		hasAll := true
+		for _, suffix := range fileColumnSuffixes {
+			if t.Column(name+suffix) == nil {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			fileFields[name] = true
+			for _, suffix := range fileColumnSuffixes {
+				fileCompanions[name+suffix] = true
+			}
+		}
+	}
+
+	var specs []string
+	for _, col := range t.Columns {
+		name := strings.ToLower(col.Name)
+		switch {
+		case name == "id" || name == "created_at" || name == "created_by":
+			continue
+		case fileCompanions[name]:
+			continue
+		case !col.NotNull:
+			problems = append(problems, fmt.Sprintf("column %s is nullable (generated code requires NOT NULL columns)", col.Name))
+			continue
+		}
+
+		if fk, ok := fks[name]; ok {
+			if sqliteAffinity(col.Type) != "string" {
+				problems = append(problems, fmt.Sprintf("foreign key column %s must be TEXT", col.Name))
+				continue
+			}
+			specs = append(specs, fmt.Sprintf("%s:references:%s", name, fk.Table))
+			continue
+		}
+
+		if fileFields[name] {
+			typ := "file"
+			for _, hint := range imageNameHints {
+				if strings.Contains(name, hint) {
+					typ = "image"
+					break
+				}
+			}
+			specs = append(specs, name+":"+typ)
+			continue
+		}
+
+		typ := sqliteAffinity(col.Type)
+		if typ == "" {
+			problems = append(problems, fmt.Sprintf("column %s has unsupported type %q", col.Name, col.Type))
+			continue
+		}
+		specs = append(specs, name+":"+typ)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("table %q is not compatible with generated resources:\n  - %s", t.Name, strings.Join(problems, "\n  - "))
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("table %q has no data columns to generate fields from", t.Name)
+	}
+	return specs, nil
+}
+
+// sqliteAffinity maps a declared SQLite column type to an lvt field type,
+// following SQLite's type affinity rules. It returns "" for types the
+// generator cannot represent (e.g. BLOB).
+func sqliteAffinity(declared string) string {
+	t := strings.ToUpper(strings.TrimSpace(declared))
+	switch {
+	case strings.Contains(t, "BOOL"):
+		return "bool"
+	case strings.Contains(t, "DATE") || strings.Contains(t, "TIME"):
+		return "time"
+	case strings.Contains(t, "INT"):
+		return "int"
+	case strings.Contains(t, "CHAR") || strings.Contains(t, "CLOB") || strings.Contains(t, "TEXT"):
+		return "string"
+	case strings.Contains(t, "REAL") || strings.Contains(t, "FLOA") || strings.Contains(t, "DOUB") ||
+		strings.Contains(t, "NUMERIC") || strings.Contains(t, "DECIMAL"):
+		return "float"
+	}
+	return ""
+}
+
+// schemaDefinesTable reports whether a schema file already contains a
+// CREATE TABLE statement for table.
+func schemaDefinesTable(schemaPath, table string) (bool, error) {
+	content, err := os.ReadFile(schemaPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	re := regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(IF\s+NOT\s+EXISTS\s+)?["` + "`" + `]?` + regexp.QuoteMeta(table) + `["` + "`" + `]?\s*\(`)
+	return re.Match(content), nil
+}
+
+// appendExistingTableSchema adds an existing table's DDL to schema.sql so sqlc
+// can type-check the generated queries. It does nothing if schema.sql already
+// defines the table.
+func appendExistingTableSchema(schemaPath string, table *TableInfo) error {
+	defined, err := schemaDefinesTable(schemaPath, table.Name)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+	if defined {
+		return nil
+	}
+
+	f, err := os.OpenFile(schemaPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open schema: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "\n-- Existing table %s (introspected by lvt)\n%s;\n", table.Name, strings.TrimSuffix(strings.TrimSpace(table.DDL), ";")); err != nil {
+		return fmt.Errorf("failed to append to schema: %w", err)
+	}
+	return nil
+}
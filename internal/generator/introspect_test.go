@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+	_ "modernc.org/sqlite"
+)
+
+func openTestDB(t *testing.T, ddl ...string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	for _, stmt := range ddl {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("exec %q: %v", stmt, err)
+		}
+	}
+	return db
+}
+
+func TestIntrospectTable(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE users (id TEXT PRIMARY KEY)`,
+		`CREATE TABLE products (
+			id TEXT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			stock INTEGER NOT NULL,
+			price DECIMAL(10,2) NOT NULL,
+			active BOOLEAN NOT NULL,
+			released_at DATETIME NOT NULL,
+			owner_id TEXT NOT NULL REFERENCES users(id),
+			photo TEXT NOT NULL DEFAULT '',
+			photo_filename TEXT NOT NULL DEFAULT '',
+			photo_content_type TEXT NOT NULL DEFAULT '',
+			photo_size INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL
+		)`,
+	)
+
+	info, err := IntrospectTable(db, "products")
+	if err != nil {
+		t.Fatalf("IntrospectTable: %v", err)
+	}
+	if !strings.Contains(info.DDL, "CREATE TABLE products") {
+		t.Errorf("DDL = %q", info.DDL)
+	}
+
+	specs, err := info.FieldSpecs()
+	if err != nil {
+		t.Fatalf("FieldSpecs: %v", err)
+	}
+	want := []string{
+		"name:string",
+		"stock:int",
+		"price:float",
+		"active:bool",
+		"released_at:time",
+		"owner_id:references:users",
+		"photo:image",
+	}
+	if !reflect.DeepEqual(specs, want) {
+		t.Errorf("FieldSpecs() = %v, want %v", specs, want)
+	}
+	if _, err := parser.ParseFields(specs); err != nil {
+		t.Errorf("specs should be valid field definitions: %v", err)
+	}
+}
+
+func TestIntrospectTable_NotFound(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := IntrospectTable(db, "missing"); err == nil {
+		t.Fatal("expected error for missing table")
+	}
+}
+
+func TestFieldSpecs_Incompatible(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE legacy (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT,
+		data BLOB NOT NULL
+	)`)
+
+	info, err := IntrospectTable(db, "legacy")
+	if err != nil {
+		t.Fatalf("IntrospectTable: %v", err)
+	}
+	_, err = info.FieldSpecs()
+	if err == nil {
+		t.Fatal("expected incompatibility error")
+	}
+	for _, want := range []string{"TEXT primary key named id", "created_at", "title is nullable", `data has unsupported type "BLOB"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestGenerateResourceFromTable(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbDir := filepath.Join(tmpDir, "database")
+	if err := os.MkdirAll(dbDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	db := openTestDB(t, `CREATE TABLE countries (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	)`)
+	info, err := IntrospectTable(db, "countries")
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs, err := info.FieldSpecs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := parser.ParseFields(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ResourceOptions{FromTable: info}
+	for i := 0; i < 2; i++ {
+		if err := GenerateResource(tmpDir, "testmodule", "countries", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false, opts); err != nil {
+			t.Fatalf("GenerateResource: %v", err)
+		}
+	}
+
+	if matches, _ := filepath.Glob(filepath.Join(dbDir, "migrations", "*.sql")); len(matches) != 0 {
+		t.Errorf("no migration should be created, found %v", matches)
+	}
+
+	schema, err := os.ReadFile(filepath.Join(dbDir, "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(schema), "CREATE TABLE countries"); n != 1 {
+		t.Errorf("schema.sql should define countries once, found %d:\n%s", n, schema)
+	}
+
+	queries, err := os.ReadFile(filepath.Join(dbDir, "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(queries), "-- name: GetAllCountries :many") {
+		t.Error("queries.sql should contain the generated queries")
+	}
+
+	// The resource name must map to the introspected table.
+	err = GenerateResource(tmpDir, "testmodule", "nation", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false, opts)
+	if err == nil || !strings.Contains(err.Error(), "name the resource after the table") {
+		t.Errorf("expected table name mismatch error, got %v", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // JobsConfig holds configuration for generating the queue infrastructure.
@@ -30,6 +30,7 @@ type JobConfig struct {
 // GenerateQueue sets up the background job infrastructure using River.
 // It creates the migration, schema, worker init file, and injects setup into main.go.
 func GenerateQueue(projectRoot string, moduleName string) error {
+	defer track(projectRoot, "queue")()
 	projectConfig, err := config.LoadProjectConfig(projectRoot)
 	if err != nil {
 		return fmt.Errorf("failed to load project config: %w", err)
@@ -72,10 +73,13 @@ func GenerateQueue(projectRoot string, moduleName string) error {
 	if err := writeTemplateFile(kitLoader, kitName, "jobs/migration.sql.tmpl", migrationPath, nil); err != nil {
 		return fmt.Errorf("failed to generate migration: %w", err)
 	}
+	if err := addDownSection(migrationPath, filepath.Join(projectRoot, "database", "schema.sql")); err != nil {
+		return err
+	}
 
 	// 2. Append to schema.sql
 	schemaPath := filepath.Join(projectRoot, "database", "schema.sql")
-	if err := appendTemplateFile(kitLoader, kitName, "jobs/schema.sql.tmpl", schemaPath, nil); err != nil {
+	if err := appendTemplateFile(kitLoader, kitName, "jobs/schema.sql.tmpl", schemaPath, "jobs", nil); err != nil {
 		return fmt.Errorf("failed to append to schema.sql: %w", err)
 	}
 
@@ -97,10 +101,8 @@ func GenerateQueue(projectRoot string, moduleName string) error {
 			"github.com/riverqueue/river/riverdriver/riversqlite@latest",
 		}
 		args := append([]string{"get"}, dependencies...)
-		cmd := exec.Command("go", args...)
-		cmd.Dir = projectRoot
-		if output, err := cmd.CombinedOutput(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not fetch River dependencies (run 'go mod tidy' in %s to resolve):\n%s\n", projectRoot, string(output))
+		if output, err := runTool(projectRoot, "go", args...); err != nil {
+			logging.Warn("could not fetch River dependencies; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
 		}
 	}
 
@@ -112,11 +114,13 @@ func GenerateQueue(projectRoot string, moduleName string) error {
 		}
 	}
 
-	return nil
+	// 6. Deliver change events through the queue if resources emit them
+	return generateEventDeliveryJob(projectRoot, moduleName, kitLoader, kitName)
 }
 
 // GenerateJob scaffolds a new job handler and registers it with the worker.
 func GenerateJob(projectRoot string, moduleName string, jobName string) error {
+	defer track(projectRoot, "job "+strings.ToLower(jobName))()
 	// Validate queue is set up
 	workerPath := filepath.Join(projectRoot, "app", "jobs", "worker.go")
 	if _, err := os.Stat(workerPath); os.IsNotExist(err) {
@@ -188,11 +192,16 @@ func writeTemplateFile(kitLoader *kits.KitLoader, kitName, templatePath, outputP
 		return err
 	}
 
-	return os.WriteFile(outputPath, buf.Bytes(), 0644)
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	logging.Debug("wrote file", "path", outputPath, "bytes", buf.Len())
+	return nil
 }
 
-// appendTemplateFile loads a kit template and appends it to the output file.
-func appendTemplateFile(kitLoader *kits.KitLoader, kitName, templatePath, outputPath string, data interface{}) error {
+// appendTemplateFile renders a kit template into section of the shared file
+// at outputPath (see writeSection).
+func appendTemplateFile(kitLoader *kits.KitLoader, kitName, templatePath, outputPath, section string, data interface{}) error {
 	content, err := kitLoader.LoadKitTemplate(kitName, templatePath)
 	if err != nil {
 		return err
@@ -203,23 +212,12 @@ func appendTemplateFile(kitLoader *kits.KitLoader, kitName, templatePath, output
 		return err
 	}
 
-	file, err := os.OpenFile(outputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	stat, err := file.Stat()
-	if err != nil {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
 		return err
 	}
-	if stat.Size() > 0 {
-		if _, err := file.WriteString("\n"); err != nil {
-			return err
-		}
-	}
-
-	return tmpl.Execute(file, data)
+	logging.Debug("appending to file", "path", outputPath, "template", templatePath)
+	return writeSection(outputPath, section, buf.Bytes())
 }
 
 // injectJobWorker injects River client setup into main.go.
@@ -236,6 +234,10 @@ func injectJobWorker(mainGoPath string, moduleName string) error {
 		return nil // Already injected
 	}
 
+	// Apps with shared/lifecycle stop the workers and close their database
+	// in shutdown hooks, within SHUTDOWN_TIMEOUT
+	useLifecycle := strings.Contains(mainStr, "lifecycle.OnShutdown(")
+
 	// Find injection point: after appCtx creation (needed by River client)
 	lines := strings.Split(mainStr, "\n")
 	var result []string
@@ -284,6 +286,9 @@ func injectJobWorker(mainGoPath string, moduleName string) error {
 				"\tjobs.SetClient(riverClient)",
 				"\tslog.Info(\"Background job worker started\")",
 			}
+			if useLifecycle {
+				riverSetup = lifecycleRiverSetup(riverSetup)
+			}
 			result = append(result, riverSetup...)
 			injected = true
 		}
@@ -313,6 +318,33 @@ func injectJobWorker(mainGoPath string, moduleName string) error {
 	return os.WriteFile(mainGoPath, []byte(resultStr), 0644)
 }
 
+// lifecycleRiverSetup replaces the defers of the River setup with shutdown
+// hooks, registered so that the workers stop before their database closes.
+func lifecycleRiverSetup(setup []string) []string {
+	var out []string
+	for i := 0; i < len(setup); i++ {
+		switch line := setup[i]; {
+		case line == "\tdefer riverDB.Close()":
+			out = append(out,
+				"\tlifecycle.OnShutdown(\"jobs database\", func(ctx context.Context) error {",
+				"\t\treturn riverDB.Close()",
+				"\t})")
+		case line == "\tdefer func() {":
+			// Skip the deferred Stop through its closing line
+			for setup[i] != "\t}()" {
+				i++
+			}
+			out = append(out,
+				"\tlifecycle.OnShutdown(\"jobs\", func(ctx context.Context) error {",
+				"\t\treturn riverClient.Stop(ctx)",
+				"\t})")
+		default:
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
 // injectWorkerRegistration adds a river.AddWorker call to app/jobs/worker.go.
 func injectWorkerRegistration(workerPath string, jobNameCamel string) error {
 	content, err := os.ReadFile(workerPath)
@@ -353,6 +385,7 @@ type TaskConfig struct {
 
 // GenerateTask scaffolds a new scheduled task and registers it.
 func GenerateTask(projectRoot, moduleName, taskName, schedule string) error {
+	defer track(projectRoot, "task "+strings.ToLower(taskName))()
 	workerPath := filepath.Join(projectRoot, "app", "jobs", "worker.go")
 	if _, err := os.Stat(workerPath); os.IsNotExist(err) {
 		return fmt.Errorf("job queue not set up yet. Run 'lvt gen queue' first")
@@ -412,7 +445,7 @@ func GenerateTask(projectRoot, moduleName, taskName, schedule string) error {
 	mainGoPath := findMainGo(projectRoot)
 	if mainGoPath != "" {
 		if err := injectPeriodicJobsConfig(mainGoPath); err != nil {
-			fmt.Printf("⚠️  Could not auto-inject PeriodicJobs config: %v\n", err)
+			logging.Warn("could not add PeriodicJobs to the River config in main.go", "error", err)
 		}
 	}
 
@@ -291,6 +291,63 @@ func main() {
 	}
 }
 
+func TestInjectJobWorker_Lifecycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainGoContent := `package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"testmodule/database"
+	"testmodule/shared/lifecycle"
+)
+
+func main() {
+	dbPath := "app.db"
+	_, err := database.InitDB(dbPath)
+	if err != nil {
+		slog.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+	lifecycle.OnShutdown("database", func(ctx context.Context) error {
+		database.CloseDB()
+		return nil
+	})
+
+	appCtx, appCancel := context.WithCancel(context.Background())
+	defer appCancel()
+
+	// Routes go here
+	slog.Info("Server starting")
+}
+`
+	mainGoPath := filepath.Join(tmpDir, "main.go")
+	if err := os.WriteFile(mainGoPath, []byte(mainGoContent), 0644); err != nil {
+		t.Fatalf("Failed to create main.go: %v", err)
+	}
+
+	if err := injectJobWorker(mainGoPath, "testmodule"); err != nil {
+		t.Fatalf("injectJobWorker failed: %v", err)
+	}
+	result, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatalf("Failed to read modified main.go: %v", err)
+	}
+	resultStr := string(result)
+
+	// Workers stop in a shutdown hook registered after their database's
+	stop := strings.Index(resultStr, "return riverClient.Stop(ctx)")
+	closeDB := strings.Index(resultStr, "return riverDB.Close()")
+	if stop < 0 || closeDB < 0 || closeDB > stop {
+		t.Errorf("expected River shutdown hooks, database first:\n%s", resultStr)
+	}
+	if strings.Contains(resultStr, "defer riverDB.Close()") || strings.Contains(resultStr, "stopCtx") {
+		t.Error("apps with shared/lifecycle should not stop River in defers")
+	}
+}
+
 // setupTestProject creates a minimal project structure for testing.
 func setupTestProject(t *testing.T, dir string) {
 	t.Helper()
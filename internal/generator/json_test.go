@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResourceJSONField(t *testing.T) {
+	dir := t.TempDir()
+	if err := generateCounterTestResource(t, dir, "posts", "title:string", "metadata:json"); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	helpers, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts_json.go"))
+	if err != nil {
+		t.Fatalf("json helpers not generated: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "posts_json.go", helpers, 0); err != nil {
+		t.Fatalf("json helpers do not parse: %v\n%s", err, helpers)
+	}
+	for _, want := range []string{"type PostMetadata map[string]any", "func DecodePostMetadata(raw string)", "func EncodePostMetadata(v PostMetadata)"} {
+		if !strings.Contains(string(helpers), want) {
+			t.Errorf("json helpers missing %q", want)
+		}
+	}
+
+	handler, _ := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.go"))
+	if !strings.Contains(string(handler), `validate:"required,json"`) {
+		t.Error("handler should validate metadata as JSON")
+	}
+
+	tmpl, _ := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+	if !strings.Contains(string(tmpl), "font-family: monospace;") {
+		t.Error("metadata textarea should use a monospace font")
+	}
+
+	migration := readMigration(t, dir, "posts")
+	if !strings.Contains(migration, "CHECK (json_valid(metadata))") {
+		t.Fatalf("migration should constrain metadata to valid JSON:\n%s", migration)
+	}
+	db := openTestDB(t, "CREATE TABLE posts (id TEXT PRIMARY KEY, metadata TEXT NOT NULL CHECK (json_valid(metadata)))")
+	if _, err := db.Exec(`INSERT INTO posts (id, metadata) VALUES ('a', '{"k": 1}')`); err != nil {
+		t.Errorf("valid JSON rejected: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO posts (id, metadata) VALUES ('b', '{nope')`); err == nil {
+		t.Error("invalid JSON should violate the CHECK constraint")
+	}
+}
+
+func TestResourceWithoutJSONFieldSkipsHelpers(t *testing.T) {
+	dir := t.TempDir()
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app", "posts", "posts_json.go")); !os.IsNotExist(err) {
+		t.Errorf("posts_json.go should not be generated without json fields (err=%v)", err)
+	}
+}
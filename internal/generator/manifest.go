@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/manifest"
+)
+
+// Version is the lvt version recorded in .lvt/manifest.json. The main
+// package sets it.
+var Version = "dev"
+
+// tracking is the generator run being recorded, if any. Generators that
+// call others are recorded as one run.
+var tracking *trackedRun
+
+type trackedRun struct {
+	root     string                       // absolute
+	sections map[string]map[string]string // see manifest.Manifest.Sections
+}
+
+// track starts recording the files generator writes under root and the kit
+// templates it uses; the returned function writes them to root's
+// .lvt/manifest.json. generator names the run, e.g. "resource posts".
+func track(root, generator string) func() {
+	if tracking != nil {
+		return func() {}
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		logging.Warn("not recording generated files", "error", err)
+		return func() {}
+	}
+	before, err := manifest.Scan(abs)
+	if err != nil {
+		logging.Warn("not recording generated files", "error", err)
+		return func() {}
+	}
+	t := &trackedRun{root: abs, sections: make(map[string]map[string]string)}
+	tracking = t
+	run := manifest.Generator{Version: Version, Templates: make(map[string]string)}
+	kits.Observer = func(kitName, path string, data []byte) {
+		if run.Kit == "" {
+			run.Kit = kitName
+		}
+		run.Templates[path] = manifest.Checksum(data)
+	}
+	return func() {
+		tracking = nil
+		kits.Observer = nil
+		if _, err := os.Stat(abs); err != nil {
+			return // nothing was generated
+		}
+		if err := manifest.Record(abs, before, generator, run, t.sections); err != nil {
+			logging.Warn("could not update "+manifest.Path, "error", err)
+		}
+	}
+}
+
+// resourceKinds are the generators whose output belongs to an entry in
+// .lvtresources.
+var resourceKinds = map[string]bool{"resource": true, "api": true, "view": true, "schema": true}
+
+// RemovedResources returns a function reporting whether the resource a
+// generator run produced ("resource posts", "view dashboard") is no longer
+// registered in the project at basePath. Other runs are never removed.
+func RemovedResources(basePath string) (func(generator string) bool, error) {
+	if _, err := os.Stat(filepath.Join(basePath, ".lvtresources")); os.IsNotExist(err) {
+		return func(string) bool { return false }, nil
+	}
+	resources, err := ReadResources(basePath)
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[string]bool)
+	for _, r := range resources {
+		for _, name := range []string{r.Name, r.Table, r.Path[strings.LastIndex(r.Path, "/")+1:]} {
+			if name != "" {
+				registered[r.Type+" "+pluralize(strings.ToLower(name))] = true
+			}
+		}
+	}
+	return func(generator string) bool {
+		kind, name, ok := strings.Cut(generator, " ")
+		return ok && resourceKinds[kind] && !registered[kind+" "+pluralize(name)]
+	}, nil
+}
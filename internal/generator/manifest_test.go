@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/manifest"
+)
+
+func TestGenerateResource_RecordsManifest(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateView(dir, "testmodule", "dashboard", "multi", "tailwind"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := manifest.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, generator := range map[string]string{
+		"app/posts/posts.go":           "resource posts",
+		"app/posts/posts.tmpl":         "resource posts",
+		"app/dashboard/dashboard.go":   "view dashboard",
+		"app/dashboard/dashboard.tmpl": "view dashboard",
+	} {
+		if f, ok := m.Files[path]; !ok || f.Generator != generator || f.Version != Version {
+			t.Errorf("%s recorded as %+v, want generator %q", path, f, generator)
+		}
+	}
+	run := m.Generators["resource posts"]
+	if run.Kit != "multi" || run.Templates["templates/resource/handler.go.tmpl"] == "" {
+		t.Errorf("resource posts run = %+v, want the multi kit's templates", run)
+	}
+	if kits.Observer != nil || tracking != nil {
+		t.Error("recording was left on")
+	}
+
+	removed, err := RemovedResources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := manifest.Check(dir, m, removed, kits.DefaultLoader().LoadKitFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Clean() {
+		t.Errorf("fresh project report = %+v", report)
+	}
+
+	if err := WriteResources(dir, []ResourceEntry{{Name: "Posts", Path: "/posts", Type: "resource", Table: "posts"}}); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err = RemovedResources(dir); err != nil {
+		t.Fatal(err)
+	}
+	if removed("resource posts") || removed("resource post") || !removed("view dashboard") || removed("new") {
+		t.Error("RemovedResources does not match .lvtresources")
+	}
+}
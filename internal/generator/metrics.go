@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/envschema"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// MetricsData is the template data for the shared/metrics package.
+type MetricsData struct {
+	ModuleName string
+}
+
+// MetricsPackage is the app package exposing the Prometheus metrics.
+const MetricsPackage = "shared/metrics/metrics.go"
+
+// DefaultMetricsPath is where the metrics are served unless --path says
+// otherwise.
+const DefaultMetricsPath = "/metrics"
+
+// metricsTokenVar declares the bearer token protecting the metrics endpoint.
+const metricsTokenVar = `{Name: "METRICS_TOKEN", Type: TypeString, Secret: true, Description: "Bearer token required to read the metrics; open when unset"}`
+
+// GenerateMetrics instruments the app at projectRoot with Prometheus: it
+// creates shared/metrics, serves it at path and puts metrics.Middleware
+// first in the middleware chain of main.go.
+func GenerateMetrics(projectRoot, moduleName, path string) error {
+	defer track(projectRoot, "metrics")()
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("metrics path must start with /: %q", path)
+	}
+
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no database or middleware chain to instrument; use the multi, single or daisyui kit")
+	}
+
+	metricsPath := filepath.Join(projectRoot, MetricsPackage)
+	if _, err := os.Stat(metricsPath); err == nil {
+		return fmt.Errorf("metrics already set up (%s exists)", MetricsPackage)
+	}
+
+	// 1. Create shared/metrics
+	kitLoader := kits.DefaultLoader()
+	metricsDir := filepath.Dir(metricsPath)
+	if err := os.MkdirAll(metricsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/metrics directory: %w", err)
+	}
+	data := MetricsData{ModuleName: moduleName}
+	for _, f := range []string{"metrics.go", "metrics_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "metrics/"+f+".tmpl", filepath.Join(metricsDir, f), data); err != nil {
+			return fmt.Errorf("failed to generate shared/metrics/%s: %w", f, err)
+		}
+	}
+
+	// 2. Declare METRICS_TOKEN
+	if err := declareConfigVar(projectRoot, "METRICS_TOKEN", metricsTokenVar); err != nil {
+		return fmt.Errorf("failed to declare METRICS_TOKEN: %w", err)
+	}
+
+	// 3. Add the Prometheus client
+	goModPath := filepath.Join(projectRoot, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		if output, err := runTool(projectRoot, "go", "get", "github.com/prometheus/client_golang@latest"); err != nil {
+			logging.Warn("could not fetch the Prometheus client; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+		}
+	}
+
+	// 4. Serve the metrics and record requests in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectMetrics(mainGoPath, moduleName, path); err != nil {
+			return fmt.Errorf("failed to inject metrics into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// injectMetrics registers the metrics handler at path and adds
+// metrics.Middleware as the outermost middleware, so the latency it records
+// includes rate limiting, logging and the action queue.
+func injectMetrics(mainGoPath, moduleName, path string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "metrics.Middleware") {
+		return nil // Already injected
+	}
+
+	chain := "\thandler := chainMiddleware(http.DefaultServeMux,"
+	idx := strings.Index(mainStr, chain)
+	if idx < 0 {
+		return fmt.Errorf("could not find the middleware chain (expected %q)", strings.TrimSpace(chain))
+	}
+	idx += len(chain)
+	mainStr = mainStr[:idx] + "\n\t\tmetrics.Middleware(http.DefaultServeMux)," + mainStr[idx:]
+
+	lines := strings.Split(mainStr, "\n")
+	marker := -1
+	for i, line := range lines {
+		if strings.Contains(line, "// TODO: Add routes here") {
+			marker = i
+			break
+		}
+	}
+	if marker < 0 {
+		return fmt.Errorf("could not find the routes marker (expected '// TODO: Add routes here')")
+	}
+	lines = insertLine(lines, marker, "")
+	lines = insertLine(lines, marker, fmt.Sprintf("\thttp.Handle(%q, metrics.Handler())", path))
+	lines = insertLine(lines, marker, "\t// Prometheus metrics (see shared/metrics); METRICS_TOKEN protects them")
+	lines = addStartupRoute(lines, path)
+
+	mainStr, err = injectImport(strings.Join(lines, "\n"), fmt.Sprintf("\t\"%s/shared/metrics\"", moduleName))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
+
+// declareConfigVar adds decl, a Var literal, to the Schema of the app's
+// shared/config unless name is declared already. Apps without the package
+// are left alone.
+func declareConfigVar(projectRoot, name, decl string) error {
+	configPath := filepath.Join(projectRoot, envschema.File)
+	content, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	src := string(content)
+	if strings.Contains(src, fmt.Sprintf("Name: %q", name)) {
+		return nil
+	}
+
+	start := strings.Index(src, "var Schema = []Var{")
+	if start < 0 {
+		return fmt.Errorf("could not find the Schema of %s", envschema.File)
+	}
+	end := strings.Index(src[start:], "\n}")
+	if end < 0 {
+		return fmt.Errorf("could not find the end of the Schema of %s", envschema.File)
+	}
+	end += start
+	src = src[:end] + "\n\t" + decl + "," + src[end:]
+	return os.WriteFile(configPath, []byte(src), 0644)
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	mainGoPath := filepath.Join(tmpDir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"net/http"
+)
+
+var startupRoutes = []string{
+	"/",
+}
+
+func main() {
+	// TODO: Add routes here (added automatically by ` + "`lvt gen`" + `)
+
+	handler := chainMiddleware(http.DefaultServeMux,
+		loggingMiddleware,
+	)
+	http.ListenAndServe(":8080", handler)
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(tmpDir, "shared", "config", "config.go")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configGo := "package config\n\nvar Schema = []Var{\n\t{Name: \"PORT\", Type: TypeInt, Default: \"8080\"},\n}\n"
+	if err := os.WriteFile(configPath, []byte(configGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GenerateMetrics(tmpDir, "testmodule", "/_/metrics"); err != nil {
+		t.Fatalf("GenerateMetrics failed: %v", err)
+	}
+
+	for _, f := range []string{"metrics.go", "metrics_test.go"} {
+		path := filepath.Join(tmpDir, "shared", "metrics", f)
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+			t.Errorf("%s does not parse: %v", f, err)
+		}
+	}
+
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainStr := string(content)
+	for _, want := range []string{
+		`"testmodule/shared/metrics"`,
+		`http.Handle("/_/metrics", metrics.Handler())`,
+		"\t\"/_/metrics\",\n}",
+		"chainMiddleware(http.DefaultServeMux,\n\t\tmetrics.Middleware(http.DefaultServeMux),\n\t\tloggingMiddleware,",
+	} {
+		if !strings.Contains(mainStr, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), mainGoPath, content, 0); err != nil {
+		t.Errorf("main.go does not parse: %v", err)
+	}
+
+	content, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "\t"+metricsTokenVar+",\n}") {
+		t.Errorf("config.go does not declare METRICS_TOKEN:\n%s", content)
+	}
+
+	if err := GenerateMetrics(tmpDir, "testmodule", DefaultMetricsPath); err == nil {
+		t.Error("GenerateMetrics should refuse to run twice")
+	}
+}
+
+func TestGenerateMetricsRejectsSimpleKit(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".lvtrc"), []byte("kit=simple\nmodule=testmodule\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateMetrics(tmpDir, "testmodule", DefaultMetricsPath); err == nil {
+		t.Error("GenerateMetrics should reject the simple kit")
+	}
+}
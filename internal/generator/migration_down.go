@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/seeder"
+)
+
+// Generated migrations carry only their Up section in the kit templates;
+// the Down section is derived from it here so `lvt migration down` can undo
+// anything lvt generated.
+
+var (
+	upCreateTableRe   = regexp.MustCompile(`(?is)^CREATE\s+(?:VIRTUAL\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?["` + "`" + `]?(\w+)`)
+	upCreateIndexRe   = regexp.MustCompile(`(?is)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+	upCreateTriggerRe = regexp.MustCompile(`(?is)^CREATE\s+TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+	upInsertRe        = regexp.MustCompile(`(?is)^INSERT\s+(?:OR\s+\w+\s+)?INTO\s+["` + "`" + `]?(\w+)`)
+	upAddColumnRe     = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+["` + "`" + `]?(\w+)["` + "`" + `]?\s+ADD\s+(?:COLUMN\s+)?["` + "`" + `]?(\w+)["` + "`" + `]?\s*(.*)$`)
+
+	// Column constraints SQLite's ALTER TABLE DROP COLUMN refuses to drop.
+	undroppableColumnRe = regexp.MustCompile(`(?i)\b(REFERENCES|CHECK|UNIQUE|PRIMARY\s+KEY|GENERATED|AS\s*\()`)
+
+	gooseDownRe = regexp.MustCompile(`(?m)^--\s*\+goose\s+Down\b`)
+)
+
+// addDownSection appends a Down section derived from the migration's Up
+// statements, unless the kit template already wrote one. schemaPath is the
+// project's schema.sql as it was before this migration; it is only read
+// when a column has to be dropped by rebuilding its table.
+func addDownSection(migrationPath, schemaPath string) error {
+	data, err := os.ReadFile(migrationPath)
+	if err != nil {
+		return fmt.Errorf("failed to read migration: %w", err)
+	}
+	content := string(data)
+
+	up := content
+	if loc := gooseDownRe.FindStringIndex(content); loc != nil {
+		if len(splitSQLStatements(content[loc[1]:])) > 0 {
+			return nil
+		}
+		up = content[:loc[0]]
+	}
+
+	down, err := deriveDownSQL(up, schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to derive down migration for %s: %w", migrationPath, err)
+	}
+
+	out := strings.TrimRight(up, "\n") + "\n\n-- +goose Down\n-- +goose StatementBegin\n" + down + "-- +goose StatementEnd\n"
+	return os.WriteFile(migrationPath, []byte(out), 0644)
+}
+
+// deriveDownSQL returns the statements undoing up, newest first. Tables,
+// indexes and triggers are dropped; added columns are dropped in place, or
+// by rebuilding the table from schema.sql when SQLite cannot drop them.
+// Rows inserted into a table the migration creates go with the table.
+func deriveDownSQL(up, schemaPath string) (string, error) {
+	stmts := splitSQLStatements(up)
+	created := make(map[string]bool)
+	for _, stmt := range stmts {
+		if m := upCreateTableRe.FindStringSubmatch(stmt); m != nil {
+			created[strings.ToLower(m[1])] = true
+		}
+	}
+
+	var b strings.Builder
+	for i := len(stmts) - 1; i >= 0; i-- {
+		stmt := stmts[i]
+		switch {
+		case upCreateTriggerRe.MatchString(stmt):
+			fmt.Fprintf(&b, "DROP TRIGGER IF EXISTS %s;\n", upCreateTriggerRe.FindStringSubmatch(stmt)[1])
+		case upCreateIndexRe.MatchString(stmt):
+			fmt.Fprintf(&b, "DROP INDEX IF EXISTS %s;\n", upCreateIndexRe.FindStringSubmatch(stmt)[1])
+		case upCreateTableRe.MatchString(stmt):
+			fmt.Fprintf(&b, "DROP TABLE IF EXISTS %s;\n", upCreateTableRe.FindStringSubmatch(stmt)[1])
+		case upInsertRe.MatchString(stmt) && created[strings.ToLower(upInsertRe.FindStringSubmatch(stmt)[1])]:
+		case upAddColumnRe.MatchString(stmt):
+			m := upAddColumnRe.FindStringSubmatch(stmt)
+			if !undroppableColumnRe.MatchString(m[3]) {
+				fmt.Fprintf(&b, "ALTER TABLE %s DROP COLUMN %s;\n", m[1], m[2])
+				continue
+			}
+			rebuild, err := rebuildTableSQL(schemaPath, m[1])
+			if err != nil {
+				return "", fmt.Errorf("cannot drop %s.%s: %w", m[1], m[2], err)
+			}
+			b.WriteString(rebuild)
+		default:
+			return "", fmt.Errorf("no automatic rollback for statement: %s", firstLine(stmt))
+		}
+	}
+	return b.String(), nil
+}
+
+// rebuildTableSQL recreates table exactly as schema.sql defines it, keeping
+// the rows of the columns it defines. SQLite cannot drop a column that has a
+// constraint, so this is how such a column is removed. The table is dropped
+// and recreated rather than renamed so references from other tables stay
+// intact; `lvt migration` connections leave foreign keys off, so the drop
+// does not cascade.
+func rebuildTableSQL(schemaPath, table string) (string, error) {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read schema.sql: %w", err)
+	}
+	schema := string(data)
+
+	tables, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return "", err
+	}
+	parsed := seeder.FindTable(tables, table)
+	if parsed == nil {
+		return "", fmt.Errorf("table %s not found in schema.sql", table)
+	}
+	var columns []string
+	for _, col := range parsed.Columns {
+		columns = append(columns, col.Name)
+	}
+	cols := strings.Join(columns, ", ")
+	backup := table + "_down_backup"
+
+	var create string
+	var recreate []string
+	for _, stmt := range splitSQLStatements(schema) {
+		switch {
+		case upCreateTableRe.MatchString(stmt):
+			if strings.EqualFold(upCreateTableRe.FindStringSubmatch(stmt)[1], table) {
+				create = stmt
+			}
+		case upCreateIndexRe.MatchString(stmt), upCreateTriggerRe.MatchString(stmt):
+			if statementTargetsTable(stmt, table) {
+				recreate = append(recreate, stmt)
+			}
+		}
+	}
+	if create == "" {
+		return "", fmt.Errorf("table %s not found in schema.sql", table)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TEMP TABLE %s AS SELECT %s FROM %s;\n", backup, cols, table)
+	fmt.Fprintf(&b, "DROP TABLE %s;\n", table)
+	b.WriteString(create + ";\n")
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) SELECT %s FROM %s;\n", table, cols, cols, backup)
+	fmt.Fprintf(&b, "DROP TABLE %s;\n", backup)
+	for _, stmt := range recreate {
+		b.WriteString(stmt + ";\n")
+	}
+	return b.String(), nil
+}
+
+// statementTargetsTable reports whether an index or trigger is defined ON table.
+func statementTargetsTable(stmt, table string) bool {
+	return regexp.MustCompile(`(?is)^[^(]*?\bON\s+["` + "`" + `]?` + regexp.QuoteMeta(table) + `["` + "`" + `]?[\s(]`).MatchString(stmt)
+}
+
+// splitSQLStatements splits SQL into statements without their trailing
+// semicolon, dropping comments. Trigger bodies (BEGIN ... END) stay whole.
+func splitSQLStatements(sql string) []string {
+	var stmts []string
+	var cur strings.Builder
+	inQuote := false
+	flush := func() {
+		if s := strings.TrimSpace(cur.String()); s != "" {
+			stmts = append(stmts, s)
+		}
+		cur.Reset()
+	}
+	for i := 0; i < len(sql); i++ {
+		ch := sql[i]
+		switch {
+		case ch == '\'':
+			inQuote = !inQuote
+			cur.WriteByte(ch)
+		case inQuote:
+			cur.WriteByte(ch)
+		case ch == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			cur.WriteByte('\n')
+		case ch == ';':
+			s := strings.TrimSpace(cur.String())
+			if upCreateTriggerRe.MatchString(s) && !strings.HasSuffix(strings.ToUpper(s), "END") {
+				cur.WriteByte(ch)
+				continue
+			}
+			flush()
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	flush()
+	return stmts
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(line)
+}
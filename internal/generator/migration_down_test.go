@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeriveDownSQL(t *testing.T) {
+	up := `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS posts (
+  id TEXT PRIMARY KEY,
+  title TEXT NOT NULL, -- shown in lists; a ; here is not a statement end
+  created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at);
+INSERT OR IGNORE INTO posts (id, title, created_at) VALUES ('p1', 'a;b', CURRENT_TIMESTAMP);
+CREATE VIRTUAL TABLE IF NOT EXISTS posts_fts USING fts5(title, content=posts, content_rowid=rowid);
+CREATE TRIGGER IF NOT EXISTS posts_ai AFTER INSERT ON posts BEGIN
+  INSERT INTO posts_fts(rowid, title) VALUES (new.rowid, new.title);
+END;
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user';
+-- +goose StatementEnd
+`
+	down, err := deriveDownSQL(up, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `ALTER TABLE users DROP COLUMN role;
+DROP TRIGGER IF EXISTS posts_ai;
+DROP TABLE IF EXISTS posts_fts;
+DROP INDEX IF EXISTS idx_posts_created_at;
+DROP TABLE IF EXISTS posts;
+`
+	if down != want {
+		t.Errorf("down =\n%s\nwant\n%s", down, want)
+	}
+
+	if _, err := deriveDownSQL("UPDATE users SET role = 'admin';", ""); err == nil {
+		t.Error("expected an error for a statement that cannot be reversed")
+	}
+}
+
+// sqliteMaster returns the schema objects of db, keyed by name.
+func sqliteMaster(t *testing.T, db *sql.DB) map[string]string {
+	t.Helper()
+	rows, err := db.Query(`SELECT name, COALESCE(sql, '') FROM sqlite_master WHERE name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	objects := make(map[string]string)
+	for rows.Next() {
+		var name, ddl string
+		if err := rows.Scan(&name, &ddl); err != nil {
+			t.Fatal(err)
+		}
+		objects[name] = ddl
+	}
+	return objects
+}
+
+// migrationSections returns the Up and Down statements of a goose migration.
+func migrationSections(t *testing.T, path string) (up, down string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	up, down, ok := strings.Cut(string(content), "-- +goose Down")
+	if !ok {
+		t.Fatalf("%s has no Down section", path)
+	}
+	return up, down
+}
+
+func TestAddDownSection_RebuildsTableForConstrainedColumn(t *testing.T) {
+	dir := t.TempDir()
+	schema := `CREATE TABLE IF NOT EXISTS teams (
+  id TEXT PRIMARY KEY
+);
+CREATE TABLE IF NOT EXISTS users (
+  id TEXT PRIMARY KEY,
+  email TEXT NOT NULL UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+CREATE TRIGGER IF NOT EXISTS users_email_lower AFTER INSERT ON users BEGIN
+  UPDATE users SET email = lower(new.email) WHERE id = new.id;
+END;
+`
+	schemaPath := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	migrationPath := filepath.Join(dir, "20240101000000_add_user_teams.sql")
+	migration := `-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN team_id TEXT REFERENCES teams(id);
+ALTER TABLE users ADD COLUMN nickname TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_users_team_id ON users(team_id);
+-- +goose StatementEnd
+`
+	if err := os.WriteFile(migrationPath, []byte(migration), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addDownSection(migrationPath, schemaPath); err != nil {
+		t.Fatal(err)
+	}
+	up, down := migrationSections(t, migrationPath)
+
+	db := openTestDB(t, schema)
+	before := sqliteMaster(t, db)
+	if _, err := db.Exec(`INSERT INTO users (id, email) VALUES ('u1', 'A@example.com')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(up); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if _, err := db.Exec(down); err != nil {
+		t.Fatalf("down: %v\n%s", err, down)
+	}
+
+	if after := sqliteMaster(t, db); !reflect.DeepEqual(before, after) {
+		t.Errorf("schema after down differs from before up:\nbefore %v\nafter  %v", before, after)
+	}
+	var email string
+	if err := db.QueryRow(`SELECT email FROM users WHERE id = 'u1'`).Scan(&email); err != nil {
+		t.Fatalf("rows were not kept by the rebuild: %v", err)
+	}
+	if email != "a@example.com" {
+		t.Errorf("email = %q", email)
+	}
+
+	// A second call leaves the derived section alone
+	if err := addDownSection(migrationPath, schemaPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, again := migrationSections(t, migrationPath); again != down {
+		t.Error("addDownSection rewrote an existing Down section")
+	}
+}
+
+func TestGeneratedMigrationsRollBack(t *testing.T) {
+	dir := t.TempDir()
+	if err := generateCounterTestResource(t, dir, "posts", "title:string", "comments_count:counter(comments)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "comments", "post_id:references:posts", "body:text"); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateAudit(dir, &AuditConfig{ModuleName: "testmodule"}); err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := filepath.Glob(filepath.Join(dir, "database", "migrations", "*.sql"))
+	if err != nil || len(migrations) != 3 {
+		t.Fatalf("migrations = %v, %v", migrations, err)
+	}
+
+	db := openTestDB(t)
+	for _, path := range migrations {
+		up, _ := migrationSections(t, path)
+		if _, err := db.Exec(up); err != nil {
+			t.Fatalf("up %s: %v", filepath.Base(path), err)
+		}
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		_, down := migrationSections(t, migrations[i])
+		if _, err := db.Exec(down); err != nil {
+			t.Fatalf("down %s: %v", filepath.Base(migrations[i]), err)
+		}
+	}
+	if left := sqliteMaster(t, db); len(left) != 0 {
+		t.Errorf("objects left after rolling everything back: %v", left)
+	}
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// NotificationsData is the template data for the shared/lvt package.
+type NotificationsData struct {
+	DismissAfter string // Go expression of the auto-dismiss delay, e.g. "5 * time.Second"
+}
+
+// NotificationsPackage is the app package controllers call lvt.Notify from.
+const NotificationsPackage = "shared/lvt/notify.go"
+
+// DefaultNotifyDismiss is how long success and info notifications stay on
+// screen unless --dismiss says otherwise.
+const DefaultNotifyDismiss = 5 * time.Second
+
+// NotificationsEnabled reports whether `lvt gen notifications` has been run
+// in projectRoot.
+func NotificationsEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, NotificationsPackage))
+	return err == nil
+}
+
+// GenerateNotifications creates the shared/lvt package, whose Notify shows
+// a toast from any controller. Success and info toasts close themselves
+// after dismiss; 0 keeps every toast until it is closed. Pages generated
+// afterwards render the kit's notifications component.
+func GenerateNotifications(projectRoot string, dismiss time.Duration) error {
+	defer track(projectRoot, "notifications")()
+	if dismiss < 0 {
+		return fmt.Errorf("dismiss delay must not be negative: %s", dismiss)
+	}
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no notifications component; use the multi, single or daisyui kit")
+	}
+	if NotificationsEnabled(projectRoot) {
+		return fmt.Errorf("notifications already set up (%s exists)", NotificationsPackage)
+	}
+
+	dir := filepath.Join(projectRoot, filepath.Dir(NotificationsPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/lvt directory: %w", err)
+	}
+	data := NotificationsData{DismissAfter: durationExpr(dismiss)}
+	if err := writeTemplateFile(kits.DefaultLoader(), kitName, "notifications/notify.go.tmpl", filepath.Join(projectRoot, NotificationsPackage), data); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", NotificationsPackage, err)
+	}
+	return nil
+}
+
+// durationExpr returns the Go expression of d in the largest whole unit
+// from hours down to milliseconds, e.g. "5 * time.Second".
+func durationExpr(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "0"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d * time.Hour", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%d * time.Minute", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%d * time.Second", d/time.Second)
+	default:
+		return fmt.Sprintf("%d * time.Millisecond", d.Milliseconds())
+	}
+}
+
+// featureComponents returns the kit components a page needs for the
+// features set up in its project: toasts, session replay, the offline
+// action queue and the SSE fallback.
+func featureComponents(notifications, sessions, offline, sse bool) []string {
+	var files []string
+	if notifications {
+		files = append(files, "notifications.tmpl")
+	}
+	if sessions {
+		files = append(files, "sessions.tmpl")
+	}
+	if offline {
+		files = append(files, "offline.tmpl")
+	}
+	if sse {
+		files = append(files, "sse.tmpl")
+	}
+	return files
+}
+
+// withComponents prepends the kit component files, e.g. notifications.tmpl,
+// to page, a page template rendering their templates.
+func withComponents(kitLoader *kits.KitLoader, kitName, page string, files ...string) (string, error) {
+	var b strings.Builder
+	for _, file := range files {
+		component, err := kitLoader.LoadKitComponent(kitName, file)
+		if err != nil {
+			return "", fmt.Errorf("failed to load component %s: %w", file, err)
+		}
+		b.Write(component)
+		b.WriteString("\n\n")
+	}
+	return b.String() + page, nil
+}
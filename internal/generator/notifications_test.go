@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The dismiss delay is written as a readable duration constant.
+func TestGenerateNotifications(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := GenerateNotifications(dir, -time.Second); err == nil {
+		t.Error("negative dismiss delay was accepted")
+	}
+	if err := GenerateNotifications(dir, 2500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, NotificationsPackage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "const DismissAfter = 2500 * time.Millisecond"; !strings.Contains(string(content), want) {
+		t.Errorf("notify.go is missing %q", want)
+	}
+}
+
+func TestDurationExpr(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                       "0",
+		5 * time.Second:         "5 * time.Second",
+		90 * time.Second:        "90 * time.Second",
+		2500 * time.Millisecond: "2500 * time.Millisecond",
+		24 * time.Hour:          "24 * time.Hour",
+		30 * time.Minute:        "30 * time.Minute",
+	} {
+		if got := durationExpr(d); got != want {
+			t.Errorf("durationExpr(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestResourceNotifications(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	// Resources generated before gen notifications keep their toast container
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "lvt.Notify") || !strings.Contains(string(before), "state.Toasts.AddSuccess") {
+		t.Error("resource generated before gen notifications should use its toast container")
+	}
+
+	if err := GenerateNotifications(dir, DefaultNotifyDismiss); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "app", "posts", "posts.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := string(content)
+	for _, want := range []string{
+		`"testmodule/shared/lvt"`,
+		`lvt.Notify(ctx, lvt.Success, "Post created successfully")`,
+		`lvt.Notify(ctx, lvt.Error, "Could not create the post")`,
+		`lvt.Notify(ctx, lvt.Error, "Could not delete the post")`,
+	} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("posts handler is missing %q", want)
+		}
+	}
+	if strings.Contains(handler, "Toasts") {
+		t.Error("posts handler should report with lvt.Notify instead of a toast container")
+	}
+	typeCheckPackage(t, filepath.Dir(path))
+
+	page, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`{{define "notifications"}}`, `{{template "notifications" .}}`, `{{if .lvt.HasFlash "success"}}`, `data-auto-dismiss="{{.lvt.Flash "notify_dismiss_ms"}}"`} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("posts template is missing %q", want)
+		}
+	}
+	if _, err := template.New("page").Parse(string(page)); err != nil {
+		t.Errorf("posts template does not parse: %v", err)
+	}
+}
+
+// Read-only resources have no action reporting with lvt.Notify, so they
+// must not import it.
+func TestResourceNotificationsReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := GenerateNotifications(dir, DefaultNotifyDismiss); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range []string{"list", "list,show"} {
+		actions, err := ParseActions(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := generateEventsTestResource(t, dir, "notes", ResourceOptions{Actions: &actions}, "body:string"); err != nil {
+			t.Fatal(err)
+		}
+		typeCheckPackage(t, filepath.Join(dir, "app", "notes"))
+	}
+}
+
+func TestPagesRenderNotifications(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			dir := t.TempDir()
+			setupTestProject(t, dir)
+			setProjectKit(t, dir, kit)
+			if err := GenerateNotifications(dir, DefaultNotifyDismiss); err != nil {
+				t.Fatal(err)
+			}
+			generateTestPages(t, dir, kit)
+
+			for _, page := range []string{"dashboard", "lobby", "board", "signup"} {
+				typeCheckPackage(t, filepath.Join(dir, "app", page))
+
+				content, err := os.ReadFile(filepath.Join(dir, "app", page, page+".tmpl"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, want := range []string{`{{define "notifications"}}`, `{{template "notifications" .}}`, `{{template "notificationsScript" .}}`} {
+					if !strings.Contains(string(content), want) {
+						t.Errorf("%s template is missing %q", page, want)
+					}
+				}
+				if _, err := template.New("page").Parse(string(content)); err != nil {
+					t.Errorf("%s template does not parse: %v", page, err)
+				}
+			}
+		})
+	}
+}
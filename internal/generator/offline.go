@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// OfflinePackage is the app package whose Seen skips actions replayed after
+// a dropped connection.
+const OfflinePackage = "shared/offline/offline.go"
+
+// OfflineEnabled reports whether `lvt gen offline` has been run in
+// projectRoot.
+func OfflineEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, OfflinePackage))
+	return err == nil
+}
+
+// GenerateOffline creates the shared/offline package. Pages generated
+// afterwards queue the actions fired while their connection is down and
+// replay them in order once it is back; their handlers check each replayed
+// action's idempotency key with offline.Seen so none is applied twice.
+func GenerateOffline(projectRoot string) error {
+	defer track(projectRoot, "offline")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no offline component; use the multi, single or daisyui kit")
+	}
+	if OfflineEnabled(projectRoot) {
+		return fmt.Errorf("offline actions already set up (%s exists)", OfflinePackage)
+	}
+
+	dir := filepath.Join(projectRoot, filepath.Dir(OfflinePackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/offline directory: %w", err)
+	}
+	if err := writeTemplateFile(kits.DefaultLoader(), kitName, "offline/offline.go.tmpl", filepath.Join(projectRoot, OfflinePackage), nil); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", OfflinePackage, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResourceOffline(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "offline.Seen") {
+		t.Error("resource generated before gen offline should not check idempotency keys")
+	}
+
+	if err := GenerateOffline(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "app", "posts", "posts.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := string(content)
+	if !strings.Contains(handler, `"testmodule/shared/offline"`) {
+		t.Error("posts handler does not import shared/offline")
+	}
+	// Add, Update and Delete each skip a replayed action
+	if n := strings.Count(handler, "if offline.Seen(ctx) {"); n != 3 {
+		t.Errorf("posts handler checks offline.Seen %d times, want 3", n)
+	}
+	typeCheckPackage(t, filepath.Dir(path))
+
+	page, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := strings.Index(string(page), `{{template "offlineScript" .}}`)
+	client := strings.Index(string(page), `livetemplate-client.js`)
+	if !strings.Contains(string(page), `{{define "offlineScript"}}`) || script < 0 || script > client {
+		t.Error("posts template should render offlineScript before the client script")
+	}
+	if _, err := template.New("page").Parse(string(page)); err != nil {
+		t.Errorf("posts template does not parse: %v", err)
+	}
+}
+
+// Read-only resources have no action to replay, so they must not import
+// shared/offline.
+func TestResourceOfflineReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := GenerateOffline(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range []string{"list", "list,show"} {
+		actions, err := ParseActions(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := generateEventsTestResource(t, dir, "notes", ResourceOptions{Actions: &actions}, "body:string"); err != nil {
+			t.Fatal(err)
+		}
+		typeCheckPackage(t, filepath.Join(dir, "app", "notes"))
+	}
+}
+
+func TestPagesQueueOffline(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			dir := t.TempDir()
+			setupTestProject(t, dir)
+			setProjectKit(t, dir, kit)
+			if err := GenerateOffline(dir); err != nil {
+				t.Fatal(err)
+			}
+			generateTestPages(t, dir, kit)
+
+			// The number of actions in each page that change data
+			for page, checks := range map[string]int{"dashboard": 0, "lobby": 1, "board": 3, "signup": 1} {
+				content, err := os.ReadFile(filepath.Join(dir, "app", page, page+".go"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n := strings.Count(string(content), "if offline.Seen(ctx) {"); n != checks {
+					t.Errorf("%s handler checks offline.Seen %d times, want %d", page, n, checks)
+				}
+				typeCheckPackage(t, filepath.Join(dir, "app", page))
+
+				tmpl, err := os.ReadFile(filepath.Join(dir, "app", page, page+".tmpl"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(tmpl), `{{template "offlineScript" .}}`) {
+					t.Errorf("%s template does not render offlineScript", page)
+				}
+				if _, err := template.New("page").Parse(string(tmpl)); err != nil {
+					t.Errorf("%s template does not parse: %v", page, err)
+				}
+			}
+		})
+	}
+}
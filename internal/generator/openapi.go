@@ -0,0 +1,379 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/seeder"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// OpenAPIVersion is the OpenAPI specification version emitted by GenerateOpenAPI.
+const OpenAPIVersion = "3.1.0"
+
+// GenerateOpenAPI builds an OpenAPI 3.1 document describing the resources
+// registered in .lvtresources. Every resource, API and schema entry gets a
+// component schema; JSON API handlers get their full CRUD paths and resource
+// pages are listed as HTML endpoints.
+//
+// Field metadata comes from the manifest. Entries registered before fields
+// were recorded fall back to the columns in database/schema.sql.
+func GenerateOpenAPI(basePath string) (map[string]any, error) {
+	useInflections(basePath)
+	entries, err := ReadResources(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .lvtresources: %w", err)
+	}
+
+	var tables []seeder.TableSchema
+	schemaPath := filepath.Join(basePath, "database", "schema.sql")
+	if _, err := os.Stat(schemaPath); err == nil {
+		if tables, err = seeder.ParseSchema(schemaPath); err != nil {
+			return nil, fmt.Errorf("failed to parse schema: %w", err)
+		}
+	}
+
+	title := filepath.Base(basePath)
+	if moduleName := readModuleName(basePath); moduleName != "" {
+		title = moduleName
+	}
+
+	schemas := map[string]any{}
+	paths := map[string]any{}
+	var tags []string
+	hasAPI := false
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case "resource", "api", "schema":
+		default:
+			continue
+		}
+
+		table := entry.Table
+		if table == "" {
+			table = pluralize(singularize(strings.ToLower(entry.Name)))
+		}
+		fields := entry.Fields
+		if len(fields) == 0 {
+			fields = fieldsFromSchema(seeder.FindTable(tables, table))
+		}
+
+		titleCaser := cases.Title(language.English)
+		singular := titleCaser.String(singularize(table))
+		schemas[singular] = openAPIRecordSchema(fields)
+
+		switch entry.Type {
+		case "api":
+			hasAPI = true
+			schemas[singular+"Input"] = openAPIInputSchema(fields)
+			for path, item := range openAPIResourcePaths(entry.Path, entry.Name, singular) {
+				paths[path] = item
+			}
+			tags = append(tags, entry.Name)
+		case "resource":
+			paths[entry.Path] = map[string]any{
+				"get": map[string]any{
+					"tags":        []string{entry.Name},
+					"summary":     fmt.Sprintf("%s page", entry.Name),
+					"description": "Server-rendered LiveTemplate page. Interactions after load happen over the LiveTemplate WebSocket.",
+					"operationId": "view" + entry.Name,
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "HTML page",
+							"content":     map[string]any{"text/html": map[string]any{"schema": map[string]any{"type": "string"}}},
+						},
+					},
+				},
+			}
+			tags = append(tags, entry.Name)
+		}
+	}
+
+	if hasAPI {
+		schemas["Meta"] = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"page":        map[string]any{"type": "integer"},
+				"per_page":    map[string]any{"type": "integer"},
+				"total":       map[string]any{"type": "integer"},
+				"total_pages": map[string]any{"type": "integer"},
+			},
+			"required": []string{"page", "per_page", "total", "total_pages"},
+		}
+		schemas["Error"] = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"error": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"code":    map[string]any{"type": "string"},
+						"message": map[string]any{"type": "string"},
+					},
+					"required": []string{"code", "message"},
+				},
+			},
+			"required": []string{"error"},
+		}
+	}
+
+	sort.Strings(tags)
+	var tagList []map[string]any
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			tagList = append(tagList, map[string]any{"name": tag})
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": OpenAPIVersion,
+		"info": map[string]any{
+			"title":   title,
+			"version": "1.0.0",
+		},
+		"servers":    []map[string]any{{"url": "http://localhost:8080"}},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+	if len(tagList) > 0 {
+		doc["tags"] = tagList
+	}
+	return doc, nil
+}
+
+// openAPIResourcePaths returns the CRUD operations served by a gen api handler.
+func openAPIResourcePaths(base, name, singular string) map[string]any {
+	ref := func(schema string) map[string]any {
+		return map[string]any{"$ref": "#/components/schemas/" + schema}
+	}
+	jsonContent := func(schema map[string]any) map[string]any {
+		return map[string]any{"application/json": map[string]any{"schema": schema}}
+	}
+	envelope := func(data map[string]any, withMeta bool) map[string]any {
+		props := map[string]any{"data": data}
+		if withMeta {
+			props["meta"] = ref("Meta")
+		}
+		return map[string]any{"type": "object", "properties": props, "required": []string{"data"}}
+	}
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{"description": description, "content": jsonContent(ref("Error"))}
+	}
+	idParam := []map[string]any{{
+		"name": "id", "in": "path", "required": true,
+		"schema": map[string]any{"type": "string"},
+	}}
+	body := map[string]any{"required": true, "content": jsonContent(ref(singular + "Input"))}
+	tags := []string{name}
+
+	return map[string]any{
+		base: map[string]any{
+			"get": map[string]any{
+				"tags":        tags,
+				"summary":     "List " + strings.ToLower(name),
+				"operationId": "list" + name,
+				"parameters": []map[string]any{
+					{"name": "page", "in": "query", "schema": map[string]any{"type": "integer", "minimum": 1, "default": 1}},
+					{"name": "per_page", "in": "query", "schema": map[string]any{"type": "integer", "minimum": 1, "maximum": 100, "default": 20}},
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "A page of " + strings.ToLower(name),
+						"content":     jsonContent(envelope(map[string]any{"type": "array", "items": ref(singular)}, true)),
+					},
+					"500": errorResponse("Internal error"),
+				},
+			},
+			"post": map[string]any{
+				"tags":        tags,
+				"summary":     "Create a " + strings.ToLower(singular),
+				"operationId": "create" + singular,
+				"requestBody": body,
+				"responses": map[string]any{
+					"201": map[string]any{"description": "Created", "content": jsonContent(envelope(ref(singular), false))},
+					"400": errorResponse("Invalid JSON body"),
+					"422": errorResponse("Validation failed"),
+					"500": errorResponse("Internal error"),
+				},
+			},
+		},
+		base + "/{id}": map[string]any{
+			"parameters": idParam,
+			"get": map[string]any{
+				"tags":        tags,
+				"summary":     "Get a " + strings.ToLower(singular),
+				"operationId": "get" + singular,
+				"responses": map[string]any{
+					"200": map[string]any{"description": "OK", "content": jsonContent(envelope(ref(singular), false))},
+					"404": errorResponse("Not found"),
+				},
+			},
+			"put": map[string]any{
+				"tags":        tags,
+				"summary":     "Update a " + strings.ToLower(singular),
+				"operationId": "update" + singular,
+				"requestBody": body,
+				"responses": map[string]any{
+					"200": map[string]any{"description": "Updated", "content": jsonContent(envelope(ref(singular), false))},
+					"400": errorResponse("Invalid JSON body"),
+					"404": errorResponse("Not found"),
+					"422": errorResponse("Validation failed"),
+					"500": errorResponse("Internal error"),
+				},
+			},
+			"delete": map[string]any{
+				"tags":        tags,
+				"summary":     "Delete a " + strings.ToLower(singular),
+				"operationId": "delete" + singular,
+				"responses": map[string]any{
+					"204": map[string]any{"description": "Deleted"},
+					"404": errorResponse("Not found"),
+					"500": errorResponse("Internal error"),
+				},
+			},
+		},
+	}
+}
+
+// openAPIRecordSchema describes a stored record as returned by sqlc models.
+func openAPIRecordSchema(fields []ResourceField) map[string]any {
+	props := map[string]any{"id": map[string]any{"type": "string"}}
+	required := []string{"id"}
+	for _, f := range fields {
+		props[f.Name] = openAPIFieldSchema(f)
+		required = append(required, f.Name)
+		if f.File {
+			props[f.Name+"_filename"] = map[string]any{"type": "string"}
+			props[f.Name+"_content_type"] = map[string]any{"type": "string"}
+			props[f.Name+"_size"] = map[string]any{"type": "integer", "format": "int64"}
+			required = append(required, f.Name+"_filename", f.Name+"_content_type", f.Name+"_size")
+		}
+	}
+	props["created_at"] = map[string]any{"type": "string", "format": "date-time"}
+	required = append(required, "created_at")
+	return map[string]any{"type": "object", "properties": props, "required": required}
+}
+
+// openAPIInputSchema describes the create/update request body of a gen api handler.
+func openAPIInputSchema(fields []ResourceField) map[string]any {
+	props := map[string]any{}
+	var required []string
+	for _, f := range fields {
+		props[f.Name] = openAPIFieldSchema(f)
+		if hasValidateRule(f.Validate, "required") {
+			required = append(required, f.Name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// openAPIFieldSchema maps a field's Go type and validation rules to a JSON schema.
+func openAPIFieldSchema(f ResourceField) map[string]any {
+	schema := map[string]any{}
+	switch {
+	case f.GoType == "time.Time" || (f.GoType == "" && (f.SQLType == "DATETIME" || f.SQLType == "TIMESTAMP")):
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+	case f.GoType == "int64" || f.GoType == "int" || (f.GoType == "" && f.SQLType == "INTEGER"):
+		schema["type"] = "integer"
+		schema["format"] = "int64"
+	case f.GoType == "float64" || (f.GoType == "" && f.SQLType == "REAL"):
+		schema["type"] = "number"
+		schema["format"] = "double"
+	case f.GoType == "bool" || (f.GoType == "" && f.SQLType == "BOOLEAN"):
+		schema["type"] = "boolean"
+	default:
+		schema["type"] = "string"
+	}
+
+	if f.JSON {
+		schema["contentMediaType"] = "application/json"
+	}
+	if f.RichText {
+		schema["contentMediaType"] = "text/html"
+	}
+	if len(f.Options) > 0 {
+		schema["enum"] = f.Options
+	}
+	if f.References != "" {
+		schema["description"] = "ID of a record in " + f.References
+	}
+
+	isString := schema["type"] == "string"
+	for _, rule := range strings.Split(f.Validate, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "email":
+			schema["format"] = "email"
+		case "url":
+			schema["format"] = "uri"
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			switch {
+			case isString && key == "min":
+				schema["minLength"] = int(n)
+			case isString:
+				schema["maxLength"] = int(n)
+			case key == "min":
+				schema["minimum"] = n
+			default:
+				schema["maximum"] = n
+			}
+		}
+	}
+	return schema
+}
+
+func hasValidateRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldsFromSchema recovers field metadata from a parsed CREATE TABLE for
+// manifest entries that predate recorded fields.
+func fieldsFromSchema(table *seeder.TableSchema) []ResourceField {
+	if table == nil {
+		return nil
+	}
+	var fields []ResourceField
+	for _, col := range table.Columns {
+		if col.Name == "id" || col.Name == "created_at" {
+			continue
+		}
+		fields = append(fields, ResourceField{Name: col.Name, SQLType: strings.ToUpper(col.Type), JSON: col.IsJSON})
+	}
+	return fields
+}
+
+// readModuleName returns the module path declared in basePath/go.mod, or "".
+func readModuleName(basePath string) string {
+	data, err := os.ReadFile(filepath.Join(basePath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module"))
+		}
+	}
+	return ""
+}
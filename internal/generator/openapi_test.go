@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestGenerateOpenAPI(t *testing.T) {
+	dir := t.TempDir()
+	if err := generateCounterTestResource(t, dir, "posts", "title:string", "status:select:draft,published", "metadata:json"); err != nil {
+		t.Fatalf("generate resource: %v", err)
+	}
+	fields, err := parser.ParseFields([]string{"title:string", "status:select:draft,published", "metadata:json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateAPI(dir, "testmodule", "posts", fields, "multi"); err != nil {
+		t.Fatalf("generate api: %v", err)
+	}
+
+	doc, err := GenerateOpenAPI(dir)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	// Round-trip through JSON so assertions see what the command prints
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		OpenAPI    string                    `json:"openapi"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, want 3.1.0", spec.OpenAPI)
+	}
+	for path, methods := range map[string][]string{
+		"/posts":             {"get"},
+		"/api/v1/posts":      {"get", "post"},
+		"/api/v1/posts/{id}": {"get", "put", "delete"},
+	} {
+		for _, m := range methods {
+			if _, ok := spec.Paths[path][m]; !ok {
+				t.Errorf("missing %s %s", m, path)
+			}
+		}
+	}
+
+	post, ok := spec.Components.Schemas["Post"]
+	if !ok {
+		t.Fatalf("missing Post schema; have %v", spec.Components.Schemas)
+	}
+	for _, name := range []string{"id", "title", "status", "metadata", "created_at"} {
+		if _, ok := post.Properties[name]; !ok {
+			t.Errorf("Post schema missing %s", name)
+		}
+	}
+	if got := post.Properties["status"]["enum"]; !reflect.DeepEqual(got, []any{"draft", "published"}) {
+		t.Errorf("status enum = %v", got)
+	}
+	if got := post.Properties["metadata"]["contentMediaType"]; got != "application/json" {
+		t.Errorf("metadata contentMediaType = %v", got)
+	}
+	if got := post.Properties["created_at"]["format"]; got != "date-time" {
+		t.Errorf("created_at format = %v", got)
+	}
+
+	input, ok := spec.Components.Schemas["PostInput"]
+	if !ok {
+		t.Fatal("missing PostInput schema")
+	}
+	if _, ok := input.Properties["id"]; ok {
+		t.Error("PostInput should not accept an id")
+	}
+	if !reflect.DeepEqual(input.Required, []string{"title", "status", "metadata"}) {
+		t.Errorf("PostInput required = %v", input.Required)
+	}
+}
+
+func TestGenerateOpenAPIFallsBackToSchema(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "database"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	schema := "CREATE TABLE IF NOT EXISTS widgets (\n  id TEXT PRIMARY KEY,\n  name TEXT NOT NULL,\n  qty INTEGER NOT NULL,\n  created_at DATETIME NOT NULL\n);\n"
+	if err := os.WriteFile(filepath.Join(dir, "database", "schema.sql"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A manifest entry from before field metadata was recorded
+	if err := RegisterResource(dir, "Widgets", "/widgets", "resource"); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := GenerateOpenAPI(dir)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	widget := doc["components"].(map[string]any)["schemas"].(map[string]any)["Widget"].(map[string]any)
+	props := widget["properties"].(map[string]any)
+	if got := props["qty"].(map[string]any)["type"]; got != "integer" {
+		t.Errorf("qty type = %v, want integer", got)
+	}
+	if _, ok := props["name"]; !ok {
+		t.Error("Widget schema missing name")
+	}
+}
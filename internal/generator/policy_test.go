@@ -0,0 +1,156 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourcePolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Policy: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	policyPath := filepath.Join(tmpDir, "app", "posts", "policy.go")
+	policy, err := os.ReadFile(policyPath)
+	if err != nil {
+		t.Fatalf("policy.go not generated: %v", err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), policyPath, policy, goparser.AllErrors); err != nil {
+		t.Fatalf("policy.go does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"func CanList(user PolicyUser) bool",
+		"func CanCreate(user PolicyUser) bool",
+		"func CanUpdate(user PolicyUser, item PostsItem) bool",
+		"func CanDelete(user PolicyUser, item PostsItem) bool",
+	} {
+		if !strings.Contains(string(policy), want) {
+			t.Errorf("policy.go missing %s", want)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "posts", "posts.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"if !CanList(state.CurrentUser) {",
+		"if !CanCreate(state.CurrentUser) {",
+		"!CanUpdate(state.CurrentUser, updateItem)",
+		"!CanDelete(state.CurrentUser, deleteItem)",
+		"func allow(state PostsState) PostsState {",
+		"return allow(state), err",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"{{if .Allowed.Create}}",
+		"{{if index $.Allowed.Update .ID}}",
+		"{{if index .Allowed.Delete .EditingID}}",
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+
+	// The user's rules survive regenerating the resource
+	custom := []byte("package posts\n\n// custom\n")
+	if err := os.WriteFile(policyPath, custom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{Policy: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	got, err := os.ReadFile(policyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(custom) {
+		t.Errorf("policy.go was overwritten:\n%s", got)
+	}
+}
+
+func TestResourceWithoutPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "posts", ResourceOptions{}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "app", "posts", "policy.go")); !os.IsNotExist(err) {
+		t.Errorf("policy.go generated without --policy: %v", err)
+	}
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(tmpl), "Allowed") {
+		t.Error("template checks the policy without --policy")
+	}
+}
+
+func TestResourcePolicyKits(t *testing.T) {
+	fields, err := parser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ kit, css string }{
+		{"single", "tailwind"},
+		{"daisyui", "daisyui"},
+	} {
+		t.Run(tt.kit, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			if err := GenerateResource(tmpDir, "testmodule", "posts", fields, tt.kit, tt.css, "tailwind", "infinite", 20, "modal", "", false, false, ResourceOptions{Policy: true}); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(tmpDir, "app", "posts", "posts.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(data)
+			for _, want := range []string{"{{if .Allowed.Create}}", "{{if index $.Allowed.Update .ID}}", "{{if index .Allowed.Delete .EditingID}}"} {
+				if !strings.Contains(content, want) {
+					t.Errorf("template missing %s", want)
+				}
+			}
+			if _, err := template.New("page").Parse(content); err != nil {
+				t.Errorf("template does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestResourcePolicyUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	fields, err := parser.ParseFields([]string{"body:string", "post_id:references:posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "comments", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "posts", false, false, ResourceOptions{Policy: true})
+	if err == nil || !strings.Contains(err.Error(), "--parent") {
+		t.Errorf("--policy with --parent: err = %v, want it rejected", err)
+	}
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// PrerenderData is the template data for the shared/prerender package.
+type PrerenderData struct {
+	ModuleName string
+}
+
+// PrerenderPackage is the app package serving crawlers and ?static=1 a
+// static render of each page.
+const PrerenderPackage = "shared/prerender/prerender.go"
+
+// livePrerenderVar declares the switch for crawler detection.
+const livePrerenderVar = `{Name: "LIVE_PRERENDER", Type: TypeBool, Default: "true", Description: "Serve search engine crawlers, recognised by their User-Agent, a static render of each page; ?static=1 gets one either way"}`
+
+// PrerenderEnabled reports whether `lvt gen prerender` has been run in
+// projectRoot.
+func PrerenderEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, PrerenderPackage))
+	return err == nil
+}
+
+// GeneratePrerender creates the shared/prerender package and declares
+// LIVE_PRERENDER. Handlers generated afterwards are mounted with
+// prerender.Mount, so crawlers and requests with ?static=1 get the page's
+// HTML without scripts, and resource pages rendered that way page with
+// links instead of WebSocket actions.
+func GeneratePrerender(projectRoot, moduleName string) error {
+	defer track(projectRoot, "prerender")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no shared/config to switch crawler detection with; use the multi, single or daisyui kit")
+	}
+	if PrerenderEnabled(projectRoot) {
+		return fmt.Errorf("prerender already set up (%s exists)", PrerenderPackage)
+	}
+
+	// 1. Create shared/prerender
+	dir := filepath.Join(projectRoot, filepath.Dir(PrerenderPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/prerender directory: %w", err)
+	}
+	data := PrerenderData{ModuleName: moduleName}
+	if err := writeTemplateFile(kits.DefaultLoader(), kitName, "prerender/prerender.go.tmpl", filepath.Join(projectRoot, PrerenderPackage), data); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", PrerenderPackage, err)
+	}
+
+	// 2. Declare LIVE_PRERENDER
+	if err := declareConfigVar(projectRoot, "LIVE_PRERENDER", livePrerenderVar); err != nil {
+		return fmt.Errorf("failed to declare LIVE_PRERENDER: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Prerendering for crawlers can be turned off with LIVE_PRERENDER.
+func TestGeneratePrerender(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	configPath := writeTestConfig(t, dir)
+	if err := GeneratePrerender(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(config), `{Name: "LIVE_PRERENDER", Type: TypeBool, Default: "true"`) {
+		t.Errorf("config.go does not declare LIVE_PRERENDER:\n%s", config)
+	}
+}
+
+func TestPagesPrerender(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "prerender.") {
+		t.Error("resource generated before gen prerender should not be mounted with it")
+	}
+
+	if err := GeneratePrerender(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	generateTestPages(t, dir, "multi")
+
+	for _, page := range []string{"posts", "dashboard", "lobby", "board", "signup"} {
+		path := filepath.Join(dir, "app", page, page+".go")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"testmodule/shared/prerender"`, "return prerender.Mount(http.HandlerFunc("} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s handler is missing %q", page, want)
+			}
+		}
+		typeCheckPackage(t, filepath.Dir(path))
+	}
+
+	handler, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"state.Static = prerender.Static(ctx)", "if state.Static {", "Pages          []int"} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("posts handler is missing %q", want)
+		}
+	}
+	tmpl, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`{{define "staticPagination"}}`, `{{if .Static}}`, `href="?static=1&amp;page={{.}}"`} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("posts template is missing %q", want)
+		}
+	}
+}
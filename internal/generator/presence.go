@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// presencePackagePath is the generated registry of who has the pages of
+// resources generated with --presence open.
+const presencePackagePath = "app/presence/presence.go"
+
+// generatePresence writes the app/presence package unless it already exists.
+func generatePresence(projectRoot string, kitLoader *kits.KitLoader, kitName string) error {
+	path := filepath.Join(projectRoot, presencePackagePath)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create app/presence directory: %w", err)
+	}
+	for _, f := range []string{"presence.go", "presence_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "presence/"+f+".tmpl", filepath.Join(dir, f), nil); err != nil {
+			return fmt.Errorf("failed to generate app/presence/%s: %w", f, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourcePresence(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Presence: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	for _, f := range []string{"presence.go", "presence_test.go"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "app", "presence", f)); err != nil {
+			t.Errorf("app/presence/%s not generated: %v", f, err)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"testmodule/app/presence"`,
+		"func (c *TasksController) OnConnect(",
+		"func (c *TasksController) OnDisconnect() {",
+		"func (c *TasksController) PresenceSync(",
+		`presence.Join("tasks", state.PresenceKey, c.presenceName(ctx), ctx.Session())`,
+		`presence.Disconnected("tasks")`,
+		"editing = state.EditingID",
+		"state.Editors[v.Editing] = v.Name",
+		"PresenceIntervalMS: presence.Interval.Milliseconds(),",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+	if strings.Contains(string(handler), "GetUserByID") {
+		t.Error("handler should not look users up without auth")
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`{{template "presenceBar" .}}`,
+		`{{range .Viewers}}`,
+		`{{with index $.Editors .ID}}`,
+		"action: 'presence_sync'",
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+
+	test, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(test), `"action": "presence_sync",`) {
+		t.Error("WebSocket test does not check the second viewer")
+	}
+}
+
+func TestResourcePresenceWithAuth(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "app", "auth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Presence: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"livetemplate.WithAuthenticator(",
+		"return user.Email",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+}
+
+func TestResourcePresenceKits(t *testing.T) {
+	fields, err := parser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ kit, css string }{
+		{"single", "tailwind"},
+		{"daisyui", "daisyui"},
+	} {
+		t.Run(tt.kit, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			if err := GenerateResource(tmpDir, "testmodule", "tasks", fields, tt.kit, tt.css, "tailwind", "infinite", 20, "modal", "", false, false, ResourceOptions{Presence: true}); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(data)
+			for _, want := range []string{"data-presence-viewer", "data-presence-lock", "action: 'presence_sync'"} {
+				if !strings.Contains(content, want) {
+					t.Errorf("template missing %s", want)
+				}
+			}
+			if _, err := template.New("page").Parse(content); err != nil {
+				t.Errorf("template does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestResourcePresenceUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	fields, err := parser.ParseFields([]string{"body:string", "post_id:references:posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "comments", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "posts", false, false, ResourceOptions{Presence: true})
+	if err == nil || !strings.Contains(err.Error(), "--parent") {
+		t.Errorf("--presence with --parent: err = %v, want it rejected", err)
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/livetemplate/lvt/internal/kits"
 )
 
-func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) error {
+// GenerateApp creates a new app in ./appName. theme is the default daisyUI
+// theme of a daisyui kit app; "" follows the browser's light/dark preference.
+func GenerateApp(appName, moduleName, kit, stylesAdapter, theme string, devMode bool) error {
 	// Sanitize app name
 	appName = strings.ToLower(strings.TrimSpace(appName))
 	if appName == "" {
@@ -21,6 +23,7 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 	if _, err := os.Stat(appName); err == nil {
 		return fmt.Errorf("directory '%s' already exists", appName)
 	}
+	defer track(appName, "new")()
 
 	// Load kit using KitLoader
 	kitLoader := kits.DefaultLoader()
@@ -31,6 +34,13 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 
 	// Get CSS framework from kit manifest
 	cssFramework := kitInfo.Manifest.CSSFramework
+	// The static helpers only know tailwind and none; other frameworks need
+	// the kit's helpers for the home page
+	if kitInfo.Helpers == nil && cssFramework != "tailwind" && cssFramework != "none" {
+		if err := kitInfo.SetHelpersForFramework(cssFramework); err != nil {
+			return fmt.Errorf("failed to load CSS helpers for framework %q: %w", cssFramework, err)
+		}
+	}
 
 	// Module name is provided by caller (defaults to app name)
 	data := AppData{
@@ -40,6 +50,7 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 		Kit:          kitInfo,
 		CSSFramework: cssFramework,
 		Styles:       stylesAdapter,
+		Theme:        theme,
 	}
 
 	// Simple kit generates just 2 files
@@ -55,7 +66,10 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 		filepath.Join(appName, "app", "home"), // Home page directory
 		filepath.Join(appName, "database", "models"),
 		filepath.Join(appName, "database", "migrations"),
-		filepath.Join(appName, "shared"),
+		filepath.Join(appName, "shared", "actionqueue"),
+		filepath.Join(appName, "shared", "config"),
+		filepath.Join(appName, "shared", "csrf"),
+		filepath.Join(appName, "shared", "lifecycle"),
 		filepath.Join(appName, "web", "assets"),
 	}
 
@@ -65,6 +79,11 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 		}
 	}
 
+	// Kit assets are served from web/assets/<kit>
+	if err := copyKitAssets(appName, kitInfo); err != nil {
+		return err
+	}
+
 	// Read templates using kit loader (checks project kits, user kits, then embedded)
 	mainGoTmpl, err := kitLoader.LoadKitTemplate(kit, "app/main.go.tmpl")
 	if err != nil {
@@ -81,6 +100,46 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 		return fmt.Errorf("failed to read db.go template: %w", err)
 	}
 
+	stmtCacheTmpl, err := kitLoader.LoadKitTemplate(kit, "app/stmtcache.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read stmtcache.go template: %w", err)
+	}
+
+	actionQueueTmpl, err := kitLoader.LoadKitTemplate(kit, "app/actionqueue.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read actionqueue.go template: %w", err)
+	}
+
+	actionQueueTestTmpl, err := kitLoader.LoadKitTemplate(kit, "app/actionqueue_test.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read actionqueue_test.go template: %w", err)
+	}
+
+	configTmpl, err := kitLoader.LoadKitTemplate(kit, "app/config.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read config.go template: %w", err)
+	}
+
+	csrfTmpl, err := kitLoader.LoadKitTemplate(kit, "app/csrf.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read csrf.go template: %w", err)
+	}
+
+	csrfTestTmpl, err := kitLoader.LoadKitTemplate(kit, "app/csrf_test.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read csrf_test.go template: %w", err)
+	}
+
+	lifecycleTmpl, err := kitLoader.LoadKitTemplate(kit, "app/lifecycle.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read lifecycle.go template: %w", err)
+	}
+
+	lifecycleTestTmpl, err := kitLoader.LoadKitTemplate(kit, "app/lifecycle_test.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read lifecycle_test.go template: %w", err)
+	}
+
 	sqlcYamlTmpl, err := kitLoader.LoadKitTemplate(kit, "app/sqlc.yaml.tmpl")
 	if err != nil {
 		return fmt.Errorf("failed to read sqlc.yaml template: %w", err)
@@ -116,6 +175,40 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 		return fmt.Errorf("failed to generate db.go: %w", err)
 	}
 
+	// Generate database/stmtcache.go (prepared statement cache and query metrics)
+	if err := generateFile(string(stmtCacheTmpl), data, filepath.Join(appName, "database", "stmtcache.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate stmtcache.go: %w", err)
+	}
+
+	// Generate shared/actionqueue (per-session request ordering) and its tests
+	if err := generateFile(string(actionQueueTmpl), data, filepath.Join(appName, "shared", "actionqueue", "actionqueue.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate actionqueue.go: %w", err)
+	}
+	if err := generateFile(string(actionQueueTestTmpl), data, filepath.Join(appName, "shared", "actionqueue", "actionqueue_test.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate actionqueue_test.go: %w", err)
+	}
+
+	// Generate shared/config (declared environment variables)
+	if err := generateFile(string(configTmpl), data, filepath.Join(appName, "shared", "config", "config.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate config.go: %w", err)
+	}
+
+	// Generate shared/csrf (cross-site request forgery checks) and its tests
+	if err := generateFile(string(csrfTmpl), data, filepath.Join(appName, "shared", "csrf", "csrf.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate csrf.go: %w", err)
+	}
+	if err := generateFile(string(csrfTestTmpl), data, filepath.Join(appName, "shared", "csrf", "csrf_test.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate csrf_test.go: %w", err)
+	}
+
+	// Generate shared/lifecycle (graceful shutdown and hooks) and its tests
+	if err := generateFile(string(lifecycleTmpl), data, filepath.Join(appName, "shared", "lifecycle", "lifecycle.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate lifecycle.go: %w", err)
+	}
+	if err := generateFile(string(lifecycleTestTmpl), data, filepath.Join(appName, "shared", "lifecycle", "lifecycle_test.go"), kitInfo); err != nil {
+		return fmt.Errorf("failed to generate lifecycle_test.go: %w", err)
+	}
+
 	// Generate database/sqlc.yaml
 	if err := generateFile(string(sqlcYamlTmpl), data, filepath.Join(appName, "database", "sqlc.yaml"), kitInfo); err != nil {
 		return fmt.Errorf("failed to generate sqlc.yaml: %w", err)
@@ -145,6 +238,11 @@ func GenerateApp(appName, moduleName, kit, stylesAdapter string, devMode bool) e
 		return fmt.Errorf("failed to generate home.tmpl: %w", err)
 	}
 
+	// Generate shared/funcs (the app's own template functions)
+	if err := generateFuncs(appName, kitLoader, kit); err != nil {
+		return err
+	}
+
 	// Create README
 	readme := fmt.Sprintf(`# %s
 
@@ -183,6 +281,7 @@ A LiveTemplate application.
 - `+"`database/`"+` - Database layer with sqlc
 - `+"`database/migrations/`"+` - Database migrations
 - `+"`shared/`"+` - Shared utilities
+- `+"`shared/funcs/`"+` - Template functions available to every page
 
 ## Database Migrations
 
@@ -224,6 +323,7 @@ go test ./...
 		Kit:     kit,
 		Styles:  data.Styles,
 		DevMode: devMode,
+		Theme:   theme,
 	}
 	if err := config.SaveProjectConfig(appName, projectConfig); err != nil {
 		return fmt.Errorf("failed to save project config: %w", err)
@@ -342,13 +442,16 @@ lvt new myapp --kit multi
 	return nil
 }
 
-// writeGitignore creates a .gitignore file. When hasDatabase is true, SQLite
-// ignore patterns are included.
+// writeGitignore creates a .gitignore file, ignoring the development log
+// lvt serve keeps. When hasDatabase is true, SQLite and backup ignore
+// patterns are included.
 func writeGitignore(dir, appName string, hasDatabase bool) error {
 	var sb strings.Builder
 	sb.WriteString("# Environment variables\n.env\n\n")
+	sb.WriteString("# Development log (lvt serve)\n.lvt/logs/\n\n")
 	if hasDatabase {
 		sb.WriteString("# SQLite databases\n*.db\n*.db-journal\n*.db-wal\n*.db-shm\n\n")
+		sb.WriteString("# Database backups (lvt db backup)\nbackups/\n\n")
 	}
 	sb.WriteString("# Binary\n")
 	sb.WriteString(appName)
@@ -372,8 +475,9 @@ PORT=8080
 
 `)
 	if hasDatabase {
-		sb.WriteString(`# Application environment (development, production)
-APP_ENV=development
+		sb.WriteString(`# Application environment (development, test, production); selects the
+# database below. APP_ENV works too.
+LVT_ENV=development
 
 `)
 	}
@@ -382,8 +486,11 @@ LOG_LEVEL=info
 
 `)
 	if hasDatabase {
-		sb.WriteString(`# SQLite database file path
-DATABASE_PATH=app.db
+		sb.WriteString(`# SQLite database for each environment. DATABASE_PATH overrides all of
+# them except test, which uses an in-memory database unless set.
+DATABASE_PATH_DEVELOPMENT=app.db
+# DATABASE_PATH_TEST=test.db
+# DATABASE_PATH_PRODUCTION=/data/app.db
 
 `)
 	}
@@ -405,3 +512,13 @@ func ReadDevMode(basePath string) bool {
 	}
 	return projectConfig.DevMode
 }
+
+// ReadTheme reads the default daisyUI theme from .lvtrc in basePath.
+// Returns "" if .lvtrc doesn't exist or no theme is set
+func ReadTheme(basePath string) string {
+	projectConfig, err := config.LoadProjectConfig(basePath)
+	if err != nil {
+		return ""
+	}
+	return projectConfig.Theme
+}
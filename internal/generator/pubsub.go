@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// PubSubData is the template data for the shared/pubsub package and the
+// bridges relaying other packages over it.
+type PubSubData struct {
+	ModuleName string
+	Namespace  string // prefix of the app's channels or subjects
+}
+
+// PubSubPackage is the app package carrying messages between instances.
+const PubSubPackage = "shared/pubsub/pubsub.go"
+
+// The broker clients the pub/sub bridge can be generated for.
+const (
+	PubSubRedis = "redis"
+	PubSubNATS  = "nats"
+)
+
+// pubSubURLVar declares the broker connecting the app's instances.
+const pubSubURLVar = `{Name: "PUBSUB_URL", Type: TypeString, Secret: true, Description: "Broker carrying presence and broadcasts between instances, e.g. redis://localhost:6379 or nats://localhost:4222; single instance when unset"}`
+
+// pubSubBridges are the in-memory packages whose fan-out the bridge
+// relays: each gets the bridge file once it has the hook the file sets.
+var pubSubBridges = []struct {
+	pkg, hook, template, file string
+}{
+	{presencePackagePath, "func (r *Registry) Bridge(", "pubsub/presence_bridge.go.tmpl", "app/presence/bridge.go"},
+	{SSEPackage, "var relay func(delivery)", "pubsub/sse_bridge.go.tmpl", "shared/sse/bridge.go"},
+}
+
+// PubSubEnabled reports whether `lvt gen pubsub` has been run in projectRoot.
+func PubSubEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, PubSubPackage))
+	return err == nil
+}
+
+// PubSubBroker returns the broker the pub/sub bridge was generated for in
+// projectRoot, or "" without one.
+func PubSubBroker(projectRoot string) string {
+	dir := filepath.Join(projectRoot, filepath.Dir(PubSubPackage))
+	for _, broker := range []string{PubSubRedis, PubSubNATS} {
+		if _, err := os.Stat(filepath.Join(dir, broker+".go")); err == nil {
+			return broker
+		}
+	}
+	return ""
+}
+
+// GeneratePubSub creates the shared/pubsub package with the bridge of
+// broker (redis or nats), declares PUBSUB_URL and connects it in main.go.
+// app/presence and shared/sse, if generated, get a bridge.go relaying
+// their viewers and streams to the app's other instances.
+func GeneratePubSub(projectRoot, moduleName, broker string) error {
+	defer track(projectRoot, "pubsub")()
+	var client string
+	switch broker {
+	case PubSubRedis:
+		client = "github.com/redis/go-redis/v9"
+	case PubSubNATS:
+		client = "github.com/nats-io/nats.go"
+	default:
+		return fmt.Errorf("unknown pub/sub broker %q (expected redis or nats)", broker)
+	}
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit runs as a single instance; use the multi, single or daisyui kit")
+	}
+	if PubSubEnabled(projectRoot) {
+		return fmt.Errorf("pub/sub already set up (%s exists)", PubSubPackage)
+	}
+
+	// 1. Create shared/pubsub
+	kitLoader := kits.DefaultLoader()
+	dir := filepath.Join(projectRoot, filepath.Dir(PubSubPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/pubsub directory: %w", err)
+	}
+	data := PubSubData{ModuleName: moduleName, Namespace: path.Base(moduleName)}
+	for _, f := range []string{"pubsub.go", "pubsub_test.go", broker + ".go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "pubsub/"+f+".tmpl", filepath.Join(dir, f), data); err != nil {
+			return fmt.Errorf("failed to generate shared/pubsub/%s: %w", f, err)
+		}
+	}
+
+	// 2. Relay presence and event streams
+	if err := generatePubSubBridges(projectRoot, moduleName, kitLoader, kitName); err != nil {
+		return err
+	}
+
+	// 3. Declare PUBSUB_URL and add the broker's client
+	if err := declareConfigVar(projectRoot, "PUBSUB_URL", pubSubURLVar); err != nil {
+		return fmt.Errorf("failed to declare PUBSUB_URL: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, "go.mod")); err == nil {
+		if output, err := runTool(projectRoot, "go", "get", client+"@latest"); err != nil {
+			logging.Warn("could not fetch the broker client; run 'go mod tidy' to resolve", "broker", broker, "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+		}
+	}
+
+	// 4. Connect to the broker in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectPubSubSetup(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to inject the pub/sub setup into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// generatePubSubBridges writes the bridge.go of each package the bridge
+// relays that exists and lacks one, once shared/pubsub does. Packages
+// generated before they had the bridge's hook are left alone.
+func generatePubSubBridges(projectRoot, moduleName string, kitLoader *kits.KitLoader, kitName string) error {
+	if !PubSubEnabled(projectRoot) {
+		return nil
+	}
+	data := PubSubData{ModuleName: moduleName, Namespace: path.Base(moduleName)}
+	for _, b := range pubSubBridges {
+		content, err := os.ReadFile(filepath.Join(projectRoot, b.pkg))
+		if err != nil {
+			continue // not generated
+		}
+		bridgePath := filepath.Join(projectRoot, b.file)
+		if _, err := os.Stat(bridgePath); err == nil {
+			continue
+		}
+		if !strings.Contains(string(content), b.hook) {
+			logging.Warn(b.pkg + " predates the pub/sub bridge; regenerate it to reach other instances")
+			continue
+		}
+		if err := writeTemplateFile(kitLoader, kitName, b.template, bridgePath, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", b.file, err)
+		}
+	}
+	return nil
+}
+
+// injectPubSubSetup connects to the broker before the server starts, next
+// to the other setups following the database's.
+func injectPubSubSetup(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "pubsub.Setup(") {
+		return nil // Already injected
+	}
+
+	anchor := "\t\tdatabase.CloseDB()\n\t\treturn nil\n\t})\n"
+	idx := strings.Index(mainStr, anchor)
+	if idx < 0 {
+		return fmt.Errorf("could not find the database shutdown hook (expected %q)", "database.CloseDB()")
+	}
+	idx += len(anchor)
+	setup := "\n\t// Pub/sub bridge: relays presence and broadcasts when PUBSUB_URL is set (see shared/pubsub)\n" +
+		"\tif err := pubsub.Setup(context.Background()); err != nil {\n" +
+		"\t\tslog.Error(\"Failed to connect to the pub/sub broker\", \"error\", err)\n" +
+		"\t\tos.Exit(1)\n" +
+		"\t}\n"
+	mainStr = mainStr[:idx] + setup + mainStr[idx:]
+
+	for _, imp := range []string{"\t\"context\"", fmt.Sprintf("\t\"%s/shared/pubsub\"", moduleName)} {
+		if mainStr, err = injectImport(mainStr, imp); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePubSubTestApp writes the main.go and config.go GeneratePubSub
+// updates.
+func writePubSubTestApp(t *testing.T, dir string) (mainGoPath, configPath string) {
+	t.Helper()
+	mainGoPath = filepath.Join(dir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+func main() {
+	lifecycle.OnShutdown("database", func(ctx context.Context) error {
+		database.CloseDB()
+		return nil
+	})
+
+	slog.Info("Starting")
+	os.Exit(0)
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return mainGoPath, writeTestConfig(t, dir)
+}
+
+func TestGeneratePubSub(t *testing.T) {
+	for _, broker := range []string{PubSubRedis, PubSubNATS} {
+		t.Run(broker, func(t *testing.T) {
+			dir := t.TempDir()
+			setupTestProject(t, dir)
+			mainGoPath, configPath := writePubSubTestApp(t, dir)
+			if PubSubBroker(dir) != "" {
+				t.Fatal("broker reported before generation")
+			}
+			if err := GeneratePubSub(dir, "example.com/shop", broker); err != nil {
+				t.Fatal(err)
+			}
+			if PubSubBroker(dir) != broker {
+				t.Errorf("PubSubBroker() = %q after generation, want %q", PubSubBroker(dir), broker)
+			}
+
+			typeCheckPackage(t, filepath.Join(dir, "shared", "pubsub"))
+			bridge, err := os.ReadFile(filepath.Join(dir, "shared", "pubsub", broker+".go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(bridge), `bridges["`+broker+`"]`) || !strings.Contains(string(bridge), `"shop`) {
+				t.Errorf("%s.go should register its scheme under the app's namespace:\n%s", broker, bridge)
+			}
+
+			content, err := os.ReadFile(mainGoPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mainStr := string(content)
+			for _, want := range []string{`"context"`, `"example.com/shop/shared/pubsub"`, "pubsub.Setup(context.Background())"} {
+				if !strings.Contains(mainStr, want) {
+					t.Errorf("main.go missing %q", want)
+				}
+			}
+			if strings.Index(mainStr, "pubsub.Setup(") < strings.Index(mainStr, "database.CloseDB()") {
+				t.Error("the bridge should be set up after the database shutdown hook is registered")
+			}
+
+			content, err = os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), pubSubURLVar) {
+				t.Errorf("config.go does not declare PUBSUB_URL:\n%s", content)
+			}
+		})
+	}
+}
+
+func TestGeneratePubSub_Rejected(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := GeneratePubSub(dir, "testmodule", "kafka"); err == nil || !strings.Contains(err.Error(), "unknown pub/sub broker") {
+		t.Errorf("GeneratePubSub() with kafka = %v, want it rejected", err)
+	}
+	if PubSubEnabled(dir) {
+		t.Error("rejected generation still created the package")
+	}
+}
+
+func TestPubSubBridges(t *testing.T) {
+	bridgePath := filepath.Join("app", "presence", "bridge.go")
+
+	t.Run("presence before pubsub", func(t *testing.T) {
+		dir := t.TempDir()
+		setupTestProject(t, dir)
+		if err := generateEventsTestResource(t, dir, "tasks", ResourceOptions{Presence: true}, "title:string"); err != nil {
+			t.Fatalf("GenerateResource failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, bridgePath)); err == nil {
+			t.Fatal("bridge.go generated without shared/pubsub")
+		}
+		if err := GeneratePubSub(dir, "testmodule", PubSubRedis); err != nil {
+			t.Fatal(err)
+		}
+		checkPubSubBridge(t, filepath.Join(dir, bridgePath), "Default.Bridge(")
+	})
+
+	t.Run("pubsub before presence", func(t *testing.T) {
+		dir := t.TempDir()
+		setupTestProject(t, dir)
+		if err := GeneratePubSub(dir, "testmodule", PubSubRedis); err != nil {
+			t.Fatal(err)
+		}
+		if err := generateEventsTestResource(t, dir, "tasks", ResourceOptions{Presence: true}, "title:string"); err != nil {
+			t.Fatalf("GenerateResource failed: %v", err)
+		}
+		checkPubSubBridge(t, filepath.Join(dir, bridgePath), "Default.Bridge(")
+	})
+
+	t.Run("sse", func(t *testing.T) {
+		dir := t.TempDir()
+		setupTestProject(t, dir)
+		if err := GeneratePubSub(dir, "testmodule", PubSubNATS); err != nil {
+			t.Fatal(err)
+		}
+		if err := GenerateSSE(dir, "testmodule"); err != nil {
+			t.Fatal(err)
+		}
+		checkPubSubBridge(t, filepath.Join(dir, "shared", "sse", "bridge.go"), "relay = func(d delivery)")
+	})
+
+	t.Run("outdated presence", func(t *testing.T) {
+		dir := t.TempDir()
+		setupTestProject(t, dir)
+		path := filepath.Join(dir, presencePackagePath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package presence\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := GeneratePubSub(dir, "testmodule", PubSubRedis); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, bridgePath)); err == nil {
+			t.Error("bridge.go generated for a presence package without the hook")
+		}
+	})
+}
+
+func checkPubSubBridge(t *testing.T, path, want string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s not generated: %v", filepath.Base(path), err)
+	}
+	for _, s := range []string{`"testmodule/shared/pubsub"`, want} {
+		if !strings.Contains(string(content), s) {
+			t.Errorf("%s is missing %q", path, s)
+		}
+	}
+	typeCheckPackage(t, filepath.Dir(path))
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// renderCachePackagePath is the generated cache of the states produced by
+// the read actions of resources generated with --render-cache.
+const renderCachePackagePath = "shared/rendercache/rendercache.go"
+
+// generateRenderCache writes the shared/rendercache package unless it
+// already exists, and adds its metrics to the dev-mode /debug/queries.
+func generateRenderCache(projectRoot, moduleName string, kitLoader *kits.KitLoader, kitName string) error {
+	path := filepath.Join(projectRoot, renderCachePackagePath)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/rendercache directory: %w", err)
+	}
+	for _, f := range []string{"rendercache.go", "rendercache_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "rendercache/"+f+".tmpl", filepath.Join(dir, f), nil); err != nil {
+			return fmt.Errorf("failed to generate shared/rendercache/%s: %w", f, err)
+		}
+	}
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectRenderCacheMetrics(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to add the render cache metrics to main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// queryMetricsRoute is the registration of /debug/queries in main.go.
+const queryMetricsRoute = `http.HandleFunc("/debug/queries", database.QueryMetricsHandler)`
+
+// injectRenderCacheMetrics wraps /debug/queries with
+// rendercache.MetricsHandler. A main.go without the route is left alone.
+func injectRenderCacheMetrics(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "rendercache.MetricsHandler") || !strings.Contains(mainStr, queryMetricsRoute) {
+		return nil
+	}
+	mainStr = strings.Replace(mainStr, queryMetricsRoute,
+		`http.HandleFunc("/debug/queries", rendercache.MetricsHandler(database.QueryMetricsHandler))`, 1)
+	if mainStr, err = injectImport(mainStr, fmt.Sprintf("\t\"%s/shared/rendercache\"", moduleName)); err != nil {
+		return err
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResourceRenderCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	mainGoPath := filepath.Join(tmpDir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport (\n\t\"net/http\"\n\n\t\"testmodule/database\"\n)\n\nfunc main() {\n\t" + queryMetricsRoute + "\n}\n"
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{RenderCache: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	for _, f := range []string{"rendercache.go", "rendercache_test.go"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "shared", "rendercache", f)); err != nil {
+			t.Errorf("shared/rendercache/%s not generated: %v", f, err)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"testmodule/shared/rendercache"`,
+		`var renderCache = rendercache.New("tasks")`,
+		"return c.nextPage(state, ctx)",
+		"return c.search(state, ctx)",
+		"func (c *TasksController) loadMore(",
+		"renderCache.Invalidate()",
+		`json:"last_updated" rendercache:"-"`,
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+	if n := strings.Count(string(handler), "c.invalidateCache(dbCtx)"); n < 3 {
+		t.Errorf("writes invalidate the cache %d times, want create, update and delete", n)
+	}
+
+	main, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"testmodule/shared/rendercache"`,
+		`http.HandleFunc("/debug/queries", rendercache.MetricsHandler(database.QueryMetricsHandler))`,
+	} {
+		if !strings.Contains(string(main), want) {
+			t.Errorf("main.go missing %s", want)
+		}
+	}
+
+	// A second resource reuses the package without wrapping the route again
+	if err := generateEventsTestResource(t, tmpDir, "notes", ResourceOptions{RenderCache: true}, "body:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	if main, _ := os.ReadFile(mainGoPath); strings.Count(string(main), "rendercache.MetricsHandler") != 1 {
+		t.Errorf("main.go wraps /debug/queries more than once:\n%s", main)
+	}
+}
+
+func TestResourceWithoutRenderCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(handler), "rendercache") || strings.Contains(string(handler), "invalidateCache") {
+		t.Error("handler uses the render cache without --render-cache")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, renderCachePackagePath)); err == nil {
+		t.Error("shared/rendercache generated without --render-cache")
+	}
+}
@@ -5,17 +5,224 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/parser"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
-func GenerateResource(basePath, moduleName, resourceName string, fields []parser.Field, kitName, cssFramework, styles, paginationMode string, pageSize int, editMode, parentResource string, withAuthz, searchable bool) error {
+// ResourceOptions holds optional settings for GenerateResource.
+// The zero value generates a full CRUD resource.
+type ResourceOptions struct {
+	// Actions restricts which CRUD actions are generated. Nil generates all of them.
+	Actions *ResourceActions
+
+	// FromTable generates the resource for an existing table instead of
+	// creating one: no migration is written, and the table's DDL is added to
+	// schema.sql only if it isn't already there.
+	FromTable *TableInfo
+
+	// EmitEvents makes create/update/delete publish change events through
+	// the generated app/events package.
+	EmitEvents bool
+
+	// Cache caches the filtered, sorted list per search and sort order
+	// through the generated shared/cache package; writes invalidate it.
+	Cache bool
+
+	// RenderCache runs the list's read actions (paging, page size, search
+	// and filters) through the generated shared/rendercache package, which
+	// returns the state an action produced before from the same state
+	// instead of running it again; writes invalidate it.
+	RenderCache bool
+
+	// Filters adds per-field filter widgets to the toolbar and loads the
+	// list with one query searching, filtering and sorting it, built
+	// through the generated app/filter package.
+	Filters bool
+
+	// SavedViews lets users save the list's search, filters, sort and page
+	// size as named views, kept per user in the shared saved_views table.
+	SavedViews bool
+
+	// Sortable adds a position column and drag handles to reorder the rows;
+	// the list shows them in that manual order unless sorted otherwise.
+	Sortable bool
+
+	// Undo keeps a deleted record in the session for a few seconds and shows
+	// a notice with an Undo button that re-inserts it.
+	Undo bool
+
+	// Presence shows who else has the list open, as avatar chips, and marks
+	// the rows they are editing, through the generated app/presence package.
+	Presence bool
+
+	// Conflicts holds back a save when someone else changed or deleted the
+	// record since its edit form opened, offering to reload or overwrite.
+	Conflicts bool
+
+	// Policy generates policy.go with CanList, CanCreate, CanUpdate and
+	// CanDelete functions, which the handler asks before each action and the
+	// page asks to hide the controls they refuse.
+	Policy bool
+
+	// Hooks generates <resource>_hooks.go with BeforeCreate, AfterCreate,
+	// BeforeUpdate, AfterUpdate, BeforeDelete and AfterDelete functions,
+	// which the handler calls around each write.
+	Hooks bool
+
+	// IDType selects how record IDs are generated: IDTypeUUID, IDTypeULID,
+	// or "" for "<resource>-<unix nanos>" IDs.
+	IDType string
+
+	// Indexes are extra indexes on the table, e.g. composite, unique or
+	// partial ones, created by the migration alongside the generated ones.
+	Indexes []parser.Index
+
+	// KitPaths are extra directories searched for kitName, after the project
+	// and user kit directories, e.g. a staged copy of a kit under development.
+	KitPaths []string
+
+	// AssembleComponents builds the page template from the kit's components,
+	// as for kits extending multi, even when the kit doesn't extend it. Kit
+	// development mode uses it for its component gallery.
+	AssembleComponents bool
+}
+
+// appPackages are the packages lvt generates under app/ for the app as a
+// whole. A resource, view or wizard is generated into app/<name>, so it
+// cannot take one of these names.
+var appPackages = []string{"audit", "auth", "events", "filter", "i18n", "ids", "jobs", "presence", "richtext", "slugs"}
+
+// checkAppPackage rejects a resource, view or wizard name that would be
+// generated into one of the appPackages.
+func checkAppPackage(kind, name string) error {
+	lower := strings.ToLower(name)
+	if slices.Contains(appPackages, lower) {
+		return fmt.Errorf("%s name %q is reserved: lvt generates app/%s for the whole app; choose another name", kind, name, lower)
+	}
+	return nil
+}
+
+func GenerateResource(basePath, moduleName, resourceName string, fields []parser.Field, kitName, cssFramework, styles, paginationMode string, pageSize int, editMode, parentResource string, withAuthz, searchable bool, opts ...ResourceOptions) error {
+	if err := checkAppPackage("resource", resourceName); err != nil {
+		return err
+	}
+	useInflections(basePath)
+	defer track(basePath, "resource "+strings.ToLower(resourceName))()
+	var options ResourceOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	actions := AllActions()
+	if options.Actions != nil {
+		actions = *options.Actions
+	}
+	if parentResource != "" && !actions.IsFull() {
+		return fmt.Errorf("restricting actions is not supported for embedded resources (--parent)")
+	}
+	if parentResource != "" && options.EmitEvents {
+		return fmt.Errorf("--emit-events is not supported for embedded resources (--parent)")
+	}
+	if options.Cache {
+		if parentResource != "" {
+			return fmt.Errorf("--cache is not supported for embedded resources (--parent)")
+		}
+		if !CacheEnabled(basePath) {
+			return fmt.Errorf("--cache requires the cache package; run 'lvt gen cache' first")
+		}
+	}
+	if options.RenderCache && parentResource != "" {
+		return fmt.Errorf("--render-cache is not supported for embedded resources (--parent)")
+	}
+	if options.Filters {
+		if parentResource != "" {
+			return fmt.Errorf("--filters is not supported for embedded resources (--parent)")
+		}
+		if options.FromTable != nil {
+			return fmt.Errorf("--filters is not supported with an existing table")
+		}
+		if paginationMode == "cursor" {
+			return fmt.Errorf("--filters is not supported with cursor pagination")
+		}
+	}
+	if parentResource != "" && options.SavedViews {
+		return fmt.Errorf("--saved-views is not supported for embedded resources (--parent)")
+	}
+	if options.Sortable {
+		if parentResource != "" {
+			return fmt.Errorf("--sortable is not supported for embedded resources (--parent)")
+		}
+		if options.FromTable != nil {
+			return fmt.Errorf("--sortable is not supported with an existing table (the position column requires a migration)")
+		}
+		if paginationMode == "cursor" {
+			return fmt.Errorf("--sortable is not supported with cursor pagination")
+		}
+	}
+	if options.Undo {
+		if parentResource != "" {
+			return fmt.Errorf("--undo is not supported for embedded resources (--parent)")
+		}
+		if !actions.Delete {
+			return fmt.Errorf("--undo requires the delete action")
+		}
+		for _, f := range fields {
+			if f.IsFile {
+				return fmt.Errorf("--undo is not supported with file fields (%s): deleting removes the stored file", f.Name)
+			}
+		}
+	}
+	if parentResource != "" && options.Presence {
+		return fmt.Errorf("--presence is not supported for embedded resources (--parent)")
+	}
+	if options.Conflicts {
+		if parentResource != "" {
+			return fmt.Errorf("--conflicts is not supported for embedded resources (--parent)")
+		}
+		if !actions.Edit {
+			return fmt.Errorf("--conflicts requires the edit action")
+		}
+		for _, f := range fields {
+			if f.IsFile {
+				return fmt.Errorf("--conflicts is not supported with file fields (%s): a held-back save can't keep the upload", f.Name)
+			}
+		}
+	}
+	if parentResource != "" && options.Policy {
+		return fmt.Errorf("--policy is not supported for embedded resources (--parent)")
+	}
+	if parentResource != "" && options.Hooks {
+		return fmt.Errorf("--hooks is not supported for embedded resources (--parent)")
+	}
+	if err := ValidateIDType(options.IDType); err != nil {
+		return err
+	}
+	if options.IDType == IDTypeUUID && paginationMode == "cursor" {
+		return fmt.Errorf("--id uuid is not supported with cursor pagination (cursor pages follow ID order, and UUIDs are random)")
+	}
+	if table := options.FromTable; table != nil {
+		if parentResource != "" {
+			return fmt.Errorf("generating from an existing table is not supported for embedded resources (--parent)")
+		}
+		if searchable {
+			return fmt.Errorf("--searchable is not supported with an existing table (the FTS index requires a migration)")
+		}
+		if hasCreatedBy := table.Column("created_by") != nil; hasCreatedBy != withAuthz {
+			if hasCreatedBy {
+				return fmt.Errorf("table %q has a created_by column; generate it with --with-authz", table.Name)
+			}
+			return fmt.Errorf("--with-authz requires a created_by column in table %q", table.Name)
+		}
+	}
+
 	// Defaults
 	if kitName == "" {
 		kitName = "multi"
@@ -45,6 +252,9 @@ func GenerateResource(basePath, moduleName, resourceName string, fields []parser
 
 	// Load kit using KitLoader
 	kitLoader := kits.DefaultLoader()
+	for _, path := range options.KitPaths {
+		kitLoader.AddSearchPath(path)
+	}
 	kit, err := kitLoader.Load(kitName)
 	if err != nil {
 		return fmt.Errorf("failed to load kit %q: %w", kitName, err)
@@ -57,6 +267,11 @@ func GenerateResource(basePath, moduleName, resourceName string, fields []parser
 		}
 	}
 
+	// The layout references the kit's bundled assets, so the app needs them
+	if err := copyKitAssets(basePath, kit); err != nil {
+		return err
+	}
+
 	// Capitalize resource name and derive singular/plural forms
 	resourceNameLower := strings.ToLower(resourceName)
 	titleCaser := cases.Title(language.English)
@@ -67,12 +282,62 @@ func GenerateResource(basePath, moduleName, resourceName string, fields []parser
 	resourceNameSingularCap := titleCaser.String(resourceNameSingular)
 	resourceNamePluralCap := titleCaser.String(pluralize(resourceNameSingular))
 	tableName := pluralize(resourceNameSingular)
+	if options.FromTable != nil && !strings.EqualFold(options.FromTable.Name, tableName) {
+		// sqlc names models after the table, so the resource name must match it
+		return fmt.Errorf("resource name %q maps to table %q, not %q; name the resource after the table", resourceName, tableName, options.FromTable.Name)
+	}
 
+	fields, counters := splitCounterFields(fields, tableName)
+	if len(counters) > 0 && options.FromTable != nil {
+		return fmt.Errorf("counter fields are not supported with an existing table (the counter column requires a migration)")
+	}
+	fields, generated, err := splitGeneratedFields(fields)
+	if err != nil {
+		return err
+	}
+	if len(generated) > 0 {
+		if options.FromTable != nil {
+			return fmt.Errorf("generated fields are not supported with an existing table (the column requires a migration)")
+		}
+		if parentResource != "" {
+			return fmt.Errorf("generated fields are not supported for embedded resources (--parent)")
+		}
+		if !actions.Create {
+			return fmt.Errorf("generated fields require the create action, which generates their value")
+		}
+	}
 	fieldData := FieldDataFromFields(fields)
+	triggers, err := counterTriggers(filepath.Join(basePath, "database", "schema.sql"), tableName, counters, fieldData)
+	if err != nil {
+		return err
+	}
+	if len(options.Indexes) > 0 && options.FromTable != nil {
+		return fmt.Errorf("--index is not supported with an existing table (indexes are created by the migration)")
+	}
+	var indexed []string // columns besides the fields that have an index of their own
+	if withAuthz {
+		indexed = append(indexed, "created_by")
+	}
+	if options.Sortable {
+		indexed = append(indexed, "position")
+	}
+	indexes, err := indexData(tableName, options.Indexes, fieldData, counters, generated, indexed)
+	if err != nil {
+		return err
+	}
+	if len(triggers) > 0 && options.FromTable != nil {
+		for _, c := range triggers {
+			logging.Warn("counter column not kept in sync; add its triggers to a migration manually", "column", c.Table+"."+c.Name, "counts", tableName)
+		}
+		triggers = nil
+	}
 
 	// Read dev mode setting from .lvtrc
 	devMode := ReadDevMode(basePath)
 
+	_, err = os.Stat(filepath.Join(basePath, "app", "auth"))
+	hasAuth := err == nil
+
 	data := ResourceData{
 		PackageName:          resourceNameLower,
 		ModuleName:           moduleName,
@@ -85,12 +350,42 @@ func GenerateResource(basePath, moduleName, resourceName string, fields []parser
 		Kit:                  kit,
 		CSSFramework:         cssFramework, // Keep for backward compatibility
 		DevMode:              devMode,
+		Theme:                ReadTheme(basePath),
 		PaginationMode:       paginationMode,
 		PageSize:             pageSize,
 		EditMode:             editMode,
 		Styles:               styles,
 		Searchable:           searchable,
 		WithAuthz:            withAuthz,
+		Actions:              actions,
+		Counters:             counters,
+		CounterTriggers:      triggers,
+		Indexes:              indexes,
+		Generated:            generated,
+		WithAudit:            parentResource == "" && AuditEnabled(basePath),
+		WithI18n:             parentResource == "" && I18nEnabled(basePath),
+		WithNotifications:    parentResource == "" && NotificationsEnabled(basePath),
+		WithSessions:         parentResource == "" && SessionsEnabled(basePath),
+		WithOffline:          parentResource == "" && OfflineEnabled(basePath),
+		WithSSE:              parentResource == "" && SSEEnabled(basePath),
+		WithCompression:      parentResource == "" && CompressionEnabled(basePath),
+		WithPrerender:        parentResource == "" && PrerenderEnabled(basePath),
+		WithSEO:              parentResource == "" && SEOEnabled(basePath),
+		WithCSRF:             parentResource == "" && CSRFEnabled(basePath),
+		WithSecurityHeaders:  parentResource == "" && SecurityHeadersEnabled(basePath),
+		HasAuth:              hasAuth,
+		EmitEvents:           options.EmitEvents,
+		WithCache:            options.Cache,
+		WithRenderCache:      options.RenderCache,
+		WithFilters:          options.Filters,
+		WithSavedViews:       options.SavedViews,
+		Sortable:             options.Sortable,
+		WithUndo:             options.Undo,
+		WithPresence:         options.Presence,
+		WithConflicts:        options.Conflicts,
+		WithPolicy:           options.Policy,
+		WithHooks:            options.Hooks,
+		IDType:               options.IDType,
 	}
 	if data.Searchable && len(data.SearchableFields()) == 0 {
 		return fmt.Errorf("--searchable requires at least one string field for FTS indexing")
@@ -132,12 +427,112 @@ func GenerateResource(basePath, moduleName, resourceName string, fields []parser
 		return fmt.Errorf("failed to create resource directory: %w", err)
 	}
 
+	if data.IDType != "" {
+		if err := generateIDs(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if data.WithFilters {
+		if err := generateFilter(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if data.WithSavedViews {
+		if err := generateSavedViews(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if data.WithRenderCache {
+		if err := generateRenderCache(basePath, moduleName, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if data.WithPresence {
+		if err := generatePresence(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
+		if err := generatePubSubBridges(basePath, moduleName, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if len(data.Generated) > 0 {
+		if err := generateSlugs(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if len(data.RichTextFields()) > 0 {
+		if err := generateRichText(basePath, moduleName, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	if err := generateFuncs(basePath, kitLoader, kitName); err != nil {
+		return err
+	}
+
 	// Embedded mode uses different templates and skips route/home injection
 	if data.IsEmbedded {
-		return generateEmbeddedResource(basePath, resourceDir, resourceNameLower, tableName, data, kitLoader, kitName, kit)
+		err = generateEmbeddedResource(basePath, resourceDir, resourceNameLower, tableName, data, kitLoader, kitName, kit)
+	} else {
+		if data.EmitEvents {
+			if err := generateEvents(basePath, data, kitLoader, kitName); err != nil {
+				return err
+			}
+		}
+		err = generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableName, moduleName, editMode, appMode, data, kitLoader, kitName, kit, options.FromTable, options.AssembleComponents)
+	}
+	if err != nil {
+		return err
 	}
 
-	return generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableName, moduleName, editMode, appMode, data, kitLoader, kitName, kit)
+	rc := &config.ResourceConfig{
+		Pagination:  paginationMode,
+		PageSize:    pageSize,
+		EditMode:    editMode,
+		Parent:      parentResource,
+		WithAuthz:   withAuthz,
+		Searchable:  searchable,
+		EmitEvents:  options.EmitEvents,
+		Cache:       options.Cache,
+		RenderCache: options.RenderCache,
+		Filters:     options.Filters,
+		SavedViews:  options.SavedViews,
+		Sortable:    options.Sortable,
+		Undo:        options.Undo,
+		Presence:    options.Presence,
+		Conflicts:   options.Conflicts,
+		Policy:      options.Policy,
+		Hooks:       options.Hooks,
+		IDType:      options.IDType,
+	}
+	if !actions.IsFull() {
+		rc.Actions = actions.String()
+	}
+	if options.FromTable != nil {
+		rc.FromTable = options.FromTable.Name
+	}
+	return recordResourceConfig(basePath, resourceNameLower, rc)
+}
+
+// recordResourceConfig stores the options a resource was generated with in
+// .lvtrc. Directories without a .lvtrc are not lvt projects' roots, so none
+// is created there.
+func recordResourceConfig(basePath, resourceName string, rc *config.ResourceConfig) error {
+	if _, err := os.Stat(filepath.Join(basePath, config.ProjectConfigFileName)); err != nil {
+		return nil
+	}
+	projectConfig, err := config.LoadProjectConfig(basePath)
+	if err != nil {
+		return err
+	}
+	projectConfig.SetResource(resourceName, rc)
+	return config.SaveProjectConfig(basePath, projectConfig)
 }
 
 func generateEmbeddedResource(basePath, resourceDir, resourceNameLower, tableName string, data ResourceData, kitLoader *kits.KitLoader, kitName string, kit *kits.KitInfo) error {
@@ -173,6 +568,9 @@ func generateEmbeddedResource(basePath, resourceDir, resourceNameLower, tableNam
 	if err := generateFile(string(handlerTmpl), data, filepath.Join(resourceDir, resourceNameLower+".go"), kit); err != nil {
 		return fmt.Errorf("failed to generate embedded handler: %w", err)
 	}
+	if err := generateJSONHelpers(resourceDir, resourceNameLower, data, kitLoader, kitName, kit); err != nil {
+		return err
+	}
 
 	// Generate embedded template
 	tmplPath := filepath.Join(resourceDir, resourceNameLower+".tmpl")
@@ -203,14 +601,17 @@ func generateEmbeddedResource(basePath, resourceDir, resourceNameLower, tableNam
 	if err := generateFile(string(migrationTmpl), data, migrationPath, kit); err != nil {
 		return fmt.Errorf("failed to generate migration: %w", err)
 	}
+	if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+		return err
+	}
 
 	// Append to schema.sql
-	if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "\n", kit); err != nil {
+	if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "resource "+tableName, kit); err != nil {
 		return fmt.Errorf("failed to append to schema: %w", err)
 	}
 
 	// Append to queries.sql (embedded queries include filtered-by-parent)
-	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "\n", kit); err != nil {
+	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "resource "+tableName, kit); err != nil {
 		return fmt.Errorf("failed to append to queries: %w", err)
 	}
 
@@ -228,7 +629,21 @@ func generateEmbeddedResource(basePath, resourceDir, resourceNameLower, tableNam
 	return nil
 }
 
-func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableName, moduleName, editMode, appMode string, data ResourceData, kitLoader *kits.KitLoader, kitName string, kit *kits.KitInfo) error {
+// ResourceComponents are the kit components a resource page is assembled
+// from, in order.
+var ResourceComponents = []string{
+	"layout.tmpl",
+	"form.tmpl",
+	"toolbar.tmpl",
+	"table.tmpl",
+	"pagination.tmpl",
+	"search.tmpl",
+	"stats.tmpl",
+	"sort.tmpl",
+	"detail.tmpl",
+}
+
+func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableName, moduleName, editMode, appMode string, data ResourceData, kitLoader *kits.KitLoader, kitName string, kit *kits.KitInfo, fromTable *TableInfo, assembleComponents bool) error {
 	// Read templates using kit loader (checks project kits, user kits, then embedded)
 	handlerTmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/handler.go.tmpl")
 	if err != nil {
@@ -236,22 +651,12 @@ func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableN
 	}
 
 	// Load main template based on mode
+	// Kits extending multi get its component assembly, so they can override
+	// single components
 	var templateTmpl []byte
-	if appMode == "multi" {
-		componentNames := []string{
-			"layout.tmpl",
-			"form.tmpl",
-			"toolbar.tmpl",
-			"table.tmpl",
-			"pagination.tmpl",
-			"search.tmpl",
-			"stats.tmpl",
-			"sort.tmpl",
-			"detail.tmpl",
-		}
-
+	if appMode == "multi" || assembleComponents || (kit != nil && kit.DerivesFrom("multi")) {
 		var fullTemplate string
-		for _, compName := range componentNames {
+		for _, compName := range ResourceComponents {
 			compTmpl, err := kitLoader.LoadKitComponent(kitName, compName)
 			if err != nil {
 				return fmt.Errorf("failed to load component %s: %w", compName, err)
@@ -272,6 +677,32 @@ func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableN
 			return fmt.Errorf("failed to load template: %w", err)
 		}
 	}
+	components := featureComponents(data.WithNotifications, data.WithSessions, data.WithOffline, data.WithSSE)
+	if data.WithPrerender {
+		// Static renders page with links (shared/prerender)
+		components = append(components, "prerender.tmpl")
+	}
+	if data.WithSEO {
+		// The head renders the page's Meta (shared/seo)
+		components = append(components, "seo.tmpl")
+	}
+	if data.WithCSRF {
+		// Forms carry the visitor's token (shared/csrf)
+		components = append(components, "csrf.tmpl")
+	}
+	if data.WithSecurityHeaders {
+		// Delete confirmations and search clearing without inline handlers (shared/security)
+		components = append(components, "security.tmpl")
+	}
+	if len(data.RichTextFields()) > 0 {
+		// Forms edit rich text with the editor (app/richtext)
+		components = append(components, "richtext.tmpl")
+	}
+	page, err := withComponents(kitLoader, kitName, string(templateTmpl), components...)
+	if err != nil {
+		return err
+	}
+	templateTmpl = []byte(page)
 
 	queriesTmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/queries.sql.tmpl")
 	if err != nil {
@@ -297,6 +728,15 @@ func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableN
 	if err := generateFile(string(handlerTmpl), data, filepath.Join(resourceDir, resourceNameLower+".go"), kit); err != nil {
 		return fmt.Errorf("failed to generate handler: %w", err)
 	}
+	if err := generateJSONHelpers(resourceDir, resourceNameLower, data, kitLoader, kitName, kit); err != nil {
+		return err
+	}
+	if err := generatePolicy(resourceDir, data, kitLoader, kitName, kit); err != nil {
+		return err
+	}
+	if err := generateHooks(resourceDir, resourceNameLower, data, kitLoader, kitName, kit); err != nil {
+		return err
+	}
 
 	// Generate template and validate it parses correctly
 	tmplPath := filepath.Join(resourceDir, resourceNameLower+".tmpl")
@@ -307,35 +747,45 @@ func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableN
 		return err
 	}
 
-	// Generate migration file
 	dbDir := filepath.Join(basePath, "database")
-	migrationsDir := filepath.Join(dbDir, "migrations")
-	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create migrations directory: %w", err)
-	}
+	if fromTable != nil {
+		// Existing table: no migration, but sqlc still needs the table in schema.sql
+		if err := appendExistingTableSchema(filepath.Join(dbDir, "schema.sql"), fromTable); err != nil {
+			return err
+		}
+	} else {
+		// Generate migration file
+		migrationsDir := filepath.Join(dbDir, "migrations")
+		if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+			return fmt.Errorf("failed to create migrations directory: %w", err)
+		}
 
-	timestamp := time.Now()
-	migrationFilename := ""
-	migrationPath := ""
-	for {
-		timestampStr := timestamp.Format("20060102150405")
-		migrationFilename = fmt.Sprintf("%s_create_%s.sql", timestampStr, tableName)
-		migrationPath = filepath.Join(migrationsDir, migrationFilename)
-		matches, _ := filepath.Glob(filepath.Join(migrationsDir, timestampStr+"_*.sql"))
-		if len(matches) == 0 {
-			break
+		timestamp := time.Now()
+		migrationFilename := ""
+		migrationPath := ""
+		for {
+			timestampStr := timestamp.Format("20060102150405")
+			migrationFilename = fmt.Sprintf("%s_create_%s.sql", timestampStr, tableName)
+			migrationPath = filepath.Join(migrationsDir, migrationFilename)
+			matches, _ := filepath.Glob(filepath.Join(migrationsDir, timestampStr+"_*.sql"))
+			if len(matches) == 0 {
+				break
+			}
+			timestamp = timestamp.Add(1 * time.Second)
+		}
+		if err := generateFile(string(migrationTmpl), data, migrationPath, kit); err != nil {
+			return fmt.Errorf("failed to generate migration: %w", err)
+		}
+		if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+			return err
 		}
-		timestamp = timestamp.Add(1 * time.Second)
-	}
-	if err := generateFile(string(migrationTmpl), data, migrationPath, kit); err != nil {
-		return fmt.Errorf("failed to generate migration: %w", err)
-	}
 
-	if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "\n", kit); err != nil {
-		return fmt.Errorf("failed to append to schema: %w", err)
+		if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "resource "+tableName, kit); err != nil {
+			return fmt.Errorf("failed to append to schema: %w", err)
+		}
 	}
 
-	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "\n", kit); err != nil {
+	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "resource "+tableName, kit); err != nil {
 		return fmt.Errorf("failed to append to queries: %w", err)
 	}
 
@@ -371,22 +821,48 @@ func generateStandaloneResource(basePath, resourceDir, resourceNameLower, tableN
 
 		for _, route := range routes {
 			if err := InjectRoute(mainGoPath, route); err != nil {
-				fmt.Printf("⚠️  Could not auto-inject route %s: %v\n", route.Path, err)
-				fmt.Printf("   Please add manually: http.Handle(\"%s\", %s)\n",
-					route.Path, handlerCall)
+				logging.Warn("could not add the route to main.go; add it manually", "route", fmt.Sprintf("http.Handle(%q, %s)", route.Path, handlerCall), "error", err)
 			}
 		}
 	}
 
 	// Register resource for home page
-	if err := RegisterResource(basePath, data.ResourceName, "/"+resourceNameLower, "resource"); err != nil {
-		fmt.Printf("⚠️  Could not register resource in home page: %v\n", err)
+	if err := RegisterResourceFields(basePath, data.ResourceName, "/"+resourceNameLower, "resource", tableName, data.Fields, data.Indexes...); err != nil {
+		logging.Warn("could not register the resource in the home page", "error", err)
 	}
 
 	return nil
 }
 
 func generateFile(tmplStr string, data interface{}, outPath string, kit *kits.KitInfo) error {
+	funcs, err := KitTemplateFuncs(kit)
+	if err != nil {
+		return err
+	}
+	useI18n(funcs, data)
+
+	// Use custom delimiters to avoid conflicts with Go template syntax in the generated files
+	tmpl, err := template.New("template").Delims("[[", "]]").Funcs(funcs).Parse(tmplStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	logging.Debug("wrote file", "path", outPath, "bytes", buf.Len())
+
+	return nil
+}
+
+// KitTemplateFuncs returns the functions kit templates call in [[ ]]
+// actions: the base helpers, and kit's CSS, asset and icon helpers.
+func KitTemplateFuncs(kit *kits.KitInfo) (template.FuncMap, error) {
 	// Merge base funcMap with kit helpers
 	funcs := make(template.FuncMap)
 	for k, v := range funcMap {
@@ -438,6 +914,16 @@ func generateFile(tmplStr string, data interface{}, outPath string, kit *kits.Ki
 		funcs["textMutedClass"] = func(args ...interface{}) string { return kit.Helpers.TextMutedClass() }
 		funcs["textPrimaryClass"] = func(args ...interface{}) string { return kit.Helpers.TextPrimaryClass() }
 		funcs["textDangerClass"] = func(args ...interface{}) string { return kit.Helpers.TextDangerClass() }
+		funcs["notificationClass"] = func(framework string, args ...interface{}) string {
+			// Template calls: notificationClass .CSSFramework "variant"
+			variant := "info"
+			if len(args) > 0 {
+				if v, ok := args[0].(string); ok {
+					variant = v
+				}
+			}
+			return kit.Helpers.NotificationClass(variant)
+		}
 		funcs["paginationClass"] = func(args ...interface{}) string { return kit.Helpers.PaginationClass() }
 		funcs["paginationButtonClass"] = func(args ...interface{}) string { return kit.Helpers.PaginationButtonClass("") }
 		funcs["paginationActiveClass"] = func(args ...interface{}) string { return kit.Helpers.PaginationButtonClass("active") }
@@ -467,26 +953,39 @@ func generateFile(tmplStr string, data interface{}, outPath string, kit *kits.Ki
 			funcs[k] = v
 		}
 	}
+	if err := useKitAssets(funcs, kit); err != nil {
+		return nil, err
+	}
+	useKitIcons(funcs, kit)
+	return funcs, nil
+}
 
-	// Use custom delimiters to avoid conflicts with Go template syntax in the generated files
-	tmpl, err := template.New("template").Delims("[[", "]]").Funcs(funcs).Parse(tmplStr)
+// useKitAssets makes csscdn load the kit's bundled assets instead of the
+// framework's CDN when the kit ships an assets directory.
+func useKitAssets(funcs template.FuncMap, kit *kits.KitInfo) error {
+	if kit == nil {
+		return nil
+	}
+	tags, err := kit.AssetTags()
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	if tags != "" {
+		funcs["csscdn"] = func(args ...interface{}) string { return tags }
 	}
+	return nil
+}
 
-	if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// useKitIcons makes icon render the kit's icons.
+func useKitIcons(funcs template.FuncMap, kit *kits.KitInfo) {
+	if kit != nil {
+		funcs["icon"] = kit.Icon
 	}
-
-	return nil
 }
 
-func appendToFile(tmplStr string, data interface{}, outPath, separator string, kit *kits.KitInfo) error {
+// appendToFile renders tmplStr into section of the shared file at outPath,
+// replacing the section if an earlier run wrote it (see writeSection).
+func appendToFile(tmplStr string, data interface{}, outPath, section string, kit *kits.KitInfo) error {
 	// Merge base funcMap with kit helpers
 	funcs := make(template.FuncMap)
 	for k, v := range funcMap {
@@ -535,6 +1034,16 @@ func appendToFile(tmplStr string, data interface{}, outPath, separator string, k
 		funcs["textMutedClass"] = func(args ...interface{}) string { return kit.Helpers.TextMutedClass() }
 		funcs["textPrimaryClass"] = func(args ...interface{}) string { return kit.Helpers.TextPrimaryClass() }
 		funcs["textDangerClass"] = func(args ...interface{}) string { return kit.Helpers.TextDangerClass() }
+		funcs["notificationClass"] = func(framework string, args ...interface{}) string {
+			// Template calls: notificationClass .CSSFramework "variant"
+			variant := "info"
+			if len(args) > 0 {
+				if v, ok := args[0].(string); ok {
+					variant = v
+				}
+			}
+			return kit.Helpers.NotificationClass(variant)
+		}
 		funcs["paginationClass"] = func(args ...interface{}) string { return kit.Helpers.PaginationClass() }
 		funcs["paginationButtonClass"] = func(args ...interface{}) string { return kit.Helpers.PaginationButtonClass("") }
 		funcs["paginationActiveClass"] = func(args ...interface{}) string { return kit.Helpers.PaginationButtonClass("active") }
@@ -561,6 +1070,11 @@ func appendToFile(tmplStr string, data interface{}, outPath, separator string, k
 			funcs[k] = v
 		}
 	}
+	if err := useKitAssets(funcs, kit); err != nil {
+		return err
+	}
+	useKitIcons(funcs, kit)
+	useI18n(funcs, data)
 
 	// Use custom delimiters to avoid conflicts with Go template syntax in the generated files
 	tmpl, err := template.New("template").Delims("[[", "]]").Funcs(funcs).Parse(tmplStr)
@@ -573,22 +1087,7 @@ func appendToFile(tmplStr string, data interface{}, outPath, separator string, k
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	// Open file for appending (create if doesn't exist)
-	f, err := os.OpenFile(outPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
-	}
-	defer f.Close()
-
-	// Write separator and content
-	if _, err := f.WriteString(separator); err != nil {
-		return fmt.Errorf("failed to write separator: %w", err)
-	}
-	if _, err := f.Write(buf.Bytes()); err != nil {
-		return fmt.Errorf("failed to write content: %w", err)
-	}
-
-	return nil
+	return writeSection(outPath, section, buf.Bytes())
 }
 
 // findMainGo finds the main.go file in cmd/* directory
@@ -612,86 +1111,60 @@ func findMainGo(basePath string) string {
 	return ""
 }
 
-// Singularize is the exported version of singularize for use by other packages.
-func Singularize(word string) string {
-	return singularize(word)
-}
-
-// singularize handles basic English singularization
-func singularize(word string) string {
-	// Common irregular plurals (reverse map)
-	irregulars := map[string]string{
-		"people":   "person",
-		"children": "child",
-		"teeth":    "tooth",
-		"feet":     "foot",
-		"men":      "man",
-		"women":    "woman",
-		"mice":     "mouse",
-	}
-	if singular, ok := irregulars[word]; ok {
-		return singular
+// generatePolicy writes policy.go with the functions the handler asks
+// before each action, for --policy. An existing policy.go holds the user's
+// rules and is kept when the resource is generated again.
+func generatePolicy(resourceDir string, data ResourceData, kitLoader *kits.KitLoader, kitName string, kit *kits.KitInfo) error {
+	if !data.WithPolicy {
+		return nil
 	}
-
-	// Words ending in ies -> y (e.g., categories -> category)
-	if strings.HasSuffix(word, "ies") && len(word) > 3 {
-		return word[:len(word)-3] + "y"
+	path := filepath.Join(resourceDir, "policy.go")
+	if _, err := os.Stat(path); err == nil {
+		return nil
 	}
-
-	// Words ending in ses, xes, zes -> remove es (e.g., boxes -> box)
-	if strings.HasSuffix(word, "ses") || strings.HasSuffix(word, "xes") || strings.HasSuffix(word, "zes") {
-		return word[:len(word)-2]
+	tmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/policy.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read policy template: %w", err)
 	}
-
-	// Words ending in ches, shes -> remove es (e.g., watches -> watch)
-	if strings.HasSuffix(word, "ches") || strings.HasSuffix(word, "shes") {
-		return word[:len(word)-2]
+	if err := generateFile(string(tmpl), data, path, kit); err != nil {
+		return fmt.Errorf("failed to generate policy.go: %w", err)
 	}
-
-	// Words ending in s -> remove s (e.g., users -> user)
-	if strings.HasSuffix(word, "s") && len(word) > 1 {
-		return word[:len(word)-1]
-	}
-
-	// Already singular
-	return word
+	return nil
 }
 
-// pluralize handles basic English pluralization rules
-func pluralize(word string) string {
-	// If already ends in 's', return as-is
-	if strings.HasSuffix(word, "s") {
-		return word
+// generateHooks writes <resource>_hooks.go with the lifecycle functions the
+// handler calls around each write, for --hooks. Like policy.go, an existing
+// file holds the user's code and is kept when the resource is generated again.
+func generateHooks(resourceDir, resourceNameLower string, data ResourceData, kitLoader *kits.KitLoader, kitName string, kit *kits.KitInfo) error {
+	if !data.WithHooks {
+		return nil
 	}
-
-	// Common irregular plurals
-	irregulars := map[string]string{
-		"person": "people",
-		"child":  "children",
-		"tooth":  "teeth",
-		"foot":   "feet",
-		"man":    "men",
-		"woman":  "women",
-		"mouse":  "mice",
+	path := filepath.Join(resourceDir, resourceNameLower+"_hooks.go")
+	if _, err := os.Stat(path); err == nil {
+		return nil
 	}
-	if plural, ok := irregulars[word]; ok {
-		return plural
+	tmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/hooks.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read hooks template: %w", err)
 	}
-
-	// Words ending in consonant + y -> ies
-	if len(word) >= 2 && word[len(word)-1] == 'y' {
-		preceding := word[len(word)-2]
-		if preceding != 'a' && preceding != 'e' && preceding != 'i' && preceding != 'o' && preceding != 'u' {
-			return word[:len(word)-1] + "ies"
-		}
+	if err := generateFile(string(tmpl), data, path, kit); err != nil {
+		return fmt.Errorf("failed to generate %s_hooks.go: %w", resourceNameLower, err)
 	}
+	return nil
+}
 
-	// Words ending in s, x, z, ch, sh -> es
-	if strings.HasSuffix(word, "s") || strings.HasSuffix(word, "x") || strings.HasSuffix(word, "z") ||
-		strings.HasSuffix(word, "ch") || strings.HasSuffix(word, "sh") {
-		return word + "es"
+// generateJSONHelpers writes <resource>_json.go with a decoded type and
+// Decode/Encode helpers for each JSON field, if the resource has any.
+func generateJSONHelpers(resourceDir, resourceNameLower string, data ResourceData, kitLoader *kits.KitLoader, kitName string, kit *kits.KitInfo) error {
+	if len(data.JSONFields()) == 0 {
+		return nil
 	}
-
-	// Default: just add s
-	return word + "s"
+	tmpl, err := kitLoader.LoadKitTemplate(kitName, "resource/json.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read JSON helpers template: %w", err)
+	}
+	if err := generateFile(string(tmpl), data, filepath.Join(resourceDir, resourceNameLower+"_json.go"), kit); err != nil {
+		return fmt.Errorf("failed to generate JSON helpers: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"database/sql"
+	"fmt"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestGenerateResource_RecordsOptions(t *testing.T) {
+	dir := t.TempDir()
+	if err := config.SaveProjectConfig(dir, &config.ProjectConfig{Module: "testmodule", Kit: "multi", Styles: "tailwind"}); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := parser.ParseFields([]string{"name:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := ReadOnlyActions()
+	if err := GenerateResource(dir, "testmodule", "Tags", fields, "multi", "tailwind", "tailwind", "numbers", 5, "page", "", false, true,
+		ResourceOptions{Actions: &actions, IDType: IDTypeULID}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.ResourceConfig{Pagination: "numbers", PageSize: 5, EditMode: "page", Searchable: true, IDType: IDTypeULID, Actions: "list,show"}
+	if got := cfg.Resource("tags"); got == nil || *got != want {
+		t.Errorf("recorded options = %+v, want %+v", got, want)
+	}
+	if cfg.Module != "testmodule" {
+		t.Errorf("recording options lost the rest of .lvtrc: module = %q", cfg.Module)
+	}
+
+	// Without a .lvtrc nothing is recorded, and none is created
+	bare := t.TempDir()
+	if err := generateCounterTestResource(t, bare, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(bare, config.ProjectConfigFileName)); !os.IsNotExist(err) {
+		t.Errorf("GenerateResource created %s in a directory without one", config.ProjectConfigFileName)
+	}
+}
+
+func TestGenerateResource_Inflections(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.ProjectConfig{Module: "testmodule", Kit: "multi", Styles: "tailwind",
+		Inflections: map[string]string{"cactus": "cacti"}}
+	if err := config.SaveProjectConfig(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"cacti", "name:string"}, {"statuses", "label:string"}, {"people", "name:string"}} {
+		if err := generateCounterTestResource(t, dir, args[0], args[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schema, err := os.ReadFile(filepath.Join(dir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"cacti", "statuses", "people"} {
+		if !strings.Contains(string(schema), "CREATE TABLE IF NOT EXISTS "+table+" (") {
+			t.Errorf("schema.sql has no %s table:\n%s", table, schema)
+		}
+	}
+	handler, err := os.ReadFile(filepath.Join(dir, "app", "cacti", "cacti.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(handler), "type CactiState struct") || !strings.Contains(string(handler), "Cactus") {
+		t.Errorf("cacti handler does not use the .lvtrc inflection:\n%s", handler)
+	}
+}
+
+func TestGenerateResource_CursorPagination(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	fields, err := parser.ParseFields([]string{"name:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource(dir, "testmodule", "Tags", fields, "multi", "tailwind", "tailwind", "cursor", 10, "modal", "", false, false, ResourceOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	handlerPath := filepath.Join(dir, "app", "tags", "tags.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{"ListTagsFirst(", "ListTagsFrom(", "ListTagsBefore(", "encodeCursor(", `ctx.GetString("cursor")`} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	queries, err := os.ReadFile(filepath.Join(dir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"-- name: ListTagsFirst :many", "-- name: ListTagsFrom :many", "WHERE id <= ?", "-- name: ListTagsBefore :many", "WHERE id > ?"} {
+		if !strings.Contains(string(queries), want) {
+			t.Errorf("queries.sql missing %q", want)
+		}
+	}
+
+	// Cursor pages don't count the table, so there is no total to show
+	tmpl, err := os.ReadFile(filepath.Join(dir, "app", "tags", "tags.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(tmpl), "Total: <strong>{{.TotalCount}}") {
+		t.Error("cursor template shows TotalCount, which cursor mode leaves at 0")
+	}
+}
+
+// Cursor pages are ordered by ID, so IDs must grow with creation time.
+func TestGenerateResource_CursorPaginationIDType(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	fields, err := parser.ParseFields([]string{"name:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource(dir, "testmodule", "Tags", fields, "multi", "tailwind", "tailwind", "cursor", 10, "modal", "", false, false, ResourceOptions{IDType: IDTypeUUID}); err == nil {
+		t.Error("expected error for cursor pagination with --id uuid")
+	}
+	if err := GenerateResource(dir, "testmodule", "Tags", fields, "multi", "tailwind", "tailwind", "cursor", 10, "modal", "", false, false, ResourceOptions{IDType: IDTypeULID}); err != nil {
+		t.Errorf("cursor pagination with --id ulid: %v", err)
+	}
+}
+
+// TestCursorPagesNewestFirst runs the generated keyset queries on SQLite
+// the way loadTagsPage and prevTagsCursor do, across two pages.
+func TestCursorPagesNewestFirst(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	fields, err := parser.ParseFields([]string{"name:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource(dir, "testmodule", "Tags", fields, "multi", "tailwind", "tailwind", "cursor", 10, "modal", "", false, false, ResourceOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := os.ReadFile(filepath.Join(dir, "database", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queriesSQL, err := os.ReadFile(filepath.Join(dir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := make(map[string]string)
+	for _, block := range strings.Split(string(queriesSQL), "-- name: ")[1:] {
+		name, query, _ := strings.Cut(block, "\n")
+		queries[strings.Fields(name)[0]] = query
+	}
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	// IDs grow with creation time, as the handler's tag-<UnixNano> do
+	for i := 1; i <= 5; i++ {
+		if _, err := db.Exec("INSERT INTO tags (id, name, created_at) VALUES (?, ?, ?)", fmt.Sprintf("tag-%02d", i), "tag", time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	const pageSize = 2
+	page := func(query string, args ...any) []string {
+		t.Helper()
+		rows, err := db.Query(queries[query], args...)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		defer rows.Close()
+		var ids []string
+		for rows.Next() {
+			var id, name string
+			var created any
+			if err := rows.Scan(&id, &name, &created); err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	first := page("ListTagsFirst", pageSize+1)
+	if strings.Join(first[:pageSize], ",") != "tag-05,tag-04" {
+		t.Fatalf("first page = %v, want the newest first", first[:pageSize])
+	}
+	next := first[pageSize]
+	second := page("ListTagsFrom", next, pageSize+1)
+	if strings.Join(second[:pageSize], ",") != "tag-03,tag-02" {
+		t.Errorf("second page = %v, want it to go on where the first stopped", second[:pageSize])
+	}
+	if newer := page("ListTagsBefore", next, pageSize+1); len(newer) > pageSize {
+		t.Errorf("rows newer than the second page = %v, want the first page's %d, so Previous returns to it", newer, pageSize)
+	}
+	third := second[pageSize]
+	if newer := page("ListTagsBefore", third, pageSize+1); len(newer) <= pageSize || newer[pageSize-1] != next {
+		t.Errorf("rows newer than the third page = %v, want Previous to start at %s", newer, next)
+	}
+}
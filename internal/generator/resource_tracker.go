@@ -8,31 +8,93 @@ import (
 
 // ResourceEntry represents a resource or view in the application
 type ResourceEntry struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
-	Type string `json:"type"` // "resource" or "view"
+	Name    string          `json:"name"`
+	Path    string          `json:"path"`
+	Type    string          `json:"type"` // "resource", "api", "schema", "view", "wizard" or "auth"
+	Table   string          `json:"table,omitempty"`
+	Fields  []ResourceField `json:"fields,omitempty"`
+	Indexes []ResourceIndex `json:"indexes,omitempty"`
+}
+
+// ResourceField records the metadata of a generated field so that later
+// commands (such as lvt resource openapi) don't need to re-parse the schema.
+type ResourceField struct {
+	Name       string   `json:"name"`
+	GoType     string   `json:"go_type"`
+	SQLType    string   `json:"sql_type"`
+	Validate   string   `json:"validate,omitempty"`
+	InputType  string   `json:"input_type,omitempty"`
+	Textarea   bool     `json:"textarea,omitempty"`
+	Options    []string `json:"options,omitempty"`
+	References string   `json:"references,omitempty"`
+	JSON       bool     `json:"json,omitempty"`
+	RichText   bool     `json:"richtext,omitempty"`
+	File       bool     `json:"file,omitempty"`
+	Default    string   `json:"default,omitempty"`
+}
+
+// ResourceIndex records an index declared with --index.
+type ResourceIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+	Where   string   `json:"where,omitempty"`
 }
 
 // RegisterResource adds a resource to the tracking file
 func RegisterResource(basePath, name, path, resourceType string) error {
+	return registerEntry(basePath, ResourceEntry{Name: name, Path: path, Type: resourceType})
+}
+
+// RegisterResourceFields adds a resource to the tracking file along with its
+// table, field and index metadata. Re-registering an existing resource
+// refreshes the recorded fields and indexes.
+func RegisterResourceFields(basePath, name, path, resourceType, table string, fields []FieldData, indexes ...IndexData) error {
+	entry := ResourceEntry{Name: name, Path: path, Type: resourceType, Table: table}
+	for _, f := range fields {
+		entry.Fields = append(entry.Fields, ResourceField{
+			Name:       f.Name,
+			GoType:     f.GoType,
+			SQLType:    f.SQLType,
+			Validate:   f.ValidateTag,
+			InputType:  f.HTMLInputType,
+			Textarea:   f.IsTextarea,
+			Options:    f.SelectOptions,
+			References: f.ReferencedTable,
+			JSON:       f.IsJSON,
+			RichText:   f.IsRichText,
+			File:       f.IsFile,
+			Default:    f.Default,
+		})
+	}
+	for _, ix := range indexes {
+		entry.Indexes = append(entry.Indexes, ResourceIndex{Name: ix.Name, Columns: ix.Columns, Unique: ix.Unique, Where: ix.Where})
+	}
+	return registerEntry(basePath, entry)
+}
+
+func registerEntry(basePath string, entry ResourceEntry) error {
 	resources, err := ReadResources(basePath)
 	if err != nil && !os.IsNotExist(err) {
 		return err
 	}
 
-	// Check if resource already exists
-	for _, r := range resources {
-		if r.Path == path {
-			return nil // Already registered
+	// Check if resource already exists. Schema-only entries have no path,
+	// so they are told apart by name.
+	for i, r := range resources {
+		if r.Path == entry.Path && (entry.Path != "" || r.Name == entry.Name) {
+			if entry.Table == "" {
+				return nil // Already registered
+			}
+			resources[i].Table = entry.Table
+			resources[i].Fields = entry.Fields
+			resources[i].Indexes = entry.Indexes
+			return WriteResources(basePath, resources)
 		}
 	}
 
 	// Add new resource
-	resources = append(resources, ResourceEntry{
-		Name: name,
-		Path: path,
-		Type: resourceType,
-	})
+	resources = append(resources, entry)
 
 	return WriteResources(basePath, resources)
 }
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// RichTextData is the template data for the app/richtext package.
+type RichTextData struct {
+	ModuleName string
+}
+
+// richTextPackagePath is the generated package sanitizing the HTML of
+// richtext fields and registering the richText template functions.
+const richTextPackagePath = "app/richtext/richtext.go"
+
+// RichTextEnabled reports whether a richtext field has been generated in
+// projectRoot, so templates may call richText and richTextPlain.
+func RichTextEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, richTextPackagePath))
+	return err == nil
+}
+
+// generateRichText writes the app/richtext package unless it already
+// exists, and adds golang.org/x/net, whose HTML tokenizer it uses.
+func generateRichText(projectRoot, moduleName string, kitLoader *kits.KitLoader, kitName string) error {
+	if RichTextEnabled(projectRoot) {
+		return nil
+	}
+	if err := generateFuncs(projectRoot, kitLoader, kitName); err != nil {
+		return err
+	}
+	dir := filepath.Join(projectRoot, filepath.Dir(richTextPackagePath))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create app/richtext directory: %w", err)
+	}
+	data := RichTextData{ModuleName: moduleName}
+	for _, f := range []string{"richtext.go", "richtext_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "richtext/"+f+".tmpl", filepath.Join(dir, f), data); err != nil {
+			return fmt.Errorf("failed to generate app/richtext/%s: %w", f, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, "go.mod")); err == nil {
+		if output, err := runTool(projectRoot, "go", "get", "golang.org/x/net@latest"); err != nil {
+			logging.Warn("could not fetch golang.org/x/net; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
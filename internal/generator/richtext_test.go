@@ -0,0 +1,152 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestGenerateRichText(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := GenerateApp("blog", "blog", kit, "tailwind", "", false); err != nil {
+				t.Fatalf("GenerateApp failed: %v", err)
+			}
+			fields, err := fieldparser.ParseFields([]string{"title:string", "body:richtext"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := GenerateResource("blog", "blog", "posts", fields, kit, "tailwind", "tailwind", "infinite", 20, "page", "", false, false); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			if !RichTextEnabled("blog") {
+				t.Fatal("RichTextEnabled should report true after generating a richtext field")
+			}
+			for _, f := range []string{"richtext.go", "richtext_test.go"} {
+				path := filepath.Join("blog", "app", "richtext", f)
+				if _, err := goparser.ParseFile(token.NewFileSet(), path, nil, goparser.AllErrors); err != nil {
+					t.Errorf("%s does not parse: %v", f, err)
+				}
+			}
+
+			handlerPath := filepath.Join("blog", "app", "posts", "posts.go")
+			handler, err := os.ReadFile(handlerPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`"blog/app/richtext"`, "input.Body = richtext.Sanitize(input.Body)"} {
+				if !strings.Contains(string(handler), want) {
+					t.Errorf("handler is missing %q", want)
+				}
+			}
+			if got := strings.Count(string(handler), "richtext.Sanitize("); got != 2 {
+				t.Errorf("handler sanitizes %d times, want in Add and Update", got)
+			}
+			if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+				t.Errorf("handler does not parse: %v", err)
+			}
+
+			pagePath := filepath.Join("blog", "app", "posts", "posts.tmpl")
+			page, err := os.ReadFile(pagePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{
+				`{{define "richtextScript"}}`,
+				`{{template "richtextScript" .}}`,
+				`<div data-richtext>`,
+				`<textarea name="body" hidden>`,
+				`{{richText .EditingPosts.Body}}`,
+			} {
+				if !strings.Contains(string(page), want) {
+					t.Errorf("resource template is missing %q", want)
+				}
+			}
+			// The multi kit's detail view renders the sanitized HTML
+			if detail := `{{richText $.EditingPosts.Body}}`; kit == "multi" && !strings.Contains(string(page), detail) {
+				t.Errorf("detail view is missing %q", detail)
+			}
+			if strings.Contains(string(page), `<textarea name="title" hidden>`) {
+				t.Error("string field rendered with the rich-text editor")
+			}
+			if _, err := template.New("page").Funcs(TemplateFuncs("blog")).Parse(string(page)); err != nil {
+				t.Errorf("resource template does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateRichText_ListsPlainText(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := GenerateApp("blog", "blog", "multi", "tailwind", "", false); err != nil {
+		t.Fatalf("GenerateApp failed: %v", err)
+	}
+	fields, err := fieldparser.ParseFields([]string{"body:richtext", "views:int"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource("blog", "blog", "notes", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join("blog", "app", "notes", "notes.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), "{{richTextPlain .Body}}") {
+		t.Error("list does not render the rich-text display field as text")
+	}
+}
+
+func TestGenerateRichText_API(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	fields, err := fieldparser.ParseFields([]string{"title:string", "body:richtext"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateAPI(dir, "testmodule", "posts", fields, "multi"); err != nil {
+		t.Fatalf("GenerateAPI failed: %v", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "app", "api", "*.go"))
+	if err != nil || len(matches) == 0 {
+		t.Fatalf("no API handler generated: %v", err)
+	}
+	var found bool
+	for _, path := range matches {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(content), "Body: richtext.Sanitize(req.Body)") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("API handler does not sanitize the richtext field")
+	}
+	if !RichTextEnabled(dir) {
+		t.Error("GenerateAPI did not generate app/richtext")
+	}
+}
+
+func TestGenerateWizard_RichTextRejected(t *testing.T) {
+	fields, err := fieldparser.ParseFields([]string{"body:richtext"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := fieldparser.ParseFields([]string{"name:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := []WizardStep{{Name: "account", Fields: other}, {Name: "details", Fields: fields}}
+	if err := GenerateWizard(t.TempDir(), "testmodule", "signup", steps, "multi", "tailwind"); err == nil || !strings.Contains(err.Error(), "richtext") {
+		t.Errorf("GenerateWizard() with a richtext field = %v, want it rejected", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/livetemplate/lvt/internal/logging"
 )
 
 // RouteInfo contains information about a route to be injected
@@ -51,9 +53,11 @@ func InjectRoute(mainGoPath string, route RouteInfo) error {
 		}
 		if trimmedLine == routePattern || strings.Contains(line, routePattern) {
 			// Route already exists, don't add again
+			logging.Debug("route already registered, skipping", "file", mainGoPath, "route", routePattern)
 			return nil
 		}
 	}
+	logging.Debug("injecting route", "file", mainGoPath, "route", routePattern, "mux", handlePrefix)
 
 	// Add import if not present
 	importLine := fmt.Sprintf(`	"%s"`, route.ImportPath)
@@ -83,10 +87,14 @@ func InjectRoute(mainGoPath string, route RouteInfo) error {
 		}
 	}
 
-	if !importExists && importInsertIndex != -1 {
+	if importExists {
+		logging.Debug("route import already present", "import", route.ImportPath)
+	} else if importInsertIndex != -1 {
 		// Insert import
+		logging.Debug("adding route import after database import", "import", route.ImportPath, "line", importInsertIndex+1)
 		lines = insertLine(lines, importInsertIndex, importLine)
-	} else if !importExists {
+	} else {
+		logging.Debug("no database import found, adding route import at end of import block", "import", route.ImportPath)
 		// Fallback: if no /database import found, add at end of import block
 		for i, line := range lines {
 			if strings.TrimSpace(line) == ")" && i > 0 {
@@ -114,6 +122,7 @@ func InjectRoute(mainGoPath string, route RouteInfo) error {
 	for i, line := range lines {
 		if needsQueries && strings.Contains(line, "_, err := database.InitDB(dbPath)") {
 			// Replace _ with queries to enable it
+			logging.Debug("enabling queries variable for database-backed handler", "file", mainGoPath, "line", i+1)
 			lines[i] = strings.Replace(line, "_, err := database.InitDB(dbPath)", "queries, err := database.InitDB(dbPath)", 1)
 			break
 		}
@@ -136,12 +145,15 @@ func InjectRoute(mainGoPath string, route RouteInfo) error {
 	}
 
 	if routeInsertIndex == -1 {
+		logging.Debug("route marker not found", "file", mainGoPath, "marker", "TODO: Add routes here")
 		return fmt.Errorf("could not find appropriate location to inject route")
 	}
+	logging.Debug("inserting route after TODO marker", "file", mainGoPath, "line", routeInsertIndex+1)
 
 	// Insert route (with proper indentation)
 	routeLine := fmt.Sprintf("\t%s(\"%s\", %s)", handlePrefix, route.Path, route.HandlerCall)
 	lines = insertLine(lines, routeInsertIndex, routeLine)
+	lines = addStartupRoute(lines, route.Path)
 
 	// Write back
 	output := strings.Join(lines, "\n") + "\n"
@@ -152,6 +164,34 @@ func InjectRoute(mainGoPath string, route RouteInfo) error {
 	return nil
 }
 
+// addStartupRoute lists path in main.go's startupRoutes, which the generated
+// app logs on boot. Apps generated before the startup banner have no list and
+// are left alone.
+func addStartupRoute(lines []string, path string) []string {
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "var startupRoutes = []string{") {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return lines
+	}
+	entry := fmt.Sprintf("%q,", path)
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == entry {
+			return lines
+		}
+		if trimmed == "}" {
+			logging.Debug("adding route to startup banner", "route", path)
+			return insertLine(lines, i, "\t"+entry)
+		}
+	}
+	return lines
+}
+
 // insertLine inserts a line at the given index
 func insertLine(lines []string, index int, line string) []string {
 	result := make([]string, 0, len(lines)+1)
@@ -197,7 +237,7 @@ func UpdateResourceTestsForAuth(projectRoot string) error {
 			testPath := filepath.Join(resourceDir, file.Name())
 			if err := updateTestFileForAuth(testPath); err != nil {
 				// Log but don't fail - some tests might not need updating
-				fmt.Printf("   Note: Could not update %s: %v\n", testPath, err)
+				logging.Warn("could not update the test for auth", "file", testPath, "error", err)
 			}
 		}
 	}
@@ -361,6 +401,8 @@ func WrapExistingRoutesWithAuth(mainGoPath string, structName string) error {
 		"/health":                 true,
 		"/health/live":            true,
 		"/health/ready":           true,
+		"/healthz":                true,
+		"/readyz":                 true,
 		"/livetemplate-client.js": true,
 	}
 
@@ -497,6 +539,7 @@ func InjectAPIRegistration(mainGoPath, importPath string) error {
 
 	// Check if already injected
 	if strings.Contains(content, "api.RegisterRoutes") {
+		logging.Debug("API routes already registered, skipping", "file", mainGoPath)
 		return nil
 	}
 
@@ -507,6 +550,8 @@ func InjectAPIRegistration(mainGoPath, importPath string) error {
 		importMarker := "\"golang.org/x/time/rate\""
 		if strings.Contains(content, importMarker) {
 			content = strings.Replace(content, importMarker, importLine+"\n\n\t"+importMarker, 1)
+		} else {
+			logging.Debug("import marker not found, API import not added", "file", mainGoPath, "marker", importMarker)
 		}
 	}
 
@@ -534,6 +579,10 @@ func InjectAPIRegistration(mainGoPath, importPath string) error {
 		insertPoint := nextLineStart
 		registrationLine := "\tapi.RegisterRoutes(http.DefaultServeMux, queries)\n"
 		content = content[:insertPoint] + registrationLine + content[insertPoint:]
+		content = strings.Join(addStartupRoute(strings.Split(content, "\n"), "/api/v1/"), "\n")
+		logging.Debug("injecting API route registration", "file", mainGoPath)
+	} else {
+		logging.Debug("route marker not found, API routes not registered", "file", mainGoPath, "marker", todoMarker)
 	}
 
 	return os.WriteFile(mainGoPath, []byte(content), 0644)
@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
@@ -303,3 +305,48 @@ func getDBPath() string {
 
 	t.Log("✅ View handler route injection successful")
 }
+
+func TestInjectRoute_StartupRoutes(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := GenerateApp("testapp", "testapp", "multi", "tailwind", "", true); err != nil {
+		t.Fatalf("GenerateApp failed: %v", err)
+	}
+	mainGoPath := filepath.Join("testapp", "cmd", "testapp", "main.go")
+
+	route := RouteInfo{Path: "/posts", PackageName: "posts", HandlerCall: "posts.Handler(queries)", ImportPath: "testapp/app/posts"}
+	for range 2 {
+		if err := InjectRoute(mainGoPath, route); err != nil {
+			t.Fatalf("InjectRoute failed: %v", err)
+		}
+	}
+	if err := InjectAPIRegistration(mainGoPath, "testapp/app/api"); err != nil {
+		t.Fatalf("InjectAPIRegistration failed: %v", err)
+	}
+
+	result, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "main.go", result, 0); err != nil {
+		t.Fatalf("main.go does not parse: %v", err)
+	}
+	content := string(result)
+
+	if !strings.Contains(content, "const devMode = true") {
+		t.Error("main.go should record dev mode for the startup banner")
+	}
+	if !strings.Contains(content, "logStartupBanner(ln.Addr(), dbPath)") {
+		t.Error("main.go should log the startup banner after binding")
+	}
+	start := strings.Index(content, "var startupRoutes = []string{")
+	if start < 0 {
+		t.Fatal("main.go has no startupRoutes list")
+	}
+	list := content[start : start+strings.Index(content[start:], "}")]
+	if n := strings.Count(list, `"/posts",`); n != 1 {
+		t.Errorf("startupRoutes should list /posts once, found %d:\n%s", n, list)
+	}
+	if !strings.Contains(list, `"/api/v1/",`) {
+		t.Errorf("startupRoutes should list the API prefix:\n%s", list)
+	}
+}
@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/seeder"
+)
+
+// sampleRows is the number of rows in sample item lists.
+const sampleRows = 3
+
+// SampleData returns data for previewing the template at templatePath
+// without the app: the fields of the state struct its handler renders it
+// with, set to the initial values of the handler, and item lists filled
+// with rows of sample values for the resource's schema.sql table. Values
+// come from a fixed seed, so a preview is the same on every run.
+func SampleData(projectRoot, templatePath string) (map[string]any, error) {
+	dir := filepath.Dir(templatePath)
+	fields, initial, err := stateStruct(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := sampleItems(projectRoot, filepath.Base(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{}
+	for _, f := range fields {
+		if v, ok := initial[f.Name.Name]; ok {
+			data[f.Name.Name] = v
+			continue
+		}
+		data[f.Name.Name] = sampleField(f.Name.Name, f.Type, rows)
+	}
+	return data, nil
+}
+
+type stateField struct {
+	Name *ast.Ident
+	Type ast.Expr
+}
+
+// stateStruct finds the struct type named *State in the Go files of dir and
+// returns its fields and the literal values its composite literals set.
+func stateStruct(dir string) ([]stateField, map[string]any, error) {
+	fset := token.NewFileSet()
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	var files []*ast.File
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		files = append(files, file)
+	}
+
+	var name string
+	var fields []stateField
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok || name != "" || !strings.HasSuffix(spec.Name.Name, "State") {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			name = spec.Name.Name
+			for _, field := range st.Fields.List {
+				for _, ident := range field.Names {
+					if ident.IsExported() {
+						fields = append(fields, stateField{ident, field.Type})
+					}
+				}
+			}
+			return false
+		})
+	}
+	if name == "" {
+		return nil, nil, fmt.Errorf("no state struct (type ...State struct) in %s; pass the data with --data", dir)
+	}
+
+	initial := map[string]any{}
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+			if ident, ok := lit.Type.(*ast.Ident); !ok || ident.Name != name {
+				return true
+			}
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if v, ok := literalValue(kv.Value); ok {
+					initial[key.Name] = v
+				}
+			}
+			return true
+		})
+	}
+	return fields, initial, nil
+}
+
+// literalValue returns the value of a string, number or boolean literal.
+func literalValue(expr ast.Expr) (any, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			s, err := strconv.Unquote(e.Value)
+			return s, err == nil
+		case token.INT:
+			i, err := strconv.Atoi(e.Value)
+			return i, err == nil
+		case token.FLOAT:
+			f, err := strconv.ParseFloat(e.Value, 64)
+			return f, err == nil
+		}
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return nil, false
+}
+
+// sampleField returns a value for a state field without an initial value:
+// the sample rows for item lists, their count for counts, and zero values
+// otherwise.
+func sampleField(name string, typ ast.Expr, rows []any) any {
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && strings.HasSuffix(ident.Name, "Item") {
+			return rows
+		}
+		return []any{}
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return ""
+		case "bool":
+			return false
+		case "int", "int32", "int64":
+			switch {
+			case strings.HasSuffix(name, "Count") || strings.HasSuffix(name, "Size"):
+				return len(rows)
+			case strings.HasSuffix(name, "Page") || strings.HasSuffix(name, "Pages"):
+				return 1
+			}
+			return 0
+		case "float32", "float64":
+			return 0.0
+		}
+	}
+	return nil
+}
+
+// sampleItems returns rows of sample values for the table of resource,
+// keyed by the Go field names sqlc generates, or none without a table.
+func sampleItems(projectRoot, resource string) ([]any, error) {
+	schemaPath := filepath.Join(projectRoot, "database", "schema.sql")
+	tables, err := seeder.ParseSchema(schemaPath)
+	if err != nil {
+		return []any{}, nil
+	}
+	table := seeder.FindTable(tables, resource)
+	if table == nil {
+		return []any{}, nil
+	}
+	if err := seeder.Configure(projectRoot, tables, ""); err != nil {
+		return nil, err
+	}
+	if err := seeder.Reproducible(1); err != nil {
+		return nil, err
+	}
+
+	rows := make([]any, sampleRows)
+	for i := range rows {
+		row := map[string]any{}
+		for _, col := range table.Columns {
+			var value any
+			switch col.Name {
+			case "id":
+				value = fmt.Sprintf("%s-%d", resource, i+1)
+			case "created_at", "updated_at":
+				value = seeder.SeedEpoch.Add(-time.Duration(i) * 24 * time.Hour)
+			default:
+				value = seeder.GenerateValue(col)
+			}
+			row[toCamelCase(col.Name)] = value
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSampleData(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	if err := generateCounterTestResource(t, tmpDir, "posts", "title:string", "published_at:time"); err != nil {
+		t.Fatal(err)
+	}
+
+	tmplPath := filepath.Join(tmpDir, "app", "posts", "posts.tmpl")
+	data, err := SampleData(tmpDir, tmplPath)
+	if err != nil {
+		t.Fatalf("SampleData failed: %v", err)
+	}
+
+	// Initial values come from the handler
+	if data["Title"] != "Posts Management" || data["PageSize"] != 20 || data["PaginationMode"] != "infinite" {
+		t.Errorf("expected the handler's initial values, got Title=%v PageSize=%v PaginationMode=%v", data["Title"], data["PageSize"], data["PaginationMode"])
+	}
+
+	rows, ok := data["PaginatedPosts"].([]any)
+	if !ok || len(rows) != sampleRows {
+		t.Fatalf("expected %d sample rows, got %#v", sampleRows, data["PaginatedPosts"])
+	}
+	row := rows[0].(map[string]any)
+	if row["ID"] != "posts-1" {
+		t.Errorf("expected a stable ID, got %v", row["ID"])
+	}
+	if s, ok := row["Title"].(string); !ok || s == "" {
+		t.Errorf("expected a sample title, got %#v", row["Title"])
+	}
+	if _, ok := row["CreatedAt"].(time.Time); !ok {
+		t.Errorf("expected CreatedAt to be a time, got %#v", row["CreatedAt"])
+	}
+	if data["TotalCount"] != sampleRows || data["EditingPosts"] != nil {
+		t.Errorf("expected counts of the rows and nil pointers, got TotalCount=%v EditingPosts=%v", data["TotalCount"], data["EditingPosts"])
+	}
+
+	// The same seed gives the same rows
+	again, err := SampleData(tmpDir, tmplPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again["PaginatedPosts"].([]any)[0].(map[string]any)["Title"] != row["Title"] {
+		t.Error("expected sample values to be stable across runs")
+	}
+
+	if _, err := SampleData(tmpDir, filepath.Join(tmpDir, "app", "missing", "x.tmpl")); err == nil {
+		t.Error("expected an error without a state struct")
+	}
+}
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// savedViewsTableDDL marks the saved_views table as set up in schema.sql.
+const savedViewsTableDDL = "CREATE TABLE IF NOT EXISTS saved_views"
+
+// SavedViewsEnabled reports whether the saved_views table has been added to
+// projectRoot's schema by a resource generated with --saved-views.
+func SavedViewsEnabled(projectRoot string) bool {
+	schema, err := os.ReadFile(filepath.Join(projectRoot, "database", "schema.sql"))
+	return err == nil && strings.Contains(string(schema), savedViewsTableDDL)
+}
+
+// generateSavedViews adds the saved_views table, which all resources with
+// saved views share, and its queries, unless they already exist.
+func generateSavedViews(projectRoot string, kitLoader *kits.KitLoader, kitName string) error {
+	if SavedViewsEnabled(projectRoot) {
+		return nil
+	}
+
+	migrationsDir := filepath.Join(projectRoot, "database", "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	timestamp := time.Now()
+	var migrationPath string
+	for i := 0; i < 3600; i++ {
+		timestampStr := timestamp.Format("20060102150405")
+		migrationPath = filepath.Join(migrationsDir, fmt.Sprintf("%s_create_saved_views.sql", timestampStr))
+
+		matches, err := filepath.Glob(filepath.Join(migrationsDir, timestampStr+"_*"))
+		if err != nil {
+			return fmt.Errorf("failed to check for existing migrations: %w", err)
+		}
+		if len(matches) == 0 {
+			break
+		}
+		timestamp = timestamp.Add(1 * time.Second)
+		if i == 3599 {
+			return fmt.Errorf("failed to generate unique migration timestamp")
+		}
+	}
+
+	if err := writeTemplateFile(kitLoader, kitName, "savedviews/migration.sql.tmpl", migrationPath, nil); err != nil {
+		return fmt.Errorf("failed to generate saved views migration: %w", err)
+	}
+	dbDir := filepath.Join(projectRoot, "database")
+	if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+		return err
+	}
+
+	if err := appendTemplateFile(kitLoader, kitName, "savedviews/schema.sql.tmpl", filepath.Join(dbDir, "schema.sql"), "saved views", nil); err != nil {
+		return fmt.Errorf("failed to append to schema.sql: %w", err)
+	}
+	if err := appendTemplateFile(kitLoader, kitName, "savedviews/queries.sql.tmpl", filepath.Join(dbDir, "queries.sql"), "saved views", nil); err != nil {
+		return fmt.Errorf("failed to append to queries.sql: %w", err)
+	}
+	return nil
+}
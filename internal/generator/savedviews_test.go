@@ -0,0 +1,139 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceSavedViews(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Filters: true, SavedViews: true},
+		"title:string", "status:select:open,done"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"encoding/json"`,
+		"SavedViews   []models.SavedView",
+		"Filters     TasksFilters `json:\"filters\"`",
+		"func (c *TasksController) SaveView(",
+		"func (c *TasksController) ApplyView(",
+		"func (c *TasksController) ToggleDefaultView(",
+		"state.DefaultView = v.ID",
+		"func (c *TasksController) DeleteView(",
+		"func (c *TasksController) SetPageSize(",
+		`Resource: "tasks",`,
+		"if v.IsDefault {",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+	// Without auth all visitors share the views
+	if strings.Contains(string(handler), "WithAuthenticator") {
+		t.Error("handler should not identify users in an app without auth")
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`name="view_id" lvt-on:change="apply_view"`,
+		`<form name="save_view"`,
+		`name="toggle_default_view">{{if eq .CurrentView .DefaultView}}`,
+		`<option value="25" {{if eq $.PageSize 25}}selected{{end}}>25</option>`,
+		`<option value="20" {{if eq $.PageSize 20}}selected{{end}}>20</option>`,
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+
+	// The saved_views table is shared: a second resource adds no migration
+	if err := generateEventsTestResource(t, tmpDir, "notes", ResourceOptions{SavedViews: true}, "body:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	migrations, err := filepath.Glob(filepath.Join(tmpDir, "database", "migrations", "*_create_saved_views.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("got %d saved_views migrations, want 1", len(migrations))
+	}
+	migration, err := os.ReadFile(migrations[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(migration), "DROP TABLE IF EXISTS saved_views;") {
+		t.Errorf("migration has no down section:\n%s", migration)
+	}
+	for file, want := range map[string]string{
+		"schema.sql":  savedViewsTableDDL,
+		"queries.sql": "-- name: ListSavedViews :many",
+	} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "database", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(string(content), want); n != 1 {
+			t.Errorf("%s contains %q %d times, want once", file, want, n)
+		}
+	}
+}
+
+func TestResourceSavedViewsWithAuth(t *testing.T) {
+	data := ResourceData{WithSavedViews: true}
+	if data.NeedsAuthenticator() {
+		t.Error("saved views need no authenticator without auth")
+	}
+	data.HasAuth = true
+	if !data.NeedsAuthenticator() {
+		t.Error("saved views should be kept per user when the app has auth")
+	}
+}
+
+func TestPageSizeOptions(t *testing.T) {
+	for _, tt := range []struct {
+		pageSize int
+		want     []int
+	}{
+		{10, []int{10, 25, 50, 100}},
+		{20, []int{10, 20, 25, 50, 100}},
+		{500, []int{10, 25, 50, 100}},
+	} {
+		if got := (ResourceData{PageSize: tt.pageSize}).PageSizeOptions(); !slices.Equal(got, tt.want) {
+			t.Errorf("PageSizeOptions() with page size %d = %v, want %v", tt.pageSize, got, tt.want)
+		}
+	}
+}
+
+func TestResourceSavedViewsUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	fields, err := parser.ParseFields([]string{"body:string", "post_id:references:posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "comments", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "posts", false, false, ResourceOptions{SavedViews: true})
+	if err == nil || !strings.Contains(err.Error(), "--parent") {
+		t.Errorf("--saved-views with --parent: err = %v, want it rejected", err)
+	}
+}
@@ -3,19 +3,22 @@ package generator
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/parser"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
-// GenerateSchema generates only database files (migration, schema, queries) without handler or template
-func GenerateSchema(basePath, moduleName, tableName string, fields []parser.Field, kitName, cssFramework string) error {
+// GenerateSchema generates only database files (migration, schema, queries) without handler or template.
+// indexes are extra indexes on the table, as declared with --index.
+func GenerateSchema(basePath, moduleName, tableName string, fields []parser.Field, kitName, cssFramework string, indexes ...parser.Index) error {
+	useInflections(basePath)
+	defer track(basePath, "schema "+strings.ToLower(tableName))()
 	// Defaults
 	if kitName == "" {
 		kitName = "multi"
@@ -41,7 +44,21 @@ func GenerateSchema(basePath, moduleName, tableName string, fields []parser.Fiel
 	resourceNameSingularCap := titleCaser.String(tableNameSingular)
 	resourceNamePluralCap := titleCaser.String(tableNamePlural)
 
+	fields, counters := splitCounterFields(fields, tableNamePlural)
+	for _, f := range fields {
+		if f.IsGenerated {
+			return fmt.Errorf("field '%s': generated fields are not supported by gen schema; their values are set by the handler of gen resource", f.Name)
+		}
+	}
 	fieldData := FieldDataFromFields(fields)
+	triggers, err := counterTriggers(filepath.Join(basePath, "database", "schema.sql"), tableNamePlural, counters, fieldData)
+	if err != nil {
+		return err
+	}
+	tableIndexes, err := indexData(tableNamePlural, indexes, fieldData, counters, nil, nil)
+	if err != nil {
+		return err
+	}
 
 	data := ResourceData{
 		PackageName:          tableNameLower,
@@ -54,6 +71,9 @@ func GenerateSchema(basePath, moduleName, tableName string, fields []parser.Fiel
 		Fields:               fieldData,
 		Kit:                  kit,
 		CSSFramework:         cssFramework,
+		Counters:             counters,
+		CounterTriggers:      triggers,
+		Indexes:              tableIndexes,
 	}
 
 	// Load templates
@@ -102,34 +122,41 @@ func GenerateSchema(basePath, moduleName, tableName string, fields []parser.Fiel
 	if err := generateFile(string(migrationTmpl), data, migrationPath, kit); err != nil {
 		return fmt.Errorf("failed to generate migration: %w", err)
 	}
+	if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+		return err
+	}
 
 	// Append to schema.sql for sqlc
-	if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "\n", kit); err != nil {
+	if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "schema "+tableNamePlural, kit); err != nil {
 		return fmt.Errorf("failed to append to schema: %w", err)
 	}
 
 	// Append to queries.sql
-	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "\n", kit); err != nil {
+	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "schema "+tableNamePlural, kit); err != nil {
 		return fmt.Errorf("failed to append to queries: %w", err)
 	}
 
-	// Run sqlc generate to create Go types
-	fmt.Println("Running sqlc generate...")
-	cmd := exec.Command("sqlc", "generate")
-	cmd.Dir = basePath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		fmt.Printf("⚠️  sqlc generate failed: %v\n", err)
-		fmt.Printf("Output: %s\n", string(output))
-		fmt.Println("You can run 'sqlc generate' manually later")
-	} else {
-		fmt.Println("✅ sqlc generate completed successfully")
-	}
+	runSqlcGenerate(basePath)
 
 	// Register schema in resource tracker
-	if err := RegisterResource(basePath, data.ResourceName, "", "schema"); err != nil {
-		fmt.Printf("⚠️  Could not register schema in .lvtresources: %v\n", err)
+	if err := RegisterResourceFields(basePath, data.ResourceName, "", "schema", data.TableName, data.Fields, data.Indexes...); err != nil {
+		logging.Warn("could not register the schema in .lvtresources", "error", err)
 	}
 
 	return nil
 }
+
+// runSqlcGenerate runs sqlc generate to create the Go types for the queries.
+// A failure is reported but not returned: the files are written either way.
+func runSqlcGenerate(basePath string) {
+	if SkipTools {
+		return
+	}
+	logging.Println("Running sqlc generate...")
+	output, err := runTool(basePath, "sqlc", "generate")
+	if err != nil {
+		logging.Warn("sqlc generate failed; you can run it manually later", "error", err, "output", strings.TrimSpace(string(output)))
+	} else {
+		logging.Println("✅ sqlc generate completed successfully")
+	}
+}
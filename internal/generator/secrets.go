@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// SecretsPackage is the app package that loads the encrypted secrets.
+const SecretsPackage = "shared/secrets/secrets.go"
+
+// GenerateSecrets sets up loading of the encrypted secrets in the app at
+// projectRoot: it creates shared/secrets and loads it at the start of
+// main. It reports whether anything was generated; apps already set up are
+// left alone.
+func GenerateSecrets(projectRoot, moduleName string) (bool, error) {
+	defer track(projectRoot, "secrets")()
+	secretsPath := filepath.Join(projectRoot, SecretsPackage)
+	if _, err := os.Stat(secretsPath); err == nil {
+		return false, nil
+	}
+
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return false, fmt.Errorf("failed to load project config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(secretsPath), 0755); err != nil {
+		return false, fmt.Errorf("failed to create shared/secrets directory: %w", err)
+	}
+	if err := writeTemplateFile(kits.DefaultLoader(), projectConfig.GetKit(), "app/secrets.go.tmpl", secretsPath, nil); err != nil {
+		return false, fmt.Errorf("failed to generate secrets.go: %w", err)
+	}
+
+	// The package loads secrets with lvt's pkg/secrets
+	goModPath := filepath.Join(projectRoot, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		if output, err := runTool(projectRoot, "go", "get", "github.com/livetemplate/lvt@latest"); err != nil {
+			logging.Warn("could not fetch dependencies; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+		}
+	}
+
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectSecretsLoad(mainGoPath, moduleName); err != nil {
+			return true, fmt.Errorf("failed to load secrets in main.go: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// injectSecretsLoad loads the secrets first thing in main, before
+// shared/config checks the environment they may set.
+func injectSecretsLoad(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "secrets.Load()") {
+		return nil
+	}
+
+	const anchor = "func main() {\n"
+	i := strings.Index(mainStr, anchor)
+	if i < 0 {
+		return fmt.Errorf("could not find injection point in main.go (expected 'func main() {')")
+	}
+	i += len(anchor)
+	load := "\t// Decrypt secrets/secrets.yml.enc (see `lvt env secrets edit`)\n" +
+		"\tif err := secrets.Load(); err != nil {\n" +
+		"\t\tfmt.Fprintln(os.Stderr, \"Failed to load secrets:\", err)\n" +
+		"\t\tos.Exit(1)\n" +
+		"\t}\n\n"
+	mainStr = mainStr[:i] + load + mainStr[i:]
+
+	for _, imp := range []string{"\t\"fmt\"", "\t\"os\"", fmt.Sprintf("\t\"%s/shared/secrets\"", moduleName)} {
+		if mainStr, err = injectImport(mainStr, imp); err != nil {
+			return fmt.Errorf("failed to inject import %s: %w", imp, err)
+		}
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSecrets(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	mainGoPath := filepath.Join(tmpDir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport (\n\t\"log/slog\"\n)\n\nfunc main() {\n\tslog.Info(\"starting\")\n}\n"
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := GenerateSecrets(tmpDir, "testmodule")
+	if err != nil || !generated {
+		t.Fatalf("GenerateSecrets() = %v, %v", generated, err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, SecretsPackage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "lvtsecrets.Load(\".\")") {
+		t.Error("secrets.go does not load the secrets")
+	}
+
+	content, err = os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainStr := string(content)
+	if !strings.Contains(mainStr, "\"testmodule/shared/secrets\"") {
+		t.Error("main.go missing shared/secrets import")
+	}
+	if strings.Index(mainStr, "secrets.Load()") > strings.Index(mainStr, "slog.Info") {
+		t.Error("secrets should load before anything else in main")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), mainGoPath, content, 0); err != nil {
+		t.Errorf("main.go does not parse: %v", err)
+	}
+
+	// Set up already: nothing to do
+	if generated, err := GenerateSecrets(tmpDir, "testmodule"); err != nil || generated {
+		t.Errorf("second GenerateSecrets() = %v, %v", generated, err)
+	}
+}
@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/manifest"
+)
+
+// Generators add to shared files such as schema.sql and queries.sql in
+// sections delimited by marker comments:
+//
+//	-- lvt:resource posts begin
+//	CREATE TABLE posts (...);
+//	-- lvt:resource posts end
+//
+// Generating again replaces the section instead of appending a duplicate.
+// The checksum of each section as written is kept in .lvt/manifest.json, so
+// a section edited by hand is only replaced if the user agrees.
+
+// ConfirmSectionReplace, when set, asks whether to replace a section of
+// path that was edited since lvt wrote it, given its current and its
+// regenerated content. Without it, edited sections are kept.
+var ConfirmSectionReplace func(path, section, current, regenerated string) bool
+
+func sectionMarkers(section string) (begin, end string) {
+	return "-- lvt:" + section + " begin\n", "-- lvt:" + section + " end\n"
+}
+
+// findSection returns the offsets of the content of section in text, the
+// lines between its markers.
+func findSection(text, section string) (start, end int, ok bool) {
+	begin, endMarker := sectionMarkers(section)
+	i := strings.Index(text, begin)
+	if i < 0 || (i > 0 && text[i-1] != '\n') {
+		return 0, 0, false
+	}
+	start = i + len(begin)
+	j := strings.Index(text[start:], endMarker)
+	if j < 0 {
+		return 0, 0, false
+	}
+	return start, start + j, true
+}
+
+// writeSection puts content in section of the file at path, replacing the
+// section if the file has it and appending it otherwise.
+func writeSection(path, section string, content []byte) error {
+	body := strings.TrimRight(string(content), "\n")
+	body = strings.TrimLeft(body, "\n") + "\n"
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	text := string(data)
+
+	var out string
+	if start, end, ok := findSection(text, section); ok {
+		current := text[start:end]
+		if current == body {
+			recordSection(path, section, body)
+			return nil
+		}
+		if recorded := recordedSection(path, section); recorded != manifest.Checksum([]byte(current)) {
+			if ConfirmSectionReplace == nil || !ConfirmSectionReplace(path, section, current, body) {
+				logging.Warn("kept an edited section", "section", section, "file", path)
+				return nil
+			}
+		}
+		out = text[:start] + body + text[end:]
+		logging.Debug("replaced file section", "path", path, "section", section, "bytes", len(body))
+	} else {
+		begin, end := sectionMarkers(section)
+		out = text
+		if out != "" && !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if out != "" {
+			out += "\n"
+		}
+		out += begin + body + end
+		logging.Debug("appended to file", "path", path, "section", section, "bytes", len(body))
+	}
+	if err := os.WriteFile(path, []byte(out), 0644); err != nil {
+		return err
+	}
+	recordSection(path, section, body)
+	return nil
+}
+
+// refreshSection records the current content of section after a generator
+// changed it in place, so the change is not mistaken for a hand edit.
+func refreshSection(path, section string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if start, end, ok := findSection(string(data), section); ok {
+		recordSection(path, section, string(data[start:end]))
+	}
+	return nil
+}
+
+// sectionKey returns the manifest path of path in the project being
+// generated, if a run is being recorded.
+func sectionKey(path string) (string, bool) {
+	if tracking == nil {
+		return "", false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(tracking.root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func recordSection(path, section, body string) {
+	key, ok := sectionKey(path)
+	if !ok {
+		return
+	}
+	if tracking.sections[key] == nil {
+		tracking.sections[key] = make(map[string]string)
+	}
+	tracking.sections[key][section] = manifest.Checksum([]byte(body))
+}
+
+// recordedSection returns the checksum of section as lvt last wrote it,
+// or "" if it is not known.
+func recordedSection(path, section string) string {
+	key, ok := sectionKey(path)
+	if !ok {
+		return ""
+	}
+	if sum, ok := tracking.sections[key][section]; ok {
+		return sum
+	}
+	m, err := manifest.Load(tracking.root)
+	if err != nil {
+		return ""
+	}
+	return m.Sections[key][section]
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "database", "queries.sql")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("-- Database queries\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	read := func() string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	write := func(section, content string) {
+		t.Helper()
+		defer track(dir, "test")()
+		if err := writeSection(path, section, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("resource posts", "\n-- name: GetPost :one\n")
+	write("resource tags", "-- name: GetTag :one\n")
+	want := "-- Database queries\n\n" +
+		"-- lvt:resource posts begin\n-- name: GetPost :one\n-- lvt:resource posts end\n\n" +
+		"-- lvt:resource tags begin\n-- name: GetTag :one\n-- lvt:resource tags end\n"
+	if got := read(); got != want {
+		t.Fatalf("after appending:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Regenerating replaces the section in place
+	write("resource posts", "-- name: GetPost :one\n-- name: ListPosts :many\n")
+	want = strings.Replace(want, "-- name: GetPost :one\n", "-- name: GetPost :one\n-- name: ListPosts :many\n", 1)
+	if got := read(); got != want {
+		t.Fatalf("after replacing:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A hand-edited section is kept unless the user agrees to replace it
+	edited := strings.Replace(want, "-- name: GetTag :one\n", "-- name: GetTag :one\n-- mine\n", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var asked string
+	ConfirmSectionReplace = func(p, section, current, regenerated string) bool {
+		asked = section + ": " + current + "=> " + regenerated
+		return false
+	}
+	defer func() { ConfirmSectionReplace = nil }()
+	write("resource tags", "-- name: GetTag :one\n")
+	if got := read(); got != edited {
+		t.Errorf("declined replacement changed the file:\n%s", got)
+	}
+	if want := "resource tags: -- name: GetTag :one\n-- mine\n=> -- name: GetTag :one\n"; asked != want {
+		t.Errorf("asked %q, want %q", asked, want)
+	}
+
+	ConfirmSectionReplace = func(string, string, string, string) bool { return true }
+	write("resource tags", "-- name: GetTag :one\n")
+	if got := read(); got != want {
+		t.Errorf("accepted replacement:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Once written again, the section is no longer considered edited
+	ConfirmSectionReplace = func(string, string, string, string) bool {
+		t.Error("asked about a section lvt wrote")
+		return false
+	}
+	write("resource tags", "-- name: GetTagByName :one\n")
+	if got := read(); !strings.Contains(got, "GetTagByName") {
+		t.Errorf("section was not replaced:\n%s", got)
+	}
+}
+
+func TestGenerateResource_RegenerateKeepsOneSection(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	for i := 0; i < 2; i++ {
+		if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"schema.sql", "queries.sql"} {
+		data, err := os.ReadFile(filepath.Join(dir, "database", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(string(data), "-- lvt:resource posts begin"); n != 1 {
+			t.Errorf("%s has %d posts sections, want 1:\n%s", name, n, data)
+		}
+	}
+	schema, _ := os.ReadFile(filepath.Join(dir, "database", "schema.sql"))
+	if n := strings.Count(string(schema), "CREATE TABLE IF NOT EXISTS posts"); n != 1 {
+		t.Errorf("schema.sql creates posts %d times", n)
+	}
+}
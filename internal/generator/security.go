@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// SecurityData is the template data for the shared/security package.
+type SecurityData struct {
+	ModuleName string
+}
+
+// SecurityPackage is the app package setting the Content-Security-Policy
+// and the other security headers.
+const SecurityPackage = "shared/security/security.go"
+
+// cspReportURIVar declares where browsers report policy violations.
+const cspReportURIVar = `{Name: "CSP_REPORT_URI", Type: TypeString, Description: "URL browsers report Content-Security-Policy violations to; none when unset"}`
+
+// cspReportOnlyVar declares the report-only toggle, off unless reportOnly.
+func cspReportOnlyVar(reportOnly bool) string {
+	return fmt.Sprintf(`{Name: "CSP_REPORT_ONLY", Type: TypeBool, Default: "%t", Description: "Report Content-Security-Policy violations without blocking them (see shared/security)"}`, reportOnly)
+}
+
+// SecurityHeadersEnabled reports whether `lvt gen security-headers` has been
+// run in projectRoot.
+func SecurityHeadersEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, SecurityPackage))
+	return err == nil
+}
+
+// GenerateSecurityHeaders creates the shared/security package, declares
+// CSP_REPORT_ONLY and CSP_REPORT_URI, and puts security.Middleware in place
+// of the basic securityHeadersMiddleware of main.go. Pages generated
+// afterwards mark their inline scripts with the response's nonce. With
+// reportOnly, the policy only reports violations until CSP_REPORT_ONLY is
+// set to false.
+func GenerateSecurityHeaders(projectRoot, moduleName string, reportOnly bool) error {
+	defer track(projectRoot, "security-headers")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no middleware chain to set headers in; use the multi, single or daisyui kit")
+	}
+	if SecurityHeadersEnabled(projectRoot) {
+		return fmt.Errorf("security headers already set up (%s exists)", SecurityPackage)
+	}
+
+	// 1. Create shared/security, which registers cspNonce with shared/funcs
+	kitLoader := kits.DefaultLoader()
+	if err := generateFuncs(projectRoot, kitLoader, kitName); err != nil {
+		return err
+	}
+	dir := filepath.Join(projectRoot, filepath.Dir(SecurityPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/security directory: %w", err)
+	}
+	data := SecurityData{ModuleName: moduleName}
+	for _, f := range []string{"security.go", "websocket.go", "security_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "security/"+f+".tmpl", filepath.Join(dir, f), data); err != nil {
+			return fmt.Errorf("failed to generate shared/security/%s: %w", f, err)
+		}
+	}
+
+	// 2. Declare CSP_REPORT_ONLY and CSP_REPORT_URI
+	if err := declareConfigVar(projectRoot, "CSP_REPORT_ONLY", cspReportOnlyVar(reportOnly)); err != nil {
+		return fmt.Errorf("failed to declare CSP_REPORT_ONLY: %w", err)
+	}
+	if err := declareConfigVar(projectRoot, "CSP_REPORT_URI", cspReportURIVar); err != nil {
+		return fmt.Errorf("failed to declare CSP_REPORT_URI: %w", err)
+	}
+
+	// 3. Set the headers in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectSecurityHeaders(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to inject security headers into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// oldSecurityHeaders starts the basic middleware lvt new generates, whose
+// policy lets any inline script run.
+const oldSecurityHeaders = "// securityHeadersMiddleware adds security headers to all responses\nfunc securityHeadersMiddleware("
+
+// injectSecurityHeaders puts security.Middleware in the middleware chain,
+// where securityHeadersMiddleware was, and removes the latter.
+func injectSecurityHeaders(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "security.Middleware") {
+		return nil // Already injected
+	}
+
+	chain := "\thandler := chainMiddleware(http.DefaultServeMux,"
+	idx := strings.Index(mainStr, chain)
+	if idx < 0 {
+		return fmt.Errorf("could not find the middleware chain (expected %q)", strings.TrimSpace(chain))
+	}
+	if old := strings.Index(mainStr[idx:], "\n\t\tsecurityHeadersMiddleware,"); old >= 0 {
+		pos := idx + old + len("\n\t\t")
+		mainStr = mainStr[:pos] + "security.Middleware," + mainStr[pos+len("securityHeadersMiddleware,"):]
+	} else {
+		idx += len(chain)
+		mainStr = mainStr[:idx] + "\n\t\tsecurity.Middleware," + mainStr[idx:]
+	}
+
+	if start := strings.Index(mainStr, oldSecurityHeaders); start >= 0 {
+		if end := strings.Index(mainStr[start:], "\n}\n"); end >= 0 {
+			end += start + len("\n}\n")
+			if strings.HasPrefix(mainStr[end:], "\n") {
+				end++
+			}
+			mainStr = mainStr[:start] + mainStr[end:]
+		}
+	}
+
+	mainStr, err = injectImport(mainStr, fmt.Sprintf("\t\"%s/shared/security\"", moduleName))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+var (
+	scriptTag     = regexp.MustCompile(`<script\b[^>]*>`)
+	inlineHandler = regexp.MustCompile(`\son[a-z]+="`)
+)
+
+// checkCSPCompliant fails unless every inline script of the page template at
+// path carries the nonce and no element has an inline event handler, which
+// the policy of shared/security would block.
+func checkCSPCompliant(t *testing.T, path string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(content)
+	for _, tag := range scriptTag.FindAllString(page, -1) {
+		if !strings.Contains(tag, " src=") && !strings.Contains(tag, `nonce="{{cspNonce}}"`) {
+			t.Errorf("%s: inline script without the nonce: %s", filepath.Base(path), tag)
+		}
+	}
+	if m := inlineHandler.FindString(page); m != "" {
+		t.Errorf("%s: inline event handler %q", filepath.Base(path), strings.TrimSpace(m))
+	}
+	if _, err := template.New("page").Funcs(TemplateFuncs(filepath.Dir(filepath.Dir(filepath.Dir(path))))).Parse(page); err != nil {
+		t.Errorf("%s does not parse: %v", filepath.Base(path), err)
+	}
+}
+
+func TestGenerateSecurityHeaders(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := GenerateApp("shop", "shop", kit, "tailwind", "", false); err != nil {
+				t.Fatalf("GenerateApp failed: %v", err)
+			}
+			if err := GenerateSecurityHeaders("shop", "shop", false); err != nil {
+				t.Fatal(err)
+			}
+
+			mainPath := filepath.Join("shop", "cmd", "shop", "main.go")
+			main, err := os.ReadFile(mainPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`"shop/shared/security"`, "\t\tsecurity.Middleware,\n"} {
+				if !strings.Contains(string(main), want) {
+					t.Errorf("main.go is missing %q", want)
+				}
+			}
+			if strings.Contains(string(main), "securityHeadersMiddleware") {
+				t.Error("main.go still has the basic securityHeadersMiddleware")
+			}
+			typeCheckPackage(t, filepath.Dir(mainPath))
+			config, err := os.ReadFile(filepath.Join("shop", "shared", "config", "config.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`{Name: "CSP_REPORT_ONLY", Type: TypeBool, Default: "false"`, `{Name: "CSP_REPORT_URI"`} {
+				if !strings.Contains(string(config), want) {
+					t.Errorf("config.go is missing %s", want)
+				}
+			}
+
+			fields, err := fieldparser.ParseFields([]string{"name:string"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := GenerateResource("shop", "shop", "items", fields, kit, "tailwind", "tailwind", "infinite", 20, "modal", "", false, false); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			handler, err := os.ReadFile(filepath.Join("shop", "app", "items", "items.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(handler), `_ "shop/shared/security"`) {
+				t.Error("handler does not import shared/security, which registers cspNonce")
+			}
+			typeCheckPackage(t, filepath.Join("shop", "app", "items"))
+			itemsPath := filepath.Join("shop", "app", "items", "items.tmpl")
+			checkCSPCompliant(t, itemsPath)
+			items, err := os.ReadFile(itemsPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`{{define "securityScript"}}`, `{{template "securityScript" .}}`, "data-confirm="} {
+				if !strings.Contains(string(items), want) {
+					t.Errorf("resource template is missing %q", want)
+				}
+			}
+
+			for _, pattern := range []string{"chat", "kanban"} {
+				if err := GenerateView("shop", "shop", pattern+"board", kit, "tailwind", ViewOptions{Pattern: pattern}); err != nil {
+					t.Fatalf("GenerateView(%s) failed: %v", pattern, err)
+				}
+				checkCSPCompliant(t, filepath.Join("shop", "app", pattern+"board", pattern+"board.tmpl"))
+			}
+		})
+	}
+}
+
+func TestGenerateSecurityHeaders_ReportOnly(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	configPath := writeTestConfig(t, dir)
+	if err := GenerateSecurityHeaders(dir, "testmodule", true); err != nil {
+		t.Fatal(err)
+	}
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{Name: "CSP_REPORT_ONLY", Type: TypeBool, Default: "true"`; !strings.Contains(string(config), want) {
+		t.Errorf("config.go is missing %s:\n%s", want, config)
+	}
+	if _, err := os.Stat(filepath.Join(dir, funcsPackagePath)); err != nil {
+		t.Errorf("shared/funcs, which shared/security registers cspNonce with, was not generated: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// SEOData is the template data for the shared/seo package.
+type SEOData struct {
+	ModuleName string
+}
+
+// SEOPackage is the app package serving the sitemap and robots.txt and
+// describing pages with Meta.
+const SEOPackage = "shared/seo/seo.go"
+
+// baseURLVar declares the app's public URL. Apps with auth read it already.
+const baseURLVar = `{Name: "BASE_URL", Type: TypeString, Description: "Public URL of the app, e.g. https://example.com, for sitemap.xml and og:url; the request's host when unset"}`
+
+// robotsDisallowVar declares the paths robots.txt keeps every crawler from.
+const robotsDisallowVar = `{Name: "ROBOTS_DISALLOW", Type: TypeString, Description: "Comma-separated paths robots.txt disallows for every crawler, e.g. / on staging"}`
+
+// SEOEnabled reports whether `lvt gen seo` has been run in projectRoot.
+func SEOEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, SEOPackage))
+	return err == nil
+}
+
+// GenerateSEO creates the shared/seo package, declares BASE_URL and
+// ROBOTS_DISALLOW, and serves /sitemap.xml and /robots.txt from main.go.
+// Resources generated afterwards register their pages for the sitemap and
+// render title, description and Open Graph tags in their head.
+func GenerateSEO(projectRoot, moduleName string) error {
+	defer track(projectRoot, "seo")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no resources to list in a sitemap; use the multi, single or daisyui kit")
+	}
+	if SEOEnabled(projectRoot) {
+		return fmt.Errorf("seo already set up (%s exists)", SEOPackage)
+	}
+
+	// 1. Create shared/seo
+	dir := filepath.Join(projectRoot, filepath.Dir(SEOPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/seo directory: %w", err)
+	}
+	data := SEOData{ModuleName: moduleName}
+	if err := writeTemplateFile(kits.DefaultLoader(), kitName, "seo/seo.go.tmpl", filepath.Join(projectRoot, SEOPackage), data); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", SEOPackage, err)
+	}
+
+	// 2. Declare BASE_URL and ROBOTS_DISALLOW
+	if err := declareConfigVar(projectRoot, "BASE_URL", baseURLVar); err != nil {
+		return fmt.Errorf("failed to declare BASE_URL: %w", err)
+	}
+	if err := declareConfigVar(projectRoot, "ROBOTS_DISALLOW", robotsDisallowVar); err != nil {
+		return fmt.Errorf("failed to declare ROBOTS_DISALLOW: %w", err)
+	}
+
+	// 3. Serve the sitemap and robots.txt in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectSEO(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to inject seo into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// injectSEO registers the sitemap and robots.txt handlers.
+func injectSEO(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "seo.Sitemap()") {
+		return nil // Already injected
+	}
+
+	lines := strings.Split(mainStr, "\n")
+	marker := -1
+	for i, line := range lines {
+		if strings.Contains(line, "// TODO: Add routes here") {
+			marker = i
+			break
+		}
+	}
+	if marker < 0 {
+		return fmt.Errorf("could not find the routes marker (expected '// TODO: Add routes here')")
+	}
+	lines = insertLine(lines, marker, "")
+	lines = insertLine(lines, marker, `	http.Handle("/robots.txt", seo.Robots())`)
+	lines = insertLine(lines, marker, `	http.Handle("/sitemap.xml", seo.Sitemap())`)
+	lines = insertLine(lines, marker, "\t// Sitemap and robots.txt for search engines (see shared/seo)")
+	lines = addStartupRoute(lines, "/sitemap.xml")
+	lines = addStartupRoute(lines, "/robots.txt")
+
+	mainStr, err = injectImport(strings.Join(lines, "\n"), fmt.Sprintf("\t\"%s/shared/seo\"", moduleName))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+// gen seo serves /sitemap.xml and /robots.txt from main.go.
+func TestGenerateSEO(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	mainGoPath := filepath.Join(dir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"net/http"
+)
+
+var startupRoutes = []string{
+	"/",
+}
+
+func main() {
+	// TODO: Add routes here (added automatically by ` + "`lvt gen`" + `)
+
+	http.ListenAndServe(":8080", nil)
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeTestConfig(t, dir)
+
+	if err := GenerateSEO(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+
+	main, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`http.Handle("/sitemap.xml", seo.Sitemap())`,
+		`http.Handle("/robots.txt", seo.Robots())`,
+		`"/sitemap.xml",`,
+	} {
+		if !strings.Contains(string(main), want) {
+			t.Errorf("main.go is missing %q:\n%s", want, main)
+		}
+	}
+	typeCheckPackage(t, filepath.Dir(mainGoPath))
+
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`{Name: "BASE_URL"`, `{Name: "ROBOTS_DISALLOW"`} {
+		if !strings.Contains(string(config), want) {
+			t.Errorf("config.go does not declare %s:\n%s", want, config)
+		}
+	}
+}
+
+func TestPagesSEO(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "seo.") {
+		t.Error("resource generated before gen seo should not register with it")
+	}
+
+	if err := GenerateSEO(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := parser.ParseFields([]string{"title:string", "body:text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateResource(dir, "testmodule", "articles", fields, "multi", "tailwind", "tailwind", "infinite", 20, "page", "", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for page, wants := range map[string][]string{
+		"posts":    {`seo.Register("/posts", nil)`, "state.Meta = meta(state)", `"website"`},
+		"articles": {`seo.Register("/articles", func(`, "queries.GetAllArticles(ctx)", `"article"`, `seo.URL("/articles/" + item.ID)`},
+	} {
+		path := filepath.Join(dir, "app", page, page+".go")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range append(wants, `"testmodule/shared/seo"`, "seo.Meta") {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s handler is missing %q", page, want)
+			}
+		}
+		typeCheckPackage(t, filepath.Dir(path))
+
+		tmpl, err := os.ReadFile(filepath.Join(dir, "app", page, page+".tmpl"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`{{define "seoMeta"}}`, `{{template "seoMeta" .}}`, `<meta property="og:title"`} {
+			if !strings.Contains(string(tmpl), want) {
+				t.Errorf("%s template is missing %q", page, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// SessionsData is the template data for the shared/sessions package.
+type SessionsData struct {
+	ModuleName string
+	TTL        string // Go expression of how long snapshots are kept, e.g. "24 * time.Hour"
+	Namespace  string // prefix of the app's Redis keys
+}
+
+// SessionsPackage is the app package whose store keeps live sessions in the
+// session store SESSION_STORE picks.
+const SessionsPackage = "shared/sessions/sessions.go"
+
+// DefaultSessionTTL is how long a session snapshot is kept after the
+// session's last action unless --ttl says otherwise.
+const DefaultSessionTTL = 24 * time.Hour
+
+// sessionStoreVar declares the backend keeping session snapshots, which may
+// be redis when the Redis backend was generated.
+func sessionStoreVar(withRedis bool) string {
+	values := `"sqlite", "memory"`
+	if withRedis {
+		values += `, "redis"`
+	}
+	return fmt.Sprintf(`{Name: "SESSION_STORE", Type: TypeString, Default: "sqlite", Values: []string{%s}, Description: "Where live sessions are kept: the database, process memory or Redis (see shared/sessions)"}`, values)
+}
+
+// sessionsRedisURLVar declares the Redis server of SESSION_STORE=redis.
+const sessionsRedisURLVar = `{Name: "REDIS_URL", Type: TypeString, Secret: true, Description: "Redis server, e.g. redis://localhost:6379, of the session store when SESSION_STORE=redis"}`
+
+// SessionsEnabled reports whether `lvt gen sessions` has been run in
+// projectRoot.
+func SessionsEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, SessionsPackage))
+	return err == nil
+}
+
+// GenerateSessions creates the live_sessions table and the shared/sessions
+// package, whose store snapshots each page's session state so it survives
+// a server restart for ttl after the session's last action. Resources,
+// views, wizards and the auth page generated afterwards keep their
+// sessions there. SESSION_STORE picks the backend of the snapshots at
+// startup; withRedis adds the Redis backend, which instances share.
+func GenerateSessions(projectRoot, moduleName string, ttl time.Duration, withRedis bool) error {
+	defer track(projectRoot, "sessions")()
+	if ttl <= 0 {
+		return fmt.Errorf("session TTL must be positive: %s", ttl)
+	}
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no database to keep sessions in; use the multi, single or daisyui kit")
+	}
+	if SessionsEnabled(projectRoot) {
+		return fmt.Errorf("sessions already set up (%s exists)", SessionsPackage)
+	}
+	kitLoader := kits.DefaultLoader()
+
+	migrationsDir := filepath.Join(projectRoot, "database", "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	timestamp := time.Now()
+	var migrationPath string
+	for i := 0; i < 3600; i++ {
+		timestampStr := timestamp.Format("20060102150405")
+		migrationPath = filepath.Join(migrationsDir, fmt.Sprintf("%s_create_live_sessions.sql", timestampStr))
+
+		matches, err := filepath.Glob(filepath.Join(migrationsDir, timestampStr+"_*"))
+		if err != nil {
+			return fmt.Errorf("failed to check for existing migrations: %w", err)
+		}
+		if len(matches) == 0 {
+			break
+		}
+		timestamp = timestamp.Add(1 * time.Second)
+		if i == 3599 {
+			return fmt.Errorf("failed to generate unique migration timestamp")
+		}
+	}
+
+	if err := writeTemplateFile(kitLoader, kitName, "sessions/migration.sql.tmpl", migrationPath, nil); err != nil {
+		return fmt.Errorf("failed to generate sessions migration: %w", err)
+	}
+	dbDir := filepath.Join(projectRoot, "database")
+	if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+		return err
+	}
+	if err := appendTemplateFile(kitLoader, kitName, "sessions/schema.sql.tmpl", filepath.Join(dbDir, "schema.sql"), "sessions", nil); err != nil {
+		return fmt.Errorf("failed to append to schema.sql: %w", err)
+	}
+	if err := appendTemplateFile(kitLoader, kitName, "sessions/queries.sql.tmpl", filepath.Join(dbDir, "queries.sql"), "sessions", nil); err != nil {
+		return fmt.Errorf("failed to append to queries.sql: %w", err)
+	}
+
+	dir := filepath.Join(projectRoot, filepath.Dir(SessionsPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/sessions directory: %w", err)
+	}
+	files := []string{"sessions.go", "sessions_test.go"}
+	if withRedis {
+		files = append(files, "redis.go")
+	}
+	data := SessionsData{ModuleName: moduleName, TTL: durationExpr(ttl), Namespace: path.Base(moduleName)}
+	for _, f := range files {
+		if err := writeTemplateFile(kitLoader, kitName, "sessions/"+f+".tmpl", filepath.Join(dir, f), data); err != nil {
+			return fmt.Errorf("failed to generate shared/sessions/%s: %w", f, err)
+		}
+	}
+
+	if err := declareConfigVar(projectRoot, "SESSION_STORE", sessionStoreVar(withRedis)); err != nil {
+		return fmt.Errorf("failed to declare SESSION_STORE: %w", err)
+	}
+	if withRedis {
+		if err := declareConfigVar(projectRoot, "REDIS_URL", sessionsRedisURLVar); err != nil {
+			return fmt.Errorf("failed to declare REDIS_URL: %w", err)
+		}
+		if _, err := os.Stat(filepath.Join(projectRoot, "go.mod")); err == nil {
+			if output, err := runTool(projectRoot, "go", "get", "github.com/redis/go-redis/v9@latest"); err != nil {
+				logging.Warn("could not fetch the Redis client; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+			}
+		}
+	}
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectSessionsSetup(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to inject the session store setup into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// injectSessionsSetup picks the session store once the database is set up,
+// before the handlers' stores first read it.
+func injectSessionsSetup(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "sessions.Setup(") {
+		return nil // Already injected
+	}
+
+	anchor := "\t\tdatabase.CloseDB()\n\t\treturn nil\n\t})\n"
+	idx := strings.Index(mainStr, anchor)
+	if idx < 0 {
+		return fmt.Errorf("could not find the database shutdown hook (expected %q)", "database.CloseDB()")
+	}
+	idx += len(anchor)
+	setup := "\n\t// Session store: SESSION_STORE picks the backend (see shared/sessions)\n" +
+		"\tif err := sessions.Setup(context.Background()); err != nil {\n" +
+		"\t\tslog.Error(\"Failed to set up the session store\", \"error\", err)\n" +
+		"\t\tos.Exit(1)\n" +
+		"\t}\n"
+	mainStr = mainStr[:idx] + setup + mainStr[idx:]
+
+	for _, imp := range []string{"\t\"context\"", fmt.Sprintf("\t\"%s/shared/sessions\"", moduleName)} {
+		if mainStr, err = injectImport(mainStr, imp); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
@@ -0,0 +1,262 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Sessions are kept in a live_sessions table for the TTL given.
+func TestGenerateSessions(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	if err := GenerateSessions(dir, "testmodule", 0, false); err == nil {
+		t.Error("zero TTL was accepted")
+	}
+	if err := GenerateSessions(dir, "testmodule", 30*time.Minute, false); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, SessionsPackage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "const TTL = 30 * time.Minute"; !strings.Contains(string(content), want) {
+		t.Errorf("sessions.go is missing %q", want)
+	}
+	migration := readMigration(t, dir, "live_sessions")
+	for _, want := range []string{"CREATE TABLE IF NOT EXISTS live_sessions", "PRIMARY KEY (page, group_id)", "-- +goose Down", "DROP TABLE IF EXISTS live_sessions"} {
+		if !strings.Contains(migration, want) {
+			t.Errorf("migration is missing %q", want)
+		}
+	}
+	queries, err := os.ReadFile(filepath.Join(dir, "database", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"-- name: GetLiveSession :one", "-- name: SaveLiveSession :exec", "ON CONFLICT (page, group_id) DO UPDATE", "-- name: DeleteExpiredLiveSessions :exec"} {
+		if !strings.Contains(string(queries), want) {
+			t.Errorf("queries.sql is missing %q", want)
+		}
+	}
+}
+
+func TestGenerateSessionsRedis(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	mainGoPath := filepath.Join(dir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+func main() {
+	lifecycle.OnShutdown("database", func(ctx context.Context) error {
+		database.CloseDB()
+		return nil
+	})
+
+	slog.Info("Starting")
+	os.Exit(0)
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeTestConfig(t, dir)
+
+	if err := GenerateSessions(dir, "example.com/shop", DefaultSessionTTL, true); err != nil {
+		t.Fatal(err)
+	}
+
+	typeCheckPackage(t, filepath.Join(dir, "shared", "sessions"))
+	if _, err := goparser.ParseFile(token.NewFileSet(), filepath.Join(dir, "shared", "sessions", "sessions_test.go"), nil, goparser.AllErrors); err != nil {
+		t.Errorf("sessions_test.go does not parse: %v", err)
+	}
+	redis, err := os.ReadFile(filepath.Join(dir, "shared", "sessions", "redis.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`backends["redis"] = openRedis`, `NewRedis(client, "shop")`} {
+		if !strings.Contains(string(redis), want) {
+			t.Errorf("redis.go is missing %q", want)
+		}
+	}
+
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainStr := string(content)
+	for _, want := range []string{`"context"`, `"example.com/shop/shared/sessions"`, "sessions.Setup(context.Background())"} {
+		if !strings.Contains(mainStr, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+	if strings.Index(mainStr, "sessions.Setup(") < strings.Index(mainStr, "database.CloseDB()") {
+		t.Error("the session store should be set up after the database shutdown hook is registered")
+	}
+
+	content, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{sessionStoreVar(true), sessionsRedisURLVar} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("config.go does not declare %s:\n%s", want, content)
+		}
+	}
+	if !strings.Contains(sessionStoreVar(true), `"redis"`) || strings.Contains(sessionStoreVar(false), `"redis"`) {
+		t.Error("SESSION_STORE should allow redis only with the Redis backend")
+	}
+}
+
+func TestResourceSessions(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	// Resources generated before gen sessions keep livetemplate's own store
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "sessions.New") || !strings.Contains(string(before), `json:"editing_id" lvt:"transient"`) {
+		t.Error("resource generated before gen sessions should keep its sessions in memory")
+	}
+
+	if err := GenerateSessions(dir, "testmodule", DefaultSessionTTL, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "app", "posts", "posts.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := string(content)
+	for _, want := range []string{
+		`"testmodule/shared/sessions"`,
+		`store := sessions.New[PostsState]("posts", controller)`,
+		`livetemplate.AsState(initialState), livetemplate.WithStore(store)`,
+		"func (c *PostsController) Restore(state PostsState, ctx *livetemplate.Context) (PostsState, error)",
+		"c.Queries.GetPostByID(dbCtx, state.EditingID)",
+	} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("posts handler is missing %q", want)
+		}
+	}
+	// The open edit form is part of the resumed session
+	if strings.Contains(handler, `json:"editing_id" lvt:"transient"`) {
+		t.Error("EditingID should not be transient when sessions are kept")
+	}
+	typeCheckPackage(t, filepath.Dir(path))
+
+	page, err := os.ReadFile(filepath.Join(dir, "app", "posts", "posts.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := strings.Index(string(page), `{{template "sessionsScript" .}}`)
+	client := strings.Index(string(page), `livetemplate-client.js`)
+	if !strings.Contains(string(page), `{{define "sessionsScript"}}`) || script < 0 || script > client {
+		t.Error("posts template should render sessionsScript before the client script")
+	}
+	if _, err := template.New("page").Parse(string(page)); err != nil {
+		t.Errorf("posts template does not parse: %v", err)
+	}
+}
+
+func TestPagesKeepSessions(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			dir := t.TempDir()
+			setupTestProject(t, dir)
+			setProjectKit(t, dir, kit)
+			if err := GenerateSessions(dir, "testmodule", DefaultSessionTTL, false); err != nil {
+				t.Fatal(err)
+			}
+			generateTestPages(t, dir, kit)
+
+			for page, state := range map[string]string{"dashboard": "DashboardState", "lobby": "LobbyState", "board": "BoardState", "signup": "SignupState"} {
+				content, err := os.ReadFile(filepath.Join(dir, "app", page, page+".go"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, want := range []string{
+					`store := sessions.New[` + state + `]("` + page + `", controller)`,
+					`livetemplate.WithStore(store)`,
+				} {
+					if !strings.Contains(string(content), want) {
+						t.Errorf("%s handler is missing %q", page, want)
+					}
+				}
+				typeCheckPackage(t, filepath.Join(dir, "app", page))
+
+				tmpl, err := os.ReadFile(filepath.Join(dir, "app", page, page+".tmpl"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(tmpl), `{{template "sessionsScript" .}}`) {
+					t.Errorf("%s template does not render sessionsScript", page)
+				}
+				if _, err := template.New("page").Parse(string(tmpl)); err != nil {
+					t.Errorf("%s template does not parse: %v", page, err)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthSessions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := GenerateApp("shop", "shop", "multi", "tailwind", "", false); err != nil {
+		t.Fatalf("GenerateApp failed: %v", err)
+	}
+	if err := GenerateSessions("shop", "shop", DefaultSessionTTL, false); err != nil {
+		t.Fatal(err)
+	}
+	mainPath := filepath.Join("shop", "cmd", "shop", "main.go")
+	main, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(main), "sessions.Setup(context.Background())") {
+		t.Error("main.go does not set up the session store")
+	}
+	typeCheckPackage(t, filepath.Dir(mainPath))
+
+	if err := GenerateAuth("shop", &AuthConfig{ModuleName: "shop", EnablePassword: true, EnableMagicLink: true, EnableEmailConfirm: true, EnablePasswordReset: true}); err != nil {
+		t.Fatalf("GenerateAuth failed: %v", err)
+	}
+	handlerPath := filepath.Join("shop", "app", "auth", "auth.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"shop/shared/sessions"`,
+		`store := sessions.New[UserState]("auth", controller)`,
+		"livetemplate.WithStore(store)",
+		"Password      string `json:\"-\"`",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("auth handler is missing %q", want)
+		}
+	}
+	typeCheckPackage(t, filepath.Dir(handlerPath))
+}
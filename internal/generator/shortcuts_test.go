@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceKeyboardShortcuts(t *testing.T) {
+	fields, err := fieldparser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		kit, css string
+		kbd      string // the kit's styling of a shortcut key in the help overlay
+	}{
+		{"multi", "tailwind", `<kbd style="display: inline-block;`},
+		{"single", "tailwind", `<kbd style="display: inline-block;`},
+		{"daisyui", "daisyui", `<kbd class="kbd kbd-sm">`},
+	} {
+		t.Run(tt.kit, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			if err := GenerateResource(tmpDir, "testmodule", "tasks", fields, tt.kit, tt.css, "tailwind", "infinite", 20, "modal", "", false, false); err != nil {
+				t.Fatalf("GenerateResource failed: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(data)
+			for _, want := range []string{
+				`commandfor="add-modal" lvt-key="n" lvt-key-label="Add Task"`,
+				`name="query" lvt-key="/" lvt-key-label="Search"`,
+				`commandfor="add-modal" lvt-key="Escape" lvt-key-label="Close"`,
+				`<template id="lvt-shortcut-help">`,
+				`data-help-label="Show keyboard shortcuts"`,
+				tt.kbd,
+				"document.addEventListener('keydown'",
+			} {
+				if !strings.Contains(content, want) {
+					t.Errorf("template missing %s", want)
+				}
+			}
+			if _, err := template.New("page").Parse(content); err != nil {
+				t.Errorf("template does not parse: %v", err)
+			}
+		})
+	}
+}
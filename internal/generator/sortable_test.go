@@ -0,0 +1,111 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceSortable(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Sortable: true}, "title:string", "done:bool"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"func (c *TasksController) Reorder(",
+		`"-- name: ReorderTasks :exec\nUPDATE tasks SET position = CASE id"`,
+		`case "newest_first":`,
+		"return state.FilteredTasks[i].Position < state.FilteredTasks[j].Position",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	for file, wants := range map[string][]string{
+		"schema.sql": {
+			"position INTEGER NOT NULL DEFAULT 0",
+			"CREATE INDEX IF NOT EXISTS idx_tasks_position ON tasks(position);",
+		},
+		"queries.sql": {
+			"ORDER BY position, created_at DESC;",
+			"INSERT INTO tasks (id, title, done, position, created_at)\nVALUES (?, ?, ?, (SELECT COALESCE(MAX(position), 0) + 1 FROM tasks), ?)",
+		},
+	} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "database", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s missing %s", file, want)
+			}
+		}
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<tbody{{if eq .SortBy ""}} data-sortable{{end}}>`,
+		`{{if eq $.SortBy ""}}<td data-drag-handle draggable="true"`,
+		`<option value="newest_first" {{if eq .SortBy "newest_first"}}selected{{end}}>Newest First</option>`,
+		"window.liveTemplateClient.send({ action: 'reorder', data: { ids: ids } });",
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+}
+
+func TestResourceSortableWithFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Sortable: true, Filters: true}, "title:string"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+	handler, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`var tasksColumns = []string{"id", "title", "position", "created_at"}`,
+		"rows.Scan(&item.ID, &item.Title, &item.Position, &item.CreatedAt)",
+		`return "position ASC, created_at DESC"`,
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+}
+
+func TestResourceSortableUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+	fields, err := parser.ParseFields([]string{"title:string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateResource(tmpDir, "testmodule", "posts", fields, "multi", "tailwind", "tailwind", "cursor", 10, "modal", "", false, false, ResourceOptions{Sortable: true})
+	if err == nil || !strings.Contains(err.Error(), "cursor") {
+		t.Errorf("--sortable with cursor pagination: err = %v, want it rejected", err)
+	}
+}
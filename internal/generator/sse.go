@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// SSEData is the template data for the shared/sse package.
+type SSEData struct {
+	ModuleName string
+}
+
+// SSEPackage is the app package serving the event streams pages fall back
+// to without a WebSocket.
+const SSEPackage = "shared/sse/sse.go"
+
+// liveTransportVar declares the transport pages use for their updates.
+const liveTransportVar = `{Name: "LIVE_TRANSPORT", Type: TypeString, Default: "auto", Values: []string{"auto", "websocket", "sse"}, Description: "Live updates over WebSocket, falling back to server-sent events (auto), or over one of them only"}`
+
+// SSEEnabled reports whether `lvt gen sse` has been run in projectRoot.
+func SSEEnabled(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, SSEPackage))
+	return err == nil
+}
+
+// GenerateSSE creates the shared/sse package and declares LIVE_TRANSPORT.
+// Handlers generated afterwards are mounted with sse.Mount, and their pages
+// open its event stream when no WebSocket gets through, e.g. behind a proxy
+// that blocks them.
+func GenerateSSE(projectRoot, moduleName string) error {
+	defer track(projectRoot, "sse")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no SSE component; use the multi, single or daisyui kit")
+	}
+	if SSEEnabled(projectRoot) {
+		return fmt.Errorf("SSE fallback already set up (%s exists)", SSEPackage)
+	}
+
+	// 1. Create shared/sse
+	dir := filepath.Join(projectRoot, filepath.Dir(SSEPackage))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/sse directory: %w", err)
+	}
+	data := SSEData{ModuleName: moduleName}
+	if err := writeTemplateFile(kits.DefaultLoader(), kitName, "sse/sse.go.tmpl", filepath.Join(projectRoot, SSEPackage), data); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", SSEPackage, err)
+	}
+
+	// 2. Relay the streams' events to the app's other instances
+	if err := generatePubSubBridges(projectRoot, moduleName, kits.DefaultLoader(), kitName); err != nil {
+		return err
+	}
+
+	// 3. Declare LIVE_TRANSPORT
+	if err := declareConfigVar(projectRoot, "LIVE_TRANSPORT", liveTransportVar); err != nil {
+		return fmt.Errorf("failed to declare LIVE_TRANSPORT: %w", err)
+	}
+
+	// 4. Let the streams flush through the logging middleware of apps
+	// created before it could
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectUnwrap(mainGoPath); err != nil {
+			return fmt.Errorf("failed to update main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// injectUnwrap adds an Unwrap method to the responseWriter of main.go, so
+// http.ResponseController flushes event streams through it.
+func injectUnwrap(mainGoPath string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "func (rw *responseWriter) Unwrap()") {
+		return nil // Already there
+	}
+	anchor := "// Hijack implements http.Hijacker"
+	idx := strings.Index(mainStr, anchor)
+	if idx < 0 || !strings.Contains(mainStr, "type responseWriter struct") {
+		return nil // No logging responseWriter to unwrap
+	}
+	method := "// Unwrap returns the wrapped http.ResponseWriter, so http.ResponseController\n" +
+		"// reaches its Flush and SetWriteDeadline, e.g. for event streams\n" +
+		"func (rw *responseWriter) Unwrap() http.ResponseWriter {\n" +
+		"\treturn rw.ResponseWriter\n" +
+		"}\n\n"
+	mainStr = mainStr[:idx] + method + mainStr[idx:]
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
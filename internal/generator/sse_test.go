@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gen sse declares LIVE_TRANSPORT and lets the app's response writer be
+// unwrapped, so event streams can be flushed through it.
+func TestGenerateSSE(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	mainGoPath := filepath.Join(dir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+type responseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+// Hijack implements http.Hijacker to support WebSocket upgrades
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("responseWriter does not implement http.Hijacker")
+	}
+	return hijacker.Hijack()
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeTestConfig(t, dir)
+
+	if err := GenerateSSE(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(config), `{Name: "LIVE_TRANSPORT", Type: TypeString, Default: "auto"`) {
+		t.Errorf("config.go does not declare LIVE_TRANSPORT:\n%s", config)
+	}
+	main, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(main), "func (rw *responseWriter) Unwrap() http.ResponseWriter {") {
+		t.Error("main.go responseWriter has no Unwrap, so event streams cannot be flushed")
+	}
+	typeCheckPackage(t, filepath.Dir(mainGoPath))
+}
+
+func TestPagesMountSSE(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+
+	if err := generateCounterTestResource(t, dir, "notes", "body:string"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(filepath.Join(dir, "app", "notes", "notes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(before), "sse.Mount") {
+		t.Error("resource generated before gen sse should not be mounted with sse.Mount")
+	}
+
+	if err := GenerateSSE(dir, "testmodule"); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatal(err)
+	}
+	generateTestPages(t, dir, "multi")
+
+	for _, page := range []string{"posts", "dashboard", "lobby", "board", "signup"} {
+		path := filepath.Join(dir, "app", page, page+".go")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		handler := string(content)
+		for _, want := range []string{`"testmodule/shared/sse"`, "sse.Option(),", `return sse.Mount("` + page + `", http.HandlerFunc(`} {
+			if !strings.Contains(handler, want) {
+				t.Errorf("%s handler is missing %q", page, want)
+			}
+		}
+		typeCheckPackage(t, filepath.Dir(path))
+
+		tmpl, err := os.ReadFile(filepath.Join(dir, "app", page, page+".tmpl"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		script := strings.Index(string(tmpl), `{{template "sseScript" .}}`)
+		client := strings.Index(string(tmpl), `livetemplate-client`)
+		if !strings.Contains(string(tmpl), `{{define "sseScript"}}`) || script < 0 || script > client {
+			t.Errorf("%s template should render sseScript before the client script", page)
+		}
+		if _, err := template.New("page").Parse(string(tmpl)); err != nil {
+			t.Errorf("%s template does not parse: %v", page, err)
+		}
+	}
+}
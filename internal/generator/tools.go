@@ -0,0 +1,20 @@
+package generator
+
+import "os/exec"
+
+// SkipTools keeps generators from running go get and sqlc generate. lvt sets
+// it for --dry-run, which generates into a scratch copy of the project: the
+// tools would reach the network, and what they change is not what the
+// preview shows.
+var SkipTools bool
+
+// runTool runs name with args in dir and returns its combined output. It
+// does nothing when SkipTools is set.
+func runTool(dir, name string, args ...string) ([]byte, error) {
+	if SkipTools {
+		return nil, nil
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+func captureTrace(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logging.SetLevel(slog.LevelDebug)
+	logging.SetOutput(&buf, false)
+	t.Cleanup(func() {
+		logging.SetLevel(slog.LevelWarn)
+		logging.SetOutput(os.Stderr, false)
+	})
+	return &buf
+}
+
+func TestVerboseTraceGenResource(t *testing.T) {
+	dir := t.TempDir()
+	trace := captureTrace(t)
+
+	if err := generateCounterTestResource(t, dir, "posts", "title:string"); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	got := trace.String()
+	for _, want := range []string{
+		`msg="kit resolved" kit=multi source=system`,
+		`msg="template resolved" kit=multi template=resource/handler.go.tmpl path=embedded:system/multi/templates/resource/handler.go.tmpl`,
+		`msg="wrote file" path=` + filepath.Join(dir, "app", "posts", "posts.go"),
+		`msg="appended to file" path=` + filepath.Join(dir, "database", "schema.sql"),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trace missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestVerboseTraceRouteInjection(t *testing.T) {
+	mainGo := filepath.Join(t.TempDir(), "main.go")
+	src := "package main\n\nimport (\n\t\"net/http\"\n\n\t\"testapp/database\"\n)\n\nfunc main() {\n\t// TODO: Add routes here\n\thttp.ListenAndServe(\":8080\", nil)\n}\n"
+	if err := os.WriteFile(mainGo, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	route := RouteInfo{Path: "/posts", PackageName: "posts", HandlerCall: "posts.Handler(queries)", ImportPath: "testapp/app/posts"}
+
+	trace := captureTrace(t)
+	for range 2 {
+		if err := InjectRoute(mainGo, route); err != nil {
+			t.Fatalf("InjectRoute: %v", err)
+		}
+	}
+
+	got := trace.String()
+	for _, want := range []string{
+		`msg="injecting route"`,
+		`msg="adding route import after database import" import=testapp/app/posts`,
+		`msg="inserting route after TODO marker"`,
+		`msg="route already registered, skipping"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trace missing %q\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// TracingData is the template data for the shared/tracing package.
+type TracingData struct {
+	ModuleName string
+}
+
+// TracingPackage is the app package setting up OpenTelemetry tracing.
+const TracingPackage = "shared/tracing/tracing.go"
+
+// GenerateTracing sets up OpenTelemetry tracing in the app at projectRoot:
+// it creates shared/tracing, declares the OTEL_* variables it reads, sets
+// it up at the start of main and puts tracing.Middleware first in the
+// middleware chain.
+func GenerateTracing(projectRoot, moduleName string) error {
+	defer track(projectRoot, "tracing")()
+	projectConfig, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	kitName := projectConfig.GetKit()
+	if kitName == "simple" {
+		return fmt.Errorf("the simple kit has no database or middleware chain to trace; use the multi, single or daisyui kit")
+	}
+
+	tracingPath := filepath.Join(projectRoot, TracingPackage)
+	if _, err := os.Stat(tracingPath); err == nil {
+		return fmt.Errorf("tracing already set up (%s exists)", TracingPackage)
+	}
+
+	// 1. Create shared/tracing
+	kitLoader := kits.DefaultLoader()
+	tracingDir := filepath.Dir(tracingPath)
+	if err := os.MkdirAll(tracingDir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared/tracing directory: %w", err)
+	}
+	data := TracingData{ModuleName: moduleName}
+	for _, f := range []string{"tracing.go", "tracing_test.go"} {
+		if err := writeTemplateFile(kitLoader, kitName, "tracing/"+f+".tmpl", filepath.Join(tracingDir, f), data); err != nil {
+			return fmt.Errorf("failed to generate shared/tracing/%s: %w", f, err)
+		}
+	}
+
+	// 2. Declare the variables shared/tracing reads
+	vars := []struct{ name, decl string }{
+		{"OTEL_EXPORTER_OTLP_ENDPOINT", `{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Type: TypeString, Description: "OTLP/HTTP collector URL, e.g. http://localhost:4318; spans are not exported when unset"}`},
+		{"OTEL_SERVICE_NAME", fmt.Sprintf(`{Name: "OTEL_SERVICE_NAME", Type: TypeString, Default: %q, Description: "Service name of the app's spans"}`, path.Base(moduleName))},
+	}
+	for _, v := range vars {
+		if err := declareConfigVar(projectRoot, v.name, v.decl); err != nil {
+			return fmt.Errorf("failed to declare %s: %w", v.name, err)
+		}
+	}
+
+	// 3. Add the OpenTelemetry SDK and OTLP exporter
+	goModPath := filepath.Join(projectRoot, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		dependencies := []string{
+			"go.opentelemetry.io/otel@latest",
+			"go.opentelemetry.io/otel/sdk@latest",
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp@latest",
+		}
+		if output, err := runTool(projectRoot, "go", append([]string{"get"}, dependencies...)...); err != nil {
+			logging.Warn("could not fetch OpenTelemetry dependencies; run 'go mod tidy' to resolve", "dir", projectRoot, "output", strings.TrimSpace(string(output)))
+		}
+	}
+
+	// 4. Set up tracing and trace requests in main.go
+	if mainGoPath := findMainGo(projectRoot); mainGoPath != "" {
+		if err := injectTracing(mainGoPath, moduleName); err != nil {
+			return fmt.Errorf("failed to inject tracing into main.go: %w", err)
+		}
+	}
+	return nil
+}
+
+// injectTracing sets tracing up once the logger is, before the database
+// opens, and adds tracing.Middleware as the outermost middleware. Request
+// logs are written with the request context so they carry its trace ID.
+func injectTracing(mainGoPath, moduleName string) error {
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read main.go: %w", err)
+	}
+	mainStr := string(content)
+	if strings.Contains(mainStr, "tracing.Middleware") {
+		return nil // Already injected
+	}
+
+	anchor := "\tslog.SetDefault(logger)\n"
+	idx := strings.Index(mainStr, anchor)
+	if idx < 0 {
+		return fmt.Errorf("could not find the logger setup (expected %q)", strings.TrimSpace(anchor))
+	}
+	idx += len(anchor)
+	setup := "\n\t// OpenTelemetry tracing (see shared/tracing)\n" +
+		"\tif err := tracing.Setup(context.Background()); err != nil {\n" +
+		"\t\tslog.Error(\"Failed to set up tracing\", \"error\", err)\n" +
+		"\t\tos.Exit(1)\n" +
+		"\t}\n"
+	mainStr = mainStr[:idx] + setup + mainStr[idx:]
+
+	chain := "\thandler := chainMiddleware(http.DefaultServeMux,"
+	idx = strings.Index(mainStr, chain)
+	if idx < 0 {
+		return fmt.Errorf("could not find the middleware chain (expected %q)", strings.TrimSpace(chain))
+	}
+	idx += len(chain)
+	mainStr = mainStr[:idx] + "\n\t\ttracing.Middleware(http.DefaultServeMux)," + mainStr[idx:]
+
+	// Apps generated before request logs took the request context
+	mainStr = strings.NewReplacer(
+		`slog.Info("HTTP request", attrs...)`, `slog.InfoContext(r.Context(), "HTTP request", attrs...)`,
+		`slog.Warn("Slow HTTP request", attrs...)`, `slog.WarnContext(r.Context(), "Slow HTTP request", attrs...)`,
+	).Replace(mainStr)
+
+	for _, imp := range []string{"\t\"context\"", fmt.Sprintf("\t\"%s/shared/tracing\"", moduleName)} {
+		if mainStr, err = injectImport(mainStr, imp); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(mainGoPath, []byte(mainStr), 0644)
+}
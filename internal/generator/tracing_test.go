@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTracing(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	mainGoPath := filepath.Join(tmpDir, "cmd", "app", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGoPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	handler := chainMiddleware(http.DefaultServeMux,
+		loggingMiddleware,
+	)
+	http.ListenAndServe(":8080", handler)
+}
+
+func loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+		var attrs []any
+		slog.Info("HTTP request", attrs...)
+	})
+}
+`
+	if err := os.WriteFile(mainGoPath, []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(tmpDir, "shared", "config", "config.go")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configGo := "package config\n\nvar Schema = []Var{\n\t{Name: \"PORT\", Type: TypeInt, Default: \"8080\"},\n}\n"
+	if err := os.WriteFile(configPath, []byte(configGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GenerateTracing(tmpDir, "example.com/shop"); err != nil {
+		t.Fatalf("GenerateTracing failed: %v", err)
+	}
+
+	for _, f := range []string{"tracing.go", "tracing_test.go"} {
+		path := filepath.Join(tmpDir, "shared", "tracing", f)
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+			t.Errorf("%s does not parse: %v", f, err)
+		}
+	}
+
+	content, err := os.ReadFile(mainGoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainStr := string(content)
+	for _, want := range []string{
+		`"context"`,
+		`"example.com/shop/shared/tracing"`,
+		"chainMiddleware(http.DefaultServeMux,\n\t\ttracing.Middleware(http.DefaultServeMux),\n\t\tloggingMiddleware,",
+		`slog.InfoContext(r.Context(), "HTTP request", attrs...)`,
+	} {
+		if !strings.Contains(mainStr, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+	if setup := strings.Index(mainStr, "tracing.Setup("); setup < strings.Index(mainStr, "slog.SetDefault(logger)") {
+		t.Error("tracing should be set up after the logger")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), mainGoPath, content, 0); err != nil {
+		t.Errorf("main.go does not parse: %v", err)
+	}
+
+	content, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`Name: "OTEL_EXPORTER_OTLP_ENDPOINT"`, `Name: "OTEL_SERVICE_NAME", Type: TypeString, Default: "shop"`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("config.go missing %s", want)
+		}
+	}
+
+	if err := GenerateTracing(tmpDir, "example.com/shop"); err == nil {
+		t.Error("GenerateTracing should refuse to run twice")
+	}
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"slices"
 	"strings"
 	"text/template"
 
@@ -22,10 +23,14 @@ func FieldDataFromFields(fields []parser.Field) []FieldData {
 			ReferencedTable: f.ReferencedTable,
 			OnDelete:        f.OnDelete,
 			IsTextarea:      f.IsTextarea,
+			IsJSON:          f.IsJSON,
+			IsRichText:      f.IsRichText,
 			IsSelect:        f.IsSelect,
 			SelectOptions:   f.SelectOptions,
 			IsFile:          f.IsFile,
 			IsImage:         f.IsImage,
+			Default:         f.Default,
+			HasDefault:      f.HasDefault,
 			FieldMetadata:   f.Metadata,
 		}
 	}
@@ -44,7 +49,8 @@ type ResourceData struct {
 	Kit                  *kits.KitInfo  // CSS framework kit (new)
 	CSSFramework         string         // CSS framework name: "tailwind", "bulma", "pico", "none" (for backward compatibility)
 	DevMode              bool           // Use local client library instead of CDN
-	PaginationMode       string         // Pagination mode: "infinite", "load-more", "prev-next", "numbers"
+	Theme                string         // Default daisyUI theme from .lvtrc ("" follows the browser)
+	PaginationMode       string         // Pagination mode: "infinite", "load-more", "prev-next", "numbers", "cursor"
 	PageSize             int            // Page size for pagination
 	EditMode             string         // Edit mode: "modal", "page"
 	Components           ComponentUsage // Which UI components this resource uses
@@ -57,6 +63,87 @@ type ResourceData struct {
 	// Authorization (set when --with-authz is used)
 	WithAuthz bool // True when generating with ownership tracking and permission checks
 
+	// Audit trail (set when `lvt gen audit` has been run)
+	WithAudit bool // True when create/update/delete are recorded in audit_logs
+	HasAuth   bool // True when the app has `lvt gen auth`, so audit entries can name the user
+
+	// Translations (set when `lvt gen i18n` has been run)
+	WithI18n bool // True when templates translate their text with T and app/i18n
+
+	// Notifications (set when `lvt gen notifications` has been run)
+	WithNotifications bool // True when the handler reports with lvt.Notify and the page renders the notifications component
+
+	// Sessions (set when `lvt gen sessions` has been run)
+	WithSessions bool // True when the handler keeps its sessions in shared/sessions and the page replays drafts on reconnect
+
+	// Offline actions (set when `lvt gen offline` has been run)
+	WithOffline bool // True when the page queues actions while disconnected and the handler skips replayed ones with offline.Seen
+
+	// SSE fallback (set when `lvt gen sse` has been run)
+	WithSSE bool // True when the handler is mounted with shared/sse and the page falls back to its event stream without a WebSocket
+
+	// Compressed updates (set when `lvt gen compression` has been run)
+	WithCompression bool // True when the handler accepts permessage-deflate through shared/compression
+
+	// Static renders (set when `lvt gen prerender` has been run)
+	WithPrerender bool // True when the handler is mounted with shared/prerender and static renders page with links
+
+	// Search engine metadata (set when `lvt gen seo` has been run)
+	WithSEO bool // True when the page renders its Meta tags and the handler registers its pages for the sitemap
+
+	// CSRF tokens (set when the app has shared/csrf, which `lvt new` generates)
+	WithCSRF bool // True when forms render the visitor's token in a hidden _csrf field
+
+	// Content-Security-Policy nonces (set when `lvt gen security-headers` has been run)
+	WithSecurityHeaders bool // True when inline scripts carry the cspNonce of shared/security and no inline event handlers are rendered
+
+	// Change events (set when --emit-events is used)
+	EmitEvents bool // True when create/update/delete publish events through app/events
+
+	// List caching (set when --cache and --render-cache are used)
+	WithCache       bool // True when the filtered, sorted list is cached through shared/cache
+	WithRenderCache bool // True when read actions reuse the states cached by shared/rendercache
+
+	// Filter builder (set when --filters is used)
+	WithFilters bool // True when the toolbar filters the list and the database searches, filters and sorts it
+
+	// Saved views (set when --saved-views is used)
+	WithSavedViews bool // True when users can save the list's search, filters, sort and page size as named views
+
+	// Manual order (set when --sortable is used)
+	Sortable bool // True when rows have a position column and drag handles to reorder them
+
+	// Undo after delete (set when --undo is used)
+	WithUndo bool // True when a deleted record can be restored from the undo notice for a few seconds
+
+	// Presence (set when --presence is used)
+	WithPresence bool // True when the page shows who else has it open and which rows they are editing
+
+	// Edit conflicts (set when --conflicts is used)
+	WithConflicts bool // True when a save over someone else's concurrent change is held back for the user to reload or overwrite
+
+	// Authorization hooks (set when --policy is used)
+	WithPolicy bool // True when the handler asks the functions of the resource's policy.go before each action
+
+	// Lifecycle hooks (set when --hooks is used)
+	WithHooks bool // True when the handler calls the functions of the resource's <resource>_hooks.go around each write
+
+	// Record IDs (set by --id or id= in .lvtrc)
+	IDType string // "uuid", "ulid", or "" for "<resource>-<unix nanos>" IDs
+
+	// Actions selects the generated CRUD actions (set by --actions / --readonly)
+	Actions ResourceActions
+
+	// Counter caches (set by name:counter(table) fields)
+	Counters        []CounterData // Counter columns on this table
+	CounterTriggers []CounterData // Counters whose sync triggers are created by this migration
+
+	// Indexes declared with --index (composite, unique or partial)
+	Indexes []IndexData
+
+	// Generated columns (set by name:string:generated(func source) fields)
+	Generated []GeneratedData
+
 	// Embedded child resource fields (set when --parent is used)
 	ParentResource         string // Parent resource name, lowercase plural (e.g., "posts"). Empty = standalone.
 	ParentPackageName      string // Parent package name (e.g., "posts")
@@ -65,6 +152,51 @@ type ResourceData struct {
 	IsEmbedded             bool   // True when generating as embedded child
 }
 
+// NeedsAuthenticator reports whether the handler identifies the user from
+// the auth session cookie: for ownership checks, to attribute audit entries,
+// to keep each user's saved views, to name them to other viewers, or to
+// pass them to the policy functions.
+func (d ResourceData) NeedsAuthenticator() bool {
+	return d.WithAuthz || ((d.WithAudit || d.WithSavedViews || d.WithPresence || d.WithPolicy) && d.HasAuth)
+}
+
+// Translated reports whether the generated template translates its text.
+func (d ResourceData) Translated() bool {
+	return d.WithI18n
+}
+
+// TracksChanges reports whether write actions load the record before and
+// after the change, for the audit log, change events or lifecycle hooks.
+func (d ResourceData) TracksChanges() bool {
+	return d.WithAudit || d.EmitEvents || d.WithHooks
+}
+
+// TracksRestores reports whether UndoDelete uses the record it restores, for
+// the audit log, change events or the AfterCreate hook.
+func (d ResourceData) TracksRestores() bool {
+	return d.WithAudit || d.EmitEvents || (d.WithHooks && d.Actions.Create)
+}
+
+// UsesNotify reports whether the handler calls lvt.Notify: the write actions,
+// reordering rows and saving a view report with it.
+func (d ResourceData) UsesNotify() bool {
+	return d.WithNotifications && (!d.Actions.ReadOnly() || d.Sortable || d.WithSavedViews)
+}
+
+// NewIDExpr returns the Go expression generating a record ID from app/ids,
+// or "" when the handler builds the default "<resource>-<unix nanos>" ID.
+func (d ResourceData) NewIDExpr() string {
+	return newIDExpr(d.IDType)
+}
+
+// IDValidateTag returns the validate tag for record IDs submitted by forms.
+func (d ResourceData) IDValidateTag() string {
+	if d.IDType == "" {
+		return "required"
+	}
+	return "required," + d.IDType
+}
+
 // NonReferenceFields returns fields excluding the parent reference field.
 // Used in embedded templates to omit the parent FK from forms.
 func (d ResourceData) NonReferenceFields() []FieldData {
@@ -92,11 +224,63 @@ func (d ResourceData) NonFileFields() []FieldData {
 	return result
 }
 
-// SearchableFields returns string fields suitable for FTS indexing (excludes file/image/reference).
+// SearchableFields returns string fields suitable for FTS indexing (excludes file/image/reference/JSON).
 func (d ResourceData) SearchableFields() []FieldData {
 	var result []FieldData
 	for _, f := range d.NonFileFields() {
-		if f.GoType == "string" && !f.IsReference {
+		if f.GoType == "string" && !f.IsReference && !f.IsJSON {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// FilterFields returns the fields the toolbar filters on: select fields by
+// option, booleans by yes/no and times by date range, then created_at.
+func (d ResourceData) FilterFields() []FieldData {
+	var result []FieldData
+	for _, f := range d.NonFileFields() {
+		if f.IsSelect || f.GoType == "bool" || f.GoType == "time.Time" {
+			result = append(result, f)
+		}
+	}
+	return append(result, FieldData{Name: "created_at", GoType: "time.Time", SQLType: "DATETIME"})
+}
+
+// PageSizeOptions returns the page sizes the toolbar offers: 10, 25, 50 and
+// 100, plus the generated page size if it isn't one of them.
+func (d ResourceData) PageSizeOptions() []int {
+	sizes := []int{10, 25, 50, 100}
+	if d.PageSize < 1 || d.PageSize > 100 || slices.Contains(sizes, d.PageSize) {
+		return sizes
+	}
+	sizes = append(sizes, d.PageSize)
+	slices.Sort(sizes)
+	return sizes
+}
+
+// JSONFields returns only JSON document fields.
+func (d ResourceData) JSONFields() []FieldData {
+	var result []FieldData
+	for _, f := range d.Fields {
+		if f.IsJSON {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// RichTextFields returns only rich-text fields.
+func (d ResourceData) RichTextFields() []FieldData {
+	return richTextFields(d.Fields)
+}
+
+// richTextFields returns the fields holding HTML, which app/richtext
+// sanitizes before they are stored.
+func richTextFields(fields []FieldData) []FieldData {
+	var result []FieldData
+	for _, f := range fields {
+		if f.IsRichText {
 			result = append(result, f)
 		}
 	}
@@ -122,10 +306,14 @@ type FieldData struct {
 	ReferencedTable      string
 	OnDelete             string
 	IsTextarea           bool     // true if field should render as textarea
+	IsJSON               bool     // true if field holds a JSON document (rendered as a textarea)
+	IsRichText           bool     // true if field holds HTML, edited with the rich-text editor and sanitized by app/richtext
 	IsSelect             bool     // true if field should render as <select>
 	SelectOptions        []string // options for select fields
 	IsFile               bool     // true if field is a file upload
 	IsImage              bool     // true if field is an image upload (subset of file)
+	Default              string   // default value (name:type=value); see SQLDefault and GoDefault
+	HasDefault           bool     // true if the field has a default
 	parser.FieldMetadata          // validation + HTML rendering metadata (embedded)
 }
 
@@ -134,6 +322,7 @@ type AppData struct {
 	ModuleName   string
 	Kit          *kits.KitInfo // CSS framework kit (new)
 	DevMode      bool          // Use local client library instead of CDN
+	Theme        string        // Default daisyUI theme (lvt new --theme)
 	CSSFramework string        // CSS framework name for home page (for backward compatibility)
 	Styles       string        // Style adapter: "tailwind", "unstyled"
 }
@@ -145,6 +334,14 @@ var funcMap = template.FuncMap{
 	"camelCase":    toCamelCase,
 	"displayField": getDisplayField,
 	"singularize":  singularizeForTemplate,
+	// daisyuiThemes lists the themes the daisyui kit's theme switcher offers
+	"daisyuiThemes": func() []string { return kits.DaisyUIThemes },
+	// icon renders a kit icon as inline SVG; without a kit there are none
+	"icon": func(name string, class ...string) string { return "" },
+	// T, runtime and lang write user-facing text; see i18n.go
+	"T":       plainText,
+	"runtime": func(expr string) runtimeArg { return runtimeArg(expr) },
+	"lang":    func() string { return "en" },
 }
 
 // singularizeForTemplate wraps singularize for use in templates.
@@ -204,9 +401,9 @@ func getDisplayField(fields []FieldData) FieldData {
 		}
 	}
 
-	// Prefer the first non-reference, non-file string field (most likely human-readable)
+	// Prefer the first non-reference, non-file, non-JSON, non-HTML string field (most likely human-readable)
 	for _, field := range fields {
-		if !field.IsReference && !field.IsFile && field.GoType == "string" {
+		if !field.IsReference && !field.IsFile && !field.IsJSON && !field.IsRichText && field.GoType == "string" {
 			return field
 		}
 	}
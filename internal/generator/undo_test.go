@@ -0,0 +1,96 @@
+package generator
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func TestResourceUndo(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir)
+
+	if err := generateEventsTestResource(t, tmpDir, "tasks", ResourceOptions{Undo: true}, "title:string", "done:bool"); err != nil {
+		t.Fatalf("GenerateResource failed: %v", err)
+	}
+
+	handlerPath := filepath.Join(tmpDir, "app", "tasks", "tasks.go")
+	handler, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), handlerPath, handler, goparser.AllErrors); err != nil {
+		t.Fatalf("handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"func (c *TasksController) UndoDelete(",
+		"func (c *TasksController) DismissToastUndo(",
+		"const undoWindow = 10 * time.Second",
+		"UndoWindowMS:   undoWindow.Milliseconds(),",
+		"state.DeletedTasks = &before",
+		"ID:        deleted.ID,",
+		"Title: deleted.Title,",
+		"CreatedAt: deleted.CreatedAt,",
+	} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`{{template "undoBar" .}}`,
+		`data-toast="undo-{{.ID}}" data-auto-dismiss="{{$.UndoWindowMS}}"`,
+		`name="undo_delete"`,
+		`name="dismiss_toast_undo"`,
+	} {
+		if !strings.Contains(string(tmpl), want) {
+			t.Errorf("template missing %s", want)
+		}
+	}
+	if strings.Contains(string(tmpl), "cannot be undone") {
+		t.Error("delete confirmation should not say the delete cannot be undone")
+	}
+
+	test, err := os.ReadFile(filepath.Join(tmpDir, "app", "tasks", "tasks_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(test), `"action": "undo_delete",`) {
+		t.Error("WebSocket test does not undo the delete")
+	}
+}
+
+func TestResourceUndoUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		actions *ResourceActions
+		want    string
+	}{
+		{"file field", []string{"title:string", "cover:image"}, nil, "file fields"},
+		{"no delete", []string{"title:string"}, &ResourceActions{Create: true}, "delete action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir)
+			fields, err := parser.ParseFields(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = GenerateResource(tmpDir, "testmodule", "posts", fields, "multi", "tailwind", "tailwind", "infinite", 20, "modal", "", false, false, ResourceOptions{Undo: true, Actions: tt.actions})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("--undo with %s: err = %v, want it rejected", tt.name, err)
+			}
+		})
+	}
+}
@@ -8,21 +8,74 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/livetemplate/lvt/components"
+	"github.com/livetemplate/lvt/internal/appfuncs"
 )
 
 // lineNumberPattern matches Go template parse error positions like "template: name:5:" or "template: name:5:22:"
 var lineNumberPattern = regexp.MustCompile(`template:.*?:(\d+)`)
 
+// runtimeFuncs stands in for the functions generated apps register on their
+// templates, so parsing accepts calls to them and previews can render.
+var runtimeFuncs = template.FuncMap{
+	"T":             untranslated,                              // app/i18n
+	"localeOptions": func(current string) []any { return nil }, // app/i18n
+	"cspNonce":      func() string { return "" },               // shared/security
+	"richText":      func(s string) string { return s },        // app/richtext; escaped in previews
+	"richTextPlain": func(s string) string { return s },        // app/richtext
+}
+
+// TemplateFuncs returns the functions generated code registers on the
+// templates of the app at projectRoot: the app's own from shared/funcs, the
+// component library's, the app/i18n ones once `lvt gen i18n` has run,
+// shared/security's once `lvt gen security-headers` has and app/richtext's
+// once a richtext field has been generated.
+func TemplateFuncs(projectRoot string) template.FuncMap {
+	funcs := appfuncs.Stubs(projectRoot)
+	for _, set := range components.All() {
+		for name, fn := range set.Funcs {
+			funcs[name] = fn
+		}
+	}
+	if I18nEnabled(projectRoot) {
+		funcs["T"] = runtimeFuncs["T"]
+		funcs["localeOptions"] = runtimeFuncs["localeOptions"]
+	}
+	if SecurityHeadersEnabled(projectRoot) {
+		funcs["cspNonce"] = runtimeFuncs["cspNonce"]
+	}
+	if RichTextEnabled(projectRoot) {
+		funcs["richText"] = runtimeFuncs["richText"]
+		funcs["richTextPlain"] = runtimeFuncs["richTextPlain"]
+	}
+	return funcs
+}
+
+// untranslated stands in for i18n.T, formatting the source text.
+func untranslated(locale, text string, args ...any) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
 // ValidateTemplate parses a generated .tmpl file and returns a clear error
 // if the template contains syntax errors. This catches issues at generation
 // time rather than at runtime.
 func ValidateTemplate(path string) error {
+	return ValidateTemplateFuncs(path, runtimeFuncs)
+}
+
+// ValidateTemplateFuncs is ValidateTemplate with funcs as the only
+// functions besides the builtins, so calls of any other function fail.
+func ValidateTemplateFuncs(path string, funcs template.FuncMap) error {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("failed to read template %s: %w", path, err)
 	}
 
-	_, err = template.New(filepath.Base(path)).Parse(string(content))
+	_, err = template.New(filepath.Base(path)).Funcs(funcs).Parse(string(content))
 	if err != nil {
 		return formatTemplateError(path, string(content), err)
 	}
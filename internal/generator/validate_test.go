@@ -118,6 +118,30 @@ func TestValidateTemplate_UnclosedAction(t *testing.T) {
 	}
 }
 
+func TestValidateTemplateFuncs(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.tmpl")
+	if err := os.WriteFile(path, []byte(`<p>{{sub 3 1}} {{T .Locale "Hello"}}</p>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// T is only registered once translations are set up
+	err := ValidateTemplateFuncs(path, TemplateFuncs(dir))
+	if err == nil || !strings.Contains(err.Error(), `function "T" not defined`) {
+		t.Fatalf("expected T to be undefined, got: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "app", "i18n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, i18nPackagePath), []byte("package i18n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateTemplateFuncs(path, TemplateFuncs(dir)); err != nil {
+		t.Errorf("expected component and i18n functions to be defined, got: %v", err)
+	}
+}
+
 func TestExtractLineNumber(t *testing.T) {
 	tests := []struct {
 		name     string
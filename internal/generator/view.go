@@ -1,27 +1,75 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
 type ViewData struct {
-	PackageName   string
-	ModuleName    string
-	ViewName      string
-	ViewNameLower string
-	Kit           *kits.KitInfo // CSS framework kit (new)
-	CSSFramework  string        // CSS framework: "tailwind", "bulma", "pico", "none" (for backward compatibility)
-	DevMode       bool          // Use local client library instead of CDN
+	PackageName         string
+	ModuleName          string
+	ViewName            string
+	ViewNameLower       string
+	Kit                 *kits.KitInfo // CSS framework kit (new)
+	CSSFramework        string        // CSS framework: "tailwind", "bulma", "pico", "none" (for backward compatibility)
+	DevMode             bool          // Use local client library instead of CDN
+	Theme               string        // Default daisyUI theme from .lvtrc
+	Pattern             string        // Example pattern scaffolded with --pattern; "" for a bare view
+	WithNotifications   bool          // Page renders the notifications component (lvt gen notifications)
+	WithSessions        bool          // Sessions survive restarts in shared/sessions (lvt gen sessions)
+	WithOffline         bool          // Actions fired offline are queued and replayed (lvt gen offline)
+	WithSSE             bool          // Updates fall back to server-sent events (lvt gen sse)
+	WithCompression     bool          // Updates are deflated over the WebSocket (lvt gen compression)
+	WithPrerender       bool          // Crawlers and ?static=1 get a static render (lvt gen prerender)
+	WithSecurityHeaders bool          // Inline scripts carry the Content-Security-Policy nonce (lvt gen security-headers)
 }
 
-func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework string) error {
+// ViewPatterns are the example patterns `lvt gen view --pattern` scaffolds.
+var ViewPatterns = []string{"counter", "form", "wizard", "chat", "kanban"}
+
+// ValidateViewPattern returns an error unless pattern is "" or one of
+// ViewPatterns.
+func ValidateViewPattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	for _, p := range ViewPatterns {
+		if p == pattern {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid view pattern: %q (valid: %s)", pattern, strings.Join(ViewPatterns, ", "))
+}
+
+// ViewOptions holds the optional settings of GenerateView.
+type ViewOptions struct {
+	// Pattern scaffolds an interactive example (see ViewPatterns) instead of
+	// a bare handler: the kit's view/patterns/<pattern>.go.tmpl replaces the
+	// handler, and view/patterns/<pattern>.tmpl.tmpl defines the "pattern"
+	// template the page renders.
+	Pattern string
+}
+
+func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework string, opts ...ViewOptions) error {
+	if err := checkAppPackage("view", viewName); err != nil {
+		return err
+	}
+	defer track(basePath, "view "+strings.ToLower(viewName))()
+	var options ViewOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if err := ValidateViewPattern(options.Pattern); err != nil {
+		return err
+	}
 	// Load kit using KitLoader
 	kitLoader := kits.DefaultLoader()
 	kit, err := kitLoader.Load(kitName)
@@ -35,6 +83,9 @@ func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework s
 			return fmt.Errorf("failed to load CSS helpers for framework %q: %w", cssFramework, err)
 		}
 	}
+	if err := copyKitAssets(basePath, kit); err != nil {
+		return err
+	}
 
 	// Ensure view name is capitalized
 	viewName = cases.Title(language.English).String(viewName)
@@ -44,13 +95,22 @@ func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework s
 	devMode := ReadDevMode(basePath)
 
 	data := ViewData{
-		PackageName:   viewNameLower,
-		ModuleName:    moduleName,
-		ViewName:      viewName,
-		ViewNameLower: viewNameLower,
-		Kit:           kit,
-		CSSFramework:  cssFramework, // Keep for backward compatibility
-		DevMode:       devMode,
+		PackageName:         viewNameLower,
+		ModuleName:          moduleName,
+		ViewName:            viewName,
+		ViewNameLower:       viewNameLower,
+		Kit:                 kit,
+		CSSFramework:        cssFramework, // Keep for backward compatibility
+		DevMode:             devMode,
+		Theme:               ReadTheme(basePath),
+		Pattern:             options.Pattern,
+		WithNotifications:   NotificationsEnabled(basePath),
+		WithSessions:        SessionsEnabled(basePath),
+		WithOffline:         OfflineEnabled(basePath),
+		WithSSE:             SSEEnabled(basePath),
+		WithCompression:     CompressionEnabled(basePath),
+		WithPrerender:       PrerenderEnabled(basePath),
+		WithSecurityHeaders: SecurityHeadersEnabled(basePath),
 	}
 
 	// Create view directory
@@ -60,7 +120,11 @@ func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework s
 	}
 
 	// Read templates using kit loader (checks project kits, user kits, then embedded)
-	handlerTmpl, err := kitLoader.LoadKitTemplate(kitName, "view/handler.go.tmpl")
+	handlerPath := "view/handler.go.tmpl"
+	if data.Pattern != "" {
+		handlerPath = "view/patterns/" + data.Pattern + ".go.tmpl"
+	}
+	handlerTmpl, err := kitLoader.LoadKitTemplate(kitName, handlerPath)
 	if err != nil {
 		return fmt.Errorf("failed to read handler template: %w", err)
 	}
@@ -69,6 +133,22 @@ func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework s
 	if err != nil {
 		return fmt.Errorf("failed to read template template: %w", err)
 	}
+	if data.Pattern != "" {
+		// The page renders the pattern's markup in place of its placeholder
+		if !strings.Contains(string(templateTmpl), `[[template "pattern" .]]`) {
+			return fmt.Errorf("the %s kit's view/template.tmpl.tmpl does not render patterns: add [[template \"pattern\" .]] to its content", kitName)
+		}
+		patternTmpl, err := kitLoader.LoadKitTemplate(kitName, "view/patterns/"+data.Pattern+".tmpl.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to read %s pattern template: %w", data.Pattern, err)
+		}
+		templateTmpl = bytes.Join([][]byte{templateTmpl, bytes.TrimSpace(patternTmpl)}, nil)
+	}
+	page, err := withComponents(kitLoader, kitName, string(templateTmpl), featureComponents(data.WithNotifications, data.WithSessions, data.WithOffline, data.WithSSE)...)
+	if err != nil {
+		return err
+	}
+	templateTmpl = []byte(page)
 
 	testTmpl, err := kitLoader.LoadKitTemplate(kitName, "view/test.go.tmpl")
 	if err != nil {
@@ -79,6 +159,9 @@ func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework s
 	if err := generateFile(string(handlerTmpl), data, filepath.Join(viewDir, viewNameLower+".go"), kit); err != nil {
 		return fmt.Errorf("failed to generate handler: %w", err)
 	}
+	if err := generateFuncs(basePath, kitLoader, kitName); err != nil {
+		return err
+	}
 
 	// Generate template and validate it parses correctly
 	tmplPath := filepath.Join(viewDir, viewNameLower+".tmpl")
@@ -105,15 +188,13 @@ func GenerateView(basePath, moduleName, viewName string, kitName, cssFramework s
 		}
 		if err := InjectRoute(mainGoPath, route); err != nil {
 			// Log warning but don't fail - user can add route manually
-			fmt.Printf("⚠️  Could not auto-inject route: %v\n", err)
-			fmt.Printf("   Please add manually: http.Handle(\"/%s\", %s.Handler())\n",
-				viewNameLower, viewNameLower)
+			logging.Warn("could not add the route to main.go; add it manually", "route", fmt.Sprintf("http.Handle(\"/%s\", %s.Handler())", viewNameLower, viewNameLower), "error", err)
 		}
 	}
 
 	// Register view for home page
 	if err := RegisterResource(basePath, data.ViewName, "/"+viewNameLower, "view"); err != nil {
-		fmt.Printf("⚠️  Could not register view in home page: %v\n", err)
+		logging.Warn("could not register the view in the home page", "error", err)
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateViewPattern(t *testing.T) {
+	for _, p := range append([]string{""}, ViewPatterns...) {
+		if err := ValidateViewPattern(p); err != nil {
+			t.Errorf("ValidateViewPattern(%q) = %v", p, err)
+		}
+	}
+	err := ValidateViewPattern("todo")
+	if err == nil || !strings.Contains(err.Error(), "counter, form, wizard, chat, kanban") {
+		t.Errorf("ValidateViewPattern(\"todo\") = %v, want the valid patterns listed", err)
+	}
+}
+
+func TestGenerateView_Patterns(t *testing.T) {
+	actions := map[string][]string{
+		"counter": {"Increment", "Decrement", "Reset", "SetStep"},
+		"form":    {"Submit", "Reset"},
+		"wizard":  {"Next", "Back", "Finish", "Restart"},
+		"chat":    {"Mount", "Send", "Sync"},
+		"kanban":  {"Add", "Move", "Delete"},
+	}
+	markup := map[string]string{
+		"counter": `<form name="set_step"`,
+		"form":    `<form name="submit"`,
+		"wizard":  `name="next"`,
+		"chat":    `data-chat-log`,
+		"kanban":  `data-column="doing"`,
+	}
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		for _, pattern := range ViewPatterns {
+			t.Run(kit+"/"+pattern, func(t *testing.T) {
+				dir := t.TempDir()
+				if err := GenerateView(dir, "testmodule", "demo", kit, "tailwind", ViewOptions{Pattern: pattern}); err != nil {
+					t.Fatal(err)
+				}
+				handler, err := os.ReadFile(filepath.Join(dir, "app", "demo", "demo.go"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), "demo.go", handler, 0); err != nil {
+					t.Fatalf("generated handler does not parse: %v\n%s", err, handler)
+				}
+				for _, action := range actions[pattern] {
+					if !strings.Contains(string(handler), "func (c *DemoController) "+action+"(") {
+						t.Errorf("handler is missing the %s action", action)
+					}
+				}
+
+				page, err := os.ReadFile(filepath.Join(dir, "app", "demo", "demo.tmpl"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(page), markup[pattern]) {
+					t.Errorf("template is missing %s:\n%s", markup[pattern], page)
+				}
+				if strings.Contains(string(page), "Add your content here") || strings.Contains(string(page), "[[") {
+					t.Errorf("template kept the placeholder or kit delimiters:\n%s", page)
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateView_InvalidPattern(t *testing.T) {
+	dir := t.TempDir()
+	err := GenerateView(dir, "testmodule", "demo", "multi", "tailwind", ViewOptions{Pattern: "todo"})
+	if err == nil || !strings.Contains(err.Error(), "invalid view pattern") {
+		t.Fatalf("GenerateView with an unknown pattern = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app", "demo")); !os.IsNotExist(err) {
+		t.Error("an invalid pattern still created the view directory")
+	}
+}
@@ -0,0 +1,325 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/parser"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// WizardConfirm is the field list of a review step: `lvt gen wizard
+// onboarding ... step3:confirm` ends with a step showing every entry.
+const WizardConfirm = "confirm"
+
+// WizardStep is a step of a generated wizard, as given on the command line.
+type WizardStep struct {
+	Name    string         // "account", "step1"
+	Fields  []parser.Field // Empty for the review step
+	Confirm bool           // Review step listing the entries of the other steps
+}
+
+// WizardOptions holds the optional settings of GenerateWizard.
+type WizardOptions struct {
+	// IDType selects how record IDs are generated, as for resources.
+	IDType string
+}
+
+// WizardData is the template data for a wizard's handler, page and table.
+type WizardData struct {
+	PackageName          string
+	ModuleName           string
+	WizardName           string // Capitalized (e.g., "Onboarding")
+	WizardNameLower      string // Lowercase (e.g., "onboarding")
+	ResourceNameSingular string // Model name sqlc derives from the table (e.g., "Onboarding")
+	TableName            string // Table the final submit saves to (e.g., "onboardings")
+	Steps                []WizardStepData
+	Fields               []FieldData // Fields of every step, in order
+	IDType               string
+	Kit                  *kits.KitInfo
+	CSSFramework         string
+	DevMode              bool
+	Theme                string
+	WithNotifications    bool // Page renders the notifications component (lvt gen notifications)
+	WithSessions         bool // Sessions survive restarts in shared/sessions (lvt gen sessions)
+	WithOffline          bool // Actions fired offline are queued and replayed (lvt gen offline)
+	WithSSE              bool // Updates fall back to server-sent events (lvt gen sse)
+	WithCompression      bool // Updates are deflated over the WebSocket (lvt gen compression)
+	WithPrerender        bool // Crawlers and ?static=1 get a static render (lvt gen prerender)
+	WithSecurityHeaders  bool // Inline scripts carry the Content-Security-Policy nonce (lvt gen security-headers)
+}
+
+// WizardStepData is a step prepared for the templates.
+type WizardStepData struct {
+	Index     int
+	Name      string      // As given (e.g., "company_info")
+	Title     string      // Shown in the progress indicator (e.g., "Company Info")
+	InputName string      // Struct the step's form binds to (e.g., "CompanyInfoInput")
+	Fields    []FieldData // Empty for the review step
+	Confirm   bool
+	Last      bool // The step's form submits the wizard instead of moving on
+}
+
+// NewIDExpr returns the Go expression generating a record ID from app/ids,
+// or "" when the handler builds the default "<wizard>-<unix nanos>" ID.
+func (d WizardData) NewIDExpr() string {
+	return newIDExpr(d.IDType)
+}
+
+var wizardStepName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateWizardSteps checks the steps of a wizard: at least two, unique
+// names, a review step only at the end and fields every step can render.
+func validateWizardSteps(steps []WizardStep) error {
+	if len(steps) < 2 {
+		return fmt.Errorf("a wizard needs at least two steps (format: step:field,field)")
+	}
+	stepNames := make(map[string]bool)
+	fieldNames := make(map[string]string)
+	for i, step := range steps {
+		if !wizardStepName.MatchString(step.Name) {
+			return fmt.Errorf("invalid step name %q: use lowercase letters, digits and underscores", step.Name)
+		}
+		if stepNames[step.Name] {
+			return fmt.Errorf("duplicate step %q", step.Name)
+		}
+		stepNames[step.Name] = true
+
+		if step.Confirm {
+			if i != len(steps)-1 {
+				return fmt.Errorf("step %q: the %s step must be the last one", step.Name, WizardConfirm)
+			}
+			if i == 0 {
+				return fmt.Errorf("the %s step needs earlier steps to review", WizardConfirm)
+			}
+			continue
+		}
+		if len(step.Fields) == 0 {
+			return fmt.Errorf("step %q has no fields (format: %s:field,field)", step.Name, step.Name)
+		}
+		for _, f := range step.Fields {
+			if f.Name == "id" || f.Name == "created_at" {
+				return fmt.Errorf("step %q: %s is a column every wizard table has; rename the field", step.Name, f.Name)
+			}
+			if prev, ok := fieldNames[f.Name]; ok {
+				return fmt.Errorf("field %q is in both step %q and step %q", f.Name, prev, step.Name)
+			}
+			fieldNames[f.Name] = step.Name
+			if err := validateWizardField(f); err != nil {
+				return fmt.Errorf("step %q: %w", step.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateWizardField rejects the field kinds a wizard step can't collect.
+func validateWizardField(f parser.Field) error {
+	switch {
+	case f.IsFile:
+		return fmt.Errorf("field %q: file fields are not supported in a wizard", f.Name)
+	case f.IsSelect:
+		return fmt.Errorf("field %q: select fields are not supported in a wizard (commas separate the step's fields)", f.Name)
+	case f.IsReference:
+		return fmt.Errorf("field %q: references are not supported in a wizard", f.Name)
+	case f.IsJSON:
+		return fmt.Errorf("field %q: json fields are not supported in a wizard", f.Name)
+	case f.IsRichText:
+		return fmt.Errorf("field %q: richtext fields are not supported in a wizard", f.Name)
+	case f.Metadata.IsPassword:
+		return fmt.Errorf("field %q: password fields are not supported in a wizard; use lvt gen auth for accounts", f.Name)
+	case f.IsCounter, f.IsGenerated:
+		return fmt.Errorf("field %q: counter and generated fields are not supported in a wizard", f.Name)
+	case f.HasDefault:
+		return fmt.Errorf("field %q: defaults are not supported in a wizard", f.Name)
+	}
+	switch f.GoType {
+	case "string", "int64", "float64", "bool":
+		return nil
+	}
+	return fmt.Errorf("field %q: type %s is not supported in a wizard (use string, text, int, float or bool types)", f.Name, f.Type)
+}
+
+// GenerateWizard generates a multi-step form: a handler that validates each
+// step before moving on, a page with the kit's progress indicator, and the
+// table its final submit saves the entries to in one transaction.
+func GenerateWizard(basePath, moduleName, wizardName string, steps []WizardStep, kitName, cssFramework string, opts ...WizardOptions) error {
+	if err := checkAppPackage("wizard", wizardName); err != nil {
+		return err
+	}
+	defer track(basePath, "wizard "+strings.ToLower(wizardName))()
+	var options WizardOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if err := ValidateIDType(options.IDType); err != nil {
+		return err
+	}
+	if err := validateWizardSteps(steps); err != nil {
+		return err
+	}
+
+	kitLoader := kits.DefaultLoader()
+	kit, err := kitLoader.Load(kitName)
+	if err != nil {
+		return fmt.Errorf("failed to load kit %q: %w", kitName, err)
+	}
+	if kit.Helpers == nil {
+		if err := kit.SetHelpersForFramework(cssFramework); err != nil {
+			return fmt.Errorf("failed to load CSS helpers for framework %q: %w", cssFramework, err)
+		}
+	}
+	if err := copyKitAssets(basePath, kit); err != nil {
+		return err
+	}
+
+	titleCaser := cases.Title(language.English)
+	wizardNameLower := strings.ToLower(wizardName)
+	singular := singularize(wizardNameLower)
+	data := WizardData{
+		PackageName:          wizardNameLower,
+		ModuleName:           moduleName,
+		WizardName:           titleCaser.String(wizardNameLower),
+		WizardNameLower:      wizardNameLower,
+		ResourceNameSingular: toCamelCase(singular),
+		TableName:            pluralize(singular),
+		IDType:               options.IDType,
+		Kit:                  kit,
+		CSSFramework:         cssFramework,
+		DevMode:              ReadDevMode(basePath),
+		Theme:                ReadTheme(basePath),
+		WithNotifications:    NotificationsEnabled(basePath),
+		WithSessions:         SessionsEnabled(basePath),
+		WithOffline:          OfflineEnabled(basePath),
+		WithSSE:              SSEEnabled(basePath),
+		WithCompression:      CompressionEnabled(basePath),
+		WithPrerender:        PrerenderEnabled(basePath),
+		WithSecurityHeaders:  SecurityHeadersEnabled(basePath),
+	}
+	for i, step := range steps {
+		fields := FieldDataFromFields(step.Fields)
+		data.Steps = append(data.Steps, WizardStepData{
+			Index:     i,
+			Name:      step.Name,
+			Title:     titleCaser.String(strings.ReplaceAll(step.Name, "_", " ")),
+			InputName: toCamelCase(step.Name) + "Input",
+			Fields:    fields,
+			Confirm:   step.Confirm,
+			Last:      i == len(steps)-1,
+		})
+		data.Fields = append(data.Fields, fields...)
+	}
+
+	handlerTmpl, err := kitLoader.LoadKitTemplate(kitName, "wizard/handler.go.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read wizard handler template: %w", err)
+	}
+	// The page renders the kit's progress indicator component
+	progressTmpl, err := kitLoader.LoadKitComponent(kitName, "progress.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to load component progress.tmpl: %w", err)
+	}
+	pageTmpl, err := kitLoader.LoadKitTemplate(kitName, "wizard/template.tmpl.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read wizard page template: %w", err)
+	}
+	migrationTmpl, err := kitLoader.LoadKitTemplate(kitName, "wizard/migration.sql.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read migration template: %w", err)
+	}
+	schemaTmpl, err := kitLoader.LoadKitTemplate(kitName, "wizard/schema.sql.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read schema template: %w", err)
+	}
+	queriesTmpl, err := kitLoader.LoadKitTemplate(kitName, "wizard/queries.sql.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read queries template: %w", err)
+	}
+
+	wizardDir := filepath.Join(basePath, "app", wizardNameLower)
+	if err := os.MkdirAll(wizardDir, 0755); err != nil {
+		return fmt.Errorf("failed to create wizard directory: %w", err)
+	}
+	if err := generateFile(string(handlerTmpl), data, filepath.Join(wizardDir, wizardNameLower+".go"), kit); err != nil {
+		return fmt.Errorf("failed to generate handler: %w", err)
+	}
+	// Field names vary in length, so the struct fields are aligned afterwards
+	if err := formatGoFile(filepath.Join(wizardDir, wizardNameLower+".go")); err != nil {
+		return err
+	}
+	page, err := withComponents(kitLoader, kitName, string(progressTmpl)+"\n\n"+string(pageTmpl), featureComponents(data.WithNotifications, data.WithSessions, data.WithOffline, data.WithSSE)...)
+	if err != nil {
+		return err
+	}
+	tmplPath := filepath.Join(wizardDir, wizardNameLower+".tmpl")
+	if err := generateFile(page, data, tmplPath, kit); err != nil {
+		return fmt.Errorf("failed to generate template: %w", err)
+	}
+	if err := ValidateTemplate(tmplPath); err != nil {
+		return err
+	}
+	if err := generateFuncs(basePath, kitLoader, kitName); err != nil {
+		return err
+	}
+	if data.IDType != "" {
+		if err := generateIDs(basePath, kitLoader, kitName); err != nil {
+			return err
+		}
+	}
+
+	// The table the final submit saves to
+	dbDir := filepath.Join(basePath, "database")
+	migrationsDir := filepath.Join(dbDir, "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+	timestamp := time.Now()
+	var migrationPath string
+	for {
+		timestampStr := timestamp.Format("20060102150405")
+		migrationPath = filepath.Join(migrationsDir, fmt.Sprintf("%s_create_%s.sql", timestampStr, data.TableName))
+		matches, _ := filepath.Glob(filepath.Join(migrationsDir, timestampStr+"_*.sql"))
+		if len(matches) == 0 {
+			break
+		}
+		timestamp = timestamp.Add(1 * time.Second)
+	}
+	if err := generateFile(string(migrationTmpl), data, migrationPath, kit); err != nil {
+		return fmt.Errorf("failed to generate migration: %w", err)
+	}
+	if err := addDownSection(migrationPath, filepath.Join(dbDir, "schema.sql")); err != nil {
+		return err
+	}
+	if err := appendToFile(string(schemaTmpl), data, filepath.Join(dbDir, "schema.sql"), "wizard "+data.TableName, kit); err != nil {
+		return fmt.Errorf("failed to append to schema.sql: %w", err)
+	}
+	if err := appendToFile(string(queriesTmpl), data, filepath.Join(dbDir, "queries.sql"), "wizard "+data.TableName, kit); err != nil {
+		return fmt.Errorf("failed to append to queries.sql: %w", err)
+	}
+
+	mainGoPath := findMainGo(basePath)
+	if mainGoPath != "" {
+		route := RouteInfo{
+			Path:        "/" + wizardNameLower,
+			PackageName: wizardNameLower,
+			HandlerCall: wizardNameLower + ".Handler(queries)",
+			ImportPath:  moduleName + "/app/" + wizardNameLower,
+		}
+		if err := InjectRoute(mainGoPath, route); err != nil {
+			logging.Warn("could not add the route to main.go; add it manually", "route", fmt.Sprintf("http.Handle(\"/%s\", %s.Handler(queries))", wizardNameLower, wizardNameLower), "error", err)
+		}
+	}
+
+	if err := RegisterResourceFields(basePath, data.WizardName, "/"+wizardNameLower, "wizard", data.TableName, data.Fields); err != nil {
+		logging.Warn("could not register the wizard in the home page", "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/parser"
+)
+
+func wizardTestSteps(t *testing.T) []WizardStep {
+	t.Helper()
+	account, err := parser.ParseFields([]string{"name:string", "email:email"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	company, err := parser.ParseFields([]string{"company:string", "size:int", "newsletter:bool", "notes:text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []WizardStep{
+		{Name: "account", Fields: account},
+		{Name: "company_info", Fields: company},
+		{Name: "review", Confirm: true},
+	}
+}
+
+func TestGenerateWizard(t *testing.T) {
+	for _, kit := range []string{"multi", "single", "daisyui"} {
+		t.Run(kit, func(t *testing.T) {
+			dir := t.TempDir()
+			setupTestProject(t, dir)
+			if err := GenerateWizard(dir, "testmodule", "onboarding", wizardTestSteps(t), kit, "tailwind"); err != nil {
+				t.Fatal(err)
+			}
+
+			handler, err := os.ReadFile(filepath.Join(dir, "app", "onboarding", "onboarding.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{
+				`var stepNames = []string{"Account", "Company Info", "Review"}`,
+				"type AccountInput struct",
+				"type CompanyInfoInput struct",
+				`Email string ` + "`" + `json:"email" validate:"required,email"` + "`",
+				"func (c *OnboardingController) Next(",
+				"func (c *OnboardingController) Back(",
+				"func (c *OnboardingController) Submit(",
+				"database.Conn().BeginTx(dbCtx, nil)",
+				"qtx.CreateOnboarding(dbCtx, models.CreateOnboardingParams{",
+				"tx.Commit()",
+			} {
+				if !strings.Contains(string(handler), want) {
+					t.Errorf("handler is missing %q", want)
+				}
+			}
+
+			page, err := os.ReadFile(filepath.Join(dir, "app", "onboarding", "onboarding.tmpl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{
+				`{{define "progress"}}`,
+				`{{template "progress" .}}`,
+				`{{if eq .Step 0}}`,
+				`{{else if eq .Step 2}}`,
+				`<form name="next"`,
+				`value="{{.Entry.Email}}"`,
+				`{{if .Entry.Newsletter}} checked{{end}}`,
+				`name="submit"`,
+			} {
+				if !strings.Contains(string(page), want) {
+					t.Errorf("template is missing %q", want)
+				}
+			}
+
+			schema, _ := os.ReadFile(filepath.Join(dir, "database", "schema.sql"))
+			if !strings.Contains(string(schema), "CREATE TABLE IF NOT EXISTS onboardings") || !strings.Contains(string(schema), "newsletter BOOLEAN NOT NULL") {
+				t.Errorf("schema.sql is missing the onboardings table:\n%s", schema)
+			}
+			queries, _ := os.ReadFile(filepath.Join(dir, "database", "queries.sql"))
+			if !strings.Contains(string(queries), "INSERT INTO onboardings (id, name, email, company, size, newsletter, notes, created_at)") {
+				t.Errorf("queries.sql is missing the insert:\n%s", queries)
+			}
+			migrations, _ := filepath.Glob(filepath.Join(dir, "database", "migrations", "*_create_onboardings.sql"))
+			if len(migrations) != 1 {
+				t.Errorf("got migrations %v, want one creating onboardings", migrations)
+			}
+		})
+	}
+}
+
+func TestGenerateWizard_LastStepSubmits(t *testing.T) {
+	dir := t.TempDir()
+	setupTestProject(t, dir)
+	steps := wizardTestSteps(t)[:2]
+	if err := GenerateWizard(dir, "testmodule", "signup", steps, "multi", "tailwind"); err != nil {
+		t.Fatal(err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "app", "signup", "signup.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(page), `<form name="next"`); n != 1 {
+		t.Errorf("got %d next forms, want 1", n)
+	}
+	if !strings.Contains(string(page), `<form name="submit"`) {
+		t.Error("the last step's form does not submit the wizard")
+	}
+}
+
+func TestGenerateWizard_InvalidSteps(t *testing.T) {
+	steps := wizardTestSteps(t)
+	password, err := parser.ParseFields([]string{"password:password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, err := parser.ParseFields([]string{"published_at:time"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		steps []WizardStep
+		want  string
+	}{
+		{"one step", steps[:1], "at least two steps"},
+		{"duplicate step", []WizardStep{steps[0], steps[0]}, `duplicate step "account"`},
+		{"bad step name", []WizardStep{{Name: "Step 1", Fields: steps[0].Fields}, steps[1]}, "invalid step name"},
+		{"confirm first", []WizardStep{steps[2], steps[0], steps[1]}, "must be the last one"},
+		{"shared field", []WizardStep{steps[0], {Name: "again", Fields: steps[0].Fields}}, `field "name" is in both step "account" and step "again"`},
+		{"password", []WizardStep{steps[0], {Name: "secret", Fields: password}}, "password fields are not supported"},
+		{"time", []WizardStep{steps[0], {Name: "when", Fields: published}}, "type time is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := GenerateWizard(dir, "testmodule", "onboarding", tt.steps, "multi", "tailwind")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("GenerateWizard() = %v, want an error containing %q", err, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "app", "onboarding")); !os.IsNotExist(err) {
+				t.Error("invalid steps still created the wizard directory")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package inflect turns English nouns singular and plural, for the table,
+// struct and route names lvt derives from a resource name.
+//
+// The built-in rules cover regular nouns, common irregular ones (person,
+// child, criterion) and uncountable ones (data, news, equipment). A project
+// adds its own vocabulary as pairs in .lvtrc, which take precedence.
+package inflect
+
+import (
+	"regexp"
+	"strings"
+)
+
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// sWords are singular words ending in s whose plural adds es; other words
+// ending in us are plurals, like menus.
+const sWords = `(status|campus|bus|virus|bonus|census|corpus|focus|radius|apparatus|consensus|nexus|surplus|syllabus|prospectus|cactus|fungus|octopus|stimulus|circus|chorus|walrus|abacus|sinus|thesaurus|plus|minus|alias|atlas|canvas|bias|gas|lens|iris)`
+
+func rules(pairs ...string) []rule {
+	r := make([]rule, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		r = append(r, rule{regexp.MustCompile("(?i)" + pairs[i]), pairs[i+1]})
+	}
+	return r
+}
+
+// pluralRules and singularRules are tried in order; the first match wins.
+// They are deliberately narrow: a word they get wrong can be fixed with a
+// pair in .lvtrc, but a broad rule that mangles common words cannot.
+var pluralRules = rules(
+	`(quiz)$`, "${1}zes",
+	`(matr|vert)(?:ix|ex)$`, "${1}ices",
+	`(x|ch|ss|sh|zz)$`, "${1}es",
+	`([^aeiouy]|qu)y$`, "${1}ies",
+	`(kni|wi|li)fe$`, "${1}ves",
+	`(wol|shel|hal|cal|el|scar|dwar|hoo|lea|loa|thie)f$`, "${1}ves",
+	`^(ax|test)is$`, "${1}es",
+	`sis$`, "ses",
+	`(bacteri|curricul|memorand|strat|millenni|addend)(um|a)$`, "${1}a",
+	`(buffal|tomat|potat|her|ech|vet)o$`, "${1}oes",
+	sWords+`$`, "${1}es",
+	`s$`, "s",
+	`$`, "s",
+)
+
+var singularRules = rules(
+	`(database)s$`, "${1}",
+	`(quiz)zes$`, "${1}",
+	`(matr)ices$`, "${1}ix",
+	`(vert|ind)ices$`, "${1}ex",
+	sWords+`(es)?$`, "${1}",
+	`^(ax|test)(is|es)$`, "${1}is",
+	`(buffal|tomat|potat|her|ech|vet)oes$`, "${1}o",
+	`(x|ch|ss|sh|zz)es$`, "${1}",
+	`([^aeiouy]|qu)ies$`, "${1}y",
+	`(kni|wi|li)ves$`, "${1}fe",
+	`(wol|shel|hal|cal|el|scar|dwar|hoo|lea|loa|thie)ves$`, "${1}f",
+	`(analy|diagno|parenthe|progno|synop|the|hypothe|empha|cri|oa)(sis|ses)$`, "${1}sis",
+	`(bacteri|curricul|memorand|strat|millenni|addend)a$`, "${1}um",
+	`(ss|sis)$`, "${1}",
+	`s$`, "",
+)
+
+// irregular are built-in singular -> plural pairs the rules get wrong.
+var irregular = map[string]string{
+	"person":     "people",
+	"man":        "men",
+	"woman":      "women",
+	"child":      "children",
+	"tooth":      "teeth",
+	"foot":       "feet",
+	"goose":      "geese",
+	"mouse":      "mice",
+	"ox":         "oxen",
+	"criterion":  "criteria",
+	"phenomenon": "phenomena",
+	"movie":      "movies",
+	"cookie":     "cookies",
+	"zombie":     "zombies",
+}
+
+// uncountable are built-in words with the same singular and plural.
+var uncountable = []string{
+	"data", "metadata", "media", "news", "information", "equipment",
+	"feedback", "software", "hardware", "firmware", "analytics",
+	"series", "species", "sheep", "fish", "deer", "money", "rice",
+	"police", "staff", "audio", "music", "jeans", "moose", "aircraft",
+	"tennis",
+}
+
+// Inflector turns words singular and plural. The zero value is not usable;
+// use New or Default.
+type Inflector struct {
+	plurals   map[string]string // singular -> plural
+	singulars map[string]string // plural -> singular
+}
+
+// Default has only the built-in rules.
+var Default = New(nil)
+
+// New returns an Inflector with the built-in rules and the project's own
+// singular -> plural pairs, which take precedence. A pair with the same
+// word on both sides makes the word uncountable.
+func New(custom map[string]string) *Inflector {
+	in := &Inflector{plurals: make(map[string]string), singulars: make(map[string]string)}
+	add := func(singular, plural string) {
+		singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+		in.plurals[singular] = plural
+		in.singulars[plural] = singular
+	}
+	for _, word := range uncountable {
+		add(word, word)
+	}
+	for singular, plural := range irregular {
+		add(singular, plural)
+	}
+	for singular, plural := range custom {
+		if singular != "" && plural != "" {
+			add(singular, plural)
+		}
+	}
+	return in
+}
+
+// Plural returns the plural of word. Plurals are returned unchanged, so
+// Plural can be applied to a name that may already be one.
+func (in *Inflector) Plural(word string) string {
+	return in.inflect(word, in.plurals, in.singulars, pluralRules)
+}
+
+// Singular returns the singular of word. Singulars are returned unchanged.
+func (in *Inflector) Singular(word string) string {
+	return in.inflect(word, in.singulars, in.plurals, singularRules)
+}
+
+// inflect inflects the last word of a snake_case name ("blog_category"
+// becomes "blog_categories"). Words in to are replaced, words already in
+// from are kept, and anything else goes through the rules.
+func (in *Inflector) inflect(word string, to, from map[string]string, rules []rule) string {
+	if word == "" {
+		return word
+	}
+	prefix, last := "", word
+	if i := strings.LastIndex(word, "_"); i >= 0 {
+		prefix, last = word[:i+1], word[i+1:]
+	}
+	lower := strings.ToLower(last)
+	if w, ok := to[lower]; ok {
+		return prefix + matchCase(last, w)
+	}
+	if _, ok := from[lower]; ok {
+		return word
+	}
+	upper := last == strings.ToUpper(last)
+	for _, r := range rules {
+		if r.pattern.MatchString(last) {
+			last = r.pattern.ReplaceAllString(last, r.replacement)
+			if upper {
+				last = strings.ToUpper(last)
+			}
+			return prefix + last
+		}
+	}
+	return word
+}
+
+// matchCase capitalizes w like word: fully upper-case or with a leading
+// capital.
+func matchCase(word, w string) string {
+	switch {
+	case word == strings.ToUpper(word) && len(word) > 1:
+		return strings.ToUpper(w)
+	case word[0] >= 'A' && word[0] <= 'Z':
+		return strings.ToUpper(w[:1]) + w[1:]
+	}
+	return w
+}
@@ -0,0 +1,87 @@
+package inflect
+
+import "testing"
+
+func TestInflect(t *testing.T) {
+	tests := []struct {
+		singular, plural string
+	}{
+		{"user", "users"},
+		{"status", "statuses"},
+		{"address", "addresses"},
+		{"box", "boxes"},
+		{"match", "matches"},
+		{"quiz", "quizzes"},
+		{"category", "categories"},
+		{"day", "days"},
+		{"knife", "knives"},
+		{"wolf", "wolves"},
+		{"drive", "drives"},
+		{"house", "houses"},
+		{"menu", "menus"},
+		{"analysis", "analyses"},
+		{"axis", "axes"},
+		{"matrix", "matrices"},
+		{"index", "indexes"},
+		{"hero", "heroes"},
+		{"photo", "photos"},
+		{"curriculum", "curricula"},
+		{"person", "people"},
+		{"child", "children"},
+		{"movie", "movies"},
+		{"data", "data"},
+		{"news", "news"},
+		{"equipment", "equipment"},
+		{"blog_category", "blog_categories"},
+		{"line_item", "line_items"},
+		{"Person", "People"},
+		{"STATUS", "STATUSES"},
+	}
+	for _, tt := range tests {
+		if got := Default.Plural(tt.singular); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want %q", tt.singular, got, tt.plural)
+		}
+		if got := Default.Singular(tt.plural); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want %q", tt.plural, got, tt.singular)
+		}
+		// Already inflected words are left alone
+		if got := Default.Plural(tt.plural); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want it unchanged", tt.plural, got)
+		}
+		if got := Default.Singular(tt.singular); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want it unchanged", tt.singular, got)
+		}
+	}
+}
+
+func TestNewCustomPairs(t *testing.T) {
+	in := New(map[string]string{"cactus": "cacti", "person": "persons", "sms": "sms"})
+	for _, tt := range []struct {
+		singular, plural string
+	}{
+		{"cactus", "cacti"},
+		{"person", "persons"},
+		{"sms", "sms"},
+		{"plant_cactus", "plant_cacti"},
+		{"user", "users"},
+	} {
+		if got := in.Plural(tt.singular); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want %q", tt.singular, got, tt.plural)
+		}
+		if got := in.Singular(tt.plural); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want %q", tt.plural, got, tt.singular)
+		}
+	}
+	if got := Default.Plural("cactus"); got != "cactuses" {
+		t.Errorf("custom pairs leaked into Default: Plural(cactus) = %q", got)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	if got := Default.Plural(""); got != "" {
+		t.Errorf("Plural(\"\") = %q", got)
+	}
+	if got := Default.Singular(""); got != "" {
+		t.Errorf("Singular(\"\") = %q", got)
+	}
+}
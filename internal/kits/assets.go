@@ -0,0 +1,174 @@
+package kits
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// AssetsDir is the kit directory holding static files (CSS, JS, fonts,
+// images) that are copied into generated apps and served by them, so apps
+// work without a CDN.
+const AssetsDir = "assets"
+
+// AssetsURLPrefix is where generated apps serve web/assets.
+const AssetsURLPrefix = "/assets/"
+
+// Asset is a static file of a kit.
+type Asset struct {
+	// Path is slash-separated and relative to the assets directory
+	Path string
+	Data []byte
+}
+
+// Version returns a short hash of the asset's content. Asset URLs carry it,
+// so browsers can cache them for good and still see a new version.
+func (a Asset) Version() string {
+	sum := sha256.Sum256(a.Data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// URL returns the versioned URL a generated app serves the asset at, for
+// the app of kit kitName.
+func (a Asset) URL(kitName string) string {
+	return AssetsURLPrefix + kitName + "/" + a.Path + "?v=" + a.Version()
+}
+
+// Assets returns the kit's static files sorted by path, including those of
+// the kits it extends; a file replaces the parent's file of the same path.
+func (k *KitInfo) Assets() ([]Asset, error) {
+	byPath := make(map[string]Asset)
+	chain := k.Chain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		assets, err := chain[i].ownAssets()
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range assets {
+			byPath[a.Path] = a
+		}
+	}
+
+	assets := make([]Asset, 0, len(byPath))
+	for _, a := range byPath {
+		assets = append(assets, a)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Path < assets[j].Path })
+	return assets, nil
+}
+
+// AssetEntries returns the assets the layout references, in order: the
+// manifest's assets list (the kit's own or inherited), else every .css
+// file and then every .js file.
+func (k *KitInfo) AssetEntries() ([]Asset, error) {
+	assets, err := k.Assets()
+	if err != nil || len(assets) == 0 {
+		return nil, err
+	}
+
+	var listed []string
+	for _, kit := range k.Chain() {
+		if len(kit.Manifest.Assets) > 0 {
+			listed = kit.Manifest.Assets
+			break
+		}
+	}
+	if len(listed) == 0 {
+		var css, js []Asset
+		for _, a := range assets {
+			switch path.Ext(a.Path) {
+			case ".css":
+				css = append(css, a)
+			case ".js":
+				js = append(js, a)
+			}
+		}
+		return append(css, js...), nil
+	}
+
+	byPath := make(map[string]Asset, len(assets))
+	for _, a := range assets {
+		byPath[a.Path] = a
+	}
+	entries := make([]Asset, 0, len(listed))
+	for _, p := range listed {
+		a, ok := byPath[path.Clean(p)]
+		if !ok {
+			return nil, fmt.Errorf("kit %s lists asset %s, which is not in %s/", k.Manifest.Name, p, AssetsDir)
+		}
+		entries = append(entries, a)
+	}
+	return entries, nil
+}
+
+// AssetTags returns the <link> and <script> tags loading the kit's entry
+// assets from a generated app, or "" when the kit ships none.
+func (k *KitInfo) AssetTags() (string, error) {
+	entries, err := k.AssetEntries()
+	if err != nil {
+		return "", err
+	}
+	var tags []string
+	for _, a := range entries {
+		url := html.EscapeString(a.URL(k.Manifest.Name))
+		switch path.Ext(a.Path) {
+		case ".css":
+			tags = append(tags, fmt.Sprintf(`<link rel="stylesheet" href="%s" />`, url))
+		case ".js":
+			tags = append(tags, fmt.Sprintf(`<script src="%s"></script>`, url))
+		}
+	}
+	return strings.Join(tags, "\n    "), nil
+}
+
+// ownAssets reads the files of the kit's own assets directory.
+func (k *KitInfo) ownAssets() ([]Asset, error) {
+	fsys, err := k.fsys()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.Stat(fsys, AssetsDir); err != nil {
+		return nil, nil
+	}
+
+	var assets []Asset
+	err = fs.WalkDir(fsys, AssetsDir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(entry.Name(), ".") && p != AssetsDir {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		assets = append(assets, Asset{Path: strings.TrimPrefix(p, AssetsDir+"/"), Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the assets of kit %s: %w", k.Manifest.Name, err)
+	}
+	return assets, nil
+}
+
+// fsys returns the kit's own directory: embedded for system kits, on disk
+// otherwise.
+func (k *KitInfo) fsys() (fs.FS, error) {
+	if k.Source == SourceSystem {
+		return fs.Sub(systemKits, path.Join("system", k.Manifest.Name))
+	}
+	return os.DirFS(k.Path), nil
+}
@@ -0,0 +1,95 @@
+package kits
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeKitFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestKitAssets(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeKitFiles(t, filepath.Join(tmpDir, "acme-base"), map[string]string{
+		"kit.yaml":            "name: acme-base\nversion: 1.0.0\ndescription: Base\ncss_framework: tailwind\n",
+		"assets/css/base.css": "body { color: black; }\n",
+		"assets/fonts/a.woff": "font",
+	})
+	writeKitFiles(t, filepath.Join(tmpDir, "acme"), map[string]string{
+		"kit.yaml":            "name: acme\nversion: 1.0.0\ndescription: Acme\nextends: acme-base\n",
+		"assets/css/base.css": "body { color: red; }\n",
+		"assets/css/acme.css": ".acme {}\n",
+		"assets/js/acme.js":   "console.log('acme')\n",
+		"assets/.DS_Store":    "ignored",
+	})
+
+	loader := DefaultLoader()
+	loader.AddSearchPath(tmpDir)
+	kit, err := loader.Load("acme")
+	if err != nil {
+		t.Fatalf("Failed to load kit: %v", err)
+	}
+
+	assets, err := kit.Assets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, a := range assets {
+		paths = append(paths, a.Path)
+		if a.Path == "css/base.css" && !strings.Contains(string(a.Data), "red") {
+			t.Error("Expected the kit's css/base.css to replace the parent's")
+		}
+	}
+	if got, want := strings.Join(paths, " "), "css/acme.css css/base.css fonts/a.woff js/acme.js"; got != want {
+		t.Errorf("Assets() = %s, want %s", got, want)
+	}
+
+	tags, err := kit.AssetTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	acme := strings.Index(tags, `href="/assets/acme/css/acme.css?v=`)
+	base := strings.Index(tags, `href="/assets/acme/css/base.css?v=`)
+	script := strings.Index(tags, `<script src="/assets/acme/js/acme.js?v=`)
+	if acme < 0 || base < acme || script < base || strings.Contains(tags, "woff") {
+		t.Errorf("AssetTags() should load the CSS files, then the JS files:\n%s", tags)
+	}
+
+	// A manifest list picks the entries and their order
+	kit.Manifest.Assets = []string{"js/acme.js", "css/base.css"}
+	entries, err := kit.AssetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Path != "js/acme.js" || entries[1].Path != "css/base.css" {
+		t.Errorf("AssetEntries() = %v, want js/acme.js, css/base.css", entries)
+	}
+	kit.Manifest.Assets = []string{"css/missing.css"}
+	if _, err := kit.AssetEntries(); err == nil || !strings.Contains(err.Error(), "css/missing.css") {
+		t.Errorf("AssetEntries() with a missing entry = %v, want an error naming it", err)
+	}
+}
+
+func TestKitAssets_None(t *testing.T) {
+	kit, err := DefaultLoader().Load("multi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, err := kit.AssetTags()
+	if err != nil || tags != "" {
+		t.Errorf("AssetTags() for multi = %q, %v; want no tags", tags, err)
+	}
+}
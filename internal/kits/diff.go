@@ -0,0 +1,354 @@
+package kits
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileStatus describes how a file of a customized kit compares with the
+// embedded kit it was copied from.
+type FileStatus string
+
+const (
+	StatusUnchanged FileStatus = "unchanged"
+	StatusModified  FileStatus = "modified"
+	StatusLocalOnly FileStatus = "local-only"
+)
+
+// maxDiffCells bounds the line-matching table; files larger than this are
+// diffed as a single change.
+const maxDiffCells = 16 << 20
+
+// KitDiff compares a customized kit with the embedded system kit of the
+// same name.
+type KitDiff struct {
+	Name string
+	Dir  string
+	// Files are the files of the customized copy, sorted by path
+	Files []*FileDiff
+	// Inherited are embedded files the copy does not have; the loader
+	// falls back to the embedded version for them
+	Inherited []string
+}
+
+// Modified returns the files that differ from the embedded kit.
+func (d *KitDiff) Modified() []*FileDiff {
+	var modified []*FileDiff
+	for _, f := range d.Files {
+		if f.Status == StatusModified {
+			modified = append(modified, f)
+		}
+	}
+	return modified
+}
+
+// Count returns the number of files with the given status.
+func (d *KitDiff) Count(status FileStatus) int {
+	n := 0
+	for _, f := range d.Files {
+		if f.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// DiffWithEmbedded compares the kit in dir file by file with the embedded
+// system kit name.
+func DiffWithEmbedded(dir, name string) (*KitDiff, error) {
+	root := path.Join("system", name)
+	if _, err := fs.Stat(systemKits, path.Join(root, ManifestFileName)); err != nil {
+		return nil, fmt.Errorf("%s is not a system kit; there is no embedded version to compare with", name)
+	}
+
+	diff := &KitDiff{Name: name, Dir: dir}
+	local := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if p != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		local[rel] = true
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		f := &FileDiff{Path: rel, Local: string(data), Status: StatusLocalOnly}
+		if upstream, err := systemKits.ReadFile(path.Join(root, rel)); err == nil {
+			f.Upstream = string(upstream)
+			f.Status = StatusUnchanged
+			if f.Local != f.Upstream {
+				f.Status = StatusModified
+				f.compute()
+			}
+		}
+		diff.Files = append(diff.Files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	err = fs.WalkDir(systemKits, root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel := strings.TrimPrefix(p, root+"/")
+		if !local[rel] {
+			diff.Inherited = append(diff.Inherited, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(diff.Files, func(i, j int) bool { return diff.Files[i].Path < diff.Files[j].Path })
+	sort.Strings(diff.Inherited)
+	return diff, nil
+}
+
+// DiffOp marks a line of a FileDiff: shared by both versions, only in the
+// embedded (upstream) version, or only in the local copy.
+type DiffOp byte
+
+const (
+	OpEqual    DiffOp = ' '
+	OpUpstream DiffOp = '-'
+	OpLocal    DiffOp = '+'
+)
+
+// DiffLine is one line of a FileDiff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+	// Upstream and Local are the 1-based line numbers before this line in
+	// each version
+	Upstream, Local int
+}
+
+// FileDiff compares one file of a customized kit with its embedded version.
+// Changes are the runs of differing lines; each can be merged on its own.
+type FileDiff struct {
+	Path     string
+	Status   FileStatus
+	Local    string
+	Upstream string
+
+	lines   []DiffLine
+	changes [][2]int
+}
+
+// CompareText diffs two versions of a text file, e.g. before and after a
+// generator changed it; before takes the upstream side.
+func CompareText(path, before, after string) *FileDiff {
+	f := &FileDiff{Path: path, Upstream: before, Local: after, Status: StatusUnchanged}
+	if before != after {
+		f.Status = StatusModified
+		f.compute()
+	}
+	return f
+}
+
+// Changes returns the number of changes between the two versions.
+func (f *FileDiff) Changes() int {
+	return len(f.changes)
+}
+
+// Stat returns the number of lines only in the local copy and only in the
+// embedded version.
+func (f *FileDiff) Stat() (added, removed int) {
+	for _, line := range f.lines {
+		switch line.Op {
+		case OpLocal:
+			added++
+		case OpUpstream:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Unified formats the whole diff, embedded version first, with context
+// lines around each change.
+func (f *FileDiff) Unified(upstreamLabel, localLabel string, context int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", upstreamLabel, localLabel)
+	for start := 0; start < len(f.changes); {
+		// Changes closer than twice the context share a hunk
+		end := start
+		for end+1 < len(f.changes) && f.changes[end+1][0]-f.changes[end][1] <= 2*context {
+			end++
+		}
+		b.WriteString(f.hunk(f.changes[start][0], f.changes[end][1], context))
+		start = end + 1
+	}
+	return b.String()
+}
+
+// FormatChange formats change i as a hunk with context lines.
+func (f *FileDiff) FormatChange(i, context int) string {
+	return f.hunk(f.changes[i][0], f.changes[i][1], context)
+}
+
+// Merge returns the local copy with the upstream side of the changes
+// accept returns true for.
+func (f *FileDiff) Merge(accept func(change int) bool) string {
+	if f.Status != StatusModified {
+		return f.Local
+	}
+	var out []string
+	change := 0
+	for i := 0; i < len(f.lines); {
+		if change < len(f.changes) && i == f.changes[change][0] {
+			want := OpLocal
+			if accept(change) {
+				want = OpUpstream
+			}
+			for ; i < f.changes[change][1]; i++ {
+				if f.lines[i].Op == want {
+					out = append(out, f.lines[i].Text)
+				}
+			}
+			change++
+			continue
+		}
+		out = append(out, f.lines[i].Text)
+		i++
+	}
+	return joinLines(out)
+}
+
+// hunk formats lines [start, end) with context lines on each side.
+func (f *FileDiff) hunk(start, end, context int) string {
+	from := max(start-context, 0)
+	to := min(end+context, len(f.lines))
+	upstreamCount, localCount := 0, 0
+	var body strings.Builder
+	for _, line := range f.lines[from:to] {
+		if line.Op != OpLocal {
+			upstreamCount++
+		}
+		if line.Op != OpUpstream {
+			localCount++
+		}
+		fmt.Fprintf(&body, "%c%s\n", line.Op, line.Text)
+	}
+	first := f.lines[from]
+	return fmt.Sprintf("@@ -%s +%s @@\n%s", hunkRange(first.Upstream, upstreamCount), hunkRange(first.Local, localCount), body.String())
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// compute matches the lines of both versions (longest common subsequence)
+// and records the runs of differing lines.
+func (f *FileDiff) compute() {
+	a, b := splitLines(f.Upstream), splitLines(f.Local)
+	n, m := len(a), len(b)
+
+	var ops []DiffOp
+	if (n+1)*(m+1) > maxDiffCells {
+		for range a {
+			ops = append(ops, OpUpstream)
+		}
+		for range b {
+			ops = append(ops, OpLocal)
+		}
+	} else {
+		// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+		lcs := make([][]int32, n+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && a[i] == b[j]:
+				ops = append(ops, OpEqual)
+				i++
+				j++
+			case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+				ops = append(ops, OpUpstream)
+				i++
+			default:
+				ops = append(ops, OpLocal)
+				j++
+			}
+		}
+	}
+
+	f.lines = make([]DiffLine, len(ops))
+	f.changes = nil
+	i, j := 0, 0
+	for k, op := range ops {
+		line := DiffLine{Op: op, Upstream: i + 1, Local: j + 1}
+		switch op {
+		case OpEqual:
+			line.Text = a[i]
+			i++
+			j++
+		case OpUpstream:
+			line.Text = a[i]
+			i++
+		case OpLocal:
+			line.Text = b[j]
+			j++
+		}
+		f.lines[k] = line
+		if op != OpEqual {
+			if n := len(f.changes); n > 0 && f.changes[n-1][1] == k {
+				f.changes[n-1][1] = k + 1
+			} else {
+				f.changes = append(f.changes, [2]int{k, k + 1})
+			}
+		}
+	}
+}
+
+// splitLines splits text into lines; a missing final newline is ignored.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
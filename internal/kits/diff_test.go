@@ -0,0 +1,85 @@
+package kits
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffWithEmbedded(t *testing.T) {
+	dir := t.TempDir()
+	upstream, err := systemKits.ReadFile("system/multi/components/toolbar.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(upstream), "\n")
+	lines[0] = "{{/* my toolbar */}}"
+	lines = append(lines[:4], append([]string{"<!-- mine -->"}, lines[4:]...)...)
+	local := strings.Join(lines, "\n")
+
+	files := map[string]string{
+		"components/toolbar.tmpl": local,
+		"components/table.tmpl":   mustReadEmbedded(t, "system/multi/components/table.tmpl"),
+		"components/extra.tmpl":   "extra\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diff, err := DiffWithEmbedded(dir, "multi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Count(StatusLocalOnly) != 1 || diff.Count(StatusUnchanged) != 1 || len(diff.Modified()) != 1 {
+		t.Fatalf("Expected 1 local-only, 1 unchanged and 1 modified file, got %+v", diff.Files)
+	}
+	if len(diff.Inherited) == 0 {
+		t.Error("Expected the files not copied to be listed as inherited")
+	}
+
+	f := diff.Modified()[0]
+	if f.Path != "components/toolbar.tmpl" || f.Changes() != 2 {
+		t.Fatalf("Expected 2 changes in components/toolbar.tmpl, got %d in %s", f.Changes(), f.Path)
+	}
+	if added, removed := f.Stat(); added != 2 || removed != 1 {
+		t.Errorf("Stat() = +%d -%d, want +2 -1", added, removed)
+	}
+	unified := f.Unified("a", "b", 3)
+	for _, want := range []string{"--- a\n+++ b\n", "-" + strings.Split(string(upstream), "\n")[0], "+{{/* my toolbar */}}", "+<!-- mine -->"} {
+		if !strings.Contains(unified, want) {
+			t.Errorf("Unified diff missing %q:\n%s", want, unified)
+		}
+	}
+
+	// Taking only the first change keeps the second customization
+	merged := f.Merge(func(change int) bool { return change == 0 })
+	if strings.Contains(merged, "my toolbar") || !strings.Contains(merged, "<!-- mine -->") {
+		t.Errorf("Merge took the wrong changes:\n%s", merged)
+	}
+	if got := f.Merge(func(int) bool { return true }); got != string(upstream) {
+		t.Error("Taking every change should give the embedded version")
+	}
+	if got := f.Merge(func(int) bool { return false }); got != local {
+		t.Error("Taking no change should keep the local copy")
+	}
+
+	if _, err := DiffWithEmbedded(dir, "no-such-kit"); err == nil {
+		t.Error("Expected an error for a kit that is not embedded")
+	}
+}
+
+func mustReadEmbedded(t *testing.T, path string) string {
+	t.Helper()
+	data, err := systemKits.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
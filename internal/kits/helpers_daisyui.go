@@ -0,0 +1,412 @@
+package kits
+
+// DaisyUIThemeKey is the localStorage key the theme switcher saves the
+// chosen theme under.
+const DaisyUIThemeKey = "lvt-theme"
+
+// DaisyUIThemes are the themes shipped in daisyUI's themes.css, any of which
+// can be a project's default (lvt new --theme) or picked in the switcher.
+var DaisyUIThemes = []string{
+	"light", "dark", "cupcake", "bumblebee", "emerald", "corporate",
+	"synthwave", "retro", "cyberpunk", "valentine", "halloween", "garden",
+	"forest", "aqua", "lofi", "pastel", "fantasy", "wireframe", "black",
+	"luxury", "dracula", "cmyk", "autumn", "business", "acid", "lemonade",
+	"night", "coffee", "winter", "dim", "nord", "sunset", "caramellatte",
+	"abyss", "silk",
+}
+
+// IsDaisyUITheme reports whether name is one of DaisyUIThemes.
+func IsDaisyUITheme(name string) bool {
+	for _, theme := range DaisyUIThemes {
+		if theme == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DaisyUIHelpers implements CSSHelpers for daisyUI on Tailwind CSS. Colors
+// come from the theme's semantic tokens (base-100, base-content, primary,
+// ...), so every class follows the active theme, dark ones included.
+type DaisyUIHelpers struct {
+	BaseHelpers
+}
+
+// NewDaisyUIHelpers creates a new daisyUI helper
+func NewDaisyUIHelpers() CSSHelpers {
+	return &DaisyUIHelpers{}
+}
+
+// Framework information. Besides the stylesheets, CSSCDN applies the theme
+// saved by the theme switcher before the page paints, and wires up every
+// <select data-theme-switcher> on the page: picking a theme applies it and
+// saves it in localStorage; the empty option goes back to the default.
+func (h *DaisyUIHelpers) CSSCDN() string {
+	return `<link href="https://cdn.jsdelivr.net/npm/daisyui@5" rel="stylesheet" type="text/css" />
+    <link href="https://cdn.jsdelivr.net/npm/daisyui@5/themes.css" rel="stylesheet" type="text/css" />
+    <script src="https://cdn.jsdelivr.net/npm/@tailwindcss/browser@4"></script>
+    <script>
+      (function() {
+        var key = "` + DaisyUIThemeKey + `", root = document.documentElement;
+        var fallback = root.getAttribute("data-theme") || "";
+        function saved() {
+          try { return localStorage.getItem(key) || ""; } catch (e) { return ""; }
+        }
+        function apply(theme) {
+          theme = theme || fallback;
+          if (theme) root.setAttribute("data-theme", theme); else root.removeAttribute("data-theme");
+        }
+        function sync() {
+          var theme = saved();
+          document.querySelectorAll("select[data-theme-switcher]").forEach(function(select) {
+            if (select.value !== theme) select.value = theme;
+          });
+        }
+        apply(saved());
+        document.addEventListener("change", function(e) {
+          if (!e.target.matches || !e.target.matches("select[data-theme-switcher]")) return;
+          try {
+            if (e.target.value) localStorage.setItem(key, e.target.value); else localStorage.removeItem(key);
+          } catch (err) {}
+          apply(e.target.value);
+        });
+        document.addEventListener("DOMContentLoaded", function() {
+          sync();
+          // Live updates re-render the switcher without its selection
+          new MutationObserver(sync).observe(document.body, { childList: true, subtree: true });
+        });
+      })();
+    </script>`
+}
+
+// Layout helpers
+func (h *DaisyUIHelpers) ContainerClass() string {
+	return "max-w-7xl mx-auto px-4 py-8"
+}
+
+func (h *DaisyUIHelpers) SectionClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) BoxClass() string {
+	return "card bg-base-100 text-base-content border border-base-300 shadow-sm p-6 mb-6"
+}
+
+func (h *DaisyUIHelpers) ColumnClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) ColumnsClass() string {
+	return "grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-4"
+}
+
+// Form helpers
+func (h *DaisyUIHelpers) FieldClass() string {
+	return "fieldset mb-2"
+}
+
+func (h *DaisyUIHelpers) LabelClass() string {
+	return "fieldset-legend"
+}
+
+func (h *DaisyUIHelpers) InputClass() string {
+	return "input w-full"
+}
+
+func (h *DaisyUIHelpers) TextareaClass() string {
+	return "textarea w-full"
+}
+
+func (h *DaisyUIHelpers) SelectClass() string {
+	return "select w-full"
+}
+
+func (h *DaisyUIHelpers) CheckboxClass() string {
+	return "label gap-2"
+}
+
+func (h *DaisyUIHelpers) RadioClass() string {
+	return "label gap-2"
+}
+
+func (h *DaisyUIHelpers) ButtonClass(variant string) string {
+	switch variant {
+	case "primary":
+		return "btn btn-primary"
+	case "secondary":
+		return "btn btn-ghost"
+	case "danger":
+		return "btn btn-error"
+	default:
+		return "btn btn-primary"
+	}
+}
+
+func (h *DaisyUIHelpers) ButtonGroupClass() string {
+	return "join"
+}
+
+func (h *DaisyUIHelpers) FormClass() string {
+	return "space-y-4"
+}
+
+// Table helpers
+func (h *DaisyUIHelpers) TableClass() string {
+	return "table"
+}
+
+func (h *DaisyUIHelpers) TheadClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) TbodyClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) ThClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) TdClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) TrClass() string {
+	return "hover:bg-base-200"
+}
+
+func (h *DaisyUIHelpers) TableContainerClass() string {
+	return "overflow-x-auto"
+}
+
+// Navigation helpers
+func (h *DaisyUIHelpers) NavbarClass() string {
+	return "navbar bg-base-100 shadow-sm"
+}
+
+func (h *DaisyUIHelpers) NavbarBrandClass() string {
+	return "btn btn-ghost text-xl"
+}
+
+func (h *DaisyUIHelpers) NavbarMenuClass() string {
+	return "menu menu-horizontal px-1"
+}
+
+func (h *DaisyUIHelpers) NavbarItemClass() string {
+	return ""
+}
+
+func (h *DaisyUIHelpers) NavbarStartClass() string {
+	return "navbar-start"
+}
+
+func (h *DaisyUIHelpers) NavbarEndClass() string {
+	return "navbar-end"
+}
+
+// Text/Typography helpers
+func (h *DaisyUIHelpers) TitleClass(level int) string {
+	switch level {
+	case 2:
+		return "text-2xl font-bold mb-4"
+	case 3:
+		return "text-xl font-bold mb-3"
+	default:
+		return "text-3xl font-bold mb-6"
+	}
+}
+
+func (h *DaisyUIHelpers) SubtitleClass() string {
+	return "text-xl font-semibold mb-4"
+}
+
+func (h *DaisyUIHelpers) TextClass(size string) string {
+	switch size {
+	case "small":
+		return "text-sm"
+	case "large":
+		return "text-lg"
+	default:
+		return ""
+	}
+}
+
+func (h *DaisyUIHelpers) TextMutedClass() string {
+	return "text-base-content/60"
+}
+
+func (h *DaisyUIHelpers) TextPrimaryClass() string {
+	return "text-primary"
+}
+
+func (h *DaisyUIHelpers) TextDangerClass() string {
+	return "text-error"
+}
+
+func (h *DaisyUIHelpers) TextSuccessClass() string {
+	return "text-success"
+}
+
+func (h *DaisyUIHelpers) TextWarningClass() string {
+	return "text-warning"
+}
+
+// Pagination helpers
+func (h *DaisyUIHelpers) PaginationClass() string {
+	return "flex justify-between items-center mt-4"
+}
+
+func (h *DaisyUIHelpers) PaginationButtonClass(state string) string {
+	if state == "active" {
+		return "join-item btn btn-sm btn-active"
+	}
+	return "join-item btn btn-sm"
+}
+
+func (h *DaisyUIHelpers) PaginationListClass() string {
+	return "join"
+}
+
+func (h *DaisyUIHelpers) PaginationItemClass() string {
+	return ""
+}
+
+// Card/Panel helpers
+func (h *DaisyUIHelpers) CardClass() string {
+	return "card bg-base-100 shadow-sm"
+}
+
+func (h *DaisyUIHelpers) CardHeaderClass() string {
+	return "card-title px-6 pt-6"
+}
+
+func (h *DaisyUIHelpers) CardBodyClass() string {
+	return "card-body"
+}
+
+func (h *DaisyUIHelpers) CardFooterClass() string {
+	return "card-actions justify-end px-6 pb-6"
+}
+
+// Modal/Dialog helpers
+func (h *DaisyUIHelpers) ModalClass() string {
+	return "modal modal-open"
+}
+
+func (h *DaisyUIHelpers) ModalBackgroundClass() string {
+	return "modal-backdrop"
+}
+
+func (h *DaisyUIHelpers) ModalContentClass() string {
+	return "modal-box"
+}
+
+func (h *DaisyUIHelpers) ModalCloseClass() string {
+	return "btn btn-sm btn-circle btn-ghost absolute right-2 top-2"
+}
+
+// Alert/Notification helpers
+func (h *DaisyUIHelpers) AlertClass(variant string) string {
+	switch variant {
+	case "success":
+		return "alert alert-success"
+	case "danger":
+		return "alert alert-error"
+	case "warning":
+		return "alert alert-warning"
+	case "info":
+		return "alert alert-info"
+	default:
+		return "alert"
+	}
+}
+
+func (h *DaisyUIHelpers) NotificationClass(variant string) string {
+	return h.AlertClass(variant)
+}
+
+// Badge/Tag helpers
+func (h *DaisyUIHelpers) BadgeClass(variant string) string {
+	switch variant {
+	case "primary":
+		return "badge badge-primary"
+	case "success":
+		return "badge badge-success"
+	case "danger":
+		return "badge badge-error"
+	default:
+		return "badge badge-neutral"
+	}
+}
+
+func (h *DaisyUIHelpers) TagClass(variant string) string {
+	return h.BadgeClass(variant)
+}
+
+// Loading/Spinner helpers
+func (h *DaisyUIHelpers) SpinnerClass() string {
+	return "loading loading-spinner loading-sm"
+}
+
+func (h *DaisyUIHelpers) LoadingClass() string {
+	return "text-base-content/60 animate-pulse"
+}
+
+// Grid helpers
+func (h *DaisyUIHelpers) GridClass() string {
+	return "grid gap-4"
+}
+
+func (h *DaisyUIHelpers) GridItemClass() string {
+	return ""
+}
+
+// Flex helpers
+func (h *DaisyUIHelpers) FlexClass() string {
+	return "flex"
+}
+
+func (h *DaisyUIHelpers) FlexItemClass() string {
+	return ""
+}
+
+// Spacing helpers
+func (h *DaisyUIHelpers) MarginClass(size string) string {
+	switch size {
+	case "small":
+		return "m-2"
+	case "large":
+		return "m-8"
+	default:
+		return "m-4"
+	}
+}
+
+func (h *DaisyUIHelpers) PaddingClass(size string) string {
+	switch size {
+	case "small":
+		return "p-2"
+	case "large":
+		return "p-8"
+	default:
+		return "p-4"
+	}
+}
+
+// Display helpers
+func (h *DaisyUIHelpers) HiddenClass() string {
+	return "hidden"
+}
+
+func (h *DaisyUIHelpers) VisibleClass() string {
+	return "block"
+}
+
+// Framework-specific checks
+func (h *DaisyUIHelpers) NeedsWrapper() bool {
+	return false
+}
+
+func (h *DaisyUIHelpers) NeedsArticle() bool {
+	return false
+}
@@ -0,0 +1,366 @@
+package kits
+
+import (
+	"strconv"
+	"strings"
+)
+
+// overridableHelpers are the helper names a manifest's helpers section can
+// override: the CSSHelpers methods returning classes or markup.
+var overridableHelpers = map[string]bool{
+	"csscdn":                true,
+	"containerClass":        true,
+	"sectionClass":          true,
+	"boxClass":              true,
+	"columnClass":           true,
+	"columnsClass":          true,
+	"fieldClass":            true,
+	"labelClass":            true,
+	"inputClass":            true,
+	"textareaClass":         true,
+	"selectClass":           true,
+	"checkboxClass":         true,
+	"radioClass":            true,
+	"buttonClass":           true,
+	"buttonGroupClass":      true,
+	"formClass":             true,
+	"tableClass":            true,
+	"theadClass":            true,
+	"tbodyClass":            true,
+	"thClass":               true,
+	"tdClass":               true,
+	"trClass":               true,
+	"tableContainerClass":   true,
+	"navbarClass":           true,
+	"navbarBrandClass":      true,
+	"navbarMenuClass":       true,
+	"navbarItemClass":       true,
+	"navbarStartClass":      true,
+	"navbarEndClass":        true,
+	"titleClass":            true,
+	"subtitleClass":         true,
+	"textClass":             true,
+	"textMutedClass":        true,
+	"textPrimaryClass":      true,
+	"textDangerClass":       true,
+	"textSuccessClass":      true,
+	"textWarningClass":      true,
+	"paginationClass":       true,
+	"paginationButtonClass": true,
+	"paginationListClass":   true,
+	"paginationItemClass":   true,
+	"cardClass":             true,
+	"cardHeaderClass":       true,
+	"cardBodyClass":         true,
+	"cardFooterClass":       true,
+	"modalClass":            true,
+	"modalBackgroundClass":  true,
+	"modalContentClass":     true,
+	"modalCloseClass":       true,
+	"alertClass":            true,
+	"notificationClass":     true,
+	"badgeClass":            true,
+	"tagClass":              true,
+	"spinnerClass":          true,
+	"loadingClass":          true,
+	"gridClass":             true,
+	"gridItemClass":         true,
+	"flexClass":             true,
+	"flexItemClass":         true,
+	"marginClass":           true,
+	"paddingClass":          true,
+	"hiddenClass":           true,
+	"visibleClass":          true,
+}
+
+// IsOverridableHelper reports whether key (name or name.variant) names a
+// helper a manifest can override.
+func IsOverridableHelper(key string) bool {
+	name, _, _ := strings.Cut(key, ".")
+	return overridableHelpers[name]
+}
+
+// overrideHelpers wraps a kit's helpers with the overrides of its manifest
+// (and the manifests it extends).
+type overrideHelpers struct {
+	CSSHelpers
+	overrides map[string]string
+}
+
+// withOverrides returns helpers with overrides applied, or helpers itself
+// when there are none.
+func withOverrides(helpers CSSHelpers, overrides map[string]string) CSSHelpers {
+	if helpers == nil || len(overrides) == 0 {
+		return helpers
+	}
+	if o, ok := helpers.(*overrideHelpers); ok {
+		helpers = o.CSSHelpers
+	}
+	return &overrideHelpers{CSSHelpers: helpers, overrides: overrides}
+}
+
+// class returns the override for name.variant, else for name, else the
+// wrapped helper's result.
+func (h *overrideHelpers) class(name, variant string, fallback func() string) string {
+	if variant != "" {
+		if v, ok := h.overrides[name+"."+variant]; ok {
+			return v
+		}
+	}
+	if v, ok := h.overrides[name]; ok {
+		return v
+	}
+	return fallback()
+}
+
+func (h *overrideHelpers) CSSCDN() string {
+	return h.class("csscdn", "", h.CSSHelpers.CSSCDN)
+}
+
+func (h *overrideHelpers) ContainerClass() string {
+	return h.class("containerClass", "", h.CSSHelpers.ContainerClass)
+}
+
+func (h *overrideHelpers) SectionClass() string {
+	return h.class("sectionClass", "", h.CSSHelpers.SectionClass)
+}
+
+func (h *overrideHelpers) BoxClass() string {
+	return h.class("boxClass", "", h.CSSHelpers.BoxClass)
+}
+
+func (h *overrideHelpers) ColumnClass() string {
+	return h.class("columnClass", "", h.CSSHelpers.ColumnClass)
+}
+
+func (h *overrideHelpers) ColumnsClass() string {
+	return h.class("columnsClass", "", h.CSSHelpers.ColumnsClass)
+}
+
+func (h *overrideHelpers) FieldClass() string {
+	return h.class("fieldClass", "", h.CSSHelpers.FieldClass)
+}
+
+func (h *overrideHelpers) LabelClass() string {
+	return h.class("labelClass", "", h.CSSHelpers.LabelClass)
+}
+
+func (h *overrideHelpers) InputClass() string {
+	return h.class("inputClass", "", h.CSSHelpers.InputClass)
+}
+
+func (h *overrideHelpers) TextareaClass() string {
+	return h.class("textareaClass", "", h.CSSHelpers.TextareaClass)
+}
+
+func (h *overrideHelpers) SelectClass() string {
+	return h.class("selectClass", "", h.CSSHelpers.SelectClass)
+}
+
+func (h *overrideHelpers) CheckboxClass() string {
+	return h.class("checkboxClass", "", h.CSSHelpers.CheckboxClass)
+}
+
+func (h *overrideHelpers) RadioClass() string {
+	return h.class("radioClass", "", h.CSSHelpers.RadioClass)
+}
+
+func (h *overrideHelpers) ButtonClass(variant string) string {
+	return h.class("buttonClass", variant, func() string { return h.CSSHelpers.ButtonClass(variant) })
+}
+
+func (h *overrideHelpers) ButtonGroupClass() string {
+	return h.class("buttonGroupClass", "", h.CSSHelpers.ButtonGroupClass)
+}
+
+func (h *overrideHelpers) FormClass() string {
+	return h.class("formClass", "", h.CSSHelpers.FormClass)
+}
+
+func (h *overrideHelpers) TableClass() string {
+	return h.class("tableClass", "", h.CSSHelpers.TableClass)
+}
+
+func (h *overrideHelpers) TheadClass() string {
+	return h.class("theadClass", "", h.CSSHelpers.TheadClass)
+}
+
+func (h *overrideHelpers) TbodyClass() string {
+	return h.class("tbodyClass", "", h.CSSHelpers.TbodyClass)
+}
+
+func (h *overrideHelpers) ThClass() string {
+	return h.class("thClass", "", h.CSSHelpers.ThClass)
+}
+
+func (h *overrideHelpers) TdClass() string {
+	return h.class("tdClass", "", h.CSSHelpers.TdClass)
+}
+
+func (h *overrideHelpers) TrClass() string {
+	return h.class("trClass", "", h.CSSHelpers.TrClass)
+}
+
+func (h *overrideHelpers) TableContainerClass() string {
+	return h.class("tableContainerClass", "", h.CSSHelpers.TableContainerClass)
+}
+
+func (h *overrideHelpers) NavbarClass() string {
+	return h.class("navbarClass", "", h.CSSHelpers.NavbarClass)
+}
+
+func (h *overrideHelpers) NavbarBrandClass() string {
+	return h.class("navbarBrandClass", "", h.CSSHelpers.NavbarBrandClass)
+}
+
+func (h *overrideHelpers) NavbarMenuClass() string {
+	return h.class("navbarMenuClass", "", h.CSSHelpers.NavbarMenuClass)
+}
+
+func (h *overrideHelpers) NavbarItemClass() string {
+	return h.class("navbarItemClass", "", h.CSSHelpers.NavbarItemClass)
+}
+
+func (h *overrideHelpers) NavbarStartClass() string {
+	return h.class("navbarStartClass", "", h.CSSHelpers.NavbarStartClass)
+}
+
+func (h *overrideHelpers) NavbarEndClass() string {
+	return h.class("navbarEndClass", "", h.CSSHelpers.NavbarEndClass)
+}
+
+func (h *overrideHelpers) TitleClass(level int) string {
+	return h.class("titleClass", strconv.Itoa(level), func() string { return h.CSSHelpers.TitleClass(level) })
+}
+
+func (h *overrideHelpers) SubtitleClass() string {
+	return h.class("subtitleClass", "", h.CSSHelpers.SubtitleClass)
+}
+
+func (h *overrideHelpers) TextClass(size string) string {
+	return h.class("textClass", size, func() string { return h.CSSHelpers.TextClass(size) })
+}
+
+func (h *overrideHelpers) TextMutedClass() string {
+	return h.class("textMutedClass", "", h.CSSHelpers.TextMutedClass)
+}
+
+func (h *overrideHelpers) TextPrimaryClass() string {
+	return h.class("textPrimaryClass", "", h.CSSHelpers.TextPrimaryClass)
+}
+
+func (h *overrideHelpers) TextDangerClass() string {
+	return h.class("textDangerClass", "", h.CSSHelpers.TextDangerClass)
+}
+
+func (h *overrideHelpers) TextSuccessClass() string {
+	return h.class("textSuccessClass", "", h.CSSHelpers.TextSuccessClass)
+}
+
+func (h *overrideHelpers) TextWarningClass() string {
+	return h.class("textWarningClass", "", h.CSSHelpers.TextWarningClass)
+}
+
+func (h *overrideHelpers) PaginationClass() string {
+	return h.class("paginationClass", "", h.CSSHelpers.PaginationClass)
+}
+
+func (h *overrideHelpers) PaginationButtonClass(state string) string {
+	return h.class("paginationButtonClass", state, func() string { return h.CSSHelpers.PaginationButtonClass(state) })
+}
+
+func (h *overrideHelpers) PaginationListClass() string {
+	return h.class("paginationListClass", "", h.CSSHelpers.PaginationListClass)
+}
+
+func (h *overrideHelpers) PaginationItemClass() string {
+	return h.class("paginationItemClass", "", h.CSSHelpers.PaginationItemClass)
+}
+
+func (h *overrideHelpers) CardClass() string {
+	return h.class("cardClass", "", h.CSSHelpers.CardClass)
+}
+
+func (h *overrideHelpers) CardHeaderClass() string {
+	return h.class("cardHeaderClass", "", h.CSSHelpers.CardHeaderClass)
+}
+
+func (h *overrideHelpers) CardBodyClass() string {
+	return h.class("cardBodyClass", "", h.CSSHelpers.CardBodyClass)
+}
+
+func (h *overrideHelpers) CardFooterClass() string {
+	return h.class("cardFooterClass", "", h.CSSHelpers.CardFooterClass)
+}
+
+func (h *overrideHelpers) ModalClass() string {
+	return h.class("modalClass", "", h.CSSHelpers.ModalClass)
+}
+
+func (h *overrideHelpers) ModalBackgroundClass() string {
+	return h.class("modalBackgroundClass", "", h.CSSHelpers.ModalBackgroundClass)
+}
+
+func (h *overrideHelpers) ModalContentClass() string {
+	return h.class("modalContentClass", "", h.CSSHelpers.ModalContentClass)
+}
+
+func (h *overrideHelpers) ModalCloseClass() string {
+	return h.class("modalCloseClass", "", h.CSSHelpers.ModalCloseClass)
+}
+
+func (h *overrideHelpers) AlertClass(variant string) string {
+	return h.class("alertClass", variant, func() string { return h.CSSHelpers.AlertClass(variant) })
+}
+
+func (h *overrideHelpers) NotificationClass(variant string) string {
+	return h.class("notificationClass", variant, func() string { return h.CSSHelpers.NotificationClass(variant) })
+}
+
+func (h *overrideHelpers) BadgeClass(variant string) string {
+	return h.class("badgeClass", variant, func() string { return h.CSSHelpers.BadgeClass(variant) })
+}
+
+func (h *overrideHelpers) TagClass(variant string) string {
+	return h.class("tagClass", variant, func() string { return h.CSSHelpers.TagClass(variant) })
+}
+
+func (h *overrideHelpers) SpinnerClass() string {
+	return h.class("spinnerClass", "", h.CSSHelpers.SpinnerClass)
+}
+
+func (h *overrideHelpers) LoadingClass() string {
+	return h.class("loadingClass", "", h.CSSHelpers.LoadingClass)
+}
+
+func (h *overrideHelpers) GridClass() string {
+	return h.class("gridClass", "", h.CSSHelpers.GridClass)
+}
+
+func (h *overrideHelpers) GridItemClass() string {
+	return h.class("gridItemClass", "", h.CSSHelpers.GridItemClass)
+}
+
+func (h *overrideHelpers) FlexClass() string {
+	return h.class("flexClass", "", h.CSSHelpers.FlexClass)
+}
+
+func (h *overrideHelpers) FlexItemClass() string {
+	return h.class("flexItemClass", "", h.CSSHelpers.FlexItemClass)
+}
+
+func (h *overrideHelpers) MarginClass(size string) string {
+	return h.class("marginClass", size, func() string { return h.CSSHelpers.MarginClass(size) })
+}
+
+func (h *overrideHelpers) PaddingClass(size string) string {
+	return h.class("paddingClass", size, func() string { return h.CSSHelpers.PaddingClass(size) })
+}
+
+func (h *overrideHelpers) HiddenClass() string {
+	return h.class("hiddenClass", "", h.CSSHelpers.HiddenClass)
+}
+
+func (h *overrideHelpers) VisibleClass() string {
+	return h.class("visibleClass", "", h.CSSHelpers.VisibleClass)
+}
@@ -0,0 +1,182 @@
+package kits
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed icons
+var iconSets embed.FS
+
+// IconsDir is the kit directory holding SVG files that replace icons of the
+// kit's icon set, or add icons to it, by name (icons/trash.svg).
+const IconsDir = "icons"
+
+// IconSets lists the embedded icon sets a kit can select with icons:.
+var IconSets = []string{"heroicons", "lucide"}
+
+// IconNames lists the icons every embedded set provides. Generated
+// toolbars, row actions, modals and pagination use them.
+var IconNames = []string{
+	"check",
+	"chevron-down",
+	"chevron-left",
+	"chevron-right",
+	"chevron-up",
+	"close",
+	"edit",
+	"plus",
+	"search",
+	"trash",
+	"view",
+}
+
+var (
+	iconNamePattern  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	svgNoisePattern  = regexp.MustCompile(`(?s)<\?xml.*?\?>|<!--.*?-->|<!DOCTYPE[^>]*>`)
+	svgSpacePattern  = regexp.MustCompile(`>\s+<`)
+	svgAttrsToRedo   = regexp.MustCompile(`\s(width|height|class|style|aria-hidden|focusable)="[^"]*"`)
+	svgOpeningTagEnd = regexp.MustCompile(`^<svg\b[^>]*?(/?>)`)
+)
+
+// IsIconSet reports whether name is an embedded icon set.
+func IsIconSet(name string) bool {
+	for _, set := range IconSets {
+		if set == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IconSet returns the icon set the kit uses, its own or inherited, or ""
+// when the kit uses none.
+func (k *KitInfo) IconSet() string {
+	for _, kit := range k.Chain() {
+		if kit.Manifest.Icons != "" {
+			return kit.Manifest.Icons
+		}
+	}
+	return ""
+}
+
+// Icon returns the named icon as inline SVG sized to the surrounding text,
+// with class lvt-icon plus the given classes. The kit's icons directory
+// (or that of a kit it extends) wins over the icon set. Icon returns ""
+// when the kit uses no icon set and does not ship the icon, so templates
+// fall back to text-only buttons.
+func (k *KitInfo) Icon(name string, class ...string) (string, error) {
+	if !iconNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid icon name %q", name)
+	}
+
+	for _, kit := range k.Chain() {
+		data, err := kit.ownIcon(name)
+		if err != nil {
+			return "", err
+		}
+		if data != nil {
+			return renderIcon(data, name, class)
+		}
+	}
+
+	set := k.IconSet()
+	if set == "" {
+		return "", nil
+	}
+	if !IsIconSet(set) {
+		return "", fmt.Errorf("kit %s uses unknown icon set %q (available: %s)", k.Manifest.Name, set, strings.Join(IconSets, ", "))
+	}
+	data, err := iconSets.ReadFile(path.Join(IconsDir, set, name+".svg"))
+	if err != nil {
+		return "", fmt.Errorf("icon set %s has no icon %q", set, name)
+	}
+	return renderIcon(data, name, class)
+}
+
+// Icons returns the names of the icons the kit can render, sorted.
+func (k *KitInfo) Icons() ([]string, error) {
+	names := make(map[string]bool)
+	if set := k.IconSet(); IsIconSet(set) {
+		entries, err := fs.ReadDir(iconSets, path.Join(IconsDir, set))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if name, ok := strings.CutSuffix(entry.Name(), ".svg"); ok {
+				names[name] = true
+			}
+		}
+	}
+	for _, kit := range k.Chain() {
+		own, err := kit.ownIconNames()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range own {
+			names[name] = true
+		}
+	}
+
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// ownIcon reads icons/<name>.svg of the kit itself, or returns nil when the
+// kit does not override the icon.
+func (k *KitInfo) ownIcon(name string) ([]byte, error) {
+	fsys, err := k.fsys()
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(fsys, path.Join(IconsDir, name+".svg"))
+	if err != nil {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// ownIconNames lists the icons of the kit's own icons directory.
+func (k *KitInfo) ownIconNames() ([]string, error) {
+	fsys, err := k.fsys()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(fsys, IconsDir)
+	if err != nil {
+		return nil, nil
+	}
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".svg")
+		if ok && !entry.IsDir() && iconNamePattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// renderIcon rewrites an SVG file for inline use: prolog and comments go,
+// and the size, class and accessibility attributes are set.
+func renderIcon(data []byte, name string, class []string) (string, error) {
+	svg := svgNoisePattern.ReplaceAllString(string(data), "")
+	svg = strings.TrimSpace(svgSpacePattern.ReplaceAllString(svg, "><"))
+
+	tag := svgOpeningTagEnd.FindStringSubmatchIndex(svg)
+	if tag == nil {
+		return "", fmt.Errorf("icon %s is not an SVG file", name)
+	}
+	opening := svgAttrsToRedo.ReplaceAllString(svg[:tag[2]], "")
+	classes := strings.TrimSpace("lvt-icon " + strings.Join(class, " "))
+	attrs := fmt.Sprintf(` class="%s" width="1em" height="1em" style="vertical-align: -0.125em;" aria-hidden="true" focusable="false"`, classes)
+	return strings.TrimRight(opening, " ") + attrs + svg[tag[2]:], nil
+}
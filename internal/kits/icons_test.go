@@ -0,0 +1,102 @@
+package kits
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIconSetsProvideEveryIcon(t *testing.T) {
+	for _, set := range IconSets {
+		kit := &KitInfo{Manifest: KitManifest{Name: "test", Icons: set}, Source: SourceLocal, Path: t.TempDir()}
+		for _, name := range IconNames {
+			svg, err := kit.Icon(name)
+			if err != nil {
+				t.Errorf("%s: %v", set, err)
+				continue
+			}
+			if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>") || strings.Contains(svg, "\n") {
+				t.Errorf("%s/%s: expected inline SVG on one line, got %s", set, name, svg)
+			}
+			if !strings.Contains(svg, `class="lvt-icon" width="1em" height="1em"`) || !strings.Contains(svg, `aria-hidden="true"`) {
+				t.Errorf("%s/%s: missing icon attributes: %s", set, name, svg)
+			}
+		}
+	}
+}
+
+func TestKitIcons(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeKitFiles(t, filepath.Join(tmpDir, "acme-base"), map[string]string{
+		"kit.yaml":       "name: acme-base\nversion: 1.0.0\ndescription: Base\ncss_framework: tailwind\nicons: lucide\n",
+		"icons/logo.svg": `<?xml version="1.0"?><!-- logo --><svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" class="big"><circle r="4"/></svg>`,
+	})
+	writeKitFiles(t, filepath.Join(tmpDir, "acme"), map[string]string{
+		"kit.yaml":        "name: acme\nversion: 1.0.0\ndescription: Acme\nextends: acme-base\n",
+		"icons/trash.svg": "<svg viewBox=\"0 0 24 24\">\n  <path d=\"M1 1h22\"/>\n</svg>\n",
+	})
+
+	loader := DefaultLoader()
+	loader.AddSearchPath(tmpDir)
+	kit, err := loader.Load("acme")
+	if err != nil {
+		t.Fatalf("Failed to load kit: %v", err)
+	}
+
+	if set := kit.IconSet(); set != "lucide" {
+		t.Errorf("IconSet() = %q, want the inherited lucide", set)
+	}
+
+	trash, err := kit.Icon("trash", "text-red-600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<svg viewBox="0 0 24 24" class="lvt-icon text-red-600" width="1em" height="1em" style="vertical-align: -0.125em;" aria-hidden="true" focusable="false"><path d="M1 1h22"/></svg>`
+	if trash != want {
+		t.Errorf("Icon(trash) = %s, want the kit's override %s", trash, want)
+	}
+
+	logo, err := kit.Icon("logo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(logo, "<?xml") || strings.Contains(logo, "logo -->") || strings.Contains(logo, `"48"`) || strings.Contains(logo, "big") {
+		t.Errorf("Icon(logo) should drop the prolog, comments and size attributes: %s", logo)
+	}
+
+	plus, err := kit.Icon("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plus, `stroke-width="2"`) {
+		t.Errorf("Icon(plus) should come from lucide: %s", plus)
+	}
+
+	if _, err := kit.Icon("no-such-icon"); err == nil {
+		t.Error("Expected an error for an icon the set does not have")
+	}
+	if _, err := kit.Icon("../kit.yaml"); err == nil {
+		t.Error("Expected an error for an invalid icon name")
+	}
+
+	icons, err := kit.Icons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(icons, " "); !strings.Contains(got, "logo") || !strings.Contains(got, "trash") || !strings.Contains(got, "chevron-left") {
+		t.Errorf("Icons() = %s, want the set's icons and the kits' own", got)
+	}
+}
+
+func TestKitIcons_None(t *testing.T) {
+	kit := &KitInfo{Manifest: KitManifest{Name: "plain"}, Source: SourceLocal, Path: t.TempDir()}
+	svg, err := kit.Icon("trash")
+	if err != nil || svg != "" {
+		t.Errorf("Icon() without an icon set = %q, %v; want \"\", nil", svg, err)
+	}
+
+	m := KitManifest{Name: "x", Version: "1.0.0", Description: "x", CSSFramework: "tailwind", Icons: "fontawesome"}
+	if err := m.Validate(); err == nil {
+		t.Error("Expected an unknown icon set to fail manifest validation")
+	}
+}
@@ -6,11 +6,11 @@ import (
 	"testing"
 )
 
-// TestKitFeatureParity is a smoke test that ensures the multi and single kit
-// monolithic templates all include a shared set of critical UI features. This
+// TestKitFeatureParity is a smoke test that ensures the multi, single and
+// daisyui kit monolithic templates all include a shared set of critical UI features. This
 // catches regressions where a kit template loses a required feature.
 func TestKitFeatureParity(t *testing.T) {
-	kits := []string{"multi", "single"}
+	kits := []string{"multi", "single", "daisyui"}
 
 	// Features that both CRUD kits must have in their monolithic template.
 	requiredFeatures := []struct {
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/logging"
 	"gopkg.in/yaml.v3"
 )
 
@@ -58,30 +60,105 @@ func (l *KitLoader) buildSearchPaths() {
 	}
 
 	l.searchPaths = paths
+	logging.Debug("kit search paths", "paths", paths)
 }
 
-// Load loads a kit by name from the first matching source
+// Load loads a kit by name from the first matching source, along with the
+// kits it extends
 func (l *KitLoader) Load(name string) (*KitInfo, error) {
+	return l.load(name, nil)
+}
+
+// load loads a kit; extending holds the kits whose parent is being loaded,
+// to catch inheritance cycles.
+func (l *KitLoader) load(name string, extending []string) (*KitInfo, error) {
+	for _, kit := range extending {
+		if kit == name {
+			return nil, ErrInvalidManifest{Field: "extends", Reason: fmt.Sprintf("inheritance cycle: %s -> %s", strings.Join(extending, " -> "), name)}
+		}
+	}
+
 	// Check cache first
 	if cached, exists := l.cache[name]; exists {
+		logging.Debug("kit resolved", "kit", name, "source", cached.Source, "cached", true)
 		return cached, nil
 	}
 
+	kit, err := l.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.resolveParent(kit, append(extending, name)); err != nil {
+		return nil, err
+	}
+	l.cache[name] = kit
+	return kit, nil
+}
+
+// resolveParent loads the kit's parent and fills in what the kit inherits
+// from it: the CSS framework, components, templates and helper overrides.
+func (l *KitLoader) resolveParent(kit *KitInfo, extending []string) error {
+	m := &kit.Manifest
+	if m.Extends == "" {
+		return nil
+	}
+	parent, err := l.load(m.Extends, extending)
+	if err != nil {
+		return fmt.Errorf("kit %s extends %s: %w", m.Name, m.Extends, err)
+	}
+	kit.Parent = parent
+	pm := parent.Manifest
+
+	if m.CSSFramework == "" {
+		m.CSSFramework = pm.CSSFramework
+		m.Framework = pm.Framework
+	}
+	if len(m.Components) == 0 {
+		m.Components = pm.Components
+	}
+	if m.Templates == (KitTemplates{}) {
+		m.Templates = pm.Templates
+	}
+	if len(pm.Helpers) > 0 {
+		helpers := make(map[string]string, len(pm.Helpers)+len(m.Helpers))
+		for k, v := range pm.Helpers {
+			helpers[k] = v
+		}
+		for k, v := range m.Helpers {
+			helpers[k] = v
+		}
+		m.Helpers = helpers
+	}
+
+	if kit.Helpers == nil && m.CSSFramework == pm.CSSFramework {
+		kit.Helpers = parent.Helpers
+	}
+	kit.Helpers = withOverrides(kit.Helpers, m.Helpers)
+	return nil
+}
+
+// find loads a kit by name from the first matching source, without
+// resolving its parent
+func (l *KitLoader) find(name string) (*KitInfo, error) {
 	// Try to load from search paths (local)
 	for _, basePath := range l.searchPaths {
 		kitPath := filepath.Join(basePath, name)
-		if kit, err := l.loadFromPath(kitPath, SourceLocal); err == nil {
-			l.cache[name] = kit
+		kit, err := l.loadFromPath(kitPath, SourceLocal)
+		if err == nil {
+			logging.Debug("kit resolved", "kit", name, "source", kit.Source, "path", kitPath)
 			return kit, nil
 		}
+		logging.Debug("kit candidate skipped", "kit", name, "path", kitPath, "reason", err)
 	}
 
 	// Try to load from embedded system kits
 	if l.embedFS != nil {
-		if kit, err := l.loadFromEmbedded(name); err == nil {
-			l.cache[name] = kit
+		kit, err := l.loadFromEmbedded(name)
+		if err == nil {
+			logging.Debug("kit resolved", "kit", name, "source", kit.Source, "path", "embedded:"+kit.Path)
 			return kit, nil
 		}
+		logging.Debug("kit candidate skipped", "kit", name, "path", "embedded:system/"+name, "reason", err)
 	}
 
 	return nil, ErrKitNotFound{Name: name}
@@ -115,11 +192,18 @@ func (l *KitLoader) loadFromPath(path string, source KitSource) (*KitInfo, error
 		}
 	}
 
+	// Kits installed from git are community kits
+	if source == SourceLocal {
+		if _, err := os.Stat(filepath.Join(path, InstallRecordFile)); err == nil {
+			source = SourceCommunity
+		}
+	}
+
 	kit := &KitInfo{
 		Manifest: *manifest,
 		Source:   source,
 		Path:     path,
-		Helpers:  helpers,
+		Helpers:  withOverrides(helpers, manifest.Helpers),
 	}
 
 	return kit, nil
@@ -168,7 +252,7 @@ func (l *KitLoader) loadFromEmbedded(name string) (*KitInfo, error) {
 		Manifest: manifest,
 		Source:   SourceSystem,
 		Path:     kitPath,
-		Helpers:  helpers,
+		Helpers:  withOverrides(helpers, manifest.Helpers),
 	}
 
 	return kit, nil
@@ -209,6 +293,16 @@ func (l *KitLoader) List(opts *KitSearchOptions) ([]*KitInfo, error) {
 		}
 	}
 
+	// Fill in what each kit inherits; kits whose parent is missing are
+	// listed as they are
+	for _, kit := range kits {
+		if kit.Parent == nil {
+			if err := l.resolveParent(kit, []string{kit.Manifest.Name}); err != nil {
+				logging.Debug("kit parent not resolved", "kit", kit.Manifest.Name, "reason", err)
+			}
+		}
+	}
+
 	return kits, nil
 }
 
@@ -274,15 +368,46 @@ func (l *KitLoader) AddSearchPath(path string) {
 	l.ClearCache() // Clear cache when paths change
 }
 
+// Observer, when set, is called with each template and component a loader
+// serves: the kit it was asked for, its path in the kit
+// ("templates/resource/handler.go.tmpl", "components/form.tmpl") and its
+// content. Generators use it to record which templates produced a file.
+var Observer func(kitName, path string, data []byte)
+
+func observe(kitName, path string, data []byte, err error) ([]byte, error) {
+	if err == nil && Observer != nil {
+		Observer(kitName, path, data)
+	}
+	return data, err
+}
+
+// LoadKitFile loads a template or component by its path in the kit, as
+// passed to Observer.
+func (l *KitLoader) LoadKitFile(kitName, path string) ([]byte, error) {
+	if name, ok := strings.CutPrefix(path, "components/"); ok {
+		return l.LoadKitComponent(kitName, name)
+	}
+	if name, ok := strings.CutPrefix(path, "templates/"); ok {
+		return l.LoadKitTemplate(kitName, name)
+	}
+	return nil, fmt.Errorf("%s is not a template or component path", path)
+}
+
 // LoadKitComponent loads a component template from a kit following cascade priority
 // Cascade: Project (.lvt/kits/) → User (~/.config/lvt/kits/) → System (embedded)
 func (l *KitLoader) LoadKitComponent(kitName, componentName string) ([]byte, error) {
+	data, err := l.loadKitComponent(kitName, componentName)
+	return observe(kitName, "components/"+componentName, data, err)
+}
+
+func (l *KitLoader) loadKitComponent(kitName, componentName string) ([]byte, error) {
 	// Try search paths first (project and user kits)
 	for _, basePath := range l.searchPaths {
 		kitPath := filepath.Join(basePath, kitName)
 		componentPath := filepath.Join(kitPath, "components", componentName)
 
 		if data, err := os.ReadFile(componentPath); err == nil {
+			logging.Debug("component resolved", "kit", kitName, "component", componentName, "path", componentPath)
 			return data, nil
 		}
 	}
@@ -291,6 +416,14 @@ func (l *KitLoader) LoadKitComponent(kitName, componentName string) ([]byte, err
 	if l.embedFS != nil {
 		embeddedPath := filepath.Join("system", kitName, "components", componentName)
 		if data, err := l.embedFS.ReadFile(embeddedPath); err == nil {
+			logging.Debug("component resolved", "kit", kitName, "component", componentName, "path", "embedded:"+embeddedPath)
+			return data, nil
+		}
+	}
+
+	// Then the kit it extends
+	if parent := l.parentOf(kitName); parent != "" {
+		if data, err := l.loadKitComponent(parent, componentName); err == nil {
 			return data, nil
 		}
 	}
@@ -302,20 +435,36 @@ func (l *KitLoader) LoadKitComponent(kitName, componentName string) ([]byte, err
 // Cascade: Project (.lvt/kits/) → User (~/.config/lvt/kits/) → System (embedded)
 // templatePath should be relative, e.g., "resource/handler.go.tmpl"
 func (l *KitLoader) LoadKitTemplate(kitName, templatePath string) ([]byte, error) {
+	data, err := l.loadKitTemplate(kitName, templatePath)
+	return observe(kitName, "templates/"+templatePath, data, err)
+}
+
+func (l *KitLoader) loadKitTemplate(kitName, templatePath string) ([]byte, error) {
 	// Try search paths first (project and user kits)
 	for _, basePath := range l.searchPaths {
 		kitPath := filepath.Join(basePath, kitName)
 		fullPath := filepath.Join(kitPath, "templates", templatePath)
 
 		if data, err := os.ReadFile(fullPath); err == nil {
+			logging.Debug("template resolved", "kit", kitName, "template", templatePath, "path", fullPath)
 			return data, nil
 		}
+		logging.Debug("template candidate missing", "kit", kitName, "template", templatePath, "path", fullPath)
 	}
 
 	// Try embedded system kits
 	if l.embedFS != nil {
 		embeddedPath := filepath.Join("system", kitName, "templates", templatePath)
 		if data, err := l.embedFS.ReadFile(embeddedPath); err == nil {
+			logging.Debug("template resolved", "kit", kitName, "template", templatePath, "path", "embedded:"+embeddedPath)
+			return data, nil
+		}
+	}
+
+	// Then the kit it extends
+	if parent := l.parentOf(kitName); parent != "" {
+		logging.Debug("template not in kit, trying parent", "kit", kitName, "parent", parent, "template", templatePath)
+		if data, err := l.loadKitTemplate(parent, templatePath); err == nil {
 			return data, nil
 		}
 	}
@@ -354,6 +503,18 @@ func (l *KitLoader) ListComponents(kitName string) ([]string, error) {
 		}
 	}
 
+	// Inherited components the kit does not override
+	if parent := l.parentOf(kitName); parent != "" {
+		if inherited, err := l.ListComponents(parent); err == nil {
+			for _, name := range inherited {
+				if !seen[name] {
+					components = append(components, name)
+					seen[name] = true
+				}
+			}
+		}
+	}
+
 	if len(components) == 0 {
 		return nil, fmt.Errorf("no components found in kit %s", kitName)
 	}
@@ -361,6 +522,16 @@ func (l *KitLoader) ListComponents(kitName string) ([]string, error) {
 	return components, nil
 }
 
+// parentOf returns the name of the kit kitName extends, or "" if it extends
+// none or cannot be loaded.
+func (l *KitLoader) parentOf(kitName string) string {
+	kit, err := l.Load(kitName)
+	if err != nil || kit.Parent == nil {
+		return ""
+	}
+	return kit.Parent.Manifest.Name
+}
+
 // ReadEmbeddedFile reads a file from the embedded filesystem
 func (l *KitLoader) ReadEmbeddedFile(path string) ([]byte, error) {
 	if l.embedFS == nil {
@@ -434,11 +605,13 @@ func loadHelpers(framework string, kitPath string) (CSSHelpers, error) {
 
 // LoadHelpersForFramework loads CSS helpers for a specific framework
 // This is a public function used by both kit loading and dynamic helper injection
-// Only Tailwind and None are supported - Bulma and Pico have been removed for simplification
+// Tailwind, daisyUI and None are supported - Bulma and Pico have been removed for simplification
 func LoadHelpersForFramework(framework string) (CSSHelpers, error) {
 	switch framework {
 	case "tailwind":
 		return NewTailwindHelpers(), nil
+	case "daisyui":
+		return NewDaisyUIHelpers(), nil
 	case "none":
 		return NewNoneHelpers(), nil
 	default:
@@ -3,6 +3,7 @@ package kits
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -12,6 +13,7 @@ func TestLoadSystemKits(t *testing.T) {
 
 	// List of all system kits that should be available
 	expectedKits := []string{
+		"daisyui",
 		"multi",
 		"simple",
 		"single",
@@ -62,9 +64,9 @@ func TestListSystemKits(t *testing.T) {
 		t.Fatalf("Failed to list system kits: %v", err)
 	}
 
-	// We should have exactly 3 system kits
-	if len(kits) != 3 {
-		t.Errorf("Expected 3 system kits, got %d", len(kits))
+	// We should have exactly 4 system kits
+	if len(kits) != 4 {
+		t.Errorf("Expected 4 system kits, got %d", len(kits))
 	}
 
 	// Verify all kits are from system source
@@ -892,3 +894,97 @@ func TestMatchesOptions_MultipleFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_Extends(t *testing.T) {
+	tmpDir := t.TempDir()
+	kitDir := filepath.Join(tmpDir, "brand")
+	if err := os.MkdirAll(filepath.Join(kitDir, "components"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `name: brand
+version: 1.0.0
+description: Multi with a brand
+extends: multi
+helpers:
+  buttonClass.primary: btn-brand
+  containerClass: brand-container
+`
+	if err := os.WriteFile(filepath.Join(kitDir, "kit.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	toolbar := `{{define "toolbar"}}<div class="brand-toolbar"></div>{{end}}`
+	if err := os.WriteFile(filepath.Join(kitDir, "components", "toolbar.tmpl"), []byte(toolbar), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := DefaultLoader()
+	loader.AddSearchPath(tmpDir)
+
+	kit, err := loader.Load("brand")
+	if err != nil {
+		t.Fatalf("Failed to load kit: %v", err)
+	}
+	if kit.Parent == nil || kit.Parent.Manifest.Name != "multi" {
+		t.Fatalf("Expected parent multi, got %v", kit.Parent)
+	}
+	if !kit.DerivesFrom("multi") || len(kit.Chain()) != 2 {
+		t.Errorf("Expected chain brand -> multi, got %d kits", len(kit.Chain()))
+	}
+	if !kit.Manifest.Templates.Resource {
+		t.Error("Expected templates to be inherited from multi")
+	}
+
+	if err := kit.SetHelpersForFramework("tailwind"); err != nil {
+		t.Fatal(err)
+	}
+	if got := kit.Helpers.ButtonClass("primary"); got != "btn-brand" {
+		t.Errorf("ButtonClass(primary) = %q, want btn-brand", got)
+	}
+	if got, want := kit.Helpers.ButtonClass("danger"), NewTailwindHelpers().ButtonClass("danger"); got != want {
+		t.Errorf("ButtonClass(danger) = %q, want the inherited %q", got, want)
+	}
+	if got := kit.Helpers.ContainerClass(); got != "brand-container" {
+		t.Errorf("ContainerClass() = %q, want brand-container", got)
+	}
+
+	// Overridden component from the kit, the rest from multi
+	data, err := loader.LoadKitComponent("brand", "toolbar.tmpl")
+	if err != nil || !strings.Contains(string(data), "brand-toolbar") {
+		t.Errorf("Expected the kit's toolbar, got %q (%v)", data, err)
+	}
+	if _, err := loader.LoadKitComponent("brand", "table.tmpl"); err != nil {
+		t.Errorf("Expected table.tmpl from multi: %v", err)
+	}
+	if _, err := loader.LoadKitTemplate("brand", "resource/handler.go.tmpl"); err != nil {
+		t.Errorf("Expected resource/handler.go.tmpl from multi: %v", err)
+	}
+	components, err := loader.ListComponents("brand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) < 9 {
+		t.Errorf("Expected inherited components, got %v", components)
+	}
+}
+
+func TestLoad_ExtendsCycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, parent := range map[string]string{"kit-a": "kit-b", "kit-b": "kit-a"} {
+		kitDir := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(kitDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		manifest := "name: " + name + "\nversion: 1.0.0\ndescription: cycle\nextends: " + parent + "\n"
+		if err := os.WriteFile(filepath.Join(kitDir, "kit.yaml"), []byte(manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader := NewLoader(nil)
+	loader.AddSearchPath(tmpDir)
+
+	_, err := loader.Load("kit-a")
+	if err == nil || !strings.Contains(err.Error(), "kit-a -> kit-b -> kit-a") {
+		t.Errorf("Expected an inheritance cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package kits
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PackageManifestFile is the manifest at the root of a kit package, next
+// to the kit's directory.
+const PackageManifestFile = "manifest.json"
+
+// PackageManifest lists what a kit package holds.
+type PackageManifest struct {
+	Name         string        `json:"name"`
+	Version      string        `json:"version"`
+	CSSFramework string        `json:"css_framework"`
+	Checksum     string        `json:"checksum"` // of the kit's files, as install verifies
+	Files        []PackageFile `json:"files"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// PackageFile is a file of a packaged kit.
+type PackageFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Package writes <name>-<version>.tar.gz of the kit in dir into outDir: a
+// manifest.json and the kit's files under <name>/. An outDir inside the kit
+// is left out of the package.
+func Package(dir, outDir string) (string, *PackageManifest, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	all, err := Files(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	absOut, err := filepath.Abs(outDir)
+	if err != nil {
+		return "", nil, err
+	}
+	var files []string
+	if rel, err := filepath.Rel(absDir, absOut); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		prefix := filepath.ToSlash(rel) + "/"
+		for _, f := range all {
+			if !strings.HasPrefix(f, prefix) {
+				files = append(files, f)
+			}
+		}
+	} else {
+		files = all
+	}
+
+	pkg := &PackageManifest{
+		Name:         manifest.Name,
+		Version:      manifest.Version,
+		CSSFramework: manifest.CSSFramework,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+	if pkg.Checksum, err = checksumFiles(dir, files); err != nil {
+		return "", nil, err
+	}
+	contents := make([][]byte, len(files))
+	for i, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return "", nil, err
+		}
+		sum := sha256.Sum256(data)
+		pkg.Files = append(pkg.Files, PackageFile{Path: f, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])})
+		contents[i] = data
+	}
+	manifestJSON, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return "", nil, fmt.Errorf("failed to create %s: %w", outDir, err)
+	}
+	path := filepath.Join(outDir, fmt.Sprintf("%s-%s.tar.gz", manifest.Name, manifest.Version))
+	out, err := os.Create(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer out.Close()
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	write := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: pkg.CreatedAt}); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	if err := write(PackageManifestFile, append(manifestJSON, '\n')); err != nil {
+		return "", nil, err
+	}
+	for i, f := range files {
+		if err := write(manifest.Name+"/"+f, contents[i]); err != nil {
+			return "", nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return "", nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return "", nil, err
+	}
+	if err := out.Close(); err != nil {
+		return "", nil, err
+	}
+	return path, pkg, nil
+}
@@ -0,0 +1,88 @@
+package kits
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "acme")
+	for path, content := range map[string]string{
+		"kit.yaml":                   "name: acme\nversion: 1.2.0\ndescription: Acme kit\ncss_framework: tailwind\n",
+		"components/form.tmpl":       "{{define \"form\"}}{{end}}",
+		InstallRecordFile:            "{}",
+		"dist/acme-1.1.0.tar.gz":     "old package",
+		".git/HEAD":                  "ref: refs/heads/main",
+		"templates/app/main.go.tmpl": "package main",
+	} {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path, pkg, err := Package(dir, filepath.Join(dir, "dist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "acme-1.2.0.tar.gz" {
+		t.Errorf("package written to %s", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	var manifest PackageManifest
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		if hdr.Name == PackageManifestFile {
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	want := []string{PackageManifestFile, "acme/components/form.tmpl", "acme/kit.yaml", "acme/templates/app/main.go.tmpl"}
+	if len(names) != len(want) {
+		t.Fatalf("package holds %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("entry %d = %s, want %s", i, names[i], want[i])
+		}
+	}
+	if manifest.Name != "acme" || manifest.Version != "1.2.0" || len(manifest.Files) != 3 || manifest.Checksum != pkg.Checksum {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	// The checksum is the one install verifies, once the output is gone
+	if err := os.RemoveAll(filepath.Join(dir, "dist")); err != nil {
+		t.Fatal(err)
+	}
+	if sum, err := Checksum(dir); err != nil || sum != pkg.Checksum {
+		t.Errorf("Checksum = %s, %v; want %s", sum, err, pkg.Checksum)
+	}
+}
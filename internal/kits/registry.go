@@ -0,0 +1,518 @@
+package kits
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// InstallRecordFile is written into every kit `lvt kits install` puts
+	// in the user kit directory: where the kit came from, so it can be
+	// updated and told apart from kits created by hand.
+	InstallRecordFile = ".lvt-install.json"
+
+	// DefaultIndexURL is the central index of community kits. LVT_KIT_INDEX
+	// or kit_index in ~/.config/lvt/config.yaml point elsewhere.
+	DefaultIndexURL = "https://raw.githubusercontent.com/livetemplate/kits/main/index.yaml"
+)
+
+// InstallRecord is the content of InstallRecordFile.
+type InstallRecord struct {
+	Source      string    `json:"source"`            // index name or git URL given to install
+	Repository  string    `json:"repository"`        // git URL fetched
+	Ref         string    `json:"ref,omitempty"`     // pinned tag or commit; empty follows the latest
+	Version     string    `json:"version,omitempty"` // index version installed
+	Commit      string    `json:"commit"`
+	Checksum    string    `json:"checksum"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Index is the kit index: the kits `lvt kits install <name>` knows.
+type Index struct {
+	Kits []IndexEntry `yaml:"kits"`
+}
+
+// IndexEntry is a kit in the index.
+type IndexEntry struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description,omitempty"`
+	Repository  string         `yaml:"repository"`
+	Versions    []IndexVersion `yaml:"versions,omitempty"` // newest first
+}
+
+// IndexVersion is a released version of an indexed kit. The commit and
+// checksum, when given, are verified on install.
+type IndexVersion struct {
+	Version  string `yaml:"version"` // git tag
+	Commit   string `yaml:"commit,omitempty"`
+	Checksum string `yaml:"checksum,omitempty"` // "sha256:<hex>", see Checksum
+}
+
+// Find returns the entry of the named kit, or nil.
+func (ix *Index) Find(name string) *IndexEntry {
+	for i := range ix.Kits {
+		if ix.Kits[i].Name == name {
+			return &ix.Kits[i]
+		}
+	}
+	return nil
+}
+
+// version returns the version matching ref (a tag, or a commit or its
+// prefix), or the latest for an empty ref.
+func (e *IndexEntry) version(ref string) (*IndexVersion, error) {
+	if len(e.Versions) == 0 {
+		if ref == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("kit %s lists no versions in the index", e.Name)
+	}
+	if ref == "" {
+		return &e.Versions[0], nil
+	}
+	var names []string
+	for i, v := range e.Versions {
+		if v.Version == ref || (v.Commit != "" && len(ref) >= 7 && strings.HasPrefix(v.Commit, ref)) {
+			return &e.Versions[i], nil
+		}
+		names = append(names, v.Version)
+	}
+	return nil, fmt.Errorf("unknown version %s of kit %s (available: %s)", ref, e.Name, strings.Join(names, ", "))
+}
+
+// LoadIndex reads the index at location, an http(s) URL or a file.
+func LoadIndex(location string) (*Index, error) {
+	var data []byte
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Get(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch kit index: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch kit index %s: %s", location, resp.Status)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, fmt.Errorf("failed to read kit index: %w", err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(location); err != nil {
+			return nil, fmt.Errorf("failed to read kit index: %w", err)
+		}
+	}
+
+	var ix Index
+	if err := yaml.Unmarshal(data, &ix); err != nil {
+		return nil, fmt.Errorf("failed to parse kit index %s: %w", location, err)
+	}
+	return &ix, nil
+}
+
+// IsGitSource reports whether an install source is a git URL or path
+// rather than the name of an indexed kit.
+func IsGitSource(source string) bool {
+	return strings.Contains(source, "://") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.HasSuffix(source, ".git") ||
+		filepath.IsAbs(source) ||
+		strings.HasPrefix(source, ".")
+}
+
+// Checksum returns "sha256:<hex>" over the paths and contents of the files
+// of a kit, ignoring .git and InstallRecordFile, so a kit has the same
+// checksum wherever it is checked out.
+func Checksum(dir string) (string, error) {
+	files, err := Files(dir)
+	if err != nil {
+		return "", err
+	}
+	return checksumFiles(dir, files)
+}
+
+// Files returns the slash-separated paths of the files of a kit, in walk
+// order, leaving out .git and InstallRecordFile.
+func Files(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir(), rel == InstallRecordFile:
+			return nil
+		case !d.Type().IsRegular():
+			return fmt.Errorf("kit contains %s, which is not a regular file", filepath.ToSlash(rel))
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+func checksumFiles(dir string, files []string) (string, error) {
+	h := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(h, "%s\x00%s\n", file, hex.EncodeToString(sum[:]))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ReadInstallRecord reads the install record of the kit in dir; it fails
+// for kits that were not installed with `lvt kits install`.
+func ReadInstallRecord(dir string) (*InstallRecord, error) {
+	data, err := os.ReadFile(filepath.Join(dir, InstallRecordFile))
+	if err != nil {
+		return nil, err
+	}
+	var rec InstallRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %w", InstallRecordFile, dir, err)
+	}
+	return &rec, nil
+}
+
+// Installer installs kits from git into the user kit directory.
+type Installer struct {
+	Dir   string                 // ~/.config/lvt/kits
+	Index string                 // index URL or file
+	Check func(dir string) error // optional extra validation of a fetched kit
+}
+
+// NewInstaller returns an installer for the user kit directory and the
+// configured index.
+func NewInstaller() (*Installer, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	index := os.Getenv("LVT_KIT_INDEX")
+	if index == "" {
+		if cfg, err := config.LoadConfig(); err == nil {
+			index = cfg.KitIndex
+		}
+	}
+	if index == "" {
+		index = DefaultIndexURL
+	}
+	return &Installer{Dir: filepath.Join(configDir, "kits"), Index: index}, nil
+}
+
+// InstallOptions configures Install.
+type InstallOptions struct {
+	Ref      string // tag or commit to pin; empty installs the latest
+	Checksum string // expected "sha256:<hex>"; indexed versions carry their own
+	Force    bool   // replace a kit of the same name
+}
+
+// InstallResult describes an install or update.
+type InstallResult struct {
+	Name     string
+	Record   *InstallRecord
+	Previous *InstallRecord // the record replaced, if any
+	Updated  bool           // false when an update found nothing new
+}
+
+// Install fetches a kit from a git URL, or an indexed kit by name, checks
+// it and installs it into the user kit directory.
+func (in *Installer) Install(source string, opts InstallOptions) (*InstallResult, error) {
+	rec, commit, checksum, err := in.resolve(source, opts.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Checksum != "" {
+		checksum = opts.Checksum
+	}
+	return in.install(rec, commit, checksum, opts.Force, nil)
+}
+
+// Update fetches an installed kit again, moving it to ref when given. Kits
+// pinned to a ref stay on it unless a new ref is given.
+func (in *Installer) Update(name, ref string) (*InstallResult, error) {
+	current, err := ReadInstallRecord(filepath.Join(in.Dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("kit %s was not installed with lvt kits install", name)
+		}
+		return nil, err
+	}
+	if ref == "" && current.Ref != "" {
+		return &InstallResult{Name: name, Record: current, Previous: current}, nil
+	}
+	rec, commit, checksum, err := in.resolve(current.Source, ref)
+	if err != nil {
+		return nil, err
+	}
+	return in.install(rec, commit, checksum, true, current)
+}
+
+// resolve returns the record to install source at ref, with the commit and
+// checksum the index expects of it.
+func (in *Installer) resolve(source, ref string) (rec *InstallRecord, commit, checksum string, err error) {
+	rec = &InstallRecord{Source: source, Repository: source, Ref: ref}
+	if IsGitSource(source) {
+		return rec, "", "", nil
+	}
+	ix, err := LoadIndex(in.Index)
+	if err != nil {
+		return nil, "", "", err
+	}
+	entry := ix.Find(source)
+	if entry == nil {
+		return nil, "", "", fmt.Errorf("kit %s is not in the index %s (install it from a git URL instead)", source, in.Index)
+	}
+	v, err := entry.version(ref)
+	if err != nil {
+		return nil, "", "", err
+	}
+	rec.Repository = entry.Repository
+	if v == nil {
+		return rec, "", "", nil
+	}
+	// Unpinned kits still get the version's tag, not the default branch
+	rec.Version = v.Version
+	return rec, v.Commit, v.Checksum, nil
+}
+
+// Remove deletes an installed kit. Kits that were not installed with
+// `lvt kits install` are left alone.
+func (in *Installer) Remove(name string) error {
+	dir := filepath.Join(in.Dir, name)
+	if _, err := ReadInstallRecord(dir); err != nil {
+		if os.IsNotExist(err) {
+			if _, statErr := os.Stat(dir); statErr == nil {
+				return fmt.Errorf("kit %s in %s was not installed with lvt kits install; delete it by hand", name, in.Dir)
+			}
+			return ErrKitNotFound{Name: name}
+		}
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// Installed returns the names of the kits installed with
+// `lvt kits install`, sorted.
+func (in *Installer) Installed() ([]string, error) {
+	entries, err := os.ReadDir(in.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if _, err := os.Stat(filepath.Join(in.Dir, entry.Name(), InstallRecordFile)); entry.IsDir() && err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+var kitNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// install fetches rec.Repository at rec.Ref, verifies it and moves it into
+// place. An update (current set) leaves the kit alone when the commit did
+// not change.
+func (in *Installer) install(rec *InstallRecord, commit, checksum string, force bool, current *InstallRecord) (*InstallResult, error) {
+	if err := os.MkdirAll(in.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", in.Dir, err)
+	}
+	// Fetch next to the destination, so the final move is a rename
+	tmp, err := os.MkdirTemp(in.Dir, ".install-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "kit")
+
+	ref := rec.Ref
+	if ref == "" {
+		ref = rec.Version
+	}
+	fetched, err := fetchGit(rec.Repository, ref, src)
+	if err != nil {
+		return nil, err
+	}
+	if commit != "" && !strings.HasPrefix(fetched, commit) {
+		return nil, fmt.Errorf("version %s of %s is commit %s, but the index expects %s", rec.Version, rec.Source, fetched, commit)
+	}
+	rec.Commit = fetched
+
+	manifest, err := readManifest(src)
+	if err != nil {
+		return nil, err
+	}
+	if !kitNamePattern.MatchString(manifest.Name) {
+		return nil, fmt.Errorf("invalid kit name %q in %s", manifest.Name, ManifestFileName)
+	}
+	// Kits live in a directory of their name, which validation checks
+	named := filepath.Join(tmp, manifest.Name)
+	if err := os.Rename(src, named); err != nil {
+		return nil, err
+	}
+	src = named
+	if rec.Checksum, err = Checksum(src); err != nil {
+		return nil, err
+	}
+	if checksum != "" && !strings.EqualFold(checksum, rec.Checksum) {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", rec.Source, checksum, rec.Checksum)
+	}
+	if in.Check != nil {
+		if err := in.Check(src); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &InstallResult{Name: manifest.Name, Record: rec, Previous: current, Updated: true}
+	if current != nil && current.Commit == rec.Commit {
+		result.Record, result.Updated = current, false
+		return result, nil
+	}
+
+	dest := filepath.Join(in.Dir, manifest.Name)
+	if _, err := os.Stat(dest); err == nil {
+		previous, err := ReadInstallRecord(dest)
+		switch {
+		case err != nil && !force:
+			return nil, fmt.Errorf("a kit named %s already exists in %s (pass --force to replace it)", manifest.Name, in.Dir)
+		case err == nil && !force:
+			return nil, fmt.Errorf("kit %s is already installed (run 'lvt kits update %s', or pass --force to reinstall)", manifest.Name, manifest.Name)
+		}
+		if result.Previous == nil {
+			result.Previous = previous
+		}
+	}
+
+	rec.InstalledAt = time.Now().UTC()
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(src, InstallRecordFile), append(data, '\n'), 0644); err != nil {
+		return nil, err
+	}
+	if err := replaceDir(src, dest, filepath.Join(tmp, ".previous")); err != nil {
+		return nil, fmt.Errorf("failed to install %s: %w", dest, err)
+	}
+	return result, nil
+}
+
+// replaceDir moves src to dest. What dest held is moved to previous first,
+// and back if src cannot take its place.
+func replaceDir(src, dest, previous string) error {
+	if _, err := os.Stat(dest); err != nil {
+		return os.Rename(src, dest)
+	}
+	if err := os.Rename(dest, previous); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dest); err != nil {
+		if restoreErr := os.Rename(previous, dest); restoreErr != nil {
+			return fmt.Errorf("%w; restoring the previous kit failed: %v", err, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// fetchGit clones repo into dir and checks out ref, then drops .git. It
+// returns the commit checked out. A repo or ref starting with "-" is
+// refused, so neither can pass git an option.
+func fetchGit(repo, ref, dir string) (string, error) {
+	if strings.HasPrefix(repo, "-") {
+		return "", fmt.Errorf("invalid repository %q", repo)
+	}
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid tag or commit %q", ref)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("installing kits requires git")
+	}
+	if _, err := runGit("", "clone", "--quiet", "--", repo, dir); err != nil {
+		return "", fmt.Errorf("failed to clone %s: %w", repo, err)
+	}
+	if ref != "" {
+		// Resolved first, so checkout gets a commit, never a path
+		commit, err := runGit(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+		if err != nil || commit == "" {
+			return "", fmt.Errorf("no tag or commit %s in %s", ref, repo)
+		}
+		if _, err := runGit(dir, "checkout", "--quiet", commit); err != nil {
+			return "", fmt.Errorf("failed to check out %s: %w", ref, err)
+		}
+	}
+	commit, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// readManifest loads and validates the kit.yaml of a fetched kit, whose
+// directory is not yet named after it.
+func readManifest(dir string) (*KitManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("not a kit: no %s at the root of the repository", ManifestFileName)
+	}
+	var manifest KitManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, ErrManifestParse{Path: ManifestFileName, Err: err}
+	}
+	if err := validateVersion(manifest.Version); err != nil {
+		return nil, ErrInvalidManifest{Field: "version", Reason: err.Error()}
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
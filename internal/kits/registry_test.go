@@ -0,0 +1,190 @@
+package kits
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// kitRepo creates a git repository holding a kit named acme, tagged v1.0.0
+// and v1.1.0, and returns its path and the checksum of each tag.
+func kitRepo(t *testing.T) (string, map[string]string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "--quiet")
+	checksums := map[string]string{}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		manifest := fmt.Sprintf("name: acme\nversion: %s\ndescription: Acme kit\ncss_framework: tailwind\n", version)
+		if err := os.WriteFile(filepath.Join(repo, ManifestFileName), []byte(manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "--quiet", "-m", version)
+		git("tag", "v"+version)
+		sum, err := Checksum(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checksums["v"+version] = sum
+	}
+	return repo, checksums
+}
+
+func writeIndex(t *testing.T, repo string, checksums map[string]string) string {
+	t.Helper()
+	index := fmt.Sprintf(`kits:
+  - name: acme
+    repository: %s
+    versions:
+      - version: v1.1.0
+        checksum: %s
+      - version: v1.0.0
+        checksum: %s
+`, repo, checksums["v1.1.0"], checksums["v1.0.0"])
+	path := filepath.Join(t.TempDir(), "index.yaml")
+	if err := os.WriteFile(path, []byte(index), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func installedVersion(t *testing.T, in *Installer) string {
+	t.Helper()
+	m, err := LoadManifest(filepath.Join(in.Dir, "acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m.Version
+}
+
+func TestInstallFromIndex(t *testing.T) {
+	repo, checksums := kitRepo(t)
+	in := &Installer{Dir: t.TempDir(), Index: writeIndex(t, repo, checksums)}
+
+	// Pinned to an older version
+	result, err := in.Install("acme", InstallOptions{Ref: "v1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "acme" || result.Record.Version != "v1.0.0" || result.Record.Checksum != checksums["v1.0.0"] {
+		t.Errorf("install record = %+v", result.Record)
+	}
+	if v := installedVersion(t, in); v != "1.0.0" {
+		t.Errorf("installed version %s, want 1.0.0", v)
+	}
+	if _, err := in.Install("acme", InstallOptions{}); err == nil || !strings.Contains(err.Error(), "already installed") {
+		t.Errorf("second install: err = %v, want already installed", err)
+	}
+
+	// Pinned kits stay put unless moved
+	if result, err = in.Update("acme", ""); err != nil || result.Updated {
+		t.Errorf("update of a pinned kit: updated=%v, err=%v", result != nil && result.Updated, err)
+	}
+	if result, err = in.Update("acme", "v1.1.0"); err != nil || !result.Updated {
+		t.Fatalf("update to v1.1.0: %+v, %v", result, err)
+	}
+	if v := installedVersion(t, in); v != "1.1.0" {
+		t.Errorf("installed version %s after update, want 1.1.0", v)
+	}
+
+	// The loader lists it as a community kit
+	loader := NewLoader(nil)
+	loader.searchPaths = []string{in.Dir}
+	if kit, err := loader.Load("acme"); err != nil || kit.Source != SourceCommunity {
+		t.Errorf("Load(acme) = %+v, %v; want a community kit", kit, err)
+	}
+
+	if names, err := in.Installed(); err != nil || len(names) != 1 || names[0] != "acme" {
+		t.Errorf("Installed() = %v, %v", names, err)
+	}
+	if err := in.Remove("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(in.Dir, "acme")); !os.IsNotExist(err) {
+		t.Errorf("acme still installed after Remove (%v)", err)
+	}
+}
+
+func TestInstallFromGitURL(t *testing.T) {
+	repo, checksums := kitRepo(t)
+	in := &Installer{Dir: t.TempDir()}
+
+	if _, err := in.Install(repo, InstallOptions{Ref: "v1.0.0", Checksum: checksums["v1.1.0"]}); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("install with a wrong checksum: err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(in.Dir, "acme")); !os.IsNotExist(err) {
+		t.Error("a kit failing its checksum was installed")
+	}
+
+	// Unpinned kits follow the default branch
+	result, err := in.Install(repo, InstallOptions{Checksum: checksums["v1.1.0"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Record.Ref != "" || installedVersion(t, in) != "1.1.0" {
+		t.Errorf("install from git = %+v", result.Record)
+	}
+	if result, err = in.Update("acme", ""); err != nil || result.Updated {
+		t.Errorf("update without new commits: updated=%v, err=%v", result != nil && result.Updated, err)
+	}
+	if _, err := in.Install(repo, InstallOptions{Ref: "v9.9.9"}); err == nil {
+		t.Error("installed an unknown tag")
+	}
+}
+
+func TestRemoveLeavesHandMadeKits(t *testing.T) {
+	in := &Installer{Dir: t.TempDir()}
+	if err := os.MkdirAll(filepath.Join(in.Dir, "mine"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Remove("mine"); err == nil || !strings.Contains(err.Error(), "not installed with lvt kits install") {
+		t.Errorf("Remove(mine) = %v", err)
+	}
+	if _, err := in.Update("mine", ""); err == nil {
+		t.Error("Update accepted a hand-made kit")
+	}
+}
+
+func TestFetchGitRefusesOptions(t *testing.T) {
+	for _, tt := range []struct{ repo, ref string }{
+		{"--upload-pack=touch /tmp/pwned", ""},
+		{"https://example.com/kit.git", "--orphan=x"},
+	} {
+		if _, err := fetchGit(tt.repo, tt.ref, t.TempDir()); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("fetchGit(%q, %q) = %v, want it refused", tt.repo, tt.ref, err)
+		}
+	}
+}
+
+func TestReplaceDirRestoresThePreviousKit(t *testing.T) {
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "acme")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, ManifestFileName), []byte("name: acme\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The new kit is gone, so it cannot take the old one's place
+	if err := replaceDir(filepath.Join(dir, "missing"), dest, filepath.Join(dir, ".previous")); err == nil {
+		t.Fatal("replaceDir succeeded without a source")
+	}
+	if _, err := os.Stat(filepath.Join(dest, ManifestFileName)); err != nil {
+		t.Errorf("the previous kit was not restored: %v", err)
+	}
+}
@@ -1,12 +1,17 @@
 package kits
 
+import (
+	"fmt"
+	"strings"
+)
+
 // KitSource represents where a kit was loaded from
 type KitSource string
 
 const (
 	SourceSystem    KitSource = "system"    // Built-in, embedded in lvt binary
 	SourceLocal     KitSource = "local"     // User's custom kits
-	SourceCommunity KitSource = "community" // From registry (future)
+	SourceCommunity KitSource = "community" // Installed with lvt kits install
 )
 
 // KitTemplates defines which generator templates are included in a kit
@@ -30,6 +35,22 @@ type KitManifest struct {
 	Tags         []string     `yaml:"tags,omitempty"`
 	Components   []string     `yaml:"components,omitempty"` // List of component template names
 	Templates    KitTemplates `yaml:"templates,omitempty"`  // Generator templates included
+
+	// Extends names a parent kit. Templates, components, helper overrides and
+	// settings this kit does not provide are inherited from the parent.
+	Extends string `yaml:"extends,omitempty"`
+
+	// Helpers overrides CSS helper results, keyed by helper name (inputClass)
+	// or, for helpers taking a variant, name.variant (buttonClass.primary)
+	Helpers map[string]string `yaml:"helpers,omitempty"`
+
+	// Assets lists the files of the assets directory the layout loads, in
+	// order. Empty means every .css file, then every .js file.
+	Assets []string `yaml:"assets,omitempty"`
+
+	// Icons selects the embedded icon set generated buttons use (heroicons,
+	// lucide). Empty means text-only buttons unless the kit ships icons.
+	Icons string `yaml:"icons,omitempty"`
 }
 
 // KitInfo represents a loaded kit with its metadata and helpers
@@ -41,6 +62,7 @@ type KitInfo struct {
 	Source  KitSource  // Where this kit was loaded from
 	Path    string     // Absolute path to kit directory
 	Helpers CSSHelpers // CSS helper implementation
+	Parent  *KitInfo   // Resolved parent kit (manifest extends), nil if none
 }
 
 // KitSearchOptions defines options for searching/filtering kits
@@ -69,10 +91,19 @@ func (m *KitManifest) Validate() error {
 		framework = m.Framework
 	}
 
-	if framework == "" {
+	// Kits that extend another may inherit the framework from it
+	if framework == "" && m.Extends == "" {
 		return ErrInvalidManifest{Field: "css_framework/framework", Reason: "either css_framework or framework is required"}
 	}
 
+	if m.Extends == m.Name {
+		return ErrInvalidManifest{Field: "extends", Reason: "a kit cannot extend itself"}
+	}
+
+	if m.Icons != "" && !IsIconSet(m.Icons) {
+		return ErrInvalidManifest{Field: "icons", Reason: fmt.Sprintf("unknown icon set %q (available: %s)", m.Icons, strings.Join(IconSets, ", "))}
+	}
+
 	// Note: We don't validate the framework value here to allow custom frameworks for testing/development
 	// The loader will use system helpers for standard frameworks (tailwind, bulma, pico, none)
 	// and return nil helpers for custom frameworks
@@ -129,6 +160,26 @@ func (k *KitInfo) GetHelpers() CSSHelpers {
 	return k.Helpers
 }
 
+// Chain returns the kit followed by the kits it extends, nearest first.
+func (k *KitInfo) Chain() []*KitInfo {
+	var chain []*KitInfo
+	for kit := k; kit != nil; kit = kit.Parent {
+		chain = append(chain, kit)
+	}
+	return chain
+}
+
+// DerivesFrom reports whether the kit is name or extends it, directly or
+// through its parents.
+func (k *KitInfo) DerivesFrom(name string) bool {
+	for _, kit := range k.Chain() {
+		if kit.Manifest.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // SetHelpersForFramework sets CSS helpers based on framework name
 // Used for CSS-agnostic kits that need helpers loaded dynamically
 func (k *KitInfo) SetHelpersForFramework(framework string) error {
@@ -147,7 +198,7 @@ func (k *KitInfo) SetHelpersForFramework(framework string) error {
 		return err
 	}
 
-	k.Helpers = helpers
+	k.Helpers = withOverrides(helpers, k.Manifest.Helpers)
 	return nil
 }
 
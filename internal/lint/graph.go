@@ -0,0 +1,232 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// Node kinds of a template graph.
+const (
+	NodeFile      = "file"      // the top-level template of a file
+	NodeDefine    = "define"    // a define or block in a file
+	NodeComponent = "component" // a component library template
+	NodeMissing   = "missing"   // invoked but defined nowhere
+)
+
+// Node is a template of a graph.
+type Node struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Unused bool   `json:"unused,omitempty"`
+}
+
+// Edge is a {{template}} or {{block}} invocation.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// Reference is an invocation of a template defined nowhere.
+type Reference struct {
+	Name       string `json:"name"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Graph is the dependency graph of a set of templates: which templates
+// and defines invoke which, the defines nothing invokes and the templates
+// invoked but defined nowhere.
+type Graph struct {
+	Nodes   []Node      `json:"nodes"`
+	Edges   []Edge      `json:"edges"`
+	Unused  []Node      `json:"unused"`
+	Missing []Reference `json:"missing"`
+	Errors  []Issue     `json:"errors"`
+}
+
+// BuildGraph reads the templates at paths and builds their graph.
+// Templates that do not parse are reported in Errors and left out.
+func BuildGraph(paths []string) (*Graph, error) {
+	var sources []*source
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		sources = append(sources, newSource(path, string(content)))
+	}
+	return buildGraph(sources), nil
+}
+
+func buildGraph(sources []*source) *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}, Unused: []Node{}, Missing: []Reference{}, Errors: []Issue{}}
+	nodes := map[string]int{} // file + "\x00" + template name -> node index
+	key := func(file, name string) string { return file + "\x00" + name }
+	addNode := func(n Node) int {
+		n.ID = fmt.Sprintf("n%d", len(g.Nodes))
+		g.Nodes = append(g.Nodes, n)
+		nodes[key(n.File, n.Name)] = len(g.Nodes) - 1
+		return len(g.Nodes) - 1
+	}
+
+	// Defines first, so invocations resolve across files
+	definedIn := map[string]string{} // template name -> first file defining it
+	var parsed []*source
+	for _, s := range sources {
+		if s.parseErr != nil {
+			g.Errors = append(g.Errors, ParseError(s.name, s.parseErr))
+			continue
+		}
+		parsed = append(parsed, s)
+		addNode(Node{Name: s.name, Kind: NodeFile, File: s.name, Line: 1})
+		for _, name := range s.sortedTreeNames() {
+			if name == s.name {
+				continue
+			}
+			line, _ := s.position(s.definePos(name))
+			addNode(Node{Name: name, Kind: NodeDefine, File: s.name, Line: line})
+			if _, ok := definedIn[name]; !ok {
+				definedIn[name] = s.name
+			}
+		}
+	}
+
+	known := componentTemplates()
+	invoked := map[int]bool{}
+	for _, s := range parsed {
+		for _, name := range s.sortedTreeNames() {
+			from := nodes[key(s.name, name)]
+			walk(s.trees[name].Root, func(n parse.Node) {
+				t, ok := n.(*parse.TemplateNode)
+				if !ok {
+					return
+				}
+				line, _ := s.position(int(t.Position()))
+
+				// The file's own define, then another file's, then the
+				// component library's
+				to, ok := nodes[key(s.name, t.Name)]
+				if !ok {
+					if file, defined := definedIn[t.Name]; defined {
+						to = nodes[key(file, t.Name)]
+					} else if to, ok = nodes[key("", t.Name)]; !ok {
+						kind := NodeMissing
+						if known[t.Name] {
+							kind = NodeComponent
+						}
+						to = addNode(Node{Name: t.Name, Kind: kind})
+					}
+				}
+				invoked[to] = true
+				g.Edges = append(g.Edges, Edge{From: g.Nodes[from].ID, To: g.Nodes[to].ID, File: s.name, Line: line})
+
+				if g.Nodes[to].Kind == NodeMissing {
+					ref := Reference{Name: t.Name, File: s.name, Line: line}
+					candidates := map[string]bool{}
+					for name := range definedIn {
+						candidates[name] = true
+					}
+					if strings.HasPrefix(t.Name, "lvt:") {
+						candidates = known
+					}
+					ref.Suggestion = closest(t.Name, candidates)
+					g.Missing = append(g.Missing, ref)
+				}
+			})
+		}
+	}
+
+	// Defines overriding component templates are rendered by the
+	// components, so only the others can be dead
+	for i, n := range g.Nodes {
+		if n.Kind == NodeDefine && !invoked[i] && !known[n.Name] {
+			g.Nodes[i].Unused = true
+			g.Unused = append(g.Unused, g.Nodes[i])
+		}
+	}
+	return g
+}
+
+// definePos returns the offset of the define or block of a template.
+func (s *source) definePos(name string) int {
+	pattern := regexp.MustCompile(`\{\{-?\s*(define|block)\s+"` + regexp.QuoteMeta(name) + `"`)
+	if loc := pattern.FindStringIndex(s.text); loc != nil {
+		return loc[0]
+	}
+	return 0
+}
+
+// Mermaid renders the graph as a Mermaid flowchart: a subgraph per file,
+// unused defines dashed and missing templates in red.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	byFile := map[string][]Node{}
+	var files []string
+	for _, n := range g.Nodes {
+		if n.File == "" {
+			continue
+		}
+		if _, ok := byFile[n.File]; !ok {
+			files = append(files, n.File)
+		}
+		byFile[n.File] = append(byFile[n.File], n)
+	}
+	sort.Strings(files)
+	for i, file := range files {
+		fmt.Fprintf(&b, "  subgraph f%d[\"%s\"]\n", i, mermaidLabel(file))
+		for _, n := range byFile[file] {
+			label := n.Name
+			if n.Kind == NodeFile {
+				label = "(page)"
+			}
+			fmt.Fprintf(&b, "    %s[\"%s\"]%s\n", n.ID, mermaidLabel(label), mermaidClass(n))
+		}
+		b.WriteString("  end\n")
+	}
+	for _, n := range g.Nodes {
+		if n.File == "" {
+			fmt.Fprintf(&b, "  %s[\"%s\"]%s\n", n.ID, mermaidLabel(n.Name), mermaidClass(n))
+		}
+	}
+
+	seen := map[[2]string]bool{}
+	for _, e := range g.Edges {
+		if seen[[2]string{e.From, e.To}] {
+			continue
+		}
+		seen[[2]string{e.From, e.To}] = true
+		fmt.Fprintf(&b, "  %s --> %s\n", e.From, e.To)
+	}
+
+	b.WriteString("  classDef unused stroke-dasharray: 5 5,color:#999\n")
+	b.WriteString("  classDef component fill:#eef\n")
+	b.WriteString("  classDef missing fill:#fdd,stroke:#c00\n")
+	return b.String()
+}
+
+func mermaidClass(n Node) string {
+	switch {
+	case n.Unused:
+		return ":::unused"
+	case n.Kind == NodeComponent || n.Kind == NodeMissing:
+		return ":::" + n.Kind
+	}
+	return ""
+}
+
+// mermaidLabel replaces the double quotes Mermaid labels cannot contain.
+func mermaidLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildGraph(t *testing.T) {
+	dir := t.TempDir()
+	files := []struct{ name, content string }{
+		{"page.tmpl", `{{template "layout" .}}{{define "layout"}}{{template "row" .}}{{template "lvt:modal:confirm:v1" .C}}{{template "shared" .}}{{end}}{{define "row"}}{{end}}{{define "dead"}}{{template "row"}}{{end}}{{define "lvt:toast:container:v1"}}{{end}}`},
+		{"shared.tmpl", `{{define "shared"}}{{template "raw" .}}{{template "lvt:modal:confrim:v1" .}}{{end}}`},
+		{"broken.tmpl", `{{if .X}}`},
+		{"partial.tmpl", `{{define "unused"}}{{end}}`},
+	}
+	var paths []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	g, err := BuildGraph(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Errors) != 1 || !strings.HasSuffix(g.Errors[0].File, "broken.tmpl") {
+		t.Errorf("expected a parse error for broken.tmpl, got %v", g.Errors)
+	}
+
+	kinds := map[string]string{}
+	for _, n := range g.Nodes {
+		kinds[n.Name] = n.Kind
+	}
+	if kinds["lvt:modal:confirm:v1"] != NodeComponent || kinds["raw"] != NodeMissing {
+		t.Errorf("expected a component and a missing node, got %v", kinds)
+	}
+
+	var unused []string
+	for _, n := range g.Unused {
+		unused = append(unused, filepath.Base(n.File)+":"+n.Name)
+	}
+	// The component override and the define invoked from another file are
+	// not dead
+	want := []string{"page.tmpl:dead", "partial.tmpl:unused"}
+	if strings.Join(unused, " ") != strings.Join(want, " ") {
+		t.Errorf("unused = %v, want %v", unused, want)
+	}
+
+	var missing []string
+	for _, ref := range g.Missing {
+		missing = append(missing, ref.Name+"->"+ref.Suggestion)
+	}
+	if strings.Join(missing, " ") != "raw->row lvt:modal:confrim:v1->lvt:modal:confirm:v1" {
+		t.Errorf("missing = %v", missing)
+	}
+
+	mermaid := g.Mermaid()
+	for _, want := range []string{"flowchart LR", `["dead"]:::unused`, `["raw"]:::missing`, " --> "} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, mermaid)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+package lint
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/livetemplate/lvt/components"
+	"github.com/livetemplate/lvt/internal/kits"
+)
+
+// tag is an HTML start tag of a template, with template actions kept as
+// written.
+type tag struct {
+	name    string
+	pos     int
+	attrs   []attr
+	actions []action // actions between attributes, e.g. {{if .X}}checked{{end}}
+}
+
+type attr struct {
+	name     string
+	pos      int
+	value    string
+	valuePos int
+	hasValue bool
+	quoted   bool
+}
+
+type action struct {
+	text string
+	pos  int
+}
+
+// scanTags returns the start tags of a template, skipping comments and the
+// contents of script and style elements.
+func scanTags(src string) []tag {
+	var tags []tag
+	for i := 0; i < len(src); {
+		switch {
+		case strings.HasPrefix(src[i:], "{{"):
+			i = skipAction(src, i)
+		case strings.HasPrefix(src[i:], "<!--"):
+			end := strings.Index(src[i+4:], "-->")
+			if end < 0 {
+				return tags
+			}
+			i += 4 + end + 3
+		case src[i] == '<' && i+1 < len(src) && isLetter(src[i+1]):
+			t, end := scanTag(src, i)
+			tags = append(tags, t)
+			i = end
+			if name := strings.ToLower(t.name); name == "script" || name == "style" {
+				close := strings.Index(strings.ToLower(src[i:]), "</"+name)
+				if close < 0 {
+					return tags
+				}
+				i += close
+			}
+		default:
+			i++
+		}
+	}
+	return tags
+}
+
+// scanTag reads the start tag at src[start] and returns it with the offset
+// after it.
+func scanTag(src string, start int) (tag, int) {
+	i := start + 1
+	for i < len(src) && (isLetter(src[i]) || isDigit(src[i]) || src[i] == '-' || src[i] == ':') {
+		i++
+	}
+	t := tag{name: src[start+1 : i], pos: start}
+
+	for i < len(src) {
+		i = skipSpace(src, i)
+		switch {
+		case i >= len(src):
+			return t, i
+		case src[i] == '>':
+			return t, i + 1
+		case strings.HasPrefix(src[i:], "/>"):
+			return t, i + 2
+		case strings.HasPrefix(src[i:], "{{"):
+			end := skipAction(src, i)
+			t.actions = append(t.actions, action{src[i:end], i})
+			i = end
+			continue
+		}
+
+		// Attribute name, which may itself contain actions (data-{{.Key}})
+		a := attr{pos: i}
+		for i < len(src) && !isSpace(src[i]) && src[i] != '=' && src[i] != '>' && !strings.HasPrefix(src[i:], "/>") {
+			if strings.HasPrefix(src[i:], "{{") {
+				if i == a.pos {
+					break
+				}
+				i = skipAction(src, i)
+				continue
+			}
+			i++
+		}
+		a.name = src[a.pos:i]
+		if a.name == "" {
+			i++
+			continue
+		}
+
+		if j := skipSpace(src, i); j < len(src) && src[j] == '=' {
+			a.hasValue = true
+			i = skipSpace(src, j+1)
+			if i < len(src) && (src[i] == '"' || src[i] == '\'') {
+				quote := src[i]
+				a.quoted = true
+				a.valuePos = i + 1
+				i++
+				for i < len(src) && src[i] != quote {
+					if strings.HasPrefix(src[i:], "{{") {
+						i = skipAction(src, i)
+						continue
+					}
+					i++
+				}
+				a.value = src[a.valuePos:min(i, len(src))]
+				i++
+			} else {
+				a.valuePos = i
+				for i < len(src) && !isSpace(src[i]) && src[i] != '>' {
+					if strings.HasPrefix(src[i:], "{{") {
+						i = skipAction(src, i)
+						continue
+					}
+					i++
+				}
+				a.value = src[a.valuePos:i]
+			}
+		}
+		t.attrs = append(t.attrs, a)
+	}
+	return t, i
+}
+
+// skipAction returns the offset after the action starting at src[start],
+// allowing for "}}" inside its strings and comments.
+func skipAction(src string, start int) int {
+	i := start + 2
+	for i < len(src) {
+		switch c := src[i]; {
+		case strings.HasPrefix(src[i:], "}}"):
+			return i + 2
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return len(src)
+			}
+			i += 2 + end + 2
+		case c == '"' || c == '\'' || c == '`':
+			i++
+			for i < len(src) && src[i] != c {
+				if src[i] == '\\' && c != '`' {
+					i++
+				}
+				i++
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	return len(src)
+}
+
+// actionsIn returns the actions in s, at their offsets plus base.
+func actionsIn(s string, base int) []action {
+	var actions []action
+	for i := 0; i < len(s); {
+		j := strings.Index(s[i:], "{{")
+		if j < 0 {
+			break
+		}
+		start := i + j
+		end := skipAction(s, start)
+		actions = append(actions, action{s[start:end], base + start})
+		i = end
+	}
+	return actions
+}
+
+// outputs reports whether the action writes a value, as opposed to a
+// control action, comment, variable declaration or template call.
+func (a action) outputs() bool {
+	body := strings.TrimSuffix(strings.TrimPrefix(a.text, "{{"), "}}")
+	body = strings.TrimSpace(strings.Trim(strings.TrimSpace(body), "-"))
+	if body == "" || strings.HasPrefix(body, "/*") {
+		return false
+	}
+	first, _, _ := strings.Cut(body, " ")
+	switch first {
+	case "if", "else", "end", "range", "with", "template", "block", "define", "break", "continue":
+		return false
+	}
+	return !declaration.MatchString(body)
+}
+
+var declaration = regexp.MustCompile(`^\$\w*\s*:?=`)
+
+// unescapedFuncs are function names conventionally used to mark values as
+// trusted (template.HTMLAttr, template.URL, ...), skipping escaping.
+var unescapedFuncs = regexp.MustCompile(`(^|[\s(|])(safe\w*|raw\w*|unsafe\w*|noescape|trusted\w*)\b`)
+
+// checkUnescapedAttributes reports template data written where html/template
+// escaping does not protect it: attribute-name positions, unquoted
+// attribute values and values marked trusted.
+func checkUnescapedAttributes(s *source) []finding {
+	var findings []finding
+	for _, t := range s.tags {
+		for _, a := range t.actions {
+			if a.outputs() {
+				findings = append(findings, finding{a.pos, fmt.Sprintf("template data %s in attribute-name position of <%s>; write the attribute and use a conditional ({{if .X}}disabled{{end}}) or a quoted value", a.text, t.name)})
+			}
+		}
+		for _, at := range t.attrs {
+			if !at.hasValue {
+				continue
+			}
+			for _, a := range actionsIn(at.value, at.valuePos) {
+				if !a.outputs() {
+					continue
+				}
+				body := stripStrings(a.text)
+				switch {
+				case !at.quoted:
+					findings = append(findings, finding{a.pos, fmt.Sprintf("unquoted attribute %s contains template data; quote the value", at.name)})
+				case unescapedFuncs.MatchString(body):
+					findings = append(findings, finding{a.pos, fmt.Sprintf("%s bypasses escaping in attribute %s; user data there can inject markup or script", a.text, at.name)})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+var stringLiteral = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`")
+
+// stripStrings blanks the string literals of an action, so their text is
+// not mistaken for function names.
+func stripStrings(text string) string {
+	return stringLiteral.ReplaceAllString(text, `""`)
+}
+
+// knownAttributes are the lvt-* attributes of the LiveTemplate client that
+// take no sub-name.
+var knownAttributes = map[string]bool{}
+
+func init() {
+	for _, name := range []string{
+		// Events
+		"click", "submit", "change", "input", "keydown", "keyup", "focus", "blur",
+		"mouseenter", "mouseleave", "click-away",
+		"window-keydown", "window-keyup", "window-scroll", "window-resize", "window-focus", "window-blur",
+		// Modifiers and forms
+		"key", "debounce", "throttle", "preserve", "disable-with", "confirm",
+		// Keyboard shortcuts: lvt-key without a key event names the shortcut key
+		"key-label",
+		// Modals
+		"modal-open", "modal-close",
+		// Directives
+		"scroll", "scroll-behavior", "scroll-threshold", "scroll-sentinel",
+		"highlight", "highlight-color", "highlight-duration",
+		"animate", "animate-duration", "autofocus", "focus-trap",
+		// Uploads
+		"upload",
+	} {
+		knownAttributes["lvt-"+name] = true
+	}
+}
+
+// attributePatterns match the lvt-* attributes that take sub-names.
+var attributePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^lvt-(data|value)-[a-z0-9_-]+$`),
+	regexp.MustCompile(`^lvt-on:(window:)?[a-z][a-z0-9-]*$`),
+	regexp.MustCompile(`^lvt-mod:(debounce|throttle)$`),
+	regexp.MustCompile(`^lvt-form:(disable-with|preserve|confirm)$`),
+	regexp.MustCompile(`^lvt-el:(addclass|removeclass|toggleclass|setattr|toggleattr|reset|disable|enable):on:([a-z0-9_-]+:)?[a-z][a-z0-9-]*$`),
+	regexp.MustCompile(`^lvt-(reset|disable|enable|addclass|removeclass|toggleclass|setattr|toggleattr)-on:([a-z0-9_-]+:)?(pending|success|error|done)$`),
+}
+
+// attributePrefixes start the lvt-* attributes that take sub-names, for
+// completion.
+var attributePrefixes = []string{
+	"lvt-on:", "lvt-on:window:", "lvt-data-", "lvt-value-",
+	"lvt-mod:debounce", "lvt-mod:throttle",
+	"lvt-form:disable-with", "lvt-form:preserve", "lvt-form:confirm",
+	"lvt-el:addClass:on:", "lvt-el:removeClass:on:", "lvt-el:toggleClass:on:",
+	"lvt-el:setAttr:on:", "lvt-el:toggleAttr:on:", "lvt-el:reset:on:",
+	"lvt-el:disable:on:", "lvt-el:enable:on:",
+	"lvt-reset-on:", "lvt-disable-on:", "lvt-enable-on:",
+	"lvt-addClass-on:", "lvt-removeClass-on:", "lvt-toggleClass-on:",
+}
+
+// Attributes returns the lvt-* attributes of the client, sorted: those
+// without sub-names, and the prefixes of the others (lvt-on:, lvt-data-).
+func Attributes() []string {
+	names := append([]string{}, attributePrefixes...)
+	for name := range knownAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ComponentTemplates returns the names of the component templates, sorted.
+func ComponentTemplates() []string {
+	var names []string
+	for name := range componentTemplates() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// knownAttribute reports whether name is an lvt-* attribute of the client.
+func knownAttribute(name string) bool {
+	name = strings.ToLower(name)
+	if knownAttributes[name] {
+		return true
+	}
+	for _, p := range attributePatterns {
+		if p.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkUnknownAttributes reports lvt-* attributes the client ignores,
+// usually typos.
+func checkUnknownAttributes(s *source) []finding {
+	var findings []finding
+	for _, t := range s.tags {
+		for _, a := range t.attrs {
+			if !strings.HasPrefix(strings.ToLower(a.name), "lvt-") || strings.Contains(a.name, "{{") || knownAttribute(a.name) {
+				continue
+			}
+			msg := fmt.Sprintf("unknown attribute %s", a.name)
+			if suggestion := closest(a.name, knownAttributes); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %s?)", suggestion)
+			}
+			findings = append(findings, finding{a.pos, msg})
+		}
+	}
+	return findings
+}
+
+var defineName = regexp.MustCompile(`\{\{-?\s*define\s+"([^"]+)"`)
+
+// componentTemplates returns the template names the component library
+// defines, and the lvt:* templates kits generate into apps (the i18n
+// language switcher).
+var componentTemplates = sync.OnceValue(func() map[string]bool {
+	names := map[string]bool{}
+	addDefines := func(fsys fs.FS, file string) {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return
+		}
+		for _, m := range defineName.FindAllStringSubmatch(string(content), -1) {
+			if strings.HasPrefix(m[1], "lvt:") {
+				names[m[1]] = true
+			}
+		}
+	}
+	for _, set := range components.All() {
+		files, _ := fs.Glob(set.FS, set.Pattern)
+		for _, file := range files {
+			addDefines(set.FS, file)
+		}
+	}
+	kitFS := kits.GetSystemKits()
+	_ = fs.WalkDir(kitFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".tmpl.tmpl") {
+			addDefines(kitFS, path)
+		}
+		return nil
+	})
+	return names
+})
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isSpace(c byte) bool  { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
+
+func skipSpace(src string, i int) int {
+	for i < len(src) && isSpace(src[i]) {
+		i++
+	}
+	return i
+}
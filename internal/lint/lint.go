@@ -0,0 +1,413 @@
+// Package lint checks LiveTemplate templates for mistakes the template
+// parser accepts: range items without keys, misspelled lvt-* attributes,
+// define blocks nothing renders, template data in unsafe attribute
+// positions, and misused component templates.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// Severity is how a rule's findings are reported.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// ParseSeverity parses off, warning or error.
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(strings.ToLower(strings.TrimSpace(s))); sev {
+	case SeverityOff, SeverityWarning, SeverityError:
+		return sev, nil
+	}
+	return "", fmt.Errorf("invalid severity %q (valid: off, warning, error)", s)
+}
+
+// Issue is one finding.
+type Issue struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", i.File, i.Line, i.Column, i.Severity, i.Message, i.Rule)
+}
+
+// Rule is a check run on every template.
+type Rule struct {
+	Name        string
+	Description string
+	Default     Severity
+	check       func(*source) []finding
+}
+
+// ParseRule reports templates that do not parse. It cannot be turned off.
+const ParseRule = "parse"
+
+// Rules lists the available rules.
+var Rules = []Rule{
+	{"range-key", "range items without a key attribute (data-key)", SeverityWarning, checkRangeKeys},
+	{"unknown-attribute", "lvt-* attributes the client does not know", SeverityError, checkUnknownAttributes},
+	{"unused-define", "define blocks nothing in the file renders", SeverityWarning, checkUnusedDefines},
+	{"unescaped-attribute", "template data where escaping does not protect it", SeverityError, checkUnescapedAttributes},
+	{"component-usage", "unknown component templates and components rendered without their state", SeverityError, checkComponentUsage},
+}
+
+// RuleNames returns the names of the available rules.
+func RuleNames() []string {
+	names := make([]string, len(Rules))
+	for i, r := range Rules {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// Config maps rule names to severities; rules not in it use their default.
+type Config map[string]Severity
+
+// ConfigFrom builds a Config from rule=severity settings, as stored under
+// lint.<rule> in .lvtrc.
+func ConfigFrom(settings map[string]string) (Config, error) {
+	cfg := Config{}
+	for name, value := range settings {
+		if findRule(name) == nil {
+			return nil, fmt.Errorf("unknown lint rule %q (rules: %s)", name, strings.Join(RuleNames(), ", "))
+		}
+		sev, err := ParseSeverity(value)
+		if err != nil {
+			return nil, fmt.Errorf("lint.%s: %w", name, err)
+		}
+		cfg[name] = sev
+	}
+	return cfg, nil
+}
+
+// Severity returns the severity of a rule.
+func (c Config) Severity(rule string) Severity {
+	if sev, ok := c[rule]; ok {
+		return sev
+	}
+	if r := findRule(rule); r != nil {
+		return r.Default
+	}
+	return SeverityError
+}
+
+func findRule(name string) *Rule {
+	for i := range Rules {
+		if Rules[i].Name == name {
+			return &Rules[i]
+		}
+	}
+	return nil
+}
+
+// File lints the template at path.
+func File(path string, cfg Config) ([]Issue, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return Source(path, string(content), cfg), nil
+}
+
+// Source lints template text; name is used as the file of its issues.
+func Source(name, text string, cfg Config) []Issue {
+	src := newSource(name, text)
+
+	var issues []Issue
+	add := func(rule string, sev Severity, f finding) {
+		line, col := src.position(f.pos)
+		issues = append(issues, Issue{File: name, Line: line, Column: col, Rule: rule, Severity: sev, Message: f.message})
+	}
+
+	if src.parseErr != nil {
+		issues = append(issues, ParseError(name, src.parseErr))
+	}
+	for _, r := range Rules {
+		sev := cfg.Severity(r.Name)
+		if sev == SeverityOff {
+			continue
+		}
+		for _, f := range r.check(src) {
+			add(r.Name, sev, f)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues
+}
+
+// finding is a rule's report at a byte offset of the source.
+type finding struct {
+	pos     int
+	message string
+}
+
+// source is a template being linted.
+type source struct {
+	name       string
+	text       string
+	trees      map[string]*parse.Tree // nil when the template does not parse
+	parseErr   error
+	tags       []tag
+	lineStarts []int
+}
+
+func newSource(name, text string) *source {
+	src := &source{name: name, text: text, tags: scanTags(text), lineStarts: []int{0}}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			src.lineStarts = append(src.lineStarts, i+1)
+		}
+	}
+
+	// Functions are registered by the app at runtime, so calls to them
+	// cannot be checked here
+	t := parse.New(name)
+	t.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := t.Parse(text, "{{", "}}", trees); err != nil {
+		src.parseErr = err
+	} else {
+		src.trees = trees
+	}
+	return src
+}
+
+// position returns the 1-based line and column of a byte offset.
+func (s *source) position(offset int) (line, col int) {
+	i := sort.Search(len(s.lineStarts), func(i int) bool { return s.lineStarts[i] > offset }) - 1
+	return i + 1, offset - s.lineStarts[i] + 1
+}
+
+var parseErrorPattern = regexp.MustCompile(`template: [^:]*:(\d+)(?::(\d+))?`)
+
+// ParseError returns the issue of a template parse error in file.
+func ParseError(file string, err error) Issue {
+	line, col := parseErrorPosition(err)
+	return Issue{File: file, Line: line, Column: col, Rule: ParseRule, Severity: SeverityError, Message: err.Error()}
+}
+
+// parseErrorPosition extracts the line and column of a parse error.
+func parseErrorPosition(err error) (line, col int) {
+	m := parseErrorPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 1, 1
+	}
+	line, _ = strconv.Atoi(m[1])
+	col, _ = strconv.Atoi(m[2])
+	return line, max(col, 1)
+}
+
+// walk calls fn for every node under n, depth first.
+func walk(n parse.Node, fn func(parse.Node)) {
+	if n == nil {
+		return
+	}
+	fn(n)
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walk(child, fn)
+		}
+	case *parse.IfNode:
+		walk(n.List, fn)
+		walk(n.ElseList, fn)
+	case *parse.RangeNode:
+		walk(n.List, fn)
+		walk(n.ElseList, fn)
+	case *parse.WithNode:
+		walk(n.List, fn)
+		walk(n.ElseList, fn)
+	}
+}
+
+// sortedTreeNames returns the names of the parsed templates, sorted so
+// findings come out in a stable order.
+func (s *source) sortedTreeNames() []string {
+	names := make([]string, 0, len(s.trees))
+	for name := range s.trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyAttributePattern matches the attributes LiveTemplate reads range item
+// keys from.
+var keyAttributePattern = regexp.MustCompile(`(?i)[\s"'](id|data-key|key|data-lvt-key|lvt-key|data-id|x-key|v-key)\s*=`)
+
+var elementPattern = regexp.MustCompile(`<[A-Za-z]`)
+
+// checkRangeKeys reports ranges rendering elements without a key attribute.
+// Without keys, LiveTemplate matches items by position, so inserting or
+// removing one re-renders every item after it.
+func checkRangeKeys(s *source) []finding {
+	var findings []finding
+	for _, name := range s.sortedTreeNames() {
+		walk(s.trees[name].Root, func(n parse.Node) {
+			r, ok := n.(*parse.RangeNode)
+			if !ok {
+				return
+			}
+			var statics strings.Builder
+			s.rangeStatics(r.List, &statics, map[string]bool{})
+			if elementPattern.MatchString(statics.String()) && !keyAttributePattern.MatchString(statics.String()) {
+				findings = append(findings, finding{int(r.Position()), `range items have no key attribute; add data-key="{{.ID}}" to each item's root element so updates patch the right element`})
+			}
+		})
+	}
+	return findings
+}
+
+// rangeStatics collects the text a range body renders around its actions,
+// following the templates it calls but not nested ranges, which are
+// checked on their own.
+func (s *source) rangeStatics(list *parse.ListNode, b *strings.Builder, visited map[string]bool) {
+	if list == nil {
+		return
+	}
+	for _, n := range list.Nodes {
+		switch n := n.(type) {
+		case *parse.TextNode:
+			b.Write(n.Text)
+		case *parse.IfNode:
+			s.rangeStatics(n.List, b, visited)
+			s.rangeStatics(n.ElseList, b, visited)
+		case *parse.WithNode:
+			s.rangeStatics(n.List, b, visited)
+			s.rangeStatics(n.ElseList, b, visited)
+		case *parse.TemplateNode:
+			if tree, ok := s.trees[n.Name]; ok && !visited[n.Name] {
+				visited[n.Name] = true
+				s.rangeStatics(tree.Root, b, visited)
+			}
+		}
+	}
+}
+
+// checkUnusedDefines reports define blocks the file's page never renders.
+// Files without top-level content are partial libraries whose defines are
+// rendered from elsewhere, so they are not checked.
+func checkUnusedDefines(s *source) []finding {
+	root, ok := s.trees[s.name]
+	if !ok || parse.IsEmptyTree(root.Root) {
+		return nil
+	}
+
+	reachable := map[string]bool{s.name: true}
+	queue := []string{s.name}
+	for len(queue) > 0 {
+		tree := s.trees[queue[0]]
+		queue = queue[1:]
+		if tree == nil {
+			continue
+		}
+		walk(tree.Root, func(n parse.Node) {
+			if t, ok := n.(*parse.TemplateNode); ok && !reachable[t.Name] {
+				reachable[t.Name] = true
+				queue = append(queue, t.Name)
+			}
+		})
+	}
+
+	var findings []finding
+	for _, name := range s.sortedTreeNames() {
+		// lvt:* defines override component templates, rendered by name
+		if reachable[name] || strings.HasPrefix(name, "lvt:") {
+			continue
+		}
+		pattern := regexp.MustCompile(`\{\{-?\s*define\s+"` + regexp.QuoteMeta(name) + `"`)
+		pos := 0
+		if loc := pattern.FindStringIndex(s.text); loc != nil {
+			pos = loc[0]
+		}
+		findings = append(findings, finding{pos, fmt.Sprintf("define %q is never rendered", name)})
+	}
+	return findings
+}
+
+// checkComponentUsage reports calls of component templates (lvt:*) that
+// the component library and the file do not define, and versioned
+// component templates called without their component state.
+func checkComponentUsage(s *source) []finding {
+	known := componentTemplates()
+	var findings []finding
+	for _, name := range s.sortedTreeNames() {
+		walk(s.trees[name].Root, func(n parse.Node) {
+			t, ok := n.(*parse.TemplateNode)
+			if !ok || !strings.HasPrefix(t.Name, "lvt:") {
+				return
+			}
+			if _, defined := s.trees[t.Name]; !known[t.Name] && !defined {
+				msg := fmt.Sprintf("unknown component template %q", t.Name)
+				if suggestion := closest(t.Name, known); suggestion != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+				}
+				findings = append(findings, finding{int(t.Position()), msg})
+				return
+			}
+			if t.Pipe == nil && versionedComponent.MatchString(t.Name) {
+				findings = append(findings, finding{int(t.Position()), fmt.Sprintf("component template %q renders a component's state; pass it, e.g. {{template %q .MyComponent}}", t.Name, t.Name)})
+			}
+		})
+	}
+	return findings
+}
+
+var versionedComponent = regexp.MustCompile(`:v\d+$`)
+
+// closest returns the candidate nearest to name, if it is near enough to
+// be a typo.
+func closest(name string, candidates map[string]bool) string {
+	best, bestDist := "", len(name)/3+1
+	for c := range candidates {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(c)); d < bestDist || (d == bestDist && best != "" && c < best) {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
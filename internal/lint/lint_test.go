@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// rulesOf returns "rule@line" for each issue, for compact assertions.
+func rulesOf(issues []Issue) []string {
+	var got []string
+	for _, i := range issues {
+		got = append(got, fmt.Sprintf("%s@%d", i.Rule, i.Line))
+	}
+	return got
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "keyed range",
+			src:  `{{range .Items}}<li data-key="{{.ID}}">{{.Name}}</li>{{end}}`,
+		},
+		{
+			name: "range without key",
+			src:  "<ul>\n{{range .Items}}<li>{{.Name}}</li>{{end}}</ul>",
+			want: []string{"range-key@2"},
+		},
+		{
+			name: "range key in called template",
+			src:  `{{range .Items}}{{template "item" .}}{{end}}{{define "item"}}<tr id="{{.ID}}"></tr>{{end}}`,
+		},
+		{
+			name: "range without elements",
+			src:  `{{range .Tags}}{{.}}, {{end}}`,
+		},
+		{
+			name: "known attributes",
+			src:  `<input lvt-on:input="search" lvt-mod:debounce="300" lvt-data-id="{{.ID}}" lvt-el:toggleClass:on:click="open" lvt-disable-on:pending {{if .Open}}lvt-autofocus{{end}} lvt-key="/" lvt-key-label="Search">`,
+		},
+		{
+			name: "unknown attribute",
+			src:  "<div>\n<button lvt-clik=\"save\" lvt-on:=\"x\">",
+			want: []string{"unknown-attribute@2", "unknown-attribute@2"},
+		},
+		{
+			name: "unused define",
+			src:  "{{template \"a\" .}}\n{{define \"a\"}}{{block \"b\" .}}{{end}}{{end}}\n{{define \"c\"}}{{end}}\n{{define \"lvt:modal:default:v1\"}}{{end}}",
+			want: []string{"unused-define@3"},
+		},
+		{
+			name: "partial library has no unused defines",
+			src:  `{{define "a"}}a{{end}}{{define "b"}}b{{end}}`,
+		},
+		{
+			name: "escaped attributes",
+			src:  `<a href="{{.URL}}" class="{{if .Active}}active{{end}}" title="{{T $.Locale "safeHTML"}}" {{if .Disabled}}disabled{{end}}>`,
+		},
+		{
+			name: "unescaped attributes",
+			src:  "<div\n{{.Attrs}} class={{.Class}} title=\"{{.Title | safeHTMLAttr}}\">",
+			want: []string{"unescaped-attribute@2", "unescaped-attribute@2", "unescaped-attribute@2"},
+		},
+		{
+			name: "component usage",
+			src:  "{{template \"lvt:modal:confirm:v1\" .Confirm}}{{template \"lvt:i18n:switcher:v1\" .Locale}}\n{{template \"lvt:modal:confrim:v1\" .Confirm}}\n{{template \"lvt:toast:container:v1\"}}\n{{template \"lvt:dropdown:panel-css\"}}",
+			want: []string{"component-usage@2", "component-usage@3"},
+		},
+		{
+			name: "parse error",
+			src:  "<div>\n{{if .X}}",
+			want: []string{"parse@2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Source("test.tmpl", tt.src, nil)
+			got := rulesOf(issues)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("got %v, want %v", issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	issues := Source("test.tmpl", `<button lvt-clik="save">{{template "lvt:modal:confrim:v1" .C}}`, nil)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if !strings.Contains(issues[0].Message, "did you mean lvt-click?") {
+		t.Errorf("expected an attribute suggestion, got %q", issues[0].Message)
+	}
+	if !strings.Contains(issues[1].Message, `did you mean "lvt:modal:confirm:v1"?`) {
+		t.Errorf("expected a component suggestion, got %q", issues[1].Message)
+	}
+	if issues[0].Line != 1 || issues[0].Column != 9 {
+		t.Errorf("expected the attribute at 1:9, got %d:%d", issues[0].Line, issues[0].Column)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	cfg, err := ConfigFrom(map[string]string{"range-key": "error", "unknown-attribute": "off"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := Source("test.tmpl", `{{range .Items}}<li lvt-nope>{{.}}</li>{{end}}`, cfg)
+	if len(issues) != 1 || issues[0].Rule != "range-key" || issues[0].Severity != SeverityError {
+		t.Errorf("expected a single range-key error, got %v", issues)
+	}
+	if cfg.Severity("unused-define") != SeverityWarning {
+		t.Error("rules without a setting should use their default")
+	}
+
+	if _, err := ConfigFrom(map[string]string{"no-such-rule": "off"}); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+	if _, err := ConfigFrom(map[string]string{"range-key": "loud"}); err == nil {
+		t.Error("expected an error for an invalid severity")
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	for _, name := range Attributes() {
+		// Prefixes complete to known attributes once their sub-name is typed
+		full := name
+		switch {
+		case strings.HasSuffix(name, "-on:"):
+			full += "pending"
+		case strings.HasSuffix(name, ":"):
+			full += "click"
+		case strings.HasSuffix(name, "-"):
+			full += "id"
+		}
+		if !knownAttribute(full) {
+			t.Errorf("completion %s (%s) is not a known attribute", name, full)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package logging is lvt's internal diagnostic log.
+//
+// It logs warnings by default. The global --verbose flag (or LVT_LOG=debug)
+// lowers the level so commands explain what they did: which kit and template
+// files were resolved, which files were written and why routes were or
+// weren't injected. Records go to stderr as key=value lines (or JSON with
+// LVT_LOG_FORMAT=json) so they never mix with command output.
+//
+// It also owns the messages commands print as they work (see Messages),
+// which --quiet turns off along with warnings.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	level  = new(slog.LevelVar)
+	logger atomic.Pointer[slog.Logger]
+
+	quiet    atomic.Bool  // SetQuiet was called
+	silenced atomic.Int32 // Silence calls not yet restored
+)
+
+func init() {
+	level.Set(envLevel())
+	SetOutput(os.Stderr, os.Getenv("LVT_LOG_FORMAT") == "json")
+}
+
+// envLevel returns the level LVT_LOG (or its older name LVT_LOG_LEVEL)
+// sets, or warn.
+func envLevel() slog.Level {
+	for _, name := range []string{"LVT_LOG", "LVT_LOG_LEVEL"} {
+		if l, ok := ParseLevel(os.Getenv(name)); ok {
+			return l
+		}
+	}
+	return slog.LevelWarn
+}
+
+// ParseLevel parses "debug", "info", "warn" or "error" (case-insensitive).
+func ParseLevel(s string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	}
+	return 0, false
+}
+
+// SetVerbose enables debug records, as the global --verbose flag does.
+func SetVerbose() {
+	level.Set(slog.LevelDebug)
+}
+
+// SetQuiet logs errors only and discards Messages, as the global --quiet
+// flag does.
+func SetQuiet() {
+	level.Set(slog.LevelError)
+	quiet.Store(true)
+}
+
+// Reset undoes SetVerbose, SetQuiet and SetLevel: the level is LVT_LOG's
+// again and Messages go to stdout.
+func Reset() {
+	level.Set(envLevel())
+	quiet.Store(false)
+}
+
+// SetLevel sets the minimum level that is logged.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// SetOutput directs records to w, as JSON objects when json is true.
+func SetOutput(w io.Writer, json bool) {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: dropTime}
+	var h slog.Handler = slog.NewTextHandler(w, opts)
+	if json {
+		opts.ReplaceAttr = nil
+		h = slog.NewJSONHandler(w, opts)
+	}
+	logger.Store(slog.New(h))
+}
+
+// dropTime removes timestamps from text output; a CLI run is short and the
+// lines are read interactively.
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
+
+// Logger returns the process-wide logger.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// Enabled reports whether records at l are logged.
+func Enabled(l slog.Level) bool {
+	return Logger().Enabled(context.Background(), l)
+}
+
+// Debug logs a trace record, shown with --verbose.
+func Debug(msg string, args ...any) {
+	Logger().Debug(msg, args...)
+}
+
+// Info logs a notable decision.
+func Info(msg string, args ...any) {
+	Logger().Info(msg, args...)
+}
+
+// Warn logs a problem that does not stop the command.
+func Warn(msg string, args ...any) {
+	Logger().Warn(msg, args...)
+}
+
+// Messages returns where commands print what they are doing for the user
+// to read: progress, summaries, next steps and the output of the tools
+// they run. It is stdout, or io.Discard under SetQuiet or Silence. Prompts
+// and the results scripts read are written to stdout directly, so neither
+// hides them.
+func Messages() io.Writer {
+	if quiet.Load() || silenced.Load() > 0 {
+		return io.Discard
+	}
+	return os.Stdout
+}
+
+// Silence discards Messages until the returned function is called, e.g.
+// while a dry run generates into a scratch copy.
+func Silence() (restore func()) {
+	silenced.Add(1)
+	var once sync.Once
+	return func() { once.Do(func() { silenced.Add(-1) }) }
+}
+
+// Print writes a message to Messages, as fmt.Print does to stdout.
+func Print(a ...any) {
+	fmt.Fprint(Messages(), a...)
+}
+
+// Printf writes a message to Messages, as fmt.Printf does to stdout.
+func Printf(format string, a ...any) {
+	fmt.Fprintf(Messages(), format, a...)
+}
+
+// Println writes a message to Messages, as fmt.Println does to stdout.
+func Println(a ...any) {
+	fmt.Fprintln(Messages(), a...)
+}
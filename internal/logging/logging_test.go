@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func capture(t *testing.T, l slog.Level, json bool) *bytes.Buffer {
+	t.Helper()
+	prev := level.Level()
+	var buf bytes.Buffer
+	SetLevel(l)
+	SetOutput(&buf, json)
+	t.Cleanup(func() {
+		SetLevel(prev)
+		SetOutput(os.Stderr, false)
+	})
+	return &buf
+}
+
+func TestSilentByDefault(t *testing.T) {
+	buf := capture(t, slog.LevelWarn, false)
+	Debug("template resolved", "path", "x")
+	Info("decision")
+	if buf.Len() != 0 {
+		t.Errorf("debug/info should be silent at warn level, got %q", buf.String())
+	}
+	Warn("careful")
+	if !strings.Contains(buf.String(), "careful") {
+		t.Errorf("warn should be logged, got %q", buf.String())
+	}
+}
+
+func TestVerboseText(t *testing.T) {
+	buf := capture(t, slog.LevelWarn, false)
+	SetVerbose()
+	Debug("wrote file", "path", "app/posts/posts.go", "bytes", 42)
+
+	got := buf.String()
+	if want := `level=DEBUG msg="wrote file" path=app/posts/posts.go bytes=42`; strings.TrimSpace(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if strings.Contains(got, "time=") {
+		t.Error("text output should not include timestamps")
+	}
+}
+
+func TestJSONOutput(t *testing.T) {
+	buf := capture(t, slog.LevelDebug, true)
+	Debug("kit resolved", "kit", "multi")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output is not JSON: %v (%q)", err, buf.String())
+	}
+	if rec["msg"] != "kit resolved" || rec["kit"] != "multi" || rec["time"] == nil {
+		t.Errorf("unexpected record %v", rec)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, " warn ": slog.LevelWarn, "warning": slog.LevelWarn, "error": slog.LevelError} {
+		if got, ok := ParseLevel(in); !ok || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v", in, got, ok)
+		}
+	}
+	if _, ok := ParseLevel("loud"); ok {
+		t.Error("ParseLevel should reject unknown levels")
+	}
+}
+
+func TestEnvLevel(t *testing.T) {
+	t.Setenv("LVT_LOG_LEVEL", "info")
+	t.Setenv("LVT_LOG", "")
+	if got := envLevel(); got != slog.LevelInfo {
+		t.Errorf("LVT_LOG_LEVEL=info: level %v, want info", got)
+	}
+	t.Setenv("LVT_LOG", "debug")
+	if got := envLevel(); got != slog.LevelDebug {
+		t.Errorf("LVT_LOG=debug: level %v, want debug (LVT_LOG wins)", got)
+	}
+	t.Setenv("LVT_LOG", "loud")
+	t.Setenv("LVT_LOG_LEVEL", "")
+	if got := envLevel(); got != slog.LevelWarn {
+		t.Errorf("invalid LVT_LOG: level %v, want warn", got)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	if Messages() != os.Stdout {
+		t.Fatal("messages should go to stdout by default")
+	}
+	restore := Silence()
+	if Messages() != io.Discard {
+		t.Error("messages printed during Silence")
+	}
+	restore()
+	restore() // twice, without unsilencing anyone else
+	if Messages() != os.Stdout {
+		t.Error("messages still discarded after Silence was restored")
+	}
+
+	t.Cleanup(Reset)
+	SetQuiet()
+	if Messages() != io.Discard || Enabled(slog.LevelWarn) {
+		t.Error("SetQuiet should discard messages and warnings")
+	}
+}
@@ -0,0 +1,207 @@
+// Package lsp is a language server for LiveTemplate templates (lvt lsp):
+// diagnostics from the template parser and the lint rules, completion of
+// lvt-* attributes and template functions, and go-to-definition of
+// {{define}} blocks, over stdio.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	"github.com/livetemplate/lvt/internal/lint"
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// Server is a language server session on one connection.
+type Server struct {
+	root     string
+	in       *bufio.Reader
+	out      io.Writer
+	docs     map[string]string // open documents by URI
+	lintCfg  lint.Config
+	funcs    template.FuncMap // functions of the app's templates
+	kitFuncs template.FuncMap // functions of kit templates, in [[ ]] actions
+	shutdown bool
+}
+
+// NewServer returns a server reading requests from in and writing to out.
+// root is the project directory, used until the client sends its own.
+func NewServer(root string, in io.Reader, out io.Writer) *Server {
+	s := &Server{in: bufio.NewReader(in), out: out, docs: map[string]string{}}
+	s.setRoot(root)
+	return s
+}
+
+// setRoot loads the template functions and lint settings of the project
+// at root.
+func (s *Server) setRoot(root string) {
+	s.root = root
+	s.funcs = generator.TemplateFuncs(root)
+
+	s.lintCfg = nil
+	if projectConfig, err := config.LoadProjectConfig(root); err != nil {
+		logging.Warn("failed to load project config", "root", root, "error", err)
+	} else if s.lintCfg, err = lint.ConfigFrom(projectConfig.LintRules); err != nil {
+		logging.Warn("invalid lint settings", "file", config.ProjectConfigFileName, "error", err)
+	}
+
+	// Kits share helper names, so those of the default kit complete all
+	kit, err := kits.DefaultLoader().Load("multi")
+	if err != nil {
+		kit = nil
+	}
+	if s.kitFuncs, err = generator.KitTemplateFuncs(kit); err != nil {
+		logging.Warn("failed to load kit helpers", "error", err)
+		s.kitFuncs, _ = generator.KitTemplateFuncs(nil)
+	}
+	// Set by resources generated with translations
+	s.kitFuncs["T"] = func(s string) string { return s }
+	s.kitFuncs["lang"] = func() string { return "" }
+}
+
+// Run serves requests until the client exits or closes the connection.
+func (s *Server) Run() error {
+	for {
+		msg, err := readMessage(s.in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	logging.Debug("lsp message", "method", msg.Method)
+	if msg.ID == nil {
+		return s.notification(msg)
+	}
+	if s.shutdown {
+		return s.replyError(msg.ID, codeInvalidRequest, "server is shut down")
+	}
+
+	var result any
+	var err error
+	switch msg.Method {
+	case "initialize":
+		result, err = s.initialize(msg.Params)
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/completion":
+		var params textDocumentPosition
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.completion(params.TextDocument.URI, params.Position)
+		}
+	case "textDocument/definition":
+		var params textDocumentPosition
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			if loc := s.definition(params.TextDocument.URI, params.Position); loc != nil {
+				result = loc
+			}
+		}
+	default:
+		return s.replyError(msg.ID, codeMethodNotFound, "method not supported: "+msg.Method)
+	}
+	if err != nil {
+		return s.replyError(msg.ID, codeInvalidParams, err.Error())
+	}
+	return writeMessage(s.out, map[string]any{"jsonrpc": "2.0", "id": msg.ID, "result": result})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, text string) error {
+	return writeMessage(s.out, map[string]any{"jsonrpc": "2.0", "id": id, "error": responseError{Code: code, Message: text}})
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p struct {
+		RootURI string `json:"rootUri"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.RootURI != "" {
+		s.setRoot(uriToPath(p.RootURI))
+	}
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync": map[string]any{
+				"openClose": true,
+				"change":    1, // full text
+				"save":      true,
+			},
+			"completionProvider": map[string]any{
+				"triggerCharacters": []string{"{", "[", "-", ":", "\"", " "},
+			},
+			"definitionProvider": true,
+		},
+		"serverInfo": map[string]any{"name": "lvt"},
+	}, nil
+}
+
+func (s *Server) notification(msg *message) error {
+	var params struct {
+		TextDocument   textDocumentItem `json:"textDocument"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	switch msg.Method {
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave", "textDocument/didClose":
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			logging.Warn("invalid notification", "method", msg.Method, "error", err)
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	uri := params.TextDocument.URI
+	switch msg.Method {
+	case "textDocument/didOpen":
+		s.docs[uri] = params.TextDocument.Text
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uri] = params.ContentChanges[n-1].Text
+		}
+	case "textDocument/didClose":
+		delete(s.docs, uri)
+		return s.publish(uri, []Diagnostic{})
+	}
+	text, ok := s.docs[uri]
+	if !ok {
+		return nil
+	}
+	return s.publish(uri, s.diagnostics(uri, text))
+}
+
+func (s *Server) publish(uri string, diagnostics []Diagnostic) error {
+	return writeMessage(s.out, map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "textDocument/publishDiagnostics",
+		"params":  map[string]any{"uri": uri, "diagnostics": diagnostics},
+	})
+}
+
+// isKitTemplate reports whether the document is a kit template, whose
+// [[ ]] actions run when code is generated.
+func isKitTemplate(uri string) bool {
+	return strings.HasSuffix(uri, ".tmpl.tmpl")
+}
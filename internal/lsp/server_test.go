@@ -0,0 +1,176 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	layout := filepath.Join(root, "app", "layout.tmpl")
+	if err := os.MkdirAll(filepath.Dir(layout), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(layout, []byte("<main>\n{{define \"row\"}}<tr></tr>{{end}}\n</main>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	uri := pathToURI(filepath.Join(root, "app", "posts.tmpl"))
+	text := "<div lvt-clik=\"save\">{{upper .Title}}</div>\n<button lvt-o>é</button>\n{{template \"r\" .}}{{template \"row\" .}}\n"
+
+	var in bytes.Buffer
+	send := func(id int, method string, params any) {
+		msg := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+		if id > 0 {
+			msg["id"] = id
+		}
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	at := func(line, character int) map[string]any {
+		return map[string]any{"textDocument": map[string]any{"uri": uri}, "position": Position{line, character}}
+	}
+	send(1, "initialize", map[string]any{"rootUri": pathToURI(root)})
+	send(0, "initialized", map[string]any{})
+	send(0, "textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "languageId": "gotmpl", "version": 1, "text": text}})
+	send(2, "textDocument/completion", at(1, 13))
+	send(3, "textDocument/completion", at(2, 13))
+	send(4, "textDocument/definition", at(2, 33))
+	send(5, "textDocument/hover", at(0, 0))
+	send(6, "shutdown", nil)
+	send(0, "exit", nil)
+
+	var out bytes.Buffer
+	if err := NewServer(root, &in, &out).Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	responses := map[int]json.RawMessage{}
+	var diagnostics []Diagnostic
+	r := bufio.NewReader(&out)
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		switch {
+		case msg.Method == "textDocument/publishDiagnostics":
+			var params struct {
+				Diagnostics []Diagnostic `json:"diagnostics"`
+			}
+			if err := json.Unmarshal(msg.Params, &params); err != nil {
+				t.Fatal(err)
+			}
+			diagnostics = params.Diagnostics
+		case msg.ID != nil:
+			var id int
+			_ = json.Unmarshal(*msg.ID, &id)
+			responses[id] = msg.Result
+			if msg.Error != nil {
+				responses[id] = json.RawMessage(msg.Error.Message)
+			}
+		}
+	}
+
+	if !strings.Contains(string(responses[1]), `"definitionProvider":true`) {
+		t.Errorf("initialize result = %s", responses[1])
+	}
+
+	var messages []string
+	for _, d := range diagnostics {
+		messages = append(messages, d.Code+": "+d.Message)
+	}
+	joined := strings.Join(messages, "\n")
+	for _, want := range []string{"unknown attribute lvt-clik", `function "upper" not defined`} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("diagnostics missing %q:\n%s", want, joined)
+		}
+	}
+	for _, d := range diagnostics {
+		if strings.Contains(d.Message, "lvt-clik") && d.Range.Start != (Position{0, 5}) {
+			t.Errorf("lvt-clik diagnostic at %+v, want 0:5", d.Range.Start)
+		}
+	}
+
+	var items []CompletionItem
+	if err := json.Unmarshal(responses[2], &items); err != nil {
+		t.Fatalf("attribute completion: %v (%s)", err, responses[2])
+	}
+	if labels := completionLabels(items); !strings.Contains(labels, " lvt-on: ") || strings.Contains(labels, "lvt-data-") {
+		t.Errorf("attribute completions after lvt-o = %s", labels)
+	}
+	if items[0].TextEdit.Range.Start != (Position{1, 8}) {
+		t.Errorf("attribute completion replaces from %+v, want 1:8", items[0].TextEdit.Range.Start)
+	}
+
+	if err := json.Unmarshal(responses[3], &items); err != nil {
+		t.Fatalf("template completion: %v (%s)", err, responses[3])
+	}
+	if labels := completionLabels(items); labels != " row " {
+		t.Errorf("template completions after \"r = %q", labels)
+	}
+
+	var loc Location
+	if err := json.Unmarshal(responses[4], &loc); err != nil {
+		t.Fatalf("definition: %v (%s)", err, responses[4])
+	}
+	if loc.URI != pathToURI(layout) || loc.Range.Start != (Position{1, 0}) {
+		t.Errorf("definition = %+v, want %s at 1:0", loc, pathToURI(layout))
+	}
+
+	if !strings.Contains(string(responses[5]), "not supported") {
+		t.Errorf("hover response = %s, want method not supported", responses[5])
+	}
+}
+
+func TestKitTemplateDiagnostics(t *testing.T) {
+	s := NewServer(t.TempDir(), nil, nil)
+	uri := pathToURI("/kit/components/form.tmpl.tmpl")
+
+	if d := s.diagnostics(uri, `<input class="[[inputClass]]" value="{{.Title}}">`); len(d) != 0 {
+		t.Errorf("unexpected diagnostics: %+v", d)
+	}
+	d := s.diagnostics(uri, "<form>\n[[inputClas]]")
+	if len(d) != 1 || !strings.Contains(d[0].Message, `function "inputClas" not defined`) || d[0].Range.Start.Line != 1 {
+		t.Errorf("diagnostics = %+v, want inputClas not defined on line 2", d)
+	}
+
+	s.docs[uri] = "[[ input"
+	if labels := completionLabels(s.completion(uri, Position{0, 8})); !strings.Contains(labels, " inputClass ") || strings.Contains(labels, " and ") {
+		t.Errorf("kit completions = %s", labels)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	text := "a😀b\nçd"
+	for _, tt := range []struct {
+		offset int
+		pos    Position
+	}{
+		{0, Position{0, 0}},
+		{5, Position{0, 3}},
+		{6, Position{0, 4}},
+		{9, Position{1, 1}},
+	} {
+		if got := positionAt(text, tt.offset); got != tt.pos {
+			t.Errorf("positionAt(%d) = %+v, want %+v", tt.offset, got, tt.pos)
+		}
+		if got := offsetAt(text, tt.pos); got != tt.offset {
+			t.Errorf("offsetAt(%+v) = %d, want %d", tt.pos, got, tt.offset)
+		}
+	}
+}
+
+func completionLabels(items []CompletionItem) string {
+	var b strings.Builder
+	b.WriteString(" ")
+	for _, item := range items {
+		b.WriteString(item.Label + " ")
+	}
+	return b.String()
+}
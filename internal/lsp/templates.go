@@ -0,0 +1,312 @@
+package lsp
+
+import (
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/livetemplate/lvt/internal/lint"
+)
+
+// diagnostics checks a document: kit templates with the generator's
+// parser and functions, app templates with the lint rules and the
+// functions the app registers.
+func (s *Server) diagnostics(uri, text string) []Diagnostic {
+	name := filepath.Base(uriToPath(uri))
+	var issues []lint.Issue
+	if isKitTemplate(uri) {
+		if _, err := texttemplate.New(name).Delims("[[", "]]").Funcs(s.kitFuncs).Parse(text); err != nil {
+			issues = append(issues, lint.ParseError(name, err))
+		}
+	} else {
+		issues = lint.Source(name, text, s.lintCfg)
+		parsed := true
+		for _, issue := range issues {
+			if issue.Rule == lint.ParseRule {
+				parsed = false
+			}
+		}
+		// The linter skips function checks; calls to functions the app
+		// does not register fail when it starts
+		if parsed {
+			if _, err := htmltemplate.New(name).Funcs(s.funcs).Parse(text); err != nil {
+				issues = append(issues, lint.ParseError(name, err))
+			}
+		}
+	}
+
+	diagnostics := []Diagnostic{}
+	for _, issue := range issues {
+		severity := severityWarning
+		if issue.Severity == lint.SeverityError {
+			severity = severityError
+		}
+		start := issueOffset(text, issue.Line, issue.Column)
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    Range{Start: positionAt(text, start), End: positionAt(text, tokenEnd(text, start))},
+			Severity: severity,
+			Code:     issue.Rule,
+			Source:   "lvt",
+			Message:  issue.Message,
+		})
+	}
+	return diagnostics
+}
+
+// issueOffset returns the byte offset of a 1-based line and byte column.
+func issueOffset(text string, line, col int) int {
+	offset := 0
+	for ; line > 1; line-- {
+		i := strings.IndexByte(text[offset:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		offset += i + 1
+	}
+	end := strings.IndexByte(text[offset:], '\n')
+	if end < 0 {
+		end = len(text) - offset
+	}
+	return offset + min(max(col-1, 0), end)
+}
+
+// tokenEnd returns the end of the token at offset, so diagnostics
+// underline it, or the end of the line when the offset starts none.
+func tokenEnd(text string, offset int) int {
+	end := offset
+	for end < len(text) && !strings.ContainsRune(" \t\r\n\"'<>=", rune(text[end])) {
+		end++
+	}
+	if end == offset {
+		if i := strings.IndexByte(text[offset:], '\n'); i >= 0 {
+			return offset + i
+		}
+		return len(text)
+	}
+	return end
+}
+
+// Builtin functions and keywords of Go templates.
+var (
+	builtinFuncs = []string{
+		"and", "call", "html", "index", "slice", "js", "len", "not", "or",
+		"print", "printf", "println", "urlquery", "eq", "ge", "gt", "le", "lt", "ne",
+	}
+	keywords = []string{
+		"if", "else", "end", "range", "with", "define", "template", "block", "break", "continue",
+	}
+)
+
+var (
+	templateNameBefore = regexp.MustCompile(`(?:template|block)\s+"([^"]*)$`)
+	identBefore        = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*$`)
+	attributeBefore    = regexp.MustCompile(`(?:^|\s)([A-Za-z:-]*)$`)
+)
+
+// completion returns the completions at pos: template names after
+// {{template ", functions and keywords in actions, and lvt-* attributes
+// in tags.
+func (s *Server) completion(uri string, pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	text, ok := s.docs[uri]
+	if !ok {
+		return items
+	}
+	offset := offsetAt(text, pos)
+	before := text[:offset]
+	add := func(label string, kind int, detail, typed string) {
+		if !strings.HasPrefix(label, typed) {
+			return
+		}
+		items = append(items, CompletionItem{
+			Label:  label,
+			Kind:   kind,
+			Detail: detail,
+			TextEdit: &TextEdit{
+				Range:   Range{Start: positionAt(text, offset-len(typed)), End: pos},
+				NewText: label,
+			},
+		})
+	}
+
+	if isKitTemplate(uri) && inAction(before, "[[", "]]") {
+		typed := identBefore.FindString(before)
+		for _, name := range sortedNames(s.kitFuncs) {
+			add(name, kindFunction, "kit helper", typed)
+		}
+		return items
+	}
+
+	if inAction(before, "{{", "}}") {
+		if m := templateNameBefore.FindStringSubmatch(before); m != nil {
+			for _, name := range s.templateNames(uri) {
+				add(name, kindModule, "template", m[1])
+			}
+			for _, name := range lint.ComponentTemplates() {
+				add(name, kindModule, "component template", m[1])
+			}
+			return items
+		}
+		typed := identBefore.FindString(before)
+		for _, name := range sortedNames(s.funcs) {
+			add(name, kindFunction, "app function", typed)
+		}
+		for _, name := range builtinFuncs {
+			add(name, kindFunction, "builtin", typed)
+		}
+		for _, name := range keywords {
+			add(name, kindKeyword, "", typed)
+		}
+		return items
+	}
+
+	if inTag(before) {
+		if m := attributeBefore.FindStringSubmatch(before); m != nil {
+			for _, name := range lint.Attributes() {
+				add(name, kindProperty, "LiveTemplate attribute", m[1])
+			}
+		}
+	}
+	return items
+}
+
+// inAction reports whether text ends inside an open..close action.
+func inAction(text, open, close string) bool {
+	return strings.LastIndex(text, open) > strings.LastIndex(text, close)
+}
+
+// inTag reports whether text ends inside an HTML start tag, outside an
+// attribute value.
+func inTag(text string) bool {
+	start := strings.LastIndexByte(text, '<')
+	if start < 0 || start < strings.LastIndexByte(text, '>') || inAction(text, "{{", "}}") {
+		return false
+	}
+	tag := text[start:]
+	if len(tag) < 2 || !isLetter(tag[1]) {
+		return false
+	}
+	// Actions may hold quotes of their own
+	for strings.Contains(tag, "{{") {
+		open := strings.Index(tag, "{{")
+		end := strings.Index(tag[open:], "}}")
+		if end < 0 {
+			return false
+		}
+		tag = tag[:open] + tag[open+end+2:]
+	}
+	return strings.Count(tag, `"`)%2 == 0 && strings.Count(tag, "'")%2 == 0
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func sortedNames(funcs map[string]any) []string {
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	defineNames    = regexp.MustCompile(`\{\{-?\s*(?:define|block)\s+"([^"]+)"`)
+	templateCalls  = regexp.MustCompile(`\{\{-?\s*(?:template|block)\s+"([^"]*)"`)
+	skippedWalkDir = map[string]bool{".git": true, ".lvt": true, "node_modules": true, "vendor": true}
+)
+
+// templateNames returns the names the document and the project's other
+// templates define, sorted.
+func (s *Server) templateNames(uri string) []string {
+	seen := map[string]bool{}
+	var names []string
+	s.eachTemplate(uri, func(_, text string) bool {
+		for _, m := range defineNames.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// definition returns the {{define}} or {{block}} of the template invoked at
+// pos: the document's own, or else the first in the project's templates.
+func (s *Server) definition(uri string, pos Position) *Location {
+	text, ok := s.docs[uri]
+	if !ok {
+		return nil
+	}
+	offset := offsetAt(text, pos)
+	var name string
+	for _, m := range templateCalls.FindAllStringSubmatchIndex(text, -1) {
+		if m[2] <= offset && offset <= m[3] {
+			name = text[m[2]:m[3]]
+			break
+		}
+	}
+	if name == "" {
+		return nil
+	}
+
+	pattern := regexp.MustCompile(`\{\{-?\s*(?:define|block)\s+"` + regexp.QuoteMeta(name) + `"`)
+	var loc *Location
+	s.eachTemplate(uri, func(docURI, text string) bool {
+		m := pattern.FindStringIndex(text)
+		if m == nil {
+			return true
+		}
+		start := positionAt(text, m[0])
+		loc = &Location{URI: docURI, Range: Range{Start: start, End: positionAt(text, m[1])}}
+		return false
+	})
+	return loc
+}
+
+// eachTemplate calls fn with the document at uri, then the other .tmpl
+// files of the project, open ones as edited, until fn returns false.
+func (s *Server) eachTemplate(uri string, fn func(uri, text string) bool) {
+	if !fn(uri, s.docs[uri]) {
+		return
+	}
+	_ = filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != s.root && skippedWalkDir[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".tmpl") || strings.HasSuffix(path, ".tmpl.tmpl") {
+			return nil
+		}
+		fileURI := pathToURI(path)
+		if fileURI == uri {
+			return nil
+		}
+		text, ok := s.docs[fileURI]
+		if !ok {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			text = string(content)
+		}
+		if !fn(fileURI, text) {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+}
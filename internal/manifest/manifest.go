@@ -0,0 +1,202 @@
+// Package manifest records what lvt generated in a project: every file a
+// generator created, which generator and lvt version wrote it last and its
+// checksum at that point, and the kit templates each generator used. The
+// record lives in .lvt/manifest.json and lets lvt status tell generated
+// files apart from edited, orphaned or outdated ones.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Path is the manifest's location relative to the project root.
+const Path = ".lvt/manifest.json"
+
+// skipDirs are not scanned: version control, installed dependencies and
+// scratch space, which generators don't write.
+var skipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true, "tmp": true}
+
+// skipPaths are directories not scanned because another tool owns them:
+// sqlc regenerates the models on every migration.
+var skipPaths = map[string]bool{"database/models": true}
+
+// skipFiles are not recorded: the manifest itself, project settings the
+// user is expected to edit, and files the go tool maintains.
+var skipFiles = map[string]bool{Path: true, ".lvtrc": true, "go.sum": true}
+
+// skipExts are the extensions of database files, which the app writes.
+var skipExts = map[string]bool{".db": true, ".db-wal": true, ".db-shm": true, ".db-journal": true, ".sqlite": true, ".sqlite3": true}
+
+// Manifest is the content of .lvt/manifest.json.
+type Manifest struct {
+	Files      map[string]File      `json:"files"`      // by slash-separated path
+	Generators map[string]Generator `json:"generators"` // by File.Generator
+	// Sections has the checksum of each marked section lvt wrote to a
+	// shared file such as database/queries.sql, by path and section name.
+	Sections map[string]map[string]string `json:"sections,omitempty"`
+}
+
+// File is a generated file.
+type File struct {
+	Generator string `json:"generator"` // e.g. "new", "resource posts", "auth"
+	Version   string `json:"version"`   // lvt version that last wrote it
+	Checksum  string `json:"checksum"`  // of its content when last written
+}
+
+// Generator is the latest run of a generator.
+type Generator struct {
+	Version   string            `json:"version"`
+	Kit       string            `json:"kit,omitempty"`
+	Templates map[string]string `json:"templates,omitempty"` // checksum by path in the kit
+}
+
+// Checksum returns the checksum of data as stored in the manifest.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Load reads the manifest of the project at root. A project without one
+// has an empty manifest.
+func Load(root string) (*Manifest, error) {
+	m := &Manifest{Files: make(map[string]File), Generators: make(map[string]Generator)}
+	data, err := os.ReadFile(filepath.Join(root, Path))
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", Path, err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]File)
+	}
+	if m.Generators == nil {
+		m.Generators = make(map[string]Generator)
+	}
+	return m, nil
+}
+
+// Save writes the manifest of the project at root.
+func (m *Manifest) Save(root string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(root, Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Paths returns the paths of the recorded files, sorted.
+func (m *Manifest) Paths() []string {
+	paths := make([]string, 0, len(m.Files))
+	for path := range m.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Scan returns the checksum of each file under root by slash-separated
+// path. A missing root has no files.
+func Scan(root string) (map[string]string, error) {
+	sums := make(map[string]string)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return sums, nil
+	}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if path != root && (skipDirs[entry.Name()] || skipPaths[rel]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || skipFiles[rel] || skipExts[filepath.Ext(rel)] {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sums[rel] = Checksum(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return sums, nil
+}
+
+// Record updates the manifest of the project at root after a generator
+// run, given the Scan of root from before the run. Files the run created
+// are attributed to it; recorded files it rewrote keep their generator but
+// take the new checksum and version, and recorded files it deleted are
+// dropped. Files that existed before lvt recorded them are left out. A run
+// that changed nothing is not recorded. sections are the checksums of the
+// sections the run wrote, as in Manifest.Sections.
+func Record(root string, before map[string]string, generator string, run Generator, sections map[string]map[string]string) error {
+	after, err := Scan(root)
+	if err != nil {
+		return err
+	}
+	m, err := Load(root)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for path, sum := range after {
+		old, existed := before[path]
+		f, recorded := m.Files[path]
+		switch {
+		case !existed:
+			m.Files[path] = File{Generator: generator, Version: run.Version, Checksum: sum}
+		case recorded && old != sum:
+			f.Version, f.Checksum = run.Version, sum
+			m.Files[path] = f
+		}
+		changed = changed || old != sum
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			delete(m.Files, path)
+			delete(m.Sections, path)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	m.Generators[generator] = run
+	for path, sums := range sections {
+		if m.Sections == nil {
+			m.Sections = make(map[string]map[string]string)
+		}
+		if m.Sections[path] == nil {
+			m.Sections[path] = make(map[string]string)
+		}
+		for section, sum := range sums {
+			m.Sections[path][section] = sum
+		}
+	}
+	return m.Save(root)
+}
@@ -0,0 +1,173 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// generate runs a generator that writes files in dir and records it.
+func generate(t *testing.T, dir, generator string, run Generator, files map[string]string, remove ...string) {
+	t.Helper()
+	before, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, dir, files)
+	for _, path := range remove {
+		if err := os.Remove(filepath.Join(dir, path)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Record(dir, before, generator, run, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"notes.txt": "mine\n"})
+
+	generate(t, dir, "new", Generator{Version: "v1"}, map[string]string{
+		"main.go":               "package main\n",
+		"database/schema.sql":   "-- schema\n",
+		"database/models/db.go": "package models\n",
+		"app.db":                "sqlite",
+		".lvtrc":                "kit=multi\n",
+		".git/HEAD":             "ref\n",
+	})
+	generate(t, dir, "resource posts", Generator{Version: "v2", Kit: "multi"}, map[string]string{
+		"app/posts/posts.go":  "package posts\n",
+		"main.go":             "package main\n\n// routes\n",
+		"database/schema.sql": "-- schema\nCREATE TABLE posts;\n",
+		"notes.txt":           "mine, edited by a generator\n",
+	})
+
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for path, f := range m.Files {
+		got[path] = f.Generator + "@" + f.Version
+	}
+	want := map[string]string{
+		"main.go":             "new@v2",
+		"database/schema.sql": "new@v2",
+		"app/posts/posts.go":  "resource posts@v2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+	if sum := m.Files["main.go"].Checksum; sum != Checksum([]byte("package main\n\n// routes\n")) {
+		t.Errorf("main.go checksum = %s, want that of the rewritten file", sum)
+	}
+	if g := m.Generators["resource posts"]; g.Version != "v2" || g.Kit != "multi" {
+		t.Errorf("generator = %+v", g)
+	}
+
+	// A run that deletes a file drops it; one that changes nothing is not
+	// recorded
+	generate(t, dir, "resource posts", Generator{Version: "v3"}, nil, "app/posts/posts.go")
+	generate(t, dir, "view about", Generator{Version: "v3"}, nil)
+	if m, err = Load(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Files["app/posts/posts.go"]; ok {
+		t.Error("deleted file is still recorded")
+	}
+	if _, ok := m.Generators["view about"]; ok {
+		t.Error("a run that changed nothing was recorded")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	generate(t, dir, "resource posts", Generator{Version: "v1", Kit: "multi", Templates: map[string]string{
+		"templates/resource/handler.go.tmpl": Checksum([]byte("old handler")),
+		"components/form.tmpl":               Checksum([]byte("form")),
+	}}, map[string]string{
+		"app/posts/posts.go":   "package posts\n",
+		"app/posts/posts.tmpl": "{{.Title}}\n",
+	})
+	generate(t, dir, "resource tags", Generator{Version: "v1", Kit: "multi", Templates: map[string]string{
+		"templates/resource/handler.go.tmpl": Checksum([]byte("old handler")),
+	}}, map[string]string{
+		"app/tags/tags.go":   "package tags\n",
+		"app/tags/tags.tmpl": "{{.Name}}\n",
+	})
+	writeFiles(t, dir, map[string]string{"app/posts/posts.go": "package posts\n\n// edited\n"})
+	if err := os.Remove(filepath.Join(dir, "app/posts/posts.tmpl")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "app/tags/tags.tmpl")); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	removed := func(generator string) bool { return generator == "resource tags" }
+	templates := map[string]string{"templates/resource/handler.go.tmpl": "new handler", "components/form.tmpl": "form"}
+	template := func(kit, path string) ([]byte, error) {
+		if kit != "multi" {
+			t.Errorf("template looked up in kit %q", kit)
+		}
+		if data, ok := templates[path]; ok {
+			return []byte(data), nil
+		}
+		return nil, fmt.Errorf("%s not found", path)
+	}
+	r, err := Check(dir, m, removed, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []FileStatus{{"app/posts/posts.go", "resource posts"}}; !reflect.DeepEqual(r.Modified, want) {
+		t.Errorf("modified = %v, want %v", r.Modified, want)
+	}
+	if want := []FileStatus{{"app/posts/posts.tmpl", "resource posts"}}; !reflect.DeepEqual(r.Missing, want) {
+		t.Errorf("missing = %v, want %v", r.Missing, want)
+	}
+	if want := []FileStatus{{"app/tags/tags.go", "resource tags"}}; !reflect.DeepEqual(r.Orphaned, want) {
+		t.Errorf("orphaned = %v, want %v", r.Orphaned, want)
+	}
+	want := []Update{{Generator: "resource posts", Version: "v1", Templates: []string{"templates/resource/handler.go.tmpl"}, Files: []string{"app/posts/posts.go"}}}
+	if !reflect.DeepEqual(r.Updates, want) {
+		t.Errorf("updates = %+v, want %+v", r.Updates, want)
+	}
+	if r.Clean() {
+		t.Error("Clean() = true")
+	}
+
+	templates["templates/resource/handler.go.tmpl"] = "old handler"
+	generate(t, dir, "resource posts", Generator{Version: "v2"}, map[string]string{
+		"app/posts/posts.go":   "package posts\n",
+		"app/posts/posts.tmpl": "{{.Title}}\n",
+	})
+	if m, err = Load(dir); err != nil {
+		t.Fatal(err)
+	}
+	if r, err = Check(dir, m, removed, template); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Modified)+len(r.Missing)+len(r.Updates) != 0 {
+		t.Errorf("after regenerating: %+v", r)
+	}
+}
@@ -0,0 +1,88 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Report is how a project's generated files compare with its manifest.
+type Report struct {
+	Modified []FileStatus // changed since lvt last wrote them
+	Missing  []FileStatus // deleted although their generator's output is still in use
+	Orphaned []FileStatus // left behind by a resource that was removed
+	Updates  []Update     // generators whose kit templates changed since they ran
+}
+
+// FileStatus is a recorded file in a Report.
+type FileStatus struct {
+	Path      string `json:"path"`
+	Generator string `json:"generator"`
+}
+
+// Update is a generator whose output is behind its kit's templates.
+type Update struct {
+	Generator string   `json:"generator"`
+	Version   string   `json:"version"`   // lvt version it last ran with
+	Templates []string `json:"templates"` // changed or removed since
+	Files     []string `json:"files"`     // its files that still exist
+}
+
+// Clean reports whether r found nothing to show.
+func (r *Report) Clean() bool {
+	return len(r.Modified)+len(r.Missing)+len(r.Orphaned)+len(r.Updates) == 0
+}
+
+// Check compares the project at root with its manifest. removed reports
+// whether the resource a generator produced has been removed from the
+// project; template returns the current content of a template in a kit.
+func Check(root string, m *Manifest, removed func(generator string) bool, template func(kit, path string) ([]byte, error)) (*Report, error) {
+	r := &Report{}
+	files := make(map[string][]string) // existing files by generator
+	for _, path := range m.Paths() {
+		f := m.Files[path]
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		exists := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		status := FileStatus{Path: path, Generator: f.Generator}
+		switch {
+		case removed(f.Generator):
+			if exists {
+				r.Orphaned = append(r.Orphaned, status)
+			}
+			continue
+		case !exists:
+			r.Missing = append(r.Missing, status)
+			continue
+		case Checksum(data) != f.Checksum:
+			r.Modified = append(r.Modified, status)
+		}
+		files[f.Generator] = append(files[f.Generator], path)
+	}
+
+	var names []string
+	for name := range m.Generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g := m.Generators[name]
+		if len(files[name]) == 0 || removed(name) {
+			continue
+		}
+		var changed []string
+		for path, sum := range g.Templates {
+			data, err := template(g.Kit, path)
+			if err != nil || Checksum(data) != sum {
+				changed = append(changed, path)
+			}
+		}
+		if len(changed) > 0 {
+			sort.Strings(changed)
+			r.Updates = append(r.Updates, Update{Generator: name, Version: g.Version, Templates: changed, Files: files[name]})
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,246 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/pressly/goose/v3"
+)
+
+// Go migrations are Go files in database/migrations (package migrations)
+// that register Up/Down functions with goose. goose orders them with the SQL
+// migrations by the version in their file name, but they have to be compiled
+// into the binary that runs them, so once a project has any, migrations run
+// through a small generated command at database/migrate instead of in lvt.
+
+const (
+	migrateRunnerDir = "database/migrate"
+	gooseModule      = "github.com/pressly/goose/v3@v3.26.0"
+)
+
+var goMigrationTmpl = template.Must(template.New("migration").Parse(`package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(up{{.Func}}, down{{.Func}})
+}
+
+// up{{.Func}} runs inside the migration's transaction, in version order
+// with the SQL migrations.
+func up{{.Func}}(ctx context.Context, tx *sql.Tx) error {
+	// Add your migration here, e.g.
+	// _, err := tx.ExecContext(ctx, "UPDATE posts SET slug = lower(title) WHERE slug IS NULL")
+	return nil
+}
+
+// down{{.Func}} reverts up{{.Func}}.
+func down{{.Func}}(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+`))
+
+var migrateRunnerTmpl = template.Must(template.New("migrate").Parse(`// Command migrate runs the app's migrations. Go migrations have to be
+// compiled into the binary that applies them, so ` + "`lvt migration`" + ` runs
+// this command once database/migrations contains one.
+//
+//	go run ./database/migrate [-db app.db] [-dir database/migrations] up|down|down-to VERSION|status
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+
+	_ "{{.Module}}/database/migrations"
+)
+
+func main() {
+	dbPath := flag.String("db", "app.db", "SQLite database file")
+	dir := flag.String("dir", "database/migrations", "migrations directory")
+	flag.Parse()
+	if flag.NArg() < 1 {
+		log.Fatal("usage: migrate [-db path] [-dir path] up|down|down-to VERSION|status")
+	}
+
+	db, err := sql.Open("sqlite", *dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := goose.SetDialect("sqlite3"); err != nil {
+		log.Fatal(err)
+	}
+	err = goose.RunContext(context.Background(), flag.Arg(0), db, *dir, flag.Args()[1:]...)
+	db.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+`))
+
+// CreateGo generates a Go migration file with Up/Down functions that receive
+// the migration's *sql.Tx, and the runner command that applies it.
+func (r *Runner) CreateGo(name string) error {
+	funcName := goMigrationFuncName(name)
+	if funcName == "" {
+		return fmt.Errorf("migration name %q has no letters or digits to name its functions after", name)
+	}
+	existing, err := filepath.Glob(filepath.Join(r.migrationsDir, "*_"+name+".go"))
+	if err != nil {
+		return fmt.Errorf("failed to check for existing migrations: %w", err)
+	}
+	if len(existing) > 0 {
+		// Go migrations share a package, so their functions would clash
+		return fmt.Errorf("a Go migration named %q already exists: %s", name, filepath.Base(existing[0]))
+	}
+
+	migrationPath, err := r.newMigrationPath(name, ".go")
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	if err := goMigrationTmpl.Execute(&buf, struct{ Func string }{funcName}); err != nil {
+		return fmt.Errorf("failed to render migration: %w", err)
+	}
+	if err := os.WriteFile(migrationPath, []byte(buf.String()), 0644); err != nil {
+		return fmt.Errorf("failed to create migration file: %w", err)
+	}
+	logging.Printf("Created migration: %s\n", filepath.Base(migrationPath))
+
+	created, err := r.ensureMigrateRunner()
+	if err != nil {
+		return err
+	}
+	if created {
+		logging.Printf("Created %s/main.go (runs migrations once Go migrations exist)\n", migrateRunnerDir)
+		cmd := exec.Command("go", "get", gooseModule)
+		cmd.Dir = r.projectRoot()
+		if output, err := cmd.CombinedOutput(); err != nil {
+			logging.Warn("could not add goose to go.mod; run 'go mod tidy' to resolve", "dir", r.projectRoot(), "output", strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// goMigrationFuncName turns a migration name such as "backfill_slugs" into
+// the CamelCase suffix of its up/down functions.
+func goMigrationFuncName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, ch := range name {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) {
+			upper = true
+			continue
+		}
+		if upper {
+			ch = unicode.ToUpper(ch)
+			upper = false
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+// projectRoot is the directory holding database/migrations.
+func (r *Runner) projectRoot() string {
+	return filepath.Dir(filepath.Dir(r.migrationsDir))
+}
+
+// ensureMigrateRunner writes database/migrate/main.go unless it exists. It
+// reports whether the file was created.
+func (r *Runner) ensureMigrateRunner() (bool, error) {
+	root := r.projectRoot()
+	path := filepath.Join(root, migrateRunnerDir, "main.go")
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+
+	module := readModulePath(root)
+	if module == "" {
+		return false, fmt.Errorf("go.mod not found in %s; Go migrations need the app's module path", root)
+	}
+	var buf strings.Builder
+	if err := migrateRunnerTmpl.Execute(&buf, struct{ Module string }{module}); err != nil {
+		return false, fmt.Errorf("failed to render migration runner: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", migrateRunnerDir, err)
+	}
+	if err := os.WriteFile(path, []byte(buf.String()), 0644); err != nil {
+		return false, fmt.Errorf("failed to create migration runner: %w", err)
+	}
+	return true, nil
+}
+
+// hasGoMigrations reports whether the migrations directory holds any Go
+// migration.
+func (r *Runner) hasGoMigrations() bool {
+	files, _ := filepath.Glob(filepath.Join(r.migrationsDir, "*.go"))
+	for _, file := range files {
+		if !strings.HasSuffix(file, "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
+// run executes a goose command (up, down, down-to), in process for SQL-only
+// projects and through the generated runner once Go migrations exist.
+func (r *Runner) run(command string, args ...string) error {
+	if !r.hasGoMigrations() {
+		return goose.RunContext(context.Background(), command, r.db, r.migrationsDir, args...)
+	}
+
+	if _, err := r.ensureMigrateRunner(); err != nil {
+		return err
+	}
+	dbPath, err := filepath.Abs(r.dbPath)
+	if err != nil {
+		return err
+	}
+	migrationsDir, err := filepath.Abs(r.migrationsDir)
+	if err != nil {
+		return err
+	}
+	cmdArgs := append([]string{"run", "./" + migrateRunnerDir, "-db", dbPath, "-dir", migrationsDir, command}, args...)
+	cmd := exec.Command("go", cmdArgs...)
+	cmd.Dir = r.projectRoot()
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	cmd.Stdout = logging.Messages()
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go run ./%s %s: %w", migrateRunnerDir, strings.Join(append([]string{command}, args...), " "), err)
+	}
+	return nil
+}
+
+// readModulePath returns the module path declared in root/go.mod, or "".
+func readModulePath(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,214 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// Concurrent `lvt migration up` runs — several app instances deploying at
+// once — serialize on a one-row lock table. SQLite has no advisory locks, so
+// the row is the lock: whoever inserts it migrates, everyone else waits for
+// it to be deleted. The holder refreshes heartbeat_at while it works, which
+// is how a lock left behind by a killed process is told apart from a slow
+// migration.
+//
+// The heartbeat is written to the same database the migrations run against,
+// so a long write transaction (a big backfill, a table rebuild) holds it up
+// until the transaction commits. The stale threshold is therefore measured in
+// minutes rather than heartbeats, and can be raised for migrations that hold
+// a single transaction longer than that.
+//
+// The lock only covers this runner: `lvt migration up` and `down` against
+// the app's SQLite database. Other tools writing to the database, or goose
+// run directly, don't take it.
+
+const (
+	lockTableName = "lvt_migration_lock"
+
+	// DefaultLockTimeout is how long a migration waits for another one to
+	// finish before giving up.
+	DefaultLockTimeout = time.Minute
+
+	// DefaultLockStaleAfter is how long the holder may go without refreshing
+	// the lock before it is reported as stale. It is far longer than the
+	// heartbeat interval because a migration's own write transaction keeps
+	// the heartbeat from landing until it commits.
+	DefaultLockStaleAfter = 10 * time.Minute
+
+	lockHeartbeat = 5 * time.Second
+	lockPoll      = 250 * time.Millisecond
+)
+
+// LockInfo describes who holds the migration lock.
+type LockInfo struct {
+	Holder      string
+	AcquiredAt  time.Time
+	HeartbeatAt time.Time
+}
+
+// Stale reports whether the holder has gone longer than after without
+// refreshing the lock, which means it died without releasing it.
+func (l *LockInfo) Stale(now time.Time, after time.Duration) bool {
+	return now.Sub(l.HeartbeatAt) > after
+}
+
+// StaleLockError is returned when the migration lock is held by a process
+// that stopped refreshing it.
+type StaleLockError struct {
+	Lock LockInfo
+}
+
+func (e *StaleLockError) Error() string {
+	return fmt.Sprintf("migration lock held by %s since %s looks stale (no heartbeat for %s); "+
+		"if no migration is running, release it with: lvt migration unlock "+
+		"(or raise --lock-stale-after if a single migration runs longer)",
+		e.Lock.Holder, e.Lock.AcquiredAt.Format(time.RFC3339), time.Since(e.Lock.HeartbeatAt).Round(time.Second))
+}
+
+// SetLockTimeout sets how long Up, Down and DownTo wait for the migration
+// lock. Zero fails immediately when another migration holds it.
+func (r *Runner) SetLockTimeout(d time.Duration) {
+	r.lockTimeout = d
+}
+
+// SetLockStaleAfter sets how long the lock holder may go without a heartbeat
+// before the lock is reported as stale. Raise it when one migration holds a
+// write transaction for longer than DefaultLockStaleAfter.
+func (r *Runner) SetLockStaleAfter(d time.Duration) {
+	r.lockStaleAfter = d
+}
+
+// withLock runs fn while holding the migration lock.
+func (r *Runner) withLock(fn func() error) error {
+	staleAfter := r.lockStaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultLockStaleAfter
+	}
+	release, err := acquireLock(r.db, lockHolder(), r.lockTimeout, staleAfter)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
+
+// lockHolder identifies this process in the lock table.
+func lockHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown-host"
+	}
+	return fmt.Sprintf("%s (pid %d)", host, os.Getpid())
+}
+
+// acquireLock takes the migration lock, waiting up to timeout for the
+// current holder to release it. A holder whose last heartbeat is older than
+// staleAfter is reported as stale. The returned func releases it.
+func acquireLock(db *sql.DB, holder string, timeout, staleAfter time.Duration) (func(), error) {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS ` + lockTableName + ` (
+  id INTEGER PRIMARY KEY CHECK (id = 1),
+  holder TEXT NOT NULL,
+  acquired_at TIMESTAMP NOT NULL,
+  heartbeat_at TIMESTAMP NOT NULL
+)`); err != nil {
+		return nil, fmt.Errorf("failed to create migration lock table: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	waited := false
+	for {
+		now := time.Now().UTC()
+		_, err := db.Exec(`INSERT INTO `+lockTableName+` (id, holder, acquired_at, heartbeat_at) VALUES (1, ?, ?, ?)`, holder, now, now)
+		if err == nil {
+			break
+		}
+
+		// Held by someone else (or the database is busy with their migration)
+		current, readErr := readLock(db)
+		if readErr == nil && current != nil && current.Stale(now, staleAfter) {
+			return nil, &StaleLockError{Lock: *current}
+		}
+		if time.Now().After(deadline) {
+			if current == nil {
+				return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+			}
+			return nil, fmt.Errorf("timed out after %s waiting for the migration lock held by %s since %s "+
+				"(use --lock-timeout to wait longer, or lvt migration unlock if that process is gone)",
+				timeout, current.Holder, current.AcquiredAt.Format(time.RFC3339))
+		}
+		if !waited && current != nil {
+			logging.Printf("Waiting for migration lock held by %s...\n", current.Holder)
+			waited = true
+		}
+		time.Sleep(lockPoll)
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(lockHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Best effort: while a migration's write transaction is open
+				// this waits out the busy timeout and fails, which only matters
+				// once staleAfter passes without a beat landing
+				_, _ = db.ExecContext(ctx, `UPDATE `+lockTableName+` SET heartbeat_at = ? WHERE id = 1 AND holder = ?`, time.Now().UTC(), holder)
+			}
+		}
+	}()
+
+	return func() {
+		stop()
+		<-done
+		_, _ = db.Exec(`DELETE FROM `+lockTableName+` WHERE id = 1 AND holder = ?`, holder)
+	}, nil
+}
+
+// readLock returns the current lock, or nil when nobody holds it.
+func readLock(db *sql.DB) (*LockInfo, error) {
+	var l LockInfo
+	err := db.QueryRow(`SELECT holder, acquired_at, heartbeat_at FROM `+lockTableName+` WHERE id = 1`).
+		Scan(&l.Holder, &l.AcquiredAt, &l.HeartbeatAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Unlock releases the migration lock regardless of who holds it, for
+// clearing a stale lock left by a process that died mid-migration. It
+// returns the lock it removed, or nil if there was none.
+func (r *Runner) Unlock() (*LockInfo, error) {
+	var exists int
+	if err := r.db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", lockTableName).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to read migration lock: %w", err)
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+	current, err := readLock(r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration lock: %w", err)
+	}
+	if current == nil {
+		return nil, nil
+	}
+	if _, err := r.db.Exec(`DELETE FROM ` + lockTableName + ` WHERE id = 1`); err != nil {
+		return nil, fmt.Errorf("failed to release migration lock: %w", err)
+	}
+	return current, nil
+}
@@ -0,0 +1,140 @@
+package migration
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func openLockTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "app.db")+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestAcquireLockWaitsForHolder(t *testing.T) {
+	db := openLockTestDB(t)
+
+	release, err := acquireLock(db, "first", time.Second, DefaultLockStaleAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = acquireLock(db, "second", 300*time.Millisecond, DefaultLockStaleAfter)
+	if err == nil || !strings.Contains(err.Error(), "held by first") {
+		t.Fatalf("second acquire = %v, want a timeout naming the holder", err)
+	}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		release()
+	}()
+	release2, err := acquireLock(db, "second", 5*time.Second, DefaultLockStaleAfter)
+	if err != nil {
+		t.Fatalf("lock not handed over after release: %v", err)
+	}
+	release2()
+}
+
+func TestWithLockSerializesMigrations(t *testing.T) {
+	db := openLockTestDB(t)
+	r := &Runner{db: db, lockTimeout: 10 * time.Second}
+
+	var running, overlaps atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.withLock(func() error {
+				if running.Add(1) > 1 {
+					overlaps.Add(1)
+				}
+				time.Sleep(50 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if overlaps.Load() > 0 {
+		t.Errorf("%d migrations ran while another held the lock", overlaps.Load())
+	}
+}
+
+func TestStaleLock(t *testing.T) {
+	db := openLockTestDB(t)
+	// Creates the lock table
+	release, err := acquireLock(db, "setup", time.Second, DefaultLockStaleAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	// A holder that died an hour ago without releasing
+	old := time.Now().UTC().Add(-time.Hour)
+	if _, err := db.Exec(`INSERT INTO `+lockTableName+` (id, holder, acquired_at, heartbeat_at) VALUES (1, 'crashed', ?, ?)`, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = acquireLock(db, "next", time.Minute, DefaultLockStaleAfter)
+	var stale *StaleLockError
+	if !errors.As(err, &stale) {
+		t.Fatalf("acquire = %v, want a StaleLockError without waiting for the timeout", err)
+	}
+	if stale.Lock.Holder != "crashed" || !strings.Contains(err.Error(), "lvt migration unlock") {
+		t.Errorf("error = %v", err)
+	}
+
+	r := &Runner{db: db}
+	released, err := r.Unlock()
+	if err != nil || released == nil || released.Holder != "crashed" {
+		t.Fatalf("Unlock = %+v, %v", released, err)
+	}
+	if released, err := r.Unlock(); err != nil || released != nil {
+		t.Errorf("second Unlock = %+v, %v; want nothing to release", released, err)
+	}
+	release, err = acquireLock(db, "next", time.Second, DefaultLockStaleAfter)
+	if err != nil {
+		t.Fatalf("acquire after unlock: %v", err)
+	}
+	release()
+}
+
+func TestLockStaleAfterIsConfigurable(t *testing.T) {
+	db := openLockTestDB(t)
+	release, err := acquireLock(db, "setup", time.Second, DefaultLockStaleAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	// A holder stuck in a long write transaction: its beats haven't landed
+	// for two minutes, but it is still migrating
+	last := time.Now().UTC().Add(-2 * time.Minute)
+	if _, err := db.Exec(`INSERT INTO `+lockTableName+` (id, holder, acquired_at, heartbeat_at) VALUES (1, 'busy', ?, ?)`, last, last); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = acquireLock(db, "next", 300*time.Millisecond, DefaultLockStaleAfter)
+	var stale *StaleLockError
+	if errors.As(err, &stale) || err == nil || !strings.Contains(err.Error(), "held by busy") {
+		t.Fatalf("acquire = %v, want a timeout naming the holder rather than a stale lock", err)
+	}
+
+	r := &Runner{db: db, lockTimeout: time.Minute}
+	r.SetLockStaleAfter(time.Minute)
+	err = r.withLock(func() error { return nil })
+	if !errors.As(err, &stale) || stale.Lock.Holder != "busy" {
+		t.Fatalf("withLock = %v, want a StaleLockError past the configured threshold", err)
+	}
+}
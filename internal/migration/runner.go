@@ -6,8 +6,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/logging"
+	"github.com/livetemplate/lvt/internal/ui/progress"
 	"github.com/pressly/goose/v3"
 	_ "modernc.org/sqlite"
 )
@@ -20,16 +26,18 @@ const (
 
 // Runner wraps goose for migration operations
 type Runner struct {
-	db            *sql.DB
-	migrationsDir string
+	db             *sql.DB
+	dbPath         string
+	migrationsDir  string
+	lockTimeout    time.Duration
+	lockStaleAfter time.Duration
 }
 
 // New creates a new migration runner
 // It auto-detects the database path and migrations directory
 func New() (*Runner, error) {
 	// Find migrations directory
-	migrationsDir, err := findMigrationsDir()
-	if err != nil {
+	if _, err := findMigrationsDir(); err != nil {
 		return nil, fmt.Errorf("migrations directory not found: %w", err)
 	}
 
@@ -38,9 +46,20 @@ func New() (*Runner, error) {
 	if err != nil {
 		return nil, fmt.Errorf("database not found: %w", err)
 	}
+	return Open(dbPath)
+}
+
+// Open creates a migration runner for the SQLite database at dbPath, such as
+// one just restored from a backup. The migrations directory is auto-detected.
+func Open(dbPath string) (*Runner, error) {
+	migrationsDir, err := findMigrationsDir()
+	if err != nil {
+		return nil, fmt.Errorf("migrations directory not found: %w", err)
+	}
 
-	// Open database connection
-	db, err := sql.Open("sqlite", dbPath)
+	// Open database connection. Concurrent migrations wait on each other's
+	// writes instead of failing with SQLITE_BUSY.
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=busy_timeout(5000)")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -51,8 +70,11 @@ func New() (*Runner, error) {
 	}
 
 	return &Runner{
-		db:            db,
-		migrationsDir: migrationsDir,
+		db:             db,
+		dbPath:         dbPath,
+		migrationsDir:  migrationsDir,
+		lockTimeout:    DefaultLockTimeout,
+		lockStaleAfter: DefaultLockStaleAfter,
 	}, nil
 }
 
@@ -66,34 +88,178 @@ func (r *Runner) Close() error {
 
 // Up runs all pending migrations and regenerates sqlc code
 func (r *Runner) Up() error {
-	if err := goose.Up(r.db, r.migrationsDir); err != nil {
+	if err := r.withLock(func() error { return r.run("up") }); err != nil {
 		return fmt.Errorf("migration up failed: %w", err)
 	}
 
 	// Run sqlc generate after successful migration
 	if err := r.runSqlcGenerate(); err != nil {
-		fmt.Printf("⚠️  Warning: sqlc generate failed: %v\n", err)
-		fmt.Println("   You can run it manually: cd database && sqlc generate")
+		logging.Warn("sqlc generate failed; run it manually: cd database && sqlc generate", "error", err)
 	}
 
 	return nil
 }
 
+// ApplyPending runs all pending migrations without regenerating sqlc code,
+// which already matches the migrations on disk. It brings a database
+// restored from an older backup up to date.
+func (r *Runner) ApplyPending() error {
+	if err := r.withLock(func() error { return r.run("up") }); err != nil {
+		return fmt.Errorf("migration up failed: %w", err)
+	}
+	return nil
+}
+
 // Down rolls back the most recent migration and regenerates sqlc code
 func (r *Runner) Down() error {
-	if err := goose.Down(r.db, r.migrationsDir); err != nil {
+	if err := r.withLock(func() error { return r.run("down") }); err != nil {
 		return fmt.Errorf("migration down failed: %w", err)
 	}
 
 	// Run sqlc generate after successful rollback
 	if err := r.runSqlcGenerate(); err != nil {
-		fmt.Printf("⚠️  Warning: sqlc generate failed: %v\n", err)
-		fmt.Println("   You can run it manually: cd database && sqlc generate")
+		logging.Warn("sqlc generate failed; run it manually: cd database && sqlc generate", "error", err)
+	}
+
+	return nil
+}
+
+// DownStep is a migration that a targeted rollback will revert.
+type DownStep struct {
+	Version int64
+	Source  string // migration file name
+	DownSQL string // the -- +goose Down section, without goose annotations
+	Go      bool   // a Go migration; its down function has no SQL to show
+}
+
+// PlanDown lists the migrations a rollback would revert, newest first.
+// With steps > 0 the last steps applied migrations are reverted; otherwise
+// every applied migration newer than toVersion is (0 reverts all of them).
+// It returns the version the database ends up at.
+func (r *Runner) PlanDown(toVersion int64, steps int) ([]DownStep, int64, error) {
+	migrations, err := goose.CollectMigrations(r.migrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	sources := make(map[int64]string, len(migrations))
+	for _, m := range migrations {
+		sources[m.Version] = m.Source
+	}
+	if steps <= 0 && toVersion != 0 {
+		if _, ok := sources[toVersion]; !ok {
+			return nil, 0, fmt.Errorf("no migration with version %d in %s", toVersion, r.migrationsDir)
+		}
+	}
+
+	applied, err := r.appliedVersions()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var plan []DownStep
+	target := toVersion
+	if steps > 0 {
+		target = 0
+		if steps < len(applied) {
+			target = applied[steps]
+		}
+	}
+	for _, version := range applied {
+		if version <= target {
+			break
+		}
+		source, ok := sources[version]
+		if !ok {
+			return nil, 0, fmt.Errorf("migration file not found for applied version %d", version)
+		}
+		step := DownStep{Version: version, Source: filepath.Base(source), Go: filepath.Ext(source) == ".go"}
+		if !step.Go {
+			if step.DownSQL, err = readDownSection(source); err != nil {
+				return nil, 0, err
+			}
+		}
+		plan = append(plan, step)
+	}
+	return plan, target, nil
+}
+
+// DownTo rolls back every applied migration newer than version and
+// regenerates sqlc code.
+func (r *Runner) DownTo(version int64) error {
+	if err := r.withLock(func() error { return r.run("down-to", strconv.FormatInt(version, 10)) }); err != nil {
+		return fmt.Errorf("migration down failed: %w", err)
+	}
+
+	if err := r.runSqlcGenerate(); err != nil {
+		logging.Warn("sqlc generate failed; run it manually: cd database && sqlc generate", "error", err)
 	}
 
 	return nil
 }
 
+// appliedVersions returns the applied migration versions, newest first,
+// the way goose reads them: the latest row for a version decides whether
+// it is applied.
+func (r *Runner) appliedVersions() ([]int64, error) {
+	if _, err := goose.EnsureDBVersion(r.db); err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	rows, err := r.db.Query("SELECT version_id, is_applied FROM " + migrationsTableName + " ORDER BY id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[int64]bool)
+	var applied []int64
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+		if err := rows.Scan(&version, &isApplied); err != nil {
+			return nil, fmt.Errorf("failed to read migration status: %w", err)
+		}
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+		if isApplied && version > 0 {
+			applied = append(applied, version)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	sort.Slice(applied, func(i, j int) bool { return applied[i] > applied[j] })
+	return applied, nil
+}
+
+// readDownSection returns the statements after "-- +goose Down" in a SQL
+// migration, dropping the goose annotation lines.
+func readDownSection(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read migration: %w", err)
+	}
+	var lines []string
+	inDown := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-- +goose") {
+			switch strings.TrimSpace(strings.TrimPrefix(trimmed, "-- +goose")) {
+			case "Down":
+				inDown = true
+			case "Up":
+				inDown = false
+			}
+			continue
+		}
+		if inDown {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
 // Status shows the status of all migrations
 func (r *Runner) Status() error {
 	if err := goose.Status(r.db, r.migrationsDir); err != nil {
@@ -102,35 +268,65 @@ func (r *Runner) Status() error {
 	return nil
 }
 
-// Create generates a new migration file with the given name
-func (r *Runner) Create(name string) error {
-	// Generate unique timestamp for migration
-	// Check if file exists and increment timestamp if needed to avoid conflicts
-	const maxRetries = 3600 // Safety limit: 1 hour worth of seconds
-	timestamp := time.Now()
-	var migrationPath string
-	var filename string
-	for i := 0; i < maxRetries; i++ {
-		timestampStr := timestamp.Format("20060102150405")
-		filename = fmt.Sprintf("%s_%s.sql", timestampStr, name)
-		migrationPath = filepath.Join(r.migrationsDir, filename)
+// MigrationStatus is a migration file and whether it is applied.
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Source    string     `json:"source"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
 
-		// Check if any migration file exists with this timestamp prefix
-		matches, err := filepath.Glob(filepath.Join(r.migrationsDir, timestampStr+"_*.sql"))
-		if err != nil {
-			return fmt.Errorf("failed to check for existing migrations: %w", err)
+// StatusEntries returns the status of every migration file, oldest first,
+// as Status prints it.
+func (r *Runner) StatusEntries() ([]MigrationStatus, error) {
+	migrations, err := goose.CollectMigrations(r.migrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	if _, err := goose.EnsureDBVersion(r.db); err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	// The latest row for a version decides whether it is applied
+	rows, err := r.db.Query("SELECT version_id, is_applied, tstamp FROM " + migrationsTableName + " ORDER BY id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	defer rows.Close()
+	latest := make(map[int64]*MigrationStatus)
+	for rows.Next() {
+		var st MigrationStatus
+		var appliedAt time.Time
+		if err := rows.Scan(&st.Version, &st.Applied, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read migration status: %w", err)
 		}
-		if len(matches) == 0 {
-			break
+		if _, seen := latest[st.Version]; seen {
+			continue
 		}
+		if st.Applied {
+			st.AppliedAt = &appliedAt
+		}
+		latest[st.Version] = &st
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
 
-		// Increment by 1 second and try again
-		timestamp = timestamp.Add(1 * time.Second)
-
-		// Check if we've exhausted retries (should never happen in practice)
-		if i == maxRetries-1 {
-			return fmt.Errorf("failed to generate unique migration timestamp after %d attempts", maxRetries)
+	entries := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		st := MigrationStatus{Version: m.Version, Source: filepath.Base(m.Source)}
+		if row, ok := latest[m.Version]; ok && row.Applied {
+			st.Applied, st.AppliedAt = true, row.AppliedAt
 		}
+		entries = append(entries, st)
+	}
+	return entries, nil
+}
+
+// Create generates a new SQL migration file with the given name
+func (r *Runner) Create(name string) error {
+	migrationPath, err := r.newMigrationPath(name, ".sql")
+	if err != nil {
+		return err
 	}
 
 	// Create migration file with goose format
@@ -149,10 +345,34 @@ func (r *Runner) Create(name string) error {
 		return fmt.Errorf("failed to create migration file: %w", err)
 	}
 
-	fmt.Printf("Created migration: %s\n", filename)
+	logging.Printf("Created migration: %s\n", filepath.Base(migrationPath))
 	return nil
 }
 
+// newMigrationPath returns the path for a new migration file with a unique
+// timestamp version. SQL and Go migrations share one version sequence.
+func (r *Runner) newMigrationPath(name, ext string) (string, error) {
+	// Check if file exists and increment timestamp if needed to avoid conflicts
+	const maxRetries = 3600 // Safety limit: 1 hour worth of seconds
+	timestamp := time.Now()
+	for i := 0; i < maxRetries; i++ {
+		timestampStr := timestamp.Format("20060102150405")
+
+		// Check if any migration file exists with this timestamp prefix
+		matches, err := filepath.Glob(filepath.Join(r.migrationsDir, timestampStr+"_*"))
+		if err != nil {
+			return "", fmt.Errorf("failed to check for existing migrations: %w", err)
+		}
+		if len(matches) == 0 {
+			return filepath.Join(r.migrationsDir, fmt.Sprintf("%s_%s%s", timestampStr, name, ext)), nil
+		}
+
+		// Increment by 1 second and try again
+		timestamp = timestamp.Add(1 * time.Second)
+	}
+	return "", fmt.Errorf("failed to generate unique migration timestamp after %d attempts", maxRetries)
+}
+
 // findMigrationsDir locates the migrations directory
 func findMigrationsDir() (string, error) {
 	// Try current directory first
@@ -186,6 +406,24 @@ func findMigrationsDir() (string, error) {
 
 // findDatabasePath locates the SQLite database file and creates it if it doesn't exist
 func findDatabasePath() (string, error) {
+	// A database configured for the environment (LVT_ENV) wins over app.db
+	if wd, err := os.Getwd(); err == nil {
+		root := config.ProjectRoot(wd)
+		if path := config.DatabasePath(root); path == config.MemoryDatabase {
+			return "", config.MemoryDatabaseError(config.Environment(root))
+		} else if path != "" {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return "", fmt.Errorf("failed to create database directory: %w", err)
+				}
+				if err := createEmptyDB(path); err != nil {
+					return "", fmt.Errorf("failed to create database: %w", err)
+				}
+			}
+			return path, nil
+		}
+	}
+
 	// Try current directory first
 	if _, err := os.Stat(defaultDBPath); err == nil {
 		return defaultDBPath, nil
@@ -241,7 +479,7 @@ func createEmptyDB(path string) error {
 		return err
 	}
 
-	fmt.Printf("Created database: %s\n", path)
+	fmt.Fprintf(os.Stderr, "Created database: %s\n", path)
 	return nil
 }
 
@@ -250,19 +488,14 @@ func (r *Runner) runSqlcGenerate() error {
 	// Find the database directory
 	dbDir := filepath.Dir(r.migrationsDir) // migrations is inside database
 
-	fmt.Println("Generating database code with sqlc...")
-
-	// Run sqlc generate
-	cmd := exec.Command("go", "run", "github.com/sqlc-dev/sqlc/cmd/sqlc", "generate")
-	cmd.Dir = dbDir
-	cmd.Env = append(os.Environ(), "GOWORK=off") // Disable workspace mode for nested modules
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("sqlc generate failed: %w", err)
-	}
-
-	fmt.Println("✅ Database code generated successfully!")
-	return nil
+	// Run sqlc generate; its output is only shown if it fails
+	return progress.New(logging.Messages(), 0).Run("Generating database code with sqlc", func() error {
+		cmd := exec.Command("go", "run", "github.com/sqlc-dev/sqlc/cmd/sqlc", "generate")
+		cmd.Dir = dbDir
+		cmd.Env = append(os.Environ(), "GOWORK=off") // Disable workspace mode for nested modules
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("sqlc generate failed: %w\n%s", err, output)
+		}
+		return nil
+	})
 }
@@ -0,0 +1,221 @@
+package migration
+
+import (
+	"database/sql"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+)
+
+func writeMigration(t *testing.T, dir, name, up, down string) {
+	t.Helper()
+	content := "-- +goose Up\n-- +goose StatementBegin\n" + up + "\n-- +goose StatementEnd\n\n" +
+		"-- +goose Down\n-- +goose StatementBegin\n" + down + "\n-- +goose StatementEnd\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestRunner applies three migrations to a fresh database.
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	dir := t.TempDir()
+	migrationsDir := filepath.Join(dir, "migrations")
+	if err := os.Mkdir(migrationsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeMigration(t, migrationsDir, "20240101000000_create_posts.sql", "CREATE TABLE posts (id TEXT);", "DROP TABLE posts;")
+	writeMigration(t, migrationsDir, "20240201000000_create_tags.sql", "CREATE TABLE tags (id TEXT);", "DROP TABLE tags;")
+	writeMigration(t, migrationsDir, "20240301000000_add_slug.sql", "ALTER TABLE posts ADD COLUMN slug TEXT;", "ALTER TABLE posts DROP COLUMN slug;")
+
+	db, err := sql.Open("sqlite", filepath.Join(dir, "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := goose.SetDialect("sqlite3"); err != nil {
+		t.Fatal(err)
+	}
+	goose.SetLogger(goose.NopLogger())
+	if err := goose.Up(db, migrationsDir); err != nil {
+		t.Fatal(err)
+	}
+	return &Runner{db: db, migrationsDir: migrationsDir}
+}
+
+func planSources(plan []DownStep) []string {
+	var sources []string
+	for _, step := range plan {
+		sources = append(sources, step.Source)
+	}
+	return sources
+}
+
+func TestPlanDown(t *testing.T) {
+	r := newTestRunner(t)
+
+	tests := []struct {
+		name       string
+		toVersion  int64
+		steps      int
+		wantTarget int64
+		want       []string
+	}{
+		{"one step", 0, 1, 20240201000000, []string{"20240301000000_add_slug.sql"}},
+		{"two steps", 0, 2, 20240101000000, []string{"20240301000000_add_slug.sql", "20240201000000_create_tags.sql"}},
+		{"more steps than applied", 0, 10, 0, []string{"20240301000000_add_slug.sql", "20240201000000_create_tags.sql", "20240101000000_create_posts.sql"}},
+		{"to version", 20240101000000, 0, 20240101000000, []string{"20240301000000_add_slug.sql", "20240201000000_create_tags.sql"}},
+		{"to current version", 20240301000000, 0, 20240301000000, nil},
+		{"to zero", 0, 0, 0, []string{"20240301000000_add_slug.sql", "20240201000000_create_tags.sql", "20240101000000_create_posts.sql"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, target, err := r.PlanDown(tt.toVersion, tt.steps)
+			if err != nil {
+				t.Fatalf("PlanDown: %v", err)
+			}
+			if target != tt.wantTarget {
+				t.Errorf("target = %d, want %d", target, tt.wantTarget)
+			}
+			if got := planSources(plan); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("plan = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	plan, _, _ := r.PlanDown(0, 1)
+	if plan[0].DownSQL != "ALTER TABLE posts DROP COLUMN slug;" {
+		t.Errorf("DownSQL = %q", plan[0].DownSQL)
+	}
+
+	if _, _, err := r.PlanDown(20240115000000, 0); err == nil {
+		t.Error("expected an error for a version with no migration file")
+	}
+}
+
+func TestDownToMatchesPlan(t *testing.T) {
+	r := newTestRunner(t)
+
+	plan, target, err := r.PlanDown(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := goose.DownTo(r.db, r.migrationsDir, target); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := r.appliedVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0] != 20240101000000 {
+		t.Errorf("applied after rolling back %v = %v, want [20240101000000]", planSources(plan), applied)
+	}
+
+	// Rolled-back migrations are no longer planned
+	plan, _, err = r.PlanDown(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := planSources(plan); len(got) != 1 || got[0] != "20240101000000_create_posts.sql" {
+		t.Errorf("plan after rollback = %v", got)
+	}
+}
+
+func TestStatusEntries(t *testing.T) {
+	r := newTestRunner(t)
+	if err := goose.DownTo(r.db, r.migrationsDir, 20240201000000); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := r.StatusEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, fmt.Sprintf("%s:%v", e.Source, e.Applied))
+		if e.Applied == (e.AppliedAt == nil) {
+			t.Errorf("%s: applied %v with applied_at %v", e.Source, e.Applied, e.AppliedAt)
+		}
+	}
+	want := "[20240101000000_create_posts.sql:true 20240201000000_create_tags.sql:true 20240301000000_add_slug.sql:false]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("entries = %v, want %s", got, want)
+	}
+}
+
+func TestGoMigrationFuncName(t *testing.T) {
+	tests := map[string]string{
+		"backfill_slugs":  "BackfillSlugs",
+		"split-full-name": "SplitFullName",
+		"v2_json":         "V2Json",
+		"__":              "",
+	}
+	for name, want := range tests {
+		if got := goMigrationFuncName(name); got != want {
+			t.Errorf("goMigrationFuncName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCreateGo(t *testing.T) {
+	t.Setenv("GOPROXY", "off") // the go get of goose may fail; CreateGo only warns
+	root := t.TempDir()
+	migrationsDir := filepath.Join(root, "database", "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/blog\n\ngo 1.25\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := &Runner{migrationsDir: migrationsDir}
+	if r.hasGoMigrations() {
+		t.Fatal("hasGoMigrations before any Go migration was created")
+	}
+
+	if err := r.CreateGo("backfill_slugs"); err != nil {
+		t.Fatal(err)
+	}
+	files, _ := filepath.Glob(filepath.Join(migrationsDir, "*_backfill_slugs.go"))
+	if len(files) != 1 {
+		t.Fatalf("migration files = %v", files)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, files[0], nil, 0)
+	if err != nil {
+		t.Fatalf("generated migration does not parse: %v", err)
+	}
+	if file.Name.Name != "migrations" {
+		t.Errorf("package = %s, want migrations", file.Name.Name)
+	}
+	for _, fn := range []string{"upBackfillSlugs", "downBackfillSlugs"} {
+		if file.Scope.Lookup(fn) == nil {
+			t.Errorf("generated migration has no %s", fn)
+		}
+	}
+
+	runner, err := os.ReadFile(filepath.Join(root, "database", "migrate", "main.go"))
+	if err != nil {
+		t.Fatalf("migration runner not created: %v", err)
+	}
+	if _, err := parser.ParseFile(fset, "main.go", runner, 0); err != nil {
+		t.Fatalf("migration runner does not parse: %v", err)
+	}
+	if !strings.Contains(string(runner), `_ "example.com/blog/database/migrations"`) {
+		t.Error("migration runner does not import the app's migrations package")
+	}
+	if !r.hasGoMigrations() {
+		t.Error("hasGoMigrations = false after creating one")
+	}
+
+	if err := r.CreateGo("backfill_slugs"); err == nil {
+		t.Error("expected an error for a second Go migration with the same name")
+	}
+}
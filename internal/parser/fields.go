@@ -1,7 +1,11 @@
 package parser
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -27,14 +31,25 @@ type Field struct {
 	ReferencedTable string
 	OnDelete        string   // CASCADE, SET NULL, RESTRICT, etc.
 	IsTextarea      bool     // true if field should render as textarea
+	IsJSON          bool     // true if field holds a JSON document (name:json)
+	IsRichText      bool     // true if field holds sanitized HTML edited with the rich-text editor (name:richtext)
 	IsSelect        bool     // true if field should render as <select>
 	SelectOptions   []string // options for select fields
 	IsFile          bool     // true if field is a file upload
 	IsImage         bool     // true if field is an image upload (subset of file)
+	IsCounter       bool     // true if field is a counter cache of child rows
+	CounterTable    string   // child table counted by a counter field (e.g., "comments")
+	CounterKey      string   // FK column in CounterTable pointing at this resource; empty = default
+	Default         string   // default value (name:type=value), normalized for the type
+	HasDefault      bool     // true if a default was given; Default may then be ""
+	IsGenerated     bool     // true if the value is generated when a record is created (name:string:generated(func source))
+	GeneratedFunc   string   // function generating the value, e.g. "slugify"
+	GeneratedFrom   string   // field the value is generated from
 	Metadata        FieldMetadata
 }
 
-// ParseFields parses field definitions in the format "name:type name2:type2"
+// ParseFields parses field definitions in the format "name:type name2:type2".
+// A field can be given a default with "name:type=value".
 func ParseFields(args []string) ([]Field, error) {
 	if len(args) == 0 {
 		return nil, fmt.Errorf("no fields provided")
@@ -42,120 +57,281 @@ func ParseFields(args []string) ([]Field, error) {
 
 	var fields []Field
 	for _, arg := range args {
-		parts := strings.Split(arg, ":")
-		if len(parts) < 2 {
-			return nil, fmt.Errorf("invalid field format '%s', expected 'name:type'", arg)
+		spec, value, hasDefault := strings.Cut(arg, "=")
+		field, err := parseField(spec)
+		if err != nil {
+			return nil, err
+		}
+		if hasDefault {
+			if err := field.setDefault(value); err != nil {
+				return nil, fmt.Errorf("field '%s': %w", field.Name, err)
+			}
 		}
+		fields = append(fields, field)
+	}
 
-		name := strings.TrimSpace(parts[0])
-		typ := strings.TrimSpace(parts[1])
+	return fields, nil
+}
 
-		if name == "" {
-			return nil, fmt.Errorf("field name cannot be empty")
-		}
-		if typ == "" {
-			return nil, fmt.Errorf("field type cannot be empty for field '%s'", name)
-		}
+// parseField parses a single "name:type" definition.
+func parseField(arg string) (Field, error) {
+	parts := strings.Split(arg, ":")
+	if len(parts) < 2 {
+		return Field{}, fmt.Errorf("invalid field format '%s', expected 'name:type'", arg)
+	}
 
-		// Handle select type: name:select:opt1,opt2,opt3
-		if strings.ToLower(typ) == "select" {
-			if len(parts) < 3 || strings.TrimSpace(parts[2]) == "" {
-				return nil, fmt.Errorf("field '%s': select type requires options, e.g., 'status:select:active,inactive,pending'", name)
-			}
-			rawOptions := strings.Split(parts[2], ",")
-			var options []string
-			for _, o := range rawOptions {
-				if s := strings.TrimSpace(o); s != "" {
-					options = append(options, s)
-				}
-			}
-			if len(options) < 2 {
-				return nil, fmt.Errorf("field '%s': select requires at least 2 non-empty options", name)
+	name := strings.TrimSpace(parts[0])
+	typ := strings.TrimSpace(parts[1])
+
+	if name == "" {
+		return Field{}, fmt.Errorf("field name cannot be empty")
+	}
+	if typ == "" {
+		return Field{}, fmt.Errorf("field type cannot be empty for field '%s'", name)
+	}
+
+	// Handle select type: name:select:opt1,opt2,opt3
+	if strings.ToLower(typ) == "select" {
+		if len(parts) < 3 || strings.TrimSpace(parts[2]) == "" {
+			return Field{}, fmt.Errorf("field '%s': select type requires options, e.g., 'status:select:active,inactive,pending'", name)
+		}
+		rawOptions := strings.Split(parts[2], ",")
+		var options []string
+		for _, o := range rawOptions {
+			if s := strings.TrimSpace(o); s != "" {
+				options = append(options, s)
 			}
-			fields = append(fields, Field{
-				Name:          name,
-				Type:          "select",
-				GoType:        "string",
-				SQLType:       "TEXT",
-				IsSelect:      true,
-				SelectOptions: options,
-				Metadata: FieldMetadata{
-					ValidateTag:   "required",
-					HTMLInputType: "text",
-				},
-			})
-			continue
 		}
-
-		// Handle file/image types: name:file or name:image
-		lowerTyp := strings.ToLower(typ)
-		if lowerTyp == "file" || lowerTyp == "image" {
-			fields = append(fields, Field{
-				Name:    name,
-				Type:    lowerTyp,
-				GoType:  "string",
-				SQLType: "TEXT",
-				IsFile:  true,
-				IsImage: lowerTyp == "image",
-				Metadata: FieldMetadata{
-					HTMLInputType: "file",
-				},
-			})
-			continue
+		if len(options) < 2 {
+			return Field{}, fmt.Errorf("field '%s': select requires at least 2 non-empty options", name)
 		}
+		return Field{
+			Name:          name,
+			Type:          "select",
+			GoType:        "string",
+			SQLType:       "TEXT",
+			IsSelect:      true,
+			SelectOptions: options,
+			Metadata: FieldMetadata{
+				ValidateTag:   "required",
+				HTMLInputType: "text",
+			},
+		}, nil
+	}
 
-		// Rejoin remaining parts for types that use colons (e.g., references:table:cascade)
-		fullType := strings.Join(parts[1:], ":")
+	// Handle file/image types: name:file or name:image
+	lowerTyp := strings.ToLower(typ)
+	if lowerTyp == "file" || lowerTyp == "image" {
+		return Field{
+			Name:    name,
+			Type:    lowerTyp,
+			GoType:  "string",
+			SQLType: "TEXT",
+			IsFile:  true,
+			IsImage: lowerTyp == "image",
+			Metadata: FieldMetadata{
+				HTMLInputType: "file",
+			},
+		}, nil
+	}
 
-		// Validate type
-		goType, sqlType, isTextarea, err := MapType(fullType)
+	// Handle counter cache: name:counter(child_table) or name:counter(child_table.fk_column)
+	if strings.HasPrefix(lowerTyp, "counter") {
+		table, key, err := parseCounter(typ)
 		if err != nil {
-			return nil, fmt.Errorf("field '%s': %w", name, err)
+			return Field{}, fmt.Errorf("field '%s': %w", name, err)
 		}
+		return Field{
+			Name:         name,
+			Type:         "counter",
+			GoType:       "int64",
+			SQLType:      "INTEGER",
+			IsCounter:    true,
+			CounterTable: table,
+			CounterKey:   key,
+		}, nil
+	}
 
-		// Parse reference metadata if it's a reference type
-		field := Field{
-			Name:       name,
-			Type:       fullType,
-			GoType:     goType,
-			SQLType:    sqlType,
-			IsTextarea: isTextarea,
-			Metadata:   GetFieldMetadata(typ),
+	// Handle generated values: name:string:generated(slugify source)
+	if len(parts) == 3 && strings.HasPrefix(strings.ToLower(strings.TrimSpace(parts[2])), "generated") {
+		if lowerTyp != "string" && lowerTyp != "str" {
+			return Field{}, fmt.Errorf("field '%s': generated values must be strings, e.g. '%s:string:%s'", name, name, strings.TrimSpace(parts[2]))
+		}
+		fn, source, err := parseGenerated(parts[2])
+		if err != nil {
+			return Field{}, fmt.Errorf("field '%s': %w", name, err)
 		}
+		return Field{
+			Name:          name,
+			Type:          "string",
+			GoType:        "string",
+			SQLType:       "TEXT",
+			IsGenerated:   true,
+			GeneratedFunc: fn,
+			GeneratedFrom: source,
+		}, nil
+	}
 
-		if strings.HasPrefix(strings.ToLower(fullType), "references:") {
-			// Parse: references:table_name[:on_delete_action]
-			parts := strings.Split(fullType, ":")
-			if len(parts) < 2 {
-				return nil, fmt.Errorf("field '%s': invalid references syntax, expected 'references:table_name'", name)
-			}
+	// Rejoin remaining parts for types that use colons (e.g., references:table:cascade)
+	fullType := strings.Join(parts[1:], ":")
+
+	// Validate type
+	goType, sqlType, isTextarea, err := MapType(fullType)
+	if err != nil {
+		return Field{}, fmt.Errorf("field '%s': %w", name, err)
+	}
+
+	// Parse reference metadata if it's a reference type
+	field := Field{
+		Name:       name,
+		Type:       fullType,
+		GoType:     goType,
+		SQLType:    sqlType,
+		IsTextarea: isTextarea,
+		IsJSON:     lowerTyp == "json",
+		IsRichText: lowerTyp == "richtext",
+		Metadata:   GetFieldMetadata(typ),
+	}
+
+	if strings.HasPrefix(strings.ToLower(fullType), "references:") {
+		// Parse: references:table_name[:on_delete_action]
+		parts := strings.Split(fullType, ":")
+		if len(parts) < 2 {
+			return Field{}, fmt.Errorf("field '%s': invalid references syntax, expected 'references:table_name'", name)
+		}
+
+		field.IsReference = true
+		field.ReferencedTable = parts[1]
+		field.Metadata = FieldMetadata{ValidateTag: "required", HTMLInputType: "text"}
 
-			field.IsReference = true
-			field.ReferencedTable = parts[1]
-			field.Metadata = FieldMetadata{ValidateTag: "required", HTMLInputType: "text"}
-
-			// Default to CASCADE
-			field.OnDelete = "CASCADE"
-
-			// Check for custom on_delete action
-			if len(parts) > 2 {
-				action := strings.ToUpper(parts[2])
-				switch action {
-				case "CASCADE", "SET NULL", "RESTRICT", "NO ACTION", "SET_NULL":
-					if action == "SET_NULL" {
-						action = "SET NULL"
-					}
-					field.OnDelete = action
-				default:
-					return nil, fmt.Errorf("field '%s': invalid ON DELETE action '%s' (supported: CASCADE, SET_NULL, RESTRICT, NO_ACTION)", name, parts[2])
+		// Default to CASCADE
+		field.OnDelete = "CASCADE"
+
+		// Check for custom on_delete action
+		if len(parts) > 2 {
+			action := strings.ToUpper(parts[2])
+			switch action {
+			case "CASCADE", "SET NULL", "RESTRICT", "NO ACTION", "SET_NULL":
+				if action == "SET_NULL" {
+					action = "SET NULL"
 				}
+				field.OnDelete = action
+			default:
+				return Field{}, fmt.Errorf("field '%s': invalid ON DELETE action '%s' (supported: CASCADE, SET_NULL, RESTRICT, NO_ACTION)", name, parts[2])
 			}
 		}
+	}
 
-		fields = append(fields, field)
+	return field, nil
+}
+
+// parseCounter parses "counter(table)" or "counter(table.column)".
+func parseCounter(typ string) (table, key string, err error) {
+	inner, ok := strings.CutPrefix(strings.ToLower(typ), "counter(")
+	if !ok || !strings.HasSuffix(inner, ")") {
+		return "", "", fmt.Errorf("invalid counter syntax '%s', expected 'counter(child_table)' or 'counter(child_table.fk_column)'", typ)
+	}
+	inner = strings.TrimSpace(strings.TrimSuffix(inner, ")"))
+	table, key, _ = strings.Cut(inner, ".")
+	if !isIdentifier(table) || (key != "" && !isIdentifier(key)) {
+		return "", "", fmt.Errorf("invalid counter target '%s', expected 'child_table' or 'child_table.fk_column'", inner)
 	}
+	return table, key, nil
+}
 
-	return fields, nil
+// generatedFuncs are the functions a generated value can be made with.
+var generatedFuncs = []string{"slugify"}
+
+// parseGenerated parses "generated(func source)", e.g. "generated(slugify title)".
+func parseGenerated(spec string) (fn, source string, err error) {
+	inner, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(spec)), "generated(")
+	if !ok || !strings.HasSuffix(inner, ")") {
+		return "", "", fmt.Errorf("invalid generated syntax '%s', expected 'generated(slugify source_field)'", spec)
+	}
+	words := strings.Fields(strings.TrimSuffix(inner, ")"))
+	if len(words) != 2 || !isIdentifier(words[1]) {
+		return "", "", fmt.Errorf("invalid generated syntax '%s', expected 'generated(slugify source_field)'", spec)
+	}
+	if !slices.Contains(generatedFuncs, words[0]) {
+		return "", "", fmt.Errorf("unknown generator '%s' (supported: %s)", words[0], strings.Join(generatedFuncs, ", "))
+	}
+	return words[0], words[1], nil
+}
+
+// setDefault gives the field the default value, checked against its type:
+// true/false for bools, a number for numbers, "now" for times, one of the
+// options for selects and any text for strings. A field with a default is
+// no longer required; an empty value falls back to the default.
+func (f *Field) setDefault(value string) error {
+	switch {
+	case f.IsFile, f.IsCounter, f.IsReference, f.IsGenerated, f.Metadata.IsPassword:
+		return fmt.Errorf("%s fields cannot have a default", f.Type)
+	case strings.Contains(value, "{{") || strings.Contains(value, "}}"):
+		return fmt.Errorf("default '%s' cannot contain {{ or }}", value)
+	}
+
+	switch {
+	case f.GoType == "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid bool default '%s' (expected true or false)", value)
+		}
+		value = strconv.FormatBool(b)
+	case f.GoType == "int64":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("invalid int default '%s'", value)
+		}
+	case f.GoType == "float64":
+		if _, err := strconv.ParseFloat(value, 64); err != nil || strings.ContainsAny(value, "xXpPnN_") {
+			return fmt.Errorf("invalid float default '%s'", value)
+		}
+	case f.GoType == "time.Time":
+		if !strings.EqualFold(value, "now") {
+			return fmt.Errorf("invalid time default '%s' (only 'now' is supported)", value)
+		}
+		value = "now"
+	case f.IsSelect:
+		if !slices.Contains(f.SelectOptions, value) {
+			return fmt.Errorf("default '%s' is not one of the options (%s)", value, strings.Join(f.SelectOptions, ", "))
+		}
+	case f.IsJSON:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("default '%s' is not valid JSON", value)
+		}
+	}
+
+	f.Default = value
+	f.HasDefault = true
+	f.Metadata.ValidateTag = optionalTag(f.Metadata.ValidateTag)
+	return nil
+}
+
+// optionalTag turns a validate tag into one accepting an empty value, e.g.
+// "required,min=3" into "omitempty,min=3".
+func optionalTag(tag string) string {
+	var rules []string
+	for _, rule := range strings.Split(tag, ",") {
+		if rule != "" && rule != "required" {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return "omitempty," + strings.Join(rules, ",")
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || (r >= 'a' && r <= 'z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
 }
 
 // fieldTypeInfo holds the combined type mapping and metadata for a field type.
@@ -188,6 +364,8 @@ var fieldTypeTable = map[string]fieldTypeInfo{
 	"phone":     {GoType: "string", SQLType: "TEXT", Metadata: FieldMetadata{ValidateTag: "required", HTMLInputType: "tel"}},
 	"tel":       {GoType: "string", SQLType: "TEXT", Metadata: FieldMetadata{ValidateTag: "required", HTMLInputType: "tel"}},
 	"password":  {GoType: "string", SQLType: "TEXT", Metadata: FieldMetadata{ValidateTag: "required,min=8", HTMLInputType: "password", HTMLMinLength: 8, IsPassword: true}},
+	"json":      {GoType: "string", SQLType: "TEXT", IsTextarea: true, Metadata: FieldMetadata{ValidateTag: "required,json", HTMLInputType: "text"}},
+	"richtext":  {GoType: "string", SQLType: "TEXT", IsTextarea: true, Metadata: FieldMetadata{ValidateTag: "required", HTMLInputType: "text"}},
 	"file":      {GoType: "string", SQLType: "TEXT", Metadata: FieldMetadata{HTMLInputType: "file"}},
 	"image":     {GoType: "string", SQLType: "TEXT", Metadata: FieldMetadata{HTMLInputType: "file"}},
 }
@@ -195,7 +373,7 @@ var fieldTypeTable = map[string]fieldTypeInfo{
 // supportedTypes returns a comma-separated list of primary supported type names.
 func supportedTypes() string {
 	// Show primary names (not aliases) in a logical order
-	return "string, text, int, bool, float, time, email, url, phone, tel, password, file, image"
+	return "string, text, richtext, int, bool, float, time, email, url, phone, tel, password, json, file, image"
 }
 
 // MapType maps a user-provided type to Go and SQL types.
@@ -208,7 +386,7 @@ func MapType(typ string) (goType, sqlType string, isTextarea bool, err error) {
 
 	info, ok := fieldTypeTable[strings.ToLower(typ)]
 	if !ok {
-		return "", "", false, fmt.Errorf("unsupported type '%s' (supported: %s, references:table)", typ, supportedTypes())
+		return "", "", false, fmt.Errorf("unsupported type '%s' (supported: %s, references:table, counter(table))", typ, supportedTypes())
 	}
 	return info.GoType, info.SQLType, info.IsTextarea, nil
 }
@@ -243,3 +421,63 @@ func FieldsToSQLColumns(fields []Field) string {
 	}
 	return sb.String()
 }
+
+// Index is an index declared with --index: columns in order, optionally
+// unique, and optionally partial (only the rows matching Where).
+type Index struct {
+	Columns []string
+	Unique  bool
+	Where   string // SQL condition of a partial index; "" indexes every row
+}
+
+var (
+	indexColumnRe = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+	indexWhereRe  = regexp.MustCompile(`(?i)(^|\s)where(\s|$)`)
+)
+
+// ParseIndex parses an --index spec: comma-separated columns, optionally
+// followed by "unique" and by "where <condition>", e.g. "email unique",
+// "org_id,created_at" or "slug unique where archived = 0".
+func ParseIndex(spec string) (Index, error) {
+	var ix Index
+	head := spec
+	if loc := indexWhereRe.FindStringIndex(spec); loc != nil {
+		head = spec[:loc[0]]
+		ix.Where = strings.TrimSpace(spec[loc[1]:])
+		if ix.Where == "" {
+			return Index{}, fmt.Errorf("index '%s': where requires a condition", spec)
+		}
+		if strings.Contains(ix.Where, ";") {
+			return Index{}, fmt.Errorf("index '%s': the where condition cannot contain ';'", spec)
+		}
+	}
+
+	head = strings.TrimSpace(head)
+	if fields := strings.Fields(head); len(fields) > 1 && strings.EqualFold(fields[len(fields)-1], "unique") {
+		ix.Unique = true
+		head = strings.TrimSpace(head[:len(head)-len("unique")])
+	}
+	seen := make(map[string]bool)
+	for _, col := range strings.Split(head, ",") {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if !indexColumnRe.MatchString(col) || col == "unique" || col == "where" {
+			return Index{}, fmt.Errorf("index '%s': invalid column '%s' (expected 'col1,col2 [unique] [where condition]')", spec, col)
+		}
+		if seen[col] {
+			return Index{}, fmt.Errorf("index '%s': column '%s' is listed twice", spec, col)
+		}
+		seen[col] = true
+		ix.Columns = append(ix.Columns, col)
+	}
+	return ix, nil
+}
+
+// Name returns the index's name on table, e.g. "idx_members_org_id_created_at",
+// with a "_unique" suffix for unique indexes.
+func (ix Index) Name(table string) string {
+	name := "idx_" + table + "_" + strings.Join(ix.Columns, "_")
+	if ix.Unique {
+		name += "_unique"
+	}
+	return name
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -402,6 +403,30 @@ func TestParseFieldsFileProperties(t *testing.T) {
 	}
 }
 
+func TestParseFieldsCounter(t *testing.T) {
+	fields, err := ParseFields([]string{"comments_count:counter(comments)", "votes:counter(ballots.poll_ref)"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := fields[0]
+	if !c.IsCounter || c.CounterTable != "comments" || c.CounterKey != "" {
+		t.Errorf("counter(comments) parsed as %+v", c)
+	}
+	if c.GoType != "int64" || c.SQLType != "INTEGER" {
+		t.Errorf("counter types = %q/%q, want int64/INTEGER", c.GoType, c.SQLType)
+	}
+	if fields[1].CounterTable != "ballots" || fields[1].CounterKey != "poll_ref" {
+		t.Errorf("counter(ballots.poll_ref) parsed as table=%q key=%q", fields[1].CounterTable, fields[1].CounterKey)
+	}
+
+	for _, bad := range []string{"n:counter", "n:counter()", "n:counter(comments", "n:counter(a.b.c)", "n:counter(1abc)"} {
+		if _, err := ParseFields([]string{bad}); err == nil {
+			t.Errorf("ParseFields(%q) expected error", bad)
+		}
+	}
+}
+
 func TestFieldsToGoStruct(t *testing.T) {
 	fields := []Field{
 		{Name: "name", GoType: "string"},
@@ -440,3 +465,143 @@ func TestFieldsToSQLColumns(t *testing.T) {
 		t.Error("expected 'age INTEGER NOT NULL' in SQL")
 	}
 }
+
+func TestParseFieldsJSON(t *testing.T) {
+	fields, err := ParseFields([]string{"metadata:json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := fields[0]
+	if !f.IsJSON || !f.IsTextarea {
+		t.Errorf("json field should be a JSON textarea, got %+v", f)
+	}
+	if f.GoType != "string" || f.SQLType != "TEXT" {
+		t.Errorf("json types = %q/%q, want string/TEXT", f.GoType, f.SQLType)
+	}
+	if f.Metadata.ValidateTag != "required,json" {
+		t.Errorf("json validate tag = %q, want required,json", f.Metadata.ValidateTag)
+	}
+}
+
+func TestParseFieldsRichText(t *testing.T) {
+	fields, err := ParseFields([]string{"body:richtext"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := fields[0]
+	if !f.IsRichText || !f.IsTextarea || f.IsJSON {
+		t.Errorf("richtext field should be a rich-text textarea, got %+v", f)
+	}
+	if f.GoType != "string" || f.SQLType != "TEXT" {
+		t.Errorf("richtext types = %q/%q, want string/TEXT", f.GoType, f.SQLType)
+	}
+}
+
+func TestParseIndex(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Index
+		name string
+	}{
+		{"email unique", Index{Columns: []string{"email"}, Unique: true}, "idx_users_email_unique"},
+		{"org_id,created_at", Index{Columns: []string{"org_id", "created_at"}}, "idx_users_org_id_created_at"},
+		{"org_id, Created_At", Index{Columns: []string{"org_id", "created_at"}}, "idx_users_org_id_created_at"},
+		{"slug UNIQUE WHERE archived = 0", Index{Columns: []string{"slug"}, Unique: true, Where: "archived = 0"}, "idx_users_slug_unique"},
+		{"status where status != 'done'", Index{Columns: []string{"status"}, Where: "status != 'done'"}, "idx_users_status"},
+	}
+	for _, tt := range tests {
+		got, err := ParseIndex(tt.spec)
+		if err != nil {
+			t.Errorf("ParseIndex(%q): %v", tt.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseIndex(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+		if name := got.Name("users"); name != tt.name {
+			t.Errorf("ParseIndex(%q).Name() = %q, want %q", tt.spec, name, tt.name)
+		}
+	}
+
+	for _, spec := range []string{"", "unique", "email,", "email,email", "bad-name", "email where", "email where 1; DROP TABLE users"} {
+		if _, err := ParseIndex(spec); err == nil {
+			t.Errorf("ParseIndex(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestParseFieldsDefaults(t *testing.T) {
+	tests := []struct {
+		spec     string
+		want     string
+		validate string
+	}{
+		{"published:bool=false", "false", ""},
+		{"published:bool=1", "true", ""},
+		{"title:string=Untitled", "Untitled", "omitempty,min=3"},
+		{"notes:text=", "", "omitempty,min=3"},
+		{"contact:email=info@example.com", "info@example.com", "omitempty,email"},
+		{"views:int=0", "0", ""},
+		{"price:float=9.99", "9.99", ""},
+		{"starts_at:time=NOW", "now", ""},
+		{"status:select:draft,published=draft", "draft", ""},
+		{"meta:json={}", "{}", "omitempty,json"},
+		{"formula:string=a=b", "a=b", "omitempty,min=3"},
+	}
+	for _, tt := range tests {
+		fields, err := ParseFields([]string{tt.spec})
+		if err != nil {
+			t.Errorf("ParseFields(%q): %v", tt.spec, err)
+			continue
+		}
+		f := fields[0]
+		if !f.HasDefault || f.Default != tt.want {
+			t.Errorf("ParseFields(%q) default = %q (set: %v), want %q", tt.spec, f.Default, f.HasDefault, tt.want)
+		}
+		if f.Metadata.ValidateTag != tt.validate {
+			t.Errorf("ParseFields(%q) validate = %q, want %q", tt.spec, f.Metadata.ValidateTag, tt.validate)
+		}
+	}
+
+	for _, spec := range []string{
+		"published:bool=maybe",
+		"views:int=1.5",
+		"price:float=NaN",
+		"starts_at:time=2024-01-01",
+		"status:select:draft,published=archived",
+		"meta:json={",
+		"secret:password=hunter22",
+		"cover:image=x.png",
+		"author_id:references:users=1",
+		"title:string={{.X}}",
+	} {
+		if _, err := ParseFields([]string{spec}); err == nil {
+			t.Errorf("ParseFields(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestParseFieldsGenerated(t *testing.T) {
+	fields, err := ParseFields([]string{"title:string", "slug:string:generated(slugify title)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slug := fields[1]
+	if !slug.IsGenerated || slug.GeneratedFunc != "slugify" || slug.GeneratedFrom != "title" || slug.GoType != "string" {
+		t.Errorf("slug = %+v", slug)
+	}
+
+	for _, spec := range []string{
+		"slug:int:generated(slugify title)",
+		"slug:string:generated(upper title)",
+		"slug:string:generated(slugify)",
+		"slug:string:generated(slugify title",
+		"slug:string:generated(slugify title)=x",
+	} {
+		if _, err := ParseFields([]string{spec}); err == nil {
+			t.Errorf("ParseFields(%q) succeeded, want an error", spec)
+		}
+	}
+}
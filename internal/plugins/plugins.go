@@ -0,0 +1,352 @@
+// Package plugins finds and runs generator plugins: executables in the
+// project's .lvt/plugins/ or the user's ~/.config/lvt/plugins/ that add
+// `lvt gen <name>` subcommands and run before and after the built-in
+// generators.
+//
+// lvt asks each plugin what it provides by running `<plugin> describe`,
+// which prints a Manifest as JSON. A subcommand runs as
+// `<plugin> gen <name> [args...]`; a hook runs as `<plugin> <hook>` with an
+// Event as JSON on stdin.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/logging"
+)
+
+// Hooks a plugin can list in its manifest.
+const (
+	HookPreGen  = "pre-gen"  // before a built-in generator; failing stops it
+	HookPostGen = "post-gen" // after a built-in generator succeeded; may contribute files
+)
+
+// describeTimeout bounds `<plugin> describe`, which runs on every `lvt gen`.
+const describeTimeout = 10 * time.Second
+
+// Source tells where a plugin was found.
+type Source string
+
+const (
+	SourceProject Source = "project" // .lvt/plugins/ in the project or a parent directory
+	SourceUser    Source = "user"    // ~/.config/lvt/plugins/
+)
+
+// Command is a `lvt gen` subcommand a plugin adds.
+type Command struct {
+	Name        string `json:"name"`
+	Usage       string `json:"usage,omitempty"` // arguments, e.g. "<name> [--queue]"
+	Description string `json:"description,omitempty"`
+}
+
+// Manifest is what a plugin prints for `<plugin> describe`.
+type Manifest struct {
+	Description string    `json:"description,omitempty"`
+	Commands    []Command `json:"commands,omitempty"`
+	Hooks       []string  `json:"hooks,omitempty"` // HookPreGen, HookPostGen
+}
+
+// Plugin is an executable found in a plugin directory.
+type Plugin struct {
+	Name   string // file name without extension
+	Path   string
+	Source Source
+	Manifest
+}
+
+// HasHook reports whether the plugin asked to run at hook.
+func (p *Plugin) HasHook(hook string) bool {
+	for _, h := range p.Hooks {
+		if h == hook {
+			return true
+		}
+	}
+	return false
+}
+
+// Env is the project a plugin runs for. Commands get it as LVT_PROJECT_DIR,
+// LVT_MODULE and LVT_KIT; hooks get it in their Event as well.
+type Env struct {
+	ProjectDir string
+	Module     string // "" outside a Go module
+	Kit        string
+}
+
+func (e Env) environ() []string {
+	return append(os.Environ(),
+		"LVT_PROJECT_DIR="+e.ProjectDir,
+		"LVT_MODULE="+e.Module,
+		"LVT_KIT="+e.Kit,
+	)
+}
+
+// Event is what a hook reads on stdin.
+type Event struct {
+	Hook       string   `json:"hook"`
+	Generator  string   `json:"generator"` // the `lvt gen` subcommand, e.g. "resource"
+	Args       []string `json:"args"`      // its arguments
+	ProjectDir string   `json:"project_dir"`
+	Module     string   `json:"module"`
+	Kit        string   `json:"kit"`
+}
+
+// File is a file a post-gen hook adds to the generator's output.
+type File struct {
+	Path    string `json:"path"` // relative to the project directory
+	Content string `json:"content"`
+	Keep    bool   `json:"keep,omitempty"` // leave an existing file alone, for files the user edits
+}
+
+// Contribution is what a post-gen hook may print on stdout.
+type Contribution struct {
+	Files []File `json:"files"`
+}
+
+// Discover returns the plugins for the project in dir: those in .lvt/plugins/
+// of dir or its nearest parent with one, then those in ~/.config/lvt/plugins/
+// that the project does not override with its own of the same name. Plugins
+// that fail to describe themselves are skipped with a warning.
+func Discover(dir string) ([]*Plugin, error) {
+	var found []*Plugin
+	seen := make(map[string]bool)
+	for _, d := range searchDirs(dir) {
+		entries, err := os.ReadDir(d.path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin directory %s: %w", d.path, err)
+		}
+		for _, entry := range entries {
+			path := filepath.Join(d.path, entry.Name())
+			name := pluginName(entry.Name())
+			if seen[name] || !isExecutable(path) {
+				continue
+			}
+			manifest, err := describe(path)
+			if err != nil {
+				logging.Warn("skipping plugin", "path", path, "error", err)
+				continue
+			}
+			seen[name] = true
+			found = append(found, &Plugin{Name: name, Path: path, Source: d.source, Manifest: manifest})
+			logging.Debug("plugin found", "name", name, "path", path)
+		}
+	}
+	return found, nil
+}
+
+type searchDir struct {
+	path   string
+	source Source
+}
+
+func searchDirs(dir string) []searchDir {
+	var dirs []searchDir
+	for d := dir; ; {
+		path := filepath.Join(d, ".lvt", "plugins")
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			dirs = append(dirs, searchDir{path, SourceProject})
+			break
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			break
+		}
+		d = parent
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, searchDir{filepath.Join(home, ".config", "lvt", "plugins"), SourceUser})
+	}
+	return dirs
+}
+
+func pluginName(file string) string {
+	if runtime.GOOS == "windows" {
+		return strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	return file
+}
+
+func isExecutable(path string) bool {
+	if strings.HasPrefix(filepath.Base(path), ".") {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode()&0111 != 0
+}
+
+func describe(path string) (Manifest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "describe")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return Manifest{}, fmt.Errorf("describe failed: %w%s", err, stderrSuffix(stderr))
+	}
+	var m Manifest
+	if err := json.Unmarshal(stdout.Bytes(), &m); err != nil {
+		return Manifest{}, fmt.Errorf("describe printed invalid JSON: %w", err)
+	}
+	for _, c := range m.Commands {
+		if c.Name == "" || strings.HasPrefix(c.Name, "-") {
+			return Manifest{}, fmt.Errorf("invalid command name %q", c.Name)
+		}
+	}
+	for _, h := range m.Hooks {
+		if h != HookPreGen && h != HookPostGen {
+			return Manifest{}, fmt.Errorf("unknown hook %q (expected %s or %s)", h, HookPreGen, HookPostGen)
+		}
+	}
+	return m, nil
+}
+
+// FindCommand returns the first plugin adding the `lvt gen` subcommand name.
+func FindCommand(plugins []*Plugin, name string) (*Plugin, bool) {
+	for _, p := range plugins {
+		for _, c := range p.Commands {
+			if c.Name == name {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Commands returns the subcommands the plugins add, sorted by name. When two
+// plugins add the same one, the first plugin's wins.
+func Commands(plugins []*Plugin) []Command {
+	var cmds []Command
+	seen := make(map[string]bool)
+	for _, p := range plugins {
+		for _, c := range p.Commands {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				cmds = append(cmds, c)
+			}
+		}
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name < cmds[j].Name })
+	return cmds
+}
+
+// RunCommand runs the plugin's `lvt gen` subcommand name in the project
+// directory, connected to the terminal.
+func RunCommand(p *Plugin, env Env, name string, args []string) error {
+	cmd := exec.Command(p.Path, append([]string{"gen", name}, args...)...)
+	cmd.Dir = env.ProjectDir
+	cmd.Env = env.environ()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plugin %s: gen %s: %w", p.Name, name, err)
+	}
+	return nil
+}
+
+// RunHooks runs hook for the built-in generator with args in each plugin
+// that asked for it, in discovery order, and stops at the first that fails.
+// Pre-gen hooks print to the terminal. Post-gen hooks print a Contribution
+// on stdout, or nothing; its files are written into the project, and their
+// paths are returned.
+func RunHooks(plugins []*Plugin, env Env, hook, generator string, args []string) ([]string, error) {
+	event, err := json.Marshal(Event{
+		Hook:       hook,
+		Generator:  generator,
+		Args:       append([]string{}, args...),
+		ProjectDir: env.ProjectDir,
+		Module:     env.Module,
+		Kit:        env.Kit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, p := range plugins {
+		if !p.HasHook(hook) {
+			continue
+		}
+		var stdout bytes.Buffer
+		cmd := exec.Command(p.Path, hook)
+		cmd.Dir = env.ProjectDir
+		cmd.Env = env.environ()
+		cmd.Stdin = bytes.NewReader(event)
+		cmd.Stdout = logging.Messages()
+		if hook == HookPostGen {
+			cmd.Stdout = &stdout
+		}
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return written, fmt.Errorf("plugin %s: %s hook failed: %w", p.Name, hook, err)
+		}
+		if stdout.Len() == 0 {
+			continue
+		}
+		var c Contribution
+		if err := json.Unmarshal(stdout.Bytes(), &c); err != nil {
+			return written, fmt.Errorf("plugin %s: %s hook printed invalid JSON: %w", p.Name, hook, err)
+		}
+		for _, f := range c.Files {
+			path, ok, err := writeFile(env.ProjectDir, f)
+			if err != nil {
+				return written, fmt.Errorf("plugin %s: %w", p.Name, err)
+			}
+			if ok {
+				written = append(written, path)
+			}
+		}
+	}
+	return written, nil
+}
+
+// writeFile writes a contributed file under projectDir, refusing paths that
+// leave it. It reports whether the file was written: a kept file that
+// already exists is not.
+func writeFile(projectDir string, f File) (string, bool, error) {
+	rel := filepath.Clean(filepath.FromSlash(f.Path))
+	if f.Path == "" || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false, fmt.Errorf("invalid file path %q: must be relative to the project", f.Path)
+	}
+	path := filepath.Join(projectDir, rel)
+	if f.Keep {
+		if _, err := os.Stat(path); err == nil {
+			return rel, false, nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", false, fmt.Errorf("failed to create directory for %s: %w", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
+		return "", false, fmt.Errorf("failed to write %s: %w", rel, err)
+	}
+	logging.Debug("wrote file", "path", path, "bytes", len(f.Content))
+	return rel, true, nil
+}
+
+func stderrSuffix(stderr bytes.Buffer) string {
+	if s := strings.TrimSpace(stderr.String()); s != "" {
+		return ": " + s
+	}
+	return ""
+}
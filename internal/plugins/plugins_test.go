@@ -0,0 +1,174 @@
+package plugins
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writePlugin writes a shell script plugin answering describe with manifest
+// and running body for other commands.
+func writePlugin(t *testing.T, dir, name, manifest, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts in these tests")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\nif [ \"$1\" = describe ]; then\n  printf '%s' '" + manifest + "'\n  exit 0\nfi\n" + body + "\n"
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	project := t.TempDir()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	projectDir := filepath.Join(project, ".lvt", "plugins")
+	userDir := filepath.Join(home, ".config", "lvt", "plugins")
+
+	writePlugin(t, projectDir, "acme", `{"description":"Acme","commands":[{"name":"service","description":"Generate a service"}],"hooks":["post-gen"]}`, "")
+	writePlugin(t, userDir, "acme", `{"description":"user copy"}`, "")
+	writePlugin(t, userDir, "extra", `{"commands":[{"name":"widget"}]}`, "")
+	writePlugin(t, projectDir, "broken", `not json`, "")
+	if err := os.WriteFile(filepath.Join(projectDir, "README"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Discovery starts from a subdirectory of the project
+	sub := filepath.Join(project, "app", "posts")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	plugs, err := Discover(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range plugs {
+		names = append(names, p.Name+"/"+string(p.Source))
+	}
+	if got, want := strings.Join(names, ","), "acme/project,extra/user"; got != want {
+		t.Fatalf("plugins = %s, want %s", got, want)
+	}
+	if plugs[0].Description != "Acme" || !plugs[0].HasHook(HookPostGen) || plugs[0].HasHook(HookPreGen) {
+		t.Errorf("acme manifest = %+v", plugs[0].Manifest)
+	}
+
+	if p, ok := FindCommand(plugs, "widget"); !ok || p.Name != "extra" {
+		t.Errorf("FindCommand(widget) = %v, %v", p, ok)
+	}
+	if _, ok := FindCommand(plugs, "missing"); ok {
+		t.Error("FindCommand(missing) found a plugin")
+	}
+	var cmds []string
+	for _, c := range Commands(plugs) {
+		cmds = append(cmds, c.Name)
+	}
+	if got := strings.Join(cmds, ","); got != "service,widget" {
+		t.Errorf("Commands = %s", got)
+	}
+}
+
+func TestDiscoverRejectsUnknownHook(t *testing.T) {
+	project := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	writePlugin(t, filepath.Join(project, ".lvt", "plugins"), "odd", `{"hooks":["pre-new"]}`, "")
+
+	plugs, err := Discover(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plugs) != 0 {
+		t.Errorf("plugin with an unknown hook was loaded: %+v", plugs[0])
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	project := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(project, ".lvt", "plugins")
+	events := filepath.Join(project, "events.json")
+	writePlugin(t, dir, "acme", `{"hooks":["pre-gen","post-gen"]}`, `
+cat >> "`+events+`"
+if [ "$1" = post-gen ]; then
+  printf '%s' '{"files":[{"path":"app/posts/extra.go","content":"package posts\n"},{"path":"app/posts/kept.go","content":"new","keep":true}]}'
+fi`)
+	kept := filepath.Join(project, "app", "posts", "kept.go")
+	if err := os.MkdirAll(filepath.Dir(kept), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(kept, []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plugs, err := Discover(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := Env{ProjectDir: project, Module: "example.com/app", Kit: "multi"}
+	if _, err := RunHooks(plugs, env, HookPreGen, "resource", []string{"posts", "title"}); err != nil {
+		t.Fatalf("pre-gen: %v", err)
+	}
+	written, err := RunHooks(plugs, env, HookPostGen, "resource", []string{"posts", "title"})
+	if err != nil {
+		t.Fatalf("post-gen: %v", err)
+	}
+	if got := strings.Join(written, ","); got != filepath.Join("app", "posts", "extra.go") {
+		t.Errorf("written = %s", got)
+	}
+	if data, _ := os.ReadFile(filepath.Join(project, "app", "posts", "extra.go")); string(data) != "package posts\n" {
+		t.Errorf("extra.go = %q", data)
+	}
+	if data, _ := os.ReadFile(kept); string(data) != "mine" {
+		t.Errorf("kept file was overwritten: %q", data)
+	}
+
+	data, err := os.ReadFile(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	var pre, post Event
+	if err := dec.Decode(&pre); err != nil {
+		t.Fatal(err)
+	}
+	if err := dec.Decode(&post); err != nil {
+		t.Fatal(err)
+	}
+	if pre.Hook != HookPreGen || post.Hook != HookPostGen || pre.Generator != "resource" || strings.Join(pre.Args, " ") != "posts title" || pre.Module != "example.com/app" || pre.Kit != "multi" {
+		t.Errorf("events = %+v, %+v", pre, post)
+	}
+}
+
+func TestRunHooksFailures(t *testing.T) {
+	tests := []struct {
+		name, body, hook, want string
+	}{
+		{"pre-gen fails", "exit 3", HookPreGen, "pre-gen hook failed"},
+		{"invalid output", "printf 'done'", HookPostGen, "invalid JSON"},
+		{"path outside project", `printf '%s' '{"files":[{"path":"../escape.go","content":"x"}]}'`, HookPostGen, "must be relative to the project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			t.Setenv("HOME", t.TempDir())
+			writePlugin(t, filepath.Join(project, ".lvt", "plugins"), "acme", `{"hooks":["`+tt.hook+`"]}`, tt.body)
+			plugs, err := Discover(project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = RunHooks(plugs, Env{ProjectDir: project}, tt.hook, "view", nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
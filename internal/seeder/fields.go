@@ -0,0 +1,136 @@
+package seeder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/livetemplate/lvt/internal/config"
+)
+
+// resourceField is the part of a .lvtresources field the seeder uses.
+type resourceField struct {
+	Name     string   `json:"name"`
+	Textarea bool     `json:"textarea"`
+	RichText bool     `json:"richtext"`
+	Options  []string `json:"options"`
+}
+
+type resourceEntry struct {
+	Table  string          `json:"table"`
+	Fields []resourceField `json:"fields"`
+}
+
+// Configure prepares value generation for the project in root: field
+// hints from .lvtresources (textareas, select options), the seed.field.*
+// overrides and seed.locale from .lvtrc. A non-empty locale overrides
+// seed.locale.
+func Configure(root string, tables []TableSchema, locale string) error {
+	applyResourceHints(root, tables)
+
+	cfg, err := config.LoadProjectConfig(root)
+	if err != nil {
+		return err
+	}
+	if err := ApplyFieldMapping(tables, cfg.SeedFields); err != nil {
+		return fmt.Errorf("%s: %w", config.ProjectConfigFileName, err)
+	}
+	if locale == "" {
+		locale = cfg.SeedLocale
+	}
+	return SetLocale(locale)
+}
+
+// ProjectRoot returns the project root of a schema.sql path.
+func ProjectRoot(schemaPath string) string {
+	return filepath.Dir(filepath.Dir(schemaPath))
+}
+
+// applyResourceHints marks the columns `lvt gen` recorded as textareas or
+// selects. Resources generated before the hints were recorded keep the
+// name-based values.
+func applyResourceHints(root string, tables []TableSchema) {
+	data, err := os.ReadFile(filepath.Join(root, ".lvtresources"))
+	if err != nil {
+		return
+	}
+	var entries []resourceEntry
+	if json.Unmarshal(data, &entries) != nil {
+		return
+	}
+	for _, entry := range entries {
+		table := FindTable(tables, entry.Table)
+		if entry.Table == "" || table == nil {
+			continue
+		}
+		for _, f := range entry.Fields {
+			if col := table.Column(f.Name); col != nil {
+				col.Textarea = f.Textarea
+				col.RichText = f.RichText
+				col.Options = f.Options
+			}
+		}
+	}
+}
+
+// ApplyFieldMapping sets the generator of the columns named in mapping,
+// keyed by column (any table) or table.column, which wins. A generator is a
+// gofakeit function name such as "company" or "sentence", or a template
+// such as "{firstname}-{number:1,99}" or "SKU-####".
+func ApplyFieldMapping(tables []TableSchema, mapping map[string]string) error {
+	for key, generator := range mapping {
+		template, err := generatorTemplate(generator)
+		if err != nil {
+			return fmt.Errorf("seed.field.%s: %w", key, err)
+		}
+		tableName, column, qualified := strings.Cut(key, ".")
+		if !qualified {
+			column = key
+		}
+		for i := range tables {
+			if qualified && tables[i].Name != tableName {
+				continue
+			}
+			col := tables[i].Column(column)
+			if col == nil {
+				continue
+			}
+			// A table.column mapping is not overridden by a plain column one
+			if !qualified && col.Generator != "" && mapping[tables[i].Name+"."+column] != "" {
+				continue
+			}
+			col.Generator = template
+		}
+	}
+	return nil
+}
+
+// generatorTemplate turns a mapping value into a gofakeit template.
+func generatorTemplate(generator string) (string, error) {
+	generator = strings.TrimSpace(generator)
+	if generator == "" {
+		return "", fmt.Errorf("empty generator")
+	}
+	if !strings.ContainsAny(generator, "{#?") {
+		if gofakeit.GetFuncLookup(generator) == nil {
+			return "", fmt.Errorf("unknown generator %q (use a gofakeit function name such as company, or a template such as {firstname})", generator)
+		}
+		generator = "{" + generator + "}"
+	}
+	// gofakeit leaves unknown {functions} in the output instead of failing
+	for _, m := range templateFuncRegex.FindAllStringSubmatch(generator, -1) {
+		if gofakeit.GetFuncLookup(m[1]) == nil {
+			return "", fmt.Errorf("unknown generator {%s} in %q", m[1], generator)
+		}
+	}
+	if _, err := gofakeit.Generate(generator); err != nil {
+		return "", fmt.Errorf("invalid template %q: %w", generator, err)
+	}
+	return generator, nil
+}
+
+var templateFuncRegex = regexp.MustCompile(`\{([^{}:]+)(?::[^{}]*)?\}`)
@@ -0,0 +1,78 @@
+package seeder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyFieldMapping(t *testing.T) {
+	tables := []TableSchema{
+		{Name: "products", Columns: []Column{{Name: "sku", Type: "TEXT"}, {Name: "title", Type: "TEXT"}}},
+		{Name: "posts", Columns: []Column{{Name: "title", Type: "TEXT"}}},
+	}
+	mapping := map[string]string{
+		"sku":         "SKU-####",
+		"title":       "company",
+		"posts.title": "{hackerphrase}",
+	}
+	if err := ApplyFieldMapping(tables, mapping); err != nil {
+		t.Fatal(err)
+	}
+	if got := tables[0].Column("sku").Generator; got != "SKU-####" {
+		t.Errorf("sku generator = %q", got)
+	}
+	if got := tables[0].Column("title").Generator; got != "{company}" {
+		t.Errorf("products.title generator = %q, want {company}", got)
+	}
+	if got := tables[1].Column("title").Generator; got != "{hackerphrase}" {
+		t.Errorf("posts.title generator = %q, want the table.column mapping", got)
+	}
+	if sku, _ := GenerateValue(*tables[0].Column("sku")).(string); !strings.HasPrefix(sku, "SKU-") || len(sku) != 8 {
+		t.Errorf("sku = %q", sku)
+	}
+
+	for _, bad := range []string{"nosuchfunction", "{nosuchfunction}", " "} {
+		if err := ApplyFieldMapping(tables, map[string]string{"sku": bad}); err == nil {
+			t.Errorf("mapping to %q was accepted", bad)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { _ = SetLocale("") })
+	root := t.TempDir()
+	resources := `[{"name": "Posts", "table": "posts", "fields": [
+		{"name": "body", "textarea": true},
+		{"name": "state", "options": ["draft", "live"]}
+	]}]`
+	if err := os.WriteFile(filepath.Join(root, ".lvtresources"), []byte(resources), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lvtrc := "seed.locale=\"fr_FR\"\nseed.field.posts.slug=\"post-###\"\n"
+	if err := os.WriteFile(filepath.Join(root, ".lvtrc"), []byte(lvtrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tables := []TableSchema{{Name: "posts", Columns: []Column{
+		{Name: "body", Type: "TEXT"}, {Name: "state", Type: "TEXT"}, {Name: "slug", Type: "TEXT"},
+	}}}
+	if err := Configure(root, tables, ""); err != nil {
+		t.Fatal(err)
+	}
+	posts := tables[0]
+	if !posts.Column("body").Textarea || len(posts.Column("state").Options) != 2 || posts.Column("slug").Generator != "post-###" {
+		t.Errorf("columns after Configure = %+v", posts.Columns)
+	}
+	if activeLocale != locales["fr_FR"] {
+		t.Error("seed.locale from .lvtrc was not applied")
+	}
+
+	// The --locale flag wins over .lvtrc
+	if err := Configure(root, tables, "es"); err != nil {
+		t.Fatal(err)
+	}
+	if activeLocale != locales["es_ES"] {
+		t.Error("the locale argument did not override seed.locale")
+	}
+}
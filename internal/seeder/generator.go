@@ -1,6 +1,7 @@
 package seeder
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +9,27 @@ import (
 	"github.com/brianvoe/gofakeit/v7"
 )
 
+// SeedEpoch is the time Reproducible pins generated dates and ids to, so
+// seeded data is the same on any day.
+var SeedEpoch = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// clock returns the time generated dates and ids are relative to.
+var clock = time.Now
+
+// SetSeed makes the generated values reproducible: the same seed produces
+// the same sequence of values. Dates stay relative to today.
+func SetSeed(seed int64) error {
+	return gofakeit.Seed(seed)
+}
+
+// Reproducible seeds the generated values and pins dates and ids to
+// SeedEpoch, so the same seed produces an identical dataset on every run
+// and machine.
+func Reproducible(seed int64) error {
+	clock = func() time.Time { return SeedEpoch }
+	return SetSeed(seed)
+}
+
 // GenerateValue generates a realistic value for a column based on its name and type
 func GenerateValue(column Column) interface{} {
 	// Skip generated fields
@@ -15,68 +37,130 @@ func GenerateValue(column Column) interface{} {
 		return nil
 	}
 
+	// A configured generator wins; Configure has checked it
+	if column.Generator != "" {
+		if value, err := gofakeit.Generate(column.Generator); err == nil {
+			return value
+		}
+	}
+
+	// Select fields hold one of their options
+	if len(column.Options) > 0 {
+		return gofakeit.RandomString(column.Options)
+	}
+
+	// JSON columns must hold a valid document
+	if column.IsJSON {
+		return generateJSON()
+	}
+
+	// Rich text is HTML: a paragraph per paragraph of prose
+	if column.RichText {
+		return "<p>" + strings.ReplaceAll(loremIpsum(), "\n\n", "</p><p>") + "</p>"
+	}
+
+	// Long-form fields get placeholder prose
+	if column.Textarea {
+		return loremIpsum()
+	}
+
 	// Context-aware generation based on field name
 	fieldLower := strings.ToLower(column.Name)
+	l := activeLocale
 
 	// Check for specific field patterns
 	switch {
 	case contains(fieldLower, "email"):
+		if l != nil {
+			return l.email()
+		}
 		return gofakeit.Email()
-	case contains(fieldLower, "name") && !contains(fieldLower, "username", "filename"):
-		return gofakeit.Name()
 	case contains(fieldLower, "username", "user_name"):
 		return gofakeit.Username()
-	case contains(fieldLower, "first_name", "firstname"):
+	case contains(fieldLower, "first_name", "firstname", "given_name"):
+		if l != nil {
+			return l.pick(l.FirstNames)
+		}
 		return gofakeit.FirstName()
-	case contains(fieldLower, "last_name", "lastname"):
+	case contains(fieldLower, "last_name", "lastname", "surname", "family_name"):
+		if l != nil {
+			return l.pick(l.LastNames)
+		}
 		return gofakeit.LastName()
+	case contains(fieldLower, "company", "organization"):
+		return gofakeit.Company()
+	case contains(fieldLower, "name") && !contains(fieldLower, "filename", "file_name"):
+		if l != nil {
+			return l.name()
+		}
+		return gofakeit.Name()
 	case contains(fieldLower, "phone", "mobile", "telephone"):
+		if l != nil {
+			return l.format(l.PhoneFormat)
+		}
 		return gofakeit.Phone()
-	case contains(fieldLower, "address"):
-		return gofakeit.Address().Address
+	case contains(fieldLower, "address", "street"):
+		if l != nil {
+			return l.street()
+		}
+		return gofakeit.Street()
 	case contains(fieldLower, "city"):
+		if l != nil {
+			return l.pick(l.Cities)
+		}
 		return gofakeit.City()
-	case contains(fieldLower, "state", "province"):
+	case contains(fieldLower, "state", "province", "region"):
+		if l != nil {
+			return l.pick(l.States)
+		}
 		return gofakeit.State()
 	case contains(fieldLower, "country"):
+		if l != nil {
+			return l.Country
+		}
 		return gofakeit.Country()
-	case contains(fieldLower, "zip", "zipcode", "postal"):
+	case contains(fieldLower, "zip", "zipcode", "postal", "postcode"):
+		if l != nil {
+			return l.format(l.ZipFormat)
+		}
 		return gofakeit.Zip()
+	case contains(fieldLower, "image", "avatar", "photo", "picture"):
+		return fmt.Sprintf("https://picsum.photos/seed/%s/640/480", gofakeit.LetterN(8))
 	case contains(fieldLower, "url", "website", "link"):
 		return gofakeit.URL()
-	case contains(fieldLower, "title"):
-		if column.Type == "TEXT" {
-			return gofakeit.JobTitle()
-		}
-		return gofakeit.BuzzWord() + " " + gofakeit.Noun()
+	case contains(fieldLower, "slug"):
+		return strings.ToLower(strings.ReplaceAll(headline(), " ", "-"))
 	case contains(fieldLower, "job", "position", "occupation"):
 		return gofakeit.JobTitle()
-	case contains(fieldLower, "company", "organization"):
-		return gofakeit.Company()
-	case contains(fieldLower, "content", "description", "body", "bio"):
-		return gofakeit.Paragraph(2, 3, 8, " ")
+	case contains(fieldLower, "title", "headline", "subject"):
+		return headline()
+	case contains(fieldLower, "content", "description", "body", "bio", "summary"):
+		return loremIpsum()
 	case contains(fieldLower, "text", "comment", "note", "message"):
-		return gofakeit.Sentence(12)
+		return gofakeit.Sentence()
 	case contains(fieldLower, "price", "amount", "cost", "fee", "salary"):
-		return gofakeit.Float64Range(10, 10000)
+		return float64(gofakeit.Number(100, 1000000)) / 100
 	case contains(fieldLower, "quantity", "count", "stock"):
 		return gofakeit.Number(1, 1000)
-	case contains(fieldLower, "age"):
-		return gofakeit.Number(18, 99)
 	case contains(fieldLower, "rating", "score"):
-		return gofakeit.Float64Range(1, 5)
+		return gofakeit.Number(1, 5)
 	case contains(fieldLower, "status"):
 		return gofakeit.RandomString([]string{"active", "inactive", "pending", "completed"})
 	case contains(fieldLower, "category", "type"):
 		return gofakeit.Word()
-	case contains(fieldLower, "image", "avatar", "photo", "picture"):
-		return gofakeit.URL()
 	case contains(fieldLower, "color", "colour"):
 		return gofakeit.Color()
 	case contains(fieldLower, "uuid"):
 		return gofakeit.UUID()
-	case contains(fieldLower, "date", "birthday", "dob"):
-		return gofakeit.Date().Format("2006-01-02")
+	case contains(fieldLower, "birthday", "birth_date", "dob"):
+		now := clock()
+		return gofakeit.DateRange(now.AddDate(-80, 0, 0), now.AddDate(-18, 0, 0)).Format("2006-01-02")
+	case contains(fieldLower, "due", "deadline", "expires", "scheduled", "starts"):
+		return futureTime().Format("2006-01-02 15:04:05")
+	case contains(fieldLower, "date"):
+		return pastTime().Format("2006-01-02")
+	case fieldLower == "age" || strings.HasSuffix(fieldLower, "_age"):
+		return gofakeit.Number(18, 99)
 	}
 
 	// Fall back to type-based generation
@@ -95,17 +179,54 @@ func generateByType(sqlType string) interface{} {
 	case strings.Contains(typeUpper, "REAL"), strings.Contains(typeUpper, "FLOAT"), strings.Contains(typeUpper, "DOUBLE"):
 		return gofakeit.Float64Range(0, 1000)
 	case strings.Contains(typeUpper, "TEXT"), strings.Contains(typeUpper, "VARCHAR"), strings.Contains(typeUpper, "CHAR"):
-		return gofakeit.Sentence(10)
+		return gofakeit.Sentence()
 	case strings.Contains(typeUpper, "DATE"), strings.Contains(typeUpper, "TIME"):
-		return gofakeit.Date().Format("2006-01-02 15:04:05")
+		return pastTime().Format("2006-01-02 15:04:05")
 	default:
 		return gofakeit.Word()
 	}
 }
 
+// headline generates a short title-cased phrase, without a full stop.
+func headline() string {
+	words := strings.Fields(gofakeit.HipsterSentence())
+	if len(words) > 6 {
+		words = words[:6]
+	}
+	title := strings.TrimRight(strings.Join(words, " "), ".!?")
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// loremIpsum generates one to three paragraphs of lorem ipsum.
+func loremIpsum() string {
+	return gofakeit.LoremIpsumParagraph(gofakeit.Number(1, 3), gofakeit.Number(2, 5), gofakeit.Number(8, 14), "\n\n")
+}
+
+// pastTime is a time in the last two years: data that already happened.
+func pastTime() time.Time {
+	now := clock()
+	return gofakeit.DateRange(now.AddDate(-2, 0, 0), now)
+}
+
+// futureTime is a time in the coming year, for due dates and the like.
+func futureTime() time.Time {
+	now := clock()
+	return gofakeit.DateRange(now, now.AddDate(1, 0, 0))
+}
+
+// generateJSON generates a small JSON object
+func generateJSON() string {
+	doc, _ := json.Marshal(map[string]any{
+		"label":   gofakeit.Word(),
+		"count":   gofakeit.Number(1, 100),
+		"enabled": gofakeit.Bool(),
+	})
+	return string(doc)
+}
+
 // GenerateID generates a test seed ID
 func GenerateID(index int) string {
-	timestamp := time.Now().UnixNano()
+	timestamp := clock().UnixNano()
 	return fmt.Sprintf("test-seed-%d-%d", timestamp, index)
 }
 
@@ -115,7 +236,7 @@ func GenerateCreatedAt() string {
 	hoursAgo := gofakeit.Number(0, 23)
 	minutesAgo := gofakeit.Number(0, 59)
 
-	date := time.Now().
+	date := clock().
 		AddDate(0, 0, -daysAgo).
 		Add(-time.Hour * time.Duration(hoursAgo)).
 		Add(-time.Minute * time.Duration(minutesAgo))
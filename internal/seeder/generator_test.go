@@ -0,0 +1,141 @@
+package seeder
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetSeedReproducesValues(t *testing.T) {
+	columns := []Column{
+		{Name: "title", Type: "TEXT"},
+		{Name: "email", Type: "TEXT"},
+		{Name: "content", Type: "TEXT"},
+		{Name: "priority", Type: "INTEGER"},
+		{Name: "published", Type: "BOOLEAN"},
+	}
+	generate := func(seed int64) []any {
+		if err := SetSeed(seed); err != nil {
+			t.Fatal(err)
+		}
+		var values []any
+		for i := 0; i < 3; i++ {
+			for _, c := range columns {
+				values = append(values, GenerateValue(c))
+			}
+		}
+		return values
+	}
+
+	first := generate(42)
+	if again := generate(42); !reflect.DeepEqual(first, again) {
+		t.Errorf("same seed gave different values:\n%v\n%v", first, again)
+	}
+	if other := generate(43); reflect.DeepEqual(first, other) {
+		t.Error("different seeds gave the same values")
+	}
+}
+
+func TestReproducibleDataset(t *testing.T) {
+	t.Cleanup(func() { clock = time.Now })
+	dump := func(seed int64) string {
+		if err := Reproducible(seed); err != nil {
+			t.Fatal(err)
+		}
+		s, tables := relationsSeeder(t)
+		if err := s.Seed(*FindTable(tables, "comments"), 20, SeedOptions{Tables: tables}); err != nil {
+			t.Fatal(err)
+		}
+		var out strings.Builder
+		for _, query := range []string{
+			"SELECT id || name FROM users",
+			"SELECT id || title || user_id FROM posts",
+			"SELECT id || body || post_id || COALESCE(parent_id, '') FROM comments",
+		} {
+			rows, err := s.db.Query(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for rows.Next() {
+				var row string
+				if err := rows.Scan(&row); err != nil {
+					t.Fatal(err)
+				}
+				out.WriteString(row + "\n")
+			}
+			rows.Close()
+		}
+		return out.String()
+	}
+
+	first := dump(42)
+	if again := dump(42); first != again {
+		t.Errorf("same seed value gave different datasets:\n%s\n%s", first, again)
+	}
+	if other := dump(43); first == other {
+		t.Error("different seed values gave the same dataset")
+	}
+	if at := GenerateCreatedAt(); at > SeedEpoch.Format("2006-01-02 15:04:05") {
+		t.Errorf("created_at %s is after the seed epoch", at)
+	}
+}
+
+func TestGenerateValueFollowsFieldSemantics(t *testing.T) {
+	if err := SetSeed(7); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i := 0; i < 20; i++ {
+		if email, _ := GenerateValue(Column{Name: "email", Type: "TEXT"}).(string); !strings.Contains(email, "@") {
+			t.Errorf("email = %q", email)
+		}
+		if first, _ := GenerateValue(Column{Name: "first_name", Type: "TEXT"}).(string); strings.Contains(first, " ") {
+			t.Errorf("first_name = %q, want a single name", first)
+		}
+		published, _ := GenerateValue(Column{Name: "published_at", Type: "DATETIME"}).(string)
+		if at, err := time.ParseInLocation("2006-01-02 15:04:05", published, time.Local); err != nil || at.After(now) {
+			t.Errorf("published_at = %q (%v), want a past time", published, err)
+		}
+		due, _ := GenerateValue(Column{Name: "due_date", Type: "DATETIME"}).(string)
+		if at, err := time.ParseInLocation("2006-01-02 15:04:05", due, time.Local); err != nil || at.Before(now.Add(-time.Minute)) {
+			t.Errorf("due_date = %q (%v), want a future time", due, err)
+		}
+		if status := GenerateValue(Column{Name: "status", Type: "TEXT", Options: []string{"draft", "live"}}); status != "draft" && status != "live" {
+			t.Errorf("select status = %v, want one of its options", status)
+		}
+	}
+	if body, _ := GenerateValue(Column{Name: "notes", Type: "TEXT", Textarea: true}).(string); !strings.Contains(strings.ToLower(body), "lorem") && len(strings.Fields(body)) < 8 {
+		t.Errorf("textarea = %q, want lorem ipsum", body)
+	}
+	if body, _ := GenerateValue(Column{Name: "body", Type: "TEXT", Textarea: true, RichText: true}).(string); !strings.HasPrefix(body, "<p>") || !strings.HasSuffix(body, "</p>") || strings.Contains(body, "\n") {
+		t.Errorf("richtext = %q, want HTML paragraphs", body)
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { _ = SetLocale("") })
+	for _, name := range []string{"de_DE", "de-DE", "de", "DE_de"} {
+		if err := SetLocale(name); err != nil {
+			t.Errorf("SetLocale(%q): %v", name, err)
+		}
+	}
+	if err := SetLocale("xx_YY"); err == nil {
+		t.Error("SetLocale accepted an unknown locale")
+	}
+
+	if err := SetLocale("de_DE"); err != nil {
+		t.Fatal(err)
+	}
+	city, _ := GenerateValue(Column{Name: "city", Type: "TEXT"}).(string)
+	found := false
+	for _, c := range locales["de_DE"].Cities {
+		found = found || c == city
+	}
+	if !found {
+		t.Errorf("de_DE city = %q, want a German city", city)
+	}
+	if zip, _ := GenerateValue(Column{Name: "zip", Type: "TEXT"}).(string); len(zip) != 5 {
+		t.Errorf("de_DE zip = %q, want 5 digits", zip)
+	}
+}
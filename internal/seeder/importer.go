@@ -0,0 +1,454 @@
+package seeder
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBatchSize is how many rows Import inserts per transaction.
+const DefaultBatchSize = 500
+
+// maxReportedErrors caps the row errors kept in an ImportResult.
+const maxReportedErrors = 50
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// Mapping maps file columns (CSV headers, JSON keys) to table columns.
+	// Columns not mapped match a table column by name, ignoring case,
+	// spaces and dashes. A mapping to "-" skips the file column.
+	Mapping map[string]string
+
+	BatchSize int       // rows per transaction; DefaultBatchSize when 0
+	DryRun    bool      // validate every row without inserting
+	Progress  io.Writer // receives a line per committed batch; may be nil
+}
+
+// RowError is an invalid row, which Import skips.
+type RowError struct {
+	Row int // CSV line or JSON record number
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// ImportResult reports what Import did.
+type ImportResult struct {
+	Imported int
+	Failed   int
+	Errors   []RowError // the first maxReportedErrors failures
+}
+
+// Import inserts the rows of a CSV, TSV, JSON (an array of objects) or JSON
+// Lines file into table, validating each value against its column. Invalid
+// rows are skipped and reported; valid ones are inserted in batched
+// transactions.
+func (s *Seeder) Import(table TableSchema, path string, opts ImportOptions) (*ImportResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	reader, err := newRecordReader(f, path)
+	if err != nil {
+		return nil, err
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	for from, to := range opts.Mapping {
+		if to != "-" && table.Column(to) == nil {
+			return nil, fmt.Errorf("cannot map %s to %s: no such column in %s", from, to, table.Name)
+		}
+	}
+
+	im := &importer{table: table, opts: opts, columns: map[string]string{}, idBase: time.Now().UnixNano()}
+	if header := reader.Header(); header != nil {
+		if err := im.checkHeader(header); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ImportResult{}
+	var batch [][]any
+	var batchColumns []string
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if !opts.DryRun {
+			if err := s.insertBatch(table, batchColumns, batch, result); err != nil {
+				return err
+			}
+		} else {
+			result.Imported += len(batch)
+		}
+		if opts.Progress != nil {
+			fmt.Fprintf(opts.Progress, "  Progress: %d rows\n", result.Imported+result.Failed)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		row, record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if rowErr, ok := err.(RowError); ok {
+			result.fail(rowErr.Row, rowErr.Err)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		columns, values, err := im.convert(record)
+		if err != nil {
+			result.fail(row, err)
+			continue
+		}
+		// Rows go into a batch with the same columns; JSON records may
+		// differ in which keys they have
+		if len(batch) > 0 && strings.Join(columns, ",") != strings.Join(batchColumns, ",") {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+		batchColumns = columns
+		batch = append(batch, append(values, row))
+		if len(batch) >= opts.BatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, flush()
+}
+
+func (r *ImportResult) fail(row int, err error) {
+	r.Failed++
+	if len(r.Errors) < maxReportedErrors {
+		r.Errors = append(r.Errors, RowError{Row: row, Err: err})
+	}
+}
+
+// insertBatch inserts rows in one transaction. Each row carries its row
+// number as a last, extra value. A row the database rejects (say, a
+// duplicate id) is reported without failing the rest of the batch.
+func (s *Seeder) insertBatch(table TableSchema, columns []string, rows [][]any, result *ImportResult) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, strings.Join(columns, ", "), placeholders))
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	imported := 0
+	for _, values := range rows {
+		row := values[len(values)-1].(int)
+		if _, err := stmt.Exec(values[:len(values)-1]...); err != nil {
+			result.fail(row, err)
+			continue
+		}
+		imported++
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	result.Imported += imported
+	return nil
+}
+
+type importer struct {
+	table   TableSchema
+	opts    ImportOptions
+	columns map[string]string // file column -> table column, "" when unknown
+	idBase  int64             // generated ids count up from here
+	rows    int64
+}
+
+// checkHeader fails when a CSV header matches no column, or a required
+// column has no header, before any row is read.
+func (im *importer) checkHeader(header []string) error {
+	var unknown []string
+	mapped := map[string]bool{}
+	for _, h := range header {
+		column, ok := im.column(h)
+		if !ok {
+			unknown = append(unknown, h)
+			continue
+		}
+		if column != "" {
+			if mapped[column] {
+				return fmt.Errorf("more than one column maps to %s.%s", im.table.Name, column)
+			}
+			mapped[column] = true
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("columns not in table %s: %s (map them with --map 'Header=column', or skip them with 'Header=-')", im.table.Name, strings.Join(unknown, ", "))
+	}
+	var missing []string
+	for _, col := range im.table.Columns {
+		if !mapped[col.Name] && !col.Nullable && !generatedColumn(col.Name) {
+			missing = append(missing, col.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no column in the file for required field(s): %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// column returns the table column a file column maps to: "" to skip it,
+// false when there is none.
+func (im *importer) column(name string) (string, bool) {
+	if column, ok := im.columns[name]; ok {
+		return column, column != "" || im.opts.Mapping[name] == "-"
+	}
+	column := ""
+	if target, ok := im.opts.Mapping[name]; ok {
+		if target != "-" {
+			column = target
+		}
+	} else {
+		normalized := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+		for _, col := range im.table.Columns {
+			if strings.ToLower(col.Name) == normalized {
+				column = col.Name
+				break
+			}
+		}
+	}
+	if column != "" && im.table.Column(column) == nil {
+		column = ""
+	}
+	im.columns[name] = column
+	return column, column != "" || im.opts.Mapping[name] == "-"
+}
+
+// convert validates a record and returns its columns, sorted as the table
+// declares them, and their values.
+func (im *importer) convert(record map[string]any) ([]string, []any, error) {
+	values := map[string]any{}
+	for key, raw := range record {
+		column, ok := im.column(key)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s is not a column of %s", key, im.table.Name)
+		}
+		if column == "" {
+			continue
+		}
+		value, err := convertValue(*im.table.Column(column), raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", column, err)
+		}
+		values[column] = value
+	}
+
+	var columns []string
+	var args []any
+	for _, col := range im.table.Columns {
+		value, ok := values[col.Name]
+		if !ok || value == nil {
+			switch {
+			case col.Name == "id" && strings.Contains(strings.ToUpper(col.Type), "TEXT"):
+				im.rows++
+				value = fmt.Sprintf("%s-%d", im.table.Name, im.idBase+im.rows)
+			case col.Name == "created_at" || col.Name == "updated_at":
+				value = time.Now().Format("2006-01-02 15:04:05")
+			case !col.Nullable && !col.IsPrimary:
+				return nil, nil, fmt.Errorf("%s: required", col.Name)
+			default:
+				continue
+			}
+		}
+		columns = append(columns, col.Name)
+		args = append(args, value)
+	}
+	return columns, args, nil
+}
+
+func generatedColumn(name string) bool {
+	return name == "id" || name == "created_at" || name == "updated_at"
+}
+
+// convertValue checks a file value against its column's type and returns
+// it as stored. An empty value is nil.
+func convertValue(col Column, raw any) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if s, ok := raw.(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return nil, nil
+		}
+	}
+
+	if col.IsJSON {
+		if s, ok := raw.(string); ok {
+			if !json.Valid([]byte(s)) {
+				return nil, fmt.Errorf("invalid JSON")
+			}
+			return s, nil
+		}
+		doc, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		return string(doc), nil
+	}
+
+	text := fmt.Sprint(raw)
+	if f, ok := raw.(float64); ok {
+		text = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	text = strings.TrimSpace(text)
+
+	if len(col.Options) > 0 {
+		for _, option := range col.Options {
+			if option == text {
+				return text, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not one of %s", text, strings.Join(col.Options, ", "))
+	}
+
+	sqlType := strings.ToUpper(col.Type)
+	switch {
+	case strings.Contains(sqlType, "BOOL"):
+		switch strings.ToLower(text) {
+		case "true", "t", "yes", "y", "1":
+			return true, nil
+		case "false", "f", "no", "n", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid boolean %q", text)
+	case strings.Contains(sqlType, "INT"):
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			if f, ferr := strconv.ParseFloat(text, 64); ferr == nil && f == math.Trunc(f) {
+				return int64(f), nil
+			}
+			return nil, fmt.Errorf("invalid integer %q", text)
+		}
+		return n, nil
+	case strings.Contains(sqlType, "REAL"), strings.Contains(sqlType, "FLOA"), strings.Contains(sqlType, "DOUB"),
+		strings.Contains(sqlType, "NUMERIC"), strings.Contains(sqlType, "DECIMAL"):
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", text)
+		}
+		return f, nil
+	case strings.Contains(sqlType, "DATE"), strings.Contains(sqlType, "TIME"):
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+			if t, err := time.Parse(layout, text); err == nil {
+				return t.Format("2006-01-02 15:04:05"), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q (expected e.g. 2024-01-31 or 2024-01-31 15:04:05)", text)
+	}
+	return text, nil
+}
+
+// recordReader reads the records of an import file.
+type recordReader interface {
+	// Header returns the CSV header, or nil for JSON
+	Header() []string
+	// Next returns the next record and its row number, or io.EOF
+	Next() (int, map[string]any, error)
+}
+
+func newRecordReader(r io.Reader, path string) (recordReader, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".tsv":
+		cr := csv.NewReader(bufio.NewReader(r))
+		if strings.EqualFold(filepath.Ext(path), ".tsv") {
+			cr.Comma = '\t'
+		}
+		cr.FieldsPerRecord = -1
+		header, err := cr.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the header of %s: %w", path, err)
+		}
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+		return &csvReader{r: cr, header: header}, nil
+	case ".json":
+		dec := json.NewDecoder(bufio.NewReader(r))
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return nil, fmt.Errorf("%s must hold a JSON array of objects", path)
+		}
+		return &jsonReader{dec: dec, array: true}, nil
+	case ".jsonl", ".ndjson":
+		return &jsonReader{dec: json.NewDecoder(bufio.NewReader(r))}, nil
+	}
+	return nil, fmt.Errorf("unsupported file type %q (expected .csv, .tsv, .json, .jsonl or .ndjson)", filepath.Ext(path))
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+func (c *csvReader) Header() []string { return c.header }
+
+func (c *csvReader) Next() (int, map[string]any, error) {
+	fields, err := c.r.Read()
+	if parseErr, ok := err.(*csv.ParseError); ok {
+		return 0, nil, RowError{Row: parseErr.Line, Err: parseErr.Err}
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	line, _ := c.r.FieldPos(0)
+	if len(fields) != len(c.header) {
+		return 0, nil, RowError{Row: line, Err: fmt.Errorf("%d fields, the header has %d", len(fields), len(c.header))}
+	}
+	record := make(map[string]any, len(fields))
+	for i, field := range fields {
+		record[c.header[i]] = field
+	}
+	return line, record, nil
+}
+
+type jsonReader struct {
+	dec   *json.Decoder
+	array bool
+	n     int
+}
+
+func (j *jsonReader) Header() []string { return nil }
+
+func (j *jsonReader) Next() (int, map[string]any, error) {
+	if j.array && !j.dec.More() {
+		return 0, nil, io.EOF
+	}
+	j.n++
+	var record map[string]any
+	if err := j.dec.Decode(&record); err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		return j.n, nil, fmt.Errorf("record %d: %w", j.n, err)
+	}
+	return j.n, record, nil
+}
@@ -0,0 +1,137 @@
+package seeder
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const importSchema = `
+CREATE TABLE posts (
+  id TEXT PRIMARY KEY,
+  title TEXT NOT NULL,
+  views INTEGER NOT NULL,
+  published BOOLEAN NOT NULL,
+  published_at DATETIME,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL
+);
+`
+
+func newImportSeeder(t *testing.T) (*Seeder, TableSchema) {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(importSchema); err != nil {
+		t.Fatal(err)
+	}
+	tables, err := parseSchemaContent(importSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Seeder{db: db}, tables[0]
+}
+
+func writeImportFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportCSV(t *testing.T) {
+	s, table := newImportSeeder(t)
+	path := writeImportFile(t, "posts.csv", "\ufeffPost Title,Views,published,Published At,Notes\n"+
+		"Hello,10,yes,2024-01-31,ignored\n"+
+		"Bad views,ten,no,,x\n"+
+		"\"Quoted, title\",3,false,2024-02-01 10:00:00,x\n"+
+		"Bad date,1,true,31/01/2024,x\n"+
+		"Too,few\n"+
+		"Last,7,1,,x\n")
+
+	opts := ImportOptions{Mapping: map[string]string{"Post Title": "title", "Notes": "-"}, BatchSize: 2}
+	result, err := s.Import(table, path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 3 || result.Failed != 3 {
+		t.Fatalf("imported %d, failed %d; want 3 and 3 (%v)", result.Imported, result.Failed, result.Errors)
+	}
+	wantLines := []int{3, 5, 6}
+	for i, rowErr := range result.Errors {
+		if rowErr.Row != wantLines[i] {
+			t.Errorf("error %d is on line %d, want %d: %v", i, rowErr.Row, wantLines[i], rowErr)
+		}
+	}
+	if !strings.Contains(result.Errors[0].Error(), "views: invalid integer") {
+		t.Errorf("error = %v, want an invalid views integer", result.Errors[0])
+	}
+
+	var title, publishedAt string
+	var views int
+	if err := s.db.QueryRow("SELECT title, views, published_at || '' FROM posts WHERE title LIKE 'Hello%'").Scan(&title, &views, &publishedAt); err != nil {
+		t.Fatal(err)
+	}
+	if views != 10 || publishedAt != "2024-01-31 00:00:00" {
+		t.Errorf("Hello row = %d views, published %q", views, publishedAt)
+	}
+
+	// Dry runs validate without inserting
+	result, err = s.Import(table, path, ImportOptions{Mapping: opts.Mapping, DryRun: true})
+	if err != nil || result.Imported != 3 {
+		t.Fatalf("dry run: %+v, %v", result, err)
+	}
+	var n int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM posts").Scan(&n); err != nil || n != 3 {
+		t.Errorf("%d posts after the dry run, want 3", n)
+	}
+}
+
+func TestImportHeaderErrors(t *testing.T) {
+	s, table := newImportSeeder(t)
+	unknown := writeImportFile(t, "posts.csv", "title,views,published,rating\n")
+	if _, err := s.Import(table, unknown, ImportOptions{}); err == nil || !strings.Contains(err.Error(), "rating") {
+		t.Errorf("unknown header: err = %v", err)
+	}
+	missing := writeImportFile(t, "posts.csv", "title,published\n")
+	if _, err := s.Import(table, missing, ImportOptions{}); err == nil || !strings.Contains(err.Error(), "views") {
+		t.Errorf("missing required column: err = %v", err)
+	}
+	if _, err := s.Import(table, missing, ImportOptions{Mapping: map[string]string{"title": "headline"}}); err == nil {
+		t.Error("mapping to a missing column was accepted")
+	}
+	if _, err := s.Import(table, writeImportFile(t, "posts.xml", ""), ImportOptions{}); err == nil {
+		t.Error("an .xml file was accepted")
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	s, table := newImportSeeder(t)
+	array := writeImportFile(t, "posts.json", `[
+		{"id": "p1", "title": "One", "views": 1, "published": true},
+		{"title": "Two", "views": 2.5, "published": false},
+		{"title": "Three", "views": 3, "published": false, "extra": 1},
+		{"id": "p1", "title": "Dup", "views": 4, "published": true}
+	]`)
+	result, err := s.Import(table, array, ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2.5 views, an unknown key and a duplicate id are row errors
+	if result.Imported != 1 || result.Failed != 3 {
+		t.Errorf("imported %d, failed %d; want 1 and 3 (%v)", result.Imported, result.Failed, result.Errors)
+	}
+
+	lines := writeImportFile(t, "posts.jsonl", "{\"title\": \"Four\", \"views\": 4, \"published\": true}\n{\"title\": \"Five\", \"views\": 5, \"published\": \"no\"}\n")
+	result, err = s.Import(table, lines, ImportOptions{})
+	if err != nil || result.Imported != 2 || result.Failed != 0 {
+		t.Errorf("jsonl: %+v, %v", result, err)
+	}
+}
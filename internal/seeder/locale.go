@@ -0,0 +1,157 @@
+package seeder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// DefaultLocale is the locale gofakeit's own data is for.
+const DefaultLocale = "en_US"
+
+// locale is the data names and addresses are generated from. Formats use
+// gofakeit's # (digit) and ? (letter) placeholders.
+type locale struct {
+	FirstNames   []string
+	LastNames    []string
+	Cities       []string
+	States       []string
+	Streets      []string
+	StreetFormat string // %[1]s is the street, %[2]s the number
+	ZipFormat    string
+	PhoneFormat  string
+	Country      string
+	EmailDomains []string
+	FamilyFirst  bool // full names are written family name first
+}
+
+var locales = map[string]*locale{
+	"en_GB": {
+		FirstNames:   []string{"Oliver", "Amelia", "George", "Isla", "Harry", "Ava", "Jack", "Mia", "Charlie", "Emily", "Thomas", "Sophie"},
+		LastNames:    []string{"Smith", "Jones", "Taylor", "Brown", "Williams", "Wilson", "Davies", "Evans", "Thomas", "Roberts", "Walker", "Wright"},
+		Cities:       []string{"London", "Manchester", "Birmingham", "Leeds", "Glasgow", "Bristol", "Edinburgh", "Liverpool", "Cardiff", "Belfast"},
+		States:       []string{"England", "Scotland", "Wales", "Northern Ireland"},
+		Streets:      []string{"High Street", "Station Road", "Church Lane", "Victoria Road", "Park Avenue", "Mill Lane", "Queens Road", "Kings Road"},
+		StreetFormat: "%[2]s %[1]s",
+		ZipFormat:    "??# #??",
+		PhoneFormat:  "07### ######",
+		Country:      "United Kingdom",
+		EmailDomains: []string{"example.co.uk", "mail.co.uk"},
+	},
+	"de_DE": {
+		FirstNames:   []string{"Lukas", "Mia", "Leon", "Emma", "Finn", "Hannah", "Jonas", "Lena", "Paul", "Lea", "Felix", "Marie"},
+		LastNames:    []string{"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Becker", "Schulz", "Hoffmann", "Koch", "Richter"},
+		Cities:       []string{"Berlin", "Hamburg", "München", "Köln", "Frankfurt am Main", "Stuttgart", "Düsseldorf", "Leipzig", "Dresden", "Bremen"},
+		States:       []string{"Bayern", "Berlin", "Hamburg", "Hessen", "Nordrhein-Westfalen", "Sachsen", "Baden-Württemberg", "Niedersachsen"},
+		Streets:      []string{"Hauptstraße", "Schulstraße", "Gartenstraße", "Bahnhofstraße", "Dorfstraße", "Bergstraße", "Lindenstraße", "Birkenweg"},
+		StreetFormat: "%[1]s %[2]s",
+		ZipFormat:    "#####",
+		PhoneFormat:  "+49 1## #######",
+		Country:      "Deutschland",
+		EmailDomains: []string{"example.de", "mail.de"},
+	},
+	"fr_FR": {
+		FirstNames:   []string{"Gabriel", "Louise", "Raphaël", "Jade", "Léo", "Ambre", "Louis", "Alice", "Jules", "Emma", "Arthur", "Chloé"},
+		LastNames:    []string{"Martin", "Bernard", "Dubois", "Thomas", "Robert", "Richard", "Petit", "Durand", "Leroy", "Moreau", "Simon", "Laurent"},
+		Cities:       []string{"Paris", "Marseille", "Lyon", "Toulouse", "Nice", "Nantes", "Strasbourg", "Montpellier", "Bordeaux", "Lille"},
+		States:       []string{"Île-de-France", "Provence-Alpes-Côte d'Azur", "Auvergne-Rhône-Alpes", "Occitanie", "Bretagne", "Normandie"},
+		Streets:      []string{"rue de la Paix", "rue Victor Hugo", "avenue Jean Jaurès", "rue de la République", "boulevard Voltaire", "place de la Mairie"},
+		StreetFormat: "%[2]s %[1]s",
+		ZipFormat:    "#####",
+		PhoneFormat:  "+33 6 ## ## ## ##",
+		Country:      "France",
+		EmailDomains: []string{"example.fr", "mail.fr"},
+	},
+	"es_ES": {
+		FirstNames:   []string{"Hugo", "Lucía", "Martín", "Sofía", "Pablo", "Martina", "Daniel", "María", "Alejandro", "Julia", "Álvaro", "Paula"},
+		LastNames:    []string{"García", "Rodríguez", "González", "Fernández", "López", "Martínez", "Sánchez", "Pérez", "Gómez", "Martín", "Jiménez", "Ruiz"},
+		Cities:       []string{"Madrid", "Barcelona", "Valencia", "Sevilla", "Zaragoza", "Málaga", "Bilbao", "Murcia", "Palma", "Alicante"},
+		States:       []string{"Madrid", "Cataluña", "Andalucía", "Comunidad Valenciana", "Galicia", "País Vasco", "Castilla y León"},
+		Streets:      []string{"Calle Mayor", "Calle Real", "Avenida de la Constitución", "Calle del Sol", "Plaza de España", "Calle Nueva"},
+		StreetFormat: "%[1]s, %[2]s",
+		ZipFormat:    "#####",
+		PhoneFormat:  "+34 6## ### ###",
+		Country:      "España",
+		EmailDomains: []string{"example.es", "correo.es"},
+	},
+	"ja_JP": {
+		FirstNames:   []string{"蓮", "陽葵", "湊", "凛", "大翔", "結菜", "悠真", "葵", "陽翔", "芽依", "樹", "紬"},
+		LastNames:    []string{"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "山本", "中村", "小林", "加藤", "吉田", "山田"},
+		Cities:       []string{"東京", "横浜", "大阪", "名古屋", "札幌", "福岡", "神戸", "京都", "仙台", "広島"},
+		States:       []string{"東京都", "神奈川県", "大阪府", "愛知県", "北海道", "福岡県", "兵庫県", "京都府"},
+		Streets:      []string{"中央", "本町", "栄町", "緑町", "旭町", "幸町", "桜木町", "錦町"},
+		StreetFormat: "%[1]s%[2]s",
+		ZipFormat:    "###-####",
+		PhoneFormat:  "090-####-####",
+		Country:      "日本",
+		EmailDomains: []string{"example.jp", "mail.jp"},
+		FamilyFirst:  true,
+	},
+}
+
+// activeLocale is the locale set by SetLocale; nil uses gofakeit's en_US
+// data.
+var activeLocale *locale
+
+// Locales returns the supported locale names.
+func Locales() []string {
+	names := []string{DefaultLocale}
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetLocale makes names, addresses and phone numbers follow a locale, such
+// as de_DE (de-DE and de also work). An empty name restores en_US.
+func SetLocale(name string) error {
+	if name == "" {
+		activeLocale = nil
+		return nil
+	}
+	normalized := strings.ReplaceAll(name, "-", "_")
+	if lang, region, ok := strings.Cut(normalized, "_"); ok {
+		normalized = strings.ToLower(lang) + "_" + strings.ToUpper(region)
+	} else {
+		normalized = strings.ToLower(normalized)
+	}
+	if normalized == DefaultLocale || normalized == "en" {
+		activeLocale = nil
+		return nil
+	}
+	for key, l := range locales {
+		if key == normalized || strings.HasPrefix(key, normalized+"_") {
+			activeLocale = l
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported locale %q (available: %s)", name, strings.Join(Locales(), ", "))
+}
+
+func (l *locale) pick(values []string) string {
+	return values[gofakeit.IntN(len(values))]
+}
+
+func (l *locale) format(pattern string) string {
+	value, _ := gofakeit.Generate(pattern)
+	return strings.ToUpper(value)
+}
+
+func (l *locale) name() string {
+	if l.FamilyFirst {
+		return l.pick(l.LastNames) + " " + l.pick(l.FirstNames)
+	}
+	return l.pick(l.FirstNames) + " " + l.pick(l.LastNames)
+}
+
+func (l *locale) street() string {
+	return fmt.Sprintf(l.StreetFormat, l.pick(l.Streets), fmt.Sprint(gofakeit.Number(1, 200)))
+}
+
+func (l *locale) email() string {
+	user := strings.ToLower(gofakeit.FirstName() + "." + gofakeit.LastName())
+	return fmt.Sprintf("%s%d@%s", user, gofakeit.Number(1, 99), l.pick(l.EmailDomains))
+}
@@ -0,0 +1,495 @@
+package seeder
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SeedsDir holds seed profiles, relative to the project root:
+// database/seeds/<profile>.yaml, .yml or .go.
+const SeedsDir = "database/seeds"
+
+// Profile is a YAML seed profile: named scenarios, applied in order.
+type Profile struct {
+	Scenarios []Scenario `yaml:"scenarios"`
+}
+
+// Scenario is a set of tables to seed, in order, so later tables can
+// reference records from earlier ones.
+type Scenario struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	Tables      []TableSeed `yaml:"tables"`
+}
+
+// TableSeed lists explicit records for a table, upserted by Key, and Count,
+// the number of rows the table should hold at least, topped up with random
+// rows.
+//
+// A record's _ref names it for later records: the value "@alice" is the id
+// of the record with _ref alice, and "@alice.email" its email. "@@" escapes
+// a literal "@".
+type TableSeed struct {
+	Table   string           `yaml:"table"`
+	Key     KeyColumns       `yaml:"key"`
+	Records []map[string]any `yaml:"records"`
+	Count   int              `yaml:"count"`
+}
+
+// KeyColumns is the natural key records are upserted by: one column, or a
+// list of them. It defaults to id.
+type KeyColumns []string
+
+// UnmarshalYAML accepts a single column name as well as a list.
+func (k *KeyColumns) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = KeyColumns{node.Value}
+		return nil
+	}
+	var columns []string
+	if err := node.Decode(&columns); err != nil {
+		return fmt.Errorf("key must be a column name or a list of them")
+	}
+	*k = columns
+	return nil
+}
+
+// refKey is the record field naming it for references.
+const refKey = "_ref"
+
+// FindProfile returns the file of the named seed profile in the project's
+// seeds directory.
+func FindProfile(name string) (string, error) {
+	schemaPath, err := FindSchemaFile()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(ProjectRoot(schemaPath), SeedsDir)
+	for _, ext := range []string{".yaml", ".yml", ".go"} {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	msg := fmt.Sprintf("seed profile %q not found (looked for %s/%s.yaml, .yml and .go)", name, SeedsDir, name)
+	if names := ListProfiles(dir); len(names) > 0 {
+		msg += "; available: " + strings.Join(names, ", ")
+	}
+	return "", fmt.Errorf("%s", msg)
+}
+
+// ListProfiles returns the names of the seed profiles in dir.
+func ListProfiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var names []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if entry.IsDir() || seen[name] || (ext != ".yaml" && ext != ".yml" && ext != ".go") {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadProfile reads a YAML seed profile.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed profile: %w", err)
+	}
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid seed profile %s: %w", path, err)
+	}
+	if len(p.Scenarios) == 0 {
+		return nil, fmt.Errorf("seed profile %s has no scenarios", path)
+	}
+	for i, sc := range p.Scenarios {
+		if sc.Name == "" {
+			return nil, fmt.Errorf("seed profile %s: scenario %d has no name", path, i+1)
+		}
+		for _, ts := range sc.Tables {
+			if ts.Table == "" {
+				return nil, fmt.Errorf("scenario %s: a table entry has no table name", sc.Name)
+			}
+			if ts.Count < 0 {
+				return nil, fmt.Errorf("scenario %s: %s count must not be negative", sc.Name, ts.Table)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// Select returns the named scenarios, in profile order, or all of them when
+// names is empty.
+func (p *Profile) Select(names []string) ([]Scenario, error) {
+	if len(names) == 0 {
+		return p.Scenarios, nil
+	}
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var selected []Scenario
+	for _, sc := range p.Scenarios {
+		if wanted[sc.Name] {
+			selected = append(selected, sc)
+			delete(wanted, sc.Name)
+		}
+	}
+	if len(wanted) > 0 {
+		var missing []string
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("scenario not found in profile: %s", strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
+
+// ProfileResult counts what applying a profile did to one table.
+type ProfileResult struct {
+	Scenario  string
+	Table     string
+	Inserted  int
+	Updated   int
+	Generated int
+}
+
+// ApplyProfile seeds the scenarios in one transaction: running it again
+// updates the same records instead of duplicating them.
+func (s *Seeder) ApplyProfile(scenarios []Scenario, tables []TableSchema) ([]ProfileResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	a := &profileApplier{tx: tx, refs: map[string]map[string]any{}, parents: newParentFiller(tx, tables, ParentsReuse, 0)}
+	var results []ProfileResult
+	for _, sc := range scenarios {
+		for _, ts := range sc.Tables {
+			table := FindTable(tables, ts.Table)
+			if table == nil {
+				return nil, fmt.Errorf("scenario %s: table %q not found in schema", sc.Name, ts.Table)
+			}
+			result := ProfileResult{Scenario: sc.Name, Table: table.Name}
+			if err := a.applyTable(*table, ts, &result); err != nil {
+				return nil, fmt.Errorf("scenario %s: %s: %w", sc.Name, table.Name, err)
+			}
+			results = append(results, result)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	a.parents.report(s.output())
+	return results, nil
+}
+
+type profileApplier struct {
+	tx      *sql.Tx
+	refs    map[string]map[string]any // _ref -> the record's row
+	rows    int                       // rows inserted, numbering generated ids
+	parents *parentFiller
+}
+
+// nextID returns an id for an inserted row, prefixed like the ids lvt seed
+// generates so --cleanup removes it.
+func (a *profileApplier) nextID() string {
+	a.rows++
+	return GenerateID(a.rows)
+}
+
+func (a *profileApplier) applyTable(table TableSchema, ts TableSeed, result *ProfileResult) error {
+	key := ts.Key
+	if len(key) == 0 {
+		key = KeyColumns{"id"}
+	}
+	for _, column := range key {
+		if table.Column(column) == nil {
+			return fmt.Errorf("key column %q not found", column)
+		}
+	}
+
+	for i, record := range ts.Records {
+		values, ref, err := a.resolve(table, record)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+		id, inserted, err := a.upsert(table, key, values)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+		if inserted {
+			result.Inserted++
+		} else {
+			result.Updated++
+		}
+		if ref != "" {
+			row, err := a.row(table, id)
+			if err != nil {
+				return err
+			}
+			a.refs[ref] = row
+		}
+	}
+
+	var existing int
+	if err := a.tx.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table.Name)).Scan(&existing); err != nil {
+		return fmt.Errorf("failed to count rows: %w", err)
+	}
+	for i := existing; i < ts.Count; i++ {
+		if err := a.insertRandom(table); err != nil {
+			return err
+		}
+		result.Generated++
+	}
+	return nil
+}
+
+// resolve turns a record into column values, replacing references.
+func (a *profileApplier) resolve(table TableSchema, record map[string]any) (map[string]any, string, error) {
+	values := map[string]any{}
+	var ref string
+	for column, value := range record {
+		if column == refKey {
+			name, ok := value.(string)
+			if !ok || name == "" {
+				return nil, "", fmt.Errorf("%s must be a name", refKey)
+			}
+			if _, taken := a.refs[name]; taken {
+				return nil, "", fmt.Errorf("%s %q is already used by another record", refKey, name)
+			}
+			ref = name
+			continue
+		}
+		if table.Column(column) == nil {
+			return nil, "", fmt.Errorf("column %q not found", column)
+		}
+		v, err := a.value(value)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", column, err)
+		}
+		values[column] = v
+	}
+	return values, ref, nil
+}
+
+// value converts a YAML value to one SQLite stores the way the app does.
+func (a *profileApplier) value(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "@@") {
+			return v[1:], nil
+		}
+		if !strings.HasPrefix(v, "@") {
+			return v, nil
+		}
+		name, column, _ := strings.Cut(v[1:], ".")
+		if column == "" {
+			column = "id"
+		}
+		row, ok := a.refs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown reference %s (refer to records of earlier tables by their %s)", v, refKey)
+		}
+		referenced, ok := row[column]
+		if !ok {
+			return nil, fmt.Errorf("reference %s: no column %q", v, column)
+		}
+		return referenced, nil
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05"), nil
+	case map[string]any, []any:
+		doc, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(doc), nil
+	}
+	return value, nil
+}
+
+// upsert updates the row matching the record's key, or inserts one, and
+// returns the row's id.
+func (a *profileApplier) upsert(table TableSchema, key KeyColumns, values map[string]any) (any, bool, error) {
+	var where []string
+	var args []any
+	for _, column := range key {
+		v, ok := values[column]
+		if !ok {
+			return nil, false, fmt.Errorf("no value for key column %q", column)
+		}
+		where = append(where, column+" = ?")
+		args = append(args, v)
+	}
+
+	var id any
+	err := a.tx.QueryRow(fmt.Sprintf("SELECT %s FROM %s WHERE %s", idColumn(table), table.Name, strings.Join(where, " AND ")), args...).Scan(&id)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, false, fmt.Errorf("failed to look up record: %w", err)
+	}
+
+	if err == nil {
+		var set []string
+		var setArgs []any
+		for _, column := range sortedColumns(values) {
+			if column == "id" {
+				continue
+			}
+			set = append(set, column+" = ?")
+			setArgs = append(setArgs, values[column])
+		}
+		if table.Column("updated_at") != nil && values["updated_at"] == nil {
+			set = append(set, "updated_at = ?")
+			setArgs = append(setArgs, clock().Format("2006-01-02 15:04:05"))
+		}
+		if len(set) > 0 {
+			setArgs = append(setArgs, id)
+			if _, err := a.tx.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE %s = ?", table.Name, strings.Join(set, ", "), idColumn(table)), setArgs...); err != nil {
+				return nil, false, fmt.Errorf("failed to update record: %w", err)
+			}
+		}
+		return id, false, nil
+	}
+
+	// Columns the record leaves out get the values lvt seed would generate,
+	// except nullable ones, which stay NULL
+	row := map[string]any{}
+	for _, column := range table.Columns {
+		if v, ok := values[column.Name]; ok {
+			row[column.Name] = v
+			continue
+		}
+		switch {
+		case column.Name == "id":
+			row[column.Name] = a.nextID()
+		case column.Name == "created_at" || column.Name == "updated_at":
+			row[column.Name] = clock().Format("2006-01-02 15:04:05")
+		case !column.Nullable:
+			v, err := a.generate(table, column)
+			if err != nil {
+				return nil, false, err
+			}
+			row[column.Name] = v
+		}
+	}
+	if err := a.insert(table, row); err != nil {
+		return nil, false, err
+	}
+	return row[idColumn(table)], true, nil
+}
+
+// insertRandom inserts a row of generated values, pointing its foreign keys
+// at existing rows.
+func (a *profileApplier) insertRandom(table TableSchema) error {
+	row := map[string]any{}
+	for _, column := range table.Columns {
+		switch strings.ToLower(column.Name) {
+		case "id":
+			row[column.Name] = a.nextID()
+		case "created_at", "updated_at":
+			row[column.Name] = GenerateCreatedAt()
+		default:
+			v, err := a.generate(table, column)
+			if err != nil {
+				return err
+			}
+			row[column.Name] = v
+		}
+	}
+	return a.insert(table, row)
+}
+
+// generate returns a random value for column: a parent row's id for
+// foreign keys, creating parents when the referenced table is empty, else
+// what lvt seed generates.
+func (a *profileApplier) generate(table TableSchema, column Column) (any, error) {
+	if fk := foreignKey(table, column.Name); fk != nil {
+		return a.parents.value(table, column, *fk)
+	}
+	return GenerateValue(column), nil
+}
+
+func (a *profileApplier) insert(table TableSchema, row map[string]any) error {
+	columns := sortedColumns(row)
+	placeholders := make([]string, len(columns))
+	args := make([]any, len(columns))
+	for i, column := range columns {
+		placeholders[i] = "?"
+		args[i] = row[column]
+	}
+	if _, err := a.tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, strings.Join(columns, ", "), strings.Join(placeholders, ", ")), args...); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+	a.parents.forget(table.Name)
+	return nil
+}
+
+// row reads back a record, so references see every column.
+func (a *profileApplier) row(table TableSchema, id any) (map[string]any, error) {
+	rows, err := a.tx.Query(fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", table.Name, idColumn(table)), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		return nil, fmt.Errorf("record %v not found after seeding it", id)
+	}
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+	row := map[string]any{}
+	for i, column := range columns {
+		row[column] = values[i]
+	}
+	return row, rows.Err()
+}
+
+// idColumn is the column rows are identified by: the primary key, or
+// SQLite's rowid.
+func idColumn(table TableSchema) string {
+	if table.PrimaryKey != "" {
+		return table.PrimaryKey
+	}
+	if table.Column("id") != nil {
+		return "id"
+	}
+	return "rowid"
+}
+
+func sortedColumns(values map[string]any) []string {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
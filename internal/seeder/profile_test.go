@@ -0,0 +1,154 @@
+package seeder
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profileSchema = `
+CREATE TABLE authors (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  email TEXT NOT NULL,
+  created_at DATETIME NOT NULL
+);
+CREATE TABLE posts (
+  id TEXT PRIMARY KEY,
+  title TEXT NOT NULL,
+  content TEXT NOT NULL,
+  author_id TEXT NOT NULL REFERENCES authors(id),
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL
+);
+`
+
+const demoProfile = `
+scenarios:
+  - name: authors
+    tables:
+      - table: authors
+        key: email
+        records:
+          - {_ref: alice, name: Alice, email: alice@example.com}
+          - {_ref: bob, name: Bob, email: bob@example.com}
+  - name: posts
+    tables:
+      - table: posts
+        key: [author_id, title]
+        records:
+          - {title: Hello, author_id: "@alice", content: "By @alice.name"}
+          - {title: "@@handle", author_id: "@bob"}
+        count: 5
+`
+
+func writeProfile(t *testing.T, content string) *Profile {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "demo.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadProfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestApplyProfile(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(profileSchema); err != nil {
+		t.Fatal(err)
+	}
+	tables, err := parseSchemaContent(profileSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Seeder{db: db}
+	p := writeProfile(t, demoProfile)
+
+	results, err := s.ApplyProfile(p.Scenarios, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Inserted != 2 || results[1].Inserted != 2 || results[1].Generated != 3 {
+		t.Errorf("first run results = %+v", results)
+	}
+
+	var content, author string
+	if err := db.QueryRow("SELECT p.content, a.name FROM posts p JOIN authors a ON a.id = p.author_id WHERE p.title = 'Hello'").Scan(&content, &author); err != nil {
+		t.Fatal(err)
+	}
+	if content != "By @alice.name" || author != "Alice" {
+		t.Errorf("Hello post = %q by %q; want a literal content by Alice", content, author)
+	}
+	var handles int
+	if err := db.QueryRow("SELECT COUNT(*) FROM posts WHERE title = '@handle'").Scan(&handles); err != nil || handles != 1 {
+		t.Errorf("escaped @@ title: %d rows, %v", handles, err)
+	}
+
+	// Running it again updates the records instead of duplicating them
+	if _, err := db.Exec("UPDATE authors SET name = 'Renamed' WHERE email = 'alice@example.com'"); err != nil {
+		t.Fatal(err)
+	}
+	results, err = s.ApplyProfile(p.Scenarios, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Updated != 2 || results[0].Inserted != 0 || results[1].Updated != 2 || results[1].Generated != 0 {
+		t.Errorf("second run results = %+v", results)
+	}
+	var authors, posts int
+	var name string
+	if err := db.QueryRow("SELECT (SELECT COUNT(*) FROM authors), (SELECT COUNT(*) FROM posts), (SELECT name FROM authors WHERE email = 'alice@example.com')").Scan(&authors, &posts, &name); err != nil {
+		t.Fatal(err)
+	}
+	if authors != 2 || posts != 5 || name != "Alice" {
+		t.Errorf("after re-run: %d authors, %d posts, alice named %q; want 2, 5, Alice", authors, posts, name)
+	}
+
+	// Generated posts point at existing authors
+	var orphans int
+	if err := db.QueryRow("SELECT COUNT(*) FROM posts WHERE author_id NOT IN (SELECT id FROM authors)").Scan(&orphans); err != nil || orphans != 0 {
+		t.Errorf("%d generated posts reference missing authors (%v)", orphans, err)
+	}
+
+	// References only reach records of the scenarios being applied
+	posts2, err := p.Select([]string{"posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ApplyProfile(posts2, tables); err == nil || !strings.Contains(err.Error(), "unknown reference @alice") {
+		t.Errorf("applying posts alone: err = %v, want an unknown reference", err)
+	}
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	tests := map[string]string{
+		"no scenarios": "scenarios: []\n",
+		"unnamed":      "scenarios:\n  - tables: []\n",
+		"no table":     "scenarios:\n  - name: a\n    tables:\n      - key: id\n",
+		"bad count":    "scenarios:\n  - name: a\n    tables:\n      - {table: posts, count: -1}\n",
+		"bad key":      "scenarios:\n  - name: a\n    tables:\n      - {table: posts, key: {a: b}}\n",
+	}
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), "p.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadProfile(path); err == nil {
+			t.Errorf("%s: LoadProfile succeeded", name)
+		}
+	}
+
+	p := writeProfile(t, demoProfile)
+	if _, err := p.Select([]string{"posts", "missing"}); err == nil {
+		t.Error("Select accepted an unknown scenario")
+	}
+}
@@ -0,0 +1,215 @@
+package seeder
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// ParentStrategy is how seeding fills foreign keys.
+type ParentStrategy string
+
+const (
+	// ParentsReuse points rows at existing parents, creating a few first
+	// when the parent table is empty.
+	ParentsReuse ParentStrategy = "reuse"
+	// ParentsCreate creates a new parent for every row.
+	ParentsCreate ParentStrategy = "create"
+	// ParentsExisting only uses existing parents, failing when a required
+	// parent table is empty.
+	ParentsExisting ParentStrategy = "existing"
+)
+
+// ParseParentStrategy parses a --parents value.
+func ParseParentStrategy(value string) (ParentStrategy, error) {
+	switch s := ParentStrategy(strings.ToLower(value)); s {
+	case ParentsReuse, ParentsCreate, ParentsExisting:
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid parent strategy %q (expected reuse, create or existing)", value)
+}
+
+// maxReusedParents caps how many parents ParentsReuse creates for an empty
+// parent table: one per ten rows, up to this many.
+const maxReusedParents = 10
+
+// parentFiller fills the foreign key columns of generated rows with ids of
+// parent rows, creating parents as its strategy says. Parents it creates
+// have test-seed ids, so `lvt seed <parent> --cleanup` removes them.
+type parentFiller struct {
+	tx       *sql.Tx
+	tables   []TableSchema
+	strategy ParentStrategy
+	rows     int // rows being seeded, which sizes the parents reuse creates
+
+	ids        map[string][]any // "table.column" -> existing values
+	created    map[string]int   // parents created, by table
+	createdIDs []string         // tables in the order parents were first created
+	inProgress map[string]bool  // tables being created, to stop cycles
+	n          int              // rows created, numbering their ids
+}
+
+func newParentFiller(tx *sql.Tx, tables []TableSchema, strategy ParentStrategy, rows int) *parentFiller {
+	if strategy == "" {
+		strategy = ParentsReuse
+	}
+	return &parentFiller{
+		tx:         tx,
+		tables:     tables,
+		strategy:   strategy,
+		rows:       rows,
+		ids:        map[string][]any{},
+		created:    map[string]int{},
+		inProgress: map[string]bool{},
+	}
+}
+
+// foreignKey returns the foreign key on column, or nil.
+func foreignKey(table TableSchema, column string) *ForeignKey {
+	for i := range table.ForeignKeys {
+		if table.ForeignKeys[i].Column == column {
+			return &table.ForeignKeys[i]
+		}
+	}
+	return nil
+}
+
+// value returns the value of a foreign key column of a row of table.
+func (p *parentFiller) value(table TableSchema, col Column, fk ForeignKey) (any, error) {
+	parent := FindTable(p.tables, fk.RefTable)
+	if parent == nil {
+		// Not in schema.sql (e.g. a table an auth migration created): use
+		// what is there
+		return p.existing(fk, col, table)
+	}
+
+	// A row cannot create its own parent in the same table, or one whose
+	// creation is already under way
+	if p.strategy == ParentsCreate && parent.Name != table.Name && !p.inProgress[parent.Name] {
+		return p.create(*parent, fk.RefColumn)
+	}
+	if p.strategy == ParentsReuse && parent.Name != table.Name && !p.inProgress[parent.Name] {
+		ids, err := p.existingIDs(fk)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			n := min(max(p.rows/10, 1), maxReusedParents)
+			for i := 0; i < n; i++ {
+				if _, err := p.create(*parent, fk.RefColumn); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return p.existing(fk, col, table)
+}
+
+// existing picks one of the rows already in the parent table.
+func (p *parentFiller) existing(fk ForeignKey, col Column, table TableSchema) (any, error) {
+	ids, err := p.existingIDs(fk)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		if col.Nullable {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s.%s references %s, which has no rows; seed %s first or use --parents reuse", table.Name, col.Name, fk.RefTable, fk.RefTable)
+	}
+	return ids[gofakeit.IntN(len(ids))], nil
+}
+
+func (p *parentFiller) existingIDs(fk ForeignKey) ([]any, error) {
+	key := fk.RefTable + "." + fk.RefColumn
+	if ids, ok := p.ids[key]; ok {
+		return ids, nil
+	}
+	rows, err := p.tx.Query(fmt.Sprintf("SELECT %s FROM %s", fk.RefColumn, fk.RefTable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parent rows of %s: %w", fk.RefTable, err)
+	}
+	defer rows.Close()
+	ids := []any{}
+	for rows.Next() {
+		var id any
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	p.ids[key] = ids
+	return ids, rows.Err()
+}
+
+// create inserts a parent row of generated values, filling its own
+// foreign keys the same way, and returns its refColumn value.
+func (p *parentFiller) create(table TableSchema, refColumn string) (any, error) {
+	p.inProgress[table.Name] = true
+	defer delete(p.inProgress, table.Name)
+
+	var columns []string
+	var values []any
+	var ref any
+	for _, col := range table.Columns {
+		var value any
+		if fk := foreignKey(table, col.Name); fk != nil {
+			v, err := p.value(table, col, *fk)
+			if err != nil {
+				return nil, err
+			}
+			value = v
+		} else {
+			switch strings.ToLower(col.Name) {
+			case "id":
+				p.n++
+				value = GenerateID(p.n)
+			case "created_at", "updated_at":
+				value = GenerateCreatedAt()
+			default:
+				value = GenerateValue(col)
+			}
+		}
+		if col.Name == refColumn {
+			ref = value
+		}
+		columns = append(columns, col.Name)
+		values = append(values, value)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	if _, err := p.tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, strings.Join(columns, ", "), placeholders), values...); err != nil {
+		return nil, fmt.Errorf("failed to create a parent %s row: %w", table.Name, err)
+	}
+	if p.created[table.Name] == 0 {
+		p.createdIDs = append(p.createdIDs, table.Name)
+	}
+	p.created[table.Name]++
+
+	// Reuse sees the new parent
+	key := table.Name + "." + refColumn
+	if ids, ok := p.ids[key]; ok {
+		p.ids[key] = append(ids, ref)
+	}
+	return ref, nil
+}
+
+// forget drops the cached ids of table after rows were inserted into it
+// behind the filler's back.
+func (p *parentFiller) forget(table string) {
+	for key := range p.ids {
+		if strings.HasPrefix(key, table+".") {
+			delete(p.ids, key)
+		}
+	}
+}
+
+// report prints the parents that were created.
+func (p *parentFiller) report(w io.Writer) {
+	for _, name := range p.createdIDs {
+		fmt.Fprintf(w, "   Created %d %s row(s) as parents\n", p.created[name], name)
+	}
+}
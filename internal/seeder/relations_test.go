@@ -0,0 +1,109 @@
+package seeder
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const relationsSchema = `
+CREATE TABLE users (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL
+);
+CREATE TABLE posts (
+  id TEXT PRIMARY KEY,
+  title TEXT NOT NULL,
+  user_id TEXT NOT NULL REFERENCES users(id)
+);
+CREATE TABLE comments (
+  id TEXT PRIMARY KEY,
+  body TEXT NOT NULL,
+  post_id TEXT NOT NULL REFERENCES posts(id),
+  parent_id TEXT REFERENCES comments(id)
+);
+`
+
+func relationsSeeder(t *testing.T) (*Seeder, []TableSchema) {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(relationsSchema); err != nil {
+		t.Fatal(err)
+	}
+	tables, err := parseSchemaContent(relationsSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Seeder{db: db}, tables
+}
+
+func count(t *testing.T, s *Seeder, query string) int {
+	t.Helper()
+	var n int
+	if err := s.db.QueryRow(query).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestSeedParents(t *testing.T) {
+	tests := []struct {
+		strategy      ParentStrategy
+		posts, users  int
+		seededParents bool // posts exist before seeding comments
+	}{
+		{ParentsReuse, 10, 10, false},
+		{ParentsReuse, 2, 1, true},
+		{ParentsCreate, 102, 101, true},
+		{ParentsExisting, 2, 1, true},
+	}
+	for _, tt := range tests {
+		s, tables := relationsSeeder(t)
+		if tt.seededParents {
+			if err := s.Seed(*FindTable(tables, "posts"), 2, SeedOptions{Tables: tables}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.Seed(*FindTable(tables, "comments"), 100, SeedOptions{Tables: tables, Parents: tt.strategy}); err != nil {
+			t.Fatalf("%s: %v", tt.strategy, err)
+		}
+
+		posts := count(t, s, "SELECT COUNT(*) FROM posts")
+		users := count(t, s, "SELECT COUNT(*) FROM users")
+		if posts != tt.posts || users != tt.users {
+			t.Errorf("%s: %d posts, %d users; want %d, %d", tt.strategy, posts, users, tt.posts, tt.users)
+		}
+		orphans := count(t, s, `SELECT
+			(SELECT COUNT(*) FROM comments WHERE post_id NOT IN (SELECT id FROM posts)) +
+			(SELECT COUNT(*) FROM comments WHERE parent_id IS NOT NULL AND parent_id NOT IN (SELECT id FROM comments)) +
+			(SELECT COUNT(*) FROM posts WHERE user_id NOT IN (SELECT id FROM users))`)
+		if orphans != 0 {
+			t.Errorf("%s: %d rows reference missing parents", tt.strategy, orphans)
+		}
+	}
+}
+
+func TestSeedParentsExistingFailsWithoutParents(t *testing.T) {
+	s, tables := relationsSeeder(t)
+	err := s.Seed(*FindTable(tables, "comments"), 5, SeedOptions{Tables: tables, Parents: ParentsExisting})
+	if err == nil || !strings.Contains(err.Error(), "comments.post_id references posts, which has no rows") {
+		t.Fatalf("err = %v, want a missing parents error", err)
+	}
+	if n := count(t, s, "SELECT COUNT(*) FROM comments"); n != 0 {
+		t.Errorf("%d comments inserted, want none", n)
+	}
+}
+
+func TestParseParentStrategy(t *testing.T) {
+	if s, err := ParseParentStrategy("Create"); err != nil || s != ParentsCreate {
+		t.Errorf("ParseParentStrategy(Create) = %q, %v", s, err)
+	}
+	if _, err := ParseParentStrategy("orphan"); err == nil {
+		t.Error("ParseParentStrategy accepted orphan")
+	}
+}
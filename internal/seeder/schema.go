@@ -9,10 +9,11 @@ import (
 )
 
 type TableSchema struct {
-	Name       string
-	Columns    []Column
-	PrimaryKey string
-	Indexes    []Index
+	Name        string
+	Columns     []Column
+	PrimaryKey  string
+	Indexes     []Index
+	ForeignKeys []ForeignKey
 }
 
 type Column struct {
@@ -20,11 +21,39 @@ type Column struct {
 	Type      string
 	Nullable  bool
 	IsPrimary bool
+	IsJSON    bool // CHECK (json_valid(...)) column generated for name:json fields
+
+	// Set by Configure from .lvtresources and .lvtrc
+	Textarea  bool     // generated as a textarea (text fields)
+	RichText  bool     // holds HTML (richtext fields)
+	Options   []string // values of a select field
+	Generator string   // gofakeit template overriding the generated value
 }
 
 type Index struct {
 	Name    string
 	Columns []string
+	Unique  bool
+	Where   string // condition of a partial index
+}
+
+// ForeignKey is a column-level (col TEXT REFERENCES t(id)) or table-level
+// (FOREIGN KEY (col) REFERENCES t(id)) reference.
+type ForeignKey struct {
+	Column    string
+	RefTable  string
+	RefColumn string // "id" when not given
+	OnDelete  string // e.g. "CASCADE", "SET NULL"; empty when not given
+}
+
+// Column returns the named column, or nil.
+func (t *TableSchema) Column(name string) *Column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
 }
 
 // ParseSchema parses the schema.sql file and returns all table schemas
@@ -77,18 +106,18 @@ func parseSchemaContent(content string) ([]TableSchema, error) {
 	// Remove comments
 	content = removeComments(content)
 
-	// Find all CREATE TABLE statements
-	tableRegex := regexp.MustCompile(`CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s*\(((?:[^()]+|\([^)]*\))*)\)`)
-	matches := tableRegex.FindAllStringSubmatch(content, -1)
+	// Find all CREATE TABLE statements. The column list is cut at the
+	// matching parenthesis so nested ones (CHECK (json_valid(x))) are kept.
+	tableRegex := regexp.MustCompile(`CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s*\(`)
+	matches := tableRegex.FindAllStringSubmatchIndex(content, -1)
 
 	for _, match := range matches {
-		if len(match) < 3 {
+		tableName := content[match[2]:match[3]]
+		columnsSQL, ok := balancedBody(content[match[1]:])
+		if !ok {
 			continue
 		}
 
-		tableName := match[1]
-		columnsSQL := match[2]
-
 		table := TableSchema{
 			Name:    tableName,
 			Columns: []Column{},
@@ -96,8 +125,9 @@ func parseSchemaContent(content string) ([]TableSchema, error) {
 		}
 
 		// Parse columns
-		columns := parseColumns(columnsSQL)
+		columns, foreignKeys := parseColumns(columnsSQL)
 		table.Columns = columns
+		table.ForeignKeys = foreignKeys
 
 		// Find primary key
 		for _, col := range columns {
@@ -111,17 +141,17 @@ func parseSchemaContent(content string) ([]TableSchema, error) {
 	}
 
 	// Parse indexes
-	indexRegex := regexp.MustCompile(`CREATE\s+INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s+ON\s+(\w+)\s*\(([^)]+)\)`)
+	indexRegex := regexp.MustCompile(`(?i)CREATE\s+(UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s+ON\s+(\w+)\s*\(([^)]+)\)(?:\s+WHERE\s+([^;]+))?`)
 	indexMatches := indexRegex.FindAllStringSubmatch(content, -1)
 
 	for _, match := range indexMatches {
-		if len(match) < 4 {
+		if len(match) < 6 {
 			continue
 		}
 
-		indexName := match[1]
-		tableName := match[2]
-		columnsStr := match[3]
+		indexName := match[2]
+		tableName := match[3]
+		columnsStr := match[4]
 
 		// Find the table and add the index
 		for i := range tables {
@@ -134,6 +164,8 @@ func parseSchemaContent(content string) ([]TableSchema, error) {
 				tables[i].Indexes = append(tables[i].Indexes, Index{
 					Name:    indexName,
 					Columns: columns,
+					Unique:  match[1] != "",
+					Where:   strings.TrimSpace(match[5]),
 				})
 				break
 			}
@@ -143,9 +175,54 @@ func parseSchemaContent(content string) ([]TableSchema, error) {
 	return tables, nil
 }
 
-// parseColumns parses column definitions from CREATE TABLE statement
-func parseColumns(columnsSQL string) []Column {
+// balancedBody returns s up to the parenthesis closing the one just before
+// it, skipping parentheses inside quoted strings.
+func balancedBody(s string) (string, bool) {
+	depth := 1
+	inQuote := false
+	for i, ch := range s {
+		switch {
+		case ch == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 {
+				return s[:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+var (
+	tableForeignKeyRegex = regexp.MustCompile(`(?i)^(?:CONSTRAINT\s+\w+\s+)?FOREIGN\s+KEY\s*\(\s*(\w+)\s*\)\s*(REFERENCES\s+.*)$`)
+	referencesRegex      = regexp.MustCompile(`(?i)\bREFERENCES\s+(\w+)\s*(?:\(\s*(\w+)\s*\))?`)
+	onDeleteRegex        = regexp.MustCompile(`(?i)\bON\s+DELETE\s+(SET\s+NULL|SET\s+DEFAULT|CASCADE|RESTRICT|NO\s+ACTION)`)
+)
+
+// parseReference parses "REFERENCES table(col) [ON DELETE action]".
+func parseReference(column, def string) (ForeignKey, bool) {
+	m := referencesRegex.FindStringSubmatch(def)
+	if m == nil {
+		return ForeignKey{}, false
+	}
+	fk := ForeignKey{Column: column, RefTable: m[1], RefColumn: m[2]}
+	if fk.RefColumn == "" {
+		fk.RefColumn = "id"
+	}
+	if od := onDeleteRegex.FindStringSubmatch(def); od != nil {
+		fk.OnDelete = strings.Join(strings.Fields(strings.ToUpper(od[1])), " ")
+	}
+	return fk, true
+}
+
+// parseColumns parses column definitions and foreign keys from CREATE TABLE statement
+func parseColumns(columnsSQL string) ([]Column, []ForeignKey) {
 	var columns []Column
+	var foreignKeys []ForeignKey
 
 	// Split by comma, but be careful of commas inside parentheses
 	columnDefs := splitColumns(columnsSQL)
@@ -156,9 +233,16 @@ func parseColumns(columnsSQL string) []Column {
 			continue
 		}
 
-		// Skip constraints like FOREIGN KEY, CHECK, etc.
+		if m := tableForeignKeyRegex.FindStringSubmatch(colDef); m != nil {
+			if fk, ok := parseReference(m[1], m[2]); ok {
+				foreignKeys = append(foreignKeys, fk)
+			}
+			continue
+		}
+
+		// Skip other constraints like CHECK, UNIQUE, etc.
 		if strings.HasPrefix(strings.ToUpper(colDef), "CONSTRAINT") ||
-			strings.HasPrefix(strings.ToUpper(colDef), "FOREIGN KEY") ||
+			strings.HasPrefix(strings.ToUpper(colDef), "PRIMARY KEY") ||
 			strings.HasPrefix(strings.ToUpper(colDef), "CHECK") ||
 			strings.HasPrefix(strings.ToUpper(colDef), "UNIQUE") {
 			continue
@@ -167,10 +251,13 @@ func parseColumns(columnsSQL string) []Column {
 		col := parseColumn(colDef)
 		if col.Name != "" {
 			columns = append(columns, col)
+			if fk, ok := parseReference(col.Name, colDef); ok {
+				foreignKeys = append(foreignKeys, fk)
+			}
 		}
 	}
 
-	return columns
+	return columns, foreignKeys
 }
 
 // parseColumn parses a single column definition
@@ -195,6 +282,9 @@ func parseColumn(colDef string) Column {
 	if strings.Contains(defUpper, "NOT NULL") {
 		col.Nullable = false
 	}
+	if strings.Contains(defUpper, "JSON_VALID(") {
+		col.IsJSON = true
+	}
 
 	return col
 }
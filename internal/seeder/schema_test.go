@@ -0,0 +1,79 @@
+package seeder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSchemaContentForeignKeys(t *testing.T) {
+	sql := `
+CREATE TABLE IF NOT EXISTS comments (
+  id TEXT PRIMARY KEY,
+  post_id TEXT NOT NULL,
+  meta TEXT NOT NULL CHECK (json_valid(meta)),
+  created_by TEXT NOT NULL REFERENCES users(id),
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_comments_post_id ON comments(post_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_comments_created_by ON comments(created_by, created_at);
+`
+	tables, err := parseSchemaContent(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+	comments := tables[0]
+
+	var names []string
+	for _, c := range comments.Columns {
+		names = append(names, c.Name)
+	}
+	// Columns after the nested CHECK parentheses must not be lost
+	if want := []string{"id", "post_id", "meta", "created_by", "created_at"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("columns = %v, want %v", names, want)
+	}
+	if c := comments.Column("meta"); c == nil || !c.IsJSON {
+		t.Errorf("meta should be a JSON column, got %+v", c)
+	}
+
+	want := []ForeignKey{
+		{Column: "created_by", RefTable: "users", RefColumn: "id"},
+		{Column: "post_id", RefTable: "posts", RefColumn: "id", OnDelete: "SET NULL"},
+	}
+	if !reflect.DeepEqual(comments.ForeignKeys, want) {
+		t.Errorf("foreign keys = %+v, want %+v", comments.ForeignKeys, want)
+	}
+	if len(comments.Indexes) != 2 || comments.Indexes[1].Name != "idx_comments_created_by" {
+		t.Errorf("indexes = %+v", comments.Indexes)
+	}
+}
+
+func TestParseSchemaContentIndexes(t *testing.T) {
+	sql := `
+CREATE TABLE IF NOT EXISTS users (
+  id TEXT PRIMARY KEY,
+  org_id TEXT NOT NULL,
+  email TEXT NOT NULL,
+  deleted_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_unique ON users(email);
+CREATE INDEX IF NOT EXISTS idx_users_org_id_created_at ON users(org_id, created_at) WHERE deleted_at IS NULL;
+`
+	tables, err := parseSchemaContent(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Index{
+		{Name: "idx_users_created_at", Columns: []string{"created_at"}},
+		{Name: "idx_users_email_unique", Columns: []string{"email"}, Unique: true},
+		{Name: "idx_users_org_id_created_at", Columns: []string{"org_id", "created_at"}, Where: "deleted_at IS NULL"},
+	}
+	if !reflect.DeepEqual(tables[0].Indexes, want) {
+		t.Errorf("indexes = %+v, want %+v", tables[0].Indexes, want)
+	}
+}
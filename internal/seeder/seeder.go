@@ -3,10 +3,13 @@ package seeder
 import (
 	"database/sql"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/logging"
 	_ "modernc.org/sqlite"
 )
 
@@ -19,11 +22,12 @@ const (
 type Seeder struct {
 	db     *sql.DB
 	dbPath string
+	out    io.Writer // progress messages; nil is stdout
 }
 
 // New creates a new Seeder instance
 func New() (*Seeder, error) {
-	dbPath, err := findDatabasePath()
+	dbPath, err := FindDatabasePath()
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +47,19 @@ func New() (*Seeder, error) {
 	}, nil
 }
 
+// SetOutput sends the progress messages of Seed and Cleanup to w instead
+// of logging.Messages.
+func (s *Seeder) SetOutput(w io.Writer) {
+	s.out = w
+}
+
+func (s *Seeder) output() io.Writer {
+	if s.out == nil {
+		return logging.Messages()
+	}
+	return s.out
+}
+
 // Close closes the database connection
 func (s *Seeder) Close() error {
 	if s.db != nil {
@@ -51,9 +68,19 @@ func (s *Seeder) Close() error {
 	return nil
 }
 
-// Seed generates and inserts N rows of test data for the given table
-func (s *Seeder) Seed(table TableSchema, count int) error {
-	fmt.Printf("Seeding %s with %d rows...\n", table.Name, count)
+// SeedOptions configures Seed.
+type SeedOptions struct {
+	// Tables is the whole schema, used to create parent rows for foreign
+	// keys. Without it foreign keys only point at existing rows.
+	Tables []TableSchema
+	// Parents is how foreign keys are filled; empty means ParentsReuse.
+	Parents ParentStrategy
+}
+
+// Seed generates and inserts N rows of test data for the given table,
+// filling its foreign keys with parent rows as opts.Parents says
+func (s *Seeder) Seed(table TableSchema, count int, opts SeedOptions) error {
+	fmt.Fprintf(s.output(), "Seeding %s with %d rows...\n", table.Name, count)
 
 	// Prepare column names and placeholders for INSERT
 	var columns []string
@@ -84,9 +111,14 @@ func (s *Seeder) Seed(table TableSchema, count int) error {
 	}
 	defer stmt.Close()
 
+	parents := newParentFiller(tx, opts.Tables, opts.Parents, count)
+
 	// Insert rows
 	for i := 0; i < count; i++ {
-		values := s.generateRow(table, i)
+		values, err := s.generateRow(table, i, parents)
+		if err != nil {
+			return err
+		}
 
 		if _, err := stmt.Exec(values...); err != nil {
 			return fmt.Errorf("failed to insert row %d: %w", i+1, err)
@@ -94,7 +126,7 @@ func (s *Seeder) Seed(table TableSchema, count int) error {
 
 		// Show progress
 		if (i+1)%10 == 0 || i+1 == count {
-			fmt.Printf("  Progress: %d/%d\n", i+1, count)
+			fmt.Fprintf(s.output(), "  Progress: %d/%d\n", i+1, count)
 		}
 	}
 
@@ -102,17 +134,27 @@ func (s *Seeder) Seed(table TableSchema, count int) error {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully seeded %d rows into %s\n", count, table.Name)
+	parents.report(s.output())
+	fmt.Fprintf(s.output(), "✅ Successfully seeded %d rows into %s\n", count, table.Name)
 	return nil
 }
 
 // generateRow generates a single row of data
-func (s *Seeder) generateRow(table TableSchema, index int) []interface{} {
+func (s *Seeder) generateRow(table TableSchema, index int, parents *parentFiller) ([]interface{}, error) {
 	var values []interface{}
 
 	for _, col := range table.Columns {
 		var value interface{}
 
+		if fk := foreignKey(table, col.Name); fk != nil {
+			v, err := parents.value(table, col, *fk)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+			continue
+		}
+
 		// Handle special columns
 		switch strings.ToLower(col.Name) {
 		case "id":
@@ -126,32 +168,33 @@ func (s *Seeder) generateRow(table TableSchema, index int) []interface{} {
 		values = append(values, value)
 	}
 
-	return values
+	return values, nil
 }
 
-// Cleanup removes all test-seeded data from the given table
-func (s *Seeder) Cleanup(tableName string) error {
-	fmt.Printf("Cleaning up test data from %s...\n", tableName)
+// Cleanup removes all test-seeded data from the given table and returns
+// the number of rows removed
+func (s *Seeder) Cleanup(tableName string) (int64, error) {
+	fmt.Fprintf(s.output(), "Cleaning up test data from %s...\n", tableName)
 
 	deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE id LIKE ?", tableName)
 
 	result, err := s.db.Exec(deleteSQL, testIDPrefix)
 	if err != nil {
-		return fmt.Errorf("failed to delete test data: %w", err)
+		return 0, fmt.Errorf("failed to delete test data: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %w", err)
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		fmt.Printf("ℹ️  No test data found in %s\n", tableName)
+		fmt.Fprintf(s.output(), "ℹ️  No test data found in %s\n", tableName)
 	} else {
-		fmt.Printf("✅ Removed %d test record(s) from %s\n", rowsAffected, tableName)
+		fmt.Fprintf(s.output(), "✅ Removed %d test record(s) from %s\n", rowsAffected, tableName)
 	}
 
-	return nil
+	return rowsAffected, nil
 }
 
 // CountTestRecords counts the number of test-seeded records in a table
@@ -167,8 +210,18 @@ func (s *Seeder) CountTestRecords(tableName string) (int, error) {
 	return count, nil
 }
 
-// findDatabasePath locates the SQLite database file
-func findDatabasePath() (string, error) {
+// FindDatabasePath locates the SQLite database file for the environment
+func FindDatabasePath() (string, error) {
+	// A database configured for the environment (LVT_ENV) wins over app.db
+	if wd, err := os.Getwd(); err == nil {
+		root := config.ProjectRoot(wd)
+		if path := config.DatabasePath(root); path == config.MemoryDatabase {
+			return "", config.MemoryDatabaseError(config.Environment(root))
+		} else if path != "" {
+			return path, nil
+		}
+	}
+
 	// Try current directory first
 	if _, err := os.Stat(defaultDBPath); err == nil {
 		return defaultDBPath, nil
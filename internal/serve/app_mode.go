@@ -3,6 +3,7 @@ package serve
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -11,20 +12,34 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/devlog"
+	"github.com/livetemplate/lvt/internal/generator"
 )
 
+// appReadyTimeout bounds the wait for a started app to answer its health
+// endpoint, including the time `go run` takes to compile it.
+const appReadyTimeout = 2 * time.Minute
+
 type AppMode struct {
-	server      *Server
-	appProcess  *exec.Cmd
-	appPort     int
-	proxy       *httputil.ReverseProxy
-	mu          sync.Mutex
-	stopChan    chan struct{}
-	mainGoPath  string
-	processDone chan struct{} // Signals when current process has exited
+	server       *Server
+	appProcess   *exec.Cmd
+	appPort      int
+	healthPrefix string // HEALTH_PATH_PREFIX, where the app serves /healthz and /readyz
+	proxy        *httputil.ReverseProxy
+	mu           sync.Mutex
+	stopChan     chan struct{}
+	mainGoPath   string
+	processDone  chan struct{}  // Signals when current process has exited
+	logs         *devlog.Writer // nil when the log could not be opened
+	sessions     *sessionIndex  // session of recent requests, by request ID
+	requests     atomic.Int64   // numbers the request IDs the proxy assigns
 }
 
 func NewAppMode(s *Server) (*AppMode, error) {
@@ -37,9 +52,11 @@ func NewAppMode(s *Server) (*AppMode, error) {
 	listener.Close()
 
 	am := &AppMode{
-		server:   s,
-		appPort:  appPort,
-		stopChan: make(chan struct{}),
+		server:       s,
+		appPort:      appPort,
+		healthPrefix: config.HealthPathPrefix(s.config.Dir),
+		stopChan:     make(chan struct{}),
+		sessions:     newSessionIndex(),
 	}
 
 	if err := am.detectApp(); err != nil {
@@ -49,6 +66,19 @@ func NewAppMode(s *Server) (*AppMode, error) {
 	targetURL, _ := url.Parse(fmt.Sprintf("http://localhost:%d", am.appPort))
 	am.proxy = httputil.NewSingleHostReverseProxy(targetURL)
 
+	// Keep the development log read by `lvt logs`
+	if am.logs, err = devlog.Open(s.config.Dir); err != nil {
+		log.Printf("Warning: not keeping the development log (%s): %v", devlog.Path, err)
+	} else {
+		log.Printf("Logging server, browser console and WebSocket activity to %s (lvt logs -f)", devlog.Path)
+	}
+	director := am.proxy.Director
+	am.proxy.Director = func(r *http.Request) {
+		director(r)
+		am.tagRequest(r)
+	}
+	am.proxy.ModifyResponse = am.logResponse
+
 	am.proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
 		log.Printf("Proxy error: %v", err)
 		w.WriteHeader(http.StatusBadGateway)
@@ -91,7 +121,13 @@ func NewAppMode(s *Server) (*AppMode, error) {
 		p { color: #7f8c8d; margin: 0; }
 	</style>
 	<script>
-		setTimeout(() => window.location.reload(), 2000);
+		// Reload once the app answers its liveness endpoint; until then the
+		// proxy answers 502 with this page
+		setInterval(() => {
+			fetch('%s', {cache: 'no-store'})
+				.then(r => { if (r.status !== 502) window.location.reload(); })
+				.catch(() => {});
+		}, 500);
 	</script>
 </head>
 <body>
@@ -102,12 +138,13 @@ func NewAppMode(s *Server) (*AppMode, error) {
 		<p style="margin-top: 1rem; font-size: 0.9rem;">This page will refresh automatically.</p>
 	</div>
 </body>
-</html>`)
+</html>`, am.healthPrefix+"/healthz")
 	}
 
 	if err := am.startApp(); err != nil {
 		return nil, fmt.Errorf("failed to start app: %w", err)
 	}
+	go am.awaitReady("")
 
 	return am, nil
 }
@@ -172,13 +209,21 @@ func (am *AppMode) startApp() error {
 	am.appProcess.Dir = am.server.config.Dir
 
 	// In test mode, discard output to prevent I/O hanging
-	// Otherwise, pipe to os.Stdout/os.Stderr for visibility
+	// Otherwise, copy it to os.Stdout/os.Stderr and the development log
 	if testing.Testing() {
 		am.appProcess.Stdout = nil
 		am.appProcess.Stderr = nil
 	} else {
 		am.appProcess.Stdout = os.Stdout
 		am.appProcess.Stderr = os.Stderr
+		if stdout, err := am.captureOutput(os.Stdout, "INFO"); err == nil {
+			defer stdout.Close() // the app has its own copy once started
+			am.appProcess.Stdout = stdout
+		}
+		if stderr, err := am.captureOutput(os.Stderr, "ERROR"); err == nil {
+			defer stderr.Close()
+			am.appProcess.Stderr = stderr
+		}
 	}
 
 	am.appProcess.Env = append(os.Environ(),
@@ -187,6 +232,17 @@ func (am *AppMode) startApp() error {
 		fmt.Sprintf("LVT_TEMPLATE_BASE_DIR=%s", am.server.config.Dir), // Set template base directory for auto-discovery
 	)
 
+	// Resolve the environment's database here, so a DATABASE_PATH_<ENV> or
+	// database= from .env or .lvtrc reaches the app
+	env := config.Environment(am.server.config.Dir)
+	am.appProcess.Env = append(am.appProcess.Env, "LVT_ENV="+env)
+	if path := config.DatabasePath(am.server.config.Dir); path != "" {
+		am.appProcess.Env = append(am.appProcess.Env, config.DatabaseEnvVar(env)+"="+path)
+	}
+	if am.healthPrefix != "" {
+		am.appProcess.Env = append(am.appProcess.Env, "HEALTH_PATH_PREFIX="+am.healthPrefix)
+	}
+
 	if err := am.appProcess.Start(); err != nil {
 		return fmt.Errorf("failed to start app: %w", err)
 	}
@@ -241,6 +297,7 @@ func (am *AppMode) Stop() {
 	am.mu.Lock()
 	defer am.mu.Unlock()
 	am.stopAppLocked()
+	_ = am.logs.Close()
 }
 
 func (am *AppMode) Restart() error {
@@ -252,40 +309,94 @@ func (am *AppMode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	am.proxy.ServeHTTP(w, r)
 }
 
-func (am *AppMode) HandleFileChange(path string) {
+// HandleFileChange restarts the app when a Go, template or SQL file
+// changes. It reports whether it handled the change: browsers are then told
+// to reload once the restarted app is ready, not straight away.
+func (am *AppMode) HandleFileChange(path string) bool {
 	ext := filepath.Ext(path)
-	if ext == ".go" || ext == ".tmpl" || ext == ".sql" {
-		log.Printf("Detected change in %s, restarting app...", path)
-
-		go func() {
-			time.Sleep(100 * time.Millisecond)
-			if err := am.Restart(); err != nil {
-				log.Printf("Failed to restart app: %v", err)
+	if ext != ".go" && ext != ".tmpl" && ext != ".sql" {
+		return false
+	}
+	if ext == ".tmpl" {
+		// Keep the running app rather than restart into a template
+		// that fails to parse at startup.
+		funcs := generator.TemplateFuncs(am.server.config.Dir)
+		if _, err := os.Stat(path); err == nil {
+			if err := generator.ValidateTemplateFuncs(path, funcs); err != nil {
+				log.Printf("Not restarting app, template does not parse:\n%v", err)
+				return true
 			}
-		}()
+		}
 	}
+	log.Printf("Detected change in %s, restarting app...", path)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if err := am.Restart(); err != nil {
+			log.Printf("Failed to restart app: %v", err)
+			return
+		}
+		am.awaitReady(path)
+	}()
+	return true
 }
 
+// awaitReady waits for the started app to come up, then tells browsers to
+// reload. changed is the file that triggered the restart, if any.
+func (am *AppMode) awaitReady(changed string) {
+	ctx, cancel := context.WithTimeout(context.Background(), appReadyTimeout)
+	defer cancel()
+	if err := am.WaitForReady(ctx); err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	if changed != "" {
+		am.server.wsManager.Broadcast(map[string]interface{}{
+			"type": "reload",
+			"path": changed,
+		})
+	}
+}
+
+// WaitForReady polls the app's liveness endpoint, /healthz, until the app
+// answers, then logs why /readyz fails if it does (e.g. pending migrations):
+// in development the app serves pages either way. Apps generated without
+// the endpoints are up once they answer at all.
 func (am *AppMode) WaitForReady(ctx context.Context) error {
 	client := &http.Client{
 		Timeout: 1 * time.Second,
 	}
+	base := fmt.Sprintf("http://localhost:%d%s", am.appPort, am.healthPrefix)
 
-	for i := 0; i < 30; i++ {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		resp, err := client.Get(fmt.Sprintf("http://localhost:%d", am.appPort))
+	for {
+		resp, err := client.Get(base + "/healthz")
 		if err == nil {
 			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				am.logReadiness(client, base+"/readyz")
+			}
 			return nil
 		}
 
-		time.Sleep(200 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("app did not become ready: %w", ctx.Err())
+		case <-time.After(200 * time.Millisecond):
+		}
 	}
+}
 
-	return fmt.Errorf("app did not become ready within timeout")
+// logReadiness logs the app's readiness: ready, or the reason /readyz gives.
+func (am *AppMode) logReadiness(client *http.Client, url string) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		log.Printf("App ready on port %d", am.appPort)
+		return
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	log.Printf("Warning: app is running but not ready (%s %d): %s", am.healthPrefix+"/readyz", resp.StatusCode, strings.TrimSpace(string(body)))
 }
@@ -1,6 +1,7 @@
 package serve
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -9,16 +10,29 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/livetemplate/lvt/internal/config"
 	"github.com/livetemplate/lvt/internal/kits"
 	"gopkg.in/yaml.v3"
 )
 
+// FixtureFile is the name of the JSON file holding a component's preview
+// data. Single-file components use "<name>.fixture.json" next to the template.
+const FixtureFile = "fixture.json"
+
+// ComponentMode is a component workbench: it lists the components being
+// developed, renders each with editable fixture data, and re-renders when
+// templates change.
 type ComponentMode struct {
 	server *Server
 	kit    *kits.KitInfo
-	tmpl   *template.Template
+
+	mu         sync.RWMutex
+	components []*Component
 }
 
 type ComponentManifest struct {
@@ -31,88 +45,345 @@ type ComponentManifest struct {
 	Templates   []string `yaml:"templates"`
 }
 
+// Component is a single entry in the workbench.
+type Component struct {
+	Name      string   `json:"name"`
+	Dir       string   `json:"dir"`
+	Templates []string `json:"templates"` // entry template first
+	Fixture   string   `json:"fixture"`   // path of the fixture JSON file
+}
+
 func NewComponentMode(s *Server) (*ComponentMode, error) {
 	cm := &ComponentMode{
 		server: s,
 	}
 
-	if err := cm.loadComponent(); err != nil {
+	if err := cm.loadComponents(); err != nil {
 		return nil, err
 	}
 
 	return cm, nil
 }
 
-func (cm *ComponentMode) loadComponent() error {
-	manifestPath := filepath.Join(cm.server.config.Dir, "component.yaml")
-	data, err := os.ReadFile(manifestPath)
+// loadComponents discovers the components to serve. A directory with a
+// component.yaml (or component.tmpl) is a single component; otherwise every
+// entry in app/components is one.
+func (cm *ComponentMode) loadComponents() error {
+	dir := cm.server.config.Dir
+
+	var components []*Component
+	kitName := ""
+	switch {
+	case fileExists(filepath.Join(dir, "component.yaml")):
+		manifest, err := readComponentManifest(filepath.Join(dir, "component.yaml"))
+		if err != nil {
+			return err
+		}
+		if len(manifest.Templates) == 0 {
+			return fmt.Errorf("no templates defined in component.yaml")
+		}
+		c, err := componentFromManifest(dir, manifest)
+		if err != nil {
+			return err
+		}
+		components = append(components, c)
+		kitName = manifest.Kit
+
+	case fileExists(filepath.Join(dir, "component.tmpl")):
+		components = append(components, &Component{
+			Name:      filepath.Base(dir),
+			Dir:       dir,
+			Templates: []string{filepath.Join(dir, "component.tmpl")},
+			Fixture:   filepath.Join(dir, FixtureFile),
+		})
+
+	default:
+		found, err := discoverComponents(filepath.Join(dir, "app", "components"))
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return fmt.Errorf("no components found: expected component.yaml or templates in %s", filepath.Join(dir, "app", "components"))
+		}
+		components = found
+		if cfg, err := config.LoadProjectConfig(dir); err == nil {
+			kitName = cfg.Kit
+		}
+	}
+
+	kit := cm.currentKit()
+	if kit == nil || (kitName != "" && kit.Manifest.Name != kitName) {
+		kit = loadComponentKit(kitName)
+	}
+
+	cm.mu.Lock()
+	cm.kit = kit
+	cm.components = components
+	cm.mu.Unlock()
+
+	log.Printf("Components loaded: %d (kit: %s)", len(components), cm.kitName())
+	return nil
+}
+
+// discoverComponents lists the components in dir: each subdirectory with
+// templates, and each top-level .tmpl file.
+func discoverComponents(dir string) ([]*Component, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var components []*Component
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			if filepath.Ext(entry.Name()) != ".tmpl" {
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), ".tmpl")
+			components = append(components, &Component{
+				Name:      name,
+				Dir:       dir,
+				Templates: []string{path},
+				Fixture:   filepath.Join(dir, name+".fixture.json"),
+			})
+			continue
+		}
+
+		if manifestPath := filepath.Join(path, "component.yaml"); fileExists(manifestPath) {
+			manifest, err := readComponentManifest(manifestPath)
+			if err != nil {
+				return nil, err
+			}
+			if len(manifest.Templates) > 0 {
+				if manifest.Name == "" {
+					manifest.Name = entry.Name()
+				}
+				c, err := componentFromManifest(path, manifest)
+				if err != nil {
+					return nil, err
+				}
+				components = append(components, c)
+				continue
+			}
+		}
+
+		var templates []string
+		for _, pattern := range []string{"*.tmpl", filepath.Join("templates", "*.tmpl")} {
+			matches, _ := filepath.Glob(filepath.Join(path, pattern))
+			templates = append(templates, matches...)
+		}
+		if len(templates) == 0 {
+			continue
+		}
+		components = append(components, &Component{
+			Name:      entry.Name(),
+			Dir:       path,
+			Templates: templates,
+			Fixture:   filepath.Join(path, FixtureFile),
+		})
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i].Name < components[j].Name })
+	return components, nil
+}
+
+func readComponentManifest(path string) (*ComponentManifest, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read component.yaml: %w", err)
+		return nil, fmt.Errorf("failed to read component.yaml: %w", err)
 	}
 
 	var manifest ComponentManifest
 	if err := yaml.Unmarshal(data, &manifest); err != nil {
-		return fmt.Errorf("failed to parse component.yaml: %w", err)
+		return nil, fmt.Errorf("failed to parse component.yaml: %w", err)
 	}
+	return &manifest, nil
+}
 
-	if manifest.Kit == "" {
-		manifest.Kit = "tailwind"
+func componentFromManifest(dir string, manifest *ComponentManifest) (*Component, error) {
+	c := &Component{
+		Name:    manifest.Name,
+		Dir:     dir,
+		Fixture: filepath.Join(dir, FixtureFile),
+	}
+	if c.Name == "" {
+		c.Name = filepath.Base(dir)
+	}
+	for _, t := range manifest.Templates {
+		path := filepath.Join(dir, t)
+		if !fileExists(path) {
+			return nil, fmt.Errorf("template file not found: %s", path)
+		}
+		c.Templates = append(c.Templates, path)
 	}
+	return c, nil
+}
 
+func loadComponentKit(name string) *kits.KitInfo {
+	if name == "" {
+		name = "multi"
+	}
 	kitLoader := kits.DefaultLoader()
-	kit, err := kitLoader.Load(manifest.Kit)
+	kit, err := kitLoader.Load(name)
 	if err != nil {
-		log.Printf("Warning: Failed to load kit %s, using default: %v", manifest.Kit, err)
-		kit, _ = kitLoader.Load("tailwind")
+		log.Printf("Warning: Failed to load kit %s, using default: %v", name, err)
+		kit, _ = kitLoader.Load("multi")
 	}
-	cm.kit = kit
+	return kit
+}
 
-	if len(manifest.Templates) == 0 {
-		return fmt.Errorf("no templates defined in component.yaml")
+func (cm *ComponentMode) currentKit() *kits.KitInfo {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.kit
+}
+
+func (cm *ComponentMode) kitName() string {
+	kit := cm.currentKit()
+	if kit == nil {
+		return "none"
 	}
+	return kit.Manifest.Name
+}
 
-	templatePath := filepath.Join(cm.server.config.Dir, manifest.Templates[0])
-	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-		return fmt.Errorf("template file not found: %s", templatePath)
+// head returns the stylesheet markup injected into previews.
+func (cm *ComponentMode) head() string {
+	kit := cm.currentKit()
+	if kit == nil {
+		return ""
+	}
+	if kit.Manifest.CDN != "" {
+		return kit.Manifest.CDN
+	}
+	if kit.Helpers != nil {
+		return kit.Helpers.CSSCDN()
 	}
+	return ""
+}
 
-	tmpl, err := cm.parseTemplate(templatePath)
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+// Components returns the discovered components.
+func (cm *ComponentMode) Components() []*Component {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return append([]*Component(nil), cm.components...)
+}
+
+func (cm *ComponentMode) component(name string) (*Component, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	for _, c := range cm.components {
+		if c.Name == name {
+			return c, true
+		}
 	}
-	cm.tmpl = tmpl
+	if name == "" && len(cm.components) > 0 {
+		return cm.components[0], true
+	}
+	return nil, false
+}
 
-	log.Printf("Component loaded: %s (kit: %s)", manifest.Name, manifest.Kit)
-	return nil
+// parseTemplate parses all of a component's templates into one set. Kit
+// templates written with [[ ]] delimiters are accepted as well.
+func (cm *ComponentMode) parseTemplate(c *Component) (*template.Template, error) {
+	tmpl := template.New(filepath.Base(c.Templates[0]))
+
+	if kit := cm.currentKit(); kit != nil && kit.Helpers != nil {
+		funcs := createTemplateFuncs(kit.Helpers)
+		tmpl.Funcs(funcs)
+	}
+
+	for i, path := range c.Templates {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		templateContent := string(content)
+		templateContent = strings.ReplaceAll(templateContent, "[[", "{{")
+		templateContent = strings.ReplaceAll(templateContent, "]]", "}}")
+
+		t := tmpl
+		if i > 0 {
+			t = tmpl.New(filepath.Base(path))
+		}
+		if _, err := t.Parse(templateContent); err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpl, nil
 }
 
-func (cm *ComponentMode) parseTemplate(path string) (*template.Template, error) {
-	content, err := os.ReadFile(path)
+// Render executes the component's entry template with data. When the entry
+// file only holds {{define}} blocks, the first block it defines is rendered.
+func (cm *ComponentMode) Render(c *Component, data interface{}) (string, error) {
+	tmpl, err := cm.parseTemplate(c)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	templateContent := string(content)
-	templateContent = strings.ReplaceAll(templateContent, "[[", "{{")
-	templateContent = strings.ReplaceAll(templateContent, "]]", "}}")
+	name := entryTemplateName(tmpl, c.Templates[0])
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("template execution error: %w", err)
+	}
+	return buf.String(), nil
+}
 
-	tmpl := template.New(filepath.Base(path))
+var defineRe = regexp.MustCompile(`(?:\{\{|\[\[)-?\s*define\s+"([^"]+)"`)
 
-	if cm.kit != nil && cm.kit.Helpers != nil {
-		funcs := createTemplateFuncs(cm.kit.Helpers)
-		tmpl.Funcs(funcs)
+func entryTemplateName(tmpl *template.Template, entryPath string) string {
+	entry := filepath.Base(entryPath)
+	if t := tmpl.Lookup(entry); t != nil && t.Tree != nil && strings.TrimSpace(t.Tree.Root.String()) != "" {
+		return entry
 	}
 
-	tmpl, err = tmpl.Parse(templateContent)
+	content, err := os.ReadFile(entryPath)
 	if err != nil {
-		return nil, err
+		return entry
+	}
+	if m := defineRe.FindSubmatch(content); m != nil {
+		return string(m[1])
 	}
+	return entry
+}
 
-	return tmpl, nil
+// LoadFixture returns the component's fixture data, or an empty object when
+// it has none yet.
+func (c *Component) LoadFixture() (json.RawMessage, error) {
+	data, err := os.ReadFile(c.Fixture)
+	if os.IsNotExist(err) {
+		return json.RawMessage("{}"), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("fixture %s is not valid JSON", c.Fixture)
+	}
+	return json.RawMessage(data), nil
+}
+
+// SaveFixture writes data, re-indented, as the component's fixture.
+func (c *Component) SaveFixture(data []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	buf.WriteByte('\n')
+	return os.WriteFile(c.Fixture, buf.Bytes(), 0644)
 }
 
 func (cm *ComponentMode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.URL.Path {
+	case "/components":
+		cm.handleComponents(w, r)
+	case "/fixture":
+		cm.handleFixture(w, r)
 	case "/preview":
 		cm.handlePreview(w, r)
 	case "/render":
@@ -124,21 +395,167 @@ func (cm *ComponentMode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
-	cdn := ""
-	kitName := "none"
-	if cm.kit != nil {
-		cdn = cm.kit.Manifest.CDN
-		kitName = cm.kit.Manifest.Name
+func (cm *ComponentMode) handleComponents(w http.ResponseWriter, r *http.Request) {
+	if err := cm.loadComponents(); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load components: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"dir":        cm.server.config.Dir,
+		"kit":        cm.kitName(),
+		"head":       cm.head(),
+		"components": cm.Components(),
+	})
+}
+
+func (cm *ComponentMode) handleFixture(w http.ResponseWriter, r *http.Request) {
+	c, ok := cm.component(r.URL.Query().Get("component"))
+	if !ok {
+		http.Error(w, "Unknown component", http.StatusNotFound)
+		return
 	}
 
-	html := `<!DOCTYPE html>
+	switch r.Method {
+	case http.MethodGet:
+		data, err := c.LoadFixture()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+
+	case http.MethodPost, http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if err := c.SaveFixture(body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"status":  "saved",
+			"fixture": c.Fixture,
+		})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handlePreview renders a component with its saved fixture as a standalone
+// page, e.g. for opening it in a separate tab or device.
+func (cm *ComponentMode) handlePreview(w http.ResponseWriter, r *http.Request) {
+	c, ok := cm.component(r.URL.Query().Get("component"))
+	if !ok {
+		http.Error(w, "Unknown component", http.StatusNotFound)
+		return
+	}
+
+	fixture, err := c.LoadFixture()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var data interface{}
+	_ = json.Unmarshal(fixture, &data)
+
+	html, err := cm.Render(c, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, `<!DOCTYPE html>
 <html lang="en">
 <head>
 	<meta charset="UTF-8">
 	<meta name="viewport" content="width=device-width, initial-scale=1.0">
-	<title>Component Development - LiveTemplate</title>
-	` + cdn + `
+	<title>%s - Component Preview</title>
+	%s
+</head>
+<body>
+%s
+</body>
+</html>`, template.HTMLEscapeString(c.Name), cm.head(), html)
+}
+
+func (cm *ComponentMode) handleRender(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	var data interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &data); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	c, ok := cm.component(r.URL.Query().Get("component"))
+	if !ok {
+		http.Error(w, "Unknown component", http.StatusNotFound)
+		return
+	}
+
+	// Templates are re-read on every render, so edits show up immediately
+	html, err := cm.Render(c, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(html))
+}
+
+func (cm *ComponentMode) handleReload(w http.ResponseWriter, r *http.Request) {
+	if err := cm.loadComponents(); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reload: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"status": "reloaded",
+	})
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(workbenchHTML))
+}
+
+// workbenchHTML is the workbench UI: a component list, a fixture JSON
+// editor, and a preview iframe whose width follows the selected viewport.
+const workbenchHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>Component Workbench - LiveTemplate</title>
 	<style>
 		* { margin: 0; padding: 0; box-sizing: border-box; }
 		body {
@@ -179,8 +596,26 @@ func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 			flex: 1;
 			overflow: hidden;
 		}
+		.sidebar {
+			width: 220px;
+			border-right: 1px solid #ddd;
+			overflow: auto;
+			background: #fafafa;
+		}
+		.sidebar a {
+			display: block;
+			padding: 0.5rem 1rem;
+			color: #2c3e50;
+			text-decoration: none;
+			border-left: 3px solid transparent;
+		}
+		.sidebar a.active {
+			background: #e3f2fd;
+			border-left-color: #3498db;
+			font-weight: 600;
+		}
 		.editor-panel {
-			flex: 1;
+			width: 35%;
 			display: flex;
 			flex-direction: column;
 			border-right: 1px solid #ddd;
@@ -193,9 +628,25 @@ func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 		}
 		.panel-header {
 			background: #ecf0f1;
-			padding: 0.75rem 1rem;
+			padding: 0.5rem 1rem;
 			border-bottom: 1px solid #bdc3c7;
 			font-weight: 600;
+			display: flex;
+			justify-content: space-between;
+			align-items: center;
+			gap: 0.5rem;
+		}
+		.panel-header button {
+			padding: 0.25rem 0.75rem;
+			border: 1px solid #bdc3c7;
+			background: white;
+			border-radius: 4px;
+			cursor: pointer;
+		}
+		.panel-header button.active {
+			background: #3498db;
+			border-color: #3498db;
+			color: white;
 		}
 		.panel-content {
 			flex: 1;
@@ -203,11 +654,14 @@ func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 			padding: 1rem;
 		}
 		.preview-frame {
+			display: block;
+			margin: 0 auto;
+			width: 100%;
+			height: 100%;
 			background: white;
 			border: 1px solid #ddd;
 			border-radius: 4px;
-			padding: 2rem;
-			min-height: 200px;
+			transition: width 0.2s;
 		}
 		textarea {
 			width: 100%;
@@ -225,87 +679,129 @@ func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 			padding: 1rem;
 			border-left: 4px solid #c33;
 			margin-bottom: 1rem;
+			white-space: pre-wrap;
 		}
 		.info {
-			background: #e3f2fd;
-			padding: 1rem;
-			border-radius: 4px;
-			margin-bottom: 1rem;
+			padding: 0.5rem 1rem;
+			font-size: 0.85rem;
+			color: #666;
+			border-top: 1px solid #ddd;
 		}
 	</style>
 </head>
 <body>
 	<div class="header">
-		<h1>Component Development</h1>
+		<h1>Component Workbench</h1>
 		<div class="status">
 			<div class="status-dot" id="statusDot"></div>
 			<span id="statusText">Connected</span>
 		</div>
 	</div>
 	<div class="container">
+		<nav class="sidebar" id="componentList"></nav>
 		<div class="editor-panel">
-			<div class="panel-header">Test Data (JSON)</div>
-			<div class="panel-content">
-				<textarea id="dataEditor" placeholder='Enter test data as JSON, e.g., {"name": "John", "items": [...]}'>{}</textarea>
+			<div class="panel-header">
+				<span>Fixture Data (JSON)</span>
+				<button id="saveFixture" title="Write to the component's fixture file">Save</button>
+			</div>
+			<div class="panel-content" style="padding: 0;">
+				<textarea id="dataEditor" spellcheck="false">{}</textarea>
 			</div>
+			<div class="info" id="info"></div>
 		</div>
 		<div class="preview-panel">
-			<div class="panel-header">Live Preview</div>
+			<div class="panel-header">
+				<span>Live Preview</span>
+				<span id="viewports">
+					<button data-width="375px">Mobile</button>
+					<button data-width="768px">Tablet</button>
+					<button data-width="100%" class="active">Desktop</button>
+				</span>
+			</div>
 			<div class="panel-content">
 				<div id="error" class="error-message" style="display: none;"></div>
-				<div class="info">
-					<strong>Component Directory:</strong> ` + cm.server.config.Dir + `<br>
-					<strong>Kit:</strong> ` + kitName + `
-				</div>
-				<div class="preview-frame" id="preview">
-					<p style="color: #999;">Component preview will appear here...</p>
-				</div>
+				<iframe class="preview-frame" id="preview" title="Component preview"></iframe>
 			</div>
 		</div>
 	</div>
 	<script>
-		const ws = new WebSocket('ws://' + location.host + '/ws');
 		const statusDot = document.getElementById('statusDot');
 		const statusText = document.getElementById('statusText');
+		const componentList = document.getElementById('componentList');
 		const dataEditor = document.getElementById('dataEditor');
 		const preview = document.getElementById('preview');
 		const errorDiv = document.getElementById('error');
+		const info = document.getElementById('info');
 
-		ws.onopen = () => {
-			statusDot.classList.remove('disconnected');
-			statusText.textContent = 'Connected';
-			renderPreview();
-		};
+		let workbench = { components: [], head: '' };
+		let current = decodeURIComponent(location.hash.slice(1));
 
-		ws.onmessage = (event) => {
-			const data = JSON.parse(event.data);
-			if (data.type === 'reload') {
-				console.log('Reloading component...');
-				window.location.reload();
-			}
-		};
+		function connect() {
+			const ws = new WebSocket('ws://' + location.host + '/ws');
+			ws.onopen = () => {
+				statusDot.classList.remove('disconnected');
+				statusText.textContent = 'Connected';
+			};
+			ws.onmessage = (event) => {
+				const data = JSON.parse(event.data);
+				if (data.type !== 'reload') {
+					return;
+				}
+				// Keep the editor contents; just pick up template changes
+				if (data.path && data.path.endsWith('.json')) {
+					return;
+				}
+				loadComponents().then(renderPreview);
+			};
+			ws.onclose = () => {
+				statusDot.classList.add('disconnected');
+				statusText.textContent = 'Disconnected';
+				setTimeout(connect, 1000);
+			};
+		}
 
-		ws.onclose = () => {
-			statusDot.classList.add('disconnected');
-			statusText.textContent = 'Disconnected';
-			setTimeout(() => window.location.reload(), 1000);
-		};
+		async function loadComponents() {
+			const response = await fetch('/components');
+			if (!response.ok) {
+				showError(await response.text());
+				return;
+			}
+			workbench = await response.json();
+			componentList.innerHTML = '';
+			for (const c of workbench.components) {
+				const link = document.createElement('a');
+				link.href = '#' + encodeURIComponent(c.name);
+				link.textContent = c.name;
+				link.className = c.name === current ? 'active' : '';
+				link.onclick = (e) => {
+					e.preventDefault();
+					selectComponent(c.name);
+				};
+				componentList.appendChild(link);
+			}
+			if (!workbench.components.some(c => c.name === current) && workbench.components.length > 0) {
+				await selectComponent(workbench.components[0].name);
+			}
+		}
 
-		dataEditor.addEventListener('input', debounce(renderPreview, 500));
+		async function selectComponent(name) {
+			current = name;
+			history.replaceState(null, '', '#' + encodeURIComponent(name));
+			for (const link of componentList.children) {
+				link.className = link.textContent === name ? 'active' : '';
+			}
+			const c = workbench.components.find(c => c.name === name);
+			info.textContent = c ? c.fixture : '';
 
-		function debounce(func, wait) {
-			let timeout;
-			return function executedFunction(...args) {
-				const later = () => {
-					clearTimeout(timeout);
-					func(...args);
-				};
-				clearTimeout(timeout);
-				timeout = setTimeout(later, wait);
-			};
+			const response = await fetch('/fixture?component=' + encodeURIComponent(name));
+			dataEditor.value = response.ok ? JSON.stringify(await response.json(), null, 2) : '{}';
+			await renderPreview();
 		}
 
 		async function renderPreview() {
+			if (!current) {
+				return;
+			}
 			let data = {};
 			try {
 				const text = dataEditor.value.trim();
@@ -318,26 +814,63 @@ func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 			}
 
 			try {
-				const response = await fetch('/render', {
+				const response = await fetch('/render?component=' + encodeURIComponent(current), {
 					method: 'POST',
 					headers: { 'Content-Type': 'application/json' },
 					body: JSON.stringify(data)
 				});
 
 				if (!response.ok) {
-					const error = await response.text();
-					showError('Render error: ' + error);
+					showError(await response.text());
 					return;
 				}
 
 				const html = await response.text();
-				preview.innerHTML = html;
+				preview.srcdoc = '<!DOCTYPE html><html><head><meta charset="UTF-8">' +
+					'<meta name="viewport" content="width=device-width, initial-scale=1.0">' +
+					workbench.head + '</head><body>' + html + '</body></html>';
 				hideError();
 			} catch (e) {
 				showError('Failed to render: ' + e.message);
 			}
 		}
 
+		document.getElementById('saveFixture').onclick = async () => {
+			const response = await fetch('/fixture?component=' + encodeURIComponent(current), {
+				method: 'POST',
+				headers: { 'Content-Type': 'application/json' },
+				body: dataEditor.value
+			});
+			if (!response.ok) {
+				showError('Save failed: ' + await response.text());
+				return;
+			}
+			hideError();
+		};
+
+		for (const button of document.querySelectorAll('#viewports button')) {
+			button.onclick = () => {
+				for (const b of document.querySelectorAll('#viewports button')) {
+					b.classList.toggle('active', b === button);
+				}
+				preview.style.width = button.dataset.width;
+			};
+		}
+
+		dataEditor.addEventListener('input', debounce(renderPreview, 300));
+
+		function debounce(func, wait) {
+			let timeout;
+			return function executedFunction(...args) {
+				const later = () => {
+					clearTimeout(timeout);
+					func(...args);
+				};
+				clearTimeout(timeout);
+				timeout = setTimeout(later, wait);
+			};
+		}
+
 		function showError(message) {
 			errorDiv.textContent = message;
 			errorDiv.style.display = 'block';
@@ -346,63 +879,9 @@ func (cm *ComponentMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 		function hideError() {
 			errorDiv.style.display = 'none';
 		}
+
+		connect();
+		loadComponents();
 	</script>
 </body>
 </html>`
-
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_, _ = w.Write([]byte(html))
-}
-
-func (cm *ComponentMode) handlePreview(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_, _ = w.Write([]byte("<p>Preview placeholder</p>"))
-}
-
-func (cm *ComponentMode) handleRender(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
-		return
-	}
-	defer r.Body.Close()
-
-	var data map[string]interface{}
-	if len(body) > 0 {
-		if err := json.Unmarshal(body, &data); err != nil {
-			http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
-			return
-		}
-	}
-
-	if err := cm.loadComponent(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to reload component: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	var buf strings.Builder
-	if err := cm.tmpl.Execute(&buf, data); err != nil {
-		http.Error(w, fmt.Sprintf("Template execution error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_, _ = w.Write([]byte(buf.String()))
-}
-
-func (cm *ComponentMode) handleReload(w http.ResponseWriter, r *http.Request) {
-	if err := cm.loadComponent(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to reload: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{
-		"status": "reloaded",
-	})
-}
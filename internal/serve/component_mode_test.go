@@ -0,0 +1,158 @@
+package serve
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestComponentMode(t *testing.T, dir string) *ComponentMode {
+	t.Helper()
+	cm, err := NewComponentMode(&Server{config: &ServerConfig{Dir: dir}})
+	if err != nil {
+		t.Fatalf("NewComponentMode: %v", err)
+	}
+	return cm
+}
+
+func TestComponentMode_DiscoversAppComponents(t *testing.T) {
+	dir := t.TempDir()
+	components := filepath.Join(dir, "app", "components")
+	writeTestFile(t, filepath.Join(components, "badge.tmpl"), `<span class="badge">{{.Label}}</span>`)
+	writeTestFile(t, filepath.Join(components, "card", "templates", "default.tmpl"), `{{define "lvt:card:default:v1"}}<div class="card">{{.Title}}</div>{{end}}`)
+	writeTestFile(t, filepath.Join(components, "card", FixtureFile), `{"Title": "Hello"}`)
+	writeTestFile(t, filepath.Join(components, "empty", "README.md"), "no templates")
+
+	cm := newTestComponentMode(t, dir)
+
+	var names []string
+	for _, c := range cm.Components() {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "badge,card" {
+		t.Fatalf("components = %v, want [badge card]", names)
+	}
+
+	card, _ := cm.component("card")
+	fixture, err := card.LoadFixture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(fixture), "Hello") {
+		t.Errorf("card fixture = %s", fixture)
+	}
+
+	badge, _ := cm.component("badge")
+	if fixture, _ := badge.LoadFixture(); string(fixture) != "{}" {
+		t.Errorf("missing fixture should default to {}, got %s", fixture)
+	}
+	if badge.Fixture != filepath.Join(components, "badge.fixture.json") {
+		t.Errorf("badge fixture path = %s", badge.Fixture)
+	}
+}
+
+func TestComponentMode_RenderAndFixtures(t *testing.T) {
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "app", "components", "card", "card.tmpl")
+	writeTestFile(t, tmplPath, `{{define "card"}}<div class="card">{{.Title}}</div>{{end}}`)
+
+	cm := newTestComponentMode(t, dir)
+	server := httptest.NewServer(cm)
+	defer server.Close()
+
+	render := func(data string) string {
+		t.Helper()
+		resp, err := http.Post(server.URL+"/render?component=card", "application/json", strings.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("render status %d: %s", resp.StatusCode, body)
+		}
+		return string(body)
+	}
+
+	if got := render(`{"Title": "First"}`); got != `<div class="card">First</div>` {
+		t.Errorf("render = %q", got)
+	}
+
+	// Template edits are picked up without restarting
+	writeTestFile(t, tmplPath, `{{define "card"}}<article>{{.Title}}</article>{{end}}`)
+	if got := render(`{"Title": "Second"}`); got != `<article>Second</article>` {
+		t.Errorf("render after edit = %q", got)
+	}
+
+	// Saved fixtures drive the standalone preview
+	resp, err := http.Post(server.URL+"/fixture?component=card", "application/json", strings.NewReader(`{"Title":"Saved"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("save fixture status %d", resp.StatusCode)
+	}
+	saved, err := os.ReadFile(filepath.Join(dir, "app", "components", "card", FixtureFile))
+	if err != nil || !strings.Contains(string(saved), `"Title": "Saved"`) {
+		t.Errorf("fixture file = %q, %v", saved, err)
+	}
+
+	resp, err = http.Get(server.URL + "/preview?component=card")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	page, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(page), "<article>Saved</article>") {
+		t.Errorf("preview should render the saved fixture:\n%s", page)
+	}
+
+	resp, err = http.Post(server.URL+"/fixture?component=card", "application/json", strings.NewReader(`{not json`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid fixture should be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestComponentMode_SingleComponentDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "component.yaml"), "name: alert\ntemplates:\n  - alert.tmpl\n")
+	writeTestFile(t, filepath.Join(dir, "alert.tmpl"), `<div role="alert">[[.Message]]</div>`)
+
+	cm := newTestComponentMode(t, dir)
+	c, ok := cm.component("")
+	if !ok || c.Name != "alert" {
+		t.Fatalf("expected the alert component, got %+v", c)
+	}
+	got, err := cm.Render(c, map[string]string{"Message": "Saved!"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != `<div role="alert">Saved!</div>` {
+		t.Errorf("render = %q", got)
+	}
+}
+
+func TestComponentMode_NoComponents(t *testing.T) {
+	if _, err := NewComponentMode(&Server{config: &ServerConfig{Dir: t.TempDir()}}); err == nil {
+		t.Error("expected an error when there are no components")
+	}
+}
@@ -49,6 +49,13 @@ func (d *ModeDetector) isComponentDirectory(dir string) bool {
 	return false
 }
 
+// hasAppComponents reports whether dir is an app with an app/components
+// directory, which component mode serves as a workbench.
+func (d *ModeDetector) hasAppComponents(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, "app", "components"))
+	return err == nil && info.IsDir()
+}
+
 func (d *ModeDetector) isKitDirectory(dir string) bool {
 	kitYamlPath := filepath.Join(dir, "kit.yaml")
 	if _, err := os.Stat(kitYamlPath); err == nil {
@@ -103,7 +110,7 @@ func (d *ModeDetector) GetModeInfo(mode ServeMode) string {
 func (d *ModeDetector) ValidateMode(mode ServeMode) error {
 	switch mode {
 	case ModeComponent:
-		if !d.isComponentDirectory(d.dir) {
+		if !d.isComponentDirectory(d.dir) && !d.hasAppComponents(d.dir) {
 			return fmt.Errorf("directory is not a valid component directory")
 		}
 	case ModeKit:
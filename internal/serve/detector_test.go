@@ -119,6 +119,14 @@ func TestModeDetector_ValidateMode(t *testing.T) {
 			mode:    ModeComponent,
 			wantErr: false,
 		},
+		{
+			name: "validates component mode for app components",
+			setupFunc: func(dir string) {
+				_ = os.MkdirAll(filepath.Join(dir, "app", "components"), 0755)
+			},
+			mode:    ModeComponent,
+			wantErr: false,
+		},
 		{
 			name: "validates kit mode",
 			setupFunc: func(dir string) {
@@ -0,0 +1,494 @@
+package serve
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/livetemplate/lvt/internal/devlog"
+)
+
+// The development log (see internal/devlog) gets the app's output, the
+// console of its pages and their WebSocket traffic. The proxy tags each
+// request it forwards with an X-Request-ID, which the app's logging
+// middleware logs, so output lines are matched to the session whose
+// request they belong to.
+
+// consoleScriptPath serves the script forwarding a page's console to the
+// log, and consolePath receives what it forwards. Both are same-origin for
+// the pages, so a Content-Security-Policy of 'self' lets them through.
+const (
+	consoleScriptPath = "/__lvt/console.js"
+	consolePath       = "/__lvt/console"
+)
+
+// consoleScript wraps the console's methods and reports uncaught errors,
+// sending what they log in batches.
+const consoleScript = `(() => {
+	const queue = [];
+	let timer = null;
+	const send = () => {
+		timer = null;
+		const batch = queue.splice(0);
+		if (batch.length) navigator.sendBeacon('` + consolePath + `', JSON.stringify(batch));
+	};
+	const format = (arg) => {
+		if (typeof arg === 'string') return arg;
+		if (arg instanceof Error) return arg.stack || String(arg);
+		try { return JSON.stringify(arg); } catch { return String(arg); }
+	};
+	const push = (level, args) => {
+		queue.push({level, msg: args.map(format).join(' '), url: location.pathname + location.search});
+		if (!timer) timer = setTimeout(send, 250);
+	};
+	for (const level of ['debug', 'log', 'info', 'warn', 'error']) {
+		const original = console[level];
+		console[level] = (...args) => {
+			push(level, args);
+			original.apply(console, args);
+		};
+	}
+	addEventListener('error', (e) => push('error', [e.message + ' (' + e.filename + ':' + e.lineno + ')']));
+	addEventListener('unhandledrejection', (e) => push('error', ['Unhandled rejection:', e.reason]));
+	addEventListener('pagehide', send);
+})();
+`
+
+// consoleLevels maps the console's methods to the log's levels.
+var consoleLevels = map[string]string{"debug": "DEBUG", "log": "INFO", "info": "INFO", "warn": "WARN", "error": "ERROR"}
+
+// sessionIndexSize bounds the requests whose session is remembered.
+const sessionIndexSize = 4096
+
+// sessionIndex remembers the session of recent requests by request ID.
+type sessionIndex struct {
+	mu    sync.Mutex
+	ids   map[string]string
+	order []string // ring of the IDs, oldest replaced first
+	next  int
+}
+
+func newSessionIndex() *sessionIndex {
+	return &sessionIndex{ids: make(map[string]string), order: make([]string, sessionIndexSize)}
+}
+
+func (x *sessionIndex) add(requestID, session string) {
+	if requestID == "" || session == "" {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.ids[requestID]; !ok {
+		delete(x.ids, x.order[x.next])
+		x.order[x.next] = requestID
+		x.next = (x.next + 1) % len(x.order)
+	}
+	x.ids[requestID] = session
+}
+
+func (x *sessionIndex) get(requestID string) string {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.ids[requestID]
+}
+
+// sessionOf returns the session a request belongs to, or "".
+func sessionOf(r *http.Request) string {
+	if c, err := r.Cookie(devlog.SessionCookie); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+// captureOutput returns a file for the app to write its stdout or stderr
+// to. Its lines are copied to echo and added to the log, at level when
+// they are not slog records. The caller closes the file once the app has
+// started; the copy ends when every process holding it has exited, so a
+// `go run` child outliving a killed parent does not hold up Wait.
+func (am *AppMode) captureOutput(echo io.Writer, level string) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer r.Close()
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1<<20)
+		for scanner.Scan() {
+			line := scanner.Text()
+			fmt.Fprintln(echo, line)
+			e := devlog.ParseServerLine(line)
+			if !strings.HasPrefix(line, "{") {
+				e.Level = level
+			}
+			if e.Session == "" {
+				if id, ok := e.Attrs["request_id"].(string); ok {
+					e.Session = am.sessions.get(id)
+				}
+			}
+			am.logs.Write(e)
+		}
+	}()
+	return w, nil
+}
+
+// tagRequest gives r a request ID, if it has none, and remembers its
+// session under it.
+func (am *AppMode) tagRequest(r *http.Request) {
+	id := r.Header.Get("X-Request-ID")
+	if id == "" {
+		id = "lvt-" + strconv.FormatInt(am.requests.Add(1), 10)
+		r.Header.Set("X-Request-ID", id)
+	}
+	am.sessions.add(id, sessionOf(r))
+}
+
+// logResponse follows a response of the app to the log: a session started
+// by it, the WebSocket it opens, and the console of the page it serves.
+func (am *AppMode) logResponse(resp *http.Response) error {
+	session := sessionOf(resp.Request)
+	if session == "" {
+		for _, c := range resp.Cookies() {
+			if c.Name == devlog.SessionCookie {
+				session = c.Value
+				am.sessions.add(resp.Request.Header.Get("X-Request-ID"), session)
+			}
+		}
+	}
+
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		if conn, ok := resp.Body.(io.ReadWriteCloser); ok {
+			resp.Body = newWSTap(conn, am.logs, session, resp.Request.URL.RequestURI())
+		}
+		return nil
+	}
+	return injectConsoleScript(resp)
+}
+
+// injectConsoleScript loads the console script in an HTML page, at the end
+// of its head or else of its body.
+func injectConsoleScript(resp *http.Response) error {
+	if resp.Request.Method != http.MethodGet || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") || resp.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	tag := []byte(`<script src="` + consoleScriptPath + `"></script>`)
+	for _, end := range []string{"</head>", "</body>"} {
+		if i := bytes.Index(body, []byte(end)); i >= 0 {
+			body = append(body[:i:i], append(tag, body[i:]...)...)
+			break
+		}
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
+
+func (am *AppMode) handleConsoleScript(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = io.WriteString(w, consoleScript)
+}
+
+// handleConsole adds the console messages a page sends to the log.
+func (am *AppMode) handleConsole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var batch []struct {
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+		URL   string `json:"url"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&batch); err != nil {
+		http.Error(w, "invalid console batch", http.StatusBadRequest)
+		return
+	}
+	session := sessionOf(r)
+	for _, m := range batch {
+		level, ok := consoleLevels[m.Level]
+		if !ok {
+			level = "INFO"
+		}
+		am.logs.Write(devlog.Entry{
+			Source:  devlog.SourceBrowser,
+			Session: session,
+			Level:   level,
+			Message: m.Msg,
+			Attrs:   map[string]any{"url": m.URL},
+		})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// wsPayloadLimit is how much of a WebSocket message is kept to describe it.
+const wsPayloadLimit = 64 * 1024
+
+// wsTap logs the WebSocket messages passing through the proxy's connection
+// to the app: reads come from the app, writes go to it.
+type wsTap struct {
+	io.ReadWriteCloser
+	logs     *devlog.Writer
+	session  string
+	path     string
+	start    time.Time
+	fromApp  frameScanner
+	toApp    frameScanner
+	closed   sync.Once
+	received atomic.Int64 // messages from the browser
+	sent     atomic.Int64 // messages to the browser
+}
+
+func newWSTap(conn io.ReadWriteCloser, logs *devlog.Writer, session, path string) *wsTap {
+	t := &wsTap{ReadWriteCloser: conn, logs: logs, session: session, path: path, start: time.Now()}
+	t.toApp.onMessage = func(m wsMessage) {
+		t.received.Add(1)
+		t.log(describeFromBrowser(m))
+	}
+	t.fromApp.onMessage = func(m wsMessage) {
+		t.sent.Add(1)
+		t.log(describeFromApp(m))
+	}
+	t.log(devlog.Entry{Level: "INFO", Message: "connected", Attrs: map[string]any{"path": path}})
+	return t
+}
+
+func (t *wsTap) Read(p []byte) (int, error) {
+	n, err := t.ReadWriteCloser.Read(p)
+	t.fromApp.feed(p[:n])
+	return n, err
+}
+
+func (t *wsTap) Write(p []byte) (int, error) {
+	t.toApp.feed(p)
+	return t.ReadWriteCloser.Write(p)
+}
+
+func (t *wsTap) Close() error {
+	t.closed.Do(func() {
+		t.log(devlog.Entry{Level: "INFO", Message: "disconnected", Attrs: map[string]any{
+			"path":     t.path,
+			"duration": time.Since(t.start).Round(time.Millisecond).String(),
+			"received": t.received.Load(),
+			"sent":     t.sent.Load(),
+		}})
+	})
+	return t.ReadWriteCloser.Close()
+}
+
+func (t *wsTap) log(e devlog.Entry) {
+	e.Source = devlog.SourceWebSocket
+	e.Session = t.session
+	t.logs.Write(e)
+}
+
+// describeFromBrowser describes a message a page sent: the action it asks
+// for and the names of the fields it sends, not their values.
+func describeFromBrowser(m wsMessage) devlog.Entry {
+	e := devlog.Entry{Level: "INFO", Message: "message", Attrs: map[string]any{"bytes": m.size}}
+	var action struct {
+		Action string         `json:"action"`
+		Data   map[string]any `json:"data"`
+	}
+	if m.text() && json.Unmarshal(m.payload, &action) == nil && action.Action != "" {
+		e.Message = "action " + action.Action
+		if len(action.Data) > 0 {
+			fields := make([]string, 0, len(action.Data))
+			for name := range action.Data {
+				fields = append(fields, name)
+			}
+			sort.Strings(fields)
+			e.Attrs["fields"] = strings.Join(fields, ",")
+		}
+		return e
+	}
+	return describeOther(e, m)
+}
+
+// describeFromApp describes a message the app sent: a tree update, with
+// the action that caused it and its validation errors.
+func describeFromApp(m wsMessage) devlog.Entry {
+	e := devlog.Entry{Level: "INFO", Message: "message", Attrs: map[string]any{"bytes": m.size}}
+	if !m.text() || !bytes.HasPrefix(m.payload, []byte(`{"tree"`)) {
+		return describeOther(e, m)
+	}
+	e.Message = "update"
+	var update struct {
+		Meta *struct {
+			Success bool              `json:"success"`
+			Errors  map[string]string `json:"errors"`
+			Action  string            `json:"action"`
+		} `json:"meta"`
+	}
+	if json.Unmarshal(m.payload, &update) == nil && update.Meta != nil {
+		if update.Meta.Action != "" {
+			e.Attrs["action"] = update.Meta.Action
+		}
+		if len(update.Meta.Errors) > 0 {
+			e.Level = "WARN"
+			e.Attrs["errors"] = update.Meta.Errors
+		}
+	}
+	return e
+}
+
+func describeOther(e devlog.Entry, m wsMessage) devlog.Entry {
+	switch {
+	case m.compressed:
+		e.Message = "message (compressed)"
+	case m.opcode == wsBinary:
+		e.Message = "binary message"
+	}
+	return e
+}
+
+// The WebSocket opcodes the tap tells apart (RFC 6455, section 5.2).
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+)
+
+// wsMessage is a WebSocket data message, with up to wsPayloadLimit bytes
+// of its payload.
+type wsMessage struct {
+	opcode     byte
+	compressed bool // permessage-deflate; the payload is not readable
+	size       int
+	payload    []byte
+}
+
+func (m wsMessage) text() bool {
+	return m.opcode == wsText && !m.compressed && m.size == len(m.payload)
+}
+
+// frameScanner reassembles the WebSocket messages of one direction of a
+// connection from its bytes as they pass, however they are split. Control
+// frames are skipped.
+type frameScanner struct {
+	onMessage func(wsMessage)
+
+	header    []byte // of the frame being read, until complete
+	inFrame   bool
+	remaining uint64 // payload bytes of the frame still to come
+	control   bool
+	fin       bool
+	masked    bool
+	mask      [4]byte
+	maskPos   int
+	message   wsMessage
+}
+
+func (s *frameScanner) feed(p []byte) {
+	for len(p) > 0 {
+		if !s.inFrame {
+			s.header = append(s.header, p[0])
+			p = p[1:]
+			if n := frameHeaderLen(s.header); len(s.header) >= n {
+				s.startFrame()
+			}
+			continue
+		}
+		n := len(p)
+		if uint64(n) > s.remaining {
+			n = int(s.remaining)
+		}
+		if !s.control {
+			for _, b := range p[:n] {
+				if s.masked {
+					b ^= s.mask[s.maskPos%4]
+					s.maskPos++
+				}
+				if len(s.message.payload) < wsPayloadLimit {
+					s.message.payload = append(s.message.payload, b)
+				}
+			}
+			s.message.size += n
+		}
+		p = p[n:]
+		s.remaining -= uint64(n)
+		if s.remaining == 0 {
+			s.endFrame()
+		}
+	}
+}
+
+// frameHeaderLen returns the length of the frame header starting with h,
+// as far as h tells it.
+func frameHeaderLen(h []byte) int {
+	if len(h) < 2 {
+		return 2
+	}
+	n := 2
+	switch h[1] & 0x7f {
+	case 126:
+		n += 2
+	case 127:
+		n += 8
+	}
+	if h[1]&0x80 != 0 {
+		n += 4
+	}
+	return n
+}
+
+func (s *frameScanner) startFrame() {
+	h := s.header
+	s.header = s.header[:0]
+	s.fin = h[0]&0x80 != 0
+	opcode := h[0] & 0x0f
+	s.masked = h[1]&0x80 != 0
+	rest := h[2:]
+	switch h[1] & 0x7f {
+	case 126:
+		s.remaining = uint64(binary.BigEndian.Uint16(rest))
+		rest = rest[2:]
+	case 127:
+		s.remaining = binary.BigEndian.Uint64(rest)
+		rest = rest[8:]
+	default:
+		s.remaining = uint64(h[1] & 0x7f)
+	}
+	if s.masked {
+		copy(s.mask[:], rest)
+	}
+	s.maskPos = 0
+	s.control = opcode >= wsClose
+	if !s.control && opcode != wsContinuation {
+		s.message = wsMessage{opcode: opcode, compressed: h[0]&0x40 != 0}
+	}
+	s.inFrame = true
+	if s.remaining == 0 {
+		s.endFrame()
+	}
+}
+
+func (s *frameScanner) endFrame() {
+	s.inFrame = false
+	if s.control || !s.fin {
+		return
+	}
+	if s.onMessage != nil {
+		s.onMessage(s.message)
+	}
+	s.message = wsMessage{}
+}
@@ -0,0 +1,287 @@
+package serve
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/livetemplate/lvt/internal/devlog"
+)
+
+// frame encodes a WebSocket frame, masked with mask when it is not nil.
+func frame(fin bool, opcode byte, payload []byte, mask []byte) []byte {
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	f := []byte{b0}
+	var maskBit byte
+	if mask != nil {
+		maskBit = 0x80
+	}
+	switch {
+	case len(payload) < 126:
+		f = append(f, maskBit|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		f = append(f, maskBit|126)
+		f = binary.BigEndian.AppendUint16(f, uint16(len(payload)))
+	default:
+		f = append(f, maskBit|127)
+		f = binary.BigEndian.AppendUint64(f, uint64(len(payload)))
+	}
+	if mask == nil {
+		return append(f, payload...)
+	}
+	f = append(f, mask...)
+	for i, b := range payload {
+		f = append(f, b^mask[i%4])
+	}
+	return f
+}
+
+func TestFrameScanner(t *testing.T) {
+	mask := []byte{1, 2, 3, 4}
+	long := strings.Repeat("x", 300)
+	var stream []byte
+	stream = append(stream, frame(true, wsText, []byte(`{"action":"save"}`), mask)...)
+	stream = append(stream, frame(false, wsText, []byte("hel"), nil)...)
+	stream = append(stream, frame(true, 0x9, []byte("ping"), mask)...) // between fragments
+	stream = append(stream, frame(true, wsContinuation, []byte("lo"), nil)...)
+	stream = append(stream, frame(true, wsText, []byte(long), mask)...)
+	stream = append(stream, frame(true, wsBinary, nil, nil)...)
+
+	for _, chunk := range []int{len(stream), 7, 1} {
+		var got []wsMessage
+		s := frameScanner{onMessage: func(m wsMessage) { got = append(got, m) }}
+		for p := stream; len(p) > 0; {
+			n := min(chunk, len(p))
+			s.feed(p[:n])
+			p = p[n:]
+		}
+		if len(got) != 4 {
+			t.Fatalf("chunks of %d: got %d messages, want 4", chunk, len(got))
+		}
+		for i, want := range []string{`{"action":"save"}`, "hello", long, ""} {
+			if string(got[i].payload) != want || got[i].size != len(want) {
+				t.Errorf("chunks of %d: message %d = %q (%d bytes), want %q", chunk, i, got[i].payload, got[i].size, want)
+			}
+		}
+		if !got[2].text() || got[3].opcode != wsBinary {
+			t.Errorf("chunks of %d: opcodes %d and %d", chunk, got[2].opcode, got[3].opcode)
+		}
+	}
+}
+
+func TestDescribeWebSocketMessages(t *testing.T) {
+	e := describeFromBrowser(wsMessage{opcode: wsText, size: 47, payload: []byte(`{"action":"save","data":{"title":"","done":true}}`)[:47]})
+	if e.Message != "message" {
+		t.Errorf("a truncated message described as %q", e.Message)
+	}
+	payload := []byte(`{"action":"save","data":{"title":"x","done":true}}`)
+	e = describeFromBrowser(wsMessage{opcode: wsText, size: len(payload), payload: payload})
+	if e.Message != "action save" || e.Attrs["fields"] != "done,title" {
+		t.Errorf("action described as %q %v", e.Message, e.Attrs)
+	}
+
+	payload = []byte(`{"tree":{"0":"x"},"meta":{"success":false,"errors":{"title":"required"},"action":"save"}}`)
+	e = describeFromApp(wsMessage{opcode: wsText, size: len(payload), payload: payload})
+	if e.Message != "update" || e.Level != "WARN" || e.Attrs["action"] != "save" {
+		t.Errorf("update described as %s %q %v", e.Level, e.Message, e.Attrs)
+	}
+	if e = describeFromApp(wsMessage{opcode: wsText, compressed: true, size: 10}); e.Message != "message (compressed)" {
+		t.Errorf("compressed message described as %q", e.Message)
+	}
+}
+
+func TestInjectConsoleScript(t *testing.T) {
+	tag := `<script src="` + consoleScriptPath + `"></script>`
+	tests := []struct {
+		method, contentType, encoding, body, want string
+	}{
+		{"GET", "text/html; charset=utf-8", "", "<html><head></head><body></body></html>", "<html><head>" + tag + "</head><body></body></html>"},
+		{"GET", "text/html", "", "<body><p>x</p></body>", "<body><p>x</p>" + tag + "</body>"},
+		{"GET", "text/html", "gzip", "<head></head>", "<head></head>"},
+		{"GET", "application/json", "", `{"head":"</head>"}`, `{"head":"</head>"}`},
+		{"POST", "text/html", "", "<head></head>", "<head></head>"},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{
+			Request: httptest.NewRequest(tt.method, "/", nil),
+			Header:  http.Header{"Content-Type": {tt.contentType}},
+			Body:    io.NopCloser(strings.NewReader(tt.body)),
+		}
+		if tt.encoding != "" {
+			resp.Header.Set("Content-Encoding", tt.encoding)
+		}
+		if err := injectConsoleScript(resp); err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != tt.want {
+			t.Errorf("%s %s %q: body = %q, want %q", tt.method, tt.contentType, tt.encoding, body, tt.want)
+		}
+	}
+}
+
+func TestSessionIndex(t *testing.T) {
+	x := newSessionIndex()
+	x.add("lvt-1", "s1")
+	x.add("lvt-1", "s1") // again, without taking a second slot
+	for i := 2; i <= sessionIndexSize; i++ {
+		x.add("r"+strconv.Itoa(i), "s")
+	}
+	if x.get("lvt-1") != "s1" {
+		t.Fatal("request forgotten before the index was full")
+	}
+	x.add("one-more", "s2")
+	if x.get("lvt-1") != "" || x.get("one-more") != "s2" {
+		t.Error("the oldest request should make room for the newest")
+	}
+}
+
+// readLog returns the entries of the log at root.
+func readLog(t *testing.T, root string) []devlog.Entry {
+	t.Helper()
+	var entries []devlog.Entry
+	if err := devlog.Read(root, devlog.Filter{}, func(e devlog.Entry) { entries = append(entries, e) }); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestHandleConsole(t *testing.T) {
+	root := t.TempDir()
+	logs, err := devlog.Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	am := &AppMode{logs: logs, sessions: newSessionIndex()}
+
+	req := httptest.NewRequest(http.MethodPost, consolePath, strings.NewReader(`[{"level":"warn","msg":"careful","url":"/tasks"},{"level":"trace","msg":"odd"}]`))
+	req.AddCookie(&http.Cookie{Name: devlog.SessionCookie, Value: "sess-1"})
+	rec := httptest.NewRecorder()
+	am.handleConsole(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("POST status = %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	am.handleConsole(rec, httptest.NewRequest(http.MethodPost, consolePath, strings.NewReader("nope")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid batch status = %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	am.handleConsole(rec, httptest.NewRequest(http.MethodGet, consolePath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d", rec.Code)
+	}
+	logs.Close()
+
+	entries := readLog(t, root)
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(entries))
+	}
+	if e := entries[0]; e.Source != devlog.SourceBrowser || e.Session != "sess-1" || e.Level != "WARN" || e.Message != "careful" || e.Attrs["url"] != "/tasks" {
+		t.Errorf("entry = %+v", e)
+	}
+	if entries[1].Level != "INFO" {
+		t.Errorf("unknown console level logged as %s", entries[1].Level)
+	}
+}
+
+// TestAppModeProxyLog runs a WebSocket and a page through the proxy AppMode
+// sets up and checks what reaches the log.
+func TestAppModeProxyLog(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/page" {
+			http.SetCookie(w, &http.Cookie{Name: devlog.SessionCookie, Value: "fresh-session"})
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = io.WriteString(w, "<html><head></head></html>")
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"tree":{},"meta":{"success":false,"errors":{"title":"required"},"action":"save"}}`))
+		_, _, _ = conn.ReadMessage() // until the browser closes
+	}))
+	defer app.Close()
+
+	root := t.TempDir()
+	logs, err := devlog.Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, _ := url.Parse(app.URL)
+	am := &AppMode{logs: logs, sessions: newSessionIndex()}
+	am.proxy = httputil.NewSingleHostReverseProxy(target)
+	director := am.proxy.Director
+	am.proxy.Director = func(r *http.Request) {
+		director(r)
+		am.tagRequest(r)
+	}
+	am.proxy.ModifyResponse = am.logResponse
+	proxy := httptest.NewServer(am.proxy)
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL + "/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), consoleScriptPath) {
+		t.Errorf("page served without the console script: %s", body)
+	}
+	if am.sessions.get("lvt-1") != "fresh-session" {
+		t.Error("the session a response starts should be remembered for its request")
+	}
+
+	header := http.Header{"Cookie": {devlog.SessionCookie + "=sess-2"}}
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(proxy.URL, "http")+"/live", header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"action":"save","data":{"title":""}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := conn.ReadMessage(); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	var entries []devlog.Entry
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if entries = readLog(t, root); len(entries) == 4 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	logs.Close()
+
+	var got []string
+	for _, e := range entries {
+		if e.Source != devlog.SourceWebSocket || e.Session != "sess-2" {
+			t.Errorf("entry of %s/%s, want ws/sess-2", e.Source, e.Session)
+		}
+		got = append(got, e.Level+" "+e.Message)
+	}
+	want := "INFO connected|INFO action save|WARN update|INFO disconnected"
+	if strings.Join(got, "|") != want {
+		t.Errorf("logged %v, want %s", got, want)
+	}
+}
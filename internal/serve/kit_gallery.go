@@ -0,0 +1,552 @@
+package serve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/livetemplate/livetemplate"
+	"github.com/livetemplate/lvt/components/modal"
+	"github.com/livetemplate/lvt/components/toast"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+)
+
+// galleryMaxItems is the most sample records the ItemCount prop can ask for.
+const galleryMaxItems = 20
+
+// galleryContentDefine is where the layout renders the page body; the
+// gallery points it at the one component being previewed.
+const galleryContentDefine = `{{define "content"}}`
+
+// galleryHiddenTemplates are page plumbing rather than components.
+var galleryHiddenTemplates = map[string]bool{
+	"layout":       true,
+	"content":      true,
+	"pageRouting":  true,
+	"formContent":  true,
+	"tableContent": true,
+}
+
+// GalleryComponent is a kit component file and the templates it defines.
+type GalleryComponent struct {
+	Name      string   `json:"name"`
+	Templates []string `json:"templates"`
+}
+
+// KitGallery renders a kit's components one at a time, inside the kit's
+// own layout, with sample data the props panel can change.
+type KitGallery struct {
+	Components []GalleryComponent
+
+	dir          string // holds the generated page template and renders
+	source       string // generated page template, assembled from every component
+	state        map[string]interface{}
+	items        []map[string]interface{}
+	cssFramework string
+	kit          *kits.KitInfo
+	templates    map[string]bool
+}
+
+// generateKitGallery generates the sample resource from the components of
+// the kit staged in dir (see generateKitSample) and collects the templates
+// each component defines.
+func generateKitGallery(kitName, dir string) (*KitGallery, error) {
+	kitsDir := filepath.Join(dir, "kits")
+	loader := kits.DefaultLoader()
+	loader.AddSearchPath(kitsDir)
+	kit, err := loader.Load(kitName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kit: %w", err)
+	}
+	cssFramework, styles := sampleFramework(kit)
+
+	appDir := filepath.Join(dir, "gallery")
+	if err := os.MkdirAll(filepath.Join(appDir, "database", "migrations"), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "database", "schema.sql"), nil, 0644); err != nil {
+		return nil, err
+	}
+	fields, err := fieldparser.ParseFields(sampleFieldSpecs)
+	if err != nil {
+		return nil, err
+	}
+	if err := generator.GenerateResource(appDir, "sample", sampleResource, fields, kitName, cssFramework, styles,
+		"prev-next", 5, "modal", "", false, false,
+		generator.ResourceOptions{KitPaths: []string{kitsDir}, AssembleComponents: true}); err != nil {
+		return nil, fmt.Errorf("failed to generate the gallery resource: %w", err)
+	}
+
+	resourceDir := filepath.Join(appDir, "app", sampleResource)
+	source, err := os.ReadFile(filepath.Join(resourceDir, sampleResource+".tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(string(source), galleryContentDefine) {
+		return nil, fmt.Errorf("the page template defines no \"content\" for the gallery to render components into")
+	}
+	state, err := sampleState(filepath.Join(resourceDir, sampleResource+".go"), fields, cssFramework)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &KitGallery{
+		dir:          appDir,
+		source:       string(source),
+		state:        state,
+		items:        sampleItems(fields, galleryMaxItems),
+		cssFramework: cssFramework,
+		kit:          kit,
+		templates:    make(map[string]bool),
+	}
+	generated := make(map[string]bool)
+	for _, m := range defineRe.FindAllStringSubmatch(g.source, -1) {
+		generated[m[1]] = true
+	}
+	for _, file := range generator.ResourceComponents {
+		data, err := loader.LoadKitComponent(kitName, file)
+		if err != nil {
+			continue
+		}
+		component := GalleryComponent{Name: strings.TrimSuffix(file, ".tmpl")}
+		for _, m := range defineRe.FindAllStringSubmatch(string(data), -1) {
+			// Templates the kit leaves out for this resource aren't generated
+			if name := m[1]; generated[name] && !galleryHiddenTemplates[name] && !g.templates[name] {
+				component.Templates = append(component.Templates, name)
+				g.templates[name] = true
+			}
+		}
+		if len(component.Templates) > 0 {
+			g.Components = append(g.Components, component)
+		}
+	}
+	return g, nil
+}
+
+// Props returns the sample values the props panel can change: the scalar
+// fields of the page state and ItemCount, the number of sample records.
+func (g *KitGallery) Props() map[string]interface{} {
+	props := map[string]interface{}{"ItemCount": 3}
+	for name, value := range g.state {
+		switch value.(type) {
+		case string, int, bool:
+			if name != "CSSFramework" {
+				props[name] = value
+			}
+		}
+	}
+	props["EditingID"] = g.items[0]["ID"]
+	return props
+}
+
+// PropNames returns the names of Props, sorted.
+func (g *KitGallery) PropNames() []string {
+	props := g.Props()
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render renders one component template inside the kit's layout, with
+// props (as decoded from JSON) overriding the sample values.
+func (g *KitGallery) Render(name string, props map[string]interface{}) (string, error) {
+	if !g.templates[name] {
+		return "", fmt.Errorf("unknown component template %q", name)
+	}
+
+	state, err := g.renderState(props)
+	if err != nil {
+		return "", err
+	}
+
+	source := strings.Replace(g.source, galleryContentDefine,
+		galleryContentDefine+`{{template "`+name+`" .}}{{end}}{{define "lvtGalleryPageContent"}}`, 1)
+	renderDir, err := os.MkdirTemp(g.dir, "render-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(renderDir)
+	tmplPath := filepath.Join(renderDir, sampleResource+".tmpl")
+	if err := os.WriteFile(tmplPath, []byte(source), 0644); err != nil {
+		return "", err
+	}
+
+	tmpl, err := livetemplate.New(sampleResource,
+		livetemplate.WithParseFiles(tmplPath),
+		livetemplate.WithComponentTemplates(modal.Templates(), toast.Templates()),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the page template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, state); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+
+	// Show dialogs (the add modal) instead of leaving them closed
+	page := strings.Replace(withKitCDN(g.kit, buf.String()), "</body>",
+		`<script>document.querySelectorAll("dialog:not([open])").forEach(function(d) { d.show(); });</script>
+</body>`, 1)
+	return page, nil
+}
+
+// renderState copies the sample state, applying props. JSON numbers are
+// converted to the type of the value they replace.
+func (g *KitGallery) renderState(props map[string]interface{}) (map[string]interface{}, error) {
+	defaults := g.Props()
+	values := make(map[string]interface{}, len(defaults))
+	for name, value := range defaults {
+		values[name] = value
+	}
+	for name, value := range props {
+		def, ok := defaults[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown prop %q", name)
+		}
+		switch def.(type) {
+		case int:
+			n, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("prop %s must be a number", name)
+			}
+			values[name] = int(n)
+		case bool:
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("prop %s must be true or false", name)
+			}
+			values[name] = b
+		default:
+			values[name] = fmt.Sprint(value)
+		}
+	}
+
+	count := min(max(values["ItemCount"].(int), 0), galleryMaxItems)
+	items := g.items[:count]
+
+	state := make(map[string]interface{}, len(g.state))
+	for name, value := range g.state {
+		switch {
+		case isItemSlice(value):
+			state[name] = items
+		case strings.HasPrefix(name, "Editing") && name != "EditingID":
+			// The record the edit form and detail page show
+			state[name] = nil
+			for _, item := range g.items {
+				if item["ID"] == values["EditingID"] {
+					state[name] = item
+				}
+			}
+		default:
+			state[name] = value
+		}
+	}
+	for name, value := range values {
+		if name != "ItemCount" {
+			state[name] = value
+		}
+	}
+	state["CSSFramework"] = g.cssFramework
+	return state, nil
+}
+
+func isItemSlice(value interface{}) bool {
+	_, ok := value.([]map[string]interface{})
+	return ok
+}
+
+// handleGallery serves the component gallery page.
+func (km *KitMode) handleGallery(w http.ResponseWriter, r *http.Request) {
+	manifest := km.currentKit().Manifest
+	sample := km.Sample()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if sample == nil || sample.Gallery == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		report := "Component gallery has not been built"
+		if sample != nil {
+			report = sample.Validation.Format()
+		}
+		fmt.Fprintf(w, `<!DOCTYPE html><html><body><pre>%s</pre>%s</body></html>`, html.EscapeString(report), galleryReloadScript)
+		return
+	}
+
+	gallery := sample.Gallery
+	components, err := json.Marshal(gallery.Components)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := json.Marshal(gallery.Props())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	propNames, err := json.Marshal(gallery.PropNames())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	page := strings.NewReplacer(
+		"{{KIT}}", html.EscapeString(manifest.Name),
+		"{{COMPONENTS}}", string(components),
+		"{{PROPS}}", string(props),
+		"{{PROP_NAMES}}", string(propNames),
+		"{{RELOAD}}", galleryReloadScript,
+	).Replace(galleryPage)
+	if _, err := w.Write([]byte(page)); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+// handleGalleryRender renders one component template:
+// /gallery/render?template=<name>&props=<JSON object>.
+func (km *KitMode) handleGalleryRender(w http.ResponseWriter, r *http.Request) {
+	sample := km.Sample()
+	if sample == nil || sample.Gallery == nil {
+		http.Error(w, "Component gallery has not been built", http.StatusServiceUnavailable)
+		return
+	}
+
+	props := map[string]interface{}{}
+	if raw := r.URL.Query().Get("props"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &props); err != nil {
+			http.Error(w, fmt.Sprintf("invalid props: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	page, err := sample.Gallery.Render(r.URL.Query().Get("template"), props)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `<pre style="color: #c0392b; white-space: pre-wrap;">%s</pre>`, html.EscapeString(err.Error()))
+		return
+	}
+	if _, err := w.Write([]byte(page)); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+// galleryReloadScript reloads the page when the kit changes; the view
+// (component, viewports, props) is kept in the URL hash.
+const galleryReloadScript = `<script>
+	(function connect() {
+		const ws = new WebSocket('ws://' + location.host + '/ws');
+		ws.onmessage = (event) => {
+			if (JSON.parse(event.data).type === 'reload') window.location.reload();
+		};
+		ws.onclose = () => setTimeout(() => window.location.reload(), 1000);
+	})();
+</script>`
+
+const galleryPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>Component Gallery - {{KIT}}</title>
+	<style>
+		* { margin: 0; padding: 0; box-sizing: border-box; }
+		body {
+			font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif;
+			height: 100vh;
+			display: flex;
+			flex-direction: column;
+		}
+		.header {
+			background: #2c3e50;
+			color: white;
+			padding: 1rem 2rem;
+			display: flex;
+			justify-content: space-between;
+			align-items: center;
+			border-bottom: 3px solid #9b59b6;
+		}
+		.header h1 { font-size: 1.5rem; font-weight: 600; }
+		.header a { color: white; }
+		.viewports { display: flex; gap: 1rem; font-size: 0.9rem; }
+		.container { display: flex; flex: 1; overflow: hidden; }
+		.sidebar {
+			width: 300px;
+			background: #f8f9fa;
+			border-right: 1px solid #ddd;
+			overflow-y: auto;
+		}
+		.section { padding: 1rem; border-bottom: 1px solid #ddd; }
+		.section h3 {
+			font-size: 0.85rem;
+			text-transform: uppercase;
+			color: #7f8c8d;
+			margin-bottom: 0.5rem;
+		}
+		.component-list { list-style: none; }
+		.component-list button {
+			width: 100%;
+			text-align: left;
+			padding: 0.4rem 0.5rem;
+			margin: 0.15rem 0;
+			background: white;
+			border: 1px solid #ddd;
+			border-radius: 4px;
+			cursor: pointer;
+		}
+		.component-list button.active { border-color: #9b59b6; background: #f4ecf7; }
+		.component-list small { color: #7f8c8d; display: block; font-family: 'Monaco', 'Menlo', 'Consolas', monospace; }
+		.prop { display: block; margin: 0.4rem 0; font-size: 0.85rem; }
+		.prop span { display: block; font-family: 'Monaco', 'Menlo', 'Consolas', monospace; color: #2c3e50; }
+		.prop input[type=text], .prop input[type=number] {
+			width: 100%;
+			padding: 0.25rem 0.4rem;
+			border: 1px solid #ccc;
+			border-radius: 4px;
+		}
+		.main { flex: 1; overflow: auto; padding: 1.5rem; }
+		.template { margin-bottom: 2rem; }
+		.template h2 { font-size: 1.1rem; margin-bottom: 0.75rem; font-family: 'Monaco', 'Menlo', 'Consolas', monospace; }
+		.frames { display: flex; gap: 1.5rem; align-items: flex-start; overflow-x: auto; padding-bottom: 0.5rem; }
+		.frame-label { font-size: 0.8rem; color: #7f8c8d; margin-bottom: 0.25rem; }
+		.frames iframe { border: 1px solid #bdc3c7; border-radius: 4px; background: white; height: 200px; }
+	</style>
+</head>
+<body>
+	<div class="header">
+		<h1>Component Gallery - {{KIT}}</h1>
+		<div class="viewports" id="viewports"></div>
+		<a href="/">Kit overview</a>
+	</div>
+	<div class="container">
+		<div class="sidebar">
+			<div class="section">
+				<h3>Components</h3>
+				<ul class="component-list" id="components"></ul>
+			</div>
+			<div class="section">
+				<h3>Props</h3>
+				<form id="props"></form>
+				<button type="button" id="resetProps">Reset</button>
+			</div>
+		</div>
+		<div class="main" id="main"></div>
+	</div>
+	<script>
+		const COMPONENTS = {{COMPONENTS}};
+		const DEFAULT_PROPS = {{PROPS}};
+		const PROP_NAMES = {{PROP_NAMES}};
+		const VIEWPORTS = [
+			{ name: 'mobile', label: 'Mobile', width: 375 },
+			{ name: 'tablet', label: 'Tablet', width: 768 },
+			{ name: 'desktop', label: 'Desktop', width: 1280 },
+		];
+
+		// The view lives in the hash so reloads on kit changes keep it
+		let view = { component: 'all', viewports: ['desktop'], props: {} };
+		try {
+			if (location.hash.length > 1) view = Object.assign(view, JSON.parse(decodeURIComponent(location.hash.slice(1))));
+		} catch (e) {}
+
+		function save() {
+			history.replaceState(null, '', '#' + encodeURIComponent(JSON.stringify(view)));
+		}
+
+		function escapeHTML(s) {
+			const div = document.createElement('div');
+			div.textContent = s;
+			return div.innerHTML;
+		}
+
+		function renderViewports() {
+			document.getElementById('viewports').innerHTML = VIEWPORTS.map(v =>
+				'<label><input type="checkbox" value="' + v.name + '"' + (view.viewports.includes(v.name) ? ' checked' : '') + '> ' +
+				v.label + ' (' + v.width + 'px)</label>'
+			).join('');
+			document.querySelectorAll('#viewports input').forEach(input => input.addEventListener('change', () => {
+				view.viewports = Array.from(document.querySelectorAll('#viewports input:checked')).map(i => i.value);
+				save();
+				renderFrames();
+			}));
+		}
+
+		function renderComponents() {
+			const entries = [{ name: 'all', templates: ['every component'] }].concat(COMPONENTS);
+			document.getElementById('components').innerHTML = entries.map(c =>
+				'<li><button type="button" data-component="' + c.name + '"' + (c.name === view.component ? ' class="active"' : '') + '>' +
+				escapeHTML(c.name) + '<small>' + escapeHTML(c.templates.join(', ')) + '</small></button></li>'
+			).join('');
+			document.querySelectorAll('#components button').forEach(button => button.addEventListener('click', () => {
+				view.component = button.dataset.component;
+				save();
+				renderComponents();
+				renderFrames();
+			}));
+		}
+
+		function renderProps() {
+			const props = Object.assign({}, DEFAULT_PROPS, view.props);
+			document.getElementById('props').innerHTML = PROP_NAMES.map(name => {
+				const value = props[name];
+				if (typeof value === 'boolean') {
+					return '<label class="prop"><input type="checkbox" name="' + name + '"' + (value ? ' checked' : '') + '> ' + name + '</label>';
+				}
+				const type = typeof value === 'number' ? 'number' : 'text';
+				return '<label class="prop"><span>' + name + '</span><input type="' + type + '" name="' + name + '" value="' + escapeHTML(String(value)) + '"></label>';
+			}).join('');
+		}
+
+		document.getElementById('props').addEventListener('change', (event) => {
+			const input = event.target;
+			let value = input.type === 'checkbox' ? input.checked : input.value;
+			if (input.type === 'number') value = Number(value);
+			if (value === DEFAULT_PROPS[input.name]) delete view.props[input.name]; else view.props[input.name] = value;
+			save();
+			renderFrames();
+		});
+		document.getElementById('resetProps').addEventListener('click', () => {
+			view.props = {};
+			save();
+			renderProps();
+			renderFrames();
+		});
+
+		function renderFrames() {
+			const templates = [];
+			COMPONENTS.forEach(c => {
+				if (view.component === 'all' || view.component === c.name) templates.push(...c.templates);
+			});
+			const props = encodeURIComponent(JSON.stringify(view.props));
+			const viewports = VIEWPORTS.filter(v => view.viewports.includes(v.name));
+			document.getElementById('main').innerHTML = templates.map(t =>
+				'<div class="template"><h2>' + escapeHTML(t) + '</h2><div class="frames">' +
+				viewports.map(v =>
+					'<div><div class="frame-label">' + v.label + ' · ' + v.width + 'px</div>' +
+					'<iframe title="' + escapeHTML(t) + ' (' + v.label + ')" style="width: ' + v.width + 'px" ' +
+					'src="/gallery/render?template=' + encodeURIComponent(t) + '&props=' + props + '"></iframe></div>'
+				).join('') +
+				'</div></div>'
+			).join('') || '<p>Pick at least one viewport.</p>';
+			document.querySelectorAll('#main iframe').forEach(frame => frame.addEventListener('load', () => {
+				// Fit the frame to the rendered component
+				const doc = frame.contentDocument;
+				if (doc) frame.style.height = Math.min(Math.max(doc.documentElement.scrollHeight, 80), 1200) + 'px';
+			}));
+		}
+
+		renderViewports();
+		renderComponents();
+		renderProps();
+		renderFrames();
+	</script>
+	{{RELOAD}}
+</body>
+</html>`
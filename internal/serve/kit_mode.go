@@ -1,20 +1,26 @@
 package serve
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"html"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/livetemplate/lvt/internal/kits"
 )
 
 type KitMode struct {
 	server *Server
+	mu     sync.RWMutex
 	kit    *kits.KitInfo
+	sample *KitSample
 }
 
 func NewKitMode(s *Server) (*KitMode, error) {
@@ -25,6 +31,7 @@ func NewKitMode(s *Server) (*KitMode, error) {
 	if err := km.loadKit(); err != nil {
 		return nil, err
 	}
+	km.rebuildSample()
 
 	return km, nil
 }
@@ -45,24 +52,117 @@ func (km *KitMode) loadKit() error {
 		return fmt.Errorf("failed to load kit: %w", err)
 	}
 
+	km.mu.Lock()
 	km.kit = kit
+	km.mu.Unlock()
 	log.Printf("Kit loaded: %s (version: %s)", kit.Manifest.Name, kit.Manifest.Version)
 	return nil
 }
 
+func (km *KitMode) currentKit() *kits.KitInfo {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return km.kit
+}
+
+// Sample returns the most recently built sample app.
+func (km *KitMode) Sample() *KitSample {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return km.sample
+}
+
+// rebuildSample regenerates the sample app against the kit and re-runs kit
+// validation, replacing (and removing) the previous sample.
+func (km *KitMode) rebuildSample() {
+	sample, err := buildKitSample(km.server.config.Dir)
+	if err != nil {
+		log.Printf("Error: Failed to build sample app: %v", err)
+		return
+	}
+
+	result := sample.Validation
+	if result.HasErrors() {
+		log.Printf("Kit validation: %d error(s), %d warning(s)\n%s", result.ErrorCount(), result.WarningCount(), result.Format())
+	} else {
+		log.Printf("Kit validation passed (%d warning(s)); sample app rebuilt", result.WarningCount())
+	}
+
+	km.mu.Lock()
+	previous := km.sample
+	km.sample = sample
+	km.mu.Unlock()
+
+	if previous != nil {
+		os.RemoveAll(previous.Dir)
+	}
+}
+
+// HandleFileChange reloads the kit and rebuilds the sample app so the next
+// page reload shows the kit's real output.
+func (km *KitMode) HandleFileChange(path string) {
+	if err := km.loadKit(); err != nil {
+		log.Printf("Error: Failed to reload kit: %v", err)
+	}
+	km.rebuildSample()
+}
+
+// Stop removes the sample app's temporary directory.
+func (km *KitMode) Stop() {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if km.sample != nil {
+		os.RemoveAll(km.sample.Dir)
+		km.sample = nil
+	}
+}
+
 func (km *KitMode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.URL.Path {
 	case "/test":
 		km.handleTest(w, r)
 	case "/helpers":
 		km.handleHelpers(w, r)
+	case "/sample":
+		km.handleSample(w, r)
+	case "/validation":
+		km.handleValidation(w, r)
+	case "/gallery":
+		km.handleGallery(w, r)
+	case "/gallery/render":
+		km.handleGalleryRender(w, r)
 	default:
+		if strings.HasPrefix(r.URL.Path, kits.AssetsURLPrefix) {
+			km.handleAsset(w, r)
+			return
+		}
 		km.handleIndex(w, r)
 	}
 }
 
+// handleAsset serves the kit's assets at the URLs the generated layout
+// uses, /assets/<kit>/<path>, so the sample and gallery load them.
+func (km *KitMode) handleAsset(w http.ResponseWriter, r *http.Request) {
+	kit := km.currentKit()
+	name := strings.TrimPrefix(r.URL.Path, kits.AssetsURLPrefix+kit.Manifest.Name+"/")
+	assets, err := kit.Assets()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, a := range assets {
+		if a.Path == name {
+			// The kit is being edited; never cache
+			w.Header().Set("Cache-Control", "no-cache")
+			http.ServeContent(w, r, a.Path, time.Time{}, bytes.NewReader(a.Data))
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
 func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
-	manifest := km.kit.Manifest
+	manifest := km.currentKit().Manifest
 
 	html := `<!DOCTYPE html>
 <html lang="en">
@@ -182,11 +282,38 @@ func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 		.test-card-body {
 			padding: 1rem;
 		}
+		.sample-frame {
+			flex: 2;
+			width: 100%;
+			min-height: 0;
+			border: 0;
+			border-bottom: 1px solid #bdc3c7;
+		}
+		.issue-list {
+			list-style: none;
+			font-size: 0.85rem;
+		}
+		.issue {
+			padding: 0.4rem 0.5rem;
+			margin: 0.25rem 0;
+			border-left: 3px solid #95a5a6;
+			background: white;
+		}
+		.issue.error { border-left-color: #e74c3c; }
+		.issue.warning { border-left-color: #f39c12; }
+		.issue.ok { border-left-color: #2ecc71; }
+		.issue-file {
+			display: block;
+			color: #7f8c8d;
+			font-family: 'Monaco', 'Menlo', 'Consolas', monospace;
+			font-size: 0.75rem;
+		}
 	</style>
 </head>
 <body>
 	<div class="header">
 		<h1>Kit Development - ` + manifest.Name + `</h1>
+		<a href="/gallery" style="color: white;">Component Gallery</a>
 		<div class="status">
 			<div class="status-dot" id="statusDot"></div>
 			<span id="statusText">Connected</span>
@@ -213,6 +340,12 @@ func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 					<div>` + manifest.Author + `</div>
 				</div>
 			</div>
+			<div class="info-section">
+				<h3>Validation</h3>
+				<ul class="issue-list" id="validationList">
+					<li class="issue">Loading...</li>
+				</ul>
+			</div>
 			<div class="info-section">
 				<h3>Helper Methods</h3>
 				<ul class="helper-list" id="helpersList">
@@ -221,6 +354,8 @@ func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 			</div>
 		</div>
 		<div class="main-panel">
+			<div class="panel-header">Sample App <small id="builtAt"></small></div>
+			<iframe class="sample-frame" id="sampleFrame" src="/sample" title="Sample app"></iframe>
 			<div class="panel-header">Component Examples</div>
 			<div class="panel-content">
 				<div class="test-grid" id="testGrid">
@@ -238,6 +373,7 @@ func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 			statusDot.classList.remove('disconnected');
 			statusText.textContent = 'Connected';
 			loadHelpers();
+			loadValidation();
 		};
 
 		ws.onmessage = (event) => {
@@ -266,6 +402,35 @@ func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 				console.error('Failed to load helpers:', e);
 			}
 		}
+
+		function escapeHTML(s) {
+			const div = document.createElement('div');
+			div.textContent = s;
+			return div.innerHTML;
+		}
+
+		async function loadValidation() {
+			try {
+				const response = await fetch('/validation');
+				const report = await response.json();
+				const list = document.getElementById('validationList');
+				const issues = report.issues.filter(i => i.level !== 'info');
+				if (report.built_at) {
+					document.getElementById('builtAt').textContent = '(built ' + new Date(report.built_at).toLocaleTimeString() + ')';
+				}
+				if (issues.length === 0) {
+					list.innerHTML = '<li class="issue ok">Kit is valid</li>';
+					return;
+				}
+				list.innerHTML = issues.map(i =>
+					'<li class="issue ' + i.level + '">' + escapeHTML(i.message) +
+					(i.file ? '<span class="issue-file">' + escapeHTML(i.file) + (i.line ? ':' + i.line : '') + '</span>' : '') +
+					'</li>'
+				).join('');
+			} catch (e) {
+				console.error('Failed to load validation:', e);
+			}
+		}
 	</script>
 </body>
 </html>`
@@ -277,7 +442,7 @@ func (km *KitMode) handleIndex(w http.ResponseWriter, r *http.Request) {
 }
 
 func (km *KitMode) generateTestCards() string {
-	helpers := km.kit.Helpers
+	helpers := km.currentKit().Helpers
 
 	// If kit doesn't have helpers (custom kits), show a message
 	if helpers == nil {
@@ -355,6 +520,66 @@ func (km *KitMode) handleHelpers(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(helpers)
 }
 
+// handleSample serves the rendered sample app page, or the validation
+// report when the sample could not be generated.
+func (km *KitMode) handleSample(w http.ResponseWriter, r *http.Request) {
+	sample := km.Sample()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if sample == nil || sample.HTML == "" {
+		w.WriteHeader(http.StatusInternalServerError)
+		report := "Sample app has not been built"
+		if sample != nil {
+			report = sample.Validation.Format()
+		}
+		fmt.Fprintf(w, "<pre>%s</pre>", html.EscapeString(report))
+		return
+	}
+	if _, err := w.Write([]byte(sample.HTML)); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+type validationIssue struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+type validationReport struct {
+	Valid   bool              `json:"valid"`
+	Errors  int               `json:"errors"`
+	BuiltAt string            `json:"built_at"`
+	Issues  []validationIssue `json:"issues"`
+}
+
+func (km *KitMode) handleValidation(w http.ResponseWriter, r *http.Request) {
+	report := validationReport{Issues: []validationIssue{}}
+	if sample := km.Sample(); sample != nil {
+		result := sample.Validation
+		report.Valid = !result.HasErrors()
+		report.Errors = result.ErrorCount()
+		report.BuiltAt = sample.BuiltAt.Format(time.RFC3339)
+		for _, issue := range result.Issues {
+			file := issue.File
+			if rel, err := filepath.Rel(km.server.config.Dir, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+			report.Issues = append(report.Issues, validationIssue{
+				Level:   string(issue.Level),
+				Message: issue.Message,
+				File:    file,
+				Line:    issue.Line,
+				Hint:    issue.Hint,
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
 func (km *KitMode) Reload() error {
 	return km.loadKit()
 }
@@ -0,0 +1,148 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestKitMode(t *testing.T) (*KitMode, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "mykit")
+	writeTestFile(t, filepath.Join(dir, "kit.yaml"), `name: mykit
+version: 1.0.0
+description: A test kit
+framework: mykit
+cdn: '<link rel="stylesheet" href="https://example.com/mykit.css">'
+`)
+	km, err := NewKitMode(&Server{config: &ServerConfig{Dir: dir}})
+	if err != nil {
+		t.Fatalf("NewKitMode: %v", err)
+	}
+	t.Cleanup(km.Stop)
+	return km, dir
+}
+
+func getValidation(t *testing.T, km *KitMode) validationReport {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	km.ServeHTTP(rec, httptest.NewRequest("GET", "/validation", nil))
+	var report validationReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode validation: %v (%s)", err, rec.Body.String())
+	}
+	return report
+}
+
+func TestKitMode_SampleApp(t *testing.T) {
+	km, _ := newTestKitMode(t)
+
+	rec := httptest.NewRecorder()
+	km.ServeHTTP(rec, httptest.NewRequest("GET", "/sample", nil))
+	if rec.Code != 200 {
+		t.Fatalf("GET /sample = %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"https://example.com/mykit.css", // custom framework styled through its CDN
+		"Sample title 1",
+		"Sample title 3",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("sample page missing %q", want)
+		}
+	}
+
+	if report := getValidation(t, km); !report.Valid || report.BuiltAt == "" {
+		t.Errorf("expected a valid, built sample: %+v", report)
+	}
+}
+
+func TestKitMode_RebuildsOnChange(t *testing.T) {
+	km, dir := newTestKitMode(t)
+	first := km.Sample().Dir
+
+	// A broken template override fails generation and is reported
+	broken := filepath.Join(dir, "templates", "resource", "template.tmpl.tmpl")
+	writeTestFile(t, broken, `[[if .ResourceName]]unterminated`)
+	km.HandleFileChange(broken)
+
+	if _, err := os.Stat(first); !os.IsNotExist(err) {
+		t.Errorf("previous sample directory %s was not removed", first)
+	}
+	report := getValidation(t, km)
+	if report.Valid || report.Errors == 0 || !strings.Contains(report.Issues[len(report.Issues)-1].Message, "Sample app") {
+		t.Errorf("expected a sample app error, got %+v", report)
+	}
+	rec := httptest.NewRecorder()
+	km.ServeHTTP(rec, httptest.NewRequest("GET", "/sample", nil))
+	if rec.Code != 500 || !strings.Contains(rec.Body.String(), "Sample app") {
+		t.Errorf("GET /sample = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Fixing the kit recovers on the next change
+	if err := os.Remove(broken); err != nil {
+		t.Fatal(err)
+	}
+	km.HandleFileChange(broken)
+	if report := getValidation(t, km); !report.Valid {
+		t.Errorf("expected recovery after fix, got %+v", report)
+	}
+}
+
+func TestKitMode_Gallery(t *testing.T) {
+	km, _ := newTestKitMode(t)
+	gallery := km.Sample().Gallery
+	if gallery == nil {
+		t.Fatalf("gallery not built: %s", km.Sample().Validation.Format())
+	}
+
+	var names []string
+	for _, c := range gallery.Components {
+		names = append(names, c.Name)
+	}
+	for _, want := range []string{"form", "table", "pagination", "stats", "detail"} {
+		if !strings.Contains(strings.Join(names, " "), want) {
+			t.Errorf("gallery components %v missing %s", names, want)
+		}
+	}
+
+	// Every component renders on its own inside the kit's layout
+	for _, c := range gallery.Components {
+		for _, name := range c.Templates {
+			rec := httptest.NewRecorder()
+			km.ServeHTTP(rec, httptest.NewRequest("GET", "/gallery/render?template="+name, nil))
+			if rec.Code != 200 {
+				t.Errorf("render %s = %d: %s", name, rec.Code, rec.Body.String())
+				continue
+			}
+			if !strings.Contains(rec.Body.String(), "https://example.com/mykit.css") {
+				t.Errorf("render %s is missing the kit's CSS", name)
+			}
+		}
+	}
+
+	render := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		km.ServeHTTP(rec, httptest.NewRequest("GET", "/gallery/render?"+query, nil))
+		return rec
+	}
+	if body := render("template=resourceTable").Body.String(); !strings.Contains(body, "Sample title 3") || strings.Contains(body, "Sample title 4") {
+		t.Errorf("expected 3 sample rows by default")
+	}
+	if body := render(`template=resourceTable&props={"ItemCount":5}`).Body.String(); !strings.Contains(body, "Sample title 5") {
+		t.Errorf("ItemCount prop not applied")
+	}
+	if rec := render(`template=resourceTable&props={"Nope":1}`); rec.Code != 500 {
+		t.Errorf("unknown prop = %d, want 500", rec.Code)
+	}
+
+	rec := httptest.NewRecorder()
+	km.ServeHTTP(rec, httptest.NewRequest("GET", "/gallery", nil))
+	if rec.Code != 200 || !strings.Contains(rec.Body.String(), `"name":"form"`) {
+		t.Errorf("GET /gallery = %d", rec.Code)
+	}
+}
@@ -0,0 +1,347 @@
+package serve
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/livetemplate/livetemplate"
+	"github.com/livetemplate/lvt/components/modal"
+	"github.com/livetemplate/lvt/components/toast"
+	"github.com/livetemplate/lvt/internal/generator"
+	"github.com/livetemplate/lvt/internal/kits"
+	fieldparser "github.com/livetemplate/lvt/internal/parser"
+	"github.com/livetemplate/lvt/internal/validator"
+)
+
+// sampleResource is the resource generated against a kit under development.
+// It has one field of every scalar type so each form control and table cell
+// the kit templates produce is exercised.
+const sampleResource = "samples"
+
+var sampleFieldSpecs = []string{
+	"title:string",
+	"body:text",
+	"views:int",
+	"price:float",
+	"published:bool",
+	"published_at:time",
+	"email:email",
+	"website:url",
+	"phone:phone",
+}
+
+// sampleBaseKit supplies the generator templates and components a kit under
+// development doesn't override, so CDN-only kits still get a sample app.
+const sampleBaseKit = "multi"
+
+// KitSample is a sample app generated from a kit under development.
+type KitSample struct {
+	Dir        string                      // Temporary directory holding the staged kit and app
+	HTML       string                      // Rendered sample resource page
+	Validation *validator.ValidationResult // Kit validation plus generation/render errors
+	Gallery    *KitGallery                 // The kit's components, rendered one at a time
+	BuiltAt    time.Time
+}
+
+// buildKitSample stages kitDir on top of the base kit, generates the sample
+// resource with it into a temporary app and renders the page with sample
+// data. Generation and render failures are reported through Validation, so
+// a sample is returned whenever the temporary directory could be created.
+func buildKitSample(kitDir string) (*KitSample, error) {
+	dir, err := os.MkdirTemp("", "lvt-kit-sample-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sample directory: %w", err)
+	}
+
+	sample := &KitSample{
+		Dir:        dir,
+		Validation: validator.ValidateKit(kitDir),
+		BuiltAt:    time.Now(),
+	}
+
+	html, err := generateKitSample(kitDir, dir)
+	if err != nil {
+		sample.Validation.AddError(fmt.Sprintf("Sample app: %v", err), kitDir, 0)
+		return sample, nil
+	}
+	sample.HTML = html
+
+	gallery, err := generateKitGallery(filepath.Base(kitDir), dir)
+	if err != nil {
+		sample.Validation.AddError(fmt.Sprintf("Component gallery: %v", err), kitDir, 0)
+		return sample, nil
+	}
+	sample.Gallery = gallery
+	return sample, nil
+}
+
+func generateKitSample(kitDir, dir string) (string, error) {
+	kitName := filepath.Base(kitDir)
+	kitsDir := filepath.Join(dir, "kits")
+	if err := stageKit(kitDir, filepath.Join(kitsDir, kitName)); err != nil {
+		return "", fmt.Errorf("failed to stage kit: %w", err)
+	}
+
+	loader := kits.DefaultLoader()
+	loader.AddSearchPath(kitsDir)
+	kit, err := loader.Load(kitName)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kit: %w", err)
+	}
+
+	cssFramework, styles := sampleFramework(kit)
+
+	appDir := filepath.Join(dir, "app")
+	if err := os.MkdirAll(filepath.Join(appDir, "database", "migrations"), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "database", "schema.sql"), nil, 0644); err != nil {
+		return "", err
+	}
+
+	fields, err := fieldparser.ParseFields(sampleFieldSpecs)
+	if err != nil {
+		return "", err
+	}
+	if err := generator.GenerateResource(appDir, "sample", sampleResource, fields, kitName, cssFramework, styles,
+		"prev-next", 5, "modal", "", false, false, generator.ResourceOptions{KitPaths: []string{kitsDir}}); err != nil {
+		return "", fmt.Errorf("failed to generate sample resource: %w", err)
+	}
+
+	resourceDir := filepath.Join(appDir, "app", sampleResource)
+	tmplPath := filepath.Join(resourceDir, sampleResource+".tmpl")
+	if err := generator.ValidateTemplate(tmplPath); err != nil {
+		return "", err
+	}
+
+	state, err := sampleState(filepath.Join(resourceDir, sampleResource+".go"), fields, cssFramework)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := livetemplate.New(sampleResource,
+		livetemplate.WithParseFiles(tmplPath),
+		livetemplate.WithComponentTemplates(modal.Templates(), toast.Templates()),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse sample template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, state); err != nil {
+		return "", fmt.Errorf("failed to render sample template: %w", err)
+	}
+
+	return withKitCDN(kit, buf.String()), nil
+}
+
+// withKitCDN adds the CDN of a kit without helpers to the page's head;
+// other kits' pages already link their CSS.
+func withKitCDN(kit *kits.KitInfo, page string) string {
+	if kit.Helpers == nil && kit.Manifest.CDN != "" {
+		page = strings.Replace(page, "</head>", kit.Manifest.CDN+"\n</head>", 1)
+	}
+	return page
+}
+
+// sampleFramework returns the CSS framework and styles to generate the
+// sample with. Kits with a custom framework have no helpers; they get
+// unstyled markup and the kit's CDN styles it.
+func sampleFramework(kit *kits.KitInfo) (cssFramework, styles string) {
+	if kit.Helpers == nil {
+		return "none", "unstyled"
+	}
+	return kit.Manifest.CSSFramework, "tailwind"
+}
+
+// stageKit copies the base kit's templates and components to dst and then
+// overlays everything in kitDir.
+func stageKit(kitDir, dst string) error {
+	loader := kits.DefaultLoader()
+	for _, sub := range []string{"templates", "components"} {
+		if err := copyEmbeddedKitDir(loader, filepath.Join("system", sampleBaseKit, sub), filepath.Join(dst, sub)); err != nil {
+			return err
+		}
+	}
+
+	return filepath.WalkDir(kitDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(kitDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
+
+func copyEmbeddedKitDir(loader *kits.KitLoader, embeddedPath, dst string) error {
+	entries, err := loader.ReadEmbeddedDir(embeddedPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		src := filepath.Join(embeddedPath, entry.Name())
+		target := filepath.Join(dst, entry.Name())
+		if entry.IsDir() {
+			if err := copyEmbeddedKitDir(loader, src, target); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := loader.ReadEmbeddedFile(src)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sampleState builds the template data for the generated page. The state
+// struct is read from the generated handler, since it can't be compiled
+// without the app's sqlc models; its fields are filled with sample values.
+func sampleState(handlerPath string, fields []fieldparser.Field, cssFramework string) (map[string]interface{}, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), handlerPath, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated handler: %w", err)
+	}
+
+	var stateType *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok && strings.HasSuffix(spec.Name.Name, "State") {
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				stateType = st
+				return false
+			}
+		}
+		return stateType == nil
+	})
+	if stateType == nil {
+		return nil, fmt.Errorf("no state struct found in %s", filepath.Base(handlerPath))
+	}
+
+	items := sampleItems(fields, 3)
+	state := map[string]interface{}{}
+	for _, field := range stateType.Fields.List {
+		for _, name := range field.Names {
+			state[name.Name] = sampleStateValue(name.Name, field.Type, items)
+		}
+	}
+	state["CSSFramework"] = cssFramework
+	return state, nil
+}
+
+func sampleStateValue(name string, expr ast.Expr, items []map[string]interface{}) interface{} {
+	switch t := expr.(type) {
+	case *ast.ArrayType:
+		return items
+	case *ast.StarExpr:
+		if sel, ok := t.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "Container" {
+			return toast.New("notifications")
+		}
+		return nil
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			switch name {
+			case "Title":
+				return "Samples"
+			case "PaginationMode":
+				return "prev-next"
+			}
+			return ""
+		case "int", "int64":
+			switch name {
+			case "CurrentPage", "TotalPages":
+				return 1
+			case "PageSize", "TotalCount", "LoadedCount":
+				return len(items)
+			}
+			return 0
+		case "bool":
+			return false
+		}
+	}
+	return nil
+}
+
+// sampleItems returns n records with a value for every field, keyed by the
+// names sqlc gives the model's fields.
+func sampleItems(fields []fieldparser.Field, n int) []map[string]interface{} {
+	created := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+	items := make([]map[string]interface{}, n)
+	for i := range items {
+		item := map[string]interface{}{
+			"ID":        fmt.Sprintf("sample-%d", i+1),
+			"CreatedAt": created.Add(time.Duration(i) * time.Hour),
+		}
+		for _, f := range fields {
+			item[sqlcFieldName(f.Name)] = sampleValue(f, i)
+		}
+		items[i] = item
+	}
+	return items
+}
+
+func sampleValue(f fieldparser.Field, i int) interface{} {
+	switch f.GoType {
+	case "int64":
+		return int64(100 * (i + 1))
+	case "float64":
+		return 9.99 * float64(i+1)
+	case "bool":
+		return i%2 == 0
+	case "time.Time":
+		return time.Date(2025, 2, 1+i, 12, 0, 0, 0, time.UTC)
+	}
+	switch f.Metadata.HTMLInputType {
+	case "email":
+		return fmt.Sprintf("user%d@example.com", i+1)
+	case "url":
+		return fmt.Sprintf("https://example.com/%d", i+1)
+	case "tel":
+		return fmt.Sprintf("+1 555 010%d", i)
+	}
+	if f.IsTextarea {
+		return fmt.Sprintf("Sample body text for record %d, long enough to wrap in a table cell.", i+1)
+	}
+	return fmt.Sprintf("Sample %s %d", f.Name, i+1)
+}
+
+// sqlcFieldName converts a column name to the Go field name sqlc generates
+// (snake_case to CamelCase, with "id" and "url" initialisms upper-cased).
+func sqlcFieldName(column string) string {
+	parts := strings.Split(column, "_")
+	for i, p := range parts {
+		switch p {
+		case "id", "url":
+			parts[i] = strings.ToUpper(p)
+		default:
+			if p != "" {
+				parts[i] = strings.ToUpper(p[:1]) + p[1:]
+			}
+		}
+	}
+	return strings.Join(parts, "")
+}
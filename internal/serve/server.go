@@ -7,8 +7,10 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"syscall"
 	"time"
@@ -131,6 +133,8 @@ func (s *Server) setupComponentRoutes() {
 	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		cm.ServeHTTP(w, r)
 	})
+	s.mux.HandleFunc("/components", cm.handleComponents)
+	s.mux.HandleFunc("/fixture", cm.handleFixture)
 	s.mux.HandleFunc("/preview", cm.handlePreview)
 	s.mux.HandleFunc("/render", cm.handleRender)
 	s.mux.HandleFunc("/reload", cm.handleReload)
@@ -155,6 +159,10 @@ func (s *Server) setupKitRoutes() {
 	})
 	s.mux.HandleFunc("/test", km.handleTest)
 	s.mux.HandleFunc("/helpers", km.handleHelpers)
+	s.mux.HandleFunc("/sample", km.handleSample)
+	s.mux.HandleFunc("/validation", km.handleValidation)
+	s.mux.HandleFunc("/gallery", km.handleGallery)
+	s.mux.HandleFunc("/gallery/render", km.handleGalleryRender)
 }
 
 func (s *Server) setupAppRoutes() {
@@ -174,6 +182,8 @@ func (s *Server) setupAppRoutes() {
 	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		am.ServeHTTP(w, r)
 	})
+	s.mux.HandleFunc(consoleScriptPath, am.handleConsoleScript)
+	s.mux.HandleFunc(consolePath, am.handleConsole)
 }
 
 func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
@@ -259,8 +269,11 @@ func (s *Server) getWebSocketScript() string {
 func (s *Server) handleFileChange(path string) {
 	log.Printf("File changed: %s", path)
 
-	if s.appMode != nil {
-		s.appMode.HandleFileChange(path)
+	if s.appMode != nil && s.appMode.HandleFileChange(path) {
+		return // reloads browsers once the restarted app is ready
+	}
+	if s.kitMode != nil {
+		s.kitMode.HandleFileChange(path)
 	}
 
 	s.wsManager.Broadcast(map[string]interface{}{
@@ -348,6 +361,10 @@ func (s *Server) Shutdown() error {
 		s.appMode.Stop()
 	}
 
+	if s.kitMode != nil {
+		s.kitMode.Stop()
+	}
+
 	if s.httpServer != nil {
 		if err := s.httpServer.Shutdown(ctx); err != nil {
 			return fmt.Errorf("server shutdown failed: %w", err)
@@ -370,5 +387,25 @@ func (s *Server) isPortAvailable(port int) bool {
 
 func (s *Server) openBrowser(url string) {
 	time.Sleep(500 * time.Millisecond)
-	log.Printf("Please open your browser at: %s", url)
+	cmd := browserCommand(url)
+	if cmd == nil || cmd.Start() != nil {
+		log.Printf("Please open your browser at: %s", url)
+		return
+	}
+	go func() { _ = cmd.Wait() }()
+}
+
+// browserCommand opens url in the default browser, or is nil on Linux
+// without a display to open it on.
+func browserCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return nil
+	}
+	return exec.Command("xdg-open", url)
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/livetemplate/lvt/internal/stack"
+	"gopkg.in/yaml.v3"
 )
 
 // helperGenerate creates a temp dir structure (project root + deploy subdir) and runs Generate.
@@ -379,3 +381,59 @@ func TestGenerator_Generate_MakefileNoBackupWithoutSQLite(t *testing.T) {
 		t.Error("Makefile for non-SQLite config should NOT contain backup target")
 	}
 }
+
+func TestDockerCompose_PubSubScaleProfile(t *testing.T) {
+	for _, broker := range []string{"redis", "nats"} {
+		t.Run(broker, func(t *testing.T) {
+			_, deployDir := helperGenerate(t, stack.StackConfig{
+				Provider: stack.ProviderDocker,
+				Database: stack.DatabaseSQLite,
+				Backup:   stack.BackupNone,
+				Redis:    stack.RedisNone,
+				Storage:  stack.StorageNone,
+				CI:       stack.CINone,
+				PubSub:   broker,
+			})
+
+			content, err := os.ReadFile(filepath.Join(deployDir, "docker-compose.yml"))
+			if err != nil {
+				t.Fatalf("docker-compose.yml should exist with a pub/sub bridge: %v", err)
+			}
+			var compose struct {
+				Services map[string]struct {
+					Image       string   `yaml:"image"`
+					Profiles    []string `yaml:"profiles"`
+					Ports       []string `yaml:"ports"`
+					Environment []string `yaml:"environment"`
+				} `yaml:"services"`
+			}
+			if err := yaml.Unmarshal(content, &compose); err != nil {
+				t.Fatalf("docker-compose.yml is not valid YAML: %v\n%s", err, content)
+			}
+
+			app := compose.Services["app"]
+			if len(app.Profiles) != 0 || !slices.Contains(app.Environment, "PUBSUB_URL=${PUBSUB_URL:-}") {
+				t.Errorf("app should run without a profile and take PUBSUB_URL: %+v", app)
+			}
+			second := compose.Services["app-2"]
+			if !slices.Equal(second.Profiles, []string{"scale"}) || !slices.Equal(second.Ports, []string{"8081:8080"}) {
+				t.Errorf("app-2 should run on 8081 in the scale profile: %+v", second)
+			}
+			pubsub := compose.Services["pubsub"]
+			if !strings.HasPrefix(pubsub.Image, broker+":") || !slices.Equal(pubsub.Profiles, []string{"scale"}) {
+				t.Errorf("pubsub should run the %s broker in the scale profile: %+v", broker, pubsub)
+			}
+			if !strings.Contains(string(content), "sticky sessions") {
+				t.Error("docker-compose.yml should explain sticky sessions")
+			}
+
+			readme, err := os.ReadFile(filepath.Join(deployDir, "README.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(readme), "docker compose --profile scale up") {
+				t.Error("README.md should describe the scale profile")
+			}
+		})
+	}
+}
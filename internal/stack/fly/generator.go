@@ -27,6 +27,9 @@ var readmeTemplate string
 //go:embed templates/litestream.yml.tmpl
 var litestreamTemplate string
 
+//go:embed templates/release.sh.tmpl
+var releaseTemplate string
+
 // Generator implements stack.Generator for Fly.io
 type Generator struct{}
 
@@ -71,6 +74,13 @@ func (g *Generator) Generate(ctx context.Context, config stack.StackConfig, outp
 		}
 	}
 
+	// Generate the release step that migrates the SQLite database
+	if config.Database == stack.DatabaseSQLite {
+		if err := g.generateFile(filepath.Join(outputDir, "release.sh"), releaseTemplate, data); err != nil {
+			return fmt.Errorf("failed to generate release.sh: %w", err)
+		}
+	}
+
 	// Generate litestream.yml if needed
 	if config.Backup == stack.BackupLitestream {
 		if err := g.generateLitestream(outputDir, config, data); err != nil {
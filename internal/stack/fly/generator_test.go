@@ -76,7 +76,7 @@ func TestGenerator_FlyToml_Content(t *testing.T) {
 		contains string
 	}{
 		{"dockerfile path", `dockerfile = "deploy/Dockerfile"`},
-		{"health check path", `/health/live`},
+		{"health check path", `/readyz`},
 		{"force https", `force_https = true`},
 		{"auto stop format", `auto_stop_machines = "stop"`},
 		{"mounts for sqlite", `[mounts]`},
@@ -335,3 +335,83 @@ func TestGenerator_Generate_WithProjectDir(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerator_Generate_ReleaseAndSecrets(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployDir := filepath.Join(tmpDir, "deploy")
+
+	config := stack.StackConfig{
+		Provider: stack.ProviderFly,
+		Database: stack.DatabaseSQLite,
+		Backup:   stack.BackupNone,
+		Redis:    stack.RedisNone,
+		Storage:  stack.StorageNone,
+		CI:       stack.CINone,
+		Secrets:  map[string]string{"LVT_MASTER_KEY": "Decrypts the secrets"},
+	}
+
+	gen := New()
+	if err := gen.Generate(context.Background(), config, deployDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	release, err := os.ReadFile(filepath.Join(deployDir, "release.sh"))
+	if err != nil {
+		t.Fatalf("Expected deploy/release.sh: %v", err)
+	}
+	if !bytes.Contains(release, []byte("database/migrations up")) || !bytes.Contains(release, []byte(`exec "$@"`)) {
+		t.Error("release.sh should apply migrations, then start the app")
+	}
+
+	dockerfile, err := os.ReadFile(filepath.Join(deployDir, "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(dockerfile, []byte(`ENTRYPOINT ["sh", "./release.sh"]`)) {
+		t.Error("Dockerfile should run release.sh before the app")
+	}
+
+	readme, err := os.ReadFile(filepath.Join(deployDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(readme, []byte("fly secrets set LVT_MASTER_KEY=...")) {
+		t.Error("README.md should document the required secrets")
+	}
+}
+
+func TestGenerator_Generate_PubSub(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployDir := filepath.Join(tmpDir, "deploy")
+
+	config := stack.StackConfig{
+		Provider: stack.ProviderFly,
+		Database: stack.DatabasePostgres,
+		Backup:   stack.BackupNone,
+		Redis:    stack.RedisNone,
+		Storage:  stack.StorageNone,
+		CI:       stack.CINone,
+		PubSub:   "nats",
+	}
+
+	gen := New()
+	if err := gen.Generate(context.Background(), config, deployDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "fly.toml"))
+	if err != nil {
+		t.Fatalf("Expected fly.toml at project root: %v", err)
+	}
+	if !bytes.Contains(content, []byte(`LIVE_TRANSPORT = "websocket"`)) {
+		t.Error("fly.toml should recommend WebSockets for several machines")
+	}
+
+	readme, err := os.ReadFile(filepath.Join(deployDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(readme, []byte("fly secrets set PUBSUB_URL=nats://")) {
+		t.Error("README.md should describe setting PUBSUB_URL")
+	}
+}
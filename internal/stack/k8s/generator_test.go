@@ -333,3 +333,57 @@ func TestGenerator_Generate_HealthProbes(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerator_Generate_PubSubAffinity(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := stack.StackConfig{
+		Provider:  stack.ProviderK8s,
+		Database:  stack.DatabasePostgres,
+		Backup:    stack.BackupNone,
+		Redis:     stack.RedisNone,
+		Storage:   stack.StorageNone,
+		CI:        stack.CINone,
+		Namespace: "myapp",
+		Ingress:   stack.IngressNginx,
+		Registry:  stack.RegistryGHCR,
+		PubSub:    "redis",
+	}
+
+	gen := New()
+	if err := gen.Generate(context.Background(), config, tmpDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := map[string][]string{
+		"deployment.yaml":     {"name: PUBSUB_URL", "key: pubsub-url"},
+		"secret.yaml.example": {"pubsub-url:"},
+		"service.yaml":        {"sessionAffinity: ClientIP"},
+		"ingress.yaml":        {`nginx.ingress.kubernetes.io/affinity: "cookie"`, "session-cookie-name"},
+	}
+	for file, needles := range want {
+		content, err := os.ReadFile(filepath.Join(tmpDir, file))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		for _, needle := range needles {
+			if !strings.Contains(string(content), needle) {
+				t.Errorf("%s should contain %q", file, needle)
+			}
+		}
+	}
+
+	// Without a bridge nothing asks for affinity
+	plainDir := t.TempDir()
+	config.PubSub = ""
+	if err := gen.Generate(context.Background(), config, plainDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(plainDir, "ingress.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "affinity") {
+		t.Error("ingress.yaml should not set affinity without a pub/sub bridge")
+	}
+}
@@ -0,0 +1,102 @@
+package railway
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/livetemplate/lvt/internal/stack"
+)
+
+//go:embed templates/railway.toml.tmpl
+var railwayTomlTemplate string
+
+//go:embed templates/Dockerfile.tmpl
+var dockerfileTemplate string
+
+//go:embed templates/.env.example.tmpl
+var envExampleTemplate string
+
+//go:embed templates/README.md.tmpl
+var readmeTemplate string
+
+//go:embed templates/release.sh.tmpl
+var releaseTemplate string
+
+// Generator implements stack.Generator for Railway
+type Generator struct{}
+
+// New creates a new Railway generator
+func New() *Generator {
+	return &Generator{}
+}
+
+// Generate creates Railway deployment configuration
+func (g *Generator) Generate(ctx context.Context, config stack.StackConfig, outputDir string) error {
+	projectDir := config.ResolveProjectDir(outputDir)
+	data := config.ToTemplateData(filepath.Base(projectDir))
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// railway.toml at project root (Railway reads it from there)
+	if err := g.generateFile(filepath.Join(projectDir, "railway.toml"), railwayTomlTemplate, data); err != nil {
+		return fmt.Errorf("failed to generate railway.toml: %w", err)
+	}
+
+	deployFiles := map[string]string{
+		"Dockerfile":   dockerfileTemplate,
+		".env.example": envExampleTemplate,
+		"README.md":    readmeTemplate,
+	}
+	if config.Database == stack.DatabaseSQLite {
+		deployFiles["release.sh"] = releaseTemplate
+	}
+	for filename, tmplContent := range deployFiles {
+		if err := g.generateFile(filepath.Join(outputDir, filename), tmplContent, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// generateFile generates a single file from template
+func (g *Generator) generateFile(outputPath, tmplContent string, data *stack.TemplateData) error {
+	tmpl, err := template.New(filepath.Base(outputPath)).Parse(tmplContent)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that railway.toml exists at the project root
+func (g *Generator) Validate(ctx context.Context, stackDir string) error {
+	if _, err := os.Stat(filepath.Join(filepath.Dir(stackDir), "railway.toml")); err != nil {
+		return fmt.Errorf("railway.toml not found: %w", err)
+	}
+	return nil
+}
+
+// GetInfo returns information about the Railway stack
+func (g *Generator) GetInfo(ctx context.Context, stackDir string) (*stack.StackInfo, error) {
+	return &stack.StackInfo{
+		Provider:          string(stack.ProviderRailway),
+		DeploymentCommand: "railway up",
+	}, nil
+}
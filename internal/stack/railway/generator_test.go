@@ -0,0 +1,94 @@
+package railway
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/stack"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployDir := filepath.Join(tmpDir, "deploy")
+
+	config := stack.StackConfig{
+		Provider:   stack.ProviderRailway,
+		Database:   stack.DatabaseSQLite,
+		Backup:     stack.BackupNone,
+		Redis:      stack.RedisNone,
+		Storage:    stack.StorageNone,
+		CI:         stack.CINone,
+		ProjectDir: tmpDir,
+		Secrets:    map[string]string{"LVT_MASTER_KEY": "Decrypts the secrets"},
+	}
+
+	if err := New().Generate(context.Background(), config, deployDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "railway.toml"))
+	if err != nil {
+		t.Fatalf("Expected railway.toml at project root: %v", err)
+	}
+	for _, want := range []string{`dockerfilePath = "deploy/Dockerfile"`, `healthcheckPath = "/readyz"`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("railway.toml missing %q", want)
+		}
+	}
+
+	for _, file := range []string{"Dockerfile", ".env.example", "README.md", "release.sh"} {
+		if _, err := os.Stat(filepath.Join(deployDir, file)); os.IsNotExist(err) {
+			t.Errorf("Expected deploy/%s does not exist", file)
+		}
+	}
+
+	dockerfile, err := os.ReadFile(filepath.Join(deployDir, "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"ENV DATABASE_PATH=/data/app.db", `ENTRYPOINT ["sh", "./release.sh"]`, "COPY --from=builder /app/bin ./bin"} {
+		if !strings.Contains(string(dockerfile), want) {
+			t.Errorf("Dockerfile missing %q", want)
+		}
+	}
+
+	readme, err := os.ReadFile(filepath.Join(deployDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"railway volume add --mount-path /data", `railway variables --set "LVT_MASTER_KEY=..."`} {
+		if !strings.Contains(string(readme), want) {
+			t.Errorf("README.md missing %q", want)
+		}
+	}
+}
+
+func TestGenerator_Generate_NoDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployDir := filepath.Join(tmpDir, "deploy")
+
+	config := stack.StackConfig{
+		Provider:   stack.ProviderRailway,
+		Database:   stack.DatabaseNone,
+		ProjectDir: tmpDir,
+	}
+
+	if err := New().Generate(context.Background(), config, deployDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Nothing to migrate
+	if _, err := os.Stat(filepath.Join(deployDir, "release.sh")); err == nil {
+		t.Error("release.sh should not be generated without a database")
+	}
+	dockerfile, err := os.ReadFile(filepath.Join(deployDir, "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(dockerfile), "release.sh") || strings.Contains(string(dockerfile), "/data") {
+		t.Error("Dockerfile without a database should not mount or migrate one")
+	}
+}
@@ -0,0 +1,79 @@
+package render
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/stack"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployDir := filepath.Join(tmpDir, "deploy")
+
+	config := stack.StackConfig{
+		Provider:   stack.ProviderRender,
+		Database:   stack.DatabaseSQLite,
+		Backup:     stack.BackupNone,
+		Redis:      stack.RedisNone,
+		Storage:    stack.StorageNone,
+		CI:         stack.CINone,
+		ProjectDir: tmpDir,
+		Secrets:    map[string]string{"SESSION_SECRET": "Signs session cookies"},
+	}
+
+	if err := New().Generate(context.Background(), config, deployDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "render.yaml"))
+	if err != nil {
+		t.Fatalf("Expected render.yaml at project root: %v", err)
+	}
+	for _, want := range []string{
+		"dockerfilePath: ./deploy/Dockerfile",
+		"healthCheckPath: /readyz",
+		"mountPath: /data",
+		"value: /data/app.db",
+		"- key: SESSION_SECRET\n        sync: false",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("render.yaml missing %q", want)
+		}
+	}
+
+	for _, file := range []string{"Dockerfile", ".env.example", "README.md", "release.sh"} {
+		if _, err := os.Stat(filepath.Join(deployDir, file)); os.IsNotExist(err) {
+			t.Errorf("Expected deploy/%s does not exist", file)
+		}
+	}
+}
+
+func TestGenerator_Generate_NoDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	deployDir := filepath.Join(tmpDir, "deploy")
+
+	config := stack.StackConfig{
+		Provider:   stack.ProviderRender,
+		Database:   stack.DatabaseNone,
+		ProjectDir: tmpDir,
+	}
+
+	if err := New().Generate(context.Background(), config, deployDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "render.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "disk:") {
+		t.Error("render.yaml without a database should not attach a disk")
+	}
+	if !strings.Contains(string(content), "plan: free") {
+		t.Error("render.yaml without a disk should use the free plan")
+	}
+}
@@ -6,7 +6,8 @@ import (
 )
 
 // Package stack provides types and configuration for deployment stack generation.
-// It supports multiple cloud providers (Docker, Fly.io, DigitalOcean, Kubernetes)
+// It supports multiple cloud providers (Docker, Fly.io, DigitalOcean, Kubernetes,
+// Railway, Render)
 // with configurable database, backup, caching, and storage options.
 
 type Provider string
@@ -16,6 +17,8 @@ const (
 	ProviderFly          Provider = "fly"
 	ProviderDigitalOcean Provider = "do"
 	ProviderK8s          Provider = "k8s"
+	ProviderRailway      Provider = "railway"
+	ProviderRender       Provider = "render"
 )
 
 type DatabaseType string
@@ -90,6 +93,12 @@ type StackConfig struct {
 	// instead of deriving the root via filepath.Dir(outputDir). This makes
 	// the contract explicit and avoids assumptions about directory depth.
 	ProjectDir string
+	// Secrets are the variables the app needs set as platform secrets,
+	// with a description of each, for the generated documentation.
+	Secrets map[string]string
+	// PubSub is the broker of the app's pub/sub bridge (redis or nats),
+	// empty for an app running as one instance.
+	PubSub string
 }
 
 // ResolveProjectDir returns ProjectDir if set, otherwise derives it from
@@ -108,16 +117,28 @@ func (c StackConfig) ResolveProjectDir(outputDir string) string {
 func (c *StackConfig) NeedsCompose() bool {
 	return c.Database == DatabasePostgres ||
 		c.Backup == BackupLitestream ||
-		c.Redis != RedisNone
+		c.Redis != RedisNone ||
+		c.PubSub != ""
 }
 
 func (c *StackConfig) Validate() error {
 	validProviders := map[Provider]bool{
 		ProviderDocker: true, ProviderFly: true,
 		ProviderDigitalOcean: true, ProviderK8s: true,
+		ProviderRailway: true, ProviderRender: true,
 	}
 	if !validProviders[c.Provider] {
-		return fmt.Errorf("invalid provider: %s. Valid: docker, fly, do, k8s", c.Provider)
+		return fmt.Errorf("invalid provider: %s. Valid: docker, fly, do, k8s, railway, render", c.Provider)
+	}
+
+	// Railway and Render run a single container with a volume
+	if c.Provider == ProviderRailway || c.Provider == ProviderRender {
+		if c.Database == DatabasePostgres {
+			return fmt.Errorf("--db postgres is not supported for %s (use sqlite or none)", c.Provider)
+		}
+		if c.Backup != BackupNone && c.Backup != "" || c.Redis != RedisNone && c.Redis != "" || c.MultiRegion {
+			return fmt.Errorf("--backup, --redis and --multi-region are not supported for %s", c.Provider)
+		}
 	}
 
 	if c.Backup == BackupLitestream && c.Storage == StorageNone {
@@ -150,9 +171,14 @@ type TemplateData struct {
 	Ingress     string
 	Registry    string
 	Secrets     map[string]string
+	PubSub      string
 }
 
 func (c *StackConfig) ToTemplateData(projectName string) *TemplateData {
+	secrets := make(map[string]string, len(c.Secrets))
+	for name, description := range c.Secrets {
+		secrets[name] = description
+	}
 	return &TemplateData{
 		ProjectName: projectName,
 		Provider:    string(c.Provider),
@@ -165,6 +191,7 @@ func (c *StackConfig) ToTemplateData(projectName string) *TemplateData {
 		MultiRegion: c.MultiRegion,
 		Ingress:     string(c.Ingress),
 		Registry:    string(c.Registry),
-		Secrets:     make(map[string]string),
+		Secrets:     secrets,
+		PubSub:      c.PubSub,
 	}
 }
@@ -83,6 +83,36 @@ func TestStackConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "render with sqlite succeeds",
+			config: StackConfig{
+				Provider: ProviderRender,
+				Database: DatabaseSQLite,
+				Backup:   BackupNone,
+				Redis:    RedisNone,
+			},
+			wantErr: false,
+		},
+		{
+			name: "railway with postgres fails",
+			config: StackConfig{
+				Provider: ProviderRailway,
+				Database: DatabasePostgres,
+			},
+			wantErr: true,
+			errMsg:  "--db postgres is not supported for railway (use sqlite or none)",
+		},
+		{
+			name: "render with litestream fails",
+			config: StackConfig{
+				Provider: ProviderRender,
+				Database: DatabaseSQLite,
+				Backup:   BackupLitestream,
+				Storage:  StorageS3,
+			},
+			wantErr: true,
+			errMsg:  "--backup, --redis and --multi-region are not supported for render",
+		},
 	}
 
 	for _, tt := range tests {
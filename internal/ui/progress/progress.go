@@ -0,0 +1,138 @@
+// Package progress reports the steps of long-running CLI operations.
+//
+// On a terminal each running step shows an animated spinner with its elapsed
+// time. Elsewhere (CI logs, pipes, --no-progress) a step prints one line when
+// it starts, a heartbeat while it runs, and one line when it finishes, so logs
+// never go silent for long stretches.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+var disabled atomic.Bool
+
+// Disable turns off spinner animation for the rest of the process, as the
+// global --no-progress flag does. Steps are still reported line by line.
+func Disable() {
+	disabled.Store(true)
+}
+
+// Animated reports whether progress written to w is animated: w must be a
+// terminal, and neither --no-progress, LVT_NO_PROGRESS, CI nor TERM=dumb set.
+func Animated(w io.Writer) bool {
+	if disabled.Load() || os.Getenv("LVT_NO_PROGRESS") != "" || os.Getenv("CI") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(f.Fd())
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const (
+	frameInterval     = 100 * time.Millisecond
+	heartbeatInterval = 15 * time.Second
+)
+
+// Progress reports a sequence of steps to a writer.
+type Progress struct {
+	w        io.Writer
+	total    int
+	step     int
+	animate  bool
+	interval time.Duration // spinner frame or heartbeat interval
+	mu       sync.Mutex    // serializes writes to w
+}
+
+// New returns a Progress writing to w. With total > 0 steps are numbered
+// "[n/total]".
+func New(w io.Writer, total int) *Progress {
+	p := &Progress{w: w, total: total, animate: Animated(w), interval: heartbeatInterval}
+	if p.animate {
+		p.interval = frameInterval
+	}
+	return p
+}
+
+// Run reports title while fn runs and whether it succeeded when fn returns,
+// and returns fn's error for the caller to report. fn must not write to the
+// terminal itself; capture command output and print it afterwards instead.
+func (p *Progress) Run(title string, fn func() error) error {
+	p.step++
+	if p.total > 0 {
+		title = fmt.Sprintf("[%d/%d] %s", p.step, p.total, title)
+	}
+
+	start := time.Now()
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.tick(title, start, stop)
+	}()
+
+	err := fn()
+	close(stop)
+	wg.Wait()
+
+	elapsed := formatElapsed(time.Since(start))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.animate {
+		fmt.Fprint(p.w, "\r\033[K")
+	}
+	if err != nil {
+		fmt.Fprintf(p.w, "❌ %s (%s)\n", title, elapsed)
+	} else {
+		fmt.Fprintf(p.w, "✅ %s (%s)\n", title, elapsed)
+	}
+	return err
+}
+
+// tick draws the spinner, or prints the start line and heartbeats, until
+// stop is closed.
+func (p *Progress) tick(title string, start time.Time, stop <-chan struct{}) {
+	if !p.animate {
+		p.printf("%s...\n", title)
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		if p.animate {
+			p.printf("\r\033[K%s %s (%s)", spinnerFrames[frame%len(spinnerFrames)], title, formatElapsed(time.Since(start)))
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if !p.animate {
+				p.printf("   still running: %s (%s)\n", title, formatElapsed(time.Since(start)))
+			}
+		}
+	}
+}
+
+func (p *Progress) printf(format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.w, format, args...)
+}
+
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
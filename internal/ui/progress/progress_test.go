@@ -0,0 +1,80 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunReportsSteps(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, 2)
+
+	if err := p.Run("Generating files", func() error { return nil }); err != nil {
+		t.Fatalf("Run returned %v", err)
+	}
+	wantErr := errors.New("boom")
+	if err := p.Run("Installing dependencies", func() error { return wantErr }); err != wantErr {
+		t.Fatalf("Run returned %v, want %v", err, wantErr)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"[1/2] Generating files...\n",
+		"✅ [1/2] Generating files (",
+		"[2/2] Installing dependencies...\n",
+		"❌ [2/2] Installing dependencies (",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\r") || strings.Contains(out, "\033[") {
+		t.Errorf("non-terminal output contains control sequences: %q", out)
+	}
+}
+
+func TestRunHeartbeat(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, 0)
+	p.interval = 10 * time.Millisecond
+
+	p.Run("Pulling image", func() error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	if !strings.Contains(buf.String(), "still running: Pulling image (") {
+		t.Errorf("expected heartbeat lines for a long step:\n%s", buf.String())
+	}
+}
+
+func TestAnimated(t *testing.T) {
+	if Animated(&bytes.Buffer{}) {
+		t.Error("a buffer is not a terminal")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if Animated(f) {
+		t.Error("a regular file is not a terminal")
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := map[time.Duration]string{
+		1500 * time.Millisecond: "1.5s",
+		90 * time.Second:        "1m30s",
+	}
+	for d, want := range tests {
+		if got := formatElapsed(d); got != want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
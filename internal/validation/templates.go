@@ -5,18 +5,30 @@ import (
 	"fmt"
 	"html/template"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/livetemplate/lvt/internal/appfuncs"
 	"github.com/livetemplate/lvt/internal/validator"
 )
 
 // tmplLinePattern matches template parse errors like "template: name:5:" or "template: name:5:22:".
 var tmplLinePattern = regexp.MustCompile(`template:.*?:(\d+)`)
 
+// runtimeFuncs stands in for the functions generated apps register on their
+// templates (see generator/validate.go), so parsing accepts calls to them.
+var runtimeFuncs = template.FuncMap{
+	"T":             func(locale, text string, args ...any) string { return text }, // app/i18n
+	"localeOptions": func(current string) []any { return nil },                     // app/i18n
+	"cspNonce":      func() string { return "" },                                   // shared/security
+	"richText":      func(s string) string { return s },                            // app/richtext
+	"richTextPlain": func(s string) string { return s },                            // app/richtext
+}
+
 // TemplateCheck validates all .tmpl files in an app directory using
 // html/template (matching the existing generator/validate.go convention).
 type TemplateCheck struct{}
@@ -26,6 +38,9 @@ func (c *TemplateCheck) Name() string { return "templates" }
 func (c *TemplateCheck) Run(ctx context.Context, appPath string) *validator.ValidationResult {
 	result := validator.NewValidationResult()
 	var found bool
+	// The app's own functions from shared/funcs, then the generated ones
+	funcs := appfuncs.Stubs(appPath)
+	maps.Copy(funcs, runtimeFuncs)
 
 	walkErr := filepath.WalkDir(appPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -50,7 +65,7 @@ func (c *TemplateCheck) Run(ctx context.Context, appPath string) *validator.Vali
 			return nil
 		}
 		found = true
-		c.validateFile(path, appPath, result)
+		c.validateFile(path, appPath, funcs, result)
 		return nil
 	})
 
@@ -71,7 +86,7 @@ func (c *TemplateCheck) Run(ctx context.Context, appPath string) *validator.Vali
 	return result
 }
 
-func (c *TemplateCheck) validateFile(path, appPath string, result *validator.ValidationResult) {
+func (c *TemplateCheck) validateFile(path, appPath string, funcs template.FuncMap, result *validator.ValidationResult) {
 	relPath, _ := filepath.Rel(appPath, path)
 	if relPath == "" {
 		relPath = path
@@ -86,7 +101,7 @@ func (c *TemplateCheck) validateFile(path, appPath string, result *validator.Val
 	src := string(content)
 
 	// Parse check.
-	_, parseErr := template.New(filepath.Base(path)).Parse(src)
+	_, parseErr := template.New(filepath.Base(path)).Funcs(funcs).Parse(src)
 	if parseErr != nil {
 		lineNum := extractLineNumber(parseErr)
 		hint := ""
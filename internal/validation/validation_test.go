@@ -196,6 +196,39 @@ func TestTemplateCheck_Valid(t *testing.T) {
 	}
 }
 
+func TestTemplateCheck_AppFuncs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "shared/funcs/funcs.go", `package funcs
+
+import (
+	"html/template"
+	"strings"
+)
+
+var registry = template.FuncMap{
+	"shout": strings.ToUpper,
+}
+`)
+	writeFile(t, dir, "views/index.tmpl", `<h1>{{shout .Title}}</h1>`)
+
+	result := (&TemplateCheck{}).Run(context.Background(), dir)
+
+	if !result.Valid {
+		t.Errorf("expected valid with shared/funcs functions, got: %s", result.Format())
+	}
+}
+
+func TestTemplateCheck_CSPNonce(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "views/index.tmpl", `<script nonce="{{cspNonce}}">start()</script>`)
+
+	result := (&TemplateCheck{}).Run(context.Background(), dir)
+
+	if !result.Valid {
+		t.Errorf("expected valid with the shared/security nonce, got: %s", result.Format())
+	}
+}
+
 func TestTemplateCheck_Invalid(t *testing.T) {
 	dir := t.TempDir()
 	writeFile(t, dir, "views/bad.tmpl", "<h1>{{.Title}</h1>") // missing closing }}
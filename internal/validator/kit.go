@@ -7,6 +7,7 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/livetemplate/lvt/internal/kits"
 )
@@ -42,6 +43,11 @@ func ValidateKit(path string) *ValidationResult {
 		result.Merge(helpersResult)
 	}
 
+	// Validate icon overrides (if icons/ exists)
+	if _, err := os.Stat(filepath.Join(path, kits.IconsDir)); err == nil {
+		result.Merge(validateKitIcons(path))
+	}
+
 	// Validate README
 	readmeResult := validateKitReadme(path)
 	result.Merge(readmeResult)
@@ -87,6 +93,18 @@ func validateKitManifest(path string) *ValidationResult {
 		return result
 	}
 
+	// A kit that extends another needs the parent at generation time
+	if manifest.Extends != "" {
+		if _, err := kits.DefaultLoader().Load(manifest.Extends); err != nil {
+			result.AddError(fmt.Sprintf("Parent kit %q not found: %v", manifest.Extends, err), manifestPath, 0)
+		}
+	}
+	for key := range manifest.Helpers {
+		if !kits.IsOverridableHelper(key) {
+			result.AddWarning(fmt.Sprintf("Unknown helper override %q - it will be ignored", key), manifestPath, 0)
+		}
+	}
+
 	// Check for recommended fields
 	if manifest.Author == "" {
 		result.AddWarning("Author field is empty", manifestPath, 0)
@@ -96,8 +114,21 @@ func validateKitManifest(path string) *ValidationResult {
 		result.AddWarning("License field is empty", manifestPath, 0)
 	}
 
-	if manifest.CDN == "" && manifest.CustomCSS == "" {
-		result.AddWarning("No CDN or custom CSS specified", manifestPath, 0)
+	_, err = os.Stat(filepath.Join(path, kits.AssetsDir))
+	hasAssets := err == nil
+	for _, asset := range manifest.Assets {
+		switch filepath.Ext(asset) {
+		case ".css", ".js":
+		default:
+			result.AddWarning(fmt.Sprintf("Asset %q is neither .css nor .js - the layout will not load it", asset), manifestPath, 0)
+		}
+		if _, err := os.Stat(filepath.Join(path, kits.AssetsDir, filepath.FromSlash(asset))); err != nil && manifest.Extends == "" {
+			result.AddError(fmt.Sprintf("Asset %q not found in %s/", asset, kits.AssetsDir), manifestPath, 0)
+		}
+	}
+
+	if manifest.CDN == "" && manifest.CustomCSS == "" && manifest.Extends == "" && !hasAssets {
+		result.AddWarning("No CDN, custom CSS or assets specified", manifestPath, 0)
 	}
 
 	if len(manifest.Tags) == 0 {
@@ -107,6 +138,38 @@ func validateKitManifest(path string) *ValidationResult {
 	return result
 }
 
+// validateKitIcons checks that the files of the icons directory are SVG
+// icons the icon helper can render
+func validateKitIcons(path string) *ValidationResult {
+	result := NewValidationResult()
+
+	manifest, err := kits.LoadManifest(path)
+	if err != nil {
+		return result // Reported by validateKitManifest
+	}
+	kit := &kits.KitInfo{Manifest: *manifest, Source: kits.SourceLocal, Path: path}
+
+	iconsPath := filepath.Join(path, kits.IconsDir)
+	entries, err := os.ReadDir(iconsPath)
+	if err != nil {
+		result.AddError(fmt.Sprintf("Failed to read %s/: %v", kits.IconsDir, err), iconsPath, 0)
+		return result
+	}
+	for _, entry := range entries {
+		file := filepath.Join(iconsPath, entry.Name())
+		name, ok := strings.CutSuffix(entry.Name(), ".svg")
+		if entry.IsDir() || !ok {
+			result.AddWarning(fmt.Sprintf("%s is not an .svg file - it will be ignored", entry.Name()), file, 0)
+			continue
+		}
+		if _, err := kit.Icon(name); err != nil {
+			result.AddError(fmt.Sprintf("Icon %q cannot be used: %v", name, err), file, 0)
+		}
+	}
+
+	return result
+}
+
 // validateKitHelpers validates the helpers.go file
 func validateKitHelpers(path string) *ValidationResult {
 	result := NewValidationResult()
@@ -0,0 +1,193 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Canonical returns a decoded tree (the map[string]any / []any values
+// encoding/json produces) with its range operation lists in a canonical
+// order, so two updates that differ only in the order of operations whose
+// order is not observable compare equal.
+//
+// Runs of consecutive removes and of consecutive updates are sorted by item
+// ID: each touches one item and moves none, so applying them in any order
+// gives the same DOM. Appends, prepends, inserts and reorders keep their
+// position, since they depend on the operations before them.
+func Canonical(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[k] = Canonical(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = Canonical(child)
+		}
+		if isRangeOpList(out) {
+			sortCommutingOps(out)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// CanonicalJSON re-encodes a JSON update in canonical form: Canonical
+// operation order, object keys sorted with dynamic positions in numeric order
+// ("2" before "10") ahead of the reserved keys, two-space indentation and
+// unescaped HTML. A compressed update (see Decode) is decoded first.
+func CanonicalJSON(data []byte) ([]byte, error) {
+	if msg, _, err := Decode(data); err == nil {
+		data = msg
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("update is not JSON: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, Canonical(v), ""); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isRangeOpList reports whether every element of list is a range operation.
+func isRangeOpList(list []any) bool {
+	if len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if opKind(item) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// opKind returns the kind of a range operation, or "" if op is not one.
+func opKind(op any) RangeOpKind {
+	arr, ok := op.([]any)
+	if !ok || len(arr) == 0 {
+		return ""
+	}
+	kind, ok := arr[0].(string)
+	if !ok {
+		return ""
+	}
+	for _, k := range RangeOpKinds {
+		if string(k) == kind {
+			return k
+		}
+	}
+	return ""
+}
+
+// sortCommutingOps sorts each run of consecutive remove or update operations
+// by item ID, in place.
+func sortCommutingOps(ops []any) {
+	for start := 0; start < len(ops); {
+		kind := opKind(ops[start])
+		end := start + 1
+		for end < len(ops) && opKind(ops[end]) == kind {
+			end++
+		}
+		if kind == OpRemove || kind == OpUpdate {
+			run := ops[start:end]
+			sort.SliceStable(run, func(i, j int) bool { return opItemID(run[i]) < opItemID(run[j]) })
+		}
+		start = end
+	}
+}
+
+func opItemID(op any) string {
+	arr := op.([]any)
+	if len(arr) < 2 {
+		return ""
+	}
+	if id, ok := arr[1].(string); ok {
+		return id
+	}
+	return fmt.Sprint(arr[1])
+}
+
+// treeKeyLess orders dynamic positions numerically, then the other keys
+// alphabetically.
+func treeKeyLess(a, b string) bool {
+	ai, aErr := strconv.Atoi(a)
+	bi, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return ai < bi
+	case aErr == nil:
+		return true
+	case bErr == nil:
+		return false
+	default:
+		return a < b
+	}
+}
+
+func writeCanonical(buf *bytes.Buffer, v any, indent string) error {
+	inner := indent + "  "
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return treeKeyLess(keys[i], keys[j]) })
+		buf.WriteString("{\n")
+		for i, k := range keys {
+			key, _ := json.Marshal(k)
+			buf.WriteString(inner)
+			buf.Write(key)
+			buf.WriteString(": ")
+			if err := writeCanonical(buf, v[k], inner); err != nil {
+				return err
+			}
+			if i < len(keys)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "}")
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, item := range v {
+			buf.WriteString(inner)
+			if err := writeCanonical(buf, item, inner); err != nil {
+				return err
+			}
+			if i < len(v)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "]")
+	default:
+		// Statics hold HTML; keep it readable rather than \u003c-escaped
+		var scalar bytes.Buffer
+		enc := json.NewEncoder(&scalar)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		buf.Write(bytes.TrimSuffix(scalar.Bytes(), []byte("\n")))
+	}
+	return nil
+}
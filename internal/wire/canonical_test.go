@@ -0,0 +1,77 @@
+package wire
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestCanonicalSortsCommutingOps(t *testing.T) {
+	a := decode(t, `{"8":{"0":[["r","todo-5"],["r","todo-3"],["u","todo-4",{"0":"x"}],["u","todo-1",{"2":"#1"}],["a",[{"1":"todo-6"}]]]}}`)
+	b := decode(t, `{"8":{"0":[["r","todo-3"],["r","todo-5"],["u","todo-1",{"2":"#1"}],["u","todo-4",{"0":"x"}],["a",[{"1":"todo-6"}]]]}}`)
+	if !reflect.DeepEqual(Canonical(a), Canonical(b)) {
+		t.Errorf("updates differing only in remove/update order are not equal:\n%v\n%v", Canonical(a), Canonical(b))
+	}
+}
+
+func TestCanonicalKeepsPositionalOps(t *testing.T) {
+	tests := map[string][2]string{
+		// Each run is sorted on its own; a remove never moves past an update
+		"runs":     {`[["u","b",{}],["r","z"],["u","a",{}]]`, `[["u","a",{}],["r","z"],["u","b",{}]]`},
+		"inserts":  {`[["i","x",{"0":"b"}],["i","x",{"0":"a"}]]`, `[["i","x",{"0":"a"}],["i","x",{"0":"b"}]]`},
+		"prepends": {`[["p",[{"0":"b"}]],["p",[{"0":"a"}]]]`, `[["p",[{"0":"a"}]],["p",[{"0":"b"}]]]`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if reflect.DeepEqual(Canonical(decode(t, tt[0])), Canonical(decode(t, tt[1]))) {
+				t.Errorf("%s and %s canonicalized equal; their order is observable", tt[0], tt[1])
+			}
+		})
+	}
+
+	// Statics and range items are not operation lists
+	statics := decode(t, `{"s":["u","r"],"d":[{"0":"b"},{"0":"a"}]}`)
+	if !reflect.DeepEqual(Canonical(statics), statics) {
+		t.Errorf("Canonical changed a tree without operations: %v", Canonical(statics))
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	got, err := CanonicalJSON([]byte(`{"s":["<p>"],"10":"x","2":[["r","b"],["r","a"]],"f":"ab"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "2": [
+    [
+      "r",
+      "a"
+    ],
+    [
+      "r",
+      "b"
+    ]
+  ],
+  "10": "x",
+  "f": "ab",
+  "s": [
+    "<p>"
+  ]
+}`
+	if string(got) != want {
+		t.Errorf("CanonicalJSON =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := CanonicalJSON([]byte(`{`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
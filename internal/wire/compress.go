@@ -0,0 +1,77 @@
+package wire
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Encodings a message can be carried in. JSON is the format itself; the
+// others compress it.
+const (
+	EncodingJSON    = "json"
+	EncodingDeflate = "deflate" // permessage-deflate (RFC 7692), negotiated in the WebSocket handshake
+	EncodingGzip    = "gzip"
+)
+
+// DeflateExtension is the Sec-WebSocket-Extensions value under which the
+// client and server agree to deflate messages.
+const DeflateExtension = "permessage-deflate"
+
+// deflateTail ends each flushed deflate block. RFC 7692 strips it from the
+// frame payload and the receiver appends it again.
+var deflateTail = []byte{0x00, 0x00, 0xff, 0xff}
+
+// Deflate compresses a message as a permessage-deflate frame payload
+// without context takeover, as gorilla/websocket sends it at its default
+// level, so len(Deflate(msg)) is the size the message takes on the wire.
+func Deflate(msg []byte) []byte {
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.BestSpeed)
+	_, _ = w.Write(msg)
+	_ = w.Flush()
+	return bytes.TrimSuffix(buf.Bytes(), deflateTail)
+}
+
+// Gzip compresses a message as a gzip stream, as HTTP responses sent with
+// Content-Encoding: gzip carry it.
+func Gzip(msg []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write(msg)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+// Decode returns the JSON of a message in any of the encodings and which
+// one it was in. JSON is returned as is.
+func Decode(data []byte) ([]byte, string, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return data, EncodingJSON, nil
+	}
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", fmt.Errorf("wire: invalid gzip message: %w", err)
+		}
+		msg, err := io.ReadAll(r)
+		if err != nil {
+			return nil, "", fmt.Errorf("wire: invalid gzip message: %w", err)
+		}
+		return msg, EncodingGzip, nil
+	}
+	r := flate.NewReader(io.MultiReader(bytes.NewReader(data), bytes.NewReader(deflateTail)))
+	defer r.Close()
+	msg, err := io.ReadAll(r)
+	// The restored tail ends the input without a final block
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, "", fmt.Errorf("wire: message is neither JSON nor compressed JSON: %w", err)
+	}
+	if t := bytes.TrimSpace(msg); len(t) == 0 || (t[0] != '{' && t[0] != '[') {
+		return nil, "", fmt.Errorf("wire: message is neither JSON nor compressed JSON")
+	}
+	return msg, EncodingDeflate, nil
+}
@@ -0,0 +1,111 @@
+package wire
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+const sampleUpdate = `{"tree":{"0":{"s":["<li>","</li>"],"d":[{"0":"first item"},{"0":"second item"},{"0":"third item"}]}},"meta":{"success":true,"errors":{}}}`
+
+func TestDecode(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		data     []byte
+		encoding string
+	}{
+		{"json", []byte(sampleUpdate), EncodingJSON},
+		{"deflate", Deflate([]byte(sampleUpdate)), EncodingDeflate},
+		{"gzip", Gzip([]byte(sampleUpdate)), EncodingGzip},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, encoding, err := Decode(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if encoding != tt.encoding {
+				t.Errorf("encoding = %q, want %q", encoding, tt.encoding)
+			}
+			if string(msg) != sampleUpdate {
+				t.Errorf("decoded %q, want %q", msg, sampleUpdate)
+			}
+			if err := ValidateServerMessage(tt.data); err != nil {
+				t.Errorf("ValidateServerMessage() = %v", err)
+			}
+		})
+	}
+
+	if _, _, err := Decode([]byte("not a message")); err == nil {
+		t.Error("Decode() of garbage should fail")
+	}
+}
+
+// TestDeflateMatchesWebSocket checks that Deflate sizes a message as a
+// WebSocket connection that negotiated compression sends it.
+func TestDeflateMatchesWebSocket(t *testing.T) {
+	upgrader := websocket.Upgrader{EnableCompression: true}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Sent once asked, so the handshake is read by then
+		if _, _, err := conn.ReadMessage(); err == nil {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(sampleUpdate))
+		}
+	}))
+	defer srv.Close()
+
+	var counted *countingConn
+	dialer := websocket.Dialer{
+		EnableCompression: true,
+		NetDial: func(network, addr string) (net.Conn, error) {
+			conn, err := net.Dial(network, addr)
+			counted = &countingConn{Conn: conn}
+			return counted, err
+		},
+	}
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if !strings.Contains(resp.Header.Get("Sec-WebSocket-Extensions"), DeflateExtension) {
+		t.Fatal("compression was not negotiated")
+	}
+	afterHandshake := counted.read
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"action":"send"}`)); err != nil {
+		t.Fatal(err)
+	}
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg) != sampleUpdate {
+		t.Fatalf("received %q", msg)
+	}
+	// The frame is the payload behind a two-byte header
+	frame := counted.read - afterHandshake
+	if got := len(Deflate(msg)) + 2; got != frame {
+		t.Errorf("Deflate() sizes the frame at %d bytes, the connection read %d", got, frame)
+	}
+	if frame >= len(sampleUpdate) {
+		t.Errorf("deflated frame of %d bytes is not smaller than the %d byte message", frame, len(sampleUpdate))
+	}
+}
+
+type countingConn struct {
+	net.Conn
+	read int
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.read += n
+	return n, err
+}
@@ -0,0 +1,183 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Schema returns the JSON Schema (draft-07) for the current wire format.
+// Message objects are derived from the Go types in this package; tree nodes
+// and range operations, which are positional, are described directly.
+func Schema() map[string]any {
+	ops := make([]any, 0, len(RangeOpKinds))
+	for _, kind := range RangeOpKinds {
+		ops = append(ops, ref("op_"+string(kind)))
+	}
+
+	return map[string]any{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id":     "urn:livetemplate:wire:" + Version,
+		"title":   "LiveTemplate wire format",
+		"version": Version,
+		"definitions": map[string]any{
+			"serverMessage": map[string]any{
+				"anyOf": []any{ref("envelope"), ref("uploadMessage")},
+			},
+			"clientMessage": objectSchema(ClientMessage{}, true),
+			"envelope":      objectSchema(Envelope{}, false),
+			"meta":          objectSchema(Meta{}, false),
+			"uploadMessage": withAnyRequired(objectSchema(UploadMessage{}, true), "upload_name", "entry_id", "type"),
+			"treeNode": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					KeyStatics:     ref("statics"),
+					KeyRange:       map[string]any{"type": "array", "items": ref("treeNode")},
+					KeyFingerprint: map[string]any{"type": "string", "pattern": "^[0-9a-f]{16}$"},
+					KeyMetadata:    ref("rangeMetadata"),
+				},
+				"patternProperties": map[string]any{
+					"^[0-9]+$": ref("dynamic"),
+				},
+				"additionalProperties": false,
+			},
+			"dynamic": map[string]any{
+				"anyOf": []any{
+					map[string]any{"type": []any{"string", "number", "boolean", "null"}},
+					ref("treeNode"),
+					ref("rangeOps"),
+				},
+			},
+			"statics": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"rangeMetadata": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"idKey": map[string]any{"type": "string"},
+				},
+			},
+			"rangeOps": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"oneOf": ops},
+			},
+			"op_a": tuple(OpAppend, 2, map[string]any{"type": "array", "items": ref("treeNode")}, ref("statics"), ref("rangeMetadata")),
+			"op_p": tuple(OpPrepend, 2, map[string]any{"type": "array", "items": ref("treeNode")}, ref("statics")),
+			"op_i": tuple(OpInsert, 3, map[string]any{"type": "string"}, ref("treeNode"), ref("statics")),
+			"op_r": tuple(OpRemove, 2, map[string]any{"type": "string"}),
+			"op_u": tuple(OpUpdate, 3, map[string]any{"type": "string"}, ref("treeNode")),
+			"op_o": tuple(OpReorder, 2, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}),
+		},
+	}
+}
+
+// SchemaJSON returns Schema as indented JSON, the format of schema/wire.schema.json.
+func SchemaJSON() []byte {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		// Schema is built from static values; failing to marshal is a programming error.
+		panic(fmt.Sprintf("wire: marshal schema: %v", err))
+	}
+	return append(data, '\n')
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+// tuple describes a range operation array: the kind, followed by its
+// arguments, of which the first minItems-1 are required.
+func tuple(kind RangeOpKind, minItems int, args ...any) map[string]any {
+	items := append([]any{map[string]any{"const": string(kind)}}, args...)
+	return map[string]any{
+		"type":            "array",
+		"items":           items,
+		"minItems":        minItems,
+		"maxItems":        len(items),
+		"additionalItems": false,
+	}
+}
+
+func withAnyRequired(schema map[string]any, fields ...string) map[string]any {
+	alternatives := make([]any, 0, len(fields))
+	for _, f := range fields {
+		alternatives = append(alternatives, map[string]any{"required": []any{f}})
+	}
+	schema["anyOf"] = alternatives
+	return schema
+}
+
+// objectSchema derives an object schema from a struct's JSON tags. Fields
+// without omitempty are required. A `schema:"name"` tag points the field at a
+// definition; `schema:",minLength=N"` adds a string length constraint.
+func objectSchema(v any, additional bool) map[string]any {
+	t := reflect.TypeOf(v)
+	properties := map[string]any{}
+	var required []any
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		defName, constraint, _ := strings.Cut(field.Tag.Get("schema"), ",")
+		var prop map[string]any
+		if defName != "" {
+			prop = ref(defName)
+		} else {
+			prop = typeSchema(field.Type)
+		}
+		if n, ok := strings.CutPrefix(constraint, "minLength="); ok {
+			length, err := strconv.Atoi(n)
+			if err != nil {
+				panic(fmt.Sprintf("wire: invalid minLength on %s.%s", t.Name(), field.Name))
+			}
+			prop["minLength"] = length
+		}
+		properties[name] = prop
+
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": additional,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return map[string]any{"type": "integer"}
+	case reflect.Float64, reflect.Float32:
+		return map[string]any{"type": "number"}
+	case reflect.Map:
+		// Go maps marshal to null when nil
+		schema := map[string]any{"type": []any{"object", "null"}}
+		if t.Elem().Kind() != reflect.Interface {
+			schema["additionalProperties"] = typeSchema(t.Elem())
+		}
+		return schema
+	case reflect.Slice:
+		return map[string]any{"type": []any{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Interface:
+		return map[string]any{}
+	}
+	panic(fmt.Sprintf("wire: unsupported schema type %s", t))
+}
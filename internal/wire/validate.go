@@ -0,0 +1,403 @@
+package wire
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// schemaFile is the checked-in schema. Tests keep it identical to SchemaJSON.
+//
+//go:embed schema/wire.schema.json
+var schemaFile []byte
+
+// SchemaFile returns the checked-in JSON Schema for the current wire format,
+// for tooling that validates messages outside of Go.
+func SchemaFile() []byte {
+	return bytes.Clone(schemaFile)
+}
+
+// Definitions that can be passed to Validate.
+const (
+	DefServerMessage = "serverMessage"
+	DefClientMessage = "clientMessage"
+	DefEnvelope      = "envelope"
+	DefTreeNode      = "treeNode"
+	DefRangeOps      = "rangeOps"
+)
+
+// ValidationError is a schema violation at a JSON path such as
+// "$.tree.0[1][0]".
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+var (
+	loadOnce    sync.Once
+	definitions map[string]any
+	loadErr     error
+
+	patternMu    sync.Mutex
+	patternCache = map[string]*regexp.Regexp{}
+)
+
+func loadDefinitions() (map[string]any, error) {
+	loadOnce.Do(func() {
+		var schema map[string]any
+		if err := json.Unmarshal(schemaFile, &schema); err != nil {
+			loadErr = fmt.Errorf("wire: invalid schema file: %w", err)
+			return
+		}
+		defs, ok := schema["definitions"].(map[string]any)
+		if !ok {
+			loadErr = fmt.Errorf("wire: schema file has no definitions")
+			return
+		}
+		definitions = defs
+	})
+	return definitions, loadErr
+}
+
+// Validate checks a JSON message against the named schema definition.
+// A compressed message (see Decode) is checked as the JSON it carries.
+// It returns nil if the message is valid, or a *ValidationError for the
+// first violation found.
+func Validate(def string, data []byte) error {
+	if msg, _, err := Decode(data); err == nil {
+		data = msg
+	}
+	defs, err := loadDefinitions()
+	if err != nil {
+		return err
+	}
+	schema, ok := defs[def]
+	if !ok {
+		return fmt.Errorf("wire: unknown schema definition %q", def)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return &ValidationError{Path: "$", Message: "invalid JSON: " + err.Error()}
+	}
+
+	return validator{defs: defs}.validate(schema, v, "$")
+}
+
+// ValidateServerMessage checks a server-to-client message.
+func ValidateServerMessage(data []byte) error {
+	return Validate(DefServerMessage, data)
+}
+
+// ValidateClientMessage checks a client-to-server message.
+func ValidateClientMessage(data []byte) error {
+	return Validate(DefClientMessage, data)
+}
+
+// validator interprets the subset of JSON Schema used by Schema.
+type validator struct {
+	defs map[string]any
+}
+
+func (v validator) validate(s any, value any, path string) error {
+	schema, ok := s.(map[string]any)
+	if !ok {
+		// true/false schemas
+		if b, isBool := s.(bool); isBool && !b {
+			return &ValidationError{Path: path, Message: "value not allowed"}
+		}
+		return nil
+	}
+
+	if r, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(r, "#/definitions/")
+		def, ok := v.defs[name]
+		if !ok {
+			return fmt.Errorf("wire: unresolved schema reference %q", r)
+		}
+		return v.validate(def, value, path)
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %s", describeType(t), jsonType(value))}
+	}
+	if c, ok := schema["const"]; ok && value != c {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected %q, got %v", c, value)}
+	}
+
+	if alts, ok := schema["oneOf"].([]any); ok {
+		if err := v.validateOneOf(alts, value, path); err != nil {
+			return err
+		}
+	}
+	if alts, ok := schema["anyOf"].([]any); ok {
+		if err := v.validateAnyOf(alts, value, path); err != nil {
+			return err
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		return v.validateObject(schema, val, path)
+	case []any:
+		return v.validateArray(schema, val, path)
+	case string:
+		return validateString(schema, val, path)
+	}
+	return nil
+}
+
+func (v validator) validateAnyOf(alts []any, value any, path string) error {
+	var errs []error
+	for _, alt := range alts {
+		err := v.validate(alt, value, path)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return bestError(errs, path, "value does not match any allowed schema")
+}
+
+func (v validator) validateOneOf(alts []any, value any, path string) error {
+	matched := 0
+	var errs []error
+	for _, alt := range alts {
+		if err := v.validate(alt, value, path); err == nil {
+			matched++
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	switch {
+	case matched == 1:
+		return nil
+	case matched > 1:
+		return &ValidationError{Path: path, Message: "value matches more than one schema"}
+	}
+
+	// For range ops the first element selects the alternative, so report the
+	// error from that alternative instead of the first one tried.
+	if arr, ok := value.([]any); ok && len(arr) > 0 {
+		if kind, ok := arr[0].(string); ok {
+			for _, alt := range alts {
+				if name := refName(alt); name == "op_"+kind {
+					return v.validate(alt, value, path)
+				}
+			}
+			return &ValidationError{Path: path + "[0]", Message: fmt.Sprintf("unknown range operation %q", kind)}
+		}
+	}
+	return bestError(errs, path, "value does not match any allowed schema")
+}
+
+func (v validator) validateObject(schema map[string]any, obj map[string]any, path string) error {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; !present {
+				return &ValidationError{Path: path, Message: fmt.Sprintf("missing required field %q", name)}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	patterns, _ := schema["patternProperties"].(map[string]any)
+
+	// Sorted keys keep error reporting deterministic.
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "." + key
+		if prop, ok := properties[key]; ok {
+			if err := v.validate(prop, obj[key], childPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		matched := false
+		for pattern, prop := range patterns {
+			if compilePattern(pattern).MatchString(key) {
+				matched = true
+				if err := v.validate(prop, obj[key], childPath); err != nil {
+					return err
+				}
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if additional, ok := schema["additionalProperties"]; ok {
+			if b, isBool := additional.(bool); isBool {
+				if !b {
+					return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected field %q", key)}
+				}
+				continue
+			}
+			if err := v.validate(additional, obj[key], childPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v validator) validateArray(schema map[string]any, arr []any, path string) error {
+	if n, ok := schema["minItems"].(float64); ok && len(arr) < int(n) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at least %d items, got %d", int(n), len(arr))}
+	}
+	if n, ok := schema["maxItems"].(float64); ok && len(arr) > int(n) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at most %d items, got %d", int(n), len(arr))}
+	}
+
+	switch items := schema["items"].(type) {
+	case []any:
+		for i, elem := range arr {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if i < len(items) {
+				if err := v.validate(items[i], elem, itemPath); err != nil {
+					return err
+				}
+				continue
+			}
+			if additional, ok := schema["additionalItems"]; ok {
+				if err := v.validate(additional, elem, itemPath); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		for i, elem := range arr {
+			if err := v.validate(items, elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateString(schema map[string]any, s string, path string) error {
+	if n, ok := schema["minLength"].(float64); ok && len([]rune(s)) < int(n) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at least %d characters", int(n))}
+	}
+	if pattern, ok := schema["pattern"].(string); ok && !compilePattern(pattern).MatchString(s) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("%q does not match %s", s, pattern)}
+	}
+	return nil
+}
+
+func matchesType(t any, value any) bool {
+	switch t := t.(type) {
+	case string:
+		return jsonTypeIs(t, value)
+	case []any:
+		for _, name := range t {
+			if s, ok := name.(string); ok && jsonTypeIs(s, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonTypeIs(t string, value any) bool {
+	switch t {
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	}
+	return jsonType(value) == t
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func describeType(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, n := range list {
+			names = append(names, fmt.Sprint(n))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func refName(s any) string {
+	if m, ok := s.(map[string]any); ok {
+		if r, ok := m["$ref"].(string); ok {
+			return strings.TrimPrefix(r, "#/definitions/")
+		}
+	}
+	return ""
+}
+
+// bestError picks the deepest error among failed alternatives: the
+// alternative that got furthest before failing is most likely the intended
+// one. Errors at path itself only mean the value had the wrong shape.
+func bestError(errs []error, path, fallback string) error {
+	var best *ValidationError
+	for _, err := range errs {
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			return err
+		}
+		if ve.Path != path && (best == nil || len(ve.Path) > len(best.Path)) {
+			best = ve
+		}
+	}
+	if best != nil {
+		return best
+	}
+	return &ValidationError{Path: path, Message: fallback}
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	patternMu.Lock()
+	defer patternMu.Unlock()
+	if re, ok := patternCache[pattern]; ok {
+		return re
+	}
+	re := regexp.MustCompile(pattern)
+	patternCache[pattern] = re
+	return re
+}
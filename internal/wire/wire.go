@@ -0,0 +1,72 @@
+// Package wire defines the LiveTemplate WebSocket message format as a
+// versioned schema.
+//
+// The Go types in this package are the source of truth. Schema derives a JSON
+// Schema (draft-07) from them, which is checked in as schema/wire.schema.json
+// so non-Go consumers (browser tooling, the client library) can validate
+// against exactly the same rules. Validate interprets that schema, so the
+// testing framework and any other Go tooling share a single validator.
+//
+// Changing the wire format requires bumping Version: the tests compare the
+// generated schema against schema/versions.lock and fail when the schema of an
+// already-released version changes. See README.md in this directory.
+package wire
+
+// Version is the wire format version described by this package.
+// Bump it whenever Schema changes; the minor version for additive changes,
+// the major version for anything an existing client could reject.
+const Version = "1.0.0"
+
+// Envelope is a server-to-client update: a tree diff plus optional metadata
+// about the action that produced it.
+type Envelope struct {
+	Tree map[string]any `json:"tree" schema:"treeNode"`
+	Meta *Meta          `json:"meta,omitempty" schema:"meta"`
+}
+
+// Meta describes the outcome of the action that produced an update.
+type Meta struct {
+	Success bool              `json:"success"`
+	Errors  map[string]string `json:"errors"`
+	Action  string            `json:"action,omitempty"`
+}
+
+// ClientMessage is a client-to-server action. Upload messages carry extra
+// top-level fields (upload_name, entry_id, ...) alongside action.
+type ClientMessage struct {
+	Action string         `json:"action" schema:",minLength=1"`
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+// UploadMessage is a server-to-client upload protocol message (start
+// response, progress, completion, cancellation). Its payload is defined by
+// the upload protocol; the wire schema only identifies it.
+type UploadMessage struct {
+	Type       string `json:"type,omitempty"`
+	UploadName string `json:"upload_name,omitempty"`
+	EntryID    string `json:"entry_id,omitempty"`
+}
+
+// Reserved tree node keys. All other keys are numeric dynamic positions.
+const (
+	KeyStatics     = "s"
+	KeyRange       = "d"
+	KeyFingerprint = "f"
+	KeyMetadata    = "m"
+)
+
+// RangeOpKind identifies a range operation, the first element of an
+// operation array such as ["r", "item-2"].
+type RangeOpKind string
+
+const (
+	OpAppend  RangeOpKind = "a" // ["a", items, statics?, metadata?]
+	OpPrepend RangeOpKind = "p" // ["p", items, statics?]
+	OpInsert  RangeOpKind = "i" // ["i", afterID, item, statics?]
+	OpRemove  RangeOpKind = "r" // ["r", itemID]
+	OpUpdate  RangeOpKind = "u" // ["u", itemID, changes]
+	OpReorder RangeOpKind = "o" // ["o", [itemIDs]]
+)
+
+// RangeOpKinds lists every range operation in schema order.
+var RangeOpKinds = []RangeOpKind{OpAppend, OpPrepend, OpInsert, OpRemove, OpUpdate, OpReorder}
@@ -0,0 +1,222 @@
+package wire
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	schemaPath = "schema/wire.schema.json"
+	lockPath   = "schema/versions.lock"
+)
+
+func TestSchemaFileUpToDate(t *testing.T) {
+	want := SchemaJSON()
+
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.WriteFile(schemaPath, want, 0644); err != nil {
+			t.Fatalf("Failed to write schema: %v", err)
+		}
+		t.Logf("Updated %s", schemaPath)
+		return
+	}
+
+	if !bytes.Equal(schemaFile, want) {
+		t.Fatalf("%s is out of date with the Go types in this package.\n"+
+			"Run: UPDATE_GOLDEN=1 go test ./internal/wire", schemaPath)
+	}
+}
+
+// TestSchemaVersionLocked fails when the schema changes without a Version
+// bump. Each released version's schema hash is recorded in versions.lock and
+// must never change.
+func TestSchemaVersionLocked(t *testing.T) {
+	sum := sha256.Sum256(SchemaJSON())
+	hash := "sha256:" + hex.EncodeToString(sum[:])
+
+	locked, err := readLock(lockPath)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", lockPath, err)
+	}
+
+	recorded, ok := locked[Version]
+	switch {
+	case ok && recorded == hash:
+		return
+	case ok:
+		t.Fatalf("wire format changed without a version bump: schema for %s was %s, now %s.\n"+
+			"Bump wire.Version, then run: UPDATE_GOLDEN=1 go test ./internal/wire", Version, recorded, hash)
+	case os.Getenv("UPDATE_GOLDEN") == "1":
+		f, err := os.OpenFile(lockPath, os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", lockPath, err)
+		}
+		defer f.Close()
+		if _, err := fmt.Fprintf(f, "%s %s\n", Version, hash); err != nil {
+			t.Fatalf("Failed to update %s: %v", lockPath, err)
+		}
+		t.Logf("Locked wire format %s", Version)
+	default:
+		t.Fatalf("wire format %s is not recorded in %s.\n"+
+			"Run: UPDATE_GOLDEN=1 go test ./internal/wire", Version, lockPath)
+	}
+}
+
+func readLock(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	locked := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		locked[fields[0]] = fields[1]
+	}
+	return locked, scanner.Err()
+}
+
+func TestValidateServerMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		wantErr string // substring of the error, "" for valid
+	}{
+		{
+			name: "initial render",
+			msg:  `{"tree":{"s":["<p>","</p>"],"0":"Hello"},"meta":{"success":true,"errors":{}}}`,
+		},
+		{
+			name: "diff without meta",
+			msg:  `{"tree":{"0":"updated","1":{"0":5}}}`,
+		},
+		{
+			name: "range with items and metadata",
+			msg:  `{"tree":{"0":{"s":["<li>","</li>"],"d":[{"0":"a"},{"0":"b"}],"f":"0123456789abcdef","m":{"idKey":"id"}}}}`,
+		},
+		{
+			name: "range operations",
+			msg: `{"tree":{"0":[["a",[{"0":"x"}],["<li>","</li>"],{"idKey":"id"}],["p",[{"0":"y"}]],` +
+				`["i","item-1",{"0":"z"}],["r","item-2"],["u","item-3",{"0":"new"}],["o",["item-3","item-1"]]]}}`,
+		},
+		{
+			name: "validation errors",
+			msg:  `{"tree":{},"meta":{"success":false,"errors":{"title":"required"},"action":"add"}}`,
+		},
+		{
+			name: "upload progress",
+			msg:  `{"type":"upload_progress","upload_name":"photo","entry_id":"e1","progress":50}`,
+		},
+		{
+			name:    "missing tree",
+			msg:     `{"meta":{"success":true,"errors":{}}}`,
+			wantErr: "$",
+		},
+		{
+			name:    "unknown tree key",
+			msg:     `{"tree":{"x":"nope"}}`,
+			wantErr: `unexpected field "x"`,
+		},
+		{
+			name:    "bad fingerprint",
+			msg:     `{"tree":{"0":{"f":"XYZ"}}}`,
+			wantErr: "$.tree.0.f",
+		},
+		{
+			name:    "unknown range op",
+			msg:     `{"tree":{"0":[["z","item-1"]]}}`,
+			wantErr: `unknown range operation "z"`,
+		},
+		{
+			name:    "remove without id",
+			msg:     `{"tree":{"0":[["r"]]}}`,
+			wantErr: "at least 2 items",
+		},
+		{
+			name:    "update with extra argument",
+			msg:     `{"tree":{"0":[["u","item-1",{},"extra"]]}}`,
+			wantErr: "at most 3 items",
+		},
+		{
+			name:    "meta missing success",
+			msg:     `{"tree":{},"meta":{"errors":{}}}`,
+			wantErr: `missing required field "success"`,
+		},
+		{
+			name:    "invalid json",
+			msg:     `{"tree":`,
+			wantErr: "invalid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServerMessage([]byte(tt.msg))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected valid message, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateClientMessage(t *testing.T) {
+	valid := []string{
+		`{"action":"add","data":{"title":"Buy milk"}}`,
+		`{"action":"delete","data":{"id":"abc"}}`,
+		`{"action":"next_page"}`,
+		`{"action":"upload_start","upload_name":"photo","files":[{"name":"a.png","size":10}]}`,
+	}
+	for _, msg := range valid {
+		if err := ValidateClientMessage([]byte(msg)); err != nil {
+			t.Errorf("%s: expected valid, got: %v", msg, err)
+		}
+	}
+
+	invalid := map[string]string{
+		`{"data":{}}`:                 `missing required field "action"`,
+		`{"action":""}`:               "at least 1 characters",
+		`{"action":"add","data":[1]}`: "expected object or null",
+		`"add"`:                       "expected object",
+	}
+	for msg, want := range invalid {
+		err := ValidateClientMessage([]byte(msg))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got: %v", msg, want, err)
+		}
+	}
+}
+
+func TestValidateUnknownDefinition(t *testing.T) {
+	if err := Validate("nope", []byte(`{}`)); err == nil {
+		t.Fatal("expected error for unknown definition")
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/livetemplate/lvt/commands"
 	"github.com/livetemplate/lvt/internal/config"
+	"github.com/livetemplate/lvt/internal/logging"
 	"github.com/livetemplate/lvt/internal/ui"
+	"github.com/livetemplate/lvt/internal/ui/progress"
 )
 
 // Version information (can be overridden at build time with -ldflags)
@@ -24,7 +26,9 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Parse global flags (--config) before command
+	commands.SetVersion(version)
+
+	// Parse global flags (--config, --no-progress, --verbose, --quiet, --json) before command
 	command, args := parseGlobalFlags(os.Args[1:])
 
 	var err error
@@ -48,16 +52,24 @@ func main() {
 		err = commands.Parse(args)
 	case "resource", "res":
 		err = commands.Resource(args)
-	case "console", "db":
+	case "console":
 		err = commands.Console(args)
+	case "db":
+		err = commands.DB(args)
 	case "seed":
 		err = commands.Seed(args)
 	case "kits", "kit":
 		err = commands.Kits(args)
+	case "plugins", "plugin":
+		err = commands.Plugins(args)
+	case "status":
+		err = commands.Status(args)
 	case "stack":
 		err = commands.Stack(args)
 	case "serve", "server":
 		err = commands.Serve(args)
+	case "logs", "log":
+		err = commands.Logs(args)
 	case "env":
 		err = commands.Env(args)
 	case "install-agent", "agent":
@@ -68,6 +80,14 @@ func main() {
 		err = commands.Component(args)
 	case "auth":
 		err = commands.AuthManage(args)
+	case "i18n":
+		err = commands.I18n(args)
+	case "lsp":
+		err = commands.Lsp(args)
+	case "demo":
+		err = commands.Demo(args)
+	case "adopt":
+		err = commands.Adopt(args)
 	case "version", "--version", "-v":
 		printVersion()
 		return
@@ -145,22 +165,41 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  lvt [--config <path>] <command> [args...] Run command with optional config file")
+	fmt.Println("  lvt [--no-progress] <command> [args...]   Print plain step lines instead of spinners (CI logs)")
+	fmt.Println("  lvt [--verbose] <command> [args...]       Trace kit/template resolution, file writes and route injection")
+	fmt.Println("  lvt [--quiet] <command> [args...]         Print only errors (and --json results), for scripts")
+	fmt.Println("  lvt [--json] <command> [args...]          Print results as JSON (status, list, info, seed, parse and logs commands)")
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  lvt new [<app-name>] [--module <name>]       Create a new LiveTemplate app")
 	fmt.Println("  lvt new component <name>                      Scaffold a new UI component")
 	fmt.Println("  lvt gen <subcommand> [args...]                Generate code (resource, view, schema, or auth)")
 	fmt.Println("  lvt migration <command>                       Manage database migrations")
+	fmt.Println("  lvt db console [--command <sql>]              Open an interactive SQL shell on the app database")
+	fmt.Println("  lvt db backup [--keep <n>] [--max-age <age>]  Snapshot the app database into backups/")
+	fmt.Println("  lvt db restore <file> | --latest              Restore a backup and run pending migrations")
 	fmt.Println("  lvt resource <command>                        Inspect resources and schemas")
 	fmt.Println("  lvt seed <resource> [--count N] [--cleanup]   Generate test data")
 	fmt.Println("  lvt kits <command>                            Manage CSS framework kits")
+	fmt.Println("  lvt plugins list                              List generator plugins (.lvt/plugins)")
+	fmt.Println("  lvt status                                    Show generated files modified, orphaned or out of date")
 	fmt.Println("  lvt serve [options]                           Start development server with hot reload")
+	fmt.Println("  lvt logs [-f] [--since <d>] [--docker]        Tail server, browser console and WebSocket logs by session")
 	fmt.Println("  lvt parse <template-file>                     Validate and analyze template file")
+	fmt.Println("  lvt parse ./... [--watch]                     Parse every app template, optionally on change")
+	fmt.Println("  lvt parse --lint [path...] [--format json]    Lint templates (rules set in .lvtrc)")
+	fmt.Println("  lvt parse graph [path...] [--format json]     Template dependency graph (Mermaid)")
+	fmt.Println("  lvt parse render <template> [--data <file>]   Render HTML and update tree offline")
+	fmt.Println("  lvt parse budget <template> [--scenario <f>]  Report update payload sizes")
+	fmt.Println("  lvt lsp                                       Template language server for editors (stdio)")
 	fmt.Println("  lvt env <command>                             Manage environment variables")
 	fmt.Println("  lvt install-agent [--llm <type>]              Install AI agent for your LLM")
 	fmt.Println("  lvt styles <command>                          Manage component style adapters")
 	fmt.Println("  lvt component <command>                       Manage UI components (list, eject)")
 	fmt.Println("  lvt auth <command>                            Manage auth users (confirm, list)")
+	fmt.Println("  lvt i18n extract [--check]                    Add template strings to the locale files")
+	fmt.Println("  lvt adopt [--out <dir>] [--dry-run]           Scaffold LiveTemplate pages for a net/http app")
+	fmt.Println("  lvt demo [blog|tasks]                         Generate, seed and run a sample app")
 	fmt.Println("  lvt version                                   Show version information")
 	fmt.Println()
 	fmt.Println("Generate Subcommands:")
@@ -168,9 +207,16 @@ func printUsage() {
 	fmt.Println("  lvt gen view <name>                           Generate view-only handler (no database)")
 	fmt.Println("  lvt gen schema <table> <field:type>...        Generate database schema only")
 	fmt.Println("  lvt gen auth [StructName] [table_name]        Generate authentication system")
+	fmt.Println("  lvt gen i18n [--locales en,fr]                Set up translations (T, locale files, middleware)")
+	fmt.Println("  lvt gen deploy --target <fly|railway|render>  Generate deployment (config, release step, secrets)")
+	fmt.Println("  lvt gen metrics [--path /metrics]             Set up Prometheus metrics (HTTP, sessions, actions, DB)")
+	fmt.Println("  lvt gen otel                                  Set up OpenTelemetry tracing (requests, actions, queries)")
+	fmt.Println("  lvt gen cache [--redis]                       Set up query result caching (in memory or Redis)")
 	fmt.Println()
 	fmt.Println("Generate Options:")
 	fmt.Println("  --skip-validation                              Skip post-generation validation")
+	fmt.Println("  --dry-run                                      Print the files and diffs, write nothing")
+	fmt.Println("                                                 (new, gen resource/view/schema/auth, kits customize)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  lvt new myapp")
@@ -189,7 +235,7 @@ func printUsage() {
 	fmt.Println("  lvt gen auth --no-email-confirm               Skip email confirmation")
 	fmt.Println("  lvt gen auth --no-password-reset              Skip password reset flow")
 	fmt.Println("  lvt gen auth --no-sessions-ui                 Skip session management UI")
-	fmt.Println("  lvt gen auth --no-csrf                        Skip CSRF protection")
+	fmt.Println("  lvt gen auth --no-csrf                        Leave CSRF tokens out of auth forms")
 	fmt.Println()
 	fmt.Println("Auth Management Commands (for testing):")
 	fmt.Println("  lvt auth [--db <path>] confirm <email>        Confirm a user's email")
@@ -198,12 +244,17 @@ func printUsage() {
 	fmt.Println("Migration Commands:")
 	fmt.Println("  lvt migration up                          Run pending migrations")
 	fmt.Println("  lvt migration down                        Rollback last migration")
+	fmt.Println("  lvt migration down --to <v> | --steps N   Rollback to a version or N steps (--dry-run to preview)")
 	fmt.Println("  lvt migration status                      Show migration status")
-	fmt.Println("  lvt migration create <name>               Create new migration file")
+	fmt.Println("  lvt migration unlock                      Release a stale migration lock")
+	fmt.Println("  lvt migration create <name> [--type go]   Create new SQL (or Go) migration file")
 	fmt.Println()
 	fmt.Println("Resource Commands:")
 	fmt.Println("  lvt resource list                         List all available resources")
 	fmt.Println("  lvt resource describe <name>              Show detailed schema for a resource")
+	fmt.Println("  lvt resource openapi [-o file]            Export an OpenAPI 3.1 document")
+	fmt.Println("  lvt resource erd [--format mermaid|dot]   Export an entity-relationship diagram")
+	fmt.Println("  lvt resource diff [--json]                Diff schema.sql against the live database")
 	fmt.Println()
 	fmt.Println("Seed Commands:")
 	fmt.Println("  lvt seed tasks --count 50                 Generate 50 test records")
@@ -217,18 +268,23 @@ func printUsage() {
 	fmt.Println("  lvt kits create mykit                     Create a new CSS framework kit")
 	fmt.Println("  lvt kits info tailwind                    Show kit details")
 	fmt.Println("  lvt kits validate <path>                  Validate kit implementation")
+	fmt.Println("  lvt kits diff multi                       Compare a customized kit with the shipped one")
+	fmt.Println("  lvt kits test <path>                      Run the kit contract tests (build, boot, browser)")
 	fmt.Println()
 	fmt.Println("Serve Commands:")
 	fmt.Println("  lvt serve                                 Start dev server (auto-detect mode)")
 	fmt.Println("  lvt serve --port 8080                     Start on custom port")
-	fmt.Println("  lvt serve --mode component                Force component development mode")
-	fmt.Println("  lvt serve --mode kit                      Force kit development mode")
+	fmt.Println("  lvt serve --mode component                Component workbench (app/components/*)")
+	fmt.Println("  lvt serve --mode kit                      Kit development (sample app, component gallery, validation)")
 	fmt.Println("  lvt serve --mode app                      Force app development mode")
 	fmt.Println("  lvt serve --no-browser                    Don't open browser automatically")
 	fmt.Println("  lvt serve --no-reload                     Disable live reload")
 	fmt.Println()
 	fmt.Println("Environment Commands:")
 	fmt.Println("  lvt env generate                          Generate .env.example with detected config")
+	fmt.Println("  lvt env validate                          Check the environment against shared/config")
+	fmt.Println("  lvt env diff                              Compare the environment with shared/config")
+	fmt.Println("  lvt env secrets edit                      Edit the encrypted secrets in $EDITOR")
 	fmt.Println()
 	fmt.Println("AI Agent Commands:")
 	fmt.Println("  lvt install-agent --list                  List all available AI agents")
@@ -261,6 +317,8 @@ func printUsage() {
 	fmt.Println("  multi  - Multi-page app with full HTML layout (Tailwind CSS)")
 	fmt.Println("  single - Single-page app, component-only (Tailwind CSS)")
 	fmt.Println("  simple - Simple prototype/counter example (Pico CSS)")
+	fmt.Println("  daisyui - Multi-page app with daisyUI themes, dark mode and a theme switcher")
+	fmt.Println("            (pick the default palette with --theme)")
 	fmt.Println()
 	fmt.Println("App Mode Options:")
 	fmt.Println("  multi (default)    - Multi-page app with full HTML layout")
@@ -274,10 +332,11 @@ func printUsage() {
 	fmt.Println("  - docs/references/api-reference.md Complete API reference")
 }
 
-// parseGlobalFlags parses global flags like --config and returns the command and remaining args
+// parseGlobalFlags parses global flags like --config, --no-progress, --verbose, --quiet and --json and returns the command and remaining args
 func parseGlobalFlags(args []string) (string, []string) {
 	var filteredArgs []string
 	var command string
+	quiet := false
 
 	for i := 0; i < len(args); i++ {
 		if args[i] == "--config" && i+1 < len(args) {
@@ -286,6 +345,30 @@ func parseGlobalFlags(args []string) (string, []string) {
 			i++ // Skip the next argument (the path)
 			continue
 		}
+		if args[i] == "--verbose" {
+			// Trace kit resolution, template lookups, file writes and
+			// route injection to stderr. Accepted anywhere on the command line.
+			logging.SetVerbose()
+			continue
+		}
+		if args[i] == "--quiet" {
+			// Only errors, for scripts. Accepted anywhere on the
+			// command line.
+			quiet = true
+			continue
+		}
+		if args[i] == "--json" {
+			// Machine-readable results for scripts and CI. Accepted
+			// anywhere on the command line.
+			commands.EnableJSON()
+			continue
+		}
+		if args[i] == "--no-progress" {
+			// Plain step lines instead of spinners, e.g. for CI logs.
+			// Accepted anywhere on the command line.
+			progress.Disable()
+			continue
+		}
 
 		// First non-flag argument is the command
 		if command == "" {
@@ -295,5 +378,9 @@ func parseGlobalFlags(args []string) (string, []string) {
 		}
 	}
 
+	if quiet {
+		commands.EnableQuiet()
+	}
+
 	return command, filteredArgs
 }
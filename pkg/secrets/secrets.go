@@ -0,0 +1,231 @@
+// Package secrets stores an app's production secrets encrypted in its
+// repository, so they need not live in plaintext .env files.
+//
+// The secrets are a YAML document, edited with `lvt env secrets edit` and
+// committed encrypted with NaCl secretbox (XSalsa20-Poly1305) as File. The
+// master key that decrypts it is read from the LVT_MASTER_KEY environment
+// variable or, in development, from KeyFile, which must not be committed.
+//
+// Example:
+//
+//	s, err := secrets.Load(".")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	apiKey := s.Get("stripe.api_key")
+package secrets
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"gopkg.in/yaml.v3"
+)
+
+// Locations of the encrypted secrets and the development master key,
+// relative to the app root, and the variable holding the master key.
+const (
+	File    = "secrets/secrets.yml.enc"
+	KeyFile = "secrets/master.key"
+	KeyEnv  = "LVT_MASTER_KEY"
+)
+
+// header starts every encrypted file, naming the format.
+const header = "lvt-secrets:v1:"
+
+const nonceSize = 24
+
+// ErrNoKey is returned when the secrets exist but no master key is set.
+var ErrNoKey = errors.New("no master key: set " + KeyEnv + " or create " + KeyFile)
+
+// ErrWrongKey is returned when the master key does not decrypt the secrets.
+var ErrWrongKey = errors.New("the master key does not decrypt the secrets")
+
+// GenerateKey returns a new random master key, 32 bytes hex-encoded.
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func parseKey(key string) (*[32]byte, error) {
+	b, err := hex.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(b) != 32 {
+		return nil, fmt.Errorf("invalid master key: want 64 hex characters")
+	}
+	var k [32]byte
+	copy(k[:], b)
+	return &k, nil
+}
+
+// MasterKey returns the master key of the app in dir: LVT_MASTER_KEY, else
+// the contents of KeyFile, else "".
+func MasterKey(dir string) (string, error) {
+	if key := strings.TrimSpace(os.Getenv(KeyEnv)); key != "" {
+		return key, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, KeyFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Encrypt encrypts plaintext with key, as the contents of File.
+func Encrypt(key string, plaintext []byte) ([]byte, error) {
+	k, err := parseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := secretbox.Seal(nonce[:], plaintext, &nonce, k)
+	return []byte(header + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// Decrypt decrypts the contents of File with key.
+func Decrypt(key string, data []byte) ([]byte, error) {
+	k, err := parseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte(header)) {
+		return nil, fmt.Errorf("not an encrypted secrets file")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(string(data[len(header):]))
+	if err != nil || len(sealed) < nonceSize+secretbox.Overhead {
+		return nil, fmt.Errorf("corrupt secrets file")
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], sealed[:nonceSize])
+	plaintext, ok := secretbox.Open(nil, sealed[nonceSize:], &nonce, k)
+	if !ok {
+		return nil, ErrWrongKey
+	}
+	return plaintext, nil
+}
+
+// Store holds decrypted secrets.
+type Store struct {
+	data map[string]any
+}
+
+// Load decrypts the secrets of the app in dir. An app without File has no
+// secrets and gets an empty Store.
+func Load(dir string) (*Store, error) {
+	data, err := os.ReadFile(filepath.Join(dir, File))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Store{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	key, err := MasterKey(dir)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, ErrNoKey
+	}
+	plaintext, err := Decrypt(key, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", File, err)
+	}
+	return Parse(plaintext)
+}
+
+// Parse reads decrypted secrets, a YAML mapping.
+func Parse(plaintext []byte) (*Store, error) {
+	s := &Store{data: map[string]any{}}
+	if err := yaml.Unmarshal(plaintext, &s.data); err != nil {
+		return nil, fmt.Errorf("invalid secrets YAML: %w", err)
+	}
+	if s.data == nil {
+		s.data = map[string]any{}
+	}
+	return s, nil
+}
+
+// Lookup returns the value at a dotted path, e.g. "stripe.api_key", and
+// whether it is set. Values that are not mappings are formatted as text.
+func (s *Store) Lookup(path string) (string, bool) {
+	var value any = s.data
+	for _, part := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if value, ok = m[part]; !ok {
+			return "", false
+		}
+	}
+	if _, ok := value.(map[string]any); ok || value == nil {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// Get returns the value at a dotted path, or "" when it is not set.
+func (s *Store) Get(path string) string {
+	value, _ := s.Lookup(path)
+	return value
+}
+
+// Keys returns the dotted paths of every value, sorted.
+func (s *Store) Keys() []string {
+	var keys []string
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			if nested, ok := v.(map[string]any); ok {
+				walk(prefix+k+".", nested)
+			} else {
+				keys = append(keys, prefix+k)
+			}
+		}
+	}
+	walk("", s.data)
+	sort.Strings(keys)
+	return keys
+}
+
+var envName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// Export sets an environment variable for every top-level secret named
+// like one (e.g. SESSION_SECRET) that the environment does not already
+// set, so code reading the environment gets it.
+func (s *Store) Export() error {
+	for name, value := range s.data {
+		if !envName.MatchString(name) {
+			continue
+		}
+		if _, nested := value.(map[string]any); nested || value == nil {
+			continue
+		}
+		if _, set := os.LookupEnv(name); set {
+			continue
+		}
+		if err := os.Setenv(name, fmt.Sprint(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Encrypt(key, []byte("token: abc\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), header) || strings.Contains(string(data), "abc") {
+		t.Errorf("encrypted = %q", data)
+	}
+
+	plaintext, err := Decrypt(key, data)
+	if err != nil || string(plaintext) != "token: abc\n" {
+		t.Errorf("Decrypt() = %q, %v", plaintext, err)
+	}
+
+	other, _ := GenerateKey()
+	if _, err := Decrypt(other, data); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Decrypt() with another key: err = %v, want ErrWrongKey", err)
+	}
+	if _, err := Decrypt("short", data); err == nil {
+		t.Error("Decrypt() with an invalid key: want error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(KeyEnv, "")
+
+	// No secrets file: nothing to load
+	s, err := Load(dir)
+	if err != nil || s.Get("anything") != "" {
+		t.Fatalf("Load() without secrets = %v, %v", s, err)
+	}
+
+	key, _ := GenerateKey()
+	data, err := Encrypt(key, []byte("SESSION_SECRET: s3cret\nPORT: 9000\nstripe:\n  api_key: sk_test\n  retries: 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "secrets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, File), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("Load() without a key: err = %v, want ErrNoKey", err)
+	}
+
+	t.Setenv(KeyEnv, key)
+	s, err = Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Get("stripe.api_key"); got != "sk_test" {
+		t.Errorf("Get(stripe.api_key) = %q", got)
+	}
+	if got := s.Get("stripe.retries"); got != "3" {
+		t.Errorf("Get(stripe.retries) = %q", got)
+	}
+	if _, ok := s.Lookup("stripe"); ok {
+		t.Error("Lookup(stripe) of a mapping: want not found")
+	}
+	if got := strings.Join(s.Keys(), " "); got != "PORT SESSION_SECRET stripe.api_key stripe.retries" {
+		t.Errorf("Keys() = %s", got)
+	}
+
+	t.Setenv("SESSION_SECRET", "")
+	os.Unsetenv("SESSION_SECRET")
+	t.Setenv("PORT", "8080")
+	if err := s.Export(); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("SESSION_SECRET"); got != "s3cret" {
+		t.Errorf("exported SESSION_SECRET = %q", got)
+	}
+	if got := os.Getenv("PORT"); got != "8080" {
+		t.Errorf("PORT = %q, the environment should win", got)
+	}
+}
@@ -0,0 +1,172 @@
+package testing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Allocator hands out test resources (ports, temp directories, database names)
+// that are unique across every test in the process, including tests running
+// with t.Parallel() and -parallel N.
+//
+// Ports come from the kernel and are additionally tracked by the allocator, so
+// a port is never handed to two tests at once even if the kernel reuses it
+// between the probe and the server bind. Resources are released automatically
+// through t.Cleanup.
+//
+// Most suites can use the package-level helpers (AllocatePort, AllocateTempDir,
+// AllocateDBName), which share a single default allocator.
+type Allocator struct {
+	mu    sync.Mutex
+	ports map[int]string // port -> owning test name
+	names map[string]int // base name -> next sequence number
+}
+
+// NewAllocator creates an empty allocator.
+func NewAllocator() *Allocator {
+	return &Allocator{
+		ports: make(map[int]string),
+		names: make(map[string]int),
+	}
+}
+
+var defaultAllocator = NewAllocator()
+
+// DefaultAllocator returns the process-wide allocator used by the package-level helpers.
+func DefaultAllocator() *Allocator {
+	return defaultAllocator
+}
+
+// maxPortAttempts bounds the number of kernel probes before giving up.
+const maxPortAttempts = 50
+
+// Port reserves a free TCP port for the test and releases it when the test ends.
+func (a *Allocator) Port(t testing.TB) int {
+	t.Helper()
+
+	port, err := a.reservePort(t.Name())
+	if err != nil {
+		t.Fatalf("Failed to allocate port: %v", err)
+	}
+	t.Cleanup(func() { a.ReleasePort(port) })
+	return port
+}
+
+// ReservePort reserves a free TCP port outside of a test (e.g. in TestMain).
+// The caller must call ReleasePort when the port is no longer needed.
+func (a *Allocator) ReservePort() (int, error) {
+	return a.reservePort("")
+}
+
+// ReleasePort makes a previously reserved port available again.
+func (a *Allocator) ReleasePort(port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.ports, port)
+}
+
+func (a *Allocator) reservePort(owner string) (int, error) {
+	for range maxPortAttempts {
+		port, err := GetFreePort()
+		if err != nil {
+			return 0, err
+		}
+
+		a.mu.Lock()
+		if _, taken := a.ports[port]; !taken {
+			a.ports[port] = owner
+			a.mu.Unlock()
+			return port, nil
+		}
+		a.mu.Unlock()
+	}
+	return 0, fmt.Errorf("no unreserved port found after %d attempts", maxPortAttempts)
+}
+
+// TempDir creates a uniquely named directory under the test's temp directory.
+// Unlike t.TempDir, the directory name is readable (prefix plus sequence number),
+// which helps when a generated app's directory name becomes its module name.
+func (a *Allocator) TempDir(t testing.TB, prefix string) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), a.uniqueName(t, prefix, "-"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	return dir
+}
+
+// DBName returns a database name unique to this test, suitable for
+// PostgreSQL/MySQL databases or SQLite file names. Only lowercase letters,
+// digits, and underscores are used.
+func (a *Allocator) DBName(t testing.TB, prefix string) string {
+	t.Helper()
+	return a.uniqueName(t, prefix, "_")
+}
+
+// DBPath returns a path to a fresh SQLite database file in the test's temp directory.
+func (a *Allocator) DBPath(t testing.TB) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), a.DBName(t, "")+".db")
+}
+
+var nonIdentChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxNameLength keeps generated names within PostgreSQL's 63-byte identifier limit.
+const maxNameLength = 63
+
+// uniqueName builds prefix+sep+testname+sep+seq with all non-alphanumeric
+// characters collapsed to sep, truncated to fit maxNameLength.
+func (a *Allocator) uniqueName(t testing.TB, prefix, sep string) string {
+	base := nonIdentChars.ReplaceAllString(strings.ToLower(t.Name()), sep)
+	base = strings.Trim(base, sep)
+	if prefix != "" {
+		base = strings.Trim(nonIdentChars.ReplaceAllString(strings.ToLower(prefix), sep), sep) + sep + base
+	}
+
+	// Truncate before counting, leaving room for sep and up to six digits,
+	// so long names that differ only past the limit get different numbers.
+	if limit := maxNameLength - len(sep) - 6; len(base) > limit {
+		base = strings.TrimRight(base[:limit], sep)
+	}
+
+	a.mu.Lock()
+	seq := a.names[base]
+	a.names[base] = seq + 1
+	a.mu.Unlock()
+
+	return fmt.Sprintf("%s%s%d", base, sep, seq)
+}
+
+// AllocatePort reserves a free TCP port from the default allocator.
+func AllocatePort(t testing.TB) int {
+	t.Helper()
+	return defaultAllocator.Port(t)
+}
+
+// AllocateTempDir creates a uniquely named temp directory from the default allocator.
+func AllocateTempDir(t testing.TB, prefix string) string {
+	t.Helper()
+	return defaultAllocator.TempDir(t, prefix)
+}
+
+// AllocateDBName returns a unique database name from the default allocator.
+func AllocateDBName(t testing.TB, prefix string) string {
+	t.Helper()
+	return defaultAllocator.DBName(t, prefix)
+}
+
+// ServerURL returns the base URL a browser should use to reach a test server on port.
+// Docker Chrome reaches the host through host.docker.internal; local and shared
+// Chrome use localhost.
+func ServerURL(port int, mode ChromeMode) string {
+	if mode == ChromeDocker {
+		return GetChromeTestURL(port)
+	}
+	return fmt.Sprintf("http://localhost:%d", port)
+}
@@ -0,0 +1,133 @@
+package testing
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAllocator_PortUniqueUnderConcurrency(t *testing.T) {
+	a := NewAllocator()
+
+	const n = 32
+	ports := make(chan int, n)
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ports <- a.Port(t)
+		}()
+	}
+	wg.Wait()
+	close(ports)
+
+	seen := make(map[int]bool)
+	for p := range ports {
+		if seen[p] {
+			t.Fatalf("port %d allocated twice", p)
+		}
+		seen[p] = true
+	}
+}
+
+func TestAllocator_ReleasePort(t *testing.T) {
+	a := NewAllocator()
+
+	port, err := a.ReservePort()
+	if err != nil {
+		t.Fatalf("ReservePort() error = %v", err)
+	}
+	if _, ok := a.ports[port]; !ok {
+		t.Fatalf("port %d not tracked after ReservePort", port)
+	}
+
+	a.ReleasePort(port)
+	if _, ok := a.ports[port]; ok {
+		t.Errorf("port %d still tracked after ReleasePort", port)
+	}
+}
+
+func TestAllocator_DBName(t *testing.T) {
+	a := NewAllocator()
+
+	first := a.DBName(t, "App")
+	second := a.DBName(t, "App")
+
+	if first == second {
+		t.Errorf("DBName returned %q twice", first)
+	}
+	if !regexp.MustCompile(`^[a-z0-9_]+$`).MatchString(first) {
+		t.Errorf("DBName = %q, want only [a-z0-9_]", first)
+	}
+	if !strings.HasPrefix(first, "app_testallocator_dbname_") {
+		t.Errorf("DBName = %q, want prefix and test name", first)
+	}
+}
+
+func TestAllocator_DBNameLength(t *testing.T) {
+	a := NewAllocator()
+
+	name := a.DBName(t, strings.Repeat("long_prefix_", 10))
+	if len(name) > maxNameLength {
+		t.Errorf("len(DBName) = %d, want <= %d", len(name), maxNameLength)
+	}
+}
+
+// Names cut to the length limit must stay unique across tests.
+func TestAllocator_DBNameLongTestNames(t *testing.T) {
+	a := NewAllocator()
+
+	prefix := strings.Repeat("shared_prefix_", 6)
+	seen := map[string]string{}
+	for _, name := range []string{prefix + "first", prefix + "second"} {
+		t.Run(name, func(t *testing.T) {
+			for _, sep := range []string{"_", "-"} {
+				got := a.uniqueName(t, "", sep)
+				if len(got) > maxNameLength {
+					t.Errorf("len(%q) = %d, want <= %d", got, len(got), maxNameLength)
+				}
+				if other, ok := seen[got]; ok {
+					t.Errorf("%q given to both %s and %s", got, other, t.Name())
+				}
+				seen[got] = t.Name()
+			}
+		})
+	}
+}
+
+func TestAllocator_TempDir(t *testing.T) {
+	a := NewAllocator()
+
+	first := a.TempDir(t, "myapp")
+	second := a.TempDir(t, "myapp")
+
+	if first == second {
+		t.Fatalf("TempDir returned %q twice", first)
+	}
+	for _, dir := range []string{first, second} {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			t.Errorf("TempDir %q was not created: %v", dir, err)
+		}
+	}
+}
+
+func TestServerURL(t *testing.T) {
+	tests := []struct {
+		mode ChromeMode
+		want string
+	}{
+		{ChromeDocker, "http://host.docker.internal:8080"},
+		{ChromeLocal, "http://localhost:8080"},
+		{ChromeShared, "http://localhost:8080"},
+	}
+
+	for _, tt := range tests {
+		if got := ServerURL(8080, tt.mode); got != tt.want {
+			t.Errorf("ServerURL(8080, %q) = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
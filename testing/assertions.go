@@ -180,6 +180,22 @@ func (a *Assert) NoConsoleErrors() error {
 	return nil
 }
 
+// WireSchemaValid verifies that every WebSocket message exchanged so far
+// conforms to the versioned wire format schema.
+func (a *Assert) WireSchemaValid() error {
+	a.test.T.Helper()
+
+	if a.test.WebSocket == nil {
+		return fmt.Errorf("websocket logger not initialized")
+	}
+
+	if errs := a.test.WebSocket.ValidateSchema(); len(errs) > 0 {
+		return fmt.Errorf("found %d message(s) violating the wire schema: %v", len(errs), errs)
+	}
+
+	return nil
+}
+
 // ElementCount verifies that exactly expectedCount elements match the selector.
 func (a *Assert) ElementCount(selector string, expectedCount int) error {
 	a.test.T.Helper()
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+	"github.com/livetemplate/lvt/internal/ui/progress"
 )
 
 const (
@@ -320,21 +321,25 @@ func StartDockerChrome(t *testing.T, debugPort int) error {
 	checkCmd := exec.Command("docker", "image", "inspect", dockerImage)
 	if _, err := checkCmd.CombinedOutput(); err != nil {
 		// Image doesn't exist, try to pull with timeout
-		t.Log("Pulling chromedp/headless-shell Docker image...")
-
 		// Use a context with timeout for the pull operation
 		pullCtx, pullCancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer pullCancel()
 
-		pullCmd := exec.CommandContext(pullCtx, "docker", "pull", dockerImage)
-		// Use Output() to properly close pipes and avoid I/O wait
-		if output, err := pullCmd.CombinedOutput(); err != nil {
+		// Progress reports the pull with periodic heartbeats in the test log
+		var output []byte
+		err := progress.New(testLogWriter{t}, 0).Run("Pulling chromedp/headless-shell Docker image", func() error {
+			pullCmd := exec.CommandContext(pullCtx, "docker", "pull", dockerImage)
+			// Use CombinedOutput() to properly close pipes and avoid I/O wait
+			var err error
+			output, err = pullCmd.CombinedOutput()
+			return err
+		})
+		if err != nil {
 			if pullCtx.Err() == context.DeadlineExceeded {
 				t.Fatal("Docker pull timed out after 60 seconds")
 			}
 			t.Fatalf("Failed to pull Docker image: %v\nOutput: %s", err, output)
 		}
-		t.Log("✅ Docker image pulled successfully")
 	} else {
 		t.Log("✅ Docker image already exists, skipping pull")
 	}
@@ -468,21 +473,10 @@ func StartTestServer(t *testing.T, mainPath string, port int) *exec.Cmd {
 		t.Fatalf("Failed to start server: %v", err)
 	}
 
-	// Wait for server to be ready
-	ready := false
-	for i := 0; i < 50; i++ { // 5 seconds
-		resp, err := http.Get(serverURL)
-		if err == nil {
-			resp.Body.Close()
-			ready = true
-			break
-		}
-		time.Sleep(100 * time.Millisecond)
-	}
-
-	if !ready {
+	// Wait for the server to answer its liveness endpoint
+	if err := waitForHealth(http.DefaultClient, serverURL, HealthPath("healthz"), 5*time.Second); err != nil {
 		_ = cmd.Process.Kill()
-		t.Fatal("Server failed to start within 5 seconds")
+		t.Fatalf("Server failed to start within 5 seconds: %v", err)
 	}
 
 	// Register cleanup handler to kill server process on test completion/failure
@@ -650,25 +644,19 @@ func ServeCSS(w http.ResponseWriter, r *http.Request) {
 	w.Write(css)
 }
 
-// WaitForServer polls an HTTP server until it responds or timeout is reached.
-// This is useful when starting a custom server in tests.
+// WaitForServer polls an HTTP server's readiness endpoint, /readyz (see
+// HealthPath), until it is ready or timeout is reached. A custom server
+// without the endpoint is ready once it responds.
 func WaitForServer(t *testing.T, serverURL string, timeout time.Duration) {
 	t.Helper()
 
 	// Use a client with per-request timeout to prevent indefinite blocking
 	client := &http.Client{Timeout: 2 * time.Second}
 
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		resp, err := client.Get(serverURL)
-		if err == nil {
-			resp.Body.Close()
-			t.Logf("✅ Server ready at %s", serverURL)
-			return
-		}
-		time.Sleep(100 * time.Millisecond)
+	if err := waitForHealth(client, strings.TrimRight(serverURL, "/"), HealthPath("readyz"), timeout); err != nil {
+		t.Fatalf("Server at %s failed to become ready: %v", serverURL, err)
 	}
-	t.Fatalf("Server at %s failed to become ready within %v", serverURL, timeout)
+	t.Logf("✅ Server ready at %s", serverURL)
 }
 
 // WaitFor polls a JavaScript condition until it returns true or timeout is reached.
@@ -1032,3 +1020,13 @@ func ValidatePicoCSS() chromedp.Action {
 		return nil
 	})
 }
+
+// testLogWriter writes each line it receives to the test log.
+type testLogWriter struct {
+	t *testing.T
+}
+
+func (w testLogWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
@@ -2,6 +2,8 @@ package testing
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -180,7 +182,7 @@ func (dt *DeploymentTest) Deploy() error {
 	return nil
 }
 
-// VerifyHealth checks if the deployed app is responding
+// VerifyHealth checks that the deployed app is ready: /readyz returns 200.
 func (dt *DeploymentTest) VerifyHealth() error {
 	dt.T.Helper()
 
@@ -188,10 +190,20 @@ func (dt *DeploymentTest) VerifyHealth() error {
 		return fmt.Errorf("app URL not set")
 	}
 
-	dt.T.Logf("Verifying health at: %s", dt.AppURL)
+	healthURL := strings.TrimRight(dt.AppURL, "/") + HealthPath("readyz")
+	dt.T.Logf("Verifying health at: %s", healthURL)
 
-	// TODO: Implement HTTP health check
-	// For now, just check URL is set
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(healthURL)
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("app not ready: %s returned %d: %s", healthURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
 	return nil
 }
 
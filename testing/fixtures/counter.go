@@ -0,0 +1,88 @@
+package fixtures
+
+// CounterState is the state CounterTemplate renders.
+type CounterState struct {
+	Title       string `json:"title"`
+	Counter     int    `json:"counter"`
+	Status      string `json:"status"`
+	LastUpdated string `json:"last_updated"`
+	SessionID   string `json:"session_id"`
+}
+
+// CounterBuilder builds a CounterState. Status is derived from the counter
+// in Build: "zero", "positive" or "negative".
+type CounterBuilder struct {
+	state CounterState
+}
+
+// Counter starts a counter state with the sample app's title and session at
+// zero.
+func Counter() *CounterBuilder {
+	return &CounterBuilder{state: CounterState{
+		Title:       "Simple Counter",
+		LastUpdated: "2023-01-01 10:00:00",
+		SessionID:   "counter-12345",
+	}}
+}
+
+func (b *CounterBuilder) Title(title string) *CounterBuilder {
+	b.state.Title = title
+	return b
+}
+
+func (b *CounterBuilder) Value(counter int) *CounterBuilder {
+	b.state.Counter = counter
+	return b
+}
+
+func (b *CounterBuilder) LastUpdated(lastUpdated string) *CounterBuilder {
+	b.state.LastUpdated = lastUpdated
+	return b
+}
+
+func (b *CounterBuilder) SessionID(sessionID string) *CounterBuilder {
+	b.state.SessionID = sessionID
+	return b
+}
+
+// Build returns the state with its status filled in.
+func (b *CounterBuilder) Build() CounterState {
+	s := b.state
+	switch {
+	case s.Counter > 0:
+		s.Status = "positive"
+	case s.Counter < 0:
+		s.Status = "negative"
+	default:
+		s.Status = "zero"
+	}
+	return s
+}
+
+// CounterInitial is the counter at zero.
+func CounterInitial() CounterState { return Counter().Build() }
+
+// CounterIncremented increments the counter to 5.
+func CounterIncremented() CounterState {
+	return Counter().Value(5).LastUpdated("2023-01-01 10:05:00").Build()
+}
+
+// CounterLargeIncrement increments the counter to 25.
+func CounterLargeIncrement() CounterState {
+	return Counter().Value(25).LastUpdated("2023-01-01 10:10:00").Build()
+}
+
+// CounterDecremented decrements the counter to 10.
+func CounterDecremented() CounterState {
+	return Counter().Value(10).LastUpdated("2023-01-01 10:15:00").Build()
+}
+
+// CounterNegative takes the counter below zero.
+func CounterNegative() CounterState {
+	return Counter().Value(-3).LastUpdated("2023-01-01 10:20:00").Build()
+}
+
+// CounterReset resets the counter to zero.
+func CounterReset() CounterState {
+	return Counter().LastUpdated("2023-01-01 10:25:00").Build()
+}
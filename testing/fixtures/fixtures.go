@@ -0,0 +1,47 @@
+/*
+Package fixtures provides the canonical sample apps lvt's own tests render —
+a todo list, a counter and a component-based todo list — as embedded
+templates plus builders for their states, so tests share them instead of
+redeclaring the state structs.
+
+	tmpl := livetemplate.Must(livetemplate.New("todos"))
+	if _, err := fixtures.Parse(tmpl, fixtures.TodosTemplate); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, fixtures.TodosAdded())
+
+Each named state (TodosInitial, TodosAdded, ..., CounterReset) is one step of
+the sequence the golden files in lvt's e2e tests were recorded from; the
+builders (Todos, Counter) make variations of them.
+*/
+package fixtures
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/livetemplate/livetemplate"
+)
+
+// Template files in Templates.
+const (
+	TodosTemplate      = "templates/todos.tmpl"
+	CounterTemplate    = "templates/counter.tmpl"
+	ComponentsTemplate = "templates/components.tmpl"
+)
+
+// Templates holds the sample apps' templates.
+//
+//go:embed templates/*.tmpl
+var Templates embed.FS
+
+// Parse parses the sample template file into tmpl, the way tmpl.ParseFiles
+// would parse it from disk.
+func Parse(tmpl *livetemplate.Template, file string) (*livetemplate.Template, error) {
+	content, err := Templates.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("fixtures: %w", err)
+	}
+	return tmpl.Parse(string(content))
+}
@@ -0,0 +1,73 @@
+package fixtures
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/livetemplate"
+)
+
+func TestTodosBuildDerivesCounts(t *testing.T) {
+	s := TodosMultipleOps()
+	if s.TodoCount != 4 || s.CompletedCount != 2 || s.RemainingCount != 2 || s.CompletionRate != 50 {
+		t.Errorf("counts = %d/%d/%d rate %d", s.TodoCount, s.CompletedCount, s.RemainingCount, s.CompletionRate)
+	}
+	if s := TodosAdded(); s.CompletionRate != 33 {
+		t.Errorf("CompletionRate = %d, want 33", s.CompletionRate)
+	}
+	if s := TodosInitial(); s.Todos == nil || s.CompletionRate != 0 {
+		t.Errorf("initial state = %+v", s)
+	}
+}
+
+func TestBuildersDoNotShareItems(t *testing.T) {
+	b := Todos().Items(TodoItem{ID: "a"})
+	first := b.Build()
+	first.Todos[0].Completed = true
+	if b.Build().Todos[0].Completed {
+		t.Error("changing a built state changed the builder")
+	}
+}
+
+func TestCounterStatus(t *testing.T) {
+	for _, tt := range []struct {
+		state CounterState
+		want  string
+	}{
+		{CounterInitial(), "zero"},
+		{CounterIncremented(), "positive"},
+		{CounterNegative(), "negative"},
+	} {
+		if tt.state.Status != tt.want {
+			t.Errorf("counter %d status = %q, want %q", tt.state.Counter, tt.state.Status, tt.want)
+		}
+	}
+}
+
+func TestTemplatesRender(t *testing.T) {
+	tests := []struct {
+		file  string
+		state any
+		want  string
+	}{
+		{TodosTemplate, TodosAdded(), "Learn Go templates"},
+		{CounterTemplate, CounterNegative(), "Status: negative"},
+		{ComponentsTemplate, ComponentsUpdated(), "Verify flattening works"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			tmpl, err := Parse(livetemplate.Must(livetemplate.New("fixture")), tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, tt.state); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("rendered output missing %q", tt.want)
+			}
+		})
+	}
+}
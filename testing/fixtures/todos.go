@@ -0,0 +1,165 @@
+package fixtures
+
+// TodoItem is one entry of the todo list app.
+type TodoItem struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	Completed bool   `json:"completed"`
+	Priority  string `json:"priority,omitempty"`
+}
+
+// TodosState is the state TodosTemplate and ComponentsTemplate render.
+type TodosState struct {
+	Title          string     `json:"title"`
+	Counter        int        `json:"counter"`
+	Todos          []TodoItem `json:"todos"`
+	TodoCount      int        `json:"todo_count"`
+	CompletedCount int        `json:"completed_count"`
+	RemainingCount int        `json:"remaining_count"`
+	CompletionRate int        `json:"completion_rate"`
+	LastUpdated    string     `json:"last_updated"`
+	SessionID      string     `json:"session_id"`
+}
+
+// TodosBuilder builds a TodosState. The counts and completion rate are
+// derived from the todos in Build, so they always agree.
+type TodosBuilder struct {
+	state TodosState
+}
+
+// Todos starts a todo list state with the sample app's title and session,
+// counter 1 and no todos.
+func Todos() *TodosBuilder {
+	return &TodosBuilder{state: TodosState{
+		Title:       "Task Manager",
+		Counter:     1,
+		LastUpdated: "2023-01-01 10:00:00",
+		SessionID:   "session-12345",
+	}}
+}
+
+func (b *TodosBuilder) Title(title string) *TodosBuilder {
+	b.state.Title = title
+	return b
+}
+
+func (b *TodosBuilder) Counter(counter int) *TodosBuilder {
+	b.state.Counter = counter
+	return b
+}
+
+// Items replaces the todos, in display order.
+func (b *TodosBuilder) Items(items ...TodoItem) *TodosBuilder {
+	b.state.Todos = append([]TodoItem{}, items...)
+	return b
+}
+
+func (b *TodosBuilder) LastUpdated(lastUpdated string) *TodosBuilder {
+	b.state.LastUpdated = lastUpdated
+	return b
+}
+
+func (b *TodosBuilder) SessionID(sessionID string) *TodosBuilder {
+	b.state.SessionID = sessionID
+	return b
+}
+
+// Build returns the state with its counts filled in. The todos are copied,
+// so the builder can be reused.
+func (b *TodosBuilder) Build() TodosState {
+	s := b.state
+	s.Todos = append([]TodoItem{}, b.state.Todos...)
+	s.TodoCount = len(s.Todos)
+	s.CompletedCount = 0
+	for _, todo := range s.Todos {
+		if todo.Completed {
+			s.CompletedCount++
+		}
+	}
+	s.RemainingCount = s.TodoCount - s.CompletedCount
+	s.CompletionRate = 0
+	if s.TodoCount > 0 {
+		s.CompletionRate = s.CompletedCount * 100 / s.TodoCount
+	}
+	return s
+}
+
+// The todos of the canonical sequence, by ID.
+var (
+	todoLearnGo   = TodoItem{ID: "todo-1", Text: "Learn Go templates", Priority: "High"}
+	todoLiveUpd   = TodoItem{ID: "todo-2", Text: "Build live updates", Completed: true, Priority: "Medium"}
+	todoWriteDocs = TodoItem{ID: "todo-3", Text: "Write documentation", Priority: "Low"}
+	todoSetupEnv  = TodoItem{ID: "todo-4", Text: "Setup development environment", Priority: "High"}
+	todoCICD      = TodoItem{ID: "todo-5", Text: "Configure CI/CD pipeline", Priority: "Medium"}
+	todoDeploy    = TodoItem{ID: "todo-6", Text: "Deploy to production", Priority: "Critical"}
+	todoMonitor   = TodoItem{ID: "todo-7", Text: "Monitor performance", Priority: "Medium"}
+)
+
+func completed(item TodoItem) TodoItem {
+	item.Completed = true
+	return item
+}
+
+// TodosInitial is the empty list the sequence starts from.
+func TodosInitial() TodosState {
+	return Todos().Build()
+}
+
+// TodosAdded adds three todos, one of them completed.
+func TodosAdded() TodosState {
+	return Todos().Counter(3).LastUpdated("2023-01-01 10:15:00").
+		Items(todoLearnGo, todoLiveUpd, todoWriteDocs).Build()
+}
+
+// TodosRemoved removes the completed todo; the counter reaches "High Activity".
+func TodosRemoved() TodosState {
+	return Todos().Counter(8).LastUpdated("2023-01-01 10:30:00").
+		Items(todoLearnGo, todoWriteDocs).Build()
+}
+
+// TodosCompleted completes one todo. The items are in reverse alphabetical
+// order so TodosSorted reorders them.
+func TodosCompleted() TodosState {
+	return Todos().Counter(8).LastUpdated("2023-01-01 10:45:00").
+		Items(todoWriteDocs, completed(todoLearnGo)).Build()
+}
+
+// TodosSorted sorts the todos alphabetically without changing them.
+func TodosSorted() TodosState {
+	return Todos().Counter(8).LastUpdated("2023-01-01 10:50:00").
+		Items(completed(todoLearnGo), todoWriteDocs).Build()
+}
+
+// TodosInsertedStart inserts one todo at the start of the list.
+func TodosInsertedStart() TodosState {
+	return Todos().Counter(9).LastUpdated("2023-01-01 11:00:00").
+		Items(todoSetupEnv, completed(todoLearnGo), todoWriteDocs).Build()
+}
+
+// TodosInsertedMiddle inserts one todo between two existing ones.
+func TodosInsertedMiddle() TodosState {
+	return Todos().Counter(10).LastUpdated("2023-01-01 11:15:00").
+		Items(todoSetupEnv, todoCICD, completed(todoLearnGo), todoWriteDocs).Build()
+}
+
+// TodosMultipleOps removes two todos, completes one and adds two in a
+// single update.
+func TodosMultipleOps() TodosState {
+	return Todos().Counter(11).LastUpdated("2023-01-01 11:30:00").
+		Items(completed(todoSetupEnv), completed(todoLearnGo), todoDeploy, todoMonitor).Build()
+}
+
+// ComponentsInitial is the empty list ComponentsTemplate starts from.
+func ComponentsInitial() TodosState {
+	return Todos().Title("Component Test").SessionID("comp-12345").Build()
+}
+
+// ComponentsUpdated adds two todos to ComponentsInitial.
+func ComponentsUpdated() TodosState {
+	return Todos().Title("Component Test").SessionID("comp-12345").
+		Counter(5).LastUpdated("2023-01-01 10:15:00").
+		Items(
+			TodoItem{ID: "todo-1", Text: "Test component templates"},
+			TodoItem{ID: "todo-2", Text: "Verify flattening works", Completed: true},
+		).Build()
+}
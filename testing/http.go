@@ -102,11 +102,7 @@ func SetupHTTP(t *testing.T, opts *HTTPSetupOptions) *HTTPTest {
 	// Allocate port
 	port := opts.Port
 	if port == 0 {
-		var err error
-		port, err = GetFreePort()
-		if err != nil {
-			t.Fatalf("Failed to allocate port: %v", err)
-		}
+		port = AllocatePort(t)
 	}
 
 	// Create cookie jar for session persistence
@@ -559,24 +555,41 @@ func (h *HTTPTest) ClearCookies() {
 	h.Client.Jar = jar
 }
 
-// WaitForServer waits for the server to be ready by polling the health endpoint.
+// WaitForServer waits for the server to be ready by polling its readiness
+// endpoint, /readyz (see HealthPath).
 func (h *HTTPTest) WaitForServer(timeout time.Duration) error {
+	return waitForHealth(h.Client, h.BaseURL, HealthPath("readyz"), timeout)
+}
+
+// HealthPath returns the path of a generated app's health endpoint, "healthz"
+// (liveness) or "readyz" (readiness), under HEALTH_PATH_PREFIX as the app
+// reads it from the environment.
+func HealthPath(name string) string {
+	return strings.TrimRight(os.Getenv("HEALTH_PATH_PREFIX"), "/") + "/" + name
+}
+
+// waitForHealth polls path on baseURL until it returns 200. A server without
+// the endpoint answers 404, and is up once it answers at all.
+func waitForHealth(client *http.Client, baseURL, path string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
-	interval := 50 * time.Millisecond
+	last := "no response"
 
 	for time.Now().Before(deadline) {
-		resp, err := h.Client.Get(h.URL("/health"))
-		if err == nil && resp.StatusCode == http.StatusOK {
-			resp.Body.Close()
-			return nil
-		}
-		if resp != nil {
+		resp, err := client.Get(baseURL + path)
+		if err != nil {
+			last = err.Error()
+		} else {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
 			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			last = fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
 		}
-		time.Sleep(interval)
+		time.Sleep(50 * time.Millisecond)
 	}
 
-	return fmt.Errorf("server not ready after %v", timeout)
+	return fmt.Errorf("server not ready after %v (%s %s)", timeout, path, last)
 }
 
 // SetDB sets the database connection for state verification.
@@ -584,10 +597,10 @@ func (h *HTTPTest) SetDB(db *sql.DB) {
 	h.DB = db
 }
 
-// DBPath returns the path to the test database.
-// This assumes TEST_MODE=1 is set, which uses :memory: by default.
+// DBPath returns the path to the test database: DATABASE_PATH_TEST, or
+// :memory:, which apps use when LVT_ENV=test.
 func (h *HTTPTest) DBPath() string {
-	dbPath := os.Getenv("DATABASE_PATH")
+	dbPath := os.Getenv("DATABASE_PATH_TEST")
 	if dbPath == "" {
 		dbPath = ":memory:"
 	}
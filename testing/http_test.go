@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestHTTPResponse_String(t *testing.T) {
@@ -540,3 +543,44 @@ func TestHTTPTest_FullWorkflow(t *testing.T) {
 		assert.Contains(t, "Success!")
 	})
 }
+
+func TestWaitForHealth(t *testing.T) {
+	t.Setenv("HEALTH_PATH_PREFIX", "/_/")
+	if path := HealthPath("readyz"); path != "/_/readyz" {
+		t.Fatalf("HealthPath(readyz) = %q, want /_/readyz", path)
+	}
+
+	// Not ready for the first two polls, e.g. while migrations run
+	var polls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) <= 2 {
+			http.Error(w, `{"status":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	if err := waitForHealth(http.DefaultClient, server.URL, HealthPath("readyz"), 5*time.Second); err != nil {
+		t.Fatalf("waitForHealth() = %v", err)
+	}
+	if n := polls.Load(); n != 3 {
+		t.Errorf("polled /readyz %d times, want 3", n)
+	}
+
+	// A server without the endpoint is ready once it answers
+	if err := waitForHealth(http.DefaultClient, server.URL, "/readyz", time.Second); err != nil {
+		t.Errorf("waitForHealth() without endpoint = %v", err)
+	}
+
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "pending migrations", http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	err := waitForHealth(http.DefaultClient, unavailable.URL, "/readyz", 200*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "pending migrations") {
+		t.Errorf("waitForHealth() on unavailable server = %v, want the readiness failure", err)
+	}
+}
@@ -139,8 +139,8 @@ func (d *DockerClient) WaitForReady(timeout time.Duration) error {
 		// Check container status
 		status, err := d.Status()
 		if err == nil && status.Running {
-			// Try HTTP health check - use /health endpoint to avoid template errors
-			path := "/health"
+			// Try HTTP health check - use the readiness endpoint to avoid template errors
+			path := "/readyz"
 			resp, err := http.Get(baseURL + path)
 			if err == nil {
 				defer resp.Body.Close()
@@ -0,0 +1,349 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/livetemplate/lvt/internal/wire"
+)
+
+// Session is a recorded WebSocket conversation, stored as a JSON fixture.
+// Replaying it sends the recorded client messages to a live server and
+// compares the server's responses with the recorded ones, so changes to the
+// wire format (e.g. after a livetemplate upgrade) show up as test failures.
+type Session struct {
+	Name        string  `json:"name"`
+	Path        string  `json:"path"`         // URL path the session connected to, e.g. "/posts"
+	WireVersion string  `json:"wire_version"` // wire format version the session was recorded with
+	Frames      []Frame `json:"frames"`
+}
+
+// Frame is a single recorded WebSocket message.
+type Frame struct {
+	Direction string          `json:"direction"` // "sent" or "received"
+	Data      json.RawMessage `json:"data"`
+}
+
+// ReplayOptions configures ReplaySession.
+type ReplayOptions struct {
+	// StructureOnly compares the shape of server messages (keys, statics,
+	// range operation kinds, value types) but not dynamic values. Use it for
+	// apps whose output contains generated IDs or timestamps.
+	StructureOnly bool
+
+	// Timeout for each server response (default 5s).
+	Timeout time.Duration
+
+	// Compress offers permessage-deflate when connecting, and fails the
+	// replay if the server does not accept it. Messages are compared as
+	// the JSON they carry either way.
+	Compress bool
+}
+
+// Session converts the logged JSON messages into a replayable session.
+func (wl *WSMessageLogger) Session(name, path string) *Session {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	s := &Session{Name: name, Path: path, WireVersion: wire.Version}
+	for _, msg := range wl.messages {
+		if msg.Type != "json" {
+			continue
+		}
+		s.Frames = append(s.Frames, Frame{Direction: msg.Direction, Data: json.RawMessage(msg.Data)})
+	}
+	return s
+}
+
+// LoadSession reads a session fixture.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return &s, nil
+}
+
+// Save writes the session as an indented JSON fixture.
+func (s *Session) Save(path string) error {
+	for i, f := range s.Frames {
+		if !json.Valid(f.Data) {
+			return fmt.Errorf("frame %d is not valid JSON", i)
+		}
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReplaySession connects to baseURL+session.Path, sends the session's client
+// messages in order, and reads one server message for every recorded one.
+// It returns the session as observed (same sent frames, actual received
+// frames) and the differences from the recording. Each received message is
+// also validated against the wire schema.
+func ReplaySession(baseURL string, session *Session, opts ReplayOptions) (*Session, []string, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(strings.TrimSuffix(baseURL, "/"), "http") + session.Path
+	dialer := *websocket.DefaultDialer
+	dialer.EnableCompression = opts.Compress
+	conn, resp, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		if resp != nil {
+			return nil, nil, fmt.Errorf("failed to connect to %s: %w (HTTP %d)", wsURL, err, resp.StatusCode)
+		}
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", wsURL, err)
+	}
+	defer conn.Close()
+	if opts.Compress && !strings.Contains(resp.Header.Get("Sec-WebSocket-Extensions"), wire.DeflateExtension) {
+		return nil, nil, fmt.Errorf("%s did not accept %s", wsURL, wire.DeflateExtension)
+	}
+
+	actual := &Session{Name: session.Name, Path: session.Path, WireVersion: wire.Version}
+	var diffs []string
+	for i, frame := range session.Frames {
+		switch frame.Direction {
+		case "sent":
+			if err := conn.WriteMessage(websocket.TextMessage, frame.Data); err != nil {
+				return nil, nil, fmt.Errorf("frame %d: failed to send: %w", i, err)
+			}
+			actual.Frames = append(actual.Frames, frame)
+
+		case "received":
+			_ = conn.SetReadDeadline(time.Now().Add(opts.Timeout))
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return nil, nil, fmt.Errorf("frame %d: failed to read server message: %w", i, err)
+			}
+			if msg, _, err := wire.Decode(data); err == nil {
+				data = msg
+			}
+			actual.Frames = append(actual.Frames, Frame{Direction: "received", Data: data})
+
+			if err := wire.ValidateServerMessage(data); err != nil {
+				diffs = append(diffs, fmt.Sprintf("frame %d: violates wire schema %s: %v", i, wire.Version, err))
+			}
+			for _, d := range CompareFrames(frame.Data, data, opts.StructureOnly) {
+				diffs = append(diffs, fmt.Sprintf("frame %d: %s", i, d))
+			}
+
+		default:
+			return nil, nil, fmt.Errorf("frame %d: unknown direction %q", i, frame.Direction)
+		}
+	}
+	return actual, diffs, nil
+}
+
+// ReplayFixtures replays every *.json session in dir as a subtest. newServer
+// starts a fresh server for each session and returns its base URL, so
+// sessions don't share state.
+//
+// Run with UPDATE_GOLDEN=1 to re-record the server messages of every
+// fixture after an intentional wire format change.
+func ReplayFixtures(t *testing.T, dir string, newServer func(t *testing.T) string, opts ReplayOptions) {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list fixtures: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("No session fixtures found in %s", dir)
+	}
+
+	update := os.Getenv("UPDATE_GOLDEN") == "1"
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			session, err := LoadSession(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, diffs, err := ReplaySession(newServer(t), session, opts)
+			if err != nil {
+				t.Fatalf("Replay failed: %v", err)
+			}
+
+			if update {
+				if err := actual.Save(path); err != nil {
+					t.Fatalf("Failed to update fixture: %v", err)
+				}
+				t.Logf("Updated %s", path)
+				return
+			}
+
+			if len(diffs) > 0 {
+				t.Errorf("Wire output differs from %s (recorded with wire format %s):\n  %s\n"+
+					"If the change is intended, run with UPDATE_GOLDEN=1 to re-record.",
+					path, session.WireVersion, strings.Join(diffs, "\n  "))
+			}
+		})
+	}
+}
+
+// CompareFrames compares a recorded server message with an actual one and
+// describes each difference by JSON path. Both are compared in canonical form
+// (see CanonicalUpdate). With structureOnly, scalar values are compared by
+// type only; statics, range operation kinds, and metadata are always compared
+// exactly. Compressed frames (see wire.Decode) are compared as their JSON.
+func CompareFrames(recorded, actual []byte, structureOnly bool) []string {
+	if msg, _, err := wire.Decode(recorded); err == nil {
+		recorded = msg
+	}
+	if msg, _, err := wire.Decode(actual); err == nil {
+		actual = msg
+	}
+	var want, got any
+	if err := json.Unmarshal(recorded, &want); err != nil {
+		return []string{fmt.Sprintf("recorded frame is not JSON: %v", err)}
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		return []string{fmt.Sprintf("actual frame is not JSON: %v", err)}
+	}
+	c := frameComparer{structureOnly: structureOnly}
+	c.compare("$", wire.Canonical(want), wire.Canonical(got), false)
+	return c.diffs
+}
+
+// CanonicalUpdate re-encodes an update (ExecuteUpdates output or a WebSocket
+// frame) in canonical form: object keys in a stable order, dynamic positions
+// numerically, and range operations whose order is not observable — runs of
+// removes or of updates — sorted by item ID. Store and compare golden
+// updates in this form so they don't depend on map iteration order.
+func CanonicalUpdate(update []byte) ([]byte, error) {
+	return wire.CanonicalJSON(update)
+}
+
+type frameComparer struct {
+	structureOnly bool
+	diffs         []string
+}
+
+func (c *frameComparer) addf(path, format string, args ...any) {
+	c.diffs = append(c.diffs, path+": "+fmt.Sprintf(format, args...))
+}
+
+// compare walks both values. exact forces value comparison even in
+// structure-only mode (used for statics, op kinds, and meta).
+func (c *frameComparer) compare(path string, want, got any, exact bool) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			c.addf(path, "expected object, got %s", describeJSON(got))
+			return
+		}
+		for _, key := range sortedKeys(w) {
+			gv, present := g[key]
+			if !present {
+				c.addf(path, "missing key %q", key)
+				continue
+			}
+			childExact := exact || key == wire.KeyStatics || key == wire.KeyMetadata || (path == "$" && key == "meta")
+			c.compare(path+"."+key, w[key], gv, childExact)
+		}
+		for _, key := range sortedKeys(g) {
+			if _, present := w[key]; !present {
+				c.addf(path, "unexpected key %q", key)
+			}
+		}
+
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			c.addf(path, "expected array, got %s", describeJSON(got))
+			return
+		}
+		if len(w) != len(g) {
+			c.addf(path, "expected %d items, got %d", len(w), len(g))
+			return
+		}
+		for i := range w {
+			// The first element of a range operation is its kind
+			childExact := exact || (i == 0 && isRangeOp(w))
+			c.compare(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], childExact)
+		}
+
+	default:
+		if c.structureOnly && !exact {
+			if describeJSON(want) != describeJSON(got) {
+				c.addf(path, "expected %s, got %s", describeJSON(want), describeJSON(got))
+			}
+			return
+		}
+		if !reflect.DeepEqual(want, got) {
+			c.addf(path, "expected %s, got %s", jsonString(want), jsonString(got))
+		}
+	}
+}
+
+func isRangeOp(arr []any) bool {
+	kind, ok := arr[0].(string)
+	if !ok {
+		return false
+	}
+	for _, k := range wire.RangeOpKinds {
+		if string(k) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func describeJSON(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func jsonString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return truncate(string(data), 80)
+}
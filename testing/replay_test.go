@@ -0,0 +1,172 @@
+package testing
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/livetemplate"
+
+	"github.com/livetemplate/lvt/internal/wire"
+)
+
+// The replay fixtures in testdata/wire pin the wire output of the
+// livetemplate version in go.mod. After upgrading livetemplate, a failure
+// here means the format changed; re-record with UPDATE_GOLDEN=1 only if the
+// change is intended (and bump internal/wire.Version if the schema changed).
+
+type replayItem struct {
+	ID   string
+	Name string
+}
+
+type replayState struct {
+	Title   string
+	Count   int
+	Visible bool
+	Items   []replayItem
+}
+
+type replayController struct{}
+
+func (replayController) Increment(state replayState, _ *livetemplate.Context) (replayState, error) {
+	state.Count++
+	return state, nil
+}
+
+func (replayController) Toggle(state replayState, _ *livetemplate.Context) (replayState, error) {
+	state.Visible = !state.Visible
+	return state, nil
+}
+
+func (replayController) AddItem(state replayState, ctx *livetemplate.Context) (replayState, error) {
+	items := make([]replayItem, 0, len(state.Items)+1)
+	items = append(items, state.Items...)
+	state.Items = append(items, replayItem{ID: ctx.GetString("id"), Name: ctx.GetString("name")})
+	return state, nil
+}
+
+func (replayController) RemoveItem(state replayState, ctx *livetemplate.Context) (replayState, error) {
+	id := ctx.GetString("id")
+	items := make([]replayItem, 0, len(state.Items))
+	for _, item := range state.Items {
+		if item.ID != id {
+			items = append(items, item)
+		}
+	}
+	state.Items = items
+	return state, nil
+}
+
+func (replayController) RenameItem(state replayState, ctx *livetemplate.Context) (replayState, error) {
+	// Copy so the previous state's items stay intact for diffing
+	items := append([]replayItem(nil), state.Items...)
+	for i := range items {
+		if items[i].ID == ctx.GetString("id") {
+			items[i].Name = ctx.GetString("name")
+		}
+	}
+	state.Items = items
+	return state, nil
+}
+
+func (replayController) Reverse(state replayState, _ *livetemplate.Context) (replayState, error) {
+	n := len(state.Items)
+	reversed := make([]replayItem, n)
+	for i, item := range state.Items {
+		reversed[n-1-i] = item
+	}
+	state.Items = reversed
+	return state, nil
+}
+
+func newReplayServer(t *testing.T) string {
+	t.Helper()
+	return startReplayServer(t)
+}
+
+// newCompressingReplayServer accepts permessage-deflate, as apps set up
+// with lvt gen compression do.
+func newCompressingReplayServer(t *testing.T) string {
+	t.Helper()
+	return startReplayServer(t, func(c *livetemplate.Config) {
+		c.Upgrader.EnableCompression = true
+	})
+}
+
+func startReplayServer(t *testing.T, opts ...livetemplate.Option) string {
+	t.Helper()
+	opts = append([]livetemplate.Option{livetemplate.WithParseFiles("testdata/replay.tmpl")}, opts...)
+	tmpl, err := livetemplate.New("replay", opts...)
+	if err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	state := &replayState{
+		Title:   "Replay",
+		Visible: true,
+		Items:   []replayItem{{ID: "item-1", Name: "Alpha"}, {ID: "item-2", Name: "Beta"}, {ID: "item-3", Name: "Gamma"}},
+	}
+	server := httptest.NewServer(tmpl.Handle(replayController{}, livetemplate.AsState(state)))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestWireReplay(t *testing.T) {
+	ReplayFixtures(t, "testdata/wire", newReplayServer, ReplayOptions{})
+}
+
+// TestWireReplayCompressed replays the same fixtures over a connection that
+// deflates its messages: they carry the same JSON.
+func TestWireReplayCompressed(t *testing.T) {
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		t.Skip("fixtures are recorded by TestWireReplay")
+	}
+	ReplayFixtures(t, "testdata/wire", newCompressingReplayServer, ReplayOptions{Compress: true})
+
+	session, err := LoadSession(filepath.Join("testdata", "wire", "counter.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ReplaySession(newReplayServer(t), session, ReplayOptions{Compress: true}); err == nil || !strings.Contains(err.Error(), "permessage-deflate") {
+		t.Errorf("replay with Compress against a server without compression = %v, want it refused", err)
+	}
+}
+
+func TestCompareFrames(t *testing.T) {
+	recorded := `{"tree":{"0":"5","1":[["r","item-2"]],"s":["<p>","</p>"]},"meta":{"success":true,"errors":{}}}`
+
+	if diffs := CompareFrames([]byte(recorded), []byte(recorded), false); len(diffs) != 0 {
+		t.Errorf("identical frames should not differ: %v", diffs)
+	}
+
+	changedValue := strings.Replace(recorded, `"0":"5"`, `"0":"6"`, 1)
+	if diffs := CompareFrames([]byte(recorded), []byte(changedValue), false); len(diffs) != 1 || !strings.Contains(diffs[0], "$.tree.0") {
+		t.Errorf("expected one diff at $.tree.0, got %v", diffs)
+	}
+	if diffs := CompareFrames([]byte(recorded), []byte(changedValue), true); len(diffs) != 0 {
+		t.Errorf("structure-only comparison should ignore values: %v", diffs)
+	}
+
+	deflated := wire.Deflate([]byte(recorded))
+	if diffs := CompareFrames([]byte(recorded), deflated, false); len(diffs) != 0 {
+		t.Errorf("a deflated frame should compare as its JSON: %v", diffs)
+	}
+	if diffs := CompareFrames(wire.Gzip([]byte(changedValue)), []byte(recorded), false); len(diffs) != 1 {
+		t.Errorf("expected one diff against a gzipped frame, got %v", diffs)
+	}
+
+	for name, actual := range map[string]string{
+		"statics":  strings.Replace(recorded, `"</p>"`, `"</div>"`, 1),
+		"op kind":  strings.Replace(recorded, `["r","item-2"]`, `["u","item-2"]`, 1),
+		"new key":  strings.Replace(recorded, `"0":"5"`, `"0":"5","2":"x"`, 1),
+		"meta":     strings.Replace(recorded, `"success":true`, `"success":false`, 1),
+		"dropped":  strings.Replace(recorded, `,"meta":{"success":true,"errors":{}}`, ``, 1),
+		"reshaped": strings.Replace(recorded, `"0":"5"`, `"0":{"0":"5"}`, 1),
+	} {
+		if diffs := CompareFrames([]byte(recorded), []byte(actual), true); len(diffs) == 0 {
+			t.Errorf("%s: structure-only comparison should report a difference", name)
+		}
+	}
+}
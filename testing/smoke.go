@@ -127,33 +127,39 @@ func testHTTPRoot(appURL string, opts *SmokeTestOptions) error {
 }
 
 // Test 2: Health Endpoint
+// The app must be live (/healthz) and ready to serve traffic (/readyz).
 func testHealthEndpoint(appURL string, opts *SmokeTestOptions) error {
-	healthURL := fmt.Sprintf("%s/health", strings.TrimRight(appURL, "/"))
+	for _, name := range []string{"healthz", "readyz"} {
+		healthURL := strings.TrimRight(appURL, "/") + HealthPath(name)
 
-	return retryHTTP(opts, func() error {
-		resp, err := http.Get(healthURL)
-		if err != nil {
-			return fmt.Errorf("failed to GET %s: %w", healthURL, err)
-		}
-		defer resp.Body.Close()
+		err := retryHTTP(opts, func() error {
+			resp, err := http.Get(healthURL)
+			if err != nil {
+				return fmt.Errorf("failed to GET %s: %w", healthURL, err)
+			}
+			defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("expected status 200, got %d", resp.StatusCode)
-		}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return fmt.Errorf("failed to read response body: %w", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("%s: expected status 200, got %d: %s", healthURL, resp.StatusCode, strings.TrimSpace(string(body)))
+			}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
-		}
+			// Check for expected response (case-insensitive)
+			bodyStr := strings.ToLower(string(body))
+			if !strings.Contains(bodyStr, "ok") && !strings.Contains(bodyStr, "healthy") {
+				return fmt.Errorf("unexpected health response: %s", string(body))
+			}
 
-		// Check for expected response (case-insensitive)
-		bodyStr := strings.ToLower(string(body))
-		if !strings.Contains(bodyStr, "ok") && !strings.Contains(bodyStr, "healthy") {
-			return fmt.Errorf("unexpected health response: %s", string(body))
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-
-		return nil
-	})
+	}
+	return nil
 }
 
 // Test 3: Static Assets
@@ -21,7 +21,6 @@ type E2ETest struct {
 	ServerCmd  *exec.Cmd
 	AppDir     string
 	AppPath    string
-	serverURL  string
 
 	// Loggers for debugging
 	Console   *ConsoleLogger
@@ -86,17 +85,10 @@ func Setup(t *testing.T, opts *SetupOptions) *E2ETest {
 	// Allocate ports
 	serverPort := opts.Port
 	if serverPort == 0 {
-		var err error
-		serverPort, err = GetFreePort()
-		if err != nil {
-			t.Fatalf("Failed to allocate server port: %v", err)
-		}
+		serverPort = AllocatePort(t)
 	}
 
-	chromePort, err := GetFreePort()
-	if err != nil {
-		t.Fatalf("Failed to allocate Chrome port: %v", err)
-	}
+	chromePort := AllocatePort(t)
 
 	// Start server
 	serverCmd := StartTestServer(t, opts.AppPath, serverPort)
@@ -168,7 +160,6 @@ func Setup(t *testing.T, opts *SetupOptions) *E2ETest {
 		ChromeMode: opts.ChromeMode,
 		ServerCmd:  serverCmd,
 		AppPath:    opts.AppPath,
-		serverURL:  fmt.Sprintf("http://localhost:%d", serverPort),
 		Console:    consoleLogger,
 		Server:     serverLogger,
 		WebSocket:  wsLogger,
@@ -226,20 +217,11 @@ func (e *E2ETest) Navigate(path string) error {
 // For Docker Chrome, this uses GetChromeTestURL to handle host.docker.internal.
 // For local Chrome, this uses localhost.
 func (e *E2ETest) URL(path string) string {
-	// For Docker Chrome, use GetChromeTestURL which handles host.docker.internal
-	if e.ChromeMode == ChromeDocker {
-		baseURL := GetChromeTestURL(e.ServerPort)
-		if path == "" || path == "/" {
-			return baseURL
-		}
-		return baseURL + path
-	}
-
-	// For local Chrome, use localhost
+	baseURL := ServerURL(e.ServerPort, e.ChromeMode)
 	if path == "" || path == "/" {
-		return e.serverURL
+		return baseURL
 	}
-	return e.serverURL + path
+	return baseURL + path
 }
 
 // DockerChromeContext provides a Docker Chrome context for tests that manage their own server.
@@ -251,7 +233,7 @@ func (e *E2ETest) URL(path string) string {
 //	defer cleanup()
 //
 //	// Start your custom server on a free port
-//	port, _ := e2etest.GetFreePort()
+//	port := e2etest.AllocatePort(t)
 //	// ... start server ...
 //
 //	// Use GetChromeTestURL for Docker Chrome to access host
@@ -268,10 +250,7 @@ type DockerChromeContext struct {
 func SetupDockerChrome(t *testing.T, timeout time.Duration) (*DockerChromeContext, func()) {
 	t.Helper()
 
-	chromePort, err := GetFreePort()
-	if err != nil {
-		t.Fatalf("Failed to allocate Chrome port: %v", err)
-	}
+	chromePort := AllocatePort(t)
 
 	if err := StartDockerChrome(t, chromePort); err != nil {
 		t.Fatalf("Failed to start Docker Chrome: %v", err)
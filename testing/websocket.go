@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+
+	"github.com/livetemplate/lvt/internal/wire"
 )
 
 type WSMessageLogger struct {
@@ -22,6 +25,7 @@ type WSMessage struct {
 	Direction string
 	Type      string
 	Data      string
+	Encoding  string // wire.EncodingJSON, or the compression Data was decoded from
 	Parsed    map[string]interface{}
 }
 
@@ -43,6 +47,9 @@ func (wl *WSMessageLogger) Start(ctx context.Context) {
 				Direction: "sent",
 				Data:      ev.Response.PayloadData,
 			}
+			if ev.Response.Opcode == 2 {
+				msg.Data = decodeBinaryFrame(msg.Data)
+			}
 
 			msg.parseData()
 			wl.messages = append(wl.messages, msg)
@@ -56,6 +63,9 @@ func (wl *WSMessageLogger) Start(ctx context.Context) {
 				Direction: "received",
 				Data:      ev.Response.PayloadData,
 			}
+			if ev.Response.Opcode == 2 {
+				msg.Data = decodeBinaryFrame(msg.Data)
+			}
 
 			msg.parseData()
 			wl.messages = append(wl.messages, msg)
@@ -63,7 +73,22 @@ func (wl *WSMessageLogger) Start(ctx context.Context) {
 	})
 }
 
+// decodeBinaryFrame returns the payload of a binary frame, which the
+// DevTools protocol reports base64-encoded.
+func decodeBinaryFrame(payload string) string {
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return payload
+	}
+	return string(data)
+}
+
 func (m *WSMessage) parseData() {
+	// Messages compressed by the app, rather than by the WebSocket
+	// extension the browser already undid, are checked as their JSON
+	if msg, encoding, err := wire.Decode([]byte(m.Data)); err == nil {
+		m.Data, m.Encoding = string(msg), encoding
+	}
 	m.Data = strings.TrimSpace(m.Data)
 
 	if strings.HasPrefix(m.Data, "{") || strings.HasPrefix(m.Data, "[") {
@@ -232,6 +257,31 @@ func (wl *WSMessageLogger) WaitForMessage(pattern string, timeout time.Duration)
 	return WSMessage{}, fmt.Errorf("timeout waiting for message matching '%s'", pattern)
 }
 
+// ValidateSchema checks every logged JSON message against the wire format
+// schema: sent messages as client actions, received messages as server
+// updates. It returns one error per invalid message.
+func (wl *WSMessageLogger) ValidateSchema() []error {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	var errs []error
+	for i, msg := range wl.messages {
+		if msg.Type != "json" {
+			continue
+		}
+		var err error
+		if msg.Direction == "sent" {
+			err = wire.ValidateClientMessage([]byte(msg.Data))
+		} else {
+			err = wire.ValidateServerMessage([]byte(msg.Data))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("message %d (%s, wire format %s): %w: %s", i, msg.Direction, wire.Version, err, truncate(msg.Data, 120)))
+		}
+	}
+	return errs
+}
+
 func (wl *WSMessageLogger) Print() {
 	wl.mu.RLock()
 	defer wl.mu.RUnlock()
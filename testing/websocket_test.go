@@ -0,0 +1,56 @@
+package testing
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/livetemplate/lvt/internal/wire"
+)
+
+func TestWSMessageLogger_ValidateSchema(t *testing.T) {
+	wl := NewWSMessageLogger()
+	for _, m := range []WSMessage{
+		{Direction: "sent", Data: `{"action":"add","data":{"title":"Milk"}}`},
+		{Direction: "received", Data: `{"tree":{"0":[["r","item-1"]]},"meta":{"success":true,"errors":{}}}`},
+		{Direction: "received", Data: `not json`},
+		{Direction: "sent", Data: `{"data":{}}`},
+		{Direction: "received", Data: `{"tree":{"0":[["x"]]}}`},
+	} {
+		m.parseData()
+		wl.messages = append(wl.messages, m)
+	}
+
+	errs := wl.ValidateSchema()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 schema errors, got %d: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "message 3 (sent") {
+		t.Errorf("first error should identify the sent message, got: %v", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), `unknown range operation "x"`) {
+		t.Errorf("second error should report the range op, got: %v", errs[1])
+	}
+}
+
+func TestWSMessageLogger_DecodesCompressedFrames(t *testing.T) {
+	update := `{"tree":{"0":"5"},"meta":{"success":true,"errors":{}}}`
+	wl := NewWSMessageLogger()
+	for _, data := range [][]byte{[]byte(update), wire.Deflate([]byte(update)), wire.Gzip([]byte(update))} {
+		m := WSMessage{Direction: "received", Data: decodeBinaryFrame(base64.StdEncoding.EncodeToString(data))}
+		m.parseData()
+		wl.messages = append(wl.messages, m)
+	}
+
+	for i, m := range wl.GetReceived() {
+		if m.Type != "json" || m.Data != update {
+			t.Errorf("message %d decoded as %s %q, want the JSON update", i, m.Type, m.Data)
+		}
+	}
+	if got := []string{wl.messages[0].Encoding, wl.messages[1].Encoding, wl.messages[2].Encoding}; strings.Join(got, ",") != "json,deflate,gzip" {
+		t.Errorf("encodings = %v", got)
+	}
+	if errs := wl.ValidateSchema(); len(errs) != 0 {
+		t.Errorf("compressed updates should validate: %v", errs)
+	}
+}